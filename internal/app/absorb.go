@@ -0,0 +1,91 @@
+package app
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// absorbCompleteMsg reports the revisions jj absorb amended.
+type absorbCompleteMsg struct {
+	from    string
+	path    string // the one file absorbed, or "" for the whole change
+	targets []string
+}
+
+// actionAbsorb moves the changes of the change selected in the log into the
+// ancestors that last touched the same lines. In the files view only the
+// selected file's changes move.
+func (m *Model) actionAbsorb() (Model, tea.Cmd) {
+	if m.focusedPane != PaneLog {
+		return *m, nil
+	}
+
+	switch m.viewMode {
+	case ViewLog:
+		if selected := m.logPanel.SelectedChange(); selected != nil {
+			if selected.IsImmutable {
+				return *m, m.refuseImmutable("absorb", selected.ChangeID)
+			}
+
+			return *m, m.runAbsorb(selected.ChangeID, "")
+		}
+	case ViewFiles:
+		if file := m.filesPanel.SelectedFile(); file != nil && m.filesPanel.ChangeID() != "" {
+			return *m, m.runAbsorb(m.filesPanel.ChangeID(), file.Path)
+		}
+	case ViewBookmarks, ViewStacks:
+	}
+
+	return *m, nil
+}
+
+// runAbsorb executes jj absorb from a change, for one path when given, and
+// returns a completion message naming the revisions amended.
+func (m *Model) runAbsorb(from, path string) tea.Cmd {
+	label := "jj absorb --from " + from
+	if path != "" {
+		label += " " + path
+	}
+
+	runner := m.runner
+
+	return m.startJob(label, func() tea.Msg {
+		var paths []string
+		if path != "" {
+			paths = append(paths, path)
+		}
+
+		output, err := runner.Absorb(from, paths...)
+		if err != nil {
+			return errMsg{err}
+		}
+
+		return absorbCompleteMsg{from: from, path: path, targets: jj.ParseAbsorbed(output)}
+	})
+}
+
+// handleAbsorbComplete says where the changes went and reloads; the files
+// view reloads too, as absorbed files leave the change.
+func (m *Model) handleAbsorbComplete(msg absorbCompleteMsg) tea.Cmd {
+	what := msg.from
+	if msg.path != "" {
+		what = msg.path
+	}
+
+	var cmds []tea.Cmd
+
+	if m.viewMode == ViewFiles && m.filesPanel.ChangeID() == msg.from {
+		cmds = append(cmds, m.loadFiles(msg.from))
+	}
+
+	if len(msg.targets) == 0 {
+		return tea.Batch(append(cmds, m.toasts.Info("nothing in "+what+" to absorb"))...)
+	}
+
+	summary := "absorbed " + what + " into " + strings.Join(msg.targets, ", ")
+
+	return tea.Batch(append(cmds, m.completeMutation(summary))...)
+}
@@ -0,0 +1,72 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestAbsorb_SelectedChange(t *testing.T) {
+	m := newTestModel(t)
+	m.logPanel.SetContent("@ aaaaaaaa\n", []jj.Change{{ChangeID: "aaaaaaaa"}})
+
+	next, _ := m.actionAbsorb()
+	*m = next
+
+	if !m.jobs.busy() {
+		t.Error("A should absorb the selected change")
+	}
+}
+
+func TestAbsorb_RefusesImmutable(t *testing.T) {
+	m := newTestModel(t)
+	m.logPanel.SetContent("◆ aaaaaaaa\n", []jj.Change{{ChangeID: "aaaaaaaa", IsImmutable: true}})
+
+	next, _ := m.actionAbsorb()
+	*m = next
+
+	if m.jobs.busy() || len(m.toasts.Items()) == 0 {
+		t.Error("absorbing an immutable change should be refused with a note")
+	}
+}
+
+func TestAbsorb_SelectedFile(t *testing.T) {
+	m := newTestModel(t)
+	m.viewMode = ViewFiles
+	m.filesPanel.SetFiles("aaaaaaaa", "aa", []jj.File{{Path: "main.go", Status: jj.FileModified}})
+
+	next, _ := m.actionAbsorb()
+	*m = next
+
+	if !m.jobs.busy() {
+		t.Fatal("A in the files view should absorb the selected file")
+	}
+
+	if got := m.jobs.running[0].label; got != "jj absorb --from aaaaaaaa main.go" {
+		t.Errorf("label = %q", got)
+	}
+}
+
+func TestAbsorbComplete_ReportsTargets(t *testing.T) {
+	m := newTestModel(t)
+
+	m.handleAbsorbComplete(absorbCompleteMsg{from: "aaaaaaaa", targets: []string{"bbbbbbbb", "cccccccc"}})
+	m.handleAbsorbComplete(absorbCompleteMsg{from: "aaaaaaaa", path: "main.go"})
+
+	items := m.toasts.Items()
+	if len(items) != 2 {
+		t.Fatalf("expected two toasts, got %+v", items)
+	}
+
+	if items[0].Text != "absorbed aaaaaaaa into bbbbbbbb, cccccccc" {
+		t.Errorf("unexpected report: %q", items[0].Text)
+	}
+
+	if items[1].Text != "nothing in main.go to absorb" {
+		t.Errorf("unexpected report: %q", items[1].Text)
+	}
+}
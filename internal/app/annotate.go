@@ -0,0 +1,82 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui"
+)
+
+// annotationLoadedMsg carries the annotated lines of path at rev.
+type annotationLoadedMsg struct {
+	rev   string
+	path  string
+	lines []jj.AnnotatedLine
+}
+
+// actionAnnotate shows the file selected in the files view in the diff
+// pane with the change that last touched each line (jj file annotate).
+func (m *Model) actionAnnotate() (Model, tea.Cmd) {
+	if m.focusedPane != PaneLog || m.viewMode != ViewFiles {
+		return *m, nil
+	}
+
+	file := m.filesPanel.SelectedFile()
+	rev := m.filesPanel.ChangeID()
+
+	if file == nil || rev == "" {
+		return *m, nil
+	}
+
+	if file.Status == jj.FileDeleted {
+		return *m, m.toasts.Info("cannot annotate " + file.Path + ": it was deleted in " + rev)
+	}
+
+	return *m, m.loadAnnotation(rev, file.Path)
+}
+
+// loadAnnotation fetches the annotated lines of path at rev.
+func (m *Model) loadAnnotation(rev, path string) tea.Cmd {
+	runner := m.runner
+
+	return func() tea.Msg {
+		lines, err := runner.Annotate(rev, path)
+		if err != nil {
+			return errMsg{err}
+		}
+
+		return annotationLoadedMsg{rev: rev, path: path, lines: lines}
+	}
+}
+
+// handleAnnotationLoaded shows the annotation in the diff pane, focused.
+func (m *Model) handleAnnotationLoaded(msg annotationLoadedMsg) tea.Cmd {
+	if !m.diffPanel.StartAnnotate("Annotate: "+msg.path+" at "+msg.rev, msg.lines) {
+		return m.toasts.Info(msg.path + " is empty in " + msg.rev)
+	}
+
+	m.focusedPane = PaneDiff
+	m.updatePanelFocus()
+
+	return m.startLogPanelBorderAnim()
+}
+
+// handleAnnotateSelect goes back to the log with the change that last
+// touched the annotated line selected.
+func (m *Model) handleAnnotateSelect(msg ui.AnnotateSelectMsg) tea.Cmd {
+	m.diffPanel.StopAnnotate()
+
+	var cmds []tea.Cmd
+	if m.viewMode != ViewLog {
+		cmds = append(cmds, m.handleBack())
+	}
+
+	m.focusedPane = PaneLog
+	m.updatePanelFocus()
+
+	if !m.logPanel.SelectChangeID(msg.ChangeID) {
+		return tea.Batch(append(cmds, m.toasts.Info(msg.ChangeID+" is not in the log"))...)
+	}
+
+	return tea.Batch(append(cmds, m.loadSelectedDiff())...)
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui"
+)
+
+// annotateTestModel returns a model in the files view of bbbbbbbb, showing
+// an annotation of main.go whose lines come from aaaaaaaa and a change not
+// in the log.
+func annotateTestModel(t *testing.T) *Model {
+	t.Helper()
+
+	m := newTestModel(t)
+	m.changes = []jj.Change{{ChangeID: "aaaaaaaa"}, {ChangeID: "bbbbbbbb"}}
+	m.logPanel.SetContent("○ aaaaaaaa\n@ bbbbbbbb\n", m.changes)
+	m.logPanel.SelectChangeID("bbbbbbbb")
+	m.viewMode = ViewFiles
+	m.filesPanel.SetFiles("bbbbbbbb", "bb", []jj.File{{Path: "main.go", Status: jj.FileModified}})
+
+	m.handleAnnotationLoaded(annotationLoadedMsg{rev: "bbbbbbbb", path: "main.go", lines: []jj.AnnotatedLine{
+		{ChangeID: "aaaaaaaa", LineNumber: 1, Content: "package main"},
+		{ChangeID: "zzzzzzzz", LineNumber: 2, Content: "func main() {}"},
+	}})
+
+	return m
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestAnnotate_ShownInDiffPane(t *testing.T) {
+	m := annotateTestModel(t)
+
+	if !m.diffPanel.Annotating() || m.focusedPane != PaneDiff {
+		t.Fatal("the annotation should be shown in the focused diff pane")
+	}
+
+	m.handleKeyMsg(tea.KeyPressMsg{Code: 'j', Text: "j"})
+
+	if m.focusedPane != PaneDiff || !m.diffPanel.Annotating() {
+		t.Error("j should move through the annotation rather than run a binding")
+	}
+}
+
+func TestAnnotate_EnterSelectsChangeInLog(t *testing.T) {
+	m := annotateTestModel(t)
+
+	m.handleAnnotateSelect(ui.AnnotateSelectMsg{ChangeID: "aaaaaaaa"})
+
+	if m.viewMode != ViewLog || m.focusedPane != PaneLog || m.diffPanel.Annotating() {
+		t.Error("going to a change should leave the annotation for the log")
+	}
+
+	if selected := m.logPanel.SelectedChange(); selected == nil || selected.ChangeID != "aaaaaaaa" {
+		t.Errorf("expected aaaaaaaa selected, got %+v", selected)
+	}
+}
+
+func TestAnnotate_ChangeNotInLog(t *testing.T) {
+	m := annotateTestModel(t)
+
+	m.handleAnnotateSelect(ui.AnnotateSelectMsg{ChangeID: "zzzzzzzz"})
+
+	if len(m.toasts.Items()) != 1 {
+		t.Error("a change outside the log should be reported")
+	}
+}
+
+func TestAnnotate_RefusesDeletedFile(t *testing.T) {
+	m := newTestModel(t)
+	m.viewMode = ViewFiles
+	m.filesPanel.SetFiles("bbbbbbbb", "bb", []jj.File{{Path: "old.go", Status: jj.FileDeleted}})
+
+	next, cmd := m.actionAnnotate()
+	*m = next
+
+	if cmd == nil || len(m.toasts.Items()) != 1 {
+		t.Error("a deleted file has nothing to annotate")
+	}
+}
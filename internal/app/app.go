@@ -2,24 +2,37 @@
 package app
 
 import (
+	"cmp"
 	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
 	"time"
 
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/spinner"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 
+	"github.com/chatter/chado/internal/config"
+	"github.com/chatter/chado/internal/forge"
 	"github.com/chatter/chado/internal/jj"
 	"github.com/chatter/chado/internal/logger"
 	"github.com/chatter/chado/internal/ui"
 	"github.com/chatter/chado/internal/ui/help"
+	"github.com/chatter/chado/internal/ui/theme"
 )
 
 // ViewMode represents the current view hierarchy.
 type ViewMode int
 
 const (
-	ViewLog   ViewMode = iota // Top level: log view
-	ViewFiles                 // Drill down: files in a change
+	ViewLog       ViewMode = iota // Top level: log view
+	ViewFiles                     // Drill down: files in a change
+	ViewBookmarks                 // Side view: local bookmarks, in place of the log
+	ViewStacks                    // Side view: mutable changes grouped into stacks, in place of the log
 )
 
 // FocusedPane represents which pane has focus.
@@ -32,21 +45,12 @@ const (
 )
 
 const (
-	// watcherDebounceDelay is the pause before flushing batched file-watcher events.
-	watcherDebounceDelay = 300 * time.Millisecond
-
 	// paneCount is the total number of navigable panes.
 	paneCount = 3
 
 	// borderAnimTickInterval is the frame interval for the focus border animation.
 	borderAnimTickInterval = 15 * time.Millisecond
 
-	// describeOverlayWidth is the fixed width of the describe-input overlay.
-	describeOverlayWidth = 60
-
-	// describeOverlayHeight is the fixed height of the describe-input overlay.
-	describeOverlayHeight = 10
-
 	// Help binding display order values (lower = shown first in status bar).
 	orderSelect     = 10
 	orderBack       = 11
@@ -55,11 +59,62 @@ const (
 	orderNew        = 14
 	orderAbandon    = 15
 	orderSquash     = 16
+	orderPush       = 17
+	orderBookmarks  = 18
+	orderFindFile   = 19
 	orderNextPane   = 20
 	orderPrevPane   = 21
+	orderCopyID     = 22
+	orderCopyCommit = 23
+	orderNewMenu    = 24
+	orderDuplicate  = 25
+	orderBackout    = 26
+	orderAbsorb     = 27
+	orderRestore    = 28
+	orderPickHunks  = 29
+	orderOpenDir    = 30
+	orderShell      = 31
+	orderPalette    = 32
+	orderCommand    = 33
+	orderDiffTool   = 34
+	orderRefresh    = 35
+	orderNext       = 36
+	orderPrev       = 37
+	orderCommit     = 38
+	orderAnnotate   = 39
+	orderWorkspaces = 40
+	orderSparse     = 41
+	orderOpAbandon  = 42
+	orderStacks     = 43
+	orderRebase     = 44
+	orderJump       = 45
+	orderFind       = 46
+	orderReword     = 47
+	orderOpenWeb    = 48
 	orderFocusPane0 = 50
 	orderFocusPane1 = 51
 	orderFocusPane2 = 52
+	orderStats      = 60
+	orderCompare    = 61
+	orderSyntax     = 62
+	orderTheme      = 63
+	orderShrinkLeft = 64
+	orderGrowLeft   = 65
+	orderLayout     = 66
+	orderStatus     = 67
+	orderTimeTravel = 68
+	orderPause      = 69
+	orderContent    = 70
+	orderSnapshots  = 71
+	orderTemplate   = 72
+	orderLineNums   = 73
+	orderWhitespace = 74
+	orderMoreLines  = 75
+	orderLessLines  = 76
+	orderFormat     = 77
+	orderWrap       = 78
+	orderDismiss    = 90
+	orderErrorPanel = 91
 	orderHelp       = 99
 	orderQuit       = 100
 
@@ -69,7 +124,7 @@ const (
 	// centerDivisor halves a dimension to find the center point.
 	centerDivisor = 2
 
-	// leftPanelWidthPct is the left panel's share of screen width.
+	// leftPanelWidthPct is the left panel's initial share of screen width.
 	leftPanelWidthPct = 40
 
 	// leftPanelSplitDivisor divides the left panel vertically into equal halves.
@@ -96,11 +151,22 @@ const (
 // Model is the main application model.
 type Model struct {
 	// Core state
+	ctx     context.Context
 	workDir string
 	version string
 	keys    KeyMap
 	log     *logger.Logger
 
+	// Problems found in the configured key bindings, reported at startup
+	keyProblems []string
+
+	// Color themes to cycle through and the one in use, with problems found
+	// in the theme config, reported at startup
+	themes        []theme.Theme
+	themeIndex    int
+	themeAuto     bool // follow the terminal background until a theme is picked
+	themeProblems []string
+
 	// JJ integration
 	runner  *jj.Runner
 	watcher *jj.Watcher
@@ -112,34 +178,196 @@ type Model struct {
 	editMode      bool
 	describeInput *ui.DescribeInput
 
+	// Fuzzy finder: the change whose files are offered while it is open, and
+	// the changes offered ahead of them when it searches everything
+	finding       bool
+	finder        *ui.Finder
+	finderFiles   filesLoadedMsg
+	finderChanges []jj.Change
+
+	// Command palette: the actions offered while the finder shows it
+	palette []ActionBinding
+
+	// Prompt for running arbitrary jj commands, or answering a question put
+	// with Ask, which promptAnswer takes
+	commanding   bool
+	commandLine  *ui.CommandLine
+	promptAnswer func(m *Model, answer string) tea.Cmd
+
 	// Panels
 	styles     *ui.Styles
+	monochrome bool // no colors: plain jj output, focus in reverse video
+	linear     bool // one panel at a time as plain text, for screen readers
 	logPanel   ui.LogPanel
 	opLogPanel ui.OpLogPanel
 	filesPanel ui.FilesPanel
 	diffPanel  ui.DiffPanel
 
+	bookmarksPanel ui.BookmarksPanel
+	stacksPanel    ui.StacksPanel
+
+	// What the diff pane shows for the selected operation
+	opDetail opDetail
+
+	// At-op comparison: the log as of the selected operation replaces the diff pane
+	comparing       bool
+	compareLogPanel ui.LogPanel
+	compareChanges  []jj.Change
+	compareRequests *requestSlot
+
 	// Help
 	statusBar    *help.StatusBar
 	floatingHelp *help.FloatingHelp
+	whichKey     *help.WhichKey // keys that can follow a pending one
+
+	// Notifications
+	toasts *ui.Toasts
+	hints  *hintScheduler
+
+	// Mutating jj commands in flight, shown in the status bar
+	jobs *jobTracker
+
+	// Read-only mode: bindings and commands that change the repo are disabled
+	readOnly bool
+
+	// Time travel: the operation the change log is shown at; empty for now
+	timeTravelOp string
+
+	// Why jj cannot be used (missing or too old), shown instead of the panels
+	jjProblem string
+
+	// What older jj leaves out and what chado does instead, for the help modal
+	featureNotes []string
+
+	// Picker mode: enter quits, leaving the selection in picked
+	picking bool
+	picked  string
+
+	// Confirmation prompt (one at a time)
+	confirming     bool
+	confirmDialog  *ui.ConfirmDialog
+	pendingConfirm confirmation
+
+	// Working-copy guard: commits the user chose to amend despite being published
+	guardDeclined map[string]bool
+
+	// Diff pane loads: superseded jj show/diff calls are cancelled, and
+	// cursor movement waits diffDebounce before loading
+	diffRequests    *requestSlot
+	diffDebounce    time.Duration
+	diffDebounceGen int
+
+	// Log paging: the log loads logLimit changes, one more page of
+	// logPageSize at a time as the cursor nears the end; zero loads it all
+	logPageSize    int
+	logLimit       int
+	logMore        bool // the last load stopped at logLimit
+	logLoadingMore bool
+
+	// Log templates V switches between, the configured one first
+	logTemplates []string
+
+	// Op log paging, the same way
+	opLogPageSize    int
+	opLogLimit       int
+	opLogMore        bool
+	opLogLoadingMore bool
 
 	// Data
 	changes     []jj.Change
 	currentDiff string
 
+	// Commands for opening directories outside chado on this platform
+	openCommands config.OpenCommands
+
+	// External diff tool, by its name in jj's merge-tools config
+	diffTool string
+
+	// Diff format chado's config picks; empty follows jj's ui.diff-formatter
+	diffFormat string
+
+	// Hyperlinks: commit IDs to the forge (at commitURL when configured) and
+	// file names to the files on disk
+	commitLinks bool
+	commitURL   string
+	fileLinks   bool
+
+	// Pull requests of the bookmarks, by bookmark, from GitHub when it is
+	// asked (github is nil when not); forgeFailing keeps a failing API to
+	// one toast
+	github       *forge.GitHub
+	pullRequests map[string]forge.PullRequest
+	forgeFailing bool
+
+	// Background jj git fetch every fetchInterval (zero for never);
+	// fetchFailing keeps a failing fetch to one toast
+	fetchInterval time.Duration
+	fetchFailing  bool
+
+	// What an empty description starts as and the trailers ctrl+t adds to
+	// it, and the jj user (user.name, user.email) the trailers name
+	describeTemplate string
+	describeTrailers []string
+	userName         string
+	userEmail        string
+
+	// Changes of the marked range described in turn: the one being described,
+	// the descriptions changed so far, and what the current one started as
+	describeBatch  []jj.Change
+	batchPosition  int
+	batchDescribed []batchEntry
+	batchStart     string
+
+	// Syntax highlighting line limit, kept for re-enabling after a toggle
+	syntaxMaxLines int
+
+	// Whether the files view shows the selected file's whole content at the
+	// change (jj file show) rather than its diff
+	fileContent bool
+
 	// Window size
 	width  int
 	height int
 
-	// Error state
-	lastError string
+	// Split between the left and right panes, adjustable for the session
+	leftWidthPct    int
+	draggingDivider bool // the divider is following the mouse
+
+	// Side-by-side or stacked panes
+	layout LayoutMode
+
+	// Change to select once the log loads, saved by a previous session
+	restoreChangeID string
+
+	// Change a jump is loading more of the log to reach
+	jumpChangeID string
 
-	// Focus border animation (one wrap when any panel is focused)
+	// Error state: the last failure, shown in full by the error panel
+	lastError    *failure
+	failedJob    *queuedJob // the job whose failure is being handled, to retry
+	showingError bool
+	errorPanel   *ui.ErrorPanel
+
+	// The change hunks are being picked from in the diff pane
+	hunkPickRev string
+
+	// Moving up and down the stack: whether ] and [ edit (jj next --edit),
+	// and whether the next log load selects where @ went
+	stackEdit         bool
+	followWorkingCopy bool
+
+	// Focus border animation (one wrap when any panel is focused), off in
+	// reduced-motion mode
+	reducedMotion        bool
 	logPanelBorderPhase  float64
 	borderAnimGeneration int // incremented on each focus change so stale ticks are ignored
 
 	// Watcher coalescing: one refresh per burst of file-system events
-	watcherPending bool // true while a watcherFlushMsg tick is in flight
+	watcherPending bool            // true while a watcherFlushMsg tick is in flight
+	watchOptions   jj.WatchOptions // what the watcher watches and skips
+	watchDebounce  time.Duration   // pause before flushing batched file-watcher events
+	opHeads        string          // jj op heads as of the last load, to tell jj commands from file edits
+	refreshPaused  bool            // file changes don't refresh the views until resumed
 }
 
 // borderAnimTickMsg is sent each frame during the focus border wrap animation.
@@ -149,17 +377,35 @@ type borderAnimTickMsg struct {
 }
 
 // New creates a new application model.
-func New(ctx context.Context, workDir string, version string, log *logger.Logger) Model {
+func New(ctx context.Context, workDir string, version string, cfg config.Config, log *logger.Logger) Model {
 	runner := jj.NewRunner(ctx, workDir, log)
+	runner.SetDiffFormat(jj.DiffFormat(cfg.Diff.Format))
+	runner.SetRevset(cfg.Log.Revset)
+	runner.SetLogTemplate(cfg.Log.Template)
 	styles := ui.NewStyles()
 
 	logPanel := ui.NewLogPanel(styles)
 	opLogPanel := ui.NewOpLogPanel(styles)
+	opLogPanel.SetHideSnapshots(cfg.OpLog.HideSnapshots)
 	filesPanel := ui.NewFilesPanel(styles)
+	filesPanel.SetTree(cfg.Files.Tree)
 	diffPanel := ui.NewDiffPanel(styles)
+	diffPanel.SetSyntaxHighlight(cfg.Diff.SyntaxHighlight, cfg.Diff.SyntaxMaxLines)
+	diffPanel.SetLineNumbers(cfg.Diff.LineNumbers)
+	diffPanel.SetWrap(cfg.Diff.Wrap)
+	diffPanel.SetFormat(diffFormatName(runner.DiffFormat()))
+	bookmarksPanel := ui.NewBookmarksPanel(styles)
+	stacksPanel := ui.NewStacksPanel(styles)
+	compareLogPanel := ui.NewLogPanel(styles)
+	compareLogPanel.SetHeading(0, "Log at operation")
 	statusBar := help.NewStatusBar("chado " + version)
+	statusBar.SetReadOnly(cfg.ReadOnly)
 	floatingHelp := help.NewFloatingHelp()
 	describeInput := ui.NewDescribeInput()
+	describeInput.SetPrefixes(cfg.Describe.Prefixes)
+	describeInput.SetSubjectLength(cfg.Describe.SubjectLength)
+	describeInput.SetLint(cfg.Describe.Lint)
+	describeInput.SetHistoryLimit(cfg.Describe.History)
 
 	// Set initial focus - log panel starts focused
 	logPanel.SetFocused(true)
@@ -167,33 +413,119 @@ func New(ctx context.Context, workDir string, version string, log *logger.Logger
 	filesPanel.SetFocused(true)
 	diffPanel.SetFocused(false)
 
-	return Model{
-		workDir:       workDir,
-		version:       version,
-		keys:          DefaultKeyMap(),
-		log:           log,
-		runner:        runner,
-		styles:        styles,
-		viewMode:      ViewLog,
-		focusedPane:   PaneLog,
-		logPanel:      logPanel,
-		opLogPanel:    opLogPanel,
-		filesPanel:    filesPanel,
-		diffPanel:     diffPanel,
-		statusBar:     statusBar,
-		floatingHelp:  floatingHelp,
-		describeInput: describeInput,
-	}
+	keys := DefaultKeyMap()
+	keyProblems := applyKeyOverrides(&keys, cfg.Keys)
+	themes, themeIndex, themeProblems := loadThemes(cfg.Theme)
+
+	m := Model{
+		ctx:              ctx,
+		workDir:          workDir,
+		version:          version,
+		keys:             keys,
+		keyProblems:      keyProblems,
+		themes:           themes,
+		themeIndex:       themeIndex,
+		themeAuto:        cfg.Theme.Name == theme.AutoName,
+		themeProblems:    themeProblems,
+		log:              log,
+		runner:           runner,
+		styles:           styles,
+		viewMode:         ViewLog,
+		focusedPane:      PaneLog,
+		logPanel:         logPanel,
+		opLogPanel:       opLogPanel,
+		filesPanel:       filesPanel,
+		diffPanel:        diffPanel,
+		bookmarksPanel:   bookmarksPanel,
+		stacksPanel:      stacksPanel,
+		compareLogPanel:  compareLogPanel,
+		compareRequests:  &requestSlot{},
+		statusBar:        statusBar,
+		floatingHelp:     floatingHelp,
+		whichKey:         help.NewWhichKey(),
+		describeInput:    describeInput,
+		finder:           ui.NewFinder(),
+		commandLine:      ui.NewCommandLine(),
+		toasts:           ui.NewToasts(),
+		hints:            newHintScheduler(cfg.Hints.Enabled),
+		jobs:             newJobTracker(),
+		confirmDialog:    ui.NewConfirmDialog(),
+		errorPanel:       ui.NewErrorPanel(),
+		guardDeclined:    make(map[string]bool),
+		diffRequests:     &requestSlot{},
+		diffDebounce:     cfg.Diff.Debounce,
+		logPageSize:      cfg.Log.PageSize,
+		logLimit:         cfg.Log.PageSize,
+		logTemplates:     logTemplateChoices(cfg.Log.Template),
+		opLogPageSize:    cfg.OpLog.PageSize,
+		opLogLimit:       cfg.OpLog.PageSize,
+		syntaxMaxLines:   cfg.Diff.SyntaxMaxLines,
+		openCommands:     cfg.Open.Commands(runtime.GOOS),
+		diffTool:         cfg.Diff.Tool,
+		diffFormat:       cfg.Diff.Format,
+		describeTemplate: cfg.Describe.Template,
+		describeTrailers: cfg.Describe.Trailers,
+		commitLinks:      cfg.Links.Commits,
+		commitURL:        cfg.Links.CommitURL,
+		fileLinks:        cfg.Links.Files,
+		stackEdit:        cfg.Stack.Edit,
+		readOnly:         cfg.ReadOnly,
+		linear:           cfg.Linear,
+		watchOptions:     jj.WatchOptions{Mode: jj.WatchMode(cfg.Watch.Mode), Ignore: cfg.Watch.Ignore},
+		watchDebounce:    cfg.Watch.Debounce,
+		fetchInterval:    cfg.Fetch.Interval,
+		leftWidthPct:     leftPanelWidthPct,
+	}
+
+	if cfg.Forge.GitHub {
+		m.github = forge.NewGitHub(os.Getenv(cfg.Forge.TokenEnv), cfg.Forge.Refresh)
+	}
+
+	if m.monochrome = cfg.NoColor; m.monochrome {
+		m.runner.SetColored(false)
+		m.styles.SetMonochrome(true)
+		m.finder.SetMonochrome(true)
+	}
+
+	m.applyTheme()
+
+	if m.fileLinks {
+		m.filesPanel.SetFileRoot(workDir)
+	}
+
+	if m.reducedMotion = cfg.ReducedMotion; m.reducedMotion {
+		m.jobs.holdStill()
+	}
+
+	return m
 }
 
 // Init initializes the application.
 func (m *Model) Init() tea.Cmd {
 	m.log.Info("initializing app", "workdir", m.workDir, "version", m.version)
 
+	var keysReport tea.Cmd
+
+	if len(m.keyProblems) > 0 {
+		m.log.Warn("key bindings config has problems", "problems", m.keyProblems)
+		keysReport = m.toasts.Error("keys config: " + strings.Join(m.keyProblems, "; "))
+	}
+
+	var themeReport tea.Cmd
+
+	if len(m.themeProblems) > 0 {
+		m.log.Warn("theme config has problems", "problems", m.themeProblems)
+		themeReport = m.toasts.Error("theme config: " + strings.Join(m.themeProblems, "; "))
+	}
+
 	return tea.Batch(
-		m.loadLog(),
-		m.loadOpLog(),
+		m.checkJJ(),
+		m.announceDirectory(),
 		m.startWatcher(),
+		m.waitForNotice(),
+		m.hints.start(),
+		keysReport,
+		themeReport,
 	)
 }
 
@@ -201,27 +533,34 @@ func (m *Model) Init() tea.Cmd {
 type logLoadedMsg struct {
 	raw     string
 	changes []jj.Change
+	limit   int    // changes the log was cut off after, or zero
+	atOp    string // operation the log was read at; empty for now
 }
 
 type diffLoadedMsg struct {
 	changeID   string
 	diffOutput string
+	generation int // diff pane request generation; stale results are dropped
 }
 
 type fileDiffLoadedMsg struct {
-	diffOutput string
+	diffOutput  string
+	contentPath string // set when diffOutput is this file's content, not a diff
+	generation  int
 }
 
 type filesLoadedMsg struct {
 	changeID   string
 	shortCode  string
 	files      []jj.File
+	stats      []jj.FileStat
 	diffOutput string
 }
 
 type opLogLoadedMsg struct {
 	raw        string
 	operations []jj.Operation
+	limit      int
 }
 
 type evoLogLoadedMsg struct {
@@ -232,8 +571,10 @@ type evoLogLoadedMsg struct {
 }
 
 type opShowLoadedMsg struct {
-	opID   string
-	output string
+	opID       string
+	output     string
+	detail     opDetail
+	generation int
 }
 
 type watcherStartedMsg struct {
@@ -241,9 +582,26 @@ type watcherStartedMsg struct {
 	err     error
 }
 
+// runnerNoticeMsg reports something the runner did on its own, e.g.
+// updating a stale working copy.
+type runnerNoticeMsg struct {
+	text string
+}
+
 // watcherFlushMsg fires after the coalescing delay; triggers one refresh.
 type watcherFlushMsg struct{}
 
+// watcherCheckedMsg carries the op heads read when a burst of file-system
+// events is flushed, to decide how much to reload.
+type watcherCheckedMsg struct {
+	opHeads string
+}
+
+// opHeadsMsg records the op heads the views were loaded at.
+type opHeadsMsg struct {
+	opHeads string
+}
+
 type errMsg struct {
 	err error
 }
@@ -256,7 +614,9 @@ type editCompleteMsg struct {
 	changeID string
 }
 
-type newCompleteMsg struct{}
+type newCompleteMsg struct {
+	summary string // e.g. "created new change on xsssnyux"
+}
 
 type abandonCompleteMsg struct {
 	changeID string
@@ -266,6 +626,46 @@ type squashCompleteMsg struct {
 	changeID string
 }
 
+type pushCompleteMsg struct {
+	changeID string
+}
+
+type bookmarksLoadedMsg struct {
+	bookmarks []jj.Bookmark
+}
+
+// bookmarksPushedMsg carries the merged per-bookmark outcome of one push.
+type bookmarksPushedMsg struct {
+	results map[string]jj.PushResult
+}
+
+// confirmation holds the follow-ups for an open confirmation dialog.
+type confirmation struct {
+	onYes func() tea.Cmd
+	onNo  func() tea.Cmd // nil when the dialog offers only yes/cancel
+}
+
+// guardedMutation is a mutating jj command held back until the working-copy
+// guard has checked that @ is safe to snapshot into.
+type guardedMutation struct {
+	rev               string                   // target revision
+	followWorkingCopy bool                     // retarget to the new @ if rev was the old one
+	run               func(rev string) tea.Cmd // runs the command against a revision
+}
+
+// workingCopyCheckedMsg carries the guard's verdict for a held-back mutation.
+type workingCopyCheckedMsg struct {
+	state    jj.WorkingCopyState
+	mutation guardedMutation
+}
+
+// changeStatsLoadedMsg carries diff stats for log rows, keyed by revision,
+// and the revisions whose stats failed to load.
+type changeStatsLoadedMsg struct {
+	stats  map[string]jj.ChangeStat
+	failed []string
+}
+
 // Update handles messages.
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -277,8 +677,16 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		m.updatePanelSizes()
+		m.describeInput.SetSize(m.width, m.height)
+		m.sizeFinder()
+		m.sizeCommandLine()
+		m.errorPanel.SetSize(m.width, m.height)
+
+		return m, m.loadVisibleStats()
 	case logLoadedMsg:
 		return m, m.handleLogLoaded(msg)
+	case diffDebounceMsg:
+		return m, m.handleDiffDebounce(msg)
 	case diffLoadedMsg:
 		m.handleDiffLoaded(msg)
 	case filesLoadedMsg:
@@ -291,23 +699,165 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, m.handleEvoLogLoaded(msg)
 	case opShowLoadedMsg:
 		m.handleOpShowLoaded(msg)
+	case interdiffLoadedMsg:
+		m.handleInterdiffLoaded(msg)
+	case rangeDiffLoadedMsg:
+		m.handleRangeDiffLoaded(msg)
+	case ui.OpDetailToggleMsg:
+		return m, m.handleOpDetailToggle()
 	case watcherStartedMsg:
 		return m, m.handleWatcherStarted(msg)
 	case jj.WatcherMsg:
 		return m, m.handleWatcherEvent(msg)
 	case watcherFlushMsg:
 		return m, m.handleWatcherFlush(msg)
+	case watcherCheckedMsg:
+		return m, m.handleWatcherChecked(msg)
+	case opHeadsMsg:
+		m.opHeads = msg.opHeads
+		return m, nil
+	case runnerNoticeMsg:
+		return m, m.handleRunnerNotice(msg)
+	case jjCheckedMsg:
+		return m, m.handleJJChecked(msg)
+	case fetchTickMsg:
+		return m, m.handleFetchTick()
+	case fetchedMsg:
+		return m, m.handleFetched(msg)
+	case pullRequestsMsg:
+		return m, m.handlePullRequests(msg)
+	case commitLinksMsg:
+		m.handleCommitLinks(msg)
+		return m, nil
 	case errMsg:
-		m.handleErr(msg)
+		return m, m.handleErr(msg)
 	case ui.DescribeSubmitMsg:
 		return m, m.handleDescribeSubmit(msg)
 	case ui.DescribeCancelMsg:
 		m.editMode = false
-	case describeCompleteMsg, editCompleteMsg, newCompleteMsg,
-		abandonCompleteMsg, squashCompleteMsg:
-		return m, m.reloadAfterMutation()
+		m.endDescribeBatch()
+	case finderFilesLoadedMsg:
+		return m, m.openFinder(msg)
+	case ui.FinderSelectMsg:
+		if m.palette != nil {
+			return m, m.handlePaletteSelect(msg)
+		}
+
+		return m, m.handleFinderSelect(msg)
+	case ui.FinderCancelMsg:
+		m.handleFinderCancel()
+	case ui.CommandSubmitMsg:
+		return m, m.handleCommandSubmit(msg)
+	case ui.CommandCancelMsg:
+		m.commanding = false
+		m.promptAnswer = nil
+	case ui.PromptSubmitMsg:
+		return m, m.handlePromptSubmit(msg)
+	case workspacesLoadedMsg:
+		return m, m.handleWorkspacesLoaded(msg)
+	case workspaceRootMsg:
+		return m, m.switchWorkspace(msg)
+	case workspaceCompleteMsg:
+		return m, m.completeMutation(msg.summary)
+	case sparseLoadedMsg:
+		return m, m.handleSparseLoaded(msg)
+	case sparseCompleteMsg:
+		return m, m.completeMutation(msg.summary)
+	case opAbandonCompleteMsg:
+		return m, m.completeMutation("abandoned operations up to " + msg.opID)
+	case commandRanMsg:
+		return m, m.handleCommandRan(msg)
+	case jobDoneMsg:
+		return m.handleJobDone(msg)
+	case spinner.TickMsg:
+		return m, m.jobs.tick(msg)
+	case statusLoadedMsg:
+		return m, m.handleStatusLoaded(msg)
+	case describeCompleteMsg:
+		return m, m.completeMutation("described " + msg.changeID)
+	case describeBatchCompleteMsg:
+		return m, m.completeMutation("described " + countChanges(msg.described))
+	case rewordCompleteMsg:
+		return m, m.handleRewordComplete(msg)
+	case commitCompleteMsg:
+		return m, m.handleCommitComplete(msg)
+	case editCompleteMsg:
+		return m, m.completeMutation("editing " + msg.changeID)
+	case newCompleteMsg:
+		return m, m.completeMutation(cmp.Or(msg.summary, "created new change"))
+	case abandonCompleteMsg:
+		return m, m.completeMutation("abandoned " + msg.changeID)
+	case squashCompleteMsg:
+		return m, m.completeMutation("squashed " + msg.changeID + " into its parent")
+	case duplicateCompleteMsg:
+		return m, m.completeMutation("duplicated " + msg.changeID)
+	case backoutCompleteMsg:
+		return m, m.completeMutation("backed out " + msg.changeID + " onto @")
+	case squashFilesCompleteMsg:
+		return m, m.handleSquashFilesComplete(msg)
+	case hunksLoadedMsg:
+		return m, m.handleHunksLoaded(msg)
+	case ui.HunkPickMsg:
+		return m, m.handleHunkPick()
+	case annotationLoadedMsg:
+		return m, m.handleAnnotationLoaded(msg)
+	case ui.AnnotateSelectMsg:
+		return m, m.handleAnnotateSelect(msg)
+	case ui.FileJumpMsg:
+		return m, m.handleFileJump(msg)
+	case ui.CopyDiffMsg:
+		return m, copyToClipboard(msg.What, msg.Text)
+	case hunksCompleteMsg:
+		return m, m.handleHunksComplete(msg)
+	case stackCompleteMsg:
+		return m, m.handleStackComplete(msg)
+	case absorbCompleteMsg:
+		return m, m.handleAbsorbComplete(msg)
+	case restoreCompleteMsg:
+		return m, m.handleRestoreComplete(msg)
+	case pushCompleteMsg:
+		return m, m.completeMutation("pushed bookmarks on " + msg.changeID)
+	case logAtOpLoadedMsg:
+		m.handleLogAtOpLoaded(msg)
+	case bookmarksLoadedMsg:
+		return m, m.handleBookmarksLoaded(msg)
+	case jumpResolvedMsg:
+		return m, m.handleJumpResolved(msg)
+	case stacksLoadedMsg:
+		return m, m.handleStacksLoaded(msg)
+	case stackMutatedMsg:
+		return m, m.handleStackMutated(msg)
+	case bookmarksPushedMsg:
+		return m, m.handleBookmarksPushed(msg)
+	case previewLoadedMsg:
+		m.handlePreviewLoaded(msg)
+	case openedMsg:
+		return m, m.toasts.Info("opened " + msg.dir)
+	case webOpenedMsg:
+		return m, m.handleWebOpened(msg)
+	case copiedMsg:
+		return m, m.handleCopied(msg)
+	case shellExitedMsg:
+		return m, m.handleShellExited(msg)
+	case diffToolExitedMsg:
+		return m, m.handleDiffToolExited(msg)
+	case ui.ToastExpiredMsg:
+		m.toasts.Expire(msg.ID)
+	case ui.ConfirmMsg:
+		return m, m.handleConfirm(msg)
+	case ui.ErrorPanelMsg:
+		return m, m.handleErrorPanel(msg)
+	case workingCopyCheckedMsg:
+		return m, m.handleWorkingCopyChecked(msg)
+	case hintTickMsg:
+		return m, m.handleHintTick()
+	case hintClearMsg:
+		m.handleHintClear(msg)
 	case borderAnimTickMsg:
 		return m, m.handleBorderAnimTick(msg)
+	case changeStatsLoadedMsg:
+		m.logPanel.SetStats(msg.stats)
+		m.logPanel.StatsFailed(msg.failed)
 	}
 
 	return m, nil
@@ -318,30 +868,48 @@ func (m *Model) View() tea.View {
 	view := tea.NewView("")
 	view.AltScreen = true
 	view.MouseMode = tea.MouseModeCellMotion
+	view.WindowTitle = m.windowTitle()
 
 	if m.width == 0 || m.height == 0 {
 		view.SetContent("Loading...")
 		return view
 	}
 
-	// Render left panels (log/files + op log stacked)
-	var leftTop string
+	if m.jjProblem != "" {
+		view.SetContent(m.renderJJProblem())
+		return view
+	}
 
-	switch m.viewMode {
-	case ViewLog:
-		leftTop = m.logPanel.View()
-	case ViewFiles:
-		leftTop = m.filesPanel.View()
+	// Panels squeezed below their chrome overlap; resizing brings them back
+	if m.tooSmall() {
+		view.SetContent(m.renderTooSmall())
+		return view
 	}
 
+	// Linear mode reads top to bottom; clicks have no layout to land on
+	if m.linear {
+		view.MouseMode = tea.MouseModeNone
+		view.SetContent(m.renderLinear())
+
+		return view
+	}
+
+	// Render left panels (log/files/bookmarks + op log stacked)
+	leftTop := m.leftTopView()
 	leftBottom := m.opLogPanel.View()
 	leftPanel := lipgloss.JoinVertical(lipgloss.Left, leftTop, leftBottom)
 
-	// Render right panel (diff)
+	// Render right panel (diff, or the at-op log while comparing)
 	rightPanel := m.diffPanel.View()
+	if m.comparing {
+		rightPanel = m.compareLogPanel.View()
+	}
 
-	// Join panels horizontally
+	// Join panels horizontally, or stack the diff between the left panels
 	panels := lipgloss.JoinHorizontal(lipgloss.Top, leftPanel, rightPanel)
+	if m.stacked() {
+		panels = lipgloss.JoinVertical(lipgloss.Left, leftTop, rightPanel, leftBottom)
+	}
 
 	// Status bar
 	statusBar := m.renderStatusBar()
@@ -349,12 +917,25 @@ func (m *Model) View() tea.View {
 	// Join vertically
 	base := lipgloss.JoinVertical(lipgloss.Left, panels, statusBar)
 
+	// Toasts float above the panels but below any modal, and the keys that
+	// can follow a pending one above them
+	base = m.renderWithToasts(base)
+	base = m.renderWithWhichKey(base)
+
 	// Show floating help modal if active
 	switch {
 	case m.showHelp:
 		view.SetContent(m.renderWithOverlay(base))
+	case m.confirming:
+		view.SetContent(m.renderConfirm(base))
+	case m.showingError:
+		view.SetContent(m.renderCentered(base, m.errorPanel.View()))
 	case m.editMode:
 		view.SetContent(m.renderWithDescribeOverlay(base))
+	case m.finding:
+		view.SetContent(m.renderCentered(base, m.finder.View()))
+	case m.commanding:
+		view.SetContent(m.renderCentered(base, m.commandLine.View()))
 	default:
 		view.SetContent(base)
 	}
@@ -362,9 +943,24 @@ func (m *Model) View() tea.View {
 	return view
 }
 
+// leftTopView renders whichever panel occupies the top-left slot.
+func (m *Model) leftTopView() string {
+	switch m.viewMode {
+	case ViewFiles:
+		return m.filesPanel.View()
+	case ViewBookmarks:
+		return m.bookmarksPanel.View()
+	case ViewStacks:
+		return m.stacksPanel.View()
+	case ViewLog:
+	}
+
+	return m.logPanel.View()
+}
+
 // Action methods for keybindings.
 
-// actionAbandon executes jj abandon on the selected change.
+// actionAbandon previews and then executes jj abandon on the selected change.
 // Only allows abandon when log panel is focused and in log view.
 func (m *Model) actionAbandon() (Model, tea.Cmd) {
 	if m.focusedPane != PaneLog || m.viewMode != ViewLog {
@@ -376,45 +972,63 @@ func (m *Model) actionAbandon() (Model, tea.Cmd) {
 		return *m, nil
 	}
 
-	return *m, m.runAbandon(selected.ChangeID)
+	if selected.IsImmutable {
+		return *m, m.refuseImmutable("abandon", selected.ChangeID)
+	}
+
+	return *m, m.previewMutation(m.abandonPreview(selected.ChangeID))
 }
 
 // actionBack handles going back up the view hierarchy.
 func (m *Model) actionBack() (Model, tea.Cmd) {
+	// Esc first closes command output shown in the diff pane
+	if m.diffPanel.Previewing() {
+		m.diffPanel.ClosePreview()
+		return *m, nil
+	}
+
 	// Only handle Esc when we're in a drilled-down view AND focused on left pane
 	if m.viewMode != ViewLog && m.focusedPane == PaneLog {
 		cmd := m.handleBack()
 		return *m, cmd
 	}
 
+	// Back at the log, Esc returns from time travel to the present
+	if m.viewMode == ViewLog && m.timeTravelOp != "" {
+		return *m, m.returnToPresent()
+	}
+
+	return *m, nil
+}
+
+// actionDismiss closes the most recent persistent error toast.
+func (m *Model) actionDismiss() (Model, tea.Cmd) {
+	m.toasts.Dismiss()
 	return *m, nil
 }
 
-// actionDescribe opens the describe input overlay for the selected change.
+// actionDescribe opens the describe input overlay for the selected change,
+// or for each change of the marked range in turn.
 // Only allows describe when log panel is focused and in log view.
 func (m *Model) actionDescribe() (Model, tea.Cmd) {
 	if m.focusedPane != PaneLog || m.viewMode != ViewLog {
 		return *m, nil
 	}
 
+	if changes := m.logPanel.MarkedRange(); changes != nil {
+		return *m, m.startDescribeBatch(changes)
+	}
+
 	selected := m.logPanel.SelectedChange()
 	if selected == nil {
 		return *m, nil
 	}
 
-	// Initialize describe input with current description
-	m.describeInput.SetChangeID(selected.ChangeID)
-	// If no real description, leave empty so placeholder shows and typing replaces
-	desc := selected.Description
-	if desc == "" || desc == "(no description set)" {
-		desc = ""
+	if selected.IsImmutable {
+		return *m, m.refuseImmutable("describe", selected.ChangeID)
 	}
 
-	m.describeInput.SetValue(desc)
-	m.describeInput.SetSize(describeOverlayWidth, describeOverlayHeight)
-	m.editMode = true
-
-	return *m, m.describeInput.Focus()
+	return *m, m.openDescribe(selected.ChangeID, selected.Description, false)
 }
 
 // actionEdit executes jj edit on the selected change.
@@ -429,7 +1043,11 @@ func (m *Model) actionEdit() (Model, tea.Cmd) {
 		return *m, nil
 	}
 
-	return *m, m.runEdit(selected.ChangeID)
+	if selected.IsImmutable {
+		return *m, m.refuseImmutable("edit", selected.ChangeID)
+	}
+
+	return *m, m.guardWorkingCopy(guardedMutation{rev: selected.ChangeID, run: m.runEdit})
 }
 
 func (m *Model) actionEnter() (Model, tea.Cmd) {
@@ -462,15 +1080,111 @@ func (m *Model) actionFocusPane2() (Model, tea.Cmd) {
 	return *m, tea.Batch(m.handleFocusChange(prevPane, m.focusedPane), m.startLogPanelBorderAnim())
 }
 
-// actionNew creates an empty change on top of current working copy.
-// Works from any context.
+// actionNew creates an empty change on top of the change selected in the
+// log, or merging it with the marked change. Elsewhere it goes on top of
+// the working copy.
 func (m *Model) actionNew() (Model, tea.Cmd) {
-	return *m, m.runNew()
+	return *m, m.runNew(m.newParents()...)
+}
+
+// newParents returns the changes a new change goes on top of: the marked
+// and selected changes in the log, or just the selected one. None means @.
+func (m *Model) newParents() []string {
+	if m.focusedPane != PaneLog || m.viewMode != ViewLog {
+		return nil
+	}
+
+	if from, to, ok := m.logPanel.ComparedRange(); ok {
+		return []string{from.ChangeID, to.ChangeID}
+	}
+
+	if selected := m.logPanel.SelectedChange(); selected != nil {
+		return []string{selected.ChangeID}
+	}
+
+	return nil
+}
+
+// actionBookmarks toggles the bookmarks panel in place of the log.
+func (m *Model) actionBookmarks() (Model, tea.Cmd) {
+	switch m.viewMode {
+	case ViewBookmarks:
+		return *m, m.handleBack()
+	case ViewFiles:
+		return *m, nil
+	case ViewLog, ViewStacks:
+	}
+
+	m.viewMode = ViewBookmarks
+	m.focusedPane = PaneLog
+	m.updatePanelFocus()
+
+	return *m, m.loadBookmarks()
+}
+
+// focusBookmarkChange goes back to the log with the selected bookmark's
+// change selected.
+func (m *Model) focusBookmarkChange() tea.Cmd {
+	bookmark := m.bookmarksPanel.SelectedBookmark()
+	if bookmark == nil {
+		return nil
+	}
+
+	if bookmark.ChangeID == "" {
+		return m.toasts.Info(bookmark.Name + " is conflicted: it points at more than one change")
+	}
+
+	if !m.logPanel.SelectChangeID(bookmark.ChangeID) {
+		return m.toasts.Info(bookmark.Name + "'s change " + bookmark.ChangeID + " is not in the log")
+	}
+
+	return m.handleBack()
+}
+
+// actionPush previews and then pushes: the bookmarks on the selected change in
+// the log, or the marked bookmarks in the bookmarks panel.
+func (m *Model) actionPush() (Model, tea.Cmd) {
+	if m.focusedPane != PaneLog {
+		return *m, nil
+	}
+
+	if m.viewMode == ViewBookmarks {
+		names := m.bookmarksPanel.PushTargets()
+		if len(names) == 0 {
+			return *m, nil
+		}
+
+		return *m, m.previewMutation(m.pushBookmarksPreview(names))
+	}
+
+	if m.viewMode == ViewStacks {
+		if stack := m.stacksPanel.SelectedStack(); stack != nil {
+			return *m, m.previewMutation(m.pushStackPreview(*stack))
+		}
+
+		return *m, nil
+	}
+
+	if m.viewMode != ViewLog {
+		return *m, nil
+	}
+
+	selected := m.logPanel.SelectedChange()
+	if selected == nil {
+		return *m, nil
+	}
+
+	return *m, m.previewMutation(m.pushPreview(selected.ChangeID))
 }
 
 // actionSquash executes jj squash on the selected change.
-// Only allows squash when log panel is focused and in log view.
+// Only allows squash when log panel is focused; in the files view it moves
+// the marked files instead.
 func (m *Model) actionSquash() (Model, tea.Cmd) {
+	if m.focusedPane == PaneLog && m.viewMode == ViewFiles {
+		return m.actionSquashFiles()
+	}
+
 	if m.focusedPane != PaneLog || m.viewMode != ViewLog {
 		return *m, nil
 	}
@@ -480,7 +1194,11 @@ func (m *Model) actionSquash() (Model, tea.Cmd) {
 		return *m, nil
 	}
 
-	return *m, m.runSquash(selected.ChangeID)
+	if selected.IsImmutable {
+		return *m, m.refuseImmutable("squash", selected.ChangeID)
+	}
+
+	return *m, m.guardWorkingCopy(guardedMutation{rev: selected.ChangeID, run: m.runSquash})
 }
 
 func (m *Model) actionNextPane() (Model, tea.Cmd) {
@@ -502,11 +1220,71 @@ func (m *Model) actionPrevPane() (Model, tea.Cmd) {
 }
 
 func (m *Model) actionQuit() (Model, tea.Cmd) {
+	return *m, m.quit()
+}
+
+// quit stops watching the repo and ends the program.
+func (m *Model) quit() tea.Cmd {
 	if m.watcher != nil {
 		m.watcher.Close()
 	}
 
-	return *m, tea.Quit
+	return tea.Quit
+}
+
+// actionToggleStats cycles the log's diff stat column: off → counts → sparkline.
+func (m *Model) actionToggleStats() (Model, tea.Cmd) {
+	m.logPanel.SetStatMode(m.logPanel.StatMode().Next())
+	return *m, m.loadVisibleStats()
+}
+
+// actionToggleSyntax turns syntax highlighting in the diff pane on or off.
+func (m *Model) actionToggleSyntax() (Model, tea.Cmd) {
+	enabled := !m.diffPanel.SyntaxHighlighting()
+	m.diffPanel.SetSyntaxHighlight(enabled, m.syntaxMaxLines)
+
+	if enabled {
+		return *m, m.toasts.Info("syntax highlighting on")
+	}
+
+	return *m, m.toasts.Info("syntax highlighting off")
+}
+
+// actionToggleLineNumbers shows or hides the line numbers of diff lines.
+func (m *Model) actionToggleLineNumbers() (Model, tea.Cmd) {
+	shown := !m.diffPanel.LineNumbers()
+	m.diffPanel.SetLineNumbers(shown)
+
+	if shown {
+		return *m, m.toasts.Info("diff line numbers shown")
+	}
+
+	return *m, m.toasts.Info("diff line numbers hidden")
+}
+
+// actionToggleWrap wraps long diff lines to the pane's width, or leaves
+// them running past it to scroll with h and l.
+func (m *Model) actionToggleWrap() (Model, tea.Cmd) {
+	wrap := !m.diffPanel.Wrap()
+	m.diffPanel.SetWrap(wrap)
+
+	if wrap {
+		return *m, m.toasts.Info("diff lines wrapped")
+	}
+
+	return *m, m.toasts.Info("diff lines unwrapped: h/l scroll sideways")
+}
+
+// actionToggleFileContent switches the files view between the selected
+// file's diff and its whole content at the change.
+func (m *Model) actionToggleFileContent() (Model, tea.Cmd) {
+	if m.viewMode != ViewFiles {
+		return *m, nil
+	}
+
+	m.fileContent = !m.fileContent
+
+	return *m, m.loadSelectedDiff()
 }
 
 // actionToggleHelp toggles the help modal visibility.
@@ -519,7 +1297,7 @@ func (m *Model) actionToggleHelp() (Model, tea.Cmd) {
 // Merges global bindings with context-specific panel bindings.
 func (m *Model) activeBindings() []ActionBinding {
 	return m.globalBindings()
-	// Note: Panel bindings (j/k, g/G) are handled by updateFocusedPanel()
+	// Note: Panel bindings (j/k, gg/G) are handled by updateFocusedPanel()
 	// They don't need to be in activeBindings() for dispatch since they're
 	// not ActionBindings - they're handled directly by the panels.
 }
@@ -533,10 +1311,15 @@ func (m *Model) activeHelpBindings() []help.Binding {
 	// Add panel-specific bindings based on focus
 	switch m.focusedPane {
 	case PaneLog:
-		if m.viewMode == ViewLog {
+		switch m.viewMode {
+		case ViewLog:
 			bindings = append(bindings, m.logPanel.HelpBindings()...)
-		} else {
+		case ViewFiles:
 			bindings = append(bindings, m.filesPanel.HelpBindings()...)
+		case ViewBookmarks:
+			bindings = append(bindings, m.bookmarksPanel.HelpBindings()...)
+		case ViewStacks:
+			bindings = append(bindings, m.stacksPanel.HelpBindings()...)
 		}
 	case PaneOpLog:
 		bindings = append(bindings, m.opLogPanel.HelpBindings()...)
@@ -549,7 +1332,7 @@ func (m *Model) activeHelpBindings() []help.Binding {
 
 // globalBindings returns the app-level keybindings with their actions.
 func (m *Model) globalBindings() []ActionBinding {
-	return []ActionBinding{
+	bindings := []ActionBinding{
 		// Quit - pinned, always visible
 		{
 			Binding: help.Binding{
@@ -558,6 +1341,7 @@ func (m *Model) globalBindings() []ActionBinding {
 				Order:    orderQuit,
 				Pinned:   true,
 			},
+			ID:     "quit",
 			Action: (*Model).actionQuit,
 		},
 		// Pane focus - "#" represents 0/1 (deduped by description)
@@ -567,6 +1351,7 @@ func (m *Model) globalBindings() []ActionBinding {
 				Category: help.CategoryNavigation,
 				Order:    orderFocusPane0,
 			},
+			ID:     "focus-pane-0",
 			Action: (*Model).actionFocusPane0,
 		},
 		{
@@ -575,6 +1360,7 @@ func (m *Model) globalBindings() []ActionBinding {
 				Category: help.CategoryNavigation,
 				Order:    orderFocusPane1,
 			},
+			ID:     "focus-pane-1",
 			Action: (*Model).actionFocusPane1,
 		},
 		{
@@ -583,8 +1369,18 @@ func (m *Model) globalBindings() []ActionBinding {
 				Category: help.CategoryNavigation,
 				Order:    orderFocusPane2,
 			},
+			ID:     "focus-pane-2",
 			Action: (*Model).actionFocusPane2,
 		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.Jump,
+				Category: help.CategoryNavigation,
+				Order:    orderJump,
+			},
+			ID:     "jump",
+			Action: (*Model).actionJump,
+		},
 		// Next/prev pane - combined keys
 		{
 			Binding: help.Binding{
@@ -592,6 +1388,7 @@ func (m *Model) globalBindings() []ActionBinding {
 				Category: help.CategoryNavigation,
 				Order:    orderNextPane,
 			},
+			ID:     "next-pane",
 			Action: (*Model).actionNextPane,
 		},
 		{
@@ -600,6 +1397,7 @@ func (m *Model) globalBindings() []ActionBinding {
 				Category: help.CategoryNavigation,
 				Order:    orderPrevPane,
 			},
+			ID:     "prev-pane",
 			Action: (*Model).actionPrevPane,
 		},
 		// Actions
@@ -609,6 +1407,7 @@ func (m *Model) globalBindings() []ActionBinding {
 				Category: help.CategoryActions,
 				Order:    orderSelect,
 			},
+			ID:     "enter",
 			Action: (*Model).actionEnter,
 		},
 		{
@@ -617,6 +1416,7 @@ func (m *Model) globalBindings() []ActionBinding {
 				Category: help.CategoryActions,
 				Order:    orderBack,
 			},
+			ID:     "back",
 			Action: (*Model).actionBack,
 		},
 		{
@@ -625,7 +1425,19 @@ func (m *Model) globalBindings() []ActionBinding {
 				Category: help.CategoryActions,
 				Order:    orderDescribe,
 			},
-			Action: (*Model).actionDescribe,
+			ID:      "describe",
+			Mutates: true,
+			Action:  (*Model).actionDescribe,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.Reword,
+				Category: help.CategoryActions,
+				Order:    orderReword,
+			},
+			ID:      "reword",
+			Mutates: true,
+			Action:  (*Model).actionReword,
 		},
 		{
 			Binding: help.Binding{
@@ -633,7 +1445,9 @@ func (m *Model) globalBindings() []ActionBinding {
 				Category: help.CategoryActions,
 				Order:    orderEdit,
 			},
-			Action: (*Model).actionEdit,
+			ID:      "edit",
+			Mutates: true,
+			Action:  (*Model).actionEdit,
 		},
 		{
 			Binding: help.Binding{
@@ -641,7 +1455,19 @@ func (m *Model) globalBindings() []ActionBinding {
 				Category: help.CategoryActions,
 				Order:    orderNew,
 			},
-			Action: (*Model).actionNew,
+			ID:      "new",
+			Mutates: true,
+			Action:  (*Model).actionNew,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.NewMenu,
+				Category: help.CategoryActions,
+				Order:    orderNewMenu,
+			},
+			ID:      newMenuBindingID,
+			Mutates: true,
+			Action:  (*Model).actionNewMenu,
 		},
 		{
 			Binding: help.Binding{
@@ -649,7 +1475,9 @@ func (m *Model) globalBindings() []ActionBinding {
 				Category: help.CategoryActions,
 				Order:    orderAbandon,
 			},
-			Action: (*Model).actionAbandon,
+			ID:      "abandon",
+			Mutates: true,
+			Action:  (*Model).actionAbandon,
 		},
 		{
 			Binding: help.Binding{
@@ -657,73 +1485,568 @@ func (m *Model) globalBindings() []ActionBinding {
 				Category: help.CategoryActions,
 				Order:    orderSquash,
 			},
-			Action: (*Model).actionSquash,
+			ID:      "squash",
+			Mutates: true,
+			Action:  (*Model).actionSquash,
 		},
-		// Help toggle - pinned, always visible
 		{
 			Binding: help.Binding{
-				Key:      m.keys.Help,
+				Key:      m.keys.Duplicate,
 				Category: help.CategoryActions,
-				Order:    orderHelp,
-				Pinned:   true,
+				Order:    orderDuplicate,
 			},
-			Action: (*Model).actionToggleHelp,
+			ID:      "duplicate",
+			Mutates: true,
+			Action:  (*Model).actionDuplicate,
 		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.Backout,
+				Category: help.CategoryActions,
+				Order:    orderBackout,
+			},
+			ID:      "backout",
+			Mutates: true,
+			Action:  (*Model).actionBackout,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.Absorb,
+				Category: help.CategoryActions,
+				Order:    orderAbsorb,
+			},
+			ID:      "absorb",
+			Mutates: true,
+			Action:  (*Model).actionAbsorb,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.restoreKey(),
+				Category: help.CategoryActions,
+				Order:    orderRestore,
+			},
+			ID:      "restore",
+			Mutates: true,
+			Action:  (*Model).actionRestore,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.PickHunks,
+				Category: help.CategoryActions,
+				Order:    orderPickHunks,
+			},
+			ID:      "pick-hunks",
+			Mutates: true,
+			Action:  (*Model).actionPickHunks,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.Workspaces,
+				Category: help.CategoryActions,
+				Order:    orderWorkspaces,
+			},
+			ID:     "workspaces",
+			Action: (*Model).actionWorkspaces,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.Sparse,
+				Category: help.CategoryActions,
+				Order:    orderSparse,
+			},
+			ID:     "sparse",
+			Action: (*Model).actionSparse,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.opAbandonKey(),
+				Category: help.CategoryActions,
+				Order:    orderOpAbandon,
+			},
+			ID:      "op-abandon",
+			Mutates: true,
+			Action:  (*Model).actionOpAbandon,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.rebaseStackKey(),
+				Category: help.CategoryActions,
+				Order:    orderRebase,
+			},
+			ID:      "rebase-stack",
+			Mutates: true,
+			Action:  (*Model).actionRebaseStack,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.Annotate,
+				Category: help.CategoryActions,
+				Order:    orderAnnotate,
+			},
+			ID:     "annotate",
+			Action: (*Model).actionAnnotate,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.Commit,
+				Category: help.CategoryActions,
+				Order:    orderCommit,
+			},
+			ID:      "commit",
+			Mutates: true,
+			Action:  (*Model).actionCommit,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.Next,
+				Category: help.CategoryActions,
+				Order:    orderNext,
+			},
+			ID:      "next",
+			Mutates: true,
+			Action:  (*Model).actionNext,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.Prev,
+				Category: help.CategoryActions,
+				Order:    orderPrev,
+			},
+			ID:      "prev",
+			Mutates: true,
+			Action:  (*Model).actionPrev,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.Push,
+				Category: help.CategoryActions,
+				Order:    orderPush,
+			},
+			ID:      "push",
+			Mutates: true,
+			Action:  (*Model).actionPush,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.FindFile,
+				Category: help.CategoryNavigation,
+				Order:    orderFindFile,
+			},
+			ID:     "find-file",
+			Action: (*Model).actionFindFile,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.Find,
+				Category: help.CategoryNavigation,
+				Order:    orderFind,
+			},
+			ID:     "find",
+			Action: (*Model).actionFindAnything,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.OpenDir,
+				Category: help.CategoryActions,
+				Order:    orderOpenDir,
+			},
+			ID:     "open-dir",
+			Action: (*Model).actionOpenDir,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.OpenWeb,
+				Category: help.CategoryActions,
+				Order:    orderOpenWeb,
+			},
+			ID:     "open-web",
+			Action: (*Model).actionOpenWeb,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.Shell,
+				Category: help.CategoryActions,
+				Order:    orderShell,
+			},
+			ID:     "shell",
+			Action: (*Model).actionShell,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.DiffTool,
+				Category: help.CategoryActions,
+				Order:    orderDiffTool,
+			},
+			ID:     "difftool",
+			Action: (*Model).actionDiffTool,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.Palette,
+				Category: help.CategoryActions,
+				Order:    orderPalette,
+			},
+			ID:     paletteBindingID,
+			Action: (*Model).actionPalette,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.Command,
+				Category: help.CategoryActions,
+				Order:    orderCommand,
+			},
+			ID:     "command",
+			Action: (*Model).actionCommand,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.Refresh,
+				Category: help.CategoryActions,
+				Order:    orderRefresh,
+			},
+			ID:     "refresh",
+			Action: (*Model).actionRefresh,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.Bookmarks,
+				Category: help.CategoryView,
+				Order:    orderBookmarks,
+			},
+			ID:     "bookmarks",
+			Action: (*Model).actionBookmarks,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.Stacks,
+				Category: help.CategoryView,
+				Order:    orderStacks,
+			},
+			ID:     "stacks",
+			Action: (*Model).actionStacks,
+		},
+		// View toggles
+		{
+			Binding: help.Binding{
+				Key:      m.compareKey(),
+				Category: help.CategoryView,
+				Order:    orderCompare,
+			},
+			ID:     "compare-at-op",
+			Action: (*Model).actionCompareAtOp,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.snapshotsKey(),
+				Category: help.CategoryView,
+				Order:    orderSnapshots,
+			},
+			ID:     "snapshots",
+			Action: (*Model).actionToggleSnapshots,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.timeTravelKey(),
+				Category: help.CategoryView,
+				Order:    orderTimeTravel,
+			},
+			ID:     "time-travel",
+			Action: (*Model).actionTimeTravel,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.CopyID,
+				Category: help.CategoryActions,
+				Order:    orderCopyID,
+			},
+			ID:     "copy-id",
+			Action: (*Model).actionCopyChangeID,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.CopyHash,
+				Category: help.CategoryActions,
+				Order:    orderCopyCommit,
+			},
+			ID:     "copy-commit",
+			Action: (*Model).actionCopyCommitID,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.ToggleStats,
+				Category: help.CategoryView,
+				Order:    orderStats,
+			},
+			ID:     "stats",
+			Action: (*Model).actionToggleStats,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.ToggleSyntax,
+				Category: help.CategoryView,
+				Order:    orderSyntax,
+			},
+			ID:     "syntax",
+			Action: (*Model).actionToggleSyntax,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.LineNumbers,
+				Category: help.CategoryView,
+				Order:    orderLineNums,
+			},
+			ID:     "line-numbers",
+			Action: (*Model).actionToggleLineNumbers,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.Wrap,
+				Category: help.CategoryView,
+				Order:    orderWrap,
+			},
+			ID:     "wrap",
+			Action: (*Model).actionToggleWrap,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.IgnoreSpace,
+				Category: help.CategoryView,
+				Order:    orderWhitespace,
+			},
+			ID:     "ignore-whitespace",
+			Action: (*Model).actionToggleIgnoreWhitespace,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.MoreContext,
+				Category: help.CategoryView,
+				Order:    orderMoreLines,
+			},
+			ID:     "more-context",
+			Action: (*Model).actionMoreContext,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.LessContext,
+				Category: help.CategoryView,
+				Order:    orderLessLines,
+			},
+			ID:     "less-context",
+			Action: (*Model).actionLessContext,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.DiffFormat,
+				Category: help.CategoryView,
+				Order:    orderFormat,
+			},
+			ID:     "diff-format",
+			Action: (*Model).actionCycleDiffFormat,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.LogTemplate,
+				Category: help.CategoryView,
+				Order:    orderTemplate,
+			},
+			ID:     "log-template",
+			Action: (*Model).actionCycleLogTemplate,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.FileContent,
+				Category: help.CategoryView,
+				Order:    orderContent,
+			},
+			ID:     "file-content",
+			Action: (*Model).actionToggleFileContent,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.CycleTheme,
+				Category: help.CategoryView,
+				Order:    orderTheme,
+			},
+			ID:     "theme",
+			Action: (*Model).actionCycleTheme,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.ShrinkLeft,
+				Category: help.CategoryView,
+				Order:    orderShrinkLeft,
+			},
+			ID:     "shrink-left",
+			Action: (*Model).actionShrinkLeft,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.GrowLeft,
+				Category: help.CategoryView,
+				Order:    orderGrowLeft,
+			},
+			ID:     "grow-left",
+			Action: (*Model).actionGrowLeft,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.ToggleLayout,
+				Category: help.CategoryView,
+				Order:    orderLayout,
+			},
+			ID:     "layout",
+			Action: (*Model).actionToggleLayout,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.ToggleStatus,
+				Category: help.CategoryView,
+				Order:    orderStatus,
+			},
+			ID:     "status",
+			Action: (*Model).actionToggleStatus,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.keys.AutoRefresh,
+				Category: help.CategoryView,
+				Order:    orderPause,
+			},
+			ID:     "auto-refresh",
+			Action: (*Model).actionToggleAutoRefresh,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.dismissKey(),
+				Category: help.CategoryActions,
+				Order:    orderDismiss,
+			},
+			ID:     "dismiss",
+			Action: (*Model).actionDismiss,
+		},
+		{
+			Binding: help.Binding{
+				Key:      m.errorKey(),
+				Category: help.CategoryActions,
+				Order:    orderErrorPanel,
+			},
+			ID:     "error-details",
+			Action: (*Model).actionErrorDetails,
+		},
+		// Help toggle - pinned, always visible
+		{
+			Binding: help.Binding{
+				Key:      m.keys.Help,
+				Category: help.CategoryActions,
+				Order:    orderHelp,
+				Pinned:   true,
+			},
+			ID:     "help",
+			Action: (*Model).actionToggleHelp,
+		},
+	}
+
+	// In read-only mode or back in time mutating bindings neither run nor
+	// show in help
+	if m.readOnly || m.timeTravelOp != "" {
+		for i := range bindings {
+			if bindings[i].Mutates {
+				bindings[i].Key.SetEnabled(false)
+			}
+		}
+	}
+
+	return bindings
+}
+
+// dismissKey returns the dismiss binding, enabled only while an error toast is shown
+// so it stays out of the status bar and help when there is nothing to dismiss.
+func (m *Model) dismissKey() key.Binding {
+	binding := m.keys.Dismiss
+	binding.SetEnabled(m.toasts != nil && m.toasts.HasErrors())
+
+	return binding
+}
+
+func (m *Model) handleBack() tea.Cmd {
+	if m.viewMode == ViewBookmarks || m.viewMode == ViewStacks {
+		m.viewMode = ViewLog
+		m.updatePanelFocus()
+
+		return m.loadSelectedDiff()
+	}
+
+	if m.viewMode == ViewFiles {
+		// Go back to log view
+		m.viewMode = ViewLog
+		m.updatePanelFocus() // log now visible in left slot; focused, not animated
+		m.diffPanel.SetTitle("Diff")
+		// Restore full diff for selected change
+		if change := m.logPanel.SelectedChange(); change != nil {
+			m.diffPanel.SetDiff(m.currentDiff)
+		}
+		// Restore global op log (switch back from evolog mode)
+		return m.loadOpLog()
+	}
+
+	return nil
+}
+
+func (m *Model) handleEnter() tea.Cmd {
+	if m.picking {
+		if cmd := m.pick(); cmd != nil {
+			return cmd
+		}
+	}
+
+	// In the diff pane, enter folds or unfolds the file under the cursor
+	if m.focusedPane == PaneDiff && !m.comparing {
+		m.diffPanel.ToggleSection()
+		return nil
+	}
+
+	switch m.viewMode {
+	case ViewLog:
+		// Drill into files
+		if change := m.logPanel.SelectedChange(); change != nil {
+			m.log.Debug("drilling into files view", "change_id", change.ChangeID)
+			m.viewMode = ViewFiles
+			m.focusedPane = PaneLog
+			m.updatePanelFocus() // files now visible in left slot; focused, not animated
+
+			return m.loadFiles(change.ChangeID)
+		}
+	case ViewFiles:
+		// On a directory of the tree, fold or unfold it
+		if m.filesPanel.ToggleDir() {
+			return nil
+		}
+
+		if file := m.filesPanel.SelectedFile(); file != nil {
+			changeID := m.filesPanel.ChangeID()
+			return m.loadFileDiff(changeID, file.Path)
+		}
+	case ViewBookmarks:
+		return m.focusBookmarkChange()
+	case ViewStacks:
+		return m.focusStackChange()
+	}
+
+	return nil
+}
+
+// handleFocusChange loads appropriate content when focus changes between panes.
+func (m *Model) handleFocusChange(fromPane, toPane FocusedPane) tea.Cmd {
+	if fromPane == toPane {
+		return nil
+	}
+
+	// When focusing op log, show op details in diff pane
+	if toPane == PaneOpLog {
+		if op := m.opLogPanel.SelectedOperation(); op != nil {
+			return m.loadOpShow(op.OpID)
+		}
 	}
-}
-
-func (m *Model) handleBack() tea.Cmd {
-	if m.viewMode == ViewFiles {
-		// Go back to log view
-		m.viewMode = ViewLog
-		m.updatePanelFocus() // log now visible in left slot; focused, not animated
-		m.diffPanel.SetTitle("Diff")
-		// Restore full diff for selected change
-		if change := m.logPanel.SelectedChange(); change != nil {
-			m.diffPanel.SetDiff(m.currentDiff)
-		}
-		// Restore global op log (switch back from evolog mode)
-		return m.loadOpLog()
-	}
-
-	return nil
-}
-
-func (m *Model) handleEnter() tea.Cmd {
-	switch m.viewMode {
-	case ViewLog:
-		// Drill into files
-		if change := m.logPanel.SelectedChange(); change != nil {
-			m.log.Debug("drilling into files view", "change_id", change.ChangeID)
-			m.viewMode = ViewFiles
-			m.focusedPane = PaneLog
-			m.updatePanelFocus() // files now visible in left slot; focused, not animated
-
-			return m.loadFiles(change.ChangeID)
-		}
-	case ViewFiles:
-		// Could drill into hunks later, for now just update diff
-		if file := m.filesPanel.SelectedFile(); file != nil {
-			changeID := m.filesPanel.ChangeID()
-			return m.loadFileDiff(changeID, file.Path)
-		}
-	}
-
-	return nil
-}
-
-// handleFocusChange loads appropriate content when focus changes between panes.
-func (m *Model) handleFocusChange(fromPane, toPane FocusedPane) tea.Cmd {
-	if fromPane == toPane {
-		return nil
-	}
-
-	// When focusing op log, show op details in diff pane
-	if toPane == PaneOpLog {
-		if op := m.opLogPanel.SelectedOperation(); op != nil {
-			return m.loadOpShow(op.OpID)
-		}
-	}
 
 	// When focusing log (from op log), show change diff in diff pane
 	if toPane == PaneLog && fromPane == PaneOpLog {
@@ -735,12 +2058,26 @@ func (m *Model) handleFocusChange(fromPane, toPane FocusedPane) tea.Cmd {
 
 // loadSelectedDiff loads diff content for the currently selected item based on view mode.
 func (m *Model) loadSelectedDiff() tea.Cmd {
-	if m.viewMode == ViewLog {
+	switch m.viewMode {
+	case ViewLog:
 		if change := m.logPanel.SelectedChange(); change != nil {
+			return m.loadLogDiff(change.ChangeID)
+		}
+
+		return nil
+	case ViewBookmarks:
+		if bookmark := m.bookmarksPanel.SelectedBookmark(); bookmark != nil && bookmark.ChangeID != "" {
+			return m.loadDiff(bookmark.ChangeID)
+		}
+
+		return nil
+	case ViewStacks:
+		if change := m.selectedStackChange(); change != nil {
 			return m.loadDiff(change.ChangeID)
 		}
 
 		return nil
+	case ViewFiles:
 	}
 
 	if file := m.filesPanel.SelectedFile(); file != nil {
@@ -751,31 +2088,46 @@ func (m *Model) loadSelectedDiff() tea.Cmd {
 }
 
 func (m *Model) handleMouse(msg tea.MouseMsg) tea.Cmd {
+	// The too-small screen has no panels to click
+	if m.tooSmall() || m.handleDividerDrag(msg) {
+		return nil
+	}
+
 	// Get the underlying mouse event
 	mouse := msg.Mouse()
 
-	// Get left panel width from rendered content
-	var leftWidth int
-	if m.viewMode == ViewLog {
-		leftWidth = lipgloss.Width(m.logPanel.View())
+	var (
+		inTopLeftPanel, inBottomLeftPanel, inRightPanel bool
+		opLogTop                                        int // screen row where the op log starts
+	)
+
+	if m.stacked() {
+		heights := m.stackedHeights()
+		opLogTop = heights.log + heights.diff
+
+		inTopLeftPanel = mouse.Y < heights.log
+		inRightPanel = mouse.Y >= heights.log && mouse.Y < opLogTop
+		inBottomLeftPanel = mouse.Y >= opLogTop
 	} else {
-		leftWidth = lipgloss.Width(m.filesPanel.View())
-	}
+		// Get left panel width from rendered content
+		leftWidth := lipgloss.Width(m.leftTopView())
 
-	// Calculate panel heights for vertical split
-	contentHeight := m.height - statusBarHeight
-	leftTopHeight := contentHeight / leftPanelSplitDivisor
+		// Calculate panel heights for vertical split
+		contentHeight := m.height - statusBarHeight
+		opLogTop = contentHeight / leftPanelSplitDivisor
 
-	// Panel content starts after border (1) and title line (1)
+		// Determine which panel was interacted with
+		inLeftPanel := mouse.X < leftWidth
+		inRightPanel = mouse.X >= leftWidth
+		inTopLeftPanel = inLeftPanel && mouse.Y < opLogTop
+		inBottomLeftPanel = inLeftPanel && mouse.Y >= opLogTop
+	}
 
-	// Determine which panel was interacted with
-	inLeftPanel := mouse.X < leftWidth
-	inRightPanel := mouse.X >= leftWidth
-	inTopLeftPanel := inLeftPanel && mouse.Y < leftTopHeight
-	inBottomLeftPanel := inLeftPanel && mouse.Y >= leftTopHeight
+	// Panel content starts after border (1) and title line (1)
 
 	// Handle scroll events (wheel)
-	if mouse.Button == tea.MouseWheelUp || mouse.Button == tea.MouseWheelDown {
+	switch mouse.Button {
+	case tea.MouseWheelUp, tea.MouseWheelDown, tea.MouseWheelLeft, tea.MouseWheelRight:
 		if inRightPanel {
 			m.diffPanel.HandleMouseScroll(mouse.Button)
 		}
@@ -789,7 +2141,7 @@ func (m *Model) handleMouse(msg tea.MouseMsg) tea.Cmd {
 		case inTopLeftPanel:
 			return m.handleLogPanelClick(mouse.Y - contentYOffset)
 		case inBottomLeftPanel:
-			return m.handleOpLogPanelClick(mouse.Y - leftTopHeight - contentYOffset)
+			return m.handleOpLogPanelClick(mouse.Y - opLogTop - contentYOffset)
 		case inRightPanel:
 			return m.handleDiffPanelClick()
 		}
@@ -804,10 +2156,25 @@ func (m *Model) handleLogPanelClick(contentY int) tea.Cmd {
 
 	var loadCmd tea.Cmd
 
-	if m.viewMode == ViewLog {
+	switch m.viewMode {
+	case ViewLog:
 		loadCmd = m.loadClickedChange(contentY)
-	} else {
+	case ViewFiles:
 		loadCmd = m.loadClickedFile(contentY)
+	case ViewBookmarks:
+		switch {
+		case m.bookmarksPanel.SelectedAt(contentY):
+			loadCmd = m.focusBookmarkChange()
+		case m.bookmarksPanel.HandleClick(contentY):
+			loadCmd = m.loadSelectedDiff()
+		}
+	case ViewStacks:
+		switch {
+		case m.stacksPanel.SelectedAt(contentY):
+			loadCmd = m.focusStackChange()
+		case m.stacksPanel.HandleClick(contentY):
+			loadCmd = m.loadSelectedDiff()
+		}
 	}
 
 	return tea.Batch(loadCmd, m.startLogPanelBorderAnim())
@@ -826,7 +2193,7 @@ func (m *Model) loadClickedChange(contentY int) tea.Cmd {
 		return nil
 	}
 
-	return m.loadDiff(m.logPanel.SelectedChange().ChangeID)
+	return tea.Batch(m.loadLogDiff(m.logPanel.SelectedChange().ChangeID), m.loadVisibleStats(), m.loadMoreLog())
 }
 
 // loadClickedFile processes a click in the files panel and loads the file diff if a file was selected.
@@ -844,7 +2211,7 @@ func (m *Model) loadClickedOp(contentY int) tea.Cmd {
 		return nil
 	}
 
-	return m.loadOpShow(m.opLogPanel.SelectedOperation().OpID)
+	return tea.Batch(m.loadOpShow(m.opLogPanel.SelectedOperation().OpID), m.loadMoreOpLog())
 }
 
 func (m *Model) handleDiffPanelClick() tea.Cmd {
@@ -856,29 +2223,35 @@ func (m *Model) handleDiffPanelClick() tea.Cmd {
 
 // loadDiff fetches the diff for a change.
 func (m *Model) loadDiff(changeID string) tea.Cmd {
+	ctx, generation := m.diffRequests.next(m.ctx)
+	runner := m.runner.WithContext(ctx)
+
 	return func() tea.Msg {
 		// Get diff
-		diffOutput, err := m.runner.Show(changeID)
+		diffOutput, err := runner.Show(changeID)
 		if err != nil {
-			return errMsg{err}
+			return superseded(ctx, err)
 		}
 
 		return diffLoadedMsg{
 			changeID:   changeID,
 			diffOutput: diffOutput,
+			generation: generation,
 		}
 	}
 }
 
 // loadEvoLog fetches the evolution log for a specific change.
 func (m *Model) loadEvoLog(changeID, shortCode string) tea.Cmd {
+	runner := m.runner
+
 	return func() tea.Msg {
-		output, err := m.runner.EvoLog(changeID)
+		output, err := runner.EvoLog(changeID)
 		if err != nil {
 			return errMsg{err}
 		}
 
-		operations := m.runner.ParseOpLogLines(output)
+		operations := runner.ParseOpLogLines(output)
 
 		return evoLogLoadedMsg{
 			changeID:   changeID,
@@ -889,81 +2262,220 @@ func (m *Model) loadEvoLog(changeID, shortCode string) tea.Cmd {
 	}
 }
 
-// loadFileDiff fetches the diff for a specific file.
+// loadFileDiff fetches the diff for a specific file, or its content when
+// the files view shows content.
 func (m *Model) loadFileDiff(changeID, filePath string) tea.Cmd {
+	ctx, generation := m.diffRequests.next(m.ctx)
+	runner := m.runner.WithContext(ctx)
+	showContent := m.fileContent
+
 	return func() tea.Msg {
-		diffOutput, err := m.runner.DiffFile(changeID, filePath)
+		if showContent {
+			content, err := runner.FileShow(changeID, filePath)
+			if err == nil && jj.IsBinary(content) {
+				content = "binary file (" + jj.DescribeContent(content).String() + ")"
+			}
+
+			if err == nil {
+				return fileDiffLoadedMsg{diffOutput: content, contentPath: filePath, generation: generation}
+			}
+
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			// A file the change deletes has no content there; its diff shows
+			// what was removed instead
+		}
+
+		diffOutput, err := runner.DiffFile(changeID, filePath)
 		if err != nil {
-			return errMsg{err}
+			return superseded(ctx, err)
 		}
 
-		return fileDiffLoadedMsg{diffOutput: diffOutput}
+		return fileDiffLoadedMsg{diffOutput: diffOutput, generation: generation}
 	}
 }
 
 // loadFiles parses files from diff output.
 func (m *Model) loadFiles(changeID string) tea.Cmd {
+	runner := m.runner
+
 	return func() tea.Msg {
-		diffOutput, err := m.runner.Diff(changeID)
+		diffOutput, err := runner.Diff(changeID)
 		if err != nil {
 			return errMsg{err}
 		}
 
 		// Get the shortest unique prefix for coloring
-		shortCode, _ := m.runner.ShortestChangeID(changeID)
+		shortCode, _ := runner.ShortestChangeID(changeID)
 		if shortCode == "" {
 			shortCode = changeID // Fallback to full ID if call fails
 		}
 
-		files := m.runner.ParseFiles(diffOutput)
+		files := runner.ParseFiles(diffOutput)
 
-		return filesLoadedMsg{changeID: changeID, shortCode: shortCode, files: files, diffOutput: diffOutput}
+		// Line counts are best-effort: the list still works without them.
+		stats, err := runner.DiffStat(changeID)
+		if err != nil {
+			m.log.Warn("loading file stats failed", "change_id", changeID, "err", err)
+		}
+
+		return filesLoadedMsg{changeID: changeID, shortCode: shortCode, files: files, stats: stats, diffOutput: diffOutput}
 	}
 }
 
 // loadLog fetches the jj log.
 func (m *Model) loadLog() tea.Cmd {
+	limit := m.logLimit
+	atOp := m.timeTravelOp
+	runner := m.runner.Pinned()
+
 	return func() tea.Msg {
-		output, err := m.runner.Log()
+		output, err := runner.Log(limit)
 		if err != nil {
 			return errMsg{err}
 		}
 
-		changes := m.runner.ParseLogLines(output)
+		changes := runner.ParseLogLines(output)
+
+		// Metadata is best-effort: the log still renders without badges or guards.
+		if meta, err := runner.LogMetadata(limit); err == nil {
+			jj.ApplyMetadata(changes, meta)
+		} else {
+			m.log.Warn("loading change metadata failed", "err", err)
+		}
 
-		return logLoadedMsg{raw: output, changes: changes}
+		return logLoadedMsg{raw: output, changes: changes, limit: limit, atOp: atOp}
+	}
+}
+
+// loadVisibleStats fetches diff stats for visible log rows that have none cached.
+func (m *Model) loadVisibleStats() tea.Cmd {
+	revs := m.logPanel.PendingStatRevs()
+	if len(revs) == 0 {
+		return nil
+	}
+
+	runner := m.runner.Pinned()
+
+	return func() tea.Msg {
+		stats := make(map[string]jj.ChangeStat, len(revs))
+
+		var failed []string
+
+		for _, rev := range revs {
+			stat, err := runner.ChangeStat(rev)
+			if err != nil {
+				m.log.Warn("loading change stat failed", "rev", rev, "err", err)
+				failed = append(failed, rev)
+
+				continue
+			}
+
+			stats[rev] = stat
+		}
+
+		return changeStatsLoadedMsg{stats: stats, failed: failed}
+	}
+}
+
+// loadBookmarks fetches the local bookmarks for the bookmarks panel.
+func (m *Model) loadBookmarks() tea.Cmd {
+	runner := m.runner
+
+	return func() tea.Msg {
+		bookmarks, err := runner.Bookmarks()
+		if err != nil {
+			return errMsg{err}
+		}
+
+		return bookmarksLoadedMsg{bookmarks: bookmarks}
 	}
 }
 
 // loadOpLog fetches the jj operation log.
 func (m *Model) loadOpLog() tea.Cmd {
+	limit := m.opLogLimit
+	runner := m.runner
+
 	return func() tea.Msg {
-		output, err := m.runner.OpLog()
+		output, err := runner.OpLog(limit)
 		if err != nil {
 			return errMsg{err}
 		}
 
-		operations := m.runner.ParseOpLogLines(output)
+		operations := runner.ParseOpLogLines(output)
 
-		return opLogLoadedMsg{raw: output, operations: operations}
+		return opLogLoadedMsg{raw: output, operations: operations, limit: limit}
 	}
 }
 
-// loadOpShow fetches details for a specific operation.
+// loadOpShow fetches details for a specific operation: its patch, or its
+// op diff when that view is chosen.
 func (m *Model) loadOpShow(opID string) tea.Cmd {
+	// With a version marked in the evolog, compare it with the selected one
+	if older, newer, ok := m.opLogPanel.ComparedVersions(); ok {
+		return m.loadInterdiff(m.opLogPanel.ChangeID(), older.OpID, newer.OpID)
+	}
+
+	ctx, generation := m.diffRequests.next(m.ctx)
+	runner := m.runner.WithContext(ctx)
+	detail := m.opDetail
+
 	return func() tea.Msg {
-		output, err := m.runner.OpShow(opID)
+		load := runner.OpShow
+
+		switch detail {
+		case opDetailDiff:
+			load = runner.OpDiff
+		case opDetailRestore:
+			load = runner.OpRestoreDiff
+		case opDetailPatch, opDetailCount:
+		}
+
+		output, err := load(opID)
 		if err != nil {
-			return errMsg{err}
+			return superseded(ctx, err)
+		}
+
+		if detail == opDetailRestore {
+			output = restorePreview(opID, output)
 		}
 
-		return opShowLoadedMsg{opID: opID, output: output}
+		return opShowLoadedMsg{opID: opID, output: output, detail: detail, generation: generation}
 	}
 }
 
 // renderWithOverlay composites the help modal on top of the base view.
 // using lipgloss v2 Canvas/Layer for true transparency.
 func (m *Model) renderWithOverlay(base string) string {
+	modal := m.helpModal()
+
+	// Calculate center position
+	overlayWidth := lipgloss.Width(modal)
+	overlayHeight := lipgloss.Height(modal)
+	overlayX := (m.width - overlayWidth) / centerDivisor
+	overlayY := (m.height - overlayHeight) / centerDivisor
+
+	// Create base layer (full screen)
+	baseLayer := lipgloss.NewLayer(base).
+		Width(m.width).
+		Height(m.height).
+		X(0).Y(0).Z(0)
+
+	// Create overlay layer (centered, on top)
+	overlayLayer := lipgloss.NewLayer(modal).
+		X(overlayX).Y(overlayY).Z(1)
+
+	// Composite and render
+	canvas := lipgloss.NewCanvas(baseLayer, overlayLayer)
+
+	return canvas.Render()
+}
+
+// helpModal renders the floating help sized to the screen.
+func (m *Model) helpModal() string {
 	// Calculate modal size (centered, ~80% of screen)
 	modalWidth := m.width * modalWidthPct / percentDivisor
 	modalHeight := m.height * modalHeightPct / percentDivisor
@@ -979,47 +2491,80 @@ func (m *Model) renderWithOverlay(base string) string {
 	// Set up and render floating help
 	m.floatingHelp.SetSize(modalWidth, modalHeight)
 	m.floatingHelp.SetBindings(m.activeHelpBindings())
-	modal := m.floatingHelp.View()
+	m.floatingHelp.SetNotes(m.featureNotes)
 
-	// Calculate center position
-	overlayWidth := lipgloss.Width(modal)
-	overlayHeight := lipgloss.Height(modal)
-	overlayX := (m.width - overlayWidth) / centerDivisor
-	overlayY := (m.height - overlayHeight) / centerDivisor
+	return m.floatingHelp.View()
+}
+
+// renderWithToasts composites the toast stack in the bottom-right corner,
+// just above the status bar.
+func (m *Model) renderWithToasts(base string) string {
+	toasts := m.toasts.View(m.width)
+	if toasts == "" {
+		return base
+	}
+
+	toastX := max(m.width-lipgloss.Width(toasts), 0)
+	toastY := max(m.height-statusBarHeight-lipgloss.Height(toasts), 0)
 
-	// Create base layer (full screen)
 	baseLayer := lipgloss.NewLayer(base).
 		Width(m.width).
 		Height(m.height).
 		X(0).Y(0).Z(0)
 
-	// Create overlay layer (centered, on top)
-	overlayLayer := lipgloss.NewLayer(modal).
-		X(overlayX).Y(overlayY).Z(1)
+	toastLayer := lipgloss.NewLayer(toasts).
+		X(toastX).Y(toastY).Z(1)
 
-	// Composite and render
-	canvas := lipgloss.NewCanvas(baseLayer, overlayLayer)
+	return lipgloss.NewCanvas(baseLayer, toastLayer).Render()
+}
 
-	return canvas.Render()
+// renderWithWhichKey composites the keys that can follow the focused
+// panel's pending key (the g of gg) in the bottom-right corner, above the
+// status bar.
+func (m *Model) renderWithWhichKey(base string) string {
+	nav := m.focusedNavigator()
+	if !nav.KeyPending() {
+		return base
+	}
+
+	m.whichKey.SetPending(nav.PendingKey(), m.pendingBindings())
+
+	popup := m.whichKey.View()
+	if popup == "" {
+		return base
+	}
+
+	popupX := max(m.width-lipgloss.Width(popup), 0)
+	popupY := max(m.height-statusBarHeight-lipgloss.Height(popup), 0)
+
+	baseLayer := lipgloss.NewLayer(base).
+		Width(m.width).
+		Height(m.height).
+		X(0).Y(0).Z(0)
+
+	popupLayer := lipgloss.NewLayer(popup).
+		X(popupX).Y(popupY).Z(1)
+
+	return lipgloss.NewCanvas(baseLayer, popupLayer).Render()
 }
 
 func (m *Model) renderStatusBar() string {
 	m.statusBar.SetWidth(m.width)
+	m.statusBar.SetActivity(m.activity(time.Now()))
 
 	return m.styles.StatusBar.Render(m.statusBar.View())
 }
 
-// renderWithDescribeOverlay composites the describe input on top of the base view
-// using lipgloss v2 Canvas/Layer for true transparency.
+// renderWithDescribeOverlay composites the describe input on top of the base view.
 func (m *Model) renderWithDescribeOverlay(base string) string {
-	// Render the describe input
-	describeView := m.describeInput.View()
-	overlayWidth := m.describeInput.Width()
-	overlayHeight := m.describeInput.Height()
+	return m.renderCentered(base, m.describeInput.View())
+}
 
-	// Calculate center position
-	overlayX := (m.width - overlayWidth) / centerDivisor
-	overlayY := (m.height - overlayHeight) / centerDivisor
+// renderCentered composites an overlay in the center of the base view
+// using lipgloss v2 Canvas/Layer for true transparency. An overlay larger
+// than the screen is pinned to the top-left so its start stays visible.
+func (m *Model) renderCentered(base, overlay string) string {
+	overlayX, overlayY := centeredOrigin(m.width, m.height, lipgloss.Width(overlay), lipgloss.Height(overlay))
 
 	// Create base layer (full screen)
 	baseLayer := lipgloss.NewLayer(base).
@@ -1028,7 +2573,7 @@ func (m *Model) renderWithDescribeOverlay(base string) string {
 		X(0).Y(0).Z(0)
 
 	// Create overlay layer (centered, on top)
-	overlayLayer := lipgloss.NewLayer(describeView).
+	overlayLayer := lipgloss.NewLayer(overlay).
 		X(overlayX).Y(overlayY).Z(1)
 
 	// Composite and render
@@ -1037,73 +2582,137 @@ func (m *Model) renderWithDescribeOverlay(base string) string {
 	return canvas.Render()
 }
 
+// centeredOrigin returns the top-left corner that centers an overlay on the
+// screen, clamped so the overlay never starts off-screen.
+func centeredOrigin(screenWidth, screenHeight, overlayWidth, overlayHeight int) (int, int) {
+	x := max((screenWidth-overlayWidth)/centerDivisor, 0)
+	y := max((screenHeight-overlayHeight)/centerDivisor, 0)
+
+	return x, y
+}
+
 // runAbandon executes jj abandon and returns a completion message.
 func (m *Model) runAbandon(changeID string) tea.Cmd {
-	return func() tea.Msg {
-		err := m.runner.Abandon(changeID)
+	runner := m.runner
+
+	return m.startJob("jj abandon "+changeID, func() tea.Msg {
+		err := runner.Abandon(changeID)
 		if err != nil {
 			return errMsg{err}
 		}
 
 		return abandonCompleteMsg{changeID: changeID}
-	}
+	})
 }
 
 // runDescribe executes jj describe and returns a completion message.
 func (m *Model) runDescribe(changeID, message string) tea.Cmd {
-	return func() tea.Msg {
-		if err := m.runner.Describe(changeID, message); err != nil {
+	runner := m.runner
+
+	return m.startJob("jj describe "+changeID, func() tea.Msg {
+		if err := runner.Describe(changeID, message); err != nil {
 			return errMsg{err}
 		}
 
 		return describeCompleteMsg{changeID: changeID}
-	}
+	})
 }
 
 // runEdit executes jj edit and returns a completion message.
 func (m *Model) runEdit(changeID string) tea.Cmd {
-	return func() tea.Msg {
-		if err := m.runner.Edit(changeID); err != nil {
+	runner := m.runner
+
+	return m.startJob("jj edit "+changeID, func() tea.Msg {
+		if err := runner.Edit(changeID); err != nil {
 			return errMsg{err}
 		}
 
 		return editCompleteMsg{changeID: changeID}
+	})
+}
+
+// runNew executes jj new on parents (@ when none) and returns a completion
+// message.
+func (m *Model) runNew(parents ...string) tea.Cmd {
+	label, summary := "jj new", "created new change"
+	if len(parents) > 0 {
+		label += " " + strings.Join(parents, " ")
+		summary += " on " + parents[0]
 	}
+
+	if len(parents) > 1 {
+		summary = "created merge of " + strings.Join(parents, " and ")
+	}
+
+	runner := m.runner
+
+	return m.startJob(label, func() tea.Msg {
+		if err := runner.New(parents...); err != nil {
+			return errMsg{err}
+		}
+
+		return newCompleteMsg{summary: summary}
+	})
 }
 
-// runNew executes jj new and returns a completion message.
-func (m *Model) runNew() tea.Cmd {
-	return func() tea.Msg {
-		if err := m.runner.New(); err != nil {
+// runPushBookmarks pushes the named bookmarks and reports per-bookmark results.
+// A failed push is not an errMsg: its error becomes each bookmark's status.
+func (m *Model) runPushBookmarks(names []string) tea.Cmd {
+	runner := m.runner
+
+	return m.startJob("jj git push --bookmark "+strings.Join(names, " --bookmark "), func() tea.Msg {
+		output, err := runner.PushBookmarks(names)
+		if err != nil {
+			m.log.Warn("bookmark push failed", "bookmarks", names, "err", err)
+		}
+
+		return bookmarksPushedMsg{results: jj.PushResults(names, output, err)}
+	})
+}
+
+// runPush executes jj git push and returns a completion message.
+func (m *Model) runPush(changeID string) tea.Cmd {
+	runner := m.runner
+
+	return m.startJob("jj git push -r "+changeID, func() tea.Msg {
+		if err := runner.Push(changeID); err != nil {
 			return errMsg{err}
 		}
 
-		return newCompleteMsg{}
-	}
+		return pushCompleteMsg{changeID: changeID}
+	})
 }
 
 // runSquash executes jj squash and returns a completion message.
 func (m *Model) runSquash(changeID string) tea.Cmd {
-	return func() tea.Msg {
-		if err := m.runner.Squash(changeID); err != nil {
+	runner := m.runner
+
+	return m.startJob("jj squash -r "+changeID, func() tea.Msg {
+		if err := runner.Squash(changeID); err != nil {
 			return errMsg{err}
 		}
 
 		return squashCompleteMsg{changeID: changeID}
-	}
+	})
 }
 
 // setFocusBorderAnimPhase sets the border anim phase on whichever panel currently has focus.
 func (m *Model) setFocusBorderAnimPhase(phase float64) {
 	switch m.focusedPane {
 	case PaneLog:
-		if m.viewMode == ViewLog {
+		switch m.viewMode {
+		case ViewLog:
 			m.logPanel.SetBorderAnimPhase(phase)
-		} else {
+		case ViewFiles:
 			m.filesPanel.SetBorderAnimPhase(phase)
+		case ViewBookmarks:
+			m.bookmarksPanel.SetBorderAnimPhase(phase)
+		case ViewStacks:
+			m.stacksPanel.SetBorderAnimPhase(phase)
 		}
 	case PaneDiff:
 		m.diffPanel.SetBorderAnimPhase(phase)
+		m.compareLogPanel.SetBorderAnimPhase(phase)
 	case PaneOpLog:
 		m.opLogPanel.SetBorderAnimPhase(phase)
 	}
@@ -1113,13 +2722,19 @@ func (m *Model) setFocusBorderAnimPhase(phase float64) {
 func (m *Model) setFocusBorderAnimating(animating bool) {
 	switch m.focusedPane {
 	case PaneLog:
-		if m.viewMode == ViewLog {
+		switch m.viewMode {
+		case ViewLog:
 			m.logPanel.SetBorderAnimating(animating)
-		} else {
+		case ViewFiles:
 			m.filesPanel.SetBorderAnimating(animating)
+		case ViewBookmarks:
+			m.bookmarksPanel.SetBorderAnimating(animating)
+		case ViewStacks:
+			m.stacksPanel.SetBorderAnimating(animating)
 		}
 	case PaneDiff:
 		m.diffPanel.SetBorderAnimating(animating)
+		m.compareLogPanel.SetBorderAnimating(animating)
 	case PaneOpLog:
 		m.opLogPanel.SetBorderAnimating(animating)
 	}
@@ -1128,6 +2743,17 @@ func (m *Model) setFocusBorderAnimating(animating bool) {
 // startLogPanelBorderAnim starts the one-shot border wrap animation for the focused panel.
 func (m *Model) startLogPanelBorderAnim() tea.Cmd {
 	m.borderAnimGeneration++
+
+	if m.reducedMotion {
+		// Straight to the static focus border; the generation stops any wrap
+		// still running
+		m.logPanelBorderPhase = 1
+		m.setFocusBorderAnimPhase(1)
+		m.setFocusBorderAnimating(false)
+
+		return nil
+	}
+
 	m.logPanelBorderPhase = 0
 	m.setFocusBorderAnimPhase(0)
 	m.setFocusBorderAnimating(true) // only explicit focus (key/mouse) runs the animation
@@ -1144,8 +2770,10 @@ func (m *Model) startLogPanelBorderAnimWithPhase(phase float64, generation int)
 
 // startWatcher starts the file system watcher.
 func (m *Model) startWatcher() tea.Cmd {
+	workDir := m.workDir
+
 	return func() tea.Msg {
-		watcher, err := jj.NewWatcher(m.workDir, m.log)
+		watcher, err := jj.NewWatcherWith(workDir, m.watchOptions, m.log)
 		if err != nil {
 			// Don't fail if watcher can't start, just disable auto-refresh
 			return watcherStartedMsg{watcher: nil, err: err}
@@ -1161,27 +2789,42 @@ func (m *Model) updateFocusedPanel(msg tea.Msg) tea.Cmd {
 	// Handle navigation in focused panel
 	switch m.focusedPane {
 	case PaneLog:
-		if m.viewMode == ViewLog {
+		switch m.viewMode {
+		case ViewLog:
 			cmd = m.logPanel.Update(msg)
-			// Update diff when selection changes
+			// Update diff once the selection settles
 			if change := m.logPanel.SelectedChange(); change != nil {
-				return tea.Batch(cmd, m.loadDiff(change.ChangeID))
+				return tea.Batch(cmd, m.debounceDiffLoad(), m.loadVisibleStats(), m.loadMoreLog())
 			}
-		} else {
+		case ViewFiles:
 			cmd = m.filesPanel.Update(msg)
-			// Update diff when file selection changes
+			// Update diff once the file selection settles
 			if file := m.filesPanel.SelectedFile(); file != nil {
-				changeID := m.filesPanel.ChangeID()
-				return tea.Batch(cmd, m.loadFileDiff(changeID, file.Path))
+				return tea.Batch(cmd, m.debounceDiffLoad())
 			}
+		case ViewBookmarks:
+			cmd = m.bookmarksPanel.Update(msg)
+			// Show the diff of the bookmark's target
+			return tea.Batch(cmd, m.debounceDiffLoad())
+		case ViewStacks:
+			cmd = m.stacksPanel.Update(msg)
+			return tea.Batch(cmd, m.debounceDiffLoad())
 		}
 	case PaneOpLog:
 		cmd = m.opLogPanel.Update(msg)
-		// Update diff pane with op show when selection changes
+		// Update the right pane when selection changes
 		if op := m.opLogPanel.SelectedOperation(); op != nil {
-			return tea.Batch(cmd, m.loadOpShow(op.OpID))
+			if m.comparing {
+				return tea.Batch(cmd, m.loadLogAtOp(op.OpID), m.loadMoreOpLog())
+			}
+
+			return tea.Batch(cmd, m.loadOpShow(op.OpID), m.loadMoreOpLog())
 		}
 	case PaneDiff:
+		if m.comparing {
+			return m.compareLogPanel.Update(msg)
+		}
+
 		cmd = m.diffPanel.Update(msg)
 	}
 
@@ -1192,21 +2835,54 @@ func (m *Model) updatePanelFocus() {
 	// Only the panel visible in the left slot gets focused when PaneLog is active
 	m.logPanel.SetFocused(m.focusedPane == PaneLog && m.viewMode == ViewLog)
 	m.filesPanel.SetFocused(m.focusedPane == PaneLog && m.viewMode == ViewFiles)
+	m.bookmarksPanel.SetFocused(m.focusedPane == PaneLog && m.viewMode == ViewBookmarks)
+	m.stacksPanel.SetFocused(m.focusedPane == PaneLog && m.viewMode == ViewStacks)
 	m.opLogPanel.SetFocused(m.focusedPane == PaneOpLog)
 	m.diffPanel.SetFocused(m.focusedPane == PaneDiff)
+	m.compareLogPanel.SetFocused(m.focusedPane == PaneDiff)
 	// Clear animating so focus-without-animation (e.g. back from files) shows static border
 	m.logPanel.SetBorderAnimating(false)
 	m.filesPanel.SetBorderAnimating(false)
+	m.bookmarksPanel.SetBorderAnimating(false)
+	m.stacksPanel.SetBorderAnimating(false)
 	m.diffPanel.SetBorderAnimating(false)
+	m.compareLogPanel.SetBorderAnimating(false)
 	m.opLogPanel.SetBorderAnimating(false)
 }
 
 func (m *Model) updatePanelSizes() {
+	// Linear mode shows one panel at a time across the whole screen
+	if m.linear {
+		m.logPanel.SetSize(m.width, m.height)
+		m.filesPanel.SetSize(m.width, m.height)
+		m.bookmarksPanel.SetSize(m.width, m.height)
+		m.stacksPanel.SetSize(m.width, m.height)
+		m.diffPanel.SetSize(m.width, m.height)
+		m.compareLogPanel.SetSize(m.width, m.height)
+		m.opLogPanel.SetSize(m.width, m.height)
+
+		return
+	}
+
+	if m.stacked() {
+		heights := m.stackedHeights()
+
+		m.logPanel.SetSize(m.width, heights.log)
+		m.filesPanel.SetSize(m.width, heights.log)
+		m.bookmarksPanel.SetSize(m.width, heights.log)
+		m.stacksPanel.SetSize(m.width, heights.log)
+		m.diffPanel.SetSize(m.width, heights.diff)
+		m.compareLogPanel.SetSize(m.width, heights.diff)
+		m.opLogPanel.SetSize(m.width, heights.opLog)
+
+		return
+	}
+
 	// Leave room for status bar
 	contentHeight := m.height - statusBarHeight
 
-	// Split horizontally: left panel ~40%, right panel ~60%
-	leftWidth := m.width * leftPanelWidthPct / percentDivisor
+	// Split horizontally at the divider (40/60 to start)
+	leftWidth := m.leftWidth()
 	rightWidth := m.width - leftWidth
 
 	// Left pane splits vertically: log 50%, op log 50%
@@ -1216,7 +2892,10 @@ func (m *Model) updatePanelSizes() {
 	m.logPanel.SetSize(leftWidth, leftTopHeight)
 	m.opLogPanel.SetSize(leftWidth, leftBottomHeight)
 	m.filesPanel.SetSize(leftWidth, leftTopHeight) // Files panel uses same size as log
+	m.bookmarksPanel.SetSize(leftWidth, leftTopHeight)
+	m.stacksPanel.SetSize(leftWidth, leftTopHeight)
 	m.diffPanel.SetSize(rightWidth, contentHeight)
+	m.compareLogPanel.SetSize(rightWidth, contentHeight)
 }
 
 // waitForChange waits for file system changes.
@@ -1225,22 +2904,68 @@ func (m *Model) waitForChange() tea.Cmd {
 		return nil
 	}
 
+	watcher := m.watcher
+
 	return func() tea.Msg {
-		<-m.watcher.Events() // Block until valid event
+		// Block until valid event; a watcher closed on switching workspace
+		// has nothing more to say
+		if _, ok := <-watcher.Events(); !ok {
+			return nil
+		}
+
 		return jj.WatcherMsg{}
 	}
 }
 
+// waitForNotice waits for the runner to report something it did on its own.
+func (m *Model) waitForNotice() tea.Cmd {
+	notices := m.runner.Notices()
+
+	return func() tea.Msg {
+		return runnerNoticeMsg{text: <-notices}
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Message handlers
 // ---------------------------------------------------------------------------
 
 func (m *Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Without a usable jj there is nothing to do but quit
+	if m.jjProblem != "" {
+		return m, m.handleQuitOnlyKey(msg)
+	}
+
+	// Keys would act on panels that cannot be seen
+	if m.tooSmall() {
+		return m, m.handleQuitOnlyKey(msg)
+	}
+
+	// When a confirmation is open, it takes all input
+	if m.confirming {
+		return m, m.confirmDialog.Update(msg)
+	}
+
+	// Likewise the error panel
+	if m.showingError {
+		return m, m.errorPanel.Update(msg)
+	}
+
 	// When edit mode is active, forward to describe input
 	if m.editMode {
 		return m, m.describeInput.Update(msg)
 	}
 
+	// When the finder is open, it takes all input
+	if m.finding {
+		return m, m.finder.Update(msg)
+	}
+
+	// So does the command prompt
+	if m.commanding {
+		return m, m.commandLine.Update(msg)
+	}
+
 	// When help modal is open, only handle ?, esc, and q
 	if m.showHelp {
 		if msg.String() == "?" || msg.String() == "esc" {
@@ -1259,8 +2984,28 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// The second key of a diff fold command (e.g. the "a" of "za"), the search
+	// prompt's input, and n/N between matches go to the diff panel rather than
+	// a global binding
+	if m.focusedPane == PaneDiff && !m.comparing && m.diffPanel.CapturesKey(msg) {
+		return m, m.diffPanel.Update(msg)
+	}
+
+	// Likewise the log search prompt's input and n/N between matching changes,
+	// through the focused panel so the diff follows the selection
+	if m.focusedPane == PaneLog && m.viewMode == ViewLog && m.logPanel.CapturesKey(msg) {
+		return m, m.updateFocusedPanel(msg)
+	}
+
+	// Digits after a count prefix's first go on building it (5j, 12j), and
+	// the second key of gg or zz finishes it
+	if m.continuesKeys(msg.String()) {
+		return m, m.updateFocusedPanel(msg)
+	}
+
 	// Try active bindings first
 	if newModel, cmd := dispatchKey(m, msg, m.activeBindings()); newModel != nil {
+		newModel.resetCounts()
 		return newModel, cmd
 	}
 
@@ -1269,45 +3014,141 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) handleLogLoaded(msg logLoadedMsg) tea.Cmd {
+	// A log read before travelling to or from an operation is out of date
+	if msg.atOp != m.timeTravelOp {
+		return nil
+	}
+
 	m.changes = msg.changes
+	m.logMore = msg.limit > 0 && len(msg.changes) >= msg.limit
+	m.logLoadingMore = false
 	m.logPanel.SetContent(msg.raw, msg.changes)
 
+	if m.restoreChangeID != "" {
+		m.logPanel.SelectChangeID(m.restoreChangeID)
+		m.restoreChangeID = ""
+	}
+
+	if m.followWorkingCopy {
+		m.selectWorkingCopy()
+	}
+
+	jumpCmd := m.continueJump()
+
+	if m.comparing {
+		m.compareLogPanel.SetGone(goneChanges(m.compareChanges, m.changes))
+	}
+
+	statsCmd := tea.Batch(m.loadVisibleStats(), jumpCmd, m.loadPullRequests(logBookmarks(m.changes)))
+
 	// Only load diff if we're in log view AND log panel is focused
 	if m.viewMode == ViewLog && m.focusedPane == PaneLog {
 		if selected := m.logPanel.SelectedChange(); selected != nil {
-			return m.loadDiff(selected.ChangeID)
+			return tea.Batch(m.loadLogDiff(selected.ChangeID), statsCmd)
 		}
 	}
 
-	return nil
+	return statsCmd
+}
+
+func (m *Model) handleBookmarksLoaded(msg bookmarksLoadedMsg) tea.Cmd {
+	m.bookmarksPanel.SetBookmarks(msg.bookmarks)
+
+	names := make([]string, len(msg.bookmarks))
+	for i, bookmark := range msg.bookmarks {
+		names[i] = bookmark.Name
+	}
+
+	prsCmd := m.loadPullRequests(names)
+
+	if m.viewMode == ViewBookmarks && m.focusedPane == PaneLog {
+		return tea.Batch(m.loadSelectedDiff(), prsCmd)
+	}
+
+	return prsCmd
+}
+
+// handleBookmarksPushed shows each bookmark's outcome inline and summarizes
+// the push in a toast; failures persist like any other error toast.
+func (m *Model) handleBookmarksPushed(msg bookmarksPushedMsg) tea.Cmd {
+	m.bookmarksPanel.SetPushResults(msg.results)
+
+	var failed []string
+
+	for name, result := range msg.results {
+		if !result.OK {
+			failed = append(failed, name)
+		}
+	}
+
+	reload := tea.Batch(m.loadBookmarks(), m.reloadAfterMutation())
+
+	if len(failed) > 0 {
+		slices.Sort(failed)
+		return tea.Batch(m.toasts.Error("push failed for "+strings.Join(failed, ", ")), reload)
+	}
+
+	return tea.Batch(m.toasts.Success(fmt.Sprintf("pushed %d bookmark(s)", len(msg.results))), reload)
 }
 
 func (m *Model) handleDiffLoaded(msg diffLoadedMsg) {
+	if !m.diffRequests.current(msg.generation) {
+		return
+	}
+
 	m.currentDiff = msg.diffOutput
+	m.diffPanel.SetTitle("Diff")
 	m.diffPanel.SetDiff(msg.diffOutput)
 }
 
 func (m *Model) handleFilesLoaded(msg filesLoadedMsg) tea.Cmd {
+	return m.showFiles(msg, "")
+}
+
+// showFiles fills the files view and loads the diff of the file at path,
+// or of the first file when path is empty or not listed.
+func (m *Model) showFiles(msg filesLoadedMsg, path string) tea.Cmd {
 	m.filesPanel.SetFiles(msg.changeID, msg.shortCode, msg.files)
+	m.filesPanel.SetStats(msg.stats)
+
 	m.currentDiff = msg.diffOutput
 
-	// Load evolog for this change (shows operations that affected it)
-	cmds := []tea.Cmd{m.loadEvoLog(msg.changeID, msg.shortCode)}
+	var cmds []tea.Cmd
+
+	// Load evolog for this change (shows operations that affected it);
+	// older jj keeps the op log there instead
+	if m.runner.Supports(jj.FeatureEvologOperations) {
+		cmds = append(cmds, m.loadEvoLog(msg.changeID, msg.shortCode))
+	}
+
+	if path != "" {
+		m.filesPanel.SelectPath(path)
+	}
 
-	// If there are files, show diff for the first one
-	if len(msg.files) > 0 {
-		cmds = append(cmds, m.loadFileDiff(msg.changeID, msg.files[0].Path))
+	if file := m.filesPanel.SelectedFile(); file != nil {
+		cmds = append(cmds, m.loadFileDiff(msg.changeID, file.Path))
 	}
 
 	return tea.Batch(cmds...)
 }
 
 func (m *Model) handleFileDiffLoaded(msg fileDiffLoadedMsg) {
-	m.diffPanel.SetTitle("Patch")
-	m.diffPanel.SetDiff(msg.diffOutput)
+	if !m.diffRequests.current(msg.generation) {
+		return
+	}
+
+	if msg.contentPath != "" {
+		m.diffPanel.SetTitle("Content")
+	} else {
+		m.diffPanel.SetTitle("Patch")
+	}
+
+	m.diffPanel.SetFileContent(msg.contentPath, msg.diffOutput)
 }
 
 func (m *Model) handleOpLogLoaded(msg opLogLoadedMsg) tea.Cmd {
+	m.opLogMore = msg.limit > 0 && len(msg.operations) >= msg.limit
+	m.opLogLoadingMore = false
 	m.opLogPanel.SetOpLogContent(msg.raw, msg.operations)
 
 	// If op log panel is focused, load op show for selected operation
@@ -1334,7 +3175,11 @@ func (m *Model) handleEvoLogLoaded(msg evoLogLoadedMsg) tea.Cmd {
 }
 
 func (m *Model) handleOpShowLoaded(msg opShowLoadedMsg) {
-	m.diffPanel.SetTitle("Operation")
+	if !m.diffRequests.current(msg.generation) {
+		return
+	}
+
+	m.diffPanel.SetTitle(msg.detail.title())
 	m.diffPanel.SetDiff(msg.output)
 }
 
@@ -1350,6 +3195,13 @@ func (m *Model) handleWatcherStarted(msg watcherStartedMsg) tea.Cmd {
 	return nil
 }
 
+// handleRunnerNotice shows what the runner did and waits for the next notice.
+func (m *Model) handleRunnerNotice(msg runnerNoticeMsg) tea.Cmd {
+	m.log.Info("runner notice", "text", msg.text)
+
+	return tea.Batch(m.toasts.Info(msg.text), m.waitForNotice())
+}
+
 func (m *Model) handleWatcherEvent(_ jj.WatcherMsg) tea.Cmd {
 	// Coalesce: schedule a single flush after a short delay.
 	// Do NOT refresh or re-arm waitForChange here.
@@ -1357,9 +3209,14 @@ func (m *Model) handleWatcherEvent(_ jj.WatcherMsg) tea.Cmd {
 		return nil
 	}
 
+	// While paused, just wait for the next change
+	if m.refreshPaused {
+		return m.waitForChange()
+	}
+
 	m.watcherPending = true
 
-	return tea.Tick(watcherDebounceDelay, func(time.Time) tea.Msg {
+	return tea.Tick(m.watchDebounce, func(time.Time) tea.Msg {
 		return watcherFlushMsg{}
 	})
 }
@@ -1368,7 +3225,58 @@ func (m *Model) handleWatcherFlush(_ watcherFlushMsg) tea.Cmd {
 	// One refresh per burst, then re-arm the watcher.
 	m.watcherPending = false
 
-	cmds := []tea.Cmd{m.loadLog(), m.loadOpLog(), m.waitForChange()}
+	if m.refreshPaused {
+		return m.waitForChange()
+	}
+
+	return tea.Batch(m.checkOpHeads(), m.waitForChange())
+}
+
+// checkOpHeads reads the op heads to see whether a burst of file-system
+// events came from a jj command or only from edits to working-copy files.
+func (m *Model) checkOpHeads() tea.Cmd {
+	workDir := m.workDir
+
+	return func() tea.Msg {
+		return watcherCheckedMsg{opHeads: jj.OpHeads(workDir)}
+	}
+}
+
+// recordOpHeads notes the op heads the views are about to be loaded at.
+func (m *Model) recordOpHeads() tea.Cmd {
+	workDir := m.workDir
+
+	return func() tea.Msg {
+		return opHeadsMsg{opHeads: jj.OpHeads(workDir)}
+	}
+}
+
+// handleWatcherChecked reloads everything after a jj operation, or when the
+// op heads can't be read. When only working-copy files changed, the log and
+// op log are as they were, so only the views showing @ are reloaded.
+func (m *Model) handleWatcherChecked(msg watcherCheckedMsg) tea.Cmd {
+	if msg.opHeads == "" || msg.opHeads != m.opHeads {
+		return m.refreshViews()
+	}
+
+	return m.refreshWorkingCopy()
+}
+
+// refreshViews reloads the log and op log, and whatever the open view shows.
+func (m *Model) refreshViews() tea.Cmd {
+	cmds := []tea.Cmd{m.refresh()}
+
+	if m.viewMode == ViewBookmarks {
+		cmds = append(cmds, m.loadBookmarks())
+	}
+
+	if m.viewMode == ViewStacks {
+		cmds = append(cmds, m.loadStacks())
+	}
+
+	if m.showingStatus() {
+		cmds = append(cmds, m.loadStatus())
+	}
 
 	// If drilled into files view, reload file list and current diff
 	if m.viewMode == ViewFiles {
@@ -1384,20 +3292,231 @@ func (m *Model) handleWatcherFlush(_ watcherFlushMsg) tea.Cmd {
 	return tea.Batch(cmds...)
 }
 
-func (m *Model) handleErr(msg errMsg) {
+// refreshWorkingCopy records edits to working-copy files into @ and reloads
+// only what shows them: the diff of @ when it is selected, the files of @
+// when drilled into, and the status. Read-only mode doesn't record the edits,
+// so there is nothing new to show.
+func (m *Model) refreshWorkingCopy() tea.Cmd {
+	if m.readOnly {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+
+	if m.showingStatus() {
+		cmds = append(cmds, m.loadStatus())
+	}
+
+	switch m.viewMode {
+	case ViewLog:
+		if selected := m.logPanel.SelectedChange(); selected != nil && m.showsWorkingCopy(selected.ChangeID) {
+			cmds = append(cmds, m.loadLogDiff(selected.ChangeID))
+		}
+	case ViewFiles:
+		if change := m.filesPanel.ChangeID(); m.isWorkingCopy(change) {
+			cmds = append(cmds, m.loadFiles(change))
+
+			if file := m.filesPanel.SelectedFile(); file != nil {
+				cmds = append(cmds, m.loadFileDiff(change, file.Path))
+			}
+		}
+	}
+
+	// The snapshot records an operation of its own; noting the heads after it
+	// keeps the watcher event it causes from reloading everything.
+	return tea.Sequence(m.snapshotWorkingCopy(), tea.Batch(append(cmds, m.recordOpHeads())...))
+}
+
+// showsWorkingCopy reports whether the log diff of changeID involves @: it
+// is @, or it is one end of a compared range that @ is the other end of.
+func (m *Model) showsWorkingCopy(changeID string) bool {
+	if from, to, ok := m.logPanel.ComparedRange(); ok {
+		return m.isWorkingCopy(from.ChangeID) || m.isWorkingCopy(to.ChangeID)
+	}
+
+	return m.isWorkingCopy(changeID)
+}
+
+// isWorkingCopy reports whether changeID is @ in the loaded log.
+func (m *Model) isWorkingCopy(changeID string) bool {
+	change, ok := m.loadedChange(changeID)
+	return ok && change.IsWorkingCopy
+}
+
+// loadedChange finds changeID in the loaded log.
+func (m *Model) loadedChange(changeID string) (jj.Change, bool) {
+	for _, change := range m.changes {
+		if change.ChangeID == changeID {
+			return change, true
+		}
+	}
+
+	return jj.Change{}, false
+}
+
+func (m *Model) handleErr(msg errMsg) tea.Cmd {
 	m.log.Error("app error", "err", msg.err)
-	m.lastError = msg.err.Error()
+	m.lastError = &failure{err: msg.err, job: m.failedJob}
+	m.failedJob = nil
+
+	// The jj problem screen already explains why commands fail
+	if m.jjProblem != "" {
+		return nil
+	}
+
+	return m.toasts.Error(msg.err.Error())
 }
 
 func (m *Model) handleDescribeSubmit(msg ui.DescribeSubmitMsg) tea.Cmd {
+	if m.describeBatch != nil {
+		return m.continueDescribeBatch(msg)
+	}
+
 	m.editMode = false
 
-	return m.runDescribe(msg.ChangeID, msg.Description)
+	return m.guardWorkingCopy(guardedMutation{
+		rev:               msg.ChangeID,
+		followWorkingCopy: true,
+		run: func(rev string) tea.Cmd {
+			if msg.Commit {
+				return m.runCommit(rev, msg.Description)
+			}
+
+			return m.runDescribe(rev, msg.Description)
+		},
+	})
+}
+
+// askConfirm opens the confirmation dialog; the follow-ups run once the user answers.
+func (m *Model) askConfirm(title, body, yesHint, noHint string, pending confirmation) {
+	m.confirmDialog.SetPrompt(title, body, yesHint, noHint)
+	m.pendingConfirm = pending
+	m.confirming = true
+}
+
+func (m *Model) handleConfirm(msg ui.ConfirmMsg) tea.Cmd {
+	pending := m.pendingConfirm
+	m.pendingConfirm = confirmation{}
+	m.confirming = false
+	m.diffPanel.ClosePreview()
+
+	switch msg.Choice {
+	case ui.ConfirmYes:
+		if pending.onYes != nil {
+			return pending.onYes()
+		}
+	case ui.ConfirmNo:
+		if pending.onNo != nil {
+			return pending.onNo()
+		}
+	case ui.ConfirmCancel:
+	}
+
+	return nil
+}
+
+// guardWorkingCopy checks @ before running a mutation. Every jj command
+// snapshots pending edits into @, so if @ is immutable or already pushed the
+// user is offered a fresh change on top instead of amending published work.
+func (m *Model) guardWorkingCopy(mutation guardedMutation) tea.Cmd {
+	runner := m.runner
+
+	return func() tea.Msg {
+		state, err := runner.WorkingCopyState()
+		if err != nil {
+			// Fail open: the guard is advisory and jj will report real problems.
+			m.log.Warn("working copy guard check failed", "err", err)
+		}
+
+		return workingCopyCheckedMsg{state: state, mutation: mutation}
+	}
+}
+
+func (m *Model) handleWorkingCopyChecked(msg workingCopyCheckedMsg) tea.Cmd {
+	mutation := msg.mutation
+	state := msg.state
+
+	if !state.Published() || m.guardDeclined[state.CommitID] {
+		return mutation.run(mutation.rev)
+	}
+
+	target := mutation.rev
+	if mutation.followWorkingCopy && sameChange(mutation.rev, state.ChangeID) {
+		target = "@"
+	}
+
+	reason := "is immutable"
+	if !state.Immutable {
+		reason = "is already pushed (" + strings.Join(state.RemoteBookmarks, ", ") + ")"
+	}
+
+	m.askConfirm(
+		"Working copy "+reason,
+		"The working copy "+state.ChangeID+" "+reason+
+			". Running jj now would snapshot your edits into it. Create a new change on top first?",
+		"new change on top",
+		"continue anyway",
+		confirmation{
+			onYes: func() tea.Cmd {
+				return tea.Sequence(m.runNew(), mutation.run(target))
+			},
+			onNo: func() tea.Cmd {
+				m.guardDeclined[state.CommitID] = true
+				return mutation.run(mutation.rev)
+			},
+		},
+	)
+
+	return nil
+}
+
+// refuseImmutable explains why an action on an immutable change was not run,
+// instead of letting jj fail with a raw error.
+func (m *Model) refuseImmutable(action, changeID string) tea.Cmd {
+	return m.toasts.Info("cannot " + action + " " + changeID + ": change is immutable")
+}
+
+// sameChange reports whether two change ID prefixes refer to the same change.
+func sameChange(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+
+	return strings.HasPrefix(a, b) || strings.HasPrefix(b, a)
+}
+
+// completeMutation confirms a finished jj command with a toast and reloads.
+func (m *Model) completeMutation(summary string) tea.Cmd {
+	return tea.Batch(m.toasts.Success(summary), m.reloadAfterMutation())
+}
+
+// refresh records edits to the working copy, then reloads the log and op log.
+// The loads themselves never snapshot, so browsing leaves the op log alone.
+func (m *Model) refresh() tea.Cmd {
+	return tea.Sequence(m.snapshotWorkingCopy(), tea.Batch(m.recordOpHeads(), m.loadLog(), m.loadOpLog()))
+}
+
+// snapshotWorkingCopy records edits to the working copy into @. Read-only
+// mode leaves the repo untouched and shows @ as last recorded.
+func (m *Model) snapshotWorkingCopy() tea.Cmd {
+	if m.readOnly {
+		return nil
+	}
+
+	runner := m.runner
+
+	return func() tea.Msg {
+		if err := runner.Snapshot(); err != nil {
+			return errMsg{err}
+		}
+
+		return nil
+	}
 }
 
 // reloadAfterMutation reloads the log and op log after a state-changing jj command.
 func (m *Model) reloadAfterMutation() tea.Cmd {
-	return tea.Batch(m.loadLog(), m.loadOpLog())
+	return tea.Batch(m.recordOpHeads(), m.loadLog(), m.loadOpLog())
 }
 
 func (m *Model) handleBorderAnimTick(msg borderAnimTickMsg) tea.Cmd {
@@ -0,0 +1,487 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/config"
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/logger"
+	"github.com/chatter/chado/internal/ui"
+)
+
+// newTestModel creates a model rooted in a temp dir with a no-op logger.
+func newTestModel(t *testing.T) *Model {
+	t.Helper()
+
+	log, _ := logger.New("")
+	m := New(context.Background(), t.TempDir(), "test", config.Default(), log)
+
+	return &m
+}
+
+// recordingMutation returns a guarded mutation that records the revision it ran against.
+func recordingMutation(rev string, follow bool, ran *[]string) guardedMutation {
+	return guardedMutation{
+		rev:               rev,
+		followWorkingCopy: follow,
+		run: func(rev string) tea.Cmd {
+			*ran = append(*ran, rev)
+			return nil
+		},
+	}
+}
+
+// =============================================================================
+// Working Copy Guard Tests
+// =============================================================================
+
+func TestWorkingCopyGuard_UnpublishedRunsImmediately(t *testing.T) {
+	m := newTestModel(t)
+
+	var ran []string
+
+	m.handleWorkingCopyChecked(workingCopyCheckedMsg{
+		state:    jj.WorkingCopyState{ChangeID: "xsssnyux", CommitID: "abc"},
+		mutation: recordingMutation("xsssnyux", false, &ran),
+	})
+
+	if m.confirming {
+		t.Error("unpublished working copy should not prompt")
+	}
+
+	if len(ran) != 1 || ran[0] != "xsssnyux" {
+		t.Errorf("mutation should run against original rev, got %v", ran)
+	}
+}
+
+func TestWorkingCopyGuard_PublishedPrompts(t *testing.T) {
+	m := newTestModel(t)
+
+	var ran []string
+
+	m.handleWorkingCopyChecked(workingCopyCheckedMsg{
+		state:    jj.WorkingCopyState{ChangeID: "xsssnyux", CommitID: "abc", RemoteBookmarks: []string{"main@origin"}},
+		mutation: recordingMutation("xsssnyux", true, &ran),
+	})
+
+	if !m.confirming {
+		t.Fatal("published working copy should prompt")
+	}
+
+	if len(ran) != 0 {
+		t.Fatalf("mutation should be held back until confirmed, got %v", ran)
+	}
+
+	m.handleConfirm(ui.ConfirmMsg{Choice: ui.ConfirmYes})
+
+	if len(ran) != 1 || ran[0] != "@" {
+		t.Errorf("describe of the old working copy should follow to the new @, got %v", ran)
+	}
+}
+
+func TestWorkingCopyGuard_DeclineRemembered(t *testing.T) {
+	m := newTestModel(t)
+	state := jj.WorkingCopyState{ChangeID: "xsssnyux", CommitID: "abc", Immutable: true}
+
+	var ran []string
+
+	m.handleWorkingCopyChecked(workingCopyCheckedMsg{state: state, mutation: recordingMutation("qpvuntsm", false, &ran)})
+	m.handleConfirm(ui.ConfirmMsg{Choice: ui.ConfirmNo})
+
+	if len(ran) != 1 || ran[0] != "qpvuntsm" {
+		t.Fatalf("declining should run the original mutation, got %v", ran)
+	}
+
+	m.handleWorkingCopyChecked(workingCopyCheckedMsg{state: state, mutation: recordingMutation("qpvuntsm", false, &ran)})
+
+	if m.confirming {
+		t.Error("a declined commit should not prompt again")
+	}
+}
+
+func TestWorkingCopyGuard_CancelDropsMutation(t *testing.T) {
+	m := newTestModel(t)
+
+	var ran []string
+
+	m.handleWorkingCopyChecked(workingCopyCheckedMsg{
+		state:    jj.WorkingCopyState{ChangeID: "xsssnyux", CommitID: "abc", Immutable: true},
+		mutation: recordingMutation("xsssnyux", false, &ran),
+	})
+	m.handleConfirm(ui.ConfirmMsg{Choice: ui.ConfirmCancel})
+
+	if m.confirming || len(ran) != 0 {
+		t.Errorf("cancel should close the dialog without running, ran %v", ran)
+	}
+}
+
+// =============================================================================
+// Immutable Guard Tests
+// =============================================================================
+
+func TestRunnerNotice_ToastsAndKeepsWaiting(t *testing.T) {
+	m := newTestModel(t)
+
+	if cmd := m.handleRunnerNotice(runnerNoticeMsg{text: "working copy was stale"}); cmd == nil {
+		t.Error("expected to keep waiting for notices")
+	}
+
+	items := m.toasts.Items()
+	if len(items) != 1 || items[0].Text != "working copy was stale" {
+		t.Errorf("expected the notice as a toast, got %+v", items)
+	}
+}
+
+func TestActions_RefuseImmutableChange(t *testing.T) {
+	m := newTestModel(t)
+	m.logPanel.SetContent("◆ zzzzzzzz root() 00000000\n", []jj.Change{
+		{ChangeID: "zzzzzzzz", Raw: "◆ zzzzzzzz root() 00000000", IsImmutable: true},
+	})
+
+	for _, action := range []Action{(*Model).actionAbandon, (*Model).actionEdit, (*Model).actionSquash, (*Model).actionDescribe} {
+		action(m)
+	}
+
+	if m.editMode {
+		t.Error("describe overlay should not open for an immutable change")
+	}
+
+	items := m.toasts.Items()
+	if len(items) != 4 {
+		t.Fatalf("expected one explanatory toast per refused action, got %d", len(items))
+	}
+
+	for _, toast := range items {
+		if !strings.Contains(toast.Text, "immutable") {
+			t.Errorf("toast should explain the refusal: %q", toast.Text)
+		}
+	}
+}
+
+// =============================================================================
+// Preview Tests
+// =============================================================================
+
+func TestPreview_ShowsEffectsAndConfirms(t *testing.T) {
+	m := newTestModel(t)
+	m.diffPanel.SetDiff("selected diff")
+
+	ran := false
+	mutation := previewedMutation{
+		title:   "Push xsssnyux?",
+		label:   "jj git push -r xsssnyux",
+		yesHint: "push",
+		run: func() tea.Cmd {
+			ran = true
+			return nil
+		},
+	}
+
+	m.handlePreviewLoaded(previewLoadedMsg{mutation: mutation, output: "Would push bookmark main", dryRun: true})
+
+	if !m.confirming || !m.diffPanel.Previewing() {
+		t.Fatal("preview should be shown with a confirmation dialog")
+	}
+
+	m.handleConfirm(ui.ConfirmMsg{Choice: ui.ConfirmYes})
+
+	if !ran {
+		t.Error("confirming should run the mutation")
+	}
+
+	if m.diffPanel.Previewing() {
+		t.Error("answering the dialog should close the preview")
+	}
+}
+
+func TestPreview_CancelDoesNotRun(t *testing.T) {
+	m := newTestModel(t)
+
+	ran := false
+	mutation := previewedMutation{run: func() tea.Cmd {
+		ran = true
+		return nil
+	}}
+
+	m.handlePreviewLoaded(previewLoadedMsg{mutation: mutation, output: "effects"})
+	m.handleConfirm(ui.ConfirmMsg{Choice: ui.ConfirmCancel})
+
+	if ran || m.diffPanel.Previewing() {
+		t.Error("cancel should close the preview without running")
+	}
+}
+
+func TestPreview_FallsBackWhenDryRunUnsupported(t *testing.T) {
+	m := newTestModel(t)
+
+	mutation := previewedMutation{
+		dryRun: func() (string, error) {
+			return "", jj.ErrDryRunUnsupported
+		},
+		fallback: func() (string, error) {
+			return "descendants", nil
+		},
+	}
+
+	msg, ok := m.previewMutation(mutation)().(previewLoadedMsg)
+	if !ok {
+		t.Fatal("expected previewLoadedMsg")
+	}
+
+	if msg.dryRun || msg.output != "descendants" {
+		t.Errorf("expected fallback output, got %+v", msg)
+	}
+}
+
+func TestPreview_DryRunErrorSurfaces(t *testing.T) {
+	m := newTestModel(t)
+
+	mutation := previewedMutation{
+		dryRun: func() (string, error) {
+			return "", &jj.Error{Command: "git push", Stderr: "no remote"}
+		},
+	}
+
+	if _, ok := m.previewMutation(mutation)().(errMsg); !ok {
+		t.Error("a failing dry run should surface as an error")
+	}
+}
+
+// =============================================================================
+// Bookmarks Tests
+// =============================================================================
+
+func TestBookmarks_ToggleView(t *testing.T) {
+	m := newTestModel(t)
+
+	m.actionBookmarks()
+
+	if m.viewMode != ViewBookmarks || m.focusedPane != PaneLog {
+		t.Fatalf("expected focused bookmarks view, got mode %v pane %v", m.viewMode, m.focusedPane)
+	}
+
+	m.actionBookmarks()
+
+	if m.viewMode != ViewLog {
+		t.Error("pressing bookmarks again should return to the log")
+	}
+}
+
+func TestBookmarks_PushResultsMergedIntoToast(t *testing.T) {
+	m := newTestModel(t)
+	m.actionBookmarks()
+	m.handleBookmarksLoaded(bookmarksLoadedMsg{bookmarks: []jj.Bookmark{{Name: "main"}, {Name: "feature"}}})
+
+	m.handleBookmarksPushed(bookmarksPushedMsg{results: map[string]jj.PushResult{
+		"main":    {OK: true, Status: "add"},
+		"feature": {Status: "rejected"},
+	}})
+
+	if !m.toasts.HasErrors() {
+		t.Fatal("a partially failed push should leave an error toast")
+	}
+
+	items := m.toasts.Items()
+	if !strings.Contains(items[len(items)-1].Text, "feature") {
+		t.Errorf("error toast should name the failed bookmark: %q", items[len(items)-1].Text)
+	}
+}
+
+func TestBookmarks_EnterGoesToChange(t *testing.T) {
+	m := newTestModel(t)
+	m.changes = []jj.Change{{ChangeID: "aaaaaaaa"}, {ChangeID: "bbbbbbbb", Bookmarks: []string{"main"}}}
+	m.logPanel.SetContent("@ aaaaaaaa\n○ bbbbbbbb\n", m.changes)
+	m.actionBookmarks()
+	m.handleBookmarksLoaded(bookmarksLoadedMsg{bookmarks: []jj.Bookmark{{Name: "main", ChangeID: "bbbbbbbb"}}})
+
+	m.handleEnter()
+
+	if m.viewMode != ViewLog {
+		t.Fatal("enter on a bookmark should go back to the log")
+	}
+
+	if selected := m.logPanel.SelectedChange(); selected == nil || selected.ChangeID != "bbbbbbbb" {
+		t.Errorf("expected main's change selected, got %+v", selected)
+	}
+}
+
+func TestBookmarks_ChangeNotInLog(t *testing.T) {
+	m := newTestModel(t)
+	m.actionBookmarks()
+	m.handleBookmarksLoaded(bookmarksLoadedMsg{bookmarks: []jj.Bookmark{{Name: "old", ChangeID: "zzzzzzzz"}}})
+
+	m.handleEnter()
+
+	if m.viewMode != ViewBookmarks || len(m.toasts.Items()) != 1 {
+		t.Error("a change outside the log should be reported, staying on the bookmarks")
+	}
+}
+
+func TestCenteredOrigin_StaysOnScreen(t *testing.T) {
+	if x, y := centeredOrigin(100, 40, 60, 10); x != 20 || y != 15 {
+		t.Errorf("expected centered origin (20, 15), got (%d, %d)", x, y)
+	}
+
+	if x, y := centeredOrigin(30, 8, 60, 10); x != 0 || y != 0 {
+		t.Errorf("oversized overlay should start at the top-left, got (%d, %d)", x, y)
+	}
+}
+
+// =============================================================================
+// Diff Fold Tests
+// =============================================================================
+
+func TestDiffFold_KeysBypassGlobalBindings(t *testing.T) {
+	m := newTestModel(t)
+	m.diffPanel.SetSize(80, 20)
+	m.diffPanel.SetDiff("Added regular file a.go:\n        1: package a\n        2:")
+	m.focusedPane = PaneDiff
+	m.updatePanelFocus()
+
+	m.handleKeyMsg(tea.KeyPressMsg(tea.Key{Code: 'z', Text: "z"}))
+	m.handleKeyMsg(tea.KeyPressMsg(tea.Key{Code: 'a', Text: "a"}))
+
+	if m.confirming {
+		t.Error("the a of za should not reach the abandon binding")
+	}
+
+	if m.diffPanel.KeyPending() {
+		t.Error("the a of za should complete the fold command")
+	}
+}
+
+func TestDiffSearch_KeysBypassGlobalBindings(t *testing.T) {
+	m := newTestModel(t)
+	m.diffPanel.SetSize(80, 20)
+	m.diffPanel.SetDiff("Added regular file a.go:\n        1: package a\n        2: package b")
+	m.focusedPane = PaneDiff
+	m.updatePanelFocus()
+
+	for _, r := range "/package" {
+		m.handleKeyMsg(tea.KeyPressMsg(tea.Key{Code: r, Text: string(r)}))
+	}
+
+	m.handleKeyMsg(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	m.handleKeyMsg(tea.KeyPressMsg(tea.Key{Code: 'n', Text: "n"}))
+
+	if current, total := m.diffPanel.SearchMatches(); current != 2 || total != 2 {
+		t.Errorf("n should move to the second match, got %d/%d", current, total)
+	}
+
+	if m.confirming || m.diffPanel.Searching() {
+		t.Error("the query and n should not reach global bindings")
+	}
+}
+
+func TestLogSearch_KeysBypassGlobalBindings(t *testing.T) {
+	m := newTestModel(t)
+	m.logPanel.SetContent("@ aaaaaaaa\n○ bbbbbbbb\n○ cccccccc\n", []jj.Change{
+		{ChangeID: "aaaaaaaa", Description: "one"},
+		{ChangeID: "bbbbbbbb", Description: "new parser"},
+		{ChangeID: "cccccccc", Description: "new lexer"},
+	})
+
+	for _, r := range "/new" {
+		m.handleKeyMsg(tea.KeyPressMsg(tea.Key{Code: r, Text: string(r)}))
+	}
+
+	m.handleKeyMsg(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	m.handleKeyMsg(tea.KeyPressMsg(tea.Key{Code: 'n', Text: "n"}))
+
+	if got := m.logPanel.SelectedChange().ChangeID; got != "cccccccc" {
+		t.Errorf("n should select the next matching change, got %s", got)
+	}
+
+	if m.logPanel.Searching() {
+		t.Error("enter should close the prompt")
+	}
+}
+
+// =============================================================================
+// Watcher Refresh Tests
+// =============================================================================
+
+func TestWatcherChecked_NewOperationReloadsEverything(t *testing.T) {
+	m := newTestModel(t)
+	m.readOnly = true
+	m.Update(opHeadsMsg{opHeads: "a1"})
+
+	if m.opHeads != "a1" {
+		t.Fatalf("op heads should be recorded, got %q", m.opHeads)
+	}
+
+	if m.handleWatcherChecked(watcherCheckedMsg{opHeads: "b2"}) == nil {
+		t.Error("a new operation should reload the log")
+	}
+
+	if m.handleWatcherChecked(watcherCheckedMsg{}) == nil {
+		t.Error("unreadable op heads should reload the log")
+	}
+}
+
+func TestWatcherChecked_FileEditsSkipTheLog(t *testing.T) {
+	m := newTestModel(t)
+	m.readOnly = true
+	m.opHeads = "a1"
+
+	// Read-only mode records no edits, so with the log left alone there is
+	// nothing to reload
+	if cmd := m.handleWatcherChecked(watcherCheckedMsg{opHeads: "a1"}); cmd != nil {
+		t.Error("edits to working-copy files alone should not reload the log")
+	}
+}
+
+func TestIsWorkingCopy(t *testing.T) {
+	m := newTestModel(t)
+	m.changes = []jj.Change{
+		{ChangeID: "aaaaaaaa", IsWorkingCopy: true},
+		{ChangeID: "bbbbbbbb"},
+	}
+
+	if !m.isWorkingCopy("aaaaaaaa") {
+		t.Error("aaaaaaaa is @")
+	}
+
+	if m.isWorkingCopy("bbbbbbbb") || m.isWorkingCopy("zzzzzzzz") {
+		t.Error("only @ is the working copy")
+	}
+}
+
+// =============================================================================
+// Reduced Motion Tests
+// =============================================================================
+
+func TestReducedMotion_FocusBorderStatic(t *testing.T) {
+	cfg := config.Default()
+	cfg.ReducedMotion = true
+
+	log, _ := logger.New("")
+	m := New(context.Background(), t.TempDir(), "test", cfg, log)
+
+	if cmd := m.startLogPanelBorderAnim(); cmd != nil {
+		t.Error("reduced motion should not tick the border animation")
+	}
+
+	if m.logPanelBorderPhase != 1 {
+		t.Errorf("the border should be complete at once, got phase %v", m.logPanelBorderPhase)
+	}
+
+	m.jobs.start("jj new", time.Now())
+
+	if got := m.jobs.status(time.Now()); !strings.HasPrefix(got, stillSpinner.Frames[0]+" running") {
+		t.Errorf("the spinner should stand still, got %q", got)
+	}
+}
+
+func TestReducedMotion_OffAnimates(t *testing.T) {
+	m := newTestModel(t)
+
+	if cmd := m.startLogPanelBorderAnim(); cmd == nil {
+		t.Error("the border should animate by default")
+	}
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/clipboard"
+)
+
+// copiedMsg is sent once an ID has been copied to the clipboard.
+type copiedMsg struct {
+	what string // e.g. "change ID"
+	text string
+	err  error // from the copy tool; the terminal was asked either way
+}
+
+// actionCopyChangeID copies the selected change's ID to the clipboard.
+// Only allows copying when log panel is focused and in log view.
+func (m *Model) actionCopyChangeID() (Model, tea.Cmd) {
+	if m.focusedPane != PaneLog || m.viewMode != ViewLog {
+		return *m, nil
+	}
+
+	selected := m.logPanel.SelectedChange()
+	if selected == nil {
+		return *m, nil
+	}
+
+	return *m, copyToClipboard("change ID", selected.ChangeID)
+}
+
+// actionCopyCommitID copies the selected change's commit ID to the clipboard.
+// Only allows copying when log panel is focused and in log view.
+func (m *Model) actionCopyCommitID() (Model, tea.Cmd) {
+	if m.focusedPane != PaneLog || m.viewMode != ViewLog {
+		return *m, nil
+	}
+
+	selected := m.logPanel.SelectedChange()
+	if selected == nil || selected.CommitID == "" {
+		return *m, nil
+	}
+
+	return *m, copyToClipboard("commit ID", selected.CommitID)
+}
+
+// copyToClipboard copies text, described as what in the confirmation.
+func copyToClipboard(what, text string) tea.Cmd {
+	return clipboard.Copy(text, func(err error) tea.Msg {
+		return copiedMsg{what: what, text: text, err: err}
+	})
+}
+
+// handleCopied confirms a copy. A missing or failing copy tool is only
+// logged: the terminal may well have set the clipboard through OSC 52.
+func (m *Model) handleCopied(msg copiedMsg) tea.Cmd {
+	if msg.err != nil && !errors.Is(msg.err, clipboard.ErrNoTool) {
+		m.log.Warn("clipboard tool failed", "error", msg.err)
+	}
+
+	// A diff is too long to repeat; its lines are counted instead
+	shown := msg.text
+	if lines := strings.Count(msg.text, "\n") + 1; lines > 1 {
+		shown = fmt.Sprintf("(%d lines)", lines)
+	}
+
+	return m.toasts.Success("copied " + msg.what + " " + shown)
+}
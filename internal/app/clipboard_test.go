@@ -0,0 +1,73 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/chatter/chado/internal/clipboard"
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestCopy_NeedsSelectedChange(t *testing.T) {
+	m := newTestModel(t)
+
+	if _, cmd := m.actionCopyChangeID(); cmd != nil {
+		t.Error("nothing should be copied without a selected change")
+	}
+
+	m.logPanel.SetContent("@ xsssnyux 1\n", []jj.Change{{ChangeID: "xsssnyux"}})
+
+	if _, cmd := m.actionCopyCommitID(); cmd != nil {
+		t.Error("nothing should be copied for a change without a commit ID")
+	}
+
+	if _, cmd := m.actionCopyChangeID(); cmd == nil {
+		t.Error("expected the change ID to be copied")
+	}
+
+	m.focusedPane = PaneDiff
+
+	if _, cmd := m.actionCopyChangeID(); cmd != nil {
+		t.Error("copying should need the log focused")
+	}
+}
+
+func TestCopy_ConfirmsWithToast(t *testing.T) {
+	m := newTestModel(t)
+
+	m.handleCopied(copiedMsg{what: "commit ID", text: "abc123", err: clipboard.ErrNoTool})
+	m.handleCopied(copiedMsg{what: "change ID", text: "xsssnyux", err: errors.New("xclip: no display")})
+
+	items := m.toasts.Items()
+	if len(items) != 2 {
+		t.Fatalf("expected a toast per copy, got %d", len(items))
+	}
+
+	if items[0].Text != "copied commit ID abc123" {
+		t.Errorf("unexpected toast %q", items[0].Text)
+	}
+
+	if m.toasts.HasErrors() {
+		t.Error("a copy tool problem should not show as an error")
+	}
+}
+
+func TestCopy_DiffCountsLines(t *testing.T) {
+	m := newTestModel(t)
+
+	if _, cmd := m.Update(ui.CopyDiffMsg{What: "hunk", Text: "a"}); cmd == nil {
+		t.Error("a copied diff should go to the clipboard")
+	}
+
+	m.handleCopied(copiedMsg{what: "hunk", text: "Modified regular file a.go:\n   1    1: package a\n   2     : x"})
+
+	items := m.toasts.Items()
+	if len(items) == 0 || items[len(items)-1].Text != "copied hunk (3 lines)" {
+		t.Errorf("a copied diff should be counted rather than repeated, got %v", items)
+	}
+}
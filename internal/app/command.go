@@ -0,0 +1,101 @@
+package app
+
+import (
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui"
+)
+
+// commandWidthPct is the command prompt's share of screen width.
+const commandWidthPct = 60
+
+// commandRanMsg carries the result of a command run from the prompt.
+type commandRanMsg struct {
+	line   string // as typed, for the output title
+	args   []string
+	output string
+	err    error
+}
+
+// actionCommand opens the prompt for running an arbitrary jj command.
+func (m *Model) actionCommand() (Model, tea.Cmd) {
+	m.commanding = true
+	m.sizeCommandLine()
+
+	return *m, m.commandLine.Open()
+}
+
+// sizeCommandLine fits the command prompt to the current window.
+func (m *Model) sizeCommandLine() {
+	m.commandLine.SetWidth(m.width * commandWidthPct / percentDivisor)
+}
+
+// handleCommandSubmit runs the typed command. A leading "jj" is optional.
+func (m *Model) handleCommandSubmit(msg ui.CommandSubmitMsg) tea.Cmd {
+	m.commanding = false
+
+	args, err := jj.SplitArgs(msg.Line)
+	if err != nil {
+		return m.handleErr(errMsg{err})
+	}
+
+	if len(args) > 0 && args[0] == "jj" {
+		args = args[1:]
+	}
+
+	// Keep jj's colors unless the user chose otherwise
+	if !slices.ContainsFunc(args, func(arg string) bool { return strings.HasPrefix(arg, "--color") }) {
+		args = append(args, m.runner.ColorFlag())
+	}
+
+	// Read-only mode doesn't record snapshots of the working copy either
+	if m.readOnly && jj.IsReadOnly(args) {
+		args = jj.WithoutSnapshot(args)
+	}
+
+	runner := m.runner.Pinned()
+
+	run := func() tea.Msg {
+		output, err := runner.RunCombined(args...)
+		return commandRanMsg{line: msg.Line, args: args, output: output, err: err}
+	}
+
+	if jj.IsReadOnly(args) {
+		return run
+	}
+
+	if m.readOnly {
+		return m.toasts.Info("read-only mode: jj " + msg.Line + " would change the repo")
+	}
+
+	if m.timeTravelOp != "" {
+		return m.toasts.Info("return to the present (esc) before jj " + msg.Line + " changes the repo")
+	}
+
+	return m.startJob("jj "+msg.Line, run)
+}
+
+// handleCommandRan shows the command's output in the diff pane, where it
+// stays until esc, and reloads if the command may have changed the repo.
+func (m *Model) handleCommandRan(msg commandRanMsg) tea.Cmd {
+	var cmds []tea.Cmd
+
+	switch {
+	case msg.err != nil:
+		cmds = append(cmds, m.handleErr(errMsg{msg.err}))
+	case strings.TrimSpace(msg.output) == "":
+		cmds = append(cmds, m.toasts.Success("ran jj "+msg.line))
+	default:
+		m.diffPanel.ShowOutput("jj "+msg.line, msg.output)
+	}
+
+	if !jj.IsReadOnly(msg.args) {
+		cmds = append(cmds, m.reloadAfterMutation())
+	}
+
+	return tea.Batch(cmds...)
+}
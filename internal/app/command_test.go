@@ -0,0 +1,57 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/chatter/chado/internal/ui"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestCommand_SubmitClosesPrompt(t *testing.T) {
+	m := newTestModel(t)
+	m.actionCommand()
+
+	if !m.commanding {
+		t.Fatal("prompt should be open")
+	}
+
+	if cmd := m.handleCommandSubmit(ui.CommandSubmitMsg{Line: "jj log"}); cmd == nil {
+		t.Error("submitting should run the command")
+	}
+
+	if m.commanding {
+		t.Error("submitting should close the prompt")
+	}
+}
+
+func TestCommand_OutputShownUntilBack(t *testing.T) {
+	m := newTestModel(t)
+	m.diffPanel.SetDiff("the diff")
+
+	m.handleCommandRan(commandRanMsg{line: "status", args: []string{"status"}, output: "Working copy changes:"})
+
+	if !m.diffPanel.Previewing() {
+		t.Fatal("command output should replace the diff")
+	}
+
+	m.actionBack()
+
+	if m.diffPanel.Previewing() {
+		t.Error("esc should close the command output")
+	}
+}
+
+func TestCommand_MutatingCommandReloads(t *testing.T) {
+	m := newTestModel(t)
+
+	if cmd := m.handleCommandRan(commandRanMsg{line: "log", args: []string{"log"}, output: "x"}); cmd != nil {
+		t.Error("a read-only command with output should not reload")
+	}
+
+	if cmd := m.handleCommandRan(commandRanMsg{line: "new", args: []string{"new"}, output: "x"}); cmd == nil {
+		t.Error("a mutating command should reload")
+	}
+}
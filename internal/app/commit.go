@@ -0,0 +1,48 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+)
+
+// commitCompleteMsg reports the working copy committed, with a new empty
+// change started on it.
+type commitCompleteMsg struct {
+	changeID string
+}
+
+// actionCommit opens the describe input for the working copy, whatever is
+// selected; submitting commits it (jj commit), finishing the change in one
+// step.
+func (m *Model) actionCommit() (Model, tea.Cmd) {
+	changeID, desc := "@", ""
+
+	for _, change := range m.changes {
+		if change.IsWorkingCopy {
+			changeID, desc = change.ChangeID, change.Description
+			break
+		}
+	}
+
+	return *m, m.openDescribe(changeID, desc, true)
+}
+
+// runCommit executes jj commit and returns a completion message.
+func (m *Model) runCommit(changeID, message string) tea.Cmd {
+	runner := m.runner
+
+	return m.startJob("jj commit "+changeID, func() tea.Msg {
+		if err := runner.Commit(message); err != nil {
+			return errMsg{err}
+		}
+
+		return commitCompleteMsg{changeID: changeID}
+	})
+}
+
+// handleCommitComplete reloads with the log selecting the new @, ready for
+// the next change.
+func (m *Model) handleCommitComplete(msg commitCompleteMsg) tea.Cmd {
+	m.followWorkingCopy = true
+
+	return m.completeMutation("committed " + msg.changeID)
+}
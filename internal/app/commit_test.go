@@ -0,0 +1,73 @@
+package app
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestCommit_DescribesWorkingCopy(t *testing.T) {
+	m := newTestModel(t)
+	m.changes = []jj.Change{
+		{ChangeID: "aaaaaaaa", Description: "fix parser", IsWorkingCopy: true},
+		{ChangeID: "bbbbbbbb", Description: "add lexer"},
+	}
+	m.logPanel.SetContent("@ aaaaaaaa\n○ bbbbbbbb\n", m.changes)
+	m.logPanel.SelectChangeID("bbbbbbbb")
+
+	m.actionCommit()
+
+	if !m.editMode || m.describeInput.ChangeID() != "aaaaaaaa" || m.describeInput.Value() != "fix parser" {
+		t.Fatalf("commit should edit @'s description whatever is selected, got %q: %q",
+			m.describeInput.ChangeID(), m.describeInput.Value())
+	}
+
+	msg, ok := m.describeInput.Update(tea.KeyPressMsg{Code: tea.KeyEnter})().(ui.DescribeSubmitMsg)
+	if !ok || !msg.Commit {
+		t.Fatalf("submitting should commit, got %+v", msg)
+	}
+
+	m.handleDescribeSubmit(msg)
+	m.handleWorkingCopyChecked(workingCopyCheckedMsg{
+		state: jj.WorkingCopyState{ChangeID: "aaaaaaaa", CommitID: "abc"},
+		mutation: guardedMutation{rev: msg.ChangeID, run: func(rev string) tea.Cmd {
+			return m.runCommit(rev, msg.Description)
+		}},
+	})
+
+	if got := m.jobs.running[0].label; got != "jj commit aaaaaaaa" {
+		t.Errorf("ran %q, want jj commit aaaaaaaa", got)
+	}
+}
+
+func TestCommit_DescribeStillDescribes(t *testing.T) {
+	m := newTestModel(t)
+	m.changes = []jj.Change{{ChangeID: "aaaaaaaa", Description: "fix parser", IsWorkingCopy: true}}
+	m.logPanel.SetContent("@ aaaaaaaa\n", m.changes)
+
+	m.actionCommit()
+	m.editMode = false
+	m.actionDescribe()
+
+	msg, _ := m.describeInput.Update(tea.KeyPressMsg{Code: tea.KeyEnter})().(ui.DescribeSubmitMsg)
+	if msg.Commit {
+		t.Error("describe after a commit should not commit")
+	}
+}
+
+func TestCommit_SelectsNewWorkingCopy(t *testing.T) {
+	m := newTestModel(t)
+
+	m.handleCommitComplete(commitCompleteMsg{changeID: "aaaaaaaa"})
+
+	if !m.followWorkingCopy {
+		t.Error("the next log load should select the new @")
+	}
+}
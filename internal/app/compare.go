@@ -0,0 +1,100 @@
+package app
+
+import (
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// logAtOpLoadedMsg carries the change log as it existed at an operation.
+type logAtOpLoadedMsg struct {
+	opID       string
+	raw        string
+	changes    []jj.Change
+	generation int // compare request generation; stale results are dropped
+}
+
+// compareKey returns the compare binding, enabled only where it applies: with
+// the op log (not an evolog) focused, or while a comparison is open so it can
+// be closed.
+func (m *Model) compareKey() key.Binding {
+	binding := m.keys.CompareAtOp
+	binding.SetEnabled(m.comparing || (m.focusedPane == PaneOpLog && m.viewMode == ViewLog))
+
+	return binding
+}
+
+// actionCompareAtOp toggles the at-op comparison: the right pane shows the
+// log as of the operation selected in the op log, next to the current log,
+// with changes that have since disappeared flagged as gone.
+func (m *Model) actionCompareAtOp() (Model, tea.Cmd) {
+	if m.comparing {
+		m.comparing = false
+		m.compareRequests.invalidate()
+
+		return *m, nil
+	}
+
+	if m.focusedPane != PaneOpLog || m.viewMode != ViewLog {
+		return *m, nil
+	}
+
+	op := m.opLogPanel.SelectedOperation()
+	if op == nil {
+		return *m, nil
+	}
+
+	m.comparing = true
+
+	return *m, m.loadLogAtOp(op.OpID)
+}
+
+// loadLogAtOp fetches the log at an operation for the comparison pane.
+func (m *Model) loadLogAtOp(opID string) tea.Cmd {
+	ctx, generation := m.compareRequests.next(m.ctx)
+	runner := m.runner.WithContext(ctx)
+
+	return func() tea.Msg {
+		output, err := runner.LogAtOp(opID)
+		if err != nil {
+			return superseded(ctx, err)
+		}
+
+		return logAtOpLoadedMsg{
+			opID:       opID,
+			raw:        output,
+			changes:    runner.ParseLogLines(output),
+			generation: generation,
+		}
+	}
+}
+
+func (m *Model) handleLogAtOpLoaded(msg logAtOpLoadedMsg) {
+	if !m.comparing || !m.compareRequests.current(msg.generation) {
+		return
+	}
+
+	m.compareLogPanel.SetHeading(0, "Log at op "+msg.opID)
+	m.compareChanges = msg.changes
+	m.compareLogPanel.SetContent(msg.raw, msg.changes)
+	m.compareLogPanel.SetGone(goneChanges(msg.changes, m.changes))
+}
+
+// goneChanges returns the IDs of changes in past that are missing from current.
+func goneChanges(past, current []jj.Change) map[string]bool {
+	present := make(map[string]bool, len(current))
+	for _, change := range current {
+		present[change.ChangeID] = true
+	}
+
+	gone := make(map[string]bool)
+
+	for _, change := range past {
+		if !present[change.ChangeID] {
+			gone[change.ChangeID] = true
+		}
+	}
+
+	return gone
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"testing"
+
+	"pgregory.net/rapid"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestCompareAtOp_RequiresOpLogFocus(t *testing.T) {
+	m := newTestModel(t)
+	m.opLogPanel.SetOpLogContent("@  bbc9fee12c4d user 1 minute ago\n", []jj.Operation{{OpID: "bbc9fee12c4d"}})
+
+	m.actionCompareAtOp()
+
+	if m.comparing {
+		t.Fatal("compare should only open from the op log")
+	}
+
+	m.focusedPane = PaneOpLog
+	m.actionCompareAtOp()
+
+	if !m.comparing {
+		t.Fatal("compare should open with the op log focused")
+	}
+
+	m.focusedPane = PaneLog
+	m.actionCompareAtOp()
+
+	if m.comparing {
+		t.Error("compare should close from anywhere")
+	}
+}
+
+func TestCompareAtOp_DropsStaleAndClosedResults(t *testing.T) {
+	m := newTestModel(t)
+	m.comparing = true
+
+	_, stale := m.compareRequests.next(m.ctx)
+	_, latest := m.compareRequests.next(m.ctx)
+
+	m.handleLogAtOpLoaded(logAtOpLoadedMsg{opID: "old", raw: "old\n", changes: []jj.Change{{ChangeID: "old"}}, generation: stale})
+
+	if m.compareChanges != nil {
+		t.Error("stale at-op log should be dropped")
+	}
+
+	m.handleLogAtOpLoaded(logAtOpLoadedMsg{opID: "new", raw: "new\n", changes: []jj.Change{{ChangeID: "new"}}, generation: latest})
+
+	if len(m.compareChanges) != 1 || m.compareChanges[0].ChangeID != "new" {
+		t.Errorf("latest at-op log should be shown, got %+v", m.compareChanges)
+	}
+}
+
+func TestGoneChanges(t *testing.T) {
+	past := []jj.Change{{ChangeID: "aaaa"}, {ChangeID: "bbbb"}, {ChangeID: "cccc"}}
+	current := []jj.Change{{ChangeID: "aaaa"}, {ChangeID: "dddd"}}
+
+	gone := goneChanges(past, current)
+
+	if len(gone) != 2 || !gone["bbbb"] || !gone["cccc"] {
+		t.Errorf("expected bbbb and cccc gone, got %v", gone)
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: a change is gone iff it is in the past log and not the current one.
+func TestGoneChanges_Membership(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		idGen := rapid.StringMatching(`[k-z]{4}`)
+		toChanges := func(ids []string) []jj.Change {
+			changes := make([]jj.Change, len(ids))
+			for i, id := range ids {
+				changes[i] = jj.Change{ChangeID: id}
+			}
+
+			return changes
+		}
+
+		pastIDs := rapid.SliceOf(idGen).Draw(t, "past")
+		currentIDs := rapid.SliceOf(idGen).Draw(t, "current")
+
+		gone := goneChanges(toChanges(pastIDs), toChanges(currentIDs))
+
+		inCurrent := make(map[string]bool)
+		for _, id := range currentIDs {
+			inCurrent[id] = true
+		}
+
+		for _, id := range pastIDs {
+			if gone[id] == inCurrent[id] {
+				t.Fatalf("%s: gone=%v but inCurrent=%v", id, gone[id], inCurrent[id])
+			}
+		}
+	})
+}
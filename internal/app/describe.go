@@ -0,0 +1,53 @@
+package app
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// noDescription is what jj's log shows for a change without a description.
+const noDescription = "(no description set)"
+
+// openDescribe opens the describe input on changeID's description, to
+// commit it when commit is set. An empty description starts as the
+// configured template, so typing fills it in.
+func (m *Model) openDescribe(changeID, desc string, commit bool) tea.Cmd {
+	if desc == noDescription {
+		desc = ""
+	}
+
+	if desc == "" {
+		desc = m.describeTemplate
+	}
+
+	m.describeInput.SetChangeID(changeID)
+	m.describeInput.SetCommit(commit)
+	m.describeInput.SetBatch(m.batchPosition+1, len(m.describeBatch))
+	m.describeInput.SetTrailers(m.trailersFor(changeID))
+	m.describeInput.SetSize(m.width, m.height)
+	m.describeInput.SetValue(desc)
+	m.editMode = true
+
+	return m.describeInput.Focus()
+}
+
+// trailersFor fills in the configured trailers for changeID: {name} and
+// {email} from jj's user, and {change_id}. Trailers naming a user jj hasn't
+// been told are left out rather than added blank.
+func (m *Model) trailersFor(changeID string) []string {
+	fill := strings.NewReplacer("{name}", m.userName, "{email}", m.userEmail, "{change_id}", changeID)
+
+	trailers := make([]string, 0, len(m.describeTrailers))
+
+	for _, trailer := range m.describeTrailers {
+		if strings.Contains(trailer, "{name}") && m.userName == "" ||
+			strings.Contains(trailer, "{email}") && m.userEmail == "" {
+			continue
+		}
+
+		trailers = append(trailers, fill.Replace(trailer))
+	}
+
+	return trailers
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestDescribe_EmptyStartsAsTemplate(t *testing.T) {
+	m := newTestModel(t)
+	m.describeTemplate = "feat: "
+	m.changes = []jj.Change{{ChangeID: "aaaaaaaa", Description: "(no description set)", IsWorkingCopy: true}}
+	m.logPanel.SetContent("@ aaaaaaaa\n", m.changes)
+
+	m.actionDescribe()
+
+	if got := m.describeInput.Value(); got != "feat: " {
+		t.Errorf("empty description should start as the template, got %q", got)
+	}
+
+	m.editMode = false
+	m.changes[0].Description = "fix parser"
+	m.logPanel.SetContent("@ aaaaaaaa\n", m.changes)
+	m.actionCommit()
+
+	if got := m.describeInput.Value(); got != "fix parser" {
+		t.Errorf("a description should be kept, got %q", got)
+	}
+}
+
+func TestDescribe_TrailersFilledIn(t *testing.T) {
+	m := newTestModel(t)
+	m.describeTrailers = []string{"Signed-off-by: {name} <{email}>", "Change-Id: {change_id}"}
+
+	if got := m.trailersFor("aaaaaaaa"); !slices.Equal(got, []string{"Change-Id: aaaaaaaa"}) {
+		t.Errorf("trailers naming an unknown user should be left out, got %q", got)
+	}
+
+	m.followSettings(jj.Settings{"user.name": `"Ann"`, "user.email": `"ann@example.com"`})
+
+	want := []string{"Signed-off-by: Ann <ann@example.com>", "Change-Id: aaaaaaaa"}
+	if got := m.trailersFor("aaaaaaaa"); !slices.Equal(got, want) {
+		t.Errorf("trailersFor = %q, want %q", got, want)
+	}
+}
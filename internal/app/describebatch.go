@@ -0,0 +1,220 @@
+package app
+
+import (
+	"strconv"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui"
+)
+
+// batchEntry is the description entered for one change of a batch.
+type batchEntry struct {
+	changeID    string
+	description string
+}
+
+// describeBatchCompleteMsg reports a batch of changes described.
+type describeBatchCompleteMsg struct {
+	described int
+}
+
+// rewordCompleteMsg reports the descriptions a search-and-replace changed,
+// out of those it looked at.
+type rewordCompleteMsg struct {
+	find     string
+	reworded int
+	total    int
+}
+
+// startDescribeBatch opens the describe input on each of changes in turn,
+// refusing them all if one is immutable. Nothing is described until the
+// last is submitted, so cancelling leaves them all as they were.
+func (m *Model) startDescribeBatch(changes []jj.Change) tea.Cmd {
+	for _, change := range changes {
+		if change.IsImmutable {
+			return m.refuseImmutable("describe", change.ChangeID)
+		}
+	}
+
+	m.describeBatch = changes
+	m.batchPosition = 0
+	m.batchDescribed = nil
+
+	return m.openNextInBatch()
+}
+
+// openNextInBatch opens the describe input on the batch's next change,
+// keeping what it starts as to tell whether it was changed.
+func (m *Model) openNextInBatch() tea.Cmd {
+	next := m.describeBatch[m.batchPosition]
+	cmd := m.openDescribe(next.ChangeID, next.Description, false)
+	m.batchStart = m.describeInput.Value()
+
+	return cmd
+}
+
+// continueDescribeBatch keeps the description submitted for the batch's
+// current change if it was changed, then opens the next, or describes the
+// changed ones after the last.
+func (m *Model) continueDescribeBatch(msg ui.DescribeSubmitMsg) tea.Cmd {
+	if msg.Description != m.batchStart {
+		m.batchDescribed = append(m.batchDescribed, batchEntry{changeID: msg.ChangeID, description: msg.Description})
+	}
+
+	if m.batchPosition++; m.batchPosition < len(m.describeBatch) {
+		return m.openNextInBatch()
+	}
+
+	changed := m.batchDescribed
+	m.endDescribeBatch()
+	m.editMode = false
+
+	if len(changed) == 0 {
+		return m.toasts.Info("no descriptions changed")
+	}
+
+	return m.guardWorkingCopy(guardedMutation{
+		rev: changed[0].changeID,
+		run: func(string) tea.Cmd { return m.runDescribeBatch(changed) },
+	})
+}
+
+// endDescribeBatch forgets the batch being described.
+func (m *Model) endDescribeBatch() {
+	m.describeBatch = nil
+	m.batchPosition = 0
+	m.batchDescribed = nil
+	m.batchStart = ""
+}
+
+// runDescribeBatch describes each change of a batch in one job.
+func (m *Model) runDescribeBatch(entries []batchEntry) tea.Cmd {
+	runner := m.runner
+
+	return m.startJob("jj describe "+countChanges(len(entries)), func() tea.Msg {
+		for _, entry := range entries {
+			if err := runner.Describe(entry.changeID, entry.description); err != nil {
+				return errMsg{err}
+			}
+		}
+
+		return describeBatchCompleteMsg{described: len(entries)}
+	})
+}
+
+// actionReword replaces text in the descriptions of the marked range of
+// changes, or the selected change's, e.g. to rename a ticket ID across a
+// stack. It asks for the text to find, then what to replace it with.
+func (m *Model) actionReword() (Model, tea.Cmd) {
+	changes := m.rewordTargets()
+	if len(changes) == 0 {
+		return *m, nil
+	}
+
+	for _, change := range changes {
+		if change.IsImmutable {
+			return *m, m.refuseImmutable("reword", change.ChangeID)
+		}
+	}
+
+	m.commanding = true
+	m.promptAnswer = func(m *Model, find string) tea.Cmd {
+		return m.askRewordReplacement(changes, find)
+	}
+	m.sizeCommandLine()
+
+	return *m, m.commandLine.Ask("Replace in "+countDescriptions(len(changes)), "find: ", "PROJ-123")
+}
+
+// rewordTargets returns the changes a reword covers: the marked range in
+// the log, or the selected change.
+func (m *Model) rewordTargets() []jj.Change {
+	if m.focusedPane != PaneLog || m.viewMode != ViewLog {
+		return nil
+	}
+
+	if changes := m.logPanel.MarkedRange(); changes != nil {
+		return changes
+	}
+
+	if selected := m.logPanel.SelectedChange(); selected != nil {
+		return []jj.Change{*selected}
+	}
+
+	return nil
+}
+
+// askRewordReplacement asks what to replace find with in the descriptions
+// of changes.
+func (m *Model) askRewordReplacement(changes []jj.Change, find string) tea.Cmd {
+	m.commanding = true
+	m.promptAnswer = func(m *Model, replace string) tea.Cmd {
+		return m.guardWorkingCopy(guardedMutation{
+			rev: changes[0].ChangeID,
+			run: func(string) tea.Cmd { return m.runReword(changes, find, replace) },
+		})
+	}
+	m.sizeCommandLine()
+
+	return m.commandLine.Ask("Replace "+find+" in "+countDescriptions(len(changes)), "with: ", "PROJ-456")
+}
+
+// runReword reads each change's full description and describes those that
+// contain find with it replaced, in one job.
+func (m *Model) runReword(changes []jj.Change, find, replace string) tea.Cmd {
+	runner := m.runner
+
+	return m.startJob("jj describe (replace "+find+")", func() tea.Msg {
+		reworded := 0
+
+		for _, change := range changes {
+			description, err := runner.FullDescription(change.ChangeID)
+			if err != nil {
+				return errMsg{err}
+			}
+
+			if !strings.Contains(description, find) {
+				continue
+			}
+
+			if err := runner.Describe(change.ChangeID, strings.ReplaceAll(description, find, replace)); err != nil {
+				return errMsg{err}
+			}
+
+			reworded++
+		}
+
+		return rewordCompleteMsg{find: find, reworded: reworded, total: len(changes)}
+	})
+}
+
+// handleRewordComplete reports the descriptions changed, reloading when
+// there were any.
+func (m *Model) handleRewordComplete(msg rewordCompleteMsg) tea.Cmd {
+	if msg.reworded == 0 {
+		return m.toasts.Info("no description contains " + msg.find)
+	}
+
+	return m.completeMutation("replaced " + msg.find + " in " + strconv.Itoa(msg.reworded) + " of " + countDescriptions(msg.total))
+}
+
+// countChanges counts changes, e.g. "1 change" or "3 changes".
+func countChanges(count int) string {
+	if count == 1 {
+		return "1 change"
+	}
+
+	return strconv.Itoa(count) + " changes"
+}
+
+// countDescriptions counts descriptions, e.g. "1 description".
+func countDescriptions(count int) string {
+	if count == 1 {
+		return "1 description"
+	}
+
+	return strconv.Itoa(count) + " descriptions"
+}
@@ -0,0 +1,136 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui"
+)
+
+// newBatchTestModel returns a model whose log lists three changes, the
+// first marked and the last selected.
+func newBatchTestModel(t *testing.T) *Model {
+	t.Helper()
+
+	m := newTestModel(t)
+	m.changes = []jj.Change{
+		{ChangeID: "aaaaaaaa", Description: "PROJ-1 fix parser", IsWorkingCopy: true},
+		{ChangeID: "bbbbbbbb", Description: "PROJ-1 add lexer"},
+		{ChangeID: "cccccccc", Description: "docs"},
+	}
+	m.logPanel.SetContent("@ aaaaaaaa\n○ bbbbbbbb\n○ cccccccc\n", m.changes)
+	m.logPanel.ToggleMark()
+	m.logPanel.SelectChangeID("cccccccc")
+
+	return m
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestDescribeBatch_DescribesEachInTurn(t *testing.T) {
+	m := newBatchTestModel(t)
+
+	m.actionDescribe()
+
+	if m.describeInput.ChangeID() != "aaaaaaaa" {
+		t.Fatalf("the batch should start at the marked change, got %s", m.describeInput.ChangeID())
+	}
+
+	m.handleDescribeSubmit(ui.DescribeSubmitMsg{ChangeID: "aaaaaaaa", Description: "PROJ-2 fix parser"})
+
+	if !m.editMode || m.describeInput.ChangeID() != "bbbbbbbb" || m.describeInput.Value() != "PROJ-1 add lexer" {
+		t.Fatalf("the next change should open, got %s: %q", m.describeInput.ChangeID(), m.describeInput.Value())
+	}
+
+	m.handleDescribeSubmit(ui.DescribeSubmitMsg{ChangeID: "bbbbbbbb", Description: "PROJ-1 add lexer"})
+	cmd := m.handleDescribeSubmit(ui.DescribeSubmitMsg{ChangeID: "cccccccc", Description: "PROJ-2 docs"})
+
+	if m.editMode || m.describeBatch != nil {
+		t.Fatal("the batch should end after its last change")
+	}
+
+	checked, ok := cmd().(workingCopyCheckedMsg)
+	if !ok {
+		t.Fatal("describing the batch should check the working copy first")
+	}
+
+	m.handleWorkingCopyChecked(checked)
+
+	if got := m.jobs.running[0].label; got != "jj describe 2 changes" {
+		t.Errorf("ran %q, want only the two changed descriptions", got)
+	}
+}
+
+func TestDescribeBatch_CancelDescribesNothing(t *testing.T) {
+	m := newBatchTestModel(t)
+
+	m.actionDescribe()
+	m.handleDescribeSubmit(ui.DescribeSubmitMsg{ChangeID: "aaaaaaaa", Description: "PROJ-2 fix parser"})
+	m.Update(ui.DescribeCancelMsg{})
+
+	if m.editMode || m.describeBatch != nil || m.batchDescribed != nil {
+		t.Error("cancelling should drop the whole batch")
+	}
+
+	m.logPanel.ToggleMark()
+	m.actionDescribe()
+
+	if m.describeInput.ChangeID() != "cccccccc" || m.describeBatch != nil {
+		t.Errorf("without a mark, describe should open on the selected change alone, got %s", m.describeInput.ChangeID())
+	}
+}
+
+func TestDescribeBatch_RefusesImmutable(t *testing.T) {
+	m := newBatchTestModel(t)
+	m.changes[1].IsImmutable = true
+	m.logPanel.SetContent("@ aaaaaaaa\n○ bbbbbbbb\n○ cccccccc\n", m.changes)
+
+	m.actionDescribe()
+
+	if m.editMode || m.describeBatch != nil {
+		t.Error("a batch with an immutable change should not be described")
+	}
+}
+
+func TestReword_AsksFindThenReplace(t *testing.T) {
+	m := newBatchTestModel(t)
+
+	m.actionReword()
+
+	if !m.commanding {
+		t.Fatal("reword should ask what to find")
+	}
+
+	m.handlePromptSubmit(ui.PromptSubmitMsg{Value: "PROJ-1"})
+
+	if !m.commanding {
+		t.Fatal("reword should ask what to replace it with")
+	}
+
+	cmd := m.handlePromptSubmit(ui.PromptSubmitMsg{Value: "PROJ-2"})
+
+	checked, ok := cmd().(workingCopyCheckedMsg)
+	if !ok {
+		t.Fatal("rewording should check the working copy first")
+	}
+
+	m.handleWorkingCopyChecked(checked)
+
+	if got := m.jobs.running[0].label; got != "jj describe (replace PROJ-1)" {
+		t.Errorf("ran %q", got)
+	}
+}
+
+func TestReword_NoMatches(t *testing.T) {
+	m := newBatchTestModel(t)
+
+	if cmd := m.handleRewordComplete(rewordCompleteMsg{find: "PROJ-9", total: 3}); cmd == nil {
+		t.Error("finding no matches should say so")
+	}
+
+	if got := countDescriptions(1) + ", " + countChanges(3); got != "1 description, 3 changes" {
+		t.Errorf("counts = %q", got)
+	}
+}
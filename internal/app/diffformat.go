@@ -0,0 +1,29 @@
+package app
+
+import (
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// diffFormatName names a diff format for the diff pane's title and toasts.
+func diffFormatName(format jj.DiffFormat) string {
+	if format == jj.DiffFormatDefault {
+		return "jj default"
+	}
+
+	return string(format)
+}
+
+// actionCycleDiffFormat re-runs the diff in the next format, from jj's
+// configured one through --git, --color-words, and --stat.
+func (m *Model) actionCycleDiffFormat() (Model, tea.Cmd) {
+	current := slices.Index(jj.DiffFormats, m.runner.DiffFormat())
+	format := jj.DiffFormats[(current+1)%len(jj.DiffFormats)]
+	m.runner.SetDiffFormat(format)
+	m.diffPanel.SetFormat(diffFormatName(format))
+
+	return *m, tea.Batch(m.toasts.Info("diff format: "+diffFormatName(format)), m.loadSelectedDiff())
+}
@@ -0,0 +1,28 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestCycleDiffFormat_WrapsAround(t *testing.T) {
+	m := newTestModel(t)
+	m.runner.SetDiffFormat(jj.DiffFormatDefault)
+
+	for _, want := range append(jj.DiffFormats[1:], jj.DiffFormatDefault) {
+		m.actionCycleDiffFormat()
+
+		if got := m.runner.DiffFormat(); got != want {
+			t.Fatalf("format = %q, want %q", got, want)
+		}
+	}
+
+	if len(m.toasts.Items()) == 0 {
+		t.Error("switching formats should say which one is showing")
+	}
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// defaultDiffContext is the lines of context jj shows around each change
+// unless configured otherwise; + and - step from it the first time.
+const defaultDiffContext = 3
+
+// actionToggleIgnoreWhitespace re-runs the diff with or without changes in
+// whitespace, staying where it was scrolled to.
+func (m *Model) actionToggleIgnoreWhitespace() (Model, tea.Cmd) {
+	ignore := !m.runner.IgnoreWhitespace()
+	m.runner.SetIgnoreWhitespace(ignore)
+
+	toast := m.toasts.Info("whitespace changes shown")
+	if ignore {
+		toast = m.toasts.Info("whitespace changes ignored")
+	}
+
+	return *m, tea.Batch(toast, m.reloadDiffOptions())
+}
+
+// actionMoreContext re-runs the diff with a line more of context around
+// each change.
+func (m *Model) actionMoreContext() (Model, tea.Cmd) {
+	return m.stepDiffContext(1)
+}
+
+// actionLessContext re-runs the diff with a line less of context around
+// each change, down to none.
+func (m *Model) actionLessContext() (Model, tea.Cmd) {
+	return m.stepDiffContext(-1)
+}
+
+// stepDiffContext changes the lines of context by lines and re-runs the
+// diff, unless that is already as few as can be shown.
+func (m *Model) stepDiffContext(lines int) (Model, tea.Cmd) {
+	current := m.runner.DiffContext()
+	if current < 0 {
+		current = defaultDiffContext
+	}
+
+	next := max(current+lines, 0)
+	if next == m.runner.DiffContext() {
+		return *m, nil
+	}
+
+	m.runner.SetDiffContext(next)
+
+	return *m, tea.Batch(
+		m.toasts.Info(fmt.Sprintf("diff context: %d lines", next)),
+		m.reloadDiffOptions(),
+	)
+}
+
+// reloadDiffOptions reloads the diff shown for new diff options, keeping
+// its scroll position.
+func (m *Model) reloadDiffOptions() tea.Cmd {
+	cmd := m.loadSelectedDiff()
+	if cmd != nil {
+		m.diffPanel.KeepScroll()
+	}
+
+	return cmd
+}
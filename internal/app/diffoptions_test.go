@@ -0,0 +1,69 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestDiffContext_StepsFromJJDefault(t *testing.T) {
+	m := newTestModel(t)
+
+	m.actionMoreContext()
+
+	if got := m.runner.DiffContext(); got != defaultDiffContext+1 {
+		t.Fatalf("context = %d, want %d", got, defaultDiffContext+1)
+	}
+
+	for range defaultDiffContext + 3 {
+		m.actionLessContext()
+	}
+
+	if got := m.runner.DiffContext(); got != 0 {
+		t.Errorf("context should stop at none, got %d", got)
+	}
+
+	if _, cmd := m.actionLessContext(); cmd != nil {
+		t.Error("less context than none should do nothing")
+	}
+}
+
+func TestIgnoreWhitespace_Toggles(t *testing.T) {
+	m := newTestModel(t)
+
+	m.actionToggleIgnoreWhitespace()
+
+	if !m.runner.IgnoreWhitespace() {
+		t.Fatal("whitespace changes should be ignored")
+	}
+
+	m.actionToggleIgnoreWhitespace()
+
+	if m.runner.IgnoreWhitespace() {
+		t.Error("whitespace changes should be shown again")
+	}
+
+	if len(m.toasts.Items()) == 0 {
+		t.Error("toggling should say whether whitespace is ignored")
+	}
+}
+
+func TestWrap_HKeepsFocusOnUnwrappedDiff(t *testing.T) {
+	m := newTestModel(t)
+	m.diffPanel.SetSize(30, 10)
+	m.diffPanel.SetDiff("Added regular file a.go:\n        1: " + strings.Repeat("x", 80))
+	m.focusedPane = PaneDiff
+	m.updatePanelFocus()
+
+	m.actionToggleWrap()
+
+	result, _ := m.handleKeyMsg(tea.KeyPressMsg(tea.Key{Code: 'h', Text: "h"}))
+	if got := result.(*Model).focusedPane; got != PaneDiff {
+		t.Errorf("h should scroll unwrapped lines, not leave the diff for pane %v", got)
+	}
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// diffToolExitedMsg is sent when the external diff tool exits.
+type diffToolExitedMsg struct {
+	err error
+}
+
+// diffToolTarget returns the revision, and the file within it, to open in
+// the diff tool: the selected change in the log, or the selected file in the
+// files view (the whole change when the cursor is on a directory).
+func (m *Model) diffToolTarget() (rev, path string, ok bool) {
+	if m.focusedPane != PaneLog {
+		return "", "", false
+	}
+
+	switch m.viewMode {
+	case ViewLog:
+		if change := m.logPanel.SelectedChange(); change != nil {
+			return change.ChangeID, "", true
+		}
+	case ViewFiles:
+		if m.filesPanel.ChangeID() == "" {
+			return "", "", false
+		}
+
+		if file := m.filesPanel.SelectedFile(); file != nil {
+			return m.filesPanel.ChangeID(), file.Path, true
+		}
+
+		return m.filesPanel.ChangeID(), "", true
+	case ViewBookmarks, ViewStacks:
+	}
+
+	return "", "", false
+}
+
+// actionDiffTool suspends the TUI and shows the selected change or file in
+// the configured external diff tool, with jj writing the contents to compare.
+func (m *Model) actionDiffTool() (Model, tea.Cmd) {
+	rev, path, ok := m.diffToolTarget()
+	if !ok {
+		return *m, nil
+	}
+
+	cmd := m.runner.DiffToolCmd(rev, path, m.diffTool)
+
+	return *m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return diffToolExitedMsg{err: err}
+	})
+}
+
+// handleDiffToolExited reports a diff tool that could not run or failed.
+func (m *Model) handleDiffToolExited(msg diffToolExitedMsg) tea.Cmd {
+	if msg.err != nil {
+		return m.handleErr(errMsg{fmt.Errorf("diff tool: %w", msg.err)})
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestDiffToolTarget(t *testing.T) {
+	m := newTestModel(t)
+
+	if _, _, ok := m.diffToolTarget(); ok {
+		t.Error("an empty log has nothing to open")
+	}
+
+	m.logPanel.SetContent("@ xsssnyux 1\n", []jj.Change{{ChangeID: "xsssnyux"}})
+
+	if rev, path, ok := m.diffToolTarget(); !ok || rev != "xsssnyux" || path != "" {
+		t.Errorf("expected the selected change, got %q %q %v", rev, path, ok)
+	}
+
+	m.viewMode = ViewFiles
+	m.filesPanel.SetFiles("qpvuntsm", "qp", []jj.File{{Path: "main.go", Status: jj.FileModified}})
+
+	if rev, path, ok := m.diffToolTarget(); !ok || rev != "qpvuntsm" || path != "main.go" {
+		t.Errorf("expected the selected file, got %q %q %v", rev, path, ok)
+	}
+
+	m.focusedPane = PaneDiff
+
+	if _, _, ok := m.diffToolTarget(); ok {
+		t.Error("the diff tool should need the log or files focused")
+	}
+}
+
+func TestDiffToolExited_ReportsFailure(t *testing.T) {
+	m := newTestModel(t)
+
+	m.handleDiffToolExited(diffToolExitedMsg{})
+
+	if m.toasts.HasErrors() {
+		t.Error("a clean exit should not report anything")
+	}
+
+	m.handleDiffToolExited(diffToolExitedMsg{err: errors.New("exit status 2")})
+
+	if !m.toasts.HasErrors() {
+		t.Error("a failed diff tool should be reported")
+	}
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+)
+
+type duplicateCompleteMsg struct {
+	changeID string
+}
+
+type backoutCompleteMsg struct {
+	changeID string
+}
+
+// actionDuplicate copies the change selected in the log onto the same
+// parents.
+func (m *Model) actionDuplicate() (Model, tea.Cmd) {
+	if m.focusedPane != PaneLog || m.viewMode != ViewLog {
+		return *m, nil
+	}
+
+	selected := m.logPanel.SelectedChange()
+	if selected == nil {
+		return *m, nil
+	}
+
+	return *m, m.runDuplicate(selected.ChangeID)
+}
+
+// actionBackout creates a change on top of the working copy that undoes the
+// change selected in the log.
+func (m *Model) actionBackout() (Model, tea.Cmd) {
+	if m.focusedPane != PaneLog || m.viewMode != ViewLog {
+		return *m, nil
+	}
+
+	selected := m.logPanel.SelectedChange()
+	if selected == nil {
+		return *m, nil
+	}
+
+	return *m, m.runBackout(selected.ChangeID)
+}
+
+// runDuplicate executes jj duplicate and returns a completion message.
+func (m *Model) runDuplicate(changeID string) tea.Cmd {
+	runner := m.runner
+
+	return m.startJob("jj duplicate "+changeID, func() tea.Msg {
+		if err := runner.Duplicate(changeID); err != nil {
+			return errMsg{err}
+		}
+
+		return duplicateCompleteMsg{changeID: changeID}
+	})
+}
+
+// runBackout backs out a change onto the working copy and returns a
+// completion message.
+func (m *Model) runBackout(changeID string) tea.Cmd {
+	runner := m.runner
+
+	return m.startJob("jj backout -r "+changeID, func() tea.Msg {
+		if err := runner.Backout(changeID); err != nil {
+			return errMsg{err}
+		}
+
+		return backoutCompleteMsg{changeID: changeID}
+	})
+}
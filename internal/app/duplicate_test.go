@@ -0,0 +1,62 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestDuplicateAndBackout_RunOnSelectedChange(t *testing.T) {
+	for name, action := range map[string]Action{
+		"duplicate": (*Model).actionDuplicate,
+		"backout":   (*Model).actionBackout,
+	} {
+		t.Run(name, func(t *testing.T) {
+			m := newTestModel(t)
+			m.logPanel.SetContent("@ aaaaaaaa\n", []jj.Change{{ChangeID: "aaaaaaaa"}})
+
+			next, _ := action(m)
+			*m = next
+
+			if !m.jobs.busy() {
+				t.Errorf("%s should run on the selected change", name)
+			}
+		})
+	}
+}
+
+func TestDuplicateAndBackout_OnlyFromTheLog(t *testing.T) {
+	for name, action := range map[string]Action{
+		"duplicate": (*Model).actionDuplicate,
+		"backout":   (*Model).actionBackout,
+	} {
+		t.Run(name, func(t *testing.T) {
+			m := newTestModel(t)
+			m.logPanel.SetContent("@ aaaaaaaa\n", []jj.Change{{ChangeID: "aaaaaaaa"}})
+			m.focusedPane = PaneOpLog
+
+			next, cmd := action(m)
+			*m = next
+
+			if cmd != nil || m.jobs.busy() {
+				t.Errorf("%s should need a change selected in the log", name)
+			}
+		})
+	}
+}
+
+func TestDuplicateAndBackout_CompletionToasts(t *testing.T) {
+	m := newTestModel(t)
+
+	m.Update(duplicateCompleteMsg{changeID: "aaaaaaaa"})
+	m.Update(backoutCompleteMsg{changeID: "aaaaaaaa"})
+
+	items := m.toasts.Items()
+	if len(items) != 2 || items[0].Text != "duplicated aaaaaaaa" || items[1].Text != "backed out aaaaaaaa onto @" {
+		t.Errorf("unexpected toasts: %+v", items)
+	}
+}
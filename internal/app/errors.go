@@ -0,0 +1,92 @@
+package app
+
+import (
+	"errors"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui"
+)
+
+// failure is an error reported to the user, kept for the error panel.
+type failure struct {
+	err error
+	job *queuedJob // the jj command that failed, run again on retry; nil reloads instead
+}
+
+// command names what failed: the job's label, or the jj command.
+func (f *failure) command() string {
+	if f.job != nil {
+		return f.job.label
+	}
+
+	var jjErr *jj.Error
+	if errors.As(f.err, &jjErr) {
+		return "jj " + jjErr.Command
+	}
+
+	return "jj"
+}
+
+// details returns the full error output and jj's hints.
+func (f *failure) details() (string, []string) {
+	var jjErr *jj.Error
+	if errors.As(f.err, &jjErr) {
+		return jjErr.Message(), jjErr.Hints()
+	}
+
+	return f.err.Error(), nil
+}
+
+// errorKey returns the error details binding, enabled only once something
+// has failed.
+func (m *Model) errorKey() key.Binding {
+	binding := m.keys.ErrorInfo
+	binding.SetEnabled(m.lastError != nil)
+
+	return binding
+}
+
+// actionErrorDetails opens the error panel on the last failure.
+func (m *Model) actionErrorDetails() (Model, tea.Cmd) {
+	if m.lastError == nil {
+		return *m, nil
+	}
+
+	retryHint := "reload"
+	if m.lastError.job != nil {
+		retryHint = "run again"
+	}
+
+	message, hints := m.lastError.details()
+	m.errorPanel.SetError(m.lastError.command(), message, hints, retryHint)
+	m.errorPanel.SetSize(m.width, m.height)
+	m.showingError = true
+
+	return *m, nil
+}
+
+// handleErrorPanel closes the error panel and retries if asked: the failed
+// jj command runs again, or when a load failed, everything reloads.
+func (m *Model) handleErrorPanel(msg ui.ErrorPanelMsg) tea.Cmd {
+	m.showingError = false
+
+	if !msg.Retry || m.lastError == nil {
+		return nil
+	}
+
+	job := m.lastError.job
+	if job == nil {
+		return m.refreshViews()
+	}
+
+	if m.timeTravelOp != "" {
+		return m.toasts.Info("return to the present (esc) before " + job.label + " changes the repo")
+	}
+
+	m.toasts.Dismiss()
+
+	return m.startJob(job.label, job.cmd)
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestErrorPanel_OpensOnLastFailure(t *testing.T) {
+	m := newTestModel(t)
+
+	if m.errorKey().Enabled() {
+		t.Error("with nothing failed there are no details to show")
+	}
+
+	m.handleErr(errMsg{&jj.Error{Command: "log", Stderr: "Error: Revision `nope` doesn't exist\nHint: Did you mean `main`?"}})
+
+	if !m.errorKey().Enabled() {
+		t.Fatal("a failure should offer its details")
+	}
+
+	next, _ := m.actionErrorDetails()
+	*m = next
+
+	if !m.showingError {
+		t.Fatal("E should open the error panel")
+	}
+
+	if got := m.lastError.command(); got != "jj log" {
+		t.Errorf("command() = %q, want %q", got, "jj log")
+	}
+
+	if message, hints := m.lastError.details(); message != "Error: Revision `nope` doesn't exist" || len(hints) != 1 {
+		t.Errorf("details() = %q, %q", message, hints)
+	}
+
+	m.handleKeyMsg(tea.KeyPressMsg(tea.Key{Code: 'n', Text: "n"}))
+
+	if m.confirming || !m.showingError {
+		t.Error("the panel should take all input while open")
+	}
+
+	m.handleErrorPanel(ui.ErrorPanelMsg{})
+
+	if m.showingError {
+		t.Error("closing should hide the panel")
+	}
+}
+
+func TestErrorPanel_RetryRunsFailedJobAgain(t *testing.T) {
+	m := newTestModel(t)
+
+	failing := func() tea.Msg {
+		return errMsg{errors.New("jj failed")}
+	}
+
+	m.startJob("jj new", failing)
+	m.handleJobDone(jobDoneMsg{id: 1, result: failing(), job: queuedJob{label: "jj new", cmd: failing}})
+
+	if m.lastError == nil || m.lastError.job == nil || m.lastError.job.label != "jj new" {
+		t.Fatalf("the failed job should be kept to retry, got %+v", m.lastError)
+	}
+
+	m.handleErrorPanel(ui.ErrorPanelMsg{Retry: true})
+
+	if !m.jobs.busy() {
+		t.Error("retrying should start the job again")
+	}
+}
+
+func TestErrorPanel_RetryAfterLoadFailureReloads(t *testing.T) {
+	m := newTestModel(t)
+	m.handleErr(errMsg{errors.New("jj command failed")})
+
+	if m.lastError.job != nil {
+		t.Fatal("a failed load is not a job")
+	}
+
+	if cmd := m.handleErrorPanel(ui.ErrorPanelMsg{Retry: true}); cmd == nil {
+		t.Error("retrying a failed load should reload")
+	}
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// fetchTimeFormat shows when the remotes were last fetched in the status bar.
+const fetchTimeFormat = "15:04"
+
+// fetchTickMsg fires when the next background fetch is due.
+type fetchTickMsg struct{}
+
+// fetchedMsg reports a background fetch: when it finished, the remote
+// bookmarks it brought new commits for, or why it failed.
+type fetchedMsg struct {
+	at        time.Time
+	bookmarks []string
+	err       error
+}
+
+// scheduleFetch sets the timer for the next background fetch. None is set
+// when fetching is off or in read-only mode, since a fetch changes the repo.
+func (m *Model) scheduleFetch() tea.Cmd {
+	if m.fetchInterval <= 0 || m.readOnly {
+		return nil
+	}
+
+	return tea.Tick(m.fetchInterval, func(time.Time) tea.Msg { return fetchTickMsg{} })
+}
+
+// handleFetchTick runs jj git fetch in the background, as a job, so
+// commands changing the repo queue behind it. While one runs, the fetch
+// waits for the next tick instead.
+func (m *Model) handleFetchTick() tea.Cmd {
+	if m.jobs.busy() {
+		return m.scheduleFetch()
+	}
+
+	runner := m.runner
+
+	return m.startJob("jj git fetch", func() tea.Msg {
+		output, err := runner.GitFetch()
+
+		return fetchedMsg{at: time.Now(), bookmarks: jj.FetchedBookmarks(output), err: err}
+	})
+}
+
+// handleFetched shows when the remotes were fetched and which bookmarks
+// moved, refreshing the views unless refreshing is paused. The first
+// failure is toasted; later ones, until a fetch works again, are only
+// logged, so being offline does not toast every interval.
+func (m *Model) handleFetched(msg fetchedMsg) tea.Cmd {
+	next := m.scheduleFetch()
+
+	if msg.err != nil {
+		m.log.Warn("background fetch failed", "err", msg.err)
+
+		if m.fetchFailing {
+			return next
+		}
+
+		m.fetchFailing = true
+
+		return tea.Batch(next, m.toasts.Error("background fetch failed: "+msg.err.Error()))
+	}
+
+	m.fetchFailing = false
+	m.statusBar.SetLastFetch(msg.at.Format(fetchTimeFormat))
+
+	if len(msg.bookmarks) == 0 {
+		return next
+	}
+
+	cmds := []tea.Cmd{next, m.toasts.Info("fetched new commits: " + strings.Join(msg.bookmarks, ", "))}
+
+	if !m.refreshPaused {
+		cmds = append(cmds, m.refreshViews())
+	}
+
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,113 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"pgregory.net/rapid"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestScheduleFetch_OptIn(t *testing.T) {
+	m := newTestModel(t)
+
+	if m.scheduleFetch() != nil {
+		t.Error("chado should not fetch unless an interval is configured")
+	}
+
+	m.fetchInterval = 10 * time.Minute
+	if m.scheduleFetch() == nil {
+		t.Error("a configured interval should set the timer")
+	}
+
+	m.readOnly = true
+	if m.scheduleFetch() != nil {
+		t.Error("read-only mode should not fetch, since fetching changes the repo")
+	}
+}
+
+func TestHandleFetchTick_QueuesMutations(t *testing.T) {
+	m := newTestModel(t)
+	m.fetchInterval = 10 * time.Minute
+
+	if m.handleFetchTick() == nil || !m.jobs.busy() {
+		t.Fatal("the fetch should run as a job")
+	}
+
+	m.startJob("jj new", func() tea.Msg { return nil })
+
+	if len(m.jobs.queue) != 1 {
+		t.Error("a command changing the repo should wait for the fetch")
+	}
+}
+
+func TestHandleFetched_ShowsTimeAndMovedBookmarks(t *testing.T) {
+	m := newTestModel(t)
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m.fetchInterval = 10 * time.Minute
+
+	at := time.Date(2026, 10, 16, 14, 5, 0, 0, time.Local)
+	m.handleFetched(fetchedMsg{at: at, bookmarks: []string{"main@origin", "push-parser@origin"}})
+
+	if view := m.renderStatusBar(); !strings.Contains(view, "fetched 14:05") {
+		t.Errorf("the status bar should show when the remotes were fetched, got %q", view)
+	}
+
+	toasts := m.toasts.Items()
+	if len(toasts) != 1 || toasts[0].Text != "fetched new commits: main@origin, push-parser@origin" {
+		t.Errorf("the bookmarks that moved should be toasted, got %+v", toasts)
+	}
+
+	m.handleFetched(fetchedMsg{at: at.Add(10 * time.Minute)})
+
+	if got := len(m.toasts.Items()); got != 1 {
+		t.Errorf("a fetch that brought nothing should not toast, got %d toasts", got)
+	}
+}
+
+func TestHandleFetched_ToastsFirstFailure(t *testing.T) {
+	m := newTestModel(t)
+	failure := fetchedMsg{err: errors.New("could not resolve host")}
+
+	m.handleFetched(failure)
+	m.handleFetched(failure)
+
+	if got := len(m.toasts.Items()); got != 1 {
+		t.Fatalf("only the first failure should be toasted, got %d toasts", got)
+	}
+
+	m.handleFetched(fetchedMsg{at: time.Now()})
+	m.handleFetched(failure)
+
+	if got := len(m.toasts.Items()); got != 2 {
+		t.Errorf("failing again after a fetch worked should be toasted, got %d toasts", got)
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: the timer is set again after every fetch, however it went, as
+// long as an interval is configured.
+func TestHandleFetched_KeepsTicking(t *testing.T) {
+	rapid.Check(t, func(rt *rapid.T) {
+		m := newTestModel(t)
+		m.fetchInterval = time.Duration(rapid.IntRange(0, 60).Draw(rt, "minutes")) * time.Minute
+
+		msg := fetchedMsg{at: time.Now()}
+		if rapid.Bool().Draw(rt, "failed") {
+			msg.err = errors.New("offline")
+		}
+
+		if cmd := m.handleFetched(msg); (cmd != nil) != (m.fetchInterval > 0) {
+			rt.Fatalf("interval %v: timer set = %v", m.fetchInterval, cmd != nil)
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestFileContent_ToggleOnlyInFilesView(t *testing.T) {
+	m := newTestModel(t)
+
+	m.actionToggleFileContent()
+
+	if m.fileContent {
+		t.Fatal("the log view has no file to show")
+	}
+
+	m.viewMode = ViewFiles
+	m.filesPanel.SetFiles("bbbbbbbb", "bb", []jj.File{{Path: "main.go", Status: jj.FileModified}})
+
+	if _, cmd := m.actionToggleFileContent(); !m.fileContent || cmd == nil {
+		t.Error("the files view should switch to content and load it")
+	}
+
+	m.actionToggleFileContent()
+
+	if m.fileContent {
+		t.Error("toggling again should go back to diffs")
+	}
+}
+
+func TestFileContent_Loaded(t *testing.T) {
+	m := newTestModel(t)
+	_, generation := m.diffRequests.next(m.ctx)
+
+	m.handleFileDiffLoaded(fileDiffLoadedMsg{diffOutput: "package main\n", contentPath: "main.go", generation: generation})
+
+	if !m.diffPanel.ShowingFileContent() || m.diffPanel.Title() != "Content" {
+		t.Errorf("content should be shown as such, got %q", m.diffPanel.Title())
+	}
+
+	_, generation = m.diffRequests.next(m.ctx)
+	m.handleFileDiffLoaded(fileDiffLoadedMsg{diffOutput: "Modified regular file main.go:\n", generation: generation})
+
+	if m.diffPanel.ShowingFileContent() || m.diffPanel.Title() != "Patch" {
+		t.Errorf("a diff should be shown as a patch, got %q", m.diffPanel.Title())
+	}
+}
@@ -0,0 +1,19 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/ui"
+)
+
+// handleFileJump goes on from a ]f or [f that moved past the diff's last or
+// first file: drilled into a change, the files list selects the next or
+// previous file, whose diff then loads. The log's diff of a whole change
+// has no further files, so the jump stops there.
+func (m *Model) handleFileJump(msg ui.FileJumpMsg) tea.Cmd {
+	if m.viewMode != ViewFiles || !m.filesPanel.MoveFiles(msg.Files) {
+		return nil
+	}
+
+	return m.debounceDiffLoad()
+}
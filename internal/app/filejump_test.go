@@ -0,0 +1,64 @@
+package app
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestFileJump_MovesFilesSelection(t *testing.T) {
+	m := newTestModel(t)
+	m.viewMode = ViewFiles
+	m.filesPanel.SetFiles("bbbbbbbb", "bb", []jj.File{
+		{Path: "a.go", Status: jj.FileModified},
+		{Path: "b.go", Status: jj.FileModified},
+		{Path: "c.go", Status: jj.FileModified},
+	})
+
+	if cmd := m.handleFileJump(ui.FileJumpMsg{Files: 2}); cmd == nil {
+		t.Fatal("jumping on should load the next file's diff")
+	}
+
+	if got := m.filesPanel.SelectedFile().Path; got != "c.go" {
+		t.Errorf("expected c.go selected, got %s", got)
+	}
+
+	if cmd := m.handleFileJump(ui.FileJumpMsg{Files: 1}); cmd != nil {
+		t.Error("there is no file after the last")
+	}
+}
+
+func TestFileJump_StopsInLog(t *testing.T) {
+	m := newTestModel(t)
+
+	if cmd := m.handleFileJump(ui.FileJumpMsg{Files: 1}); cmd != nil {
+		t.Error("the log's diff has no files past its last")
+	}
+}
+
+func TestFileJump_KeysBypassGlobalBindings(t *testing.T) {
+	m := newTestModel(t)
+	m.diffPanel.SetSize(80, 20)
+	m.diffPanel.SetDiff("Added regular file a.go:\n        1: package a\n        2:")
+	m.focusedPane = PaneDiff
+	m.updatePanelFocus()
+
+	m.handleKeyMsg(tea.KeyPressMsg(tea.Key{Code: ']', Text: "]"}))
+
+	if !m.diffPanel.KeyPending() {
+		t.Fatal("the ] of ]f should reach the diff, not move the working copy")
+	}
+
+	_, cmd := m.handleKeyMsg(tea.KeyPressMsg(tea.Key{Code: 'f', Text: "f"}))
+
+	if _, ok := cmd().(ui.FileJumpMsg); !ok || m.diffPanel.KeyPending() {
+		t.Error("]f past the diff's only file should be sent on to the files list")
+	}
+}
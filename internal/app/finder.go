@@ -0,0 +1,192 @@
+package app
+
+import (
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui"
+)
+
+const (
+	// finderWidthPct is the finder overlay's share of screen width.
+	finderWidthPct = 60
+
+	// finderHeightPct is the finder overlay's share of screen height.
+	finderHeightPct = 70
+)
+
+// finderFilesLoadedMsg carries the files of the change the finder searches,
+// and whether the loaded changes are offered ahead of them.
+type finderFilesLoadedMsg struct {
+	files      filesLoadedMsg
+	everything bool
+}
+
+// finderTarget returns the revision whose files the finder searches: the
+// change shown in the files view, the change selected in the log, bookmarks,
+// or stacks panel, or the working copy when nothing is selected.
+func (m *Model) finderTarget() string {
+	switch m.viewMode {
+	case ViewFiles:
+		if changeID := m.filesPanel.ChangeID(); changeID != "" {
+			return changeID
+		}
+	case ViewLog:
+		if change := m.logPanel.SelectedChange(); change != nil {
+			return change.ChangeID
+		}
+	case ViewBookmarks:
+		if bookmark := m.bookmarksPanel.SelectedBookmark(); bookmark != nil && bookmark.ChangeID != "" {
+			return bookmark.ChangeID
+		}
+	case ViewStacks:
+		if change := m.selectedStackChange(); change != nil {
+			return change.ChangeID
+		}
+	}
+
+	return "@"
+}
+
+// actionFindFile opens the fuzzy file finder over the target change's files.
+func (m *Model) actionFindFile() (Model, tea.Cmd) {
+	return *m, m.find(false)
+}
+
+// actionFindAnything opens the finder over the loaded changes, by change
+// ID, bookmark, and description, and the target change's files.
+func (m *Model) actionFindAnything() (Model, tea.Cmd) {
+	return *m, m.find(true)
+}
+
+// find opens the finder over the target change's files, and with
+// everything, the loaded changes. The files view already holds the files;
+// elsewhere they are loaded first.
+func (m *Model) find(everything bool) tea.Cmd {
+	target := m.finderTarget()
+
+	if m.viewMode == ViewFiles && target == m.filesPanel.ChangeID() {
+		return m.openFinder(finderFilesLoadedMsg{
+			files: filesLoadedMsg{
+				changeID:  target,
+				shortCode: m.filesPanel.ShortCode(),
+				files:     m.filesPanel.Files(),
+			},
+			everything: everything,
+		})
+	}
+
+	load := m.loadFiles(target)
+
+	return func() tea.Msg {
+		msg := load()
+		if files, ok := msg.(filesLoadedMsg); ok {
+			return finderFilesLoadedMsg{files: files, everything: everything}
+		}
+
+		return msg
+	}
+}
+
+// openFinder shows the finder over a change's file paths, after the loaded
+// changes when it searches everything.
+func (m *Model) openFinder(msg finderFilesLoadedMsg) tea.Cmd {
+	files := msg.files
+	title := "Files in " + files.shortCode
+
+	m.finderChanges = nil
+	if msg.everything {
+		m.finderChanges = slices.Clone(m.changes)
+		title = "Changes, and files in " + files.shortCode
+	}
+
+	items := make([]ui.FinderItem, 0, len(m.finderChanges)+len(files.files))
+	for _, change := range m.finderChanges {
+		items = append(items, ui.FinderItem{Label: finderChangeLabel(change), Detail: "change"})
+	}
+
+	for _, file := range files.files {
+		items = append(items, ui.FinderItem{Label: file.Path, Detail: string(file.Status)})
+	}
+
+	m.finderFiles = files
+	m.finding = true
+	m.sizeFinder()
+
+	return m.finder.Open(title, items)
+}
+
+// finderChangeLabel is the text a change is found by: its change ID,
+// bookmarks, and the first line of its description.
+func finderChangeLabel(change jj.Change) string {
+	parts := []string{change.ChangeID}
+	for _, bookmark := range change.Bookmarks {
+		parts = append(parts, "⚑"+bookmark)
+	}
+
+	if description, _, _ := strings.Cut(change.Description, "\n"); description != "" {
+		parts = append(parts, description)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// sizeFinder fits the finder overlay to the current window.
+func (m *Model) sizeFinder() {
+	m.finder.SetSize(m.width*finderWidthPct/percentDivisor, m.height*finderHeightPct/percentDivisor)
+}
+
+// handleFinderSelect selects a picked change in the log, or jumps to the
+// picked file's diff, drilling into the files view of its change unless that
+// view is already showing.
+func (m *Model) handleFinderSelect(msg ui.FinderSelectMsg) tea.Cmd {
+	m.finding = false
+	files, changes := m.finderFiles, m.finderChanges
+	m.finderFiles, m.finderChanges = filesLoadedMsg{}, nil
+
+	if msg.Index >= 0 && msg.Index < len(changes) {
+		return m.selectFoundChange(changes[msg.Index].ChangeID)
+	}
+
+	path := msg.Item.Label
+
+	if m.viewMode == ViewFiles && m.filesPanel.ChangeID() == files.changeID {
+		m.filesPanel.SelectPath(path)
+		return m.loadFileDiff(files.changeID, path)
+	}
+
+	m.viewMode = ViewFiles
+	m.focusedPane = PaneLog
+	m.updatePanelFocus()
+
+	return m.showFiles(files, path)
+}
+
+// selectFoundChange goes back to the log, from a side view if need be,
+// with the found change selected.
+func (m *Model) selectFoundChange(changeID string) tea.Cmd {
+	var cmds []tea.Cmd
+	if m.viewMode != ViewLog {
+		cmds = append(cmds, m.handleBack())
+	}
+
+	m.focusedPane = PaneLog
+	m.updatePanelFocus()
+
+	if !m.logPanel.SelectChangeID(changeID) {
+		return tea.Batch(append(cmds, m.toasts.Info(changeID+" is not in the log"))...)
+	}
+
+	return tea.Batch(append(cmds, m.loadSelectedDiff(), m.loadVisibleStats(), m.loadMoreLog())...)
+}
+
+// handleFinderCancel closes the finder or palette without acting.
+func (m *Model) handleFinderCancel() {
+	m.finding = false
+	m.finderFiles = filesLoadedMsg{}
+	m.finderChanges = nil
+	m.palette = nil
+}
@@ -0,0 +1,133 @@
+package app
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func testFinderFiles(changeID string) filesLoadedMsg {
+	return filesLoadedMsg{
+		changeID:  changeID,
+		shortCode: changeID[:4],
+		files: []jj.File{
+			{Path: "internal/app/app.go", Status: jj.FileModified},
+			{Path: "internal/ui/finder.go", Status: jj.FileAdded},
+		},
+	}
+}
+
+func TestFinderTarget_FallsBackToWorkingCopy(t *testing.T) {
+	m := newTestModel(t)
+
+	if target := m.finderTarget(); target != "@" {
+		t.Errorf("expected working copy with nothing selected, got %q", target)
+	}
+
+	m.viewMode = ViewFiles
+	m.filesPanel.SetFiles("xsssnyux", "xsss", nil)
+
+	if target := m.finderTarget(); target != "xsssnyux" {
+		t.Errorf("expected the files view's change, got %q", target)
+	}
+}
+
+func TestFinder_SelectDrillsIntoFile(t *testing.T) {
+	m := newTestModel(t)
+	m.openFinder(finderFilesLoadedMsg{files: testFinderFiles("xsssnyux")})
+
+	if !m.finding {
+		t.Fatal("finder should be open")
+	}
+
+	m.handleFinderSelect(ui.FinderSelectMsg{Item: ui.FinderItem{Label: "internal/ui/finder.go"}, Index: 1})
+
+	if m.finding {
+		t.Error("selecting should close the finder")
+	}
+
+	if m.viewMode != ViewFiles || m.filesPanel.ChangeID() != "xsssnyux" {
+		t.Fatalf("expected files view of xsssnyux, got mode %v change %q", m.viewMode, m.filesPanel.ChangeID())
+	}
+
+	if file := m.filesPanel.SelectedFile(); file == nil || file.Path != "internal/ui/finder.go" {
+		t.Errorf("expected the picked file selected, got %+v", file)
+	}
+}
+
+func TestFinder_SelectWithinFilesView(t *testing.T) {
+	m := newTestModel(t)
+	files := testFinderFiles("xsssnyux")
+	m.viewMode = ViewFiles
+	m.filesPanel.SetFiles(files.changeID, files.shortCode, files.files)
+
+	m.actionFindFile()
+
+	if !m.finding {
+		t.Fatal("finder should open at once from the files view")
+	}
+
+	m.handleFinderSelect(ui.FinderSelectMsg{Item: ui.FinderItem{Label: "internal/ui/finder.go"}, Index: 1})
+
+	if file := m.filesPanel.SelectedFile(); file == nil || file.Path != "internal/ui/finder.go" {
+		t.Errorf("expected the picked file selected, got %+v", file)
+	}
+}
+
+func TestFinder_CancelKeepsView(t *testing.T) {
+	m := newTestModel(t)
+	m.openFinder(finderFilesLoadedMsg{files: testFinderFiles("xsssnyux")})
+
+	m.handleFinderCancel()
+
+	if m.finding || m.viewMode != ViewLog {
+		t.Errorf("cancel should close the finder and stay in the log, got finding=%v mode=%v", m.finding, m.viewMode)
+	}
+}
+
+func TestFindAnything_OffersChangesThenFiles(t *testing.T) {
+	m := newTestModel(t)
+	m.changes = []jj.Change{{ChangeID: "aaaaaaaa", Bookmarks: []string{"main"}, Description: "parser: handle tabs\n\nlonger"}}
+
+	m.openFinder(finderFilesLoadedMsg{files: testFinderFiles("xsssnyux"), everything: true})
+
+	m.finder.Update(tea.KeyPressMsg(tea.Key{Code: 'm', Text: "m"}))
+
+	matches := m.finder.Matches()
+	if len(matches) == 0 || matches[0].Label != "aaaaaaaa ⚑main parser: handle tabs" {
+		t.Errorf("expected the change found by its bookmark, got %+v", matches)
+	}
+}
+
+func TestFindAnything_SelectChangeGoesToLog(t *testing.T) {
+	m := newTestModel(t)
+	m.changes = []jj.Change{{ChangeID: "aaaaaaaa"}, {ChangeID: "bbbbbbbb"}}
+	m.logPanel.SetContent("@ aaaaaaaa\n○ bbbbbbbb\n", m.changes)
+	m.viewMode = ViewBookmarks
+
+	m.openFinder(finderFilesLoadedMsg{files: testFinderFiles("xsssnyux"), everything: true})
+	m.handleFinderSelect(ui.FinderSelectMsg{Index: 1})
+
+	if selected := m.logPanel.SelectedChange(); m.viewMode != ViewLog || selected == nil || selected.ChangeID != "bbbbbbbb" {
+		t.Errorf("picking a change should select it in the log, got mode %v and %+v", m.viewMode, selected)
+	}
+}
+
+func TestFindAnything_SelectFileAfterChanges(t *testing.T) {
+	m := newTestModel(t)
+	m.changes = []jj.Change{{ChangeID: "aaaaaaaa"}}
+
+	m.openFinder(finderFilesLoadedMsg{files: testFinderFiles("xsssnyux"), everything: true})
+	m.handleFinderSelect(ui.FinderSelectMsg{Item: ui.FinderItem{Label: "internal/ui/finder.go"}, Index: 2})
+
+	if file := m.filesPanel.SelectedFile(); m.viewMode != ViewFiles || file == nil || file.Path != "internal/ui/finder.go" {
+		t.Errorf("picking a file should drill into it, got %+v", file)
+	}
+}
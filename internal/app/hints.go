@@ -0,0 +1,134 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+const (
+	// hintFirstDelay is how long after startup the first tip may appear.
+	hintFirstDelay = 45 * time.Second
+
+	// hintInterval is the pause between tips.
+	hintInterval = 3 * time.Minute
+
+	// hintDuration is how long a tip stays in the status bar.
+	hintDuration = 10 * time.Second
+)
+
+// hintTip describes an action worth teaching and how to phrase it.
+type hintTip struct {
+	bindingID string
+	format    string // %s is replaced with the binding's key label
+}
+
+// hintTips returns the tips in the order they are offered.
+func hintTips() []hintTip {
+	return []hintTip{
+		{bindingID: "help", format: "tip: press %s for all keys"},
+		{bindingID: "describe", format: "tip: %s edits the description"},
+		{bindingID: "enter", format: "tip: %s shows the files in a change"},
+		{bindingID: "find-file", format: "tip: %s jumps to a file by name"},
+		{bindingID: "palette", format: "tip: %s lists every action"},
+		{bindingID: "next-pane", format: "tip: %s moves between panes"},
+		{bindingID: "new", format: "tip: %s starts a new change"},
+		{bindingID: "squash", format: "tip: %s squashes a change into its parent"},
+		{bindingID: "stats", format: "tip: %s shows diff stats in the log"},
+	}
+}
+
+// hintTickMsg fires when it's time to consider showing the next tip.
+type hintTickMsg struct{}
+
+// hintClearMsg removes a tip from the status bar after hintDuration.
+type hintClearMsg struct {
+	generation int // must match hintScheduler.generation or the clear is stale
+}
+
+// hintScheduler tracks which actions were used this session and picks tips
+// for the ones that weren't, one at a time.
+type hintScheduler struct {
+	enabled    bool
+	used       map[string]bool
+	shown      map[string]bool
+	generation int
+}
+
+// newHintScheduler creates a scheduler; a disabled one never shows tips.
+func newHintScheduler(enabled bool) *hintScheduler {
+	return &hintScheduler{
+		enabled: enabled,
+		used:    make(map[string]bool),
+		shown:   make(map[string]bool),
+	}
+}
+
+// start schedules the first tip, or nothing when disabled.
+func (h *hintScheduler) start() tea.Cmd {
+	if !h.enabled {
+		return nil
+	}
+
+	return tea.Tick(hintFirstDelay, func(time.Time) tea.Msg { return hintTickMsg{} })
+}
+
+// recordUse marks a binding as used so its tip is never shown.
+func (h *hintScheduler) recordUse(bindingID string) {
+	h.used[bindingID] = true
+}
+
+// next returns the text of the next tip for an unused, currently enabled
+// binding and marks it shown. Returns false when nothing is left to teach.
+func (h *hintScheduler) next(bindings []ActionBinding) (string, bool) {
+	if !h.enabled {
+		return "", false
+	}
+
+	byID := make(map[string]ActionBinding, len(bindings))
+	for _, ab := range bindings {
+		byID[ab.ID] = ab
+	}
+
+	for _, tip := range hintTips() {
+		if h.used[tip.bindingID] || h.shown[tip.bindingID] {
+			continue
+		}
+
+		ab, ok := byID[tip.bindingID]
+		if !ok || !ab.Key.Enabled() {
+			continue
+		}
+
+		h.shown[tip.bindingID] = true
+		h.generation++
+
+		return fmt.Sprintf(tip.format, ab.Key.Help().Key), true
+	}
+
+	return "", false
+}
+
+// handleHintTick shows the next tip (if any) and schedules its removal and the following tick.
+func (m *Model) handleHintTick() tea.Cmd {
+	text, ok := m.hints.next(m.globalBindings())
+	if !ok {
+		return nil // Nothing left to teach; stop ticking
+	}
+
+	m.statusBar.SetHint(text)
+	generation := m.hints.generation
+
+	return tea.Batch(
+		tea.Tick(hintDuration, func(time.Time) tea.Msg { return hintClearMsg{generation: generation} }),
+		tea.Tick(hintInterval, func(time.Time) tea.Msg { return hintTickMsg{} }),
+	)
+}
+
+// handleHintClear removes the current tip unless a newer one replaced it.
+func (m *Model) handleHintClear(msg hintClearMsg) {
+	if msg.generation == m.hints.generation {
+		m.statusBar.SetHint("")
+	}
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestHintScheduler_SkipsUsedBindings(t *testing.T) {
+	m := newTestModel(t)
+	m.hints.recordUse("help")
+
+	text, ok := m.hints.next(m.globalBindings())
+	if !ok {
+		t.Fatal("expected a tip")
+	}
+
+	if strings.Contains(text, "all keys") {
+		t.Errorf("tip for a used binding was shown: %q", text)
+	}
+
+	if text != "tip: d edits the description" {
+		t.Errorf("unexpected tip %q", text)
+	}
+}
+
+func TestHintScheduler_EachTipShownOnce(t *testing.T) {
+	m := newTestModel(t)
+	seen := make(map[string]bool)
+
+	for {
+		text, ok := m.hints.next(m.globalBindings())
+		if !ok {
+			break
+		}
+
+		if seen[text] {
+			t.Fatalf("tip shown twice: %q", text)
+		}
+
+		seen[text] = true
+	}
+
+	if len(seen) != len(hintTips()) {
+		t.Errorf("expected %d tips, got %d", len(hintTips()), len(seen))
+	}
+}
+
+func TestHintScheduler_Disabled(t *testing.T) {
+	hints := newHintScheduler(false)
+
+	if hints.start() != nil {
+		t.Error("disabled scheduler should not tick")
+	}
+
+	if _, ok := hints.next(nil); ok {
+		t.Error("disabled scheduler should not return tips")
+	}
+}
+
+func TestDispatch_RecordsUsage(t *testing.T) {
+	m := newTestModel(t)
+
+	dispatchKey(m, tea.KeyPressMsg(tea.Key{Code: '?'}), m.globalBindings())
+
+	if !m.hints.used["help"] {
+		t.Error("dispatching ? should record the help binding as used")
+	}
+}
+
+func TestHintClear_IgnoresStaleGeneration(t *testing.T) {
+	m := newTestModel(t)
+
+	m.handleHintTick()
+	first := m.statusBar.Hint()
+
+	m.handleHintTick()
+	m.handleHintClear(hintClearMsg{generation: 1})
+
+	if m.statusBar.Hint() == "" || m.statusBar.Hint() == first {
+		t.Errorf("stale clear should not remove the newer tip, got %q", m.statusBar.Hint())
+	}
+
+	m.handleHintClear(hintClearMsg{generation: m.hints.generation})
+
+	if m.statusBar.Hint() != "" {
+		t.Errorf("current clear should remove the tip, got %q", m.statusBar.Hint())
+	}
+}
@@ -0,0 +1,182 @@
+package app
+
+import (
+	"strconv"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui/help"
+)
+
+// hunksBindingID identifies what can be done with picked hunks.
+const hunksBindingID = "pick-hunks"
+
+// hunksLoadedMsg carries the git-format diff to pick hunks of rev from.
+type hunksLoadedMsg struct {
+	rev  string
+	diff string
+}
+
+// hunksCompleteMsg reports picked hunks moved out of, or discarded from, a
+// change.
+type hunksCompleteMsg struct {
+	rev     string
+	summary string
+}
+
+// actionPickHunks shows the hunks of the change selected in the log in the
+// diff pane, to mark some and squash or discard just those. In the files
+// view only the marked files' hunks are shown, or the selected file's.
+func (m *Model) actionPickHunks() (Model, tea.Cmd) {
+	if m.focusedPane != PaneLog {
+		return *m, nil
+	}
+
+	var (
+		rev   string
+		paths []string
+	)
+
+	switch m.viewMode {
+	case ViewLog:
+		if selected := m.logPanel.SelectedChange(); selected != nil {
+			rev = selected.ChangeID
+		}
+	case ViewFiles:
+		rev, paths = m.filesPanel.ChangeID(), m.squashFilesPaths()
+		if len(paths) == 0 {
+			return *m, nil
+		}
+	case ViewBookmarks, ViewStacks:
+	}
+
+	if rev == "" {
+		return *m, nil
+	}
+
+	if change, _ := m.loadedChange(rev); change.IsImmutable {
+		return *m, m.refuseImmutable("pick hunks of", rev)
+	}
+
+	return *m, m.loadHunks(rev, paths)
+}
+
+// loadHunks fetches the diff of rev, or of paths in it, to pick hunks from.
+func (m *Model) loadHunks(rev string, paths []string) tea.Cmd {
+	runner := m.runner
+
+	return func() tea.Msg {
+		diff, err := runner.HunkDiff(rev, paths...)
+		if err != nil {
+			return errMsg{err}
+		}
+
+		return hunksLoadedMsg{rev: rev, diff: diff}
+	}
+}
+
+// handleHunksLoaded starts picking hunks in the diff pane, focused.
+func (m *Model) handleHunksLoaded(msg hunksLoadedMsg) tea.Cmd {
+	if !m.diffPanel.StartHunkPick("Pick hunks: "+msg.rev, msg.diff) {
+		return m.toasts.Info("no hunks to pick in " + msg.rev)
+	}
+
+	m.hunkPickRev = msg.rev
+	m.focusedPane = PaneDiff
+	m.updatePanelFocus()
+
+	return m.startLogPanelBorderAnim()
+}
+
+// handleHunkPick offers what to do with the marked hunks: squash them into
+// the change's parent or another mutable change, or discard them.
+func (m *Model) handleHunkPick() tea.Cmd {
+	rev := m.hunkPickRev
+	patch := m.diffPanel.PickedHunks()
+
+	if rev == "" || len(patch) == 0 {
+		return nil
+	}
+
+	m.palette = append(squashMenu(hunksBindingID, rev, m.changes, func(m *Model, into string) tea.Cmd {
+		return m.runSquashHunks(rev, into, patch)
+	}), ActionBinding{
+		Binding: help.Binding{Key: key.NewBinding(key.WithHelp("jj restore -i --changes-in "+rev, "none: discard them from "+rev))},
+		ID:      hunksBindingID,
+		Action: func(m *Model) (Model, tea.Cmd) {
+			return *m, m.runDiscardHunks(rev, patch)
+		},
+		Mutates: true,
+	})
+	m.finding = true
+	m.sizeFinder()
+
+	return m.finder.Open("Squash "+describeHunks(patch)+" of "+rev+" into", paletteItems(m.palette))
+}
+
+// runSquashHunks moves the picked hunks of from into another change, or
+// into from's parent when into is empty, and stops picking.
+func (m *Model) runSquashHunks(from, into string, patch []jj.FileDiff) tea.Cmd {
+	m.diffPanel.StopHunkPick()
+
+	label := "jj squash -i -r " + from
+	summary := "squashed " + describeHunks(patch) + " of " + from + " into its parent"
+
+	if into != "" {
+		label = "jj squash -i --from " + from + " --into " + into
+		summary = "squashed " + describeHunks(patch) + " of " + from + " into " + into
+	}
+
+	runner := m.runner
+
+	return m.startJob(label, func() tea.Msg {
+		if err := runner.SquashHunks(from, into, patch); err != nil {
+			return errMsg{err}
+		}
+
+		return hunksCompleteMsg{rev: from, summary: summary}
+	})
+}
+
+// runDiscardHunks undoes the picked hunks in rev and stops picking.
+func (m *Model) runDiscardHunks(rev string, patch []jj.FileDiff) tea.Cmd {
+	m.diffPanel.StopHunkPick()
+
+	runner := m.runner
+
+	return m.startJob("jj restore -i --changes-in "+rev, func() tea.Msg {
+		if err := runner.DiscardHunks(rev, patch); err != nil {
+			return errMsg{err}
+		}
+
+		return hunksCompleteMsg{rev: rev, summary: "discarded " + describeHunks(patch) + " from " + rev}
+	})
+}
+
+// handleHunksComplete reloads, with the files view, where files may have
+// left the change.
+func (m *Model) handleHunksComplete(msg hunksCompleteMsg) tea.Cmd {
+	cmds := []tea.Cmd{m.completeMutation(msg.summary)}
+
+	if m.viewMode == ViewFiles && m.filesPanel.ChangeID() == msg.rev {
+		cmds = append(cmds, m.loadFiles(msg.rev))
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// describeHunks counts the hunks in patch.
+func describeHunks(patch []jj.FileDiff) string {
+	count := 0
+	for _, file := range patch {
+		count += len(file.Hunks)
+	}
+
+	if count == 1 {
+		return "1 hunk"
+	}
+
+	return strconv.Itoa(count) + " hunks"
+}
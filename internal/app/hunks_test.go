@@ -0,0 +1,126 @@
+package app
+
+import (
+	"slices"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui"
+)
+
+// hunksTestDiff changes two lines of one file, in separate hunks.
+const hunksTestDiff = `diff --git a/list.txt b/list.txt
+--- a/list.txt
++++ b/list.txt
+@@ -1,3 +1,3 @@
+ one
+-two
++TWO
+ three
+@@ -8,3 +8,3 @@
+ eight
+-nine
++NINE
+ ten
+`
+
+// hunksTestModel returns a model picking the hunks of bbbbbbbb, over a log
+// with a mutable and an immutable change besides it.
+func hunksTestModel(t *testing.T) *Model {
+	t.Helper()
+
+	m := newTestModel(t)
+	m.changes = []jj.Change{
+		{ChangeID: "aaaaaaaa", Description: "fix parser"},
+		{ChangeID: "bbbbbbbb"},
+		{ChangeID: "cccccccc", IsImmutable: true},
+	}
+	m.logPanel.SetContent("@ aaaaaaaa\n○ bbbbbbbb\n◆ cccccccc\n", m.changes)
+	m.handleHunksLoaded(hunksLoadedMsg{rev: "bbbbbbbb", diff: hunksTestDiff})
+
+	return m
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestPickHunks_StartsInDiffPane(t *testing.T) {
+	m := hunksTestModel(t)
+
+	if !m.diffPanel.PickingHunks() || m.focusedPane != PaneDiff {
+		t.Fatal("loaded hunks should be picked in the focused diff pane")
+	}
+
+	m.handleKeyMsg(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+
+	if picked := m.diffPanel.PickedHunks(); len(picked) != 1 || len(picked[0].Hunks) != 1 {
+		t.Errorf("space should mark the hunk rather than run a binding, got %+v", picked)
+	}
+}
+
+func TestPickHunks_NothingToPick(t *testing.T) {
+	m := newTestModel(t)
+
+	m.handleHunksLoaded(hunksLoadedMsg{rev: "bbbbbbbb", diff: ""})
+
+	if m.diffPanel.PickingHunks() || len(m.toasts.Items()) != 1 {
+		t.Error("an empty diff should just say so")
+	}
+}
+
+func TestPickHunks_RefusesImmutable(t *testing.T) {
+	m := hunksTestModel(t)
+	m.diffPanel.StopHunkPick()
+	m.focusedPane = PaneLog
+	m.logPanel.SelectChangeID("cccccccc")
+
+	next, cmd := m.actionPickHunks()
+	*m = next
+
+	if cmd == nil || len(m.toasts.Items()) != 1 {
+		t.Error("hunks of an immutable change should not be picked")
+	}
+}
+
+func TestPickHunks_OffersWhereTo(t *testing.T) {
+	tests := []struct {
+		name   string
+		choice int
+		want   string
+	}{
+		{"parent", 0, "jj squash -i -r bbbbbbbb"},
+		{"other change", 1, "jj squash -i --from bbbbbbbb --into aaaaaaaa"},
+		{"discard", 2, "jj restore -i --changes-in bbbbbbbb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := hunksTestModel(t)
+			m.diffPanel.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+			m.handleHunkPick()
+
+			var labels []string
+			for _, item := range paletteItems(m.palette) {
+				labels = append(labels, item.Label)
+			}
+
+			want := []string{"parent of bbbbbbbb", "aaaaaaaa fix parser", "none: discard them from bbbbbbbb"}
+			if !slices.Equal(labels, want) {
+				t.Fatalf("choices = %v, want %v", labels, want)
+			}
+
+			m.handlePaletteSelect(ui.FinderSelectMsg{Index: tt.choice})
+
+			if got := m.jobs.running[0].label; got != tt.want {
+				t.Errorf("ran %q, want %q", got, tt.want)
+			}
+
+			if m.diffPanel.PickingHunks() {
+				t.Error("picking should stop once the hunks are acted on")
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// interdiffLoadedMsg carries the difference between two versions of a change
+// from its evolog, each named by the operation that produced it.
+type interdiffLoadedMsg struct {
+	fromOp     string
+	toOp       string
+	output     string
+	generation int
+}
+
+// loadInterdiff fetches the difference between the versions of changeID
+// left by operations fromOp and toOp.
+func (m *Model) loadInterdiff(changeID, fromOp, toOp string) tea.Cmd {
+	ctx, generation := m.diffRequests.next(m.ctx)
+	runner := m.runner.WithContext(ctx)
+
+	return func() tea.Msg {
+		output, err := runner.Interdiff(jj.AtOperation(fromOp, changeID), jj.AtOperation(toOp, changeID))
+		if err != nil {
+			return superseded(ctx, err)
+		}
+
+		return interdiffLoadedMsg{fromOp: fromOp, toOp: toOp, output: output, generation: generation}
+	}
+}
+
+func (m *Model) handleInterdiffLoaded(msg interdiffLoadedMsg) {
+	if !m.diffRequests.current(msg.generation) {
+		return
+	}
+
+	m.diffPanel.SetTitle("Interdiff " + msg.fromOp + " → " + msg.toOp)
+	m.diffPanel.SetDiff(msg.output)
+}
@@ -0,0 +1,33 @@
+package app
+
+import (
+	"testing"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestInterdiffLoaded_TitleNamesVersions(t *testing.T) {
+	m := newTestModel(t)
+
+	_, generation := m.diffRequests.next(m.ctx)
+	m.handleInterdiffLoaded(interdiffLoadedMsg{fromOp: "cccccccccccc", toOp: "aaaaaaaaaaaa", output: "diff", generation: generation})
+
+	if got, want := m.diffPanel.Title(), "Interdiff cccccccccccc → aaaaaaaaaaaa"; got != want {
+		t.Errorf("title = %q, want %q", got, want)
+	}
+}
+
+func TestInterdiffLoaded_IgnoresSuperseded(t *testing.T) {
+	m := newTestModel(t)
+	m.diffPanel.SetTitle("Operation")
+
+	_, stale := m.diffRequests.next(m.ctx)
+	m.diffRequests.next(m.ctx)
+	m.handleInterdiffLoaded(interdiffLoadedMsg{fromOp: "cccccccccccc", toOp: "aaaaaaaaaaaa", generation: stale})
+
+	if got := m.diffPanel.Title(); got != "Operation" {
+		t.Errorf("a superseded interdiff should be dropped, title is %q", got)
+	}
+}
@@ -0,0 +1,145 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// jjInstallURL is where the jj docs explain installing and upgrading.
+const jjInstallURL = "https://jj-vcs.github.io/jj/latest/install-and-setup/"
+
+// jjProblemPadding is the space beside the text of the jj problem box.
+const jjProblemPadding = 2
+
+// jjCheckedMsg carries the version of the jj on PATH, and its settings.
+type jjCheckedMsg struct {
+	version  jj.Version
+	settings jj.Settings
+	err      error
+}
+
+// checkJJ asks jj for its version before anything else runs it, then for
+// the settings chado follows.
+func (m *Model) checkJJ() tea.Cmd {
+	runner := m.runner
+
+	return func() tea.Msg {
+		version, err := runner.Version()
+		if err != nil {
+			return jjCheckedMsg{version: version, err: err}
+		}
+
+		// Settings are best-effort: without them chado keeps its defaults
+		settings, configErr := runner.Config()
+		if configErr != nil {
+			m.log.Warn("reading jj config failed", "err", configErr)
+		}
+
+		return jjCheckedMsg{version: version, settings: settings}
+	}
+}
+
+// handleJJChecked loads the repo once jj is known to work, or explains what
+// is wrong with it in place of the panels.
+func (m *Model) handleJJChecked(msg jjCheckedMsg) tea.Cmd {
+	m.jjProblem = jjProblem(msg.version, msg.err)
+	if m.jjProblem != "" {
+		m.log.Error("jj is unusable", "version", msg.version, "err", msg.err)
+		return nil
+	}
+
+	m.log.Info("found jj", "version", msg.version)
+	m.featureNotes = featureNotes(m.runner)
+	m.followSettings(msg.settings)
+
+	return tea.Batch(m.refresh(), m.loadCommitLinks(), m.scheduleFetch())
+}
+
+// followSettings applies jj's settings: its colors unless chado draws
+// without any, its user for describe's trailers, and its diff format unless
+// chado's config picks one.
+func (m *Model) followSettings(settings jj.Settings) {
+	if settings == nil {
+		return
+	}
+
+	m.runner.SetColored(settings.Colored() && !m.monochrome)
+	m.userName = settings.String("user.name")
+	m.userEmail = settings.String("user.email")
+
+	if m.diffFormat == "" {
+		m.runner.SetDiffFormat(settings.DiffFormat())
+		m.diffPanel.SetFormat(diffFormatName(settings.DiffFormat()))
+	}
+}
+
+// featureFallbacks says what chado does instead of each gated feature.
+var featureFallbacks = map[jj.Feature]string{
+	jj.FeatureEvologOperations: "a change's files keep the op log beside them instead of its evolog",
+	jj.FeatureRevert:           "backing out a change runs jj backout",
+}
+
+// featureNotes explains the features the jj on PATH is too old for.
+func featureNotes(runner *jj.Runner) []string {
+	version, _ := runner.Version()
+
+	var notes []string
+
+	for _, feature := range jj.Features {
+		if !runner.Supports(feature) {
+			notes = append(notes, fmt.Sprintf("%s needs jj %s (found %s): %s",
+				feature.Name, feature.Since, version, featureFallbacks[feature]))
+		}
+	}
+
+	return notes
+}
+
+// jjProblem describes why chado cannot use jj and how to fix it, or returns
+// "" when jj is fine.
+func jjProblem(version jj.Version, err error) string {
+	switch {
+	case errors.Is(err, jj.ErrNotInstalled):
+		return "chado needs jj (Jujutsu), but it is not on your PATH.\n\n" +
+			"Install it with your package manager (e.g. brew install jj)\n" +
+			"or cargo install --locked jj-cli, then start chado again.\n\n" +
+			jjInstallURL
+	case err != nil:
+		return fmt.Sprintf("chado could not run jj:\n\n%v", err)
+	case version.Less(jj.MinVersion):
+		return fmt.Sprintf("chado needs jj %s or newer, but found jj %s.\n\n", jj.MinVersion, version) +
+			"Upgrade it the way you installed it (e.g. brew upgrade jj\n" +
+			"or cargo install --locked jj-cli), then start chado again.\n\n" +
+			jjInstallURL
+	}
+
+	return ""
+}
+
+// handleQuitOnlyKey lets only quit through while the jj problem or the
+// too-small screen hides the panels.
+func (m *Model) handleQuitOnlyKey(msg tea.KeyMsg) tea.Cmd {
+	if key.Matches(msg, m.keys.Quit) {
+		return m.quit()
+	}
+
+	return nil
+}
+
+// renderJJProblem fills the screen with the jj problem and a quit hint.
+func (m *Model) renderJJProblem() string {
+	t := m.styles.Theme()
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(t.OverlayBorder)).
+		Padding(1, jjProblemPadding).
+		Render(m.jjProblem + "\n\nPress " + m.keys.Quit.Help().Key + " to quit.")
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/config"
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/logger"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestJJProblem(t *testing.T) {
+	if got := jjProblem(jj.MinVersion, nil); got != "" {
+		t.Errorf("a supported jj should be fine, got %q", got)
+	}
+
+	if got := jjProblem(jj.Version{}, jj.ErrNotInstalled); !strings.Contains(got, "not on your PATH") {
+		t.Errorf("expected install instructions, got %q", got)
+	}
+
+	if got := jjProblem(jj.Version{Minor: 20}, nil); !strings.Contains(got, "found jj 0.20.0") {
+		t.Errorf("expected upgrade instructions, got %q", got)
+	}
+}
+
+func TestJJChecked_LoadsOnlyWithUsableJJ(t *testing.T) {
+	m := newTestModel(t)
+
+	if cmd := m.handleJJChecked(jjCheckedMsg{version: jj.MinVersion}); cmd == nil || m.jjProblem != "" {
+		t.Error("a supported jj should load the repo")
+	}
+
+	if cmd := m.handleJJChecked(jjCheckedMsg{err: jj.ErrNotInstalled}); cmd != nil || m.jjProblem == "" {
+		t.Error("a missing jj should show the problem instead of loading")
+	}
+}
+
+func TestJJChecked_FollowsSettings(t *testing.T) {
+	m := newTestModel(t)
+
+	m.handleJJChecked(jjCheckedMsg{version: jj.MinVersion, settings: jj.ParseConfig(`ui.color = "never"`)})
+
+	if got := m.runner.ColorFlag(); got != "--color=never" {
+		t.Errorf("ui.color = never should turn jj's colors off, got %s", got)
+	}
+
+	m.handleJJChecked(jjCheckedMsg{version: jj.MinVersion})
+
+	if got := m.runner.ColorFlag(); got != "--color=never" {
+		t.Errorf("without settings chado should keep what it has, got %s", got)
+	}
+}
+
+func TestJJChecked_NoColorKeepsJJPlain(t *testing.T) {
+	cfg := config.Default()
+	cfg.NoColor = true
+
+	log, _ := logger.New("")
+	m := New(context.Background(), t.TempDir(), "test", cfg, log)
+
+	if got := m.runner.ColorFlag(); got != "--color=never" {
+		t.Errorf("no color should ask jj for plain output, got %s", got)
+	}
+
+	m.handleJJChecked(jjCheckedMsg{version: jj.MinVersion, settings: jj.ParseConfig(`ui.color = "always"`)})
+
+	if got := m.runner.ColorFlag(); got != "--color=never" {
+		t.Errorf("jj's ui.color should not bring colors back, got %s", got)
+	}
+}
+
+func TestFeatureNotes_ExplainUnsupportedFeatures(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	m := newTestModel(t)
+	notes := featureNotes(m.runner)
+
+	if len(notes) != len(jj.Features) {
+		t.Fatalf("expected a note for each feature an unknown jj lacks, got %q", notes)
+	}
+
+	if !strings.Contains(notes[0], "needs jj "+jj.FeatureEvologOperations.Since.String()) {
+		t.Errorf("expected the note to name the jj needed, got %q", notes[0])
+	}
+}
+
+func TestJJProblem_OnlyQuitAndNoErrorToasts(t *testing.T) {
+	m := newTestModel(t)
+	m.handleJJChecked(jjCheckedMsg{err: jj.ErrNotInstalled})
+
+	if _, cmd := m.handleKeyMsg(tea.KeyPressMsg{Code: 'n', Text: "n"}); cmd != nil {
+		t.Error("keys other than quit should be ignored")
+	}
+
+	if _, cmd := m.handleKeyMsg(tea.KeyPressMsg{Code: 'q', Text: "q"}); cmd == nil {
+		t.Error("q should quit")
+	}
+
+	m.handleErr(errMsg{errors.New("jj command failed")})
+
+	if m.toasts.HasErrors() {
+		t.Error("failures of jj should not pile up behind the problem screen")
+	}
+}
@@ -0,0 +1,242 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/spinner"
+	tea "charm.land/bubbletea/v2"
+)
+
+// jobElapsedAfter is how long a job runs before its elapsed time is shown,
+// so quick commands don't flash a counter.
+const jobElapsedAfter = 2 * time.Second
+
+// job is a mutating jj command running in the background.
+type job struct {
+	id      int
+	label   string // e.g. "jj squash xsssnyux"
+	started time.Time
+}
+
+// queuedJob is a mutating jj command waiting for the running one to finish.
+type queuedJob struct {
+	label string
+	cmd   tea.Cmd
+}
+
+// jobTracker tracks the mutating jj commands in flight for the status bar,
+// and those queued behind them so two never write to the repo at once.
+// It is shared by pointer because mutations are often started from closures
+// (confirmations, previews) that hold an earlier copy of the model.
+type jobTracker struct {
+	nextID  int
+	running []job
+	queue   []queuedJob
+	spinner spinner.Model
+}
+
+// jobDoneMsg carries a finished job's result, delivered once it is cleared.
+type jobDoneMsg struct {
+	id     int
+	result tea.Msg
+	job    queuedJob // what ran, to run again should it fail
+}
+
+// stillSpinner stands in for the spinner in reduced-motion mode: one frame,
+// ticking once a second so the elapsed time still counts up.
+var stillSpinner = spinner.Spinner{Frames: []string{"•"}, FPS: time.Second}
+
+// newJobTracker creates an idle tracker.
+func newJobTracker() *jobTracker {
+	return &jobTracker{spinner: spinner.New(spinner.WithSpinner(spinner.MiniDot))}
+}
+
+// holdStill stops the spinner moving, for reduced-motion mode.
+func (t *jobTracker) holdStill() {
+	t.spinner.Spinner = stillSpinner
+}
+
+// busy reports whether any job is running. A nil tracker is idle.
+func (t *jobTracker) busy() bool {
+	return t != nil && len(t.running) > 0
+}
+
+// start records a job and returns its ID.
+func (t *jobTracker) start(label string, now time.Time) int {
+	t.nextID++
+	t.running = append(t.running, job{id: t.nextID, label: label, started: now})
+
+	return t.nextID
+}
+
+// finish forgets the job with id.
+func (t *jobTracker) finish(id int) {
+	if t == nil {
+		return
+	}
+
+	for i, j := range t.running {
+		if j.id == id {
+			t.running = append(t.running[:i], t.running[i+1:]...)
+			return
+		}
+	}
+}
+
+// enqueue holds a command until the running ones finish.
+func (t *jobTracker) enqueue(label string, cmd tea.Cmd) {
+	t.queue = append(t.queue, queuedJob{label: label, cmd: cmd})
+}
+
+// dequeue takes the oldest queued command, if any.
+func (t *jobTracker) dequeue() (queuedJob, bool) {
+	if len(t.queue) == 0 {
+		return queuedJob{}, false
+	}
+
+	next := t.queue[0]
+	t.queue = t.queue[1:]
+
+	return next, true
+}
+
+// dropQueue forgets the queued commands and returns how many there were.
+func (t *jobTracker) dropQueue() int {
+	dropped := len(t.queue)
+	t.queue = nil
+
+	return dropped
+}
+
+// tick advances the spinner while jobs run; once idle it lets the ticks stop.
+func (t *jobTracker) tick(msg spinner.TickMsg) tea.Cmd {
+	if !t.busy() {
+		return nil
+	}
+
+	var cmd tea.Cmd
+	t.spinner, cmd = t.spinner.Update(msg)
+
+	return cmd
+}
+
+// status describes the oldest running job for the status bar, or "" when idle.
+func (t *jobTracker) status(now time.Time) string {
+	if !t.busy() {
+		return ""
+	}
+
+	oldest := t.running[0]
+	text := t.spinner.View() + " running " + oldest.label + "…"
+
+	if elapsed := now.Sub(oldest.started); elapsed >= jobElapsedAfter {
+		text += fmt.Sprintf(" %ds", int(elapsed.Seconds()))
+	}
+
+	if more := len(t.running) - 1; more > 0 {
+		text += fmt.Sprintf(" (+%d more)", more)
+	}
+
+	if len(t.queue) > 0 {
+		labels := make([]string, len(t.queue))
+		for i, queued := range t.queue {
+			labels[i] = queued.label
+		}
+
+		text += ", then " + strings.Join(labels, ", ")
+	}
+
+	return text
+}
+
+// activity describes what chado is busy with for the status bar: the oldest
+// running job, and whether jj is waiting for another process to release the
+// repo lock. Display-only commands rarely take the lock, so the spinner
+// ticking for jobs keeps this current.
+func (m *Model) activity(now time.Time) string {
+	status := m.jobs.status(now)
+
+	switch {
+	case !m.runner.WaitingForLock():
+		return status
+	case status == "":
+		return "waiting for repo lock…"
+	default:
+		return status + " waiting for repo lock"
+	}
+}
+
+// startJob runs a mutating jj command in the background, shown in the status
+// bar until it finishes; its result message is then handled as usual. While
+// another runs, it waits in the queue.
+func (m *Model) startJob(label string, cmd tea.Cmd) tea.Cmd {
+	if m.jobs == nil {
+		return cmd
+	}
+
+	if m.jobs.busy() {
+		m.jobs.enqueue(label, cmd)
+		return nil
+	}
+
+	return tea.Batch(m.runJob(label, cmd), m.jobs.spinner.Tick)
+}
+
+// runJob records a job as running and runs its command.
+func (m *Model) runJob(label string, cmd tea.Cmd) tea.Cmd {
+	id := m.jobs.start(label, time.Now())
+
+	return func() tea.Msg {
+		return jobDoneMsg{id: id, result: cmd(), job: queuedJob{label: label, cmd: cmd}}
+	}
+}
+
+// handleJobDone clears a finished job, handles its result, and starts the
+// next queued one. A failure drops the queue, since what was queued may
+// have depended on it.
+func (m *Model) handleJobDone(msg jobDoneMsg) (tea.Model, tea.Cmd) {
+	m.jobs.finish(msg.id)
+
+	var next tea.Cmd
+
+	if jobFailed(msg.result) {
+		m.failedJob = &msg.job
+
+		if dropped := m.jobs.dropQueue(); dropped > 0 {
+			next = m.toasts.Info(fmt.Sprintf("dropped %d queued command(s) after the failure", dropped))
+		}
+	} else if queued, ok := m.jobs.dequeue(); ok {
+		// The spinner is still ticking: the next job starts before the next tick
+		next = m.runJob(queued.label, queued.cmd)
+	}
+
+	if msg.result == nil {
+		return m, next
+	}
+
+	model, cmd := m.Update(msg.result)
+	m.failedJob = nil
+
+	return model, tea.Batch(cmd, next)
+}
+
+// jobFailed reports whether a job's result is an error, including a push
+// any bookmark of which was rejected.
+func jobFailed(result tea.Msg) bool {
+	switch result := result.(type) {
+	case errMsg:
+		return true
+	case commandRanMsg:
+		return result.err != nil
+	case bookmarksPushedMsg:
+		for _, pushed := range result.results {
+			if !pushed.OK {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,240 @@
+package app
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"pgregory.net/rapid"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestJobTracker_Status(t *testing.T) {
+	jobs := newJobTracker()
+	now := time.Now()
+
+	if got := jobs.status(now); got != "" {
+		t.Errorf("an idle tracker should show nothing, got %q", got)
+	}
+
+	id := jobs.start("jj git push -r abc", now)
+
+	if got := jobs.status(now); !strings.HasSuffix(got, "running jj git push -r abc…") {
+		t.Errorf("expected the running command, got %q", got)
+	}
+
+	jobs.start("jj new", now)
+
+	if got := jobs.status(now.Add(5 * time.Second)); !strings.HasSuffix(got, "jj git push -r abc… 5s (+1 more)") {
+		t.Errorf("expected the elapsed time and the other job, got %q", got)
+	}
+
+	jobs.finish(id)
+
+	if got := jobs.status(now); !strings.HasSuffix(got, "running jj new…") {
+		t.Errorf("expected the remaining job, got %q", got)
+	}
+}
+
+func TestStartJob_ClearsAndDeliversResult(t *testing.T) {
+	m := newTestModel(t)
+
+	cmd := m.startJob("jj describe abc", func() tea.Msg {
+		return errMsg{errors.New("jj failed")}
+	})
+
+	if !m.jobs.busy() {
+		t.Fatal("the job should show as running once started")
+	}
+
+	var done *jobDoneMsg
+
+	batch, _ := cmd().(tea.BatchMsg)
+	for _, c := range batch {
+		if msg, ok := c().(jobDoneMsg); ok {
+			done = &msg
+		}
+	}
+
+	if done == nil {
+		t.Fatal("expected the job to report when done")
+	}
+
+	m.handleJobDone(*done)
+
+	if m.jobs.busy() {
+		t.Error("the finished job should be cleared")
+	}
+
+	if !m.toasts.HasErrors() {
+		t.Error("the job's result should be handled")
+	}
+}
+
+func TestRunAction_QueuesMutationWhileBusy(t *testing.T) {
+	m := newTestModel(t)
+	m.jobs.start("jj squash -r abc", time.Now())
+
+	m.handleKeyMsg(tea.KeyPressMsg(tea.Key{Code: 'n', Text: "n"}))
+
+	if len(m.jobs.running) != 1 || len(m.jobs.queue) != 1 || m.jobs.queue[0].label != "jj new" {
+		t.Fatalf("expected the new change to wait for the squash, got running %+v, queued %+v", m.jobs.running, m.jobs.queue)
+	}
+
+	if got := m.jobs.status(time.Now()); !strings.HasSuffix(got, "running jj squash -r abc…, then jj new") {
+		t.Errorf("expected the queue in the status, got %q", got)
+	}
+
+	mode := m.logPanel.StatMode()
+	m.handleKeyMsg(tea.KeyPressMsg(tea.Key{Code: '=', Text: "="}))
+
+	if m.logPanel.StatMode() == mode {
+		t.Error("actions that don't change the repo should still run")
+	}
+}
+
+func TestCommandSubmit_QueuedWhileBusyUnlessReadOnly(t *testing.T) {
+	m := newTestModel(t)
+	m.jobs.start("jj git push -r abc", time.Now())
+
+	if cmd := m.handleCommandSubmit(ui.CommandSubmitMsg{Line: "log"}); cmd == nil {
+		t.Error("a read-only command should run alongside")
+	}
+
+	m.handleCommandSubmit(ui.CommandSubmitMsg{Line: "rebase -d main"})
+
+	if len(m.jobs.running) != 1 || len(m.jobs.queue) != 1 {
+		t.Error("a mutating command should wait while another runs")
+	}
+}
+
+func TestHandleJobDone_RunsNextQueued(t *testing.T) {
+	m := newTestModel(t)
+	id := m.jobs.start("jj squash -r abc", time.Now())
+	ran := false
+
+	m.startJob("jj new", func() tea.Msg {
+		ran = true
+		return nil
+	})
+
+	_, cmd := m.handleJobDone(jobDoneMsg{id: id})
+
+	if len(m.jobs.running) != 1 || m.jobs.running[0].label != "jj new" || len(m.jobs.queue) != 0 {
+		t.Fatalf("expected the queued job to start, got running %+v, queued %+v", m.jobs.running, m.jobs.queue)
+	}
+
+	if cmd == nil {
+		t.Fatal("expected the queued job's command")
+	}
+
+	cmd()
+
+	if !ran {
+		t.Error("the queued job's command should run")
+	}
+}
+
+func TestHandleJobDone_FailureDropsQueue(t *testing.T) {
+	m := newTestModel(t)
+	id := m.jobs.start("jj squash -r abc", time.Now())
+	m.startJob("jj new", func() tea.Msg { return nil })
+	m.startJob("jj abandon xyz", func() tea.Msg { return nil })
+
+	m.handleJobDone(jobDoneMsg{id: id, result: errMsg{errors.New("jj failed")}})
+
+	if m.jobs.busy() || len(m.jobs.queue) != 0 {
+		t.Error("a failure should drop what was queued after it")
+	}
+
+	var dropped bool
+
+	for _, toast := range m.toasts.Items() {
+		dropped = dropped || strings.Contains(toast.Text, "dropped 2 queued")
+	}
+
+	if !dropped {
+		t.Errorf("expected a note about the dropped commands, got %+v", m.toasts.Items())
+	}
+}
+
+func TestHandleJobDone_RejectedPushDropsQueue(t *testing.T) {
+	m := newTestModel(t)
+	id := m.jobs.start("jj git push --bookmark main --bookmark feature", time.Now())
+	m.startJob("jj new", func() tea.Msg { return nil })
+
+	m.handleJobDone(jobDoneMsg{id: id, result: bookmarksPushedMsg{results: map[string]jj.PushResult{
+		"main":    {OK: true, Status: "pushed"},
+		"feature": {OK: false, Status: "rejected"},
+	}}})
+
+	if m.jobs.busy() || len(m.jobs.queue) != 0 {
+		t.Error("a bookmark that failed to push should drop what was queued after it")
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+func TestJobTracker_FinishInAnyOrder(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		jobs := newJobTracker()
+		n := rapid.IntRange(1, 10).Draw(t, "n")
+
+		ids := make([]int, n)
+		for i := range ids {
+			ids[i] = jobs.start("jj new", time.Now())
+		}
+
+		for _, i := range rapid.Permutation(ids).Draw(t, "order") {
+			if !jobs.busy() {
+				t.Fatal("the tracker went idle with jobs left")
+			}
+
+			jobs.finish(i)
+		}
+
+		if jobs.busy() {
+			t.Fatal("the tracker should be idle once every job finished")
+		}
+	})
+}
+
+// Property: queued mutations run one at a time, in the order they were started.
+func TestStartJob_RunsQueueInOrder(t *testing.T) {
+	m := newTestModel(t)
+
+	rapid.Check(t, func(t *rapid.T) {
+		m.jobs = newJobTracker()
+		labels := rapid.SliceOfN(rapid.StringMatching(`jj [a-z]{3,8}`), 1, 8).Draw(t, "labels")
+
+		for _, label := range labels {
+			m.startJob(label, func() tea.Msg { return nil })
+		}
+
+		var order []string
+
+		for m.jobs.busy() {
+			if len(m.jobs.running) != 1 {
+				t.Fatalf("expected one job at a time, got %+v", m.jobs.running)
+			}
+
+			order = append(order, m.jobs.running[0].label)
+			m.handleJobDone(jobDoneMsg{id: m.jobs.running[0].id})
+		}
+
+		if !slices.Equal(order, labels) {
+			t.Fatalf("ran %v, want %v", order, labels)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// jumpResolvedMsg carries the change a jump prompt's answer names.
+type jumpResolvedMsg struct {
+	rev      string
+	changeID string
+}
+
+// actionJump asks for a change ID prefix, bookmark, or revset to select in
+// the log.
+func (m *Model) actionJump() (Model, tea.Cmd) {
+	m.commanding = true
+	m.promptAnswer = (*Model).resolveJump
+	m.sizeCommandLine()
+
+	return *m, m.commandLine.Ask("Jump to a change", "change: ", "change ID, bookmark, or revset")
+}
+
+// resolveJump looks up the one change the answer names.
+func (m *Model) resolveJump(answer string) tea.Cmd {
+	rev := strings.TrimSpace(answer)
+	if rev == "" {
+		return nil
+	}
+
+	runner := m.runner
+
+	return func() tea.Msg {
+		changeID, err := runner.ResolveChange(rev)
+		if err != nil {
+			return errMsg{err}
+		}
+
+		return jumpResolvedMsg{rev: rev, changeID: changeID}
+	}
+}
+
+// handleJumpResolved selects the change in the log, going back to it from
+// a side view, or loads more of the log until the change turns up.
+func (m *Model) handleJumpResolved(msg jumpResolvedMsg) tea.Cmd {
+	var cmds []tea.Cmd
+	if m.viewMode != ViewLog {
+		cmds = append(cmds, m.handleBack())
+	}
+
+	m.focusedPane = PaneLog
+	m.updatePanelFocus()
+
+	if m.logPanel.SelectChangeID(msg.changeID) {
+		return tea.Batch(append(cmds, m.loadSelectedDiff(), m.loadVisibleStats(), m.loadMoreLog())...)
+	}
+
+	if !m.logMore {
+		return tea.Batch(append(cmds, m.toasts.Info(msg.rev+" ("+msg.changeID+") is not in the log's revset"))...)
+	}
+
+	m.jumpChangeID = msg.changeID
+
+	return tea.Batch(append(cmds, m.loadJumpPage())...)
+}
+
+// loadJumpPage loads twice as much of the log, so a jump deep into history
+// takes few loads.
+func (m *Model) loadJumpPage() tea.Cmd {
+	m.logLimit *= 2
+	m.logLoadingMore = true
+	m.log.Debug("loading more of the log to jump", "change_id", m.jumpChangeID, "limit", m.logLimit)
+
+	return m.loadLog()
+}
+
+// continueJump selects the change a jump is waiting for once the log has
+// loaded, loading more while the log was cut off before reaching it.
+func (m *Model) continueJump() tea.Cmd {
+	changeID := m.jumpChangeID
+	if changeID == "" {
+		return nil
+	}
+
+	if m.logPanel.SelectChangeID(changeID) {
+		m.jumpChangeID = ""
+		return nil
+	}
+
+	if m.logMore {
+		return m.loadJumpPage()
+	}
+
+	m.jumpChangeID = ""
+
+	return m.toasts.Info(changeID + " is not in the log's revset")
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/chatter/chado/internal/ui"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestJump_AsksForChange(t *testing.T) {
+	m := newTestModel(t)
+
+	m.actionJump()
+
+	if !m.commanding || m.promptAnswer == nil {
+		t.Fatal("J should ask which change to jump to")
+	}
+
+	if cmd := m.handlePromptSubmit(ui.PromptSubmitMsg{Value: "  "}); cmd != nil || m.commanding {
+		t.Error("a blank answer should just close the prompt")
+	}
+}
+
+func TestJump_SelectsLoadedChange(t *testing.T) {
+	m := newTestModel(t)
+	m.handleLogLoaded(logPage(30, 0))
+	m.viewMode = ViewBookmarks
+
+	m.handleJumpResolved(jumpResolvedMsg{rev: "main", changeID: "changebc"})
+
+	if selected := m.logPanel.SelectedChange(); selected == nil || selected.ChangeID != "changebc" {
+		t.Errorf("expected changebc selected, got %+v", selected)
+	}
+
+	if m.viewMode != ViewLog || m.focusedPane != PaneLog {
+		t.Error("jumping should go back to the focused log")
+	}
+}
+
+func TestJump_LoadsMoreUntilFound(t *testing.T) {
+	m := newTestModel(t)
+	m.logPageSize, m.logLimit = 50, 50
+	m.handleLogLoaded(logPage(50, 50))
+
+	m.handleJumpResolved(jumpResolvedMsg{rev: "main", changeID: "changebz"})
+
+	if m.jumpChangeID != "changebz" || m.logLimit != 100 {
+		t.Fatalf("a change past the loaded log should load more, limit is %d", m.logLimit)
+	}
+
+	m.handleLogLoaded(logPage(100, 100))
+
+	if selected := m.logPanel.SelectedChange(); m.jumpChangeID != "" || selected == nil || selected.ChangeID != "changebz" {
+		t.Errorf("the change should be selected once loaded, got %+v", selected)
+	}
+}
+
+func TestJump_OutsideRevset(t *testing.T) {
+	m := newTestModel(t)
+	m.handleLogLoaded(logPage(30, 0))
+
+	m.handleJumpResolved(jumpResolvedMsg{rev: "root()", changeID: "zzzzzzzz"})
+
+	if m.jumpChangeID != "" || len(m.toasts.Items()) != 1 {
+		t.Error("a change outside the whole log should be reported")
+	}
+}
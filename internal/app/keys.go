@@ -1,9 +1,15 @@
 package app
 
 import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
 	"charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
 
+	"github.com/chatter/chado/internal/config"
 	"github.com/chatter/chado/internal/ui/help"
 )
 
@@ -14,7 +20,9 @@ type Action func(m *Model) (Model, tea.Cmd)
 type ActionBinding struct {
 	help.Binding // embedded for display (Key, Category, Order)
 
-	Action Action // nil = display-only (no action)
+	ID      string // stable identifier for usage tracking (independent of keys)
+	Action  Action // nil = display-only (no action)
+	Mutates bool   // runs a jj command that changes the repo; queued one at a time
 }
 
 // dispatchKey iterates through bindings and executes the first matching action.
@@ -22,7 +30,7 @@ type ActionBinding struct {
 func dispatchKey(m *Model, msg tea.KeyMsg, bindings []ActionBinding) (*Model, tea.Cmd) {
 	for _, ab := range bindings {
 		if key.Matches(msg, ab.Key) && ab.Action != nil {
-			newModel, cmd := ab.Action(m)
+			newModel, cmd := m.runAction(ab)
 			return &newModel, cmd
 		}
 	}
@@ -30,6 +38,16 @@ func dispatchKey(m *Model, msg tea.KeyMsg, bindings []ActionBinding) (*Model, te
 	return nil, nil
 }
 
+// runAction records the use of a binding and runs its action. A jj
+// mutation started while another runs waits its turn in the job queue.
+func (m *Model) runAction(ab ActionBinding) (Model, tea.Cmd) {
+	if m.hints != nil {
+		m.hints.recordUse(ab.ID)
+	}
+
+	return ab.Action(m)
+}
+
 // ToHelpBindings extracts display-only bindings from action bindings.
 func ToHelpBindings(abs []ActionBinding) []help.Binding {
 	result := make([]help.Binding, len(abs))
@@ -58,15 +76,70 @@ type KeyMap struct {
 	Bottom key.Binding
 
 	// Actions
-	Enter    key.Binding
-	Back     key.Binding
-	Abandon  key.Binding
-	Describe key.Binding
-	Edit     key.Binding
-	New      key.Binding
-	Squash   key.Binding
-	Quit     key.Binding
-	Help     key.Binding
+	Enter       key.Binding
+	Back        key.Binding
+	Abandon     key.Binding
+	Describe    key.Binding
+	Reword      key.Binding
+	Edit        key.Binding
+	New         key.Binding
+	NewMenu     key.Binding
+	Squash      key.Binding
+	Duplicate   key.Binding
+	Backout     key.Binding
+	Absorb      key.Binding
+	Restore     key.Binding
+	PickHunks   key.Binding
+	Commit      key.Binding
+	Annotate    key.Binding
+	Workspaces  key.Binding
+	Sparse      key.Binding
+	OpAbandon   key.Binding
+	Next        key.Binding
+	Prev        key.Binding
+	Push        key.Binding
+	Bookmarks   key.Binding
+	Stacks      key.Binding
+	Jump        key.Binding
+	RebaseStack key.Binding
+	CopyID      key.Binding
+	CopyHash    key.Binding
+	FindFile    key.Binding
+	Find        key.Binding
+	OpenDir     key.Binding
+	OpenWeb     key.Binding
+	Shell       key.Binding
+	DiffTool    key.Binding
+	Palette     key.Binding
+	Command     key.Binding
+	Refresh     key.Binding
+	Dismiss     key.Binding
+	ErrorInfo   key.Binding
+	Quit        key.Binding
+	Help        key.Binding
+
+	// View toggles
+	ToggleStats  key.Binding
+	CompareAtOp  key.Binding
+	Snapshots    key.Binding
+	TimeTravel   key.Binding
+	ToggleSyntax key.Binding
+	LineNumbers  key.Binding
+	Wrap         key.Binding
+	IgnoreSpace  key.Binding
+	MoreContext  key.Binding
+	LessContext  key.Binding
+	DiffFormat   key.Binding
+	LogTemplate  key.Binding
+	FileContent  key.Binding
+	ToggleStatus key.Binding
+	AutoRefresh  key.Binding
+	CycleTheme   key.Binding
+
+	// Layout
+	ShrinkLeft   key.Binding
+	GrowLeft     key.Binding
+	ToggleLayout key.Binding
 }
 
 // DefaultKeyMap returns the default key bindings.
@@ -132,6 +205,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("d"),
 			key.WithHelp("d", "describe"),
 		),
+		Reword: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("ctrl+r", "replace in descriptions"),
+		),
 		Edit: key.NewBinding(
 			key.WithKeys("e"),
 			key.WithHelp("e", "edit"),
@@ -140,10 +217,134 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("n"),
 			key.WithHelp("n", "new"),
 		),
+		NewMenu: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "new before/after"),
+		),
 		Squash: key.NewBinding(
 			key.WithKeys("s"),
 			key.WithHelp("s", "squash"),
 		),
+		Duplicate: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "duplicate"),
+		),
+		Backout: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("B", "back out"),
+		),
+		Absorb: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "absorb into ancestors"),
+		),
+		Restore: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "restore file"),
+		),
+		PickHunks: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", "pick hunks"),
+		),
+		Commit: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "commit @"),
+		),
+		Workspaces: key.NewBinding(
+			key.WithKeys("ctrl+w"),
+			key.WithHelp("ctrl+w", "workspaces"),
+		),
+		Sparse: key.NewBinding(
+			key.WithKeys("ctrl+s"),
+			key.WithHelp("ctrl+s", "sparse patterns"),
+		),
+		OpAbandon: key.NewBinding(
+			key.WithKeys("X"),
+			key.WithHelp("X", "abandon older ops"),
+		),
+		Annotate: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "annotate file"),
+		),
+		Next: key.NewBinding(
+			key.WithKeys("]"),
+			key.WithHelp("]", "@ to child"),
+		),
+		Prev: key.NewBinding(
+			key.WithKeys("["),
+			key.WithHelp("[", "@ to parent"),
+		),
+		Push: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "push"),
+		),
+		Bookmarks: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "bookmarks"),
+		),
+		Jump: key.NewBinding(
+			key.WithKeys("J"),
+			key.WithHelp("J", "jump to change"),
+		),
+		Stacks: key.NewBinding(
+			key.WithKeys("K"),
+			key.WithHelp("K", "stacks"),
+		),
+		RebaseStack: key.NewBinding(
+			key.WithKeys("O"),
+			key.WithHelp("O", "rebase stack onto trunk"),
+		),
+		CopyID: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "copy change ID"),
+		),
+		CopyHash: key.NewBinding(
+			key.WithKeys("Y"),
+			key.WithHelp("Y", "copy commit ID"),
+		),
+		FindFile: key.NewBinding(
+			key.WithKeys("ctrl+t"),
+			key.WithHelp("⌃t", "find file"),
+		),
+		Find: key.NewBinding(
+			key.WithKeys("ctrl+f"),
+			key.WithHelp("⌃f", "find change or file"),
+		),
+		OpenDir: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "open in file manager"),
+		),
+		OpenWeb: key.NewBinding(
+			key.WithKeys("ctrl+o"),
+			key.WithHelp("⌃o", "open commit on the web"),
+		),
+		Shell: key.NewBinding(
+			key.WithKeys("!"),
+			key.WithHelp("!", "shell here"),
+		),
+		DiffTool: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "open in diff tool"),
+		),
+		Palette: key.NewBinding(
+			key.WithKeys("ctrl+p"),
+			key.WithHelp("⌃p", "command palette"),
+		),
+		Command: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", "jj command"),
+		),
+		Refresh: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "refresh"),
+		),
+		Dismiss: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "dismiss error"),
+		),
+		ErrorInfo: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "error details"),
+		),
 		Quit: key.NewBinding(
 			key.WithKeys("q", "ctrl+c"),
 			key.WithHelp("q", "quit"),
@@ -152,5 +353,196 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("?"),
 			key.WithHelp("?", "help"),
 		),
+		ToggleStats: key.NewBinding(
+			key.WithKeys("="),
+			key.WithHelp("=", "diff stats"),
+		),
+		CompareAtOp: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "compare log at op"),
+		),
+		Snapshots: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "hide/show snapshots"),
+		),
+		TimeTravel: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "time travel to op"),
+		),
+		LogTemplate: key.NewBinding(
+			key.WithKeys("V"),
+			key.WithHelp("V", "log template"),
+		),
+		ToggleSyntax: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "syntax highlight"),
+		),
+		LineNumbers: key.NewBinding(
+			key.WithKeys("#"),
+			key.WithHelp("#", "diff line numbers"),
+		),
+		Wrap: key.NewBinding(
+			key.WithKeys("U"),
+			key.WithHelp("U", "wrap diff lines"),
+		),
+		IgnoreSpace: key.NewBinding(
+			key.WithKeys("I"),
+			key.WithHelp("I", "ignore whitespace"),
+		),
+		MoreContext: key.NewBinding(
+			key.WithKeys("+"),
+			key.WithHelp("+", "more diff context"),
+		),
+		LessContext: key.NewBinding(
+			key.WithKeys("-"),
+			key.WithHelp("-", "less diff context"),
+		),
+		DiffFormat: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "diff format"),
+		),
+		FileContent: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "file content/diff"),
+		),
+		ToggleStatus: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "working copy status"),
+		),
+		AutoRefresh: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "pause/resume auto-refresh"),
+		),
+		CycleTheme: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "cycle theme"),
+		),
+		ShrinkLeft: key.NewBinding(
+			key.WithKeys("<"),
+			key.WithHelp("<", "narrow left panes"),
+		),
+		GrowLeft: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">", "widen left panes"),
+		),
+		ToggleLayout: key.NewBinding(
+			key.WithKeys("|"),
+			key.WithHelp("|", "stack/split panes"),
+		),
 	}
 }
+
+// bindingsByID maps each global action's binding ID to its key binding so
+// the config file can remap it.
+func (k *KeyMap) bindingsByID() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"quit":              &k.Quit,
+		"focus-pane-0":      &k.FocusPane0,
+		"focus-pane-1":      &k.FocusPane1,
+		"focus-pane-2":      &k.FocusPane2,
+		"next-pane":         &k.NextPane,
+		"prev-pane":         &k.PrevPane,
+		"enter":             &k.Enter,
+		"back":              &k.Back,
+		"describe":          &k.Describe,
+		"reword":            &k.Reword,
+		"edit":              &k.Edit,
+		"new":               &k.New,
+		"new-menu":          &k.NewMenu,
+		"abandon":           &k.Abandon,
+		"squash":            &k.Squash,
+		"duplicate":         &k.Duplicate,
+		"backout":           &k.Backout,
+		"absorb":            &k.Absorb,
+		"restore":           &k.Restore,
+		"pick-hunks":        &k.PickHunks,
+		"commit":            &k.Commit,
+		"annotate":          &k.Annotate,
+		"workspaces":        &k.Workspaces,
+		"sparse":            &k.Sparse,
+		"op-abandon":        &k.OpAbandon,
+		"next":              &k.Next,
+		"prev":              &k.Prev,
+		"push":              &k.Push,
+		"copy-id":           &k.CopyID,
+		"copy-commit":       &k.CopyHash,
+		"find-file":         &k.FindFile,
+		"find":              &k.Find,
+		"open-dir":          &k.OpenDir,
+		"open-web":          &k.OpenWeb,
+		"shell":             &k.Shell,
+		"difftool":          &k.DiffTool,
+		"palette":           &k.Palette,
+		"command":           &k.Command,
+		"refresh":           &k.Refresh,
+		"bookmarks":         &k.Bookmarks,
+		"stacks":            &k.Stacks,
+		"jump":              &k.Jump,
+		"rebase-stack":      &k.RebaseStack,
+		"compare-at-op":     &k.CompareAtOp,
+		"snapshots":         &k.Snapshots,
+		"time-travel":       &k.TimeTravel,
+		"stats":             &k.ToggleStats,
+		"syntax":            &k.ToggleSyntax,
+		"line-numbers":      &k.LineNumbers,
+		"wrap":              &k.Wrap,
+		"ignore-whitespace": &k.IgnoreSpace,
+		"more-context":      &k.MoreContext,
+		"less-context":      &k.LessContext,
+		"diff-format":       &k.DiffFormat,
+		"log-template":      &k.LogTemplate,
+		"file-content":      &k.FileContent,
+		"status":            &k.ToggleStatus,
+		"auto-refresh":      &k.AutoRefresh,
+		"theme":             &k.CycleTheme,
+		"shrink-left":       &k.ShrinkLeft,
+		"grow-left":         &k.GrowLeft,
+		"layout":            &k.ToggleLayout,
+		"dismiss":           &k.Dismiss,
+		"error-details":     &k.ErrorInfo,
+		"help":              &k.Help,
+	}
+}
+
+// applyKeyOverrides rebinds actions to the keys configured for their IDs.
+// It returns a description of each problem: unknown actions, empty key
+// lists, and keys left bound to more than one action.
+func applyKeyOverrides(keys *KeyMap, overrides map[string]config.KeyList) []string {
+	bindings := keys.bindingsByID()
+
+	var problems []string
+
+	for _, id := range slices.Sorted(maps.Keys(overrides)) {
+		binding, ok := bindings[id]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unknown action %q", id))
+			continue
+		}
+
+		names := overrides[id]
+		if len(names) == 0 {
+			problems = append(problems, fmt.Sprintf("no keys given for %q", id))
+			continue
+		}
+
+		binding.SetKeys(names...)
+		binding.SetHelp(strings.Join(names, "/"), binding.Help().Desc)
+	}
+
+	owners := make(map[string][]string)
+
+	for id, binding := range bindings {
+		for _, name := range binding.Keys() {
+			owners[name] = append(owners[name], id)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(owners)) {
+		if ids := owners[name]; len(ids) > 1 {
+			slices.Sort(ids)
+			problems = append(problems, fmt.Sprintf("key %q is bound to %s", name, strings.Join(ids, " and ")))
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/chatter/chado/internal/config"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestBindingsByID_CoversGlobalBindings(t *testing.T) {
+	m := newTestModel(t)
+	byID := m.keys.bindingsByID()
+
+	for _, ab := range m.globalBindings() {
+		if _, ok := byID[ab.ID]; !ok {
+			t.Errorf("binding %q cannot be remapped from config", ab.ID)
+		}
+	}
+}
+
+func TestApplyKeyOverrides_DefaultsHaveNoConflicts(t *testing.T) {
+	keys := DefaultKeyMap()
+
+	if problems := applyKeyOverrides(&keys, nil); len(problems) != 0 {
+		t.Errorf("default bindings should not conflict: %v", problems)
+	}
+}
+
+func TestApplyKeyOverrides_Remaps(t *testing.T) {
+	keys := DefaultKeyMap()
+
+	problems := applyKeyOverrides(&keys, map[string]config.KeyList{"describe": {"D", "f2"}})
+	if len(problems) != 0 {
+		t.Fatalf("unexpected problems: %v", problems)
+	}
+
+	if !slices.Equal(keys.Describe.Keys(), []string{"D", "f2"}) {
+		t.Errorf("describe should be bound to D and f2, got %v", keys.Describe.Keys())
+	}
+
+	if help := keys.Describe.Help(); help.Key != "D/f2" || help.Desc != "describe" {
+		t.Errorf("help should show the new keys and keep the description, got %+v", help)
+	}
+}
+
+func TestApplyKeyOverrides_ReportsProblems(t *testing.T) {
+	keys := DefaultKeyMap()
+
+	problems := applyKeyOverrides(&keys, map[string]config.KeyList{
+		"describe": {"e"}, // edit's key
+		"rebase":   {"r"},
+		"new":      {},
+	})
+
+	report := strings.Join(problems, "\n")
+
+	for _, want := range []string{`unknown action "rebase"`, `no keys given for "new"`, `key "e" is bound to describe and edit`} {
+		if !strings.Contains(report, want) {
+			t.Errorf("expected problem %q, got:\n%s", want, report)
+		}
+	}
+
+	if !slices.Equal(keys.New.Keys(), []string{"n"}) {
+		t.Errorf("an empty override should keep the default keys, got %v", keys.New.Keys())
+	}
+}
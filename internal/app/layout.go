@@ -0,0 +1,113 @@
+package app
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// LayoutMode chooses how the panes are arranged.
+type LayoutMode int
+
+const (
+	// LayoutAuto stacks the panes on narrow terminals and puts them side by
+	// side otherwise.
+	LayoutAuto LayoutMode = iota
+	// LayoutSideBySide keeps the log and op log left of the diff.
+	LayoutSideBySide
+	// LayoutStacked puts the log above the diff and the op log below it.
+	LayoutStacked
+)
+
+const (
+	// stackedLayoutMaxWidth is the terminal width below which the auto
+	// layout stacks the panes; side by side, the log and diff get too narrow.
+	stackedLayoutMaxWidth = 100
+
+	// stackedLogPct and stackedOpLogPct are the log's and op log's shares of
+	// height when stacked; the diff takes the rest.
+	stackedLogPct   = 40
+	stackedOpLogPct = 20
+
+	// minTerminalWidth and minTerminalHeight are the smallest screen the
+	// panels are usable on; below it their borders and titles crowd out
+	// their content.
+	minTerminalWidth  = 40
+	minTerminalHeight = 16
+)
+
+// paneHeights holds the heights of the stacked rows, top to bottom.
+type paneHeights struct {
+	log   int
+	diff  int
+	opLog int
+}
+
+// stacked reports whether the panes are currently stacked.
+func (m *Model) stacked() bool {
+	switch m.layout {
+	case LayoutStacked:
+		return true
+	case LayoutSideBySide:
+		return false
+	case LayoutAuto:
+	}
+
+	return m.width < stackedLayoutMaxWidth
+}
+
+// stackedHeights splits the content height between the stacked rows.
+func (m *Model) stackedHeights() paneHeights {
+	contentHeight := m.height - statusBarHeight
+	log := contentHeight * stackedLogPct / percentDivisor
+	opLog := contentHeight * stackedOpLogPct / percentDivisor
+
+	return paneHeights{log: log, diff: contentHeight - log - opLog, opLog: opLog}
+}
+
+// actionToggleLayout switches between side-by-side and stacked panes,
+// overriding the automatic choice for the rest of the session.
+func (m *Model) actionToggleLayout() (Model, tea.Cmd) {
+	if m.stacked() {
+		m.layout = LayoutSideBySide
+	} else {
+		m.layout = LayoutStacked
+	}
+
+	m.updatePanelSizes()
+
+	if m.layout == LayoutStacked {
+		return *m, m.toasts.Info("stacked layout")
+	}
+
+	return *m, m.toasts.Info("side-by-side layout")
+}
+
+// tooSmall reports whether the screen is too small to lay the panels out,
+// once its size is known. Linear mode shows one panel and cuts it to fit,
+// so it is never too small.
+func (m *Model) tooSmall() bool {
+	if m.linear || m.width == 0 || m.height == 0 {
+		return false
+	}
+
+	return m.width < minTerminalWidth || m.height < minTerminalHeight
+}
+
+// renderTooSmall fills the screen with the size needed and the size there
+// is, in place of the panels.
+func (m *Model) renderTooSmall() string {
+	message := fmt.Sprintf("terminal too small\n(need ≥ %d×%d, have %d×%d)",
+		minTerminalWidth, minTerminalHeight, m.width, m.height)
+
+	// A screen narrower than the message wraps it, cut to the height
+	text := lipgloss.NewStyle().
+		Width(m.width).
+		MaxHeight(m.height).
+		Align(lipgloss.Center).
+		Foreground(lipgloss.Color(m.styles.Theme().Error)).
+		Render(message)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, text)
+}
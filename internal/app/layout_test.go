@@ -0,0 +1,139 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"pgregory.net/rapid"
+
+	"github.com/chatter/chado/internal/ui"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestLayout_AutoStacksNarrowTerminals(t *testing.T) {
+	m := newTestModel(t)
+
+	m.Update(tea.WindowSizeMsg{Width: stackedLayoutMaxWidth - 1, Height: 40})
+
+	if !m.stacked() {
+		t.Error("narrow terminals should stack the panes")
+	}
+
+	m.Update(tea.WindowSizeMsg{Width: stackedLayoutMaxWidth, Height: 40})
+
+	if m.stacked() {
+		t.Error("wide terminals should keep the panes side by side")
+	}
+}
+
+func TestLayout_ToggleOverridesAuto(t *testing.T) {
+	m := newTestModel(t)
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+
+	next, _ := m.actionToggleLayout()
+	*m = next
+
+	if m.stacked() || m.layout != LayoutSideBySide {
+		t.Fatal("toggling a stacked layout should put the panes side by side")
+	}
+
+	m.Update(tea.WindowSizeMsg{Width: 60, Height: 40})
+
+	if m.stacked() {
+		t.Error("a layout chosen by hand should survive resizing")
+	}
+
+	next, _ = m.actionToggleLayout()
+	*m = next
+
+	if !m.stacked() {
+		t.Error("toggling again should stack the panes")
+	}
+}
+
+func TestLayout_StackedHeightsFillScreen(t *testing.T) {
+	m := newTestModel(t)
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 41})
+
+	heights := m.stackedHeights()
+	if total := heights.log + heights.diff + heights.opLog; total != 41-statusBarHeight {
+		t.Errorf("stacked rows should fill the content height, got %d", total)
+	}
+
+	if heights.diff < heights.opLog {
+		t.Errorf("the diff should get more room than the op log, got %+v", heights)
+	}
+}
+
+func TestLayout_StackedClickFocusesDiff(t *testing.T) {
+	m := newTestModel(t)
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+
+	heights := m.stackedHeights()
+	m.Update(tea.MouseClickMsg{X: 70, Y: heights.log + 1, Button: tea.MouseLeft})
+
+	if m.focusedPane != PaneDiff {
+		t.Errorf("clicking the middle row should focus the diff, got %v", m.focusedPane)
+	}
+}
+
+func TestLayout_StackedHasNoDivider(t *testing.T) {
+	m := newTestModel(t)
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+
+	if m.onDivider(m.leftWidth() - 1) {
+		t.Error("stacked panes have no divider to drag")
+	}
+}
+
+func TestLayout_TooSmallScreen(t *testing.T) {
+	m := newTestModel(t)
+	m.Update(tea.WindowSizeMsg{Width: 30, Height: 10})
+
+	view := ui.StripANSI(m.renderTooSmall())
+	if !strings.Contains(view, "terminal too small") || !strings.Contains(view, "have 30×10") {
+		t.Errorf("expected the sizes needed and had, got:\n%s", view)
+	}
+
+	if _, cmd := m.handleKeyMsg(tea.KeyPressMsg{Code: 'n', Text: "n"}); cmd != nil {
+		t.Error("keys other than quit should be ignored while too small")
+	}
+
+	m.Update(tea.WindowSizeMsg{Width: minTerminalWidth, Height: minTerminalHeight})
+
+	if m.tooSmall() {
+		t.Error("growing to the minimum should bring the panels back")
+	}
+}
+
+func TestLayout_LinearIsNeverTooSmall(t *testing.T) {
+	m := newLinearTestModel(t, 30, 10)
+
+	if m.tooSmall() {
+		t.Error("linear mode cuts its panel to fit any screen")
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: the too-small screen fills the screen exactly, whatever its size.
+func TestLayout_TooSmallFillsScreen(t *testing.T) {
+	rapid.Check(t, func(rt *rapid.T) {
+		width := rapid.IntRange(1, minTerminalWidth-1).Draw(rt, "width")
+		height := rapid.IntRange(1, minTerminalHeight+10).Draw(rt, "height")
+
+		m := newTestModel(t)
+		m.Update(tea.WindowSizeMsg{Width: width, Height: height})
+
+		view := m.renderTooSmall()
+		if got := strings.Count(view, "\n") + 1; got != height {
+			rt.Fatalf("%d lines on a screen %d high", got, height)
+		}
+	})
+}
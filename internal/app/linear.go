@@ -0,0 +1,158 @@
+package app
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/chatter/chado/internal/ui"
+)
+
+// renderLinear draws the focused panel alone as plain text, for screen
+// readers: a heading naming it, what is under the cursor, any dialog open
+// over it, its content without borders, then notices and the status bar.
+func (m *Model) renderLinear() string {
+	panel := unbox(m.linearPanelView())
+
+	heading, content := "", panel
+	if len(panel) > 0 {
+		heading, content = strings.TrimSpace(panel[0]), panel[1:]
+	}
+
+	header := []string{"Panel: " + heading, m.announceCursor(content)}
+
+	var dialog []string
+	if overlay := m.linearOverlay(); overlay != "" {
+		dialog = append([]string{"Dialog:"}, trimmedLines(unbox(overlay))...)
+	}
+
+	footer := m.linearNotices()
+	footer = append(footer, ui.StripANSI(m.renderStatusBar()))
+
+	// The panel's empty rows and whatever the screen cannot hold are cut
+	for len(content) > 0 && content[len(content)-1] == "" {
+		content = content[:len(content)-1]
+	}
+
+	room := max(m.height-len(header)-len(dialog)-len(footer), 0)
+	content = content[:min(len(content), room)]
+
+	return strings.Join(slices.Concat(header, dialog, content, footer), "\n")
+}
+
+// linearPanelView returns the view of the focused panel, or of the diff
+// while a confirmation previews what it would do there.
+func (m *Model) linearPanelView() string {
+	if m.confirming && m.diffPanel.Previewing() {
+		return m.diffPanel.View()
+	}
+
+	switch m.focusedPane {
+	case PaneLog:
+		return m.leftTopView()
+	case PaneOpLog:
+		return m.opLogPanel.View()
+	case PaneDiff:
+	}
+
+	if m.comparing {
+		return m.compareLogPanel.View()
+	}
+
+	return m.diffPanel.View()
+}
+
+// linearOverlay returns the view of the open dialog, or "" when none is.
+func (m *Model) linearOverlay() string {
+	switch {
+	case m.showHelp:
+		return m.helpModal()
+	case m.confirming:
+		return m.confirmDialog.View()
+	case m.showingError:
+		return m.errorPanel.View()
+	case m.editMode:
+		return m.describeInput.View()
+	case m.finding:
+		return m.finder.View()
+	case m.commanding:
+		return m.commandLine.View()
+	}
+
+	if nav := m.focusedNavigator(); nav.KeyPending() {
+		m.whichKey.SetPending(nav.PendingKey(), m.pendingBindings())
+		return m.whichKey.View()
+	}
+
+	return ""
+}
+
+// announceCursor says what the row under the cursor holds, found by the
+// cursor marker that starts it.
+func (m *Model) announceCursor(content []string) string {
+	marker := ui.StripANSI(m.styles.Cursor())
+
+	for _, line := range content {
+		if row, ok := strings.CutPrefix(line, marker); ok {
+			return "Cursor: " + strings.TrimSpace(row)
+		}
+	}
+
+	return "Cursor: none"
+}
+
+// linearNotices spells out the toasts, one line each, with their level.
+func (m *Model) linearNotices() []string {
+	toasts := m.toasts.Items()
+	lines := make([]string, 0, len(toasts))
+
+	for _, toast := range toasts {
+		level := "Notice"
+
+		switch toast.Level {
+		case ui.ToastSuccess:
+			level = "Done"
+		case ui.ToastError:
+			level = "Error"
+		case ui.ToastInfo:
+		}
+
+		lines = append(lines, level+": "+strings.Join(strings.Fields(toast.Text), " "))
+	}
+
+	return lines
+}
+
+// unbox returns the lines of a bordered view without colors or the border
+// around them, trimmed on the right.
+func unbox(view string) []string {
+	lines := strings.Split(ui.StripANSI(view), "\n")
+	if len(lines) <= ui.PanelBorderHeight {
+		return nil
+	}
+
+	inner := make([]string, 0, len(lines)-ui.PanelBorderHeight)
+
+	for _, line := range lines[1 : len(lines)-1] {
+		runes := []rune(line)
+		if len(runes) >= ui.PanelBorderWidth {
+			runes = runes[1 : len(runes)-1]
+		}
+
+		inner = append(inner, strings.TrimRight(string(runes), " "))
+	}
+
+	return inner
+}
+
+// trimmedLines returns the lines that are not blank, without their padding.
+func trimmedLines(lines []string) []string {
+	var kept []string
+
+	for _, line := range lines {
+		if line = strings.TrimSpace(line); line != "" {
+			kept = append(kept, line)
+		}
+	}
+
+	return kept
+}
@@ -0,0 +1,128 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"pgregory.net/rapid"
+
+	"github.com/chatter/chado/internal/config"
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/logger"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// newLinearTestModel returns a model in linear mode showing a two-change
+// log on a width by height screen.
+func newLinearTestModel(t *testing.T, width, height int) *Model {
+	t.Helper()
+
+	cfg := config.Default()
+	cfg.Linear = true
+
+	log, _ := logger.New("")
+	m := New(context.Background(), t.TempDir(), "test", cfg, log)
+	m.Update(tea.WindowSizeMsg{Width: width, Height: height})
+
+	m.changes = []jj.Change{{ChangeID: "aaaaaaaa"}, {ChangeID: "bbbbbbbb"}}
+	m.logPanel.SetContent("@ aaaaaaaa fix parser\n○ bbbbbbbb add lexer\n", m.changes)
+
+	return &m
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestLinear_ShowsFocusedPanelAsText(t *testing.T) {
+	m := newLinearTestModel(t, 80, 24)
+	m.logPanel.CursorDown()
+
+	content := m.renderLinear()
+	lines := strings.Split(content, "\n")
+
+	if !strings.HasPrefix(lines[0], "Panel: [1]") {
+		t.Errorf("the first line should name the panel, got %q", lines[0])
+	}
+
+	if lines[1] != "Cursor: ○ bbbbbbbb add lexer" {
+		t.Errorf("the second line should say what is under the cursor, got %q", lines[1])
+	}
+
+	if strings.ContainsAny(content, "╭╰\x1b") {
+		t.Errorf("linear mode should draw no borders or colors, got:\n%s", content)
+	}
+
+	if strings.Contains(content, "Operations") {
+		t.Errorf("only the focused panel should show, got:\n%s", content)
+	}
+
+	if m.View().MouseMode != tea.MouseModeNone {
+		t.Error("linear mode has no layout for clicks to land on")
+	}
+}
+
+func TestLinear_DialogComesFirst(t *testing.T) {
+	m := newLinearTestModel(t, 80, 24)
+	m.actionCommand()
+
+	lines := strings.Split(m.renderLinear(), "\n")
+
+	if lines[2] != "Dialog:" {
+		t.Fatalf("an open dialog should follow the heading, got:\n%s", strings.Join(lines, "\n"))
+	}
+
+	if strings.HasPrefix(lines[3], "│") || lines[3] == "" {
+		t.Errorf("the dialog should be unboxed and trimmed, got %q", lines[3])
+	}
+}
+
+func TestLinear_SpellsOutToasts(t *testing.T) {
+	m := newLinearTestModel(t, 80, 24)
+	m.toasts.Error("push failed:\nrejected")
+
+	if !strings.Contains(m.renderLinear(), "\nError: push failed: rejected\n") {
+		t.Errorf("toasts should be one line each with their level, got:\n%s", m.renderLinear())
+	}
+}
+
+func TestUnbox_DropsBorder(t *testing.T) {
+	view := lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Render("│ graph\nline")
+
+	got := unbox(view)
+	if len(got) != 2 || got[0] != "│ graph" || got[1] != "line" {
+		t.Errorf("only the border should go, keeping content's own lines, got %q", got)
+	}
+
+	if unbox("") != nil {
+		t.Error("nothing is inside an empty view")
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: the linear view never runs past the bottom of the screen.
+func TestLinear_FitsScreen(t *testing.T) {
+	rapid.Check(t, func(rt *rapid.T) {
+		width := rapid.IntRange(20, 200).Draw(rt, "width")
+		height := rapid.IntRange(8, 60).Draw(rt, "height")
+		toasts := rapid.IntRange(0, 3).Draw(rt, "toasts")
+
+		m := newLinearTestModel(t, width, height)
+		for range toasts {
+			m.toasts.Info("note")
+		}
+
+		if got := strings.Count(m.renderLinear(), "\n") + 1; got > height {
+			rt.Fatalf("%d lines on a screen %d high", got, height)
+		}
+	})
+}
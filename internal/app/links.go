@@ -0,0 +1,162 @@
+package app
+
+import (
+	"errors"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/forge"
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui"
+)
+
+// errNoForge is reported when no remote is on a forge to open a commit on.
+var errNoForge = errors.New("no git remote on a known forge; set links.commit_url")
+
+// commitLinksMsg carries the commit URL template found from the remotes.
+type commitLinksMsg struct {
+	template string
+}
+
+// webOpenedMsg reports the page the browser was asked to show, and why it
+// could not be when err is set.
+type webOpenedMsg struct {
+	url string
+	err error
+}
+
+// loadCommitLinks works out where commit IDs link to: the configured URL,
+// or the forge of the preferred git remote. A repo without either gets no
+// links.
+func (m *Model) loadCommitLinks() tea.Cmd {
+	if !m.commitLinks {
+		return nil
+	}
+
+	runner, configured := m.runner, m.commitURL
+
+	return func() tea.Msg {
+		template, err := commitURLTemplate(runner, configured)
+		if err != nil {
+			m.log.Warn("listing git remotes for commit links failed", "err", err)
+			return nil
+		}
+
+		return commitLinksMsg{template: template}
+	}
+}
+
+// actionOpenWeb opens the selected change's commit on the forge in the
+// browser. Where no browser starts, the toast gives the address instead.
+func (m *Model) actionOpenWeb() (Model, tea.Cmd) {
+	if m.focusedPane != PaneLog || m.viewMode != ViewLog {
+		return *m, nil
+	}
+
+	selected := m.logPanel.SelectedChange()
+	if selected == nil {
+		return *m, nil
+	}
+
+	runner, configured, browser, commitID := m.runner, m.commitURL, m.openCommands.Browser, selected.CommitID
+
+	return *m, func() tea.Msg {
+		template, err := commitURLTemplate(runner, configured)
+		if err != nil {
+			return errMsg{err}
+		}
+
+		target := ui.CommitURL(template, commitID)
+		if target == "" {
+			return errMsg{errNoForge}
+		}
+
+		cmd, err := expandOpenCommand(browser, urlPlaceholder, target)
+		if err == nil {
+			err = cmd.Start()
+		}
+
+		if err != nil {
+			return webOpenedMsg{url: target, err: err}
+		}
+
+		go func() {
+			_ = cmd.Wait() // reap the launcher; browsers detach on their own
+		}()
+
+		return webOpenedMsg{url: target}
+	}
+}
+
+// handleWebOpened says which page opened, or gives its address when the
+// browser could not be started.
+func (m *Model) handleWebOpened(msg webOpenedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.log.Warn("starting the browser failed", "url", msg.url, "err", msg.err)
+		return m.toasts.Error("could not start the browser (" + msg.err.Error() + "); open " + msg.url)
+	}
+
+	return m.toasts.Info("opened " + msg.url)
+}
+
+// commitURLTemplate returns where commit IDs link to: the configured URL,
+// else the forge of the preferred git remote, or "" for neither.
+func commitURLTemplate(runner *jj.Runner, configured string) (string, error) {
+	if configured != "" {
+		return configured, nil
+	}
+
+	remotes, err := runner.GitRemotes()
+	if err != nil {
+		return "", err
+	}
+
+	return forgeCommitURL(preferredRemote(remotes)), nil
+}
+
+// handleCommitLinks links the commit IDs of both logs.
+func (m *Model) handleCommitLinks(msg commitLinksMsg) {
+	m.logPanel.SetCommitURL(msg.template)
+	m.compareLogPanel.SetCommitURL(msg.template)
+}
+
+// preferredRemote returns the URL of origin, else upstream, else the first
+// remote, or "" when there are none.
+func preferredRemote(remotes []jj.GitRemote) string {
+	for _, name := range []string{"origin", "upstream"} {
+		for _, remote := range remotes {
+			if remote.Name == name {
+				return remote.URL
+			}
+		}
+	}
+
+	if len(remotes) > 0 {
+		return remotes[0].URL
+	}
+
+	return ""
+}
+
+// forgeCommitURL returns the commit page template of the forge hosting
+// remote, or "" for a remote that is not on one (a local path). Hosts it
+// does not know are taken to lay pages out like GitHub, as Gitea and
+// Forgejo do.
+func forgeCommitURL(remote string) string {
+	host, path := forge.HostPath(remote)
+	if host == "" || path == "" {
+		return ""
+	}
+
+	commitPath := "/commit/"
+
+	switch {
+	case strings.Contains(host, "gitlab"):
+		commitPath = "/-/commit/"
+	case host == "bitbucket.org":
+		commitPath = "/commits/"
+	}
+
+	return "https://" + host + "/" + path + commitPath + ui.CommitIDPlaceholder
+}
@@ -0,0 +1,189 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"pgregory.net/rapid"
+
+	"github.com/chatter/chado/internal/config"
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/logger"
+	"github.com/chatter/chado/internal/ui"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestForgeCommitURL(t *testing.T) {
+	tests := []struct {
+		remote string
+		want   string
+	}{
+		{"git@github.com:chatter/chado.git", "https://github.com/chatter/chado/commit/{commit_id}"},
+		{"https://github.com/chatter/chado", "https://github.com/chatter/chado/commit/{commit_id}"},
+		{"ssh://git@gitlab.com:22/group/sub/chado.git", "https://gitlab.com/group/sub/chado/-/commit/{commit_id}"},
+		{"https://user@bitbucket.org/team/chado.git", "https://bitbucket.org/team/chado/commits/{commit_id}"},
+		{"git@codeberg.org:chatter/chado.git", "https://codeberg.org/chatter/chado/commit/{commit_id}"},
+		{"/srv/git/chado.git", ""},
+		{"file:///srv/git/chado.git", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := forgeCommitURL(tt.remote); got != tt.want {
+			t.Errorf("forgeCommitURL(%q) = %q, want %q", tt.remote, got, tt.want)
+		}
+	}
+}
+
+func TestPreferredRemote(t *testing.T) {
+	remotes := []jj.GitRemote{{Name: "fork", URL: "fork-url"}, {Name: "upstream", URL: "upstream-url"}}
+
+	if got := preferredRemote(remotes); got != "upstream-url" {
+		t.Errorf("upstream should win over other remotes, got %q", got)
+	}
+
+	remotes = append(remotes, jj.GitRemote{Name: "origin", URL: "origin-url"})
+
+	if got := preferredRemote(remotes); got != "origin-url" {
+		t.Errorf("origin should win, got %q", got)
+	}
+
+	if got := preferredRemote(remotes[:1]); got != "fork-url" {
+		t.Errorf("a lone remote should be used, got %q", got)
+	}
+
+	if preferredRemote(nil) != "" {
+		t.Error("no remotes means no forge")
+	}
+}
+
+func TestCommitLinks_ConfiguredURL(t *testing.T) {
+	cfg := config.Default()
+	cfg.Links.CommitURL = "https://git.example.com/c/{commit_id}"
+
+	log, _ := logger.New("")
+	m := New(context.Background(), t.TempDir(), "test", cfg, log)
+
+	cmd := m.loadCommitLinks()
+	if cmd == nil {
+		t.Fatal("expected the configured URL to be loaded")
+	}
+
+	msg, ok := cmd().(commitLinksMsg)
+	if !ok || msg.template != cfg.Links.CommitURL {
+		t.Fatalf("the configured URL should win over the remotes, got %+v", msg)
+	}
+
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m.changes = []jj.Change{{ChangeID: "aaaaaaaa", CommitID: "230dd059"}}
+	m.logPanel.SetContent("@ aaaaaaaa 230dd059\n", m.changes)
+	m.handleCommitLinks(msg)
+
+	if !strings.Contains(m.logPanel.View(), "https://git.example.com/c/230dd059") {
+		t.Error("the log's commit IDs should link to the configured URL")
+	}
+}
+
+func TestCommitLinks_Off(t *testing.T) {
+	cfg := config.Default()
+	cfg.Links.Commits = false
+
+	log, _ := logger.New("")
+	m := New(context.Background(), t.TempDir(), "test", cfg, log)
+
+	if m.loadCommitLinks() != nil {
+		t.Error("commit links turned off should not look for a forge")
+	}
+}
+
+func TestOpenWeb_OpensSelectedCommit(t *testing.T) {
+	cfg := config.Default()
+	cfg.Links.CommitURL = "https://git.example.com/c/{commit_id}"
+
+	log, _ := logger.New("")
+	m := New(context.Background(), t.TempDir(), "test", cfg, log)
+	m.openCommands.Browser = "true {url}"
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m.changes = []jj.Change{{ChangeID: "aaaaaaaa", CommitID: "230dd059"}}
+	m.logPanel.SetContent("@ aaaaaaaa 230dd059\n", m.changes)
+
+	_, cmd := m.actionOpenWeb()
+	if cmd == nil {
+		t.Fatal("expected a command opening the commit")
+	}
+
+	msg, ok := cmd().(webOpenedMsg)
+	if !ok || msg.err != nil || msg.url != "https://git.example.com/c/230dd059" {
+		t.Fatalf("the browser should be sent to the commit's page, got %+v", msg)
+	}
+}
+
+func TestOpenWeb_GivesURLWhenBrowserFails(t *testing.T) {
+	log, _ := logger.New("")
+	m := New(context.Background(), t.TempDir(), "test", config.Default(), log)
+
+	m.handleWebOpened(webOpenedMsg{url: "https://git.example.com/c/230dd059", err: errEmptyOpenCommand})
+
+	toasts := m.toasts.Items()
+	if len(toasts) != 1 || !strings.Contains(toasts[0].Text, "open https://git.example.com/c/230dd059") {
+		t.Errorf("a failed launch should leave the address to open by hand, got %+v", toasts)
+	}
+}
+
+func TestOpenWeb_NeedsForge(t *testing.T) {
+	log, _ := logger.New("")
+	m := New(context.Background(), t.TempDir(), "test", config.Default(), log)
+
+	// A jj whose only remote is a local path, which no forge hosts
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "jj"), []byte("#!/bin/sh\necho 'origin /srv/git/chado'\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("PATH", bin)
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m.changes = []jj.Change{{ChangeID: "aaaaaaaa", CommitID: "230dd059"}}
+	m.logPanel.SetContent("@ aaaaaaaa 230dd059\n", m.changes)
+
+	_, cmd := m.actionOpenWeb()
+	if cmd == nil {
+		t.Fatal("expected a command looking for the forge")
+	}
+
+	if msg, ok := cmd().(errMsg); !ok || !errors.Is(msg.err, errNoForge) {
+		t.Errorf("a repo without a forge remote should say so, got %+v", msg)
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: a GitHub remote links to the same page however it is written.
+func TestForgeCommitURL_SameForEverySyntax(t *testing.T) {
+	rapid.Check(t, func(rt *rapid.T) {
+		owner := rapid.StringMatching(`[a-z][a-z0-9-]{0,10}`).Draw(rt, "owner")
+		repo := rapid.StringMatching(`[a-z][a-z0-9-]{0,10}`).Draw(rt, "repo")
+		suffix := rapid.SampledFrom([]string{"", ".git", "/"}).Draw(rt, "suffix")
+
+		want := "https://github.com/" + owner + "/" + repo + "/commit/" + ui.CommitIDPlaceholder
+
+		for _, remote := range []string{
+			"git@github.com:" + owner + "/" + repo + suffix,
+			"https://github.com/" + owner + "/" + repo + suffix,
+			"ssh://git@github.com/" + owner + "/" + repo + suffix,
+		} {
+			if got := forgeCommitURL(remote); got != want {
+				rt.Fatalf("forgeCommitURL(%q) = %q, want %q", remote, got, want)
+			}
+		}
+	})
+}
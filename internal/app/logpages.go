@@ -0,0 +1,38 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+)
+
+// logPrefetchRows is how close the cursor gets to the last loaded change (or
+// operation) before the next page of the log (or op log) loads, so scrolling
+// rarely waits on it.
+const logPrefetchRows = 20
+
+// loadMoreLog grows the log by a page once the cursor nears its end, while
+// the last load was cut off and no larger one is already on its way.
+func (m *Model) loadMoreLog() tea.Cmd {
+	if !m.logMore || m.logLoadingMore || m.logPanel.Remaining() >= logPrefetchRows {
+		return nil
+	}
+
+	m.logLimit += m.logPageSize
+	m.logLoadingMore = true
+	m.log.Debug("loading more of the log", "limit", m.logLimit)
+
+	return m.loadLog()
+}
+
+// loadMoreOpLog grows the op log by a page the same way. The evolog shown
+// in its place loads whole.
+func (m *Model) loadMoreOpLog() tea.Cmd {
+	if m.viewMode != ViewLog || !m.opLogMore || m.opLogLoadingMore || m.opLogPanel.Remaining() >= logPrefetchRows {
+		return nil
+	}
+
+	m.opLogLimit += m.opLogPageSize
+	m.opLogLoadingMore = true
+	m.log.Debug("loading more of the op log", "limit", m.opLogLimit)
+
+	return m.loadOpLog()
+}
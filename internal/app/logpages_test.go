@@ -0,0 +1,137 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// logPage returns a log of n changes, as loaded with the given limit.
+func logPage(n, limit int) logLoadedMsg {
+	var raw strings.Builder
+
+	changes := make([]jj.Change, n)
+
+	for i := range changes {
+		changes[i] = jj.Change{ChangeID: fmt.Sprintf("change%c%c", 'a'+i/26, 'a'+i%26)}
+		fmt.Fprintf(&raw, "○ %s %d\n", changes[i].ChangeID, i)
+	}
+
+	return logLoadedMsg{raw: raw.String(), changes: changes, limit: limit}
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestLoadMoreLog_NearTheEnd(t *testing.T) {
+	m := newTestModel(t)
+	m.logPageSize, m.logLimit = 50, 50
+	m.handleLogLoaded(logPage(50, 50))
+
+	if cmd := m.loadMoreLog(); cmd != nil {
+		t.Fatal("the cursor is far from the end, nothing more should load")
+	}
+
+	m.logPanel.GotoBottom()
+
+	if cmd := m.loadMoreLog(); cmd == nil || m.logLimit != 100 {
+		t.Fatalf("nearing the end should load another page, limit is %d", m.logLimit)
+	}
+
+	if cmd := m.loadMoreLog(); cmd != nil {
+		t.Error("a page already on its way should not be requested again")
+	}
+
+	m.handleLogLoaded(logPage(70, 100))
+
+	if cmd := m.loadMoreLog(); cmd != nil {
+		t.Error("a log shorter than its limit is complete")
+	}
+}
+
+func TestLoadMoreLog_WholeLog(t *testing.T) {
+	m := newTestModel(t)
+	m.logPageSize, m.logLimit = 0, 0
+	m.handleLogLoaded(logPage(30, 0))
+	m.logPanel.GotoBottom()
+
+	if cmd := m.loadMoreLog(); cmd != nil {
+		t.Error("without a page size the whole log is loaded at once")
+	}
+}
+
+// opLogPage returns an op log of n operations, every other one a snapshot,
+// as loaded with the given limit.
+func opLogPage(n, limit int) opLogLoadedMsg {
+	var raw strings.Builder
+
+	operations := make([]jj.Operation, n)
+
+	for i := range operations {
+		description := "new empty commit"
+		if i%2 == 1 {
+			description = "snapshot working copy"
+		}
+
+		op := fmt.Sprintf("○  %012x user@host\n│  %s", i, description)
+		operations[i] = jj.Operation{OpID: fmt.Sprintf("%012x", i), Description: description, Raw: op}
+		raw.WriteString(op + "\n")
+	}
+
+	return opLogLoadedMsg{raw: raw.String(), operations: operations, limit: limit}
+}
+
+func TestLoadMoreOpLog_NearTheEnd(t *testing.T) {
+	m := newTestModel(t)
+	m.opLogPageSize, m.opLogLimit = 50, 50
+	m.handleOpLogLoaded(opLogPage(50, 50))
+
+	if cmd := m.loadMoreOpLog(); cmd != nil {
+		t.Fatal("the cursor is far from the end, nothing more should load")
+	}
+
+	m.opLogPanel.GotoBottom()
+
+	if cmd := m.loadMoreOpLog(); cmd == nil || m.opLogLimit != 100 {
+		t.Fatalf("nearing the end should load another page, limit is %d", m.opLogLimit)
+	}
+
+	if cmd := m.loadMoreOpLog(); cmd != nil {
+		t.Error("a page already on its way should not be requested again")
+	}
+
+	m.handleOpLogLoaded(opLogPage(70, 100))
+
+	if cmd := m.loadMoreOpLog(); cmd != nil {
+		t.Error("an op log shorter than its limit is complete")
+	}
+}
+
+func TestToggleSnapshots(t *testing.T) {
+	m := newTestModel(t)
+	m.opLogPageSize, m.opLogLimit = 100, 100
+	m.handleOpLogLoaded(opLogPage(60, 100))
+
+	if binding := m.snapshotsKey(); binding.Enabled() {
+		t.Error("hiding snapshots only applies with the op log focused")
+	}
+
+	m.focusedPane = PaneOpLog
+
+	next, _ := m.actionToggleSnapshots()
+	*m = next
+
+	if !m.opLogPanel.HidingSnapshots() || m.opLogPanel.Remaining() != 29 {
+		t.Errorf("the 30 snapshots should be hidden, %d operations below the cursor", m.opLogPanel.Remaining())
+	}
+
+	next, _ = m.actionToggleSnapshots()
+	*m = next
+
+	if m.opLogPanel.HidingSnapshots() || m.opLogPanel.Remaining() != 59 {
+		t.Errorf("snapshots should be shown again, %d operations below the cursor", m.opLogPanel.Remaining())
+	}
+}
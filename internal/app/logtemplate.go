@@ -0,0 +1,38 @@
+package app
+
+import (
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// logTemplateChoices returns the log templates to switch between: the one
+// the config picks (empty for jj's templates.log) first, then jj's built-ins.
+func logTemplateChoices(configured string) []string {
+	choices := []string{configured}
+
+	for _, template := range jj.LogTemplates {
+		if template != configured {
+			choices = append(choices, template)
+		}
+	}
+
+	return choices
+}
+
+// actionCycleLogTemplate renders the log with the next template, from one
+// line a change to every detail, and reloads it.
+func (m *Model) actionCycleLogTemplate() (Model, tea.Cmd) {
+	current := slices.Index(m.logTemplates, m.runner.LogTemplate())
+	template := m.logTemplates[(current+1)%len(m.logTemplates)]
+	m.runner.SetLogTemplate(template)
+
+	name := template
+	if name == "" {
+		name = "jj's templates.log"
+	}
+
+	return *m, tea.Batch(m.toasts.Info("log template: "+name), m.loadLog())
+}
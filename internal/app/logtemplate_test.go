@@ -0,0 +1,40 @@
+package app
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestLogTemplateChoices_ConfiguredFirst(t *testing.T) {
+	want := []string{"builtin_log_detailed", "builtin_log_oneline", "builtin_log_compact", "builtin_log_comfortable"}
+	if got := logTemplateChoices("builtin_log_detailed"); !slices.Equal(got, want) {
+		t.Errorf("choices = %v, want %v", got, want)
+	}
+
+	if got := logTemplateChoices(""); len(got) != len(jj.LogTemplates)+1 || got[0] != "" {
+		t.Errorf("with no template configured, jj's templates.log should come first, got %v", got)
+	}
+}
+
+func TestCycleLogTemplate_WrapsAround(t *testing.T) {
+	m := newTestModel(t)
+	m.logTemplates = logTemplateChoices("")
+
+	for _, want := range append(slices.Clone(jj.LogTemplates), "") {
+		m.actionCycleLogTemplate()
+
+		if got := m.runner.LogTemplate(); got != want {
+			t.Fatalf("template = %q, want %q", got, want)
+		}
+	}
+
+	if len(m.toasts.Items()) == 0 {
+		t.Error("switching templates should say which one is showing")
+	}
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"github.com/chatter/chado/internal/ui"
+	"github.com/chatter/chado/internal/ui/help"
+)
+
+// focusedNavigator returns the count prefix state of the focused panel.
+func (m *Model) focusedNavigator() *ui.Navigator {
+	switch m.focusedPane {
+	case PaneLog:
+		switch m.viewMode {
+		case ViewFiles:
+			return &m.filesPanel.Navigator
+		case ViewBookmarks:
+			return &m.bookmarksPanel.Navigator
+		case ViewStacks:
+			return &m.stacksPanel.Navigator
+		case ViewLog:
+		}
+
+		return &m.logPanel.Navigator
+	case PaneOpLog:
+		return &m.opLogPanel.Navigator
+	case PaneDiff:
+		if m.comparing {
+			return &m.compareLogPanel.Navigator
+		}
+	}
+
+	return &m.diffPanel.Navigator
+}
+
+// pendingBindings returns the keys that can follow the focused panel's
+// pending key, the diff's fold commands among them.
+func (m *Model) pendingBindings() []help.Binding {
+	if m.focusedPane == PaneDiff && !m.comparing {
+		return m.diffPanel.PendingBindings()
+	}
+
+	return m.focusedNavigator().PendingBindings()
+}
+
+// continuesKeys reports whether a key goes on with keys the focused panel
+// has begun: any digit once a count prefix is started, even those that
+// focus a pane, and the second key of gg or a z command (the t of zt).
+func (m *Model) continuesKeys(key string) bool {
+	nav := m.focusedNavigator()
+	if nav.KeyPending() {
+		return true
+	}
+
+	return nav.Counting() && len(key) == 1 && key[0] >= '0' && key[0] <= '9'
+}
+
+// resetCounts drops every panel's count prefix and pending key once a
+// global binding runs, so neither carries over to a later motion.
+func (m *Model) resetCounts() {
+	for _, nav := range []*ui.Navigator{
+		&m.logPanel.Navigator,
+		&m.filesPanel.Navigator,
+		&m.bookmarksPanel.Navigator,
+		&m.stacksPanel.Navigator,
+		&m.opLogPanel.Navigator,
+		&m.diffPanel.Navigator,
+		&m.compareLogPanel.Navigator,
+	} {
+		nav.Reset()
+	}
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestCount_DigitsPastFocusKeys(t *testing.T) {
+	m := newTestModel(t)
+	m.handleLogLoaded(logPage(40, 0))
+
+	for _, r := range "31j" {
+		m.handleKeyMsg(tea.KeyPressMsg(tea.Key{Code: r, Text: string(r)}))
+	}
+
+	if m.focusedPane != PaneLog {
+		t.Fatal("a digit continuing a count should not focus a pane")
+	}
+
+	if got := m.logPanel.SelectedChange().ChangeID; got != "changebf" {
+		t.Errorf("31j should select the 32nd change, got %s", got)
+	}
+}
+
+func TestCount_DroppedByGlobalKey(t *testing.T) {
+	m := newTestModel(t)
+	m.handleLogLoaded(logPage(40, 0))
+
+	m.handleKeyMsg(tea.KeyPressMsg(tea.Key{Code: '5', Text: "5"}))
+
+	if !m.logPanel.Counting() {
+		t.Fatal("5 should have started a count")
+	}
+
+	result, _ := m.handleKeyMsg(tea.KeyPressMsg(tea.Key{Code: 'b', Text: "b"}))
+
+	if result.(*Model).logPanel.Counting() {
+		t.Error("a global binding should drop the count typed before it")
+	}
+}
+
+func TestZCommand_KeysBypassGlobalBindings(t *testing.T) {
+	m := newTestModel(t)
+	m.handleLogLoaded(logPage(40, 0))
+
+	for _, r := range "zb" {
+		m.handleKeyMsg(tea.KeyPressMsg(tea.Key{Code: r, Text: string(r)}))
+	}
+
+	if m.viewMode != ViewLog || m.logPanel.KeyPending() {
+		t.Error("the b of zb should finish the z command, not open the bookmarks")
+	}
+}
+
+func TestWhichKey_ShownWhileKeyPending(t *testing.T) {
+	m := newTestModel(t)
+	m.width, m.height = 120, 40
+	m.handleLogLoaded(logPage(10, 0))
+
+	if strings.Contains(m.renderWithWhichKey(""), "scroll selection to center") {
+		t.Fatal("no popup should show without a pending key")
+	}
+
+	m.handleKeyMsg(tea.KeyPressMsg(tea.Key{Code: 'z', Text: "z"}))
+
+	if !strings.Contains(m.renderWithWhichKey(""), "scroll selection to center") {
+		t.Error("z should show the keys that can follow it")
+	}
+
+	m.handleKeyMsg(tea.KeyPressMsg(tea.Key{Code: 'z', Text: "z"}))
+
+	if strings.Contains(m.renderWithWhichKey(""), "scroll selection to center") {
+		t.Error("the popup should close once the command completes")
+	}
+}
@@ -0,0 +1,77 @@
+package app
+
+import (
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/ui/help"
+)
+
+// newMenuBindingID identifies the new change menu, and the choices it offers.
+const newMenuBindingID = "new-menu"
+
+// actionNewMenu offers where to create a new change relative to the change
+// selected in the log: on top of it, or inserted after or before it.
+func (m *Model) actionNewMenu() (Model, tea.Cmd) {
+	if m.focusedPane != PaneLog || m.viewMode != ViewLog {
+		return *m, nil
+	}
+
+	selected := m.logPanel.SelectedChange()
+	if selected == nil {
+		return *m, nil
+	}
+
+	m.palette = newMenu(selected.ChangeID, selected.IsImmutable)
+	m.finding = true
+	m.sizeFinder()
+
+	return *m, m.finder.Open("New change", paletteItems(m.palette))
+}
+
+// newMenu returns the choices of the new change menu for rev, run through
+// the palette. Inserting before rev rebases it, so an immutable rev refuses.
+func newMenu(rev string, immutable bool) []ActionBinding {
+	choice := func(keys, desc string, action Action) ActionBinding {
+		return ActionBinding{
+			Binding: help.Binding{Key: key.NewBinding(key.WithHelp(keys, desc))},
+			ID:      newMenuBindingID,
+			Action:  action,
+			Mutates: true,
+		}
+	}
+
+	return []ActionBinding{
+		choice("jj new", "on top of "+rev, func(m *Model) (Model, tea.Cmd) {
+			return *m, m.runNew(rev)
+		}),
+		choice("jj new -A", "insert after "+rev, func(m *Model) (Model, tea.Cmd) {
+			return *m, m.runNewInsert(rev, false)
+		}),
+		choice("jj new -B", "insert before "+rev, func(m *Model) (Model, tea.Cmd) {
+			if immutable {
+				return *m, m.refuseImmutable("insert a change before", rev)
+			}
+
+			return *m, m.runNewInsert(rev, true)
+		}),
+	}
+}
+
+// runNewInsert executes jj new inserting the new change after or before rev.
+func (m *Model) runNewInsert(rev string, before bool) tea.Cmd {
+	label, summary := "jj new --insert-after "+rev, "inserted new change after "+rev
+	if before {
+		label, summary = "jj new --insert-before "+rev, "inserted new change before "+rev
+	}
+
+	runner := m.runner
+
+	return m.startJob(label, func() tea.Msg {
+		if err := runner.NewInsert(rev, before); err != nil {
+			return errMsg{err}
+		}
+
+		return newCompleteMsg{summary: summary}
+	})
+}
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui"
+)
+
+// newMenuTestModel returns a model with a three-change log, the first selected.
+func newMenuTestModel(t *testing.T) *Model {
+	t.Helper()
+
+	m := newTestModel(t)
+	m.logPanel.SetContent("@ aaaaaaaa\n○ bbbbbbbb\n○ cccccccc\n", []jj.Change{
+		{ChangeID: "aaaaaaaa"},
+		{ChangeID: "bbbbbbbb"},
+		{ChangeID: "cccccccc"},
+	})
+
+	return m
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestNewParents_SelectedChange(t *testing.T) {
+	m := newMenuTestModel(t)
+	m.logPanel.SelectChangeID("bbbbbbbb")
+
+	if got := m.newParents(); !slices.Equal(got, []string{"bbbbbbbb"}) {
+		t.Errorf("n should start the change on the selected one, got %v", got)
+	}
+}
+
+func TestNewParents_MarkedAndSelectedMerge(t *testing.T) {
+	m := newMenuTestModel(t)
+	m.logPanel.ToggleMark()
+	m.logPanel.SelectChangeID("cccccccc")
+
+	if got := m.newParents(); !slices.Equal(got, []string{"aaaaaaaa", "cccccccc"}) {
+		t.Errorf("n should merge the marked and selected changes, got %v", got)
+	}
+}
+
+func TestNewParents_OutsideTheLogUsesWorkingCopy(t *testing.T) {
+	m := newMenuTestModel(t)
+	m.focusedPane = PaneOpLog
+
+	if got := m.newParents(); got != nil {
+		t.Errorf("outside the log n should start the change on @, got %v", got)
+	}
+}
+
+func TestNewMenu_OffersPlacements(t *testing.T) {
+	m := newMenuTestModel(t)
+	m.logPanel.SelectChangeID("bbbbbbbb")
+
+	next, _ := m.actionNewMenu()
+	*m = next
+
+	if !m.finding || len(m.palette) != 3 {
+		t.Fatalf("N should offer the placements, got %d", len(m.palette))
+	}
+
+	var labels []string
+	for _, item := range paletteItems(m.palette) {
+		labels = append(labels, item.Label)
+	}
+
+	want := []string{"on top of bbbbbbbb", "insert after bbbbbbbb", "insert before bbbbbbbb"}
+	if !slices.Equal(labels, want) {
+		t.Errorf("labels = %v, want %v", labels, want)
+	}
+
+	m.handlePaletteSelect(ui.FinderSelectMsg{Index: 2})
+
+	if !m.jobs.busy() || m.finding {
+		t.Error("picking a placement should run jj new")
+	}
+}
+
+func TestNewMenu_RefusesInsertBeforeImmutable(t *testing.T) {
+	m := newTestModel(t)
+	m.logPanel.SetContent("@ aaaaaaaa\n◆ zzzzzzzz\n", []jj.Change{
+		{ChangeID: "aaaaaaaa"},
+		{ChangeID: "zzzzzzzz", IsImmutable: true},
+	})
+	m.logPanel.SelectChangeID("zzzzzzzz")
+
+	next, _ := m.actionNewMenu()
+	*m = next
+
+	m.handlePaletteSelect(ui.FinderSelectMsg{Index: 2})
+
+	if m.jobs.busy() || len(m.toasts.Items()) != 1 {
+		t.Error("inserting before an immutable change would rebase it, so it should be refused")
+	}
+}
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+)
+
+// opAbandonWord must be typed to abandon operations, so a stray y or enter
+// can't throw history away.
+const opAbandonWord = "abandon"
+
+// opAbandonCompleteMsg reports old operations abandoned and collected.
+type opAbandonCompleteMsg struct {
+	opID string
+}
+
+// opAbandonKey returns the binding that abandons old operations, enabled
+// only with the op log (not an evolog) focused.
+func (m *Model) opAbandonKey() key.Binding {
+	binding := m.keys.OpAbandon
+	binding.SetEnabled(m.focusedPane == PaneOpLog && m.viewMode == ViewLog)
+
+	return binding
+}
+
+// actionOpAbandon discards the selected operation and every one before it
+// (jj op abandon ..op), then frees their storage (jj util gc). Having no way
+// back, it asks twice: once to explain, then for opAbandonWord.
+func (m *Model) actionOpAbandon() (Model, tea.Cmd) {
+	if m.focusedPane != PaneOpLog || m.viewMode != ViewLog {
+		return *m, nil
+	}
+
+	op := m.opLogPanel.SelectedOperation()
+	if op == nil {
+		return *m, nil
+	}
+
+	if op.IsCurrent() {
+		return *m, m.toasts.Info("cannot abandon the current operation; select an older one")
+	}
+
+	opID := op.OpID
+	m.askConfirm(
+		"Abandon operations up to "+opID+"?",
+		"Op "+opID+" and every operation before it leave the op log for good: undo, "+
+			"time travel, and op restore can no longer reach them. jj util gc then frees their storage.",
+		"continue",
+		"",
+		confirmation{
+			onYes: func() tea.Cmd {
+				return m.askOpAbandonWord(opID)
+			},
+		},
+	)
+
+	return *m, nil
+}
+
+// askOpAbandonWord asks for opAbandonWord before abandoning up to opID.
+func (m *Model) askOpAbandonWord(opID string) tea.Cmd {
+	m.commanding = true
+	m.promptAnswer = func(m *Model, answer string) tea.Cmd {
+		if strings.TrimSpace(answer) != opAbandonWord {
+			return m.toasts.Info("kept the op log; type " + opAbandonWord + " to discard it")
+		}
+
+		return m.runOpAbandon(opID)
+	}
+	m.sizeCommandLine()
+
+	return m.commandLine.Ask("Type "+opAbandonWord+" to discard op "+opID+" and older", "confirm: ", "")
+}
+
+// runOpAbandon executes jj op abandon, then jj util gc.
+func (m *Model) runOpAbandon(opID string) tea.Cmd {
+	runner := m.runner
+
+	return m.startJob("jj op abandon .."+opID, func() tea.Msg {
+		if err := runner.OpAbandon(opID); err != nil {
+			return errMsg{err}
+		}
+
+		if err := runner.GC(); err != nil {
+			return errMsg{fmt.Errorf("abandoned operations up to %s, but garbage collection failed: %w", opID, err)}
+		}
+
+		return opAbandonCompleteMsg{opID: opID}
+	})
+}
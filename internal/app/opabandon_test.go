@@ -0,0 +1,91 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui"
+)
+
+// opAbandonTestModel returns a model with the op log focused, listing the
+// current operation and an older one.
+func opAbandonTestModel(t *testing.T) *Model {
+	t.Helper()
+
+	m := newTestModel(t)
+	m.opLogPanel.SetOpLogContent("@  bbc9fee12c4d user 1 minute ago\n○  86d0094c958f user 2 minutes ago\n", []jj.Operation{
+		{OpID: "bbc9fee12c4d", Raw: "@  bbc9fee12c4d user 1 minute ago"},
+		{OpID: "86d0094c958f", Raw: "○  86d0094c958f user 2 minutes ago"},
+	})
+	m.focusedPane = PaneOpLog
+
+	return m
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestOpAbandon_AsksTwice(t *testing.T) {
+	m := opAbandonTestModel(t)
+	m.opLogPanel.CursorDown()
+
+	m.actionOpAbandon()
+
+	if !m.confirming || m.jobs.busy() {
+		t.Fatal("abandoning operations should ask first")
+	}
+
+	m.handleConfirm(ui.ConfirmMsg{Choice: ui.ConfirmYes})
+
+	if !m.commanding || m.jobs.busy() {
+		t.Fatal("a yes should still ask for the word")
+	}
+
+	m.handlePromptSubmit(ui.PromptSubmitMsg{Value: "abandon"})
+
+	if !m.jobs.busy() || m.jobs.running[0].label != "jj op abandon ..86d0094c958f" {
+		t.Error("typing the word should abandon up to the selected operation")
+	}
+}
+
+func TestOpAbandon_WrongWordKeepsHistory(t *testing.T) {
+	m := opAbandonTestModel(t)
+	m.opLogPanel.CursorDown()
+
+	m.actionOpAbandon()
+	m.handleConfirm(ui.ConfirmMsg{Choice: ui.ConfirmYes})
+	m.handlePromptSubmit(ui.PromptSubmitMsg{Value: "y"})
+
+	if m.jobs.busy() || len(m.toasts.Items()) != 1 {
+		t.Error("anything but the word should keep the op log, and say so")
+	}
+}
+
+func TestOpAbandon_RefusesCurrentOperation(t *testing.T) {
+	m := opAbandonTestModel(t)
+
+	m.actionOpAbandon()
+
+	if m.confirming || len(m.toasts.Items()) != 1 {
+		t.Error("the current operation can't be abandoned")
+	}
+}
+
+func TestOpAbandon_RequiresOpLogFocus(t *testing.T) {
+	m := opAbandonTestModel(t)
+	m.focusedPane = PaneLog
+
+	if m.opAbandonKey().Enabled() {
+		t.Error("abandoning operations should only be offered in the op log")
+	}
+
+	m.readOnly = true
+	m.focusedPane = PaneOpLog
+
+	for _, ab := range m.globalBindings() {
+		if ab.ID == "op-abandon" && ab.Key.Enabled() {
+			t.Error("abandoning operations changes the repo, so read-only mode disables it")
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// opDetail chooses what the diff pane shows for the selected operation.
+type opDetail int
+
+const (
+	// opDetailPatch shows jj op show: the operation and the patch of each
+	// change it touched.
+	opDetailPatch opDetail = iota
+	// opDetailDiff shows jj op diff: which commits the operation added,
+	// rewrote, or abandoned, and the bookmarks it moved.
+	opDetailDiff
+	// opDetailRestore previews jj op restore to the operation: the op diff
+	// from the current operation to it, under a count of the commits it
+	// would add and remove.
+	opDetailRestore
+
+	// opDetailCount is how many details p cycles through.
+	opDetailCount
+)
+
+// title heads the diff pane for the detail.
+func (d opDetail) title() string {
+	switch d {
+	case opDetailDiff:
+		return "Operation Diff"
+	case opDetailRestore:
+		return "Restore Preview"
+	case opDetailPatch, opDetailCount:
+	}
+
+	return "Operation"
+}
+
+// restorePreview heads the op diff to an operation with what restoring to
+// it would do to the commits.
+func restorePreview(opID, output string) string {
+	return "jj op restore " + opID + " " + jj.SummarizeOpDiff(output).String() + "\n\n" + output
+}
+
+// handleOpDetailToggle cycles the operation's patch, its op diff, and what
+// restoring to it would change, reloading the diff pane when it shows the
+// selected operation.
+func (m *Model) handleOpDetailToggle() tea.Cmd {
+	m.opDetail = (m.opDetail + 1) % opDetailCount
+
+	op := m.opLogPanel.SelectedOperation()
+	if m.focusedPane != PaneOpLog || m.comparing || op == nil {
+		return nil
+	}
+
+	return m.loadOpShow(op.OpID)
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestOpDetailToggle_ReloadsSelectedOperation(t *testing.T) {
+	m := newTestModel(t)
+	m.opLogPanel.SetOpLogContent("@ aaaaaaaaaaaa\n", []jj.Operation{{OpID: "aaaaaaaaaaaa"}})
+
+	if cmd := m.handleOpDetailToggle(); cmd != nil || m.opDetail != opDetailDiff {
+		t.Error("toggling away from the op log should switch the view without loading")
+	}
+
+	m.focusedPane = PaneOpLog
+
+	if cmd := m.handleOpDetailToggle(); cmd == nil || m.opDetail != opDetailRestore {
+		t.Error("toggling with the op log focused should reload the operation")
+	}
+
+	if m.handleOpDetailToggle(); m.opDetail != opDetailPatch {
+		t.Error("toggling after the restore preview should go back to the patch")
+	}
+
+	m.comparing = true
+
+	if cmd := m.handleOpDetailToggle(); cmd != nil {
+		t.Error("the diff pane holds the compared log, so nothing should load")
+	}
+}
+
+func TestOpShowLoaded_TitleFollowsDetail(t *testing.T) {
+	m := newTestModel(t)
+	m.opDetail = opDetailDiff
+
+	_, generation := m.diffRequests.next(m.ctx)
+	m.handleOpShowLoaded(opShowLoadedMsg{opID: "aaaaaaaaaaaa", output: "Changed commits:", detail: opDetailDiff, generation: generation})
+
+	if got := m.diffPanel.Title(); got != "Operation Diff" {
+		t.Errorf("expected the op diff title, got %q", got)
+	}
+
+	_, generation = m.diffRequests.next(m.ctx)
+	m.handleOpShowLoaded(opShowLoadedMsg{opID: "aaaaaaaaaaaa", detail: opDetailPatch, generation: generation})
+
+	if got := m.diffPanel.Title(); got != "Operation" {
+		t.Errorf("expected the patch title, got %q", got)
+	}
+
+	_, generation = m.diffRequests.next(m.ctx)
+	m.handleOpShowLoaded(opShowLoadedMsg{opID: "aaaaaaaaaaaa", detail: opDetailRestore, generation: generation})
+
+	if got := m.diffPanel.Title(); got != "Restore Preview" {
+		t.Errorf("expected the restore preview title, got %q", got)
+	}
+}
+
+func TestRestorePreview_HeadsWithSummary(t *testing.T) {
+	output := "Changed commits:\n○  + qpvuntsm 1c2d3e4f old\n○  - qpvuntsm/1 5a6b7c8d new\n"
+
+	want := "jj op restore aaaaaaaaaaaa adds 1 commit and removes 1\n\n" + output
+	if got := restorePreview("aaaaaaaaaaaa", output); got != want {
+		t.Errorf("restorePreview = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,126 @@
+package app
+
+import (
+	"cmp"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// dirPlaceholder is replaced with the target directory in open command templates.
+const dirPlaceholder = "{dir}"
+
+// urlPlaceholder is replaced with the page to show in the browser command.
+const urlPlaceholder = "{url}"
+
+// defaultShell is started when neither the config nor $SHELL names a shell.
+const defaultShell = "sh"
+
+// errEmptyOpenCommand is reported when an open command template is blank.
+var errEmptyOpenCommand = errors.New("open command is empty")
+
+// shellExitedMsg is sent when the user leaves the subshell.
+type shellExitedMsg struct {
+	err error
+}
+
+// openedMsg is sent once the file manager has been launched.
+type openedMsg struct {
+	dir string
+}
+
+// openTarget returns the directory to open: the selected file's directory in
+// the files view, or the repository root. A directory that no longer exists
+// (e.g. of a removed file) falls back to the root.
+func (m *Model) openTarget() string {
+	if m.viewMode != ViewFiles {
+		return m.workDir
+	}
+
+	file := m.filesPanel.SelectedFile()
+	if file == nil {
+		return m.workDir
+	}
+
+	dir := filepath.Dir(filepath.Join(m.workDir, filepath.FromSlash(file.Path)))
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return m.workDir
+	}
+
+	return dir
+}
+
+// actionOpenDir opens the target directory in the platform file manager.
+func (m *Model) actionOpenDir() (Model, tea.Cmd) {
+	dir := m.openTarget()
+	template := m.openCommands.FileManager
+
+	return *m, func() tea.Msg {
+		cmd, err := expandOpenCommand(template, dirPlaceholder, dir)
+		if err != nil {
+			return errMsg{err}
+		}
+
+		if err := cmd.Start(); err != nil {
+			return errMsg{err}
+		}
+
+		go func() {
+			_ = cmd.Wait() // reap the launcher; file managers detach on their own
+		}()
+
+		return openedMsg{dir: dir}
+	}
+}
+
+// actionShell suspends the TUI and starts a shell in the target directory.
+func (m *Model) actionShell() (Model, tea.Cmd) {
+	dir := m.openTarget()
+
+	template := m.openCommands.Shell
+	if template == "" {
+		template = cmp.Or(os.Getenv("SHELL"), defaultShell)
+	}
+
+	cmd, err := expandOpenCommand(template, dirPlaceholder, dir)
+	if err != nil {
+		return *m, m.handleErr(errMsg{err})
+	}
+
+	cmd.Dir = dir
+
+	return *m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return shellExitedMsg{err: err}
+	})
+}
+
+// handleShellExited reloads after the shell, where the user may have run jj.
+// A non-zero exit of the shell itself is not an error worth reporting.
+func (m *Model) handleShellExited(msg shellExitedMsg) tea.Cmd {
+	var exitErr *exec.ExitError
+	if msg.err != nil && !errors.As(msg.err, &exitErr) {
+		return tea.Batch(m.handleErr(errMsg{msg.err}), m.reloadAfterMutation())
+	}
+
+	return m.reloadAfterMutation()
+}
+
+// expandOpenCommand builds a command from a template, replacing placeholder
+// with value in each whitespace-separated argument so paths with spaces stay
+// a single argument.
+func expandOpenCommand(template, placeholder, value string) (*exec.Cmd, error) {
+	fields := strings.Fields(template)
+	if len(fields) == 0 {
+		return nil, errEmptyOpenCommand
+	}
+
+	for i, field := range fields {
+		fields[i] = strings.ReplaceAll(field, placeholder, value)
+	}
+
+	return exec.Command(fields[0], fields[1:]...), nil
+}
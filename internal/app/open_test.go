@@ -0,0 +1,59 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestExpandOpenCommand_ReplacesDir(t *testing.T) {
+	cmd, err := expandOpenCommand("open -a Finder {dir}", dirPlaceholder, "/tmp/my repo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"open", "-a", "Finder", "/tmp/my repo"}; !slices.Equal(cmd.Args, want) {
+		t.Errorf("expected args %q, got %q", want, cmd.Args)
+	}
+}
+
+func TestExpandOpenCommand_Empty(t *testing.T) {
+	if _, err := expandOpenCommand("  ", dirPlaceholder, "/tmp"); err == nil {
+		t.Error("a blank template should be an error")
+	}
+}
+
+func TestOpenTarget(t *testing.T) {
+	m := newTestModel(t)
+
+	if err := os.MkdirAll(filepath.Join(m.workDir, "internal", "app"), 0o750); err != nil {
+		t.Fatalf("creating dirs: %v", err)
+	}
+
+	if got := m.openTarget(); got != m.workDir {
+		t.Errorf("log view should open the repo root, got %q", got)
+	}
+
+	m.viewMode = ViewFiles
+	m.filesPanel.SetFiles("xsssnyux", "xsss", []jj.File{
+		{Path: "internal/app/app.go", Status: jj.FileModified},
+		{Path: "gone/removed.go", Status: jj.FileDeleted},
+	})
+
+	if got, want := m.openTarget(), filepath.Join(m.workDir, "internal", "app"); got != want {
+		t.Errorf("expected the selected file's directory %q, got %q", want, got)
+	}
+
+	m.filesPanel.CursorDown()
+
+	if got := m.openTarget(); got != m.workDir {
+		t.Errorf("a missing directory should fall back to the root, got %q", got)
+	}
+}
@@ -0,0 +1,72 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/ui"
+)
+
+// paletteBindingID identifies the palette's own binding, which it leaves out.
+const paletteBindingID = "palette"
+
+// paletteActions returns the bindings the palette offers: every enabled
+// global binding with an action, so new actions appear without extra wiring.
+func (m *Model) paletteActions() []ActionBinding {
+	var actions []ActionBinding
+
+	for _, ab := range m.activeBindings() {
+		if ab.Action != nil && ab.Key.Enabled() && ab.ID != paletteBindingID {
+			actions = append(actions, ab)
+		}
+	}
+
+	return actions
+}
+
+// paletteItems labels each action with its help description. Actions that
+// share a description (e.g. focusing each pane) are told apart by key.
+func paletteItems(actions []ActionBinding) []ui.FinderItem {
+	descs := make(map[string]int, len(actions))
+	for _, ab := range actions {
+		descs[ab.Key.Help().Desc]++
+	}
+
+	items := make([]ui.FinderItem, len(actions))
+
+	for i, ab := range actions {
+		label, detail := ab.Key.Help().Desc, ab.Key.Help().Key
+		if keys := ab.Key.Keys(); descs[label] > 1 && len(keys) > 0 {
+			label += " " + keys[0]
+			detail = keys[0]
+		}
+
+		items[i] = ui.FinderItem{Label: label, Detail: detail}
+	}
+
+	return items
+}
+
+// actionPalette opens the command palette over the current context's actions.
+func (m *Model) actionPalette() (Model, tea.Cmd) {
+	m.palette = m.paletteActions()
+	m.finding = true
+	m.sizeFinder()
+
+	return *m, m.finder.Open("Actions", paletteItems(m.palette))
+}
+
+// handlePaletteSelect runs the picked action as if its key had been pressed.
+func (m *Model) handlePaletteSelect(msg ui.FinderSelectMsg) tea.Cmd {
+	m.finding = false
+	actions := m.palette
+	m.palette = nil
+
+	if msg.Index < 0 || msg.Index >= len(actions) {
+		return nil
+	}
+
+	next, cmd := m.runAction(actions[msg.Index])
+	*m = next
+
+	return cmd
+}
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/chatter/chado/internal/ui"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestPaletteActions_CoverGlobalBindings(t *testing.T) {
+	m := newTestModel(t)
+
+	ids := make(map[string]bool)
+	for _, ab := range m.paletteActions() {
+		ids[ab.ID] = true
+	}
+
+	for _, want := range []string{"describe", "new", "abandon", "push", "find-file"} {
+		if !ids[want] {
+			t.Errorf("palette should offer %q", want)
+		}
+	}
+
+	if ids[paletteBindingID] {
+		t.Error("palette should not offer itself")
+	}
+
+	if ids["dismiss"] {
+		t.Error("palette should leave out disabled bindings")
+	}
+}
+
+func TestPaletteItems_DistinguishSharedDescriptions(t *testing.T) {
+	m := newTestModel(t)
+
+	labels := make(map[string]int)
+	for _, item := range paletteItems(m.paletteActions()) {
+		labels[item.Label]++
+	}
+
+	for label, count := range labels {
+		if count > 1 {
+			t.Errorf("label %q appears %d times", label, count)
+		}
+	}
+
+	if labels["focus pane 1"] != 1 {
+		t.Errorf("duplicate descriptions should be told apart by key, got %v", labels)
+	}
+}
+
+func TestPalette_SelectRunsAction(t *testing.T) {
+	m := newTestModel(t)
+	m.actionPalette()
+
+	if !m.finding || m.palette == nil {
+		t.Fatal("palette should be open")
+	}
+
+	index := -1
+
+	for i, ab := range m.palette {
+		if ab.ID == "bookmarks" {
+			index = i
+		}
+	}
+
+	m.handlePaletteSelect(ui.FinderSelectMsg{Index: index})
+
+	if m.finding || m.palette != nil {
+		t.Error("running an action should close the palette")
+	}
+
+	if m.viewMode != ViewBookmarks {
+		t.Errorf("expected the bookmarks action to run, got mode %v", m.viewMode)
+	}
+}
+
+func TestPalette_CancelRunsNothing(t *testing.T) {
+	m := newTestModel(t)
+	m.actionPalette()
+
+	m.handleFinderCancel()
+
+	if m.finding || m.palette != nil || m.viewMode != ViewLog {
+		t.Errorf("cancel should close the palette and do nothing, got finding=%v mode=%v", m.finding, m.viewMode)
+	}
+}
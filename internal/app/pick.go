@@ -0,0 +1,54 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+)
+
+// SetPicking turns on picker mode: enter ends chado with the selection
+// recorded for Picked, for use from shell scripts.
+func (m *Model) SetPicking(picking bool) {
+	m.picking = picking
+}
+
+// Picked returns what was chosen in picker mode, or "" when chado was quit
+// without choosing.
+func (m *Model) Picked() string {
+	return m.picked
+}
+
+// pick ends chado with the selection in the left pane: a change ID in the
+// log, a file path in a change's files, or a bookmark name. It returns nil
+// when nothing there can be picked (e.g. a directory of the files tree),
+// leaving enter to do its usual job.
+func (m *Model) pick() tea.Cmd {
+	if m.focusedPane != PaneLog {
+		return nil
+	}
+
+	switch m.viewMode {
+	case ViewLog:
+		if change := m.logPanel.SelectedChange(); change != nil {
+			m.picked = change.ChangeID
+		}
+	case ViewFiles:
+		if file := m.filesPanel.SelectedFile(); file != nil {
+			m.picked = file.Path
+		}
+	case ViewBookmarks:
+		if bookmark := m.bookmarksPanel.SelectedBookmark(); bookmark != nil {
+			m.picked = bookmark.Name
+		}
+	case ViewStacks:
+		if change := m.selectedStackChange(); change != nil {
+			m.picked = change.ChangeID
+		}
+	}
+
+	if m.picked == "" {
+		return nil
+	}
+
+	m.log.Debug("picked", "selection", m.picked)
+
+	return m.quit()
+}
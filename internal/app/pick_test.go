@@ -0,0 +1,57 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestPick_EnterPicksSelectedChange(t *testing.T) {
+	m := newTestModel(t)
+	m.SetPicking(true)
+	m.focusedPane = PaneLog
+	m.logPanel.SetContent("@ aaaaaaaa 1\n○ bbbbbbbb 2\n", []jj.Change{{ChangeID: "aaaaaaaa"}, {ChangeID: "bbbbbbbb"}})
+	m.logPanel.CursorDown()
+
+	if cmd := m.handleEnter(); cmd == nil {
+		t.Fatal("enter should quit in picker mode")
+	}
+
+	if got := m.Picked(); got != "bbbbbbbb" {
+		t.Errorf("picked %q, want bbbbbbbb", got)
+	}
+
+	if m.viewMode != ViewLog {
+		t.Error("picking should not drill into the change")
+	}
+}
+
+func TestPick_EnterPicksFilePath(t *testing.T) {
+	m := newTestModel(t)
+	m.SetPicking(true)
+	m.focusedPane = PaneLog
+	m.viewMode = ViewFiles
+	m.filesPanel.SetFiles("qpvuntsm", "qp", []jj.File{{Path: "internal/app/app.go", Status: jj.FileModified}})
+
+	m.handleEnter()
+
+	if got := m.Picked(); got != "internal/app/app.go" {
+		t.Errorf("picked %q, want the file path", got)
+	}
+}
+
+func TestPick_OffByDefault(t *testing.T) {
+	m := newTestModel(t)
+	m.focusedPane = PaneLog
+	m.logPanel.SetContent("@ aaaaaaaa 1\n", []jj.Change{{ChangeID: "aaaaaaaa"}})
+
+	m.handleEnter()
+
+	if m.Picked() != "" || m.viewMode != ViewFiles {
+		t.Error("outside picker mode enter should drill into the change")
+	}
+}
@@ -0,0 +1,160 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// previewDescendantLimit caps the descendants listed when previewing a rewrite.
+const previewDescendantLimit = 20
+
+// previewedMutation is a destructive action whose effects are shown in the
+// diff pane, and confirmed by the user, before it runs.
+type previewedMutation struct {
+	title    string                 // confirmation dialog title
+	label    string                 // preview pane label, e.g. the jj command line
+	yesHint  string                 // label for the confirming choice
+	dryRun   func() (string, error) // jj's own --dry-run report; may be unsupported
+	fallback func() (string, error) // describes the effects when dry-run is unsupported
+	run      func() tea.Cmd         // performs the action once confirmed
+}
+
+// previewLoadedMsg carries the predicted effects of a pending mutation.
+type previewLoadedMsg struct {
+	mutation previewedMutation
+	output   string
+	dryRun   bool // true when output came from jj --dry-run
+}
+
+// previewMutation computes a mutation's effects and, once loaded, asks the
+// user to confirm it with the preview shown alongside.
+func (m *Model) previewMutation(mutation previewedMutation) tea.Cmd {
+	return func() tea.Msg {
+		if mutation.dryRun != nil {
+			output, err := mutation.dryRun()
+			if err == nil {
+				return previewLoadedMsg{mutation: mutation, output: output, dryRun: true}
+			}
+
+			if !errors.Is(err, jj.ErrDryRunUnsupported) || mutation.fallback == nil {
+				return errMsg{err}
+			}
+		}
+
+		if mutation.fallback == nil {
+			return previewLoadedMsg{mutation: mutation}
+		}
+
+		output, err := mutation.fallback()
+		if err != nil {
+			return errMsg{err}
+		}
+
+		return previewLoadedMsg{mutation: mutation, output: output}
+	}
+}
+
+func (m *Model) handlePreviewLoaded(msg previewLoadedMsg) {
+	mutation := msg.mutation
+
+	source := "The preview pane shows the changes this affects."
+	if msg.dryRun {
+		source = "The preview pane shows what jj reports it would do (dry run)."
+	}
+
+	m.diffPanel.ShowPreview(mutation.label, msg.output)
+	m.askConfirm(mutation.title, source+" Continue?", mutation.yesHint, "", confirmation{
+		onYes: mutation.run,
+	})
+}
+
+// abandonPreview describes abandoning a change: jj has no dry run for it, so
+// the fallback lists the change and the descendants that would be rebased.
+func (m *Model) abandonPreview(changeID string) previewedMutation {
+	runner := m.runner
+
+	return previewedMutation{
+		title:   "Abandon " + changeID + "?",
+		label:   "jj abandon " + changeID,
+		yesHint: "abandon",
+		dryRun: func() (string, error) {
+			return runner.DryRun([]string{"abandon"}, changeID)
+		},
+		fallback: func() (string, error) {
+			log, err := runner.Descendants(changeID, previewDescendantLimit)
+			if err != nil {
+				return "", err
+			}
+
+			return "The first change below is abandoned; its descendants are rebased onto its parents.\n\n" + log, nil
+		},
+		run: func() tea.Cmd {
+			return m.guardWorkingCopy(guardedMutation{rev: changeID, run: m.runAbandon})
+		},
+	}
+}
+
+// pushPreview describes pushing the bookmarks on a change using jj's dry run.
+func (m *Model) pushPreview(changeID string) previewedMutation {
+	runner := m.runner
+
+	return previewedMutation{
+		title:   "Push " + changeID + "?",
+		label:   "jj git push -r " + changeID,
+		yesHint: "push",
+		dryRun: func() (string, error) {
+			return runner.PushPreview(changeID)
+		},
+		run: func() tea.Cmd {
+			return m.runPush(changeID)
+		},
+	}
+}
+
+// pushBookmarksPreview describes pushing only the named bookmarks. The
+// bookmarks show as pending until the merged results arrive.
+func (m *Model) pushBookmarksPreview(names []string) previewedMutation {
+	label := "jj git push --bookmark " + strings.Join(names, " --bookmark ")
+	runner := m.runner
+
+	return previewedMutation{
+		title:   fmt.Sprintf("Push %d bookmark(s)?", len(names)),
+		label:   label,
+		yesHint: "push",
+		dryRun: func() (string, error) {
+			return runner.PushBookmarksPreview(names)
+		},
+		run: func() tea.Cmd {
+			m.bookmarksPanel.SetPushing(names)
+			return m.runPushBookmarks(names)
+		},
+	}
+}
+
+// renderConfirm places the confirmation dialog. While a preview is shown the
+// dialog sits at the bottom of the screen so the preview stays readable.
+func (m *Model) renderConfirm(base string) string {
+	dialog := m.confirmDialog.View()
+	if !m.diffPanel.Previewing() {
+		return m.renderCentered(base, dialog)
+	}
+
+	dialogX := max((m.width-lipgloss.Width(dialog))/centerDivisor, 0)
+	dialogY := max(m.height-statusBarHeight-lipgloss.Height(dialog), 0)
+
+	baseLayer := lipgloss.NewLayer(base).
+		Width(m.width).
+		Height(m.height).
+		X(0).Y(0).Z(0)
+
+	dialogLayer := lipgloss.NewLayer(dialog).
+		X(dialogX).Y(dialogY).Z(1)
+
+	return lipgloss.NewCanvas(baseLayer, dialogLayer).Render()
+}
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"maps"
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/forge"
+	"github.com/chatter/chado/internal/jj"
+)
+
+// pullRequestsMsg carries the pull requests found for branches, by branch;
+// a branch asked about but missing has none.
+type pullRequestsMsg struct {
+	branches []string
+	prs      map[string]forge.PullRequest
+	err      error
+}
+
+// loadPullRequests asks GitHub about the pull requests of the named
+// bookmarks, in the repo of the preferred git remote. Nothing is asked when
+// lookups are off, there are no bookmarks, or the remote is not on GitHub.
+func (m *Model) loadPullRequests(branches []string) tea.Cmd {
+	if m.github == nil || len(branches) == 0 {
+		return nil
+	}
+
+	ctx, runner, client := m.ctx, m.runner, m.github
+
+	return func() tea.Msg {
+		remotes, err := runner.GitRemotes()
+		if err != nil {
+			return pullRequestsMsg{err: err}
+		}
+
+		repo, ok := forge.GitHubRepo(preferredRemote(remotes))
+		if !ok {
+			return nil
+		}
+
+		prs, err := client.PullRequests(ctx, repo, branches)
+
+		return pullRequestsMsg{branches: branches, prs: prs, err: err}
+	}
+}
+
+// handlePullRequests shows the pull requests beside their bookmarks. The
+// first failure to reach the forge is toasted; later ones, until it
+// answers again, are only logged.
+func (m *Model) handlePullRequests(msg pullRequestsMsg) tea.Cmd {
+	if msg.err != nil {
+		m.log.Warn("looking up pull requests failed", "err", msg.err)
+
+		if m.forgeFailing {
+			return nil
+		}
+
+		m.forgeFailing = true
+
+		return m.toasts.Error("could not look up pull requests: " + msg.err.Error())
+	}
+
+	m.forgeFailing = false
+
+	prs := maps.Clone(m.pullRequests)
+	if prs == nil {
+		prs = make(map[string]forge.PullRequest)
+	}
+
+	for _, branch := range msg.branches {
+		if pr, ok := msg.prs[branch]; ok {
+			prs[branch] = pr
+		} else {
+			delete(prs, branch)
+		}
+	}
+
+	m.pullRequests = prs
+	m.logPanel.SetPullRequests(prs)
+	m.compareLogPanel.SetPullRequests(prs)
+	m.bookmarksPanel.SetPullRequests(prs)
+
+	return nil
+}
+
+// logBookmarks returns the bookmarks on the loaded changes, each once.
+func logBookmarks(changes []jj.Change) []string {
+	var names []string
+
+	for _, change := range changes {
+		names = append(names, change.Bookmarks...)
+	}
+
+	slices.Sort(names)
+
+	return slices.Compact(names)
+}
@@ -0,0 +1,113 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"pgregory.net/rapid"
+
+	"github.com/chatter/chado/internal/config"
+	"github.com/chatter/chado/internal/forge"
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/logger"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestPullRequests_OffByDefault(t *testing.T) {
+	m := newTestModel(t)
+
+	if m.loadPullRequests([]string{"main"}) != nil {
+		t.Error("chado should not call out to GitHub unless asked to")
+	}
+}
+
+func TestPullRequests_OnlyForBookmarks(t *testing.T) {
+	cfg := config.Default()
+	cfg.Forge.GitHub = true
+
+	log, _ := logger.New("")
+	m := New(context.Background(), t.TempDir(), "test", cfg, log)
+
+	if m.github == nil {
+		t.Fatal("turning GitHub on should make a client")
+	}
+
+	if m.loadPullRequests(nil) != nil {
+		t.Error("without bookmarks there is nothing to ask about")
+	}
+}
+
+func TestHandlePullRequests_ReplacesAskedBranches(t *testing.T) {
+	m := newTestModel(t)
+	m.pullRequests = map[string]forge.PullRequest{
+		"stale": {Number: 1, Branch: "stale", State: forge.StateOpen},
+		"other": {Number: 2, Branch: "other", State: forge.StateOpen},
+	}
+
+	m.handlePullRequests(pullRequestsMsg{
+		branches: []string{"stale", "fresh"},
+		prs:      map[string]forge.PullRequest{"fresh": {Number: 3, Branch: "fresh", State: forge.StateMerged}},
+	})
+
+	if _, ok := m.pullRequests["stale"]; ok {
+		t.Error("a branch asked about without a pull request should lose its badge")
+	}
+
+	if m.pullRequests["other"].Number != 2 || m.pullRequests["fresh"].Number != 3 {
+		t.Errorf("branches not asked about should keep theirs, got %+v", m.pullRequests)
+	}
+}
+
+func TestHandlePullRequests_ToastsFirstFailure(t *testing.T) {
+	m := newTestModel(t)
+	failure := pullRequestsMsg{err: errors.New("github api: 401 Unauthorized")}
+
+	m.handlePullRequests(failure)
+	m.handlePullRequests(failure)
+
+	toasts := m.toasts.Items()
+	if len(toasts) != 1 || !strings.Contains(toasts[0].Text, "401 Unauthorized") {
+		t.Fatalf("only the first failure should be toasted, got %+v", toasts)
+	}
+
+	m.handlePullRequests(pullRequestsMsg{})
+	m.handlePullRequests(failure)
+
+	if got := len(m.toasts.Items()); got != 2 {
+		t.Errorf("failing again after an answer should be toasted, got %d toasts", got)
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: every bookmark in the log is asked about, once.
+func TestLogBookmarks_EachOnce(t *testing.T) {
+	rapid.Check(t, func(rt *rapid.T) {
+		name := rapid.SampledFrom([]string{"main", "push-parser", "lexer", "docs"})
+		changes := rapid.SliceOf(rapid.Custom(func(rt *rapid.T) jj.Change {
+			return jj.Change{Bookmarks: rapid.SliceOfN(name, 0, 3).Draw(rt, "bookmarks")}
+		})).Draw(rt, "changes")
+
+		got := logBookmarks(changes)
+
+		if !slices.IsSorted(got) || len(slices.Compact(slices.Clone(got))) != len(got) {
+			rt.Fatalf("expected each bookmark once, got %v", got)
+		}
+
+		for _, change := range changes {
+			for _, bookmark := range change.Bookmarks {
+				if !slices.Contains(got, bookmark) {
+					rt.Fatalf("%s is missing from %v", bookmark, got)
+				}
+			}
+		}
+	})
+}
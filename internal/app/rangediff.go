@@ -0,0 +1,47 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+)
+
+// rangeDiffLoadedMsg carries the diff from one change to another.
+type rangeDiffLoadedMsg struct {
+	from       string
+	to         string
+	output     string
+	generation int
+}
+
+// loadLogDiff loads the diff pane for the change selected in the log: its
+// own diff, or with another change marked, the diff from that one to it.
+func (m *Model) loadLogDiff(changeID string) tea.Cmd {
+	if from, to, ok := m.logPanel.ComparedRange(); ok {
+		return m.loadRangeDiff(from.ChangeID, to.ChangeID)
+	}
+
+	return m.loadDiff(changeID)
+}
+
+// loadRangeDiff fetches the difference between the contents of from and to.
+func (m *Model) loadRangeDiff(from, to string) tea.Cmd {
+	ctx, generation := m.diffRequests.next(m.ctx)
+	runner := m.runner.WithContext(ctx)
+
+	return func() tea.Msg {
+		output, err := runner.DiffRange(from, to)
+		if err != nil {
+			return superseded(ctx, err)
+		}
+
+		return rangeDiffLoadedMsg{from: from, to: to, output: output, generation: generation}
+	}
+}
+
+func (m *Model) handleRangeDiffLoaded(msg rangeDiffLoadedMsg) {
+	if !m.diffRequests.current(msg.generation) {
+		return
+	}
+
+	m.diffPanel.SetTitle("Diff " + msg.from + " → " + msg.to)
+	m.diffPanel.SetDiff(msg.output)
+}
@@ -0,0 +1,40 @@
+package app
+
+import (
+	"testing"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestRangeDiffLoaded_TitleNamesBothChanges(t *testing.T) {
+	m := newTestModel(t)
+
+	_, generation := m.diffRequests.next(m.ctx)
+	m.handleRangeDiffLoaded(rangeDiffLoadedMsg{from: "aaaaaaaa", to: "cccccccc", output: "diff", generation: generation})
+
+	if got, want := m.diffPanel.Title(), "Diff aaaaaaaa → cccccccc"; got != want {
+		t.Errorf("title = %q, want %q", got, want)
+	}
+
+	// The selected change's own diff replaces the range title
+	_, generation = m.diffRequests.next(m.ctx)
+	m.handleDiffLoaded(diffLoadedMsg{changeID: "cccccccc", diffOutput: "diff", generation: generation})
+
+	if got := m.diffPanel.Title(); got != "Diff" {
+		t.Errorf("expected the plain diff title, got %q", got)
+	}
+}
+
+func TestRangeDiffLoaded_IgnoresSuperseded(t *testing.T) {
+	m := newTestModel(t)
+
+	_, stale := m.diffRequests.next(m.ctx)
+	m.diffRequests.next(m.ctx)
+	m.handleRangeDiffLoaded(rangeDiffLoadedMsg{from: "aaaaaaaa", to: "cccccccc", generation: stale})
+
+	if got := m.diffPanel.Title(); got != "Diff" {
+		t.Errorf("a superseded range diff should be dropped, title is %q", got)
+	}
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"slices"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/ui"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestReadOnly_DisablesMutatingBindings(t *testing.T) {
+	m := newTestModel(t)
+	m.readOnly = true
+
+	for _, ab := range m.globalBindings() {
+		if ab.Mutates && ab.Key.Enabled() {
+			t.Errorf("%s should be disabled in read-only mode", ab.ID)
+		}
+	}
+
+	if _, cmd := dispatchKey(m, tea.KeyPressMsg{Code: 'n', Text: "n"}, m.globalBindings()); cmd != nil || m.jobs.busy() {
+		t.Error("n should not create a change in read-only mode")
+	}
+}
+
+func TestReadOnly_RefreshDoesNotSnapshot(t *testing.T) {
+	m := newTestModel(t)
+
+	if m.snapshotWorkingCopy() == nil {
+		t.Error("a refresh should record edits to the working copy")
+	}
+
+	m.readOnly = true
+
+	if m.snapshotWorkingCopy() != nil {
+		t.Error("read-only mode should not snapshot the working copy")
+	}
+}
+
+func TestReadOnly_CommandsDoNotSnapshot(t *testing.T) {
+	m := newTestModel(t)
+
+	ran := m.handleCommandSubmit(ui.CommandSubmitMsg{Line: "jj st"})().(commandRanMsg)
+	if slices.Contains(ran.args, "--ignore-working-copy") {
+		t.Errorf("outside read-only mode, jj st should snapshot as usual, ran jj %v", ran.args)
+	}
+
+	m.readOnly = true
+
+	ran = m.handleCommandSubmit(ui.CommandSubmitMsg{Line: "jj st"})().(commandRanMsg)
+	if !slices.Contains(ran.args, "--ignore-working-copy") {
+		t.Errorf("read-only mode should not snapshot the working copy, ran jj %v", ran.args)
+	}
+}
+
+func TestReadOnly_RefusesMutatingCommands(t *testing.T) {
+	m := newTestModel(t)
+	m.readOnly = true
+
+	if cmd := m.handleCommandSubmit(ui.CommandSubmitMsg{Line: "log"}); cmd == nil {
+		t.Error("read-only commands should still run")
+	}
+
+	m.handleCommandSubmit(ui.CommandSubmitMsg{Line: "rebase -d main"})
+
+	if m.jobs.busy() {
+		t.Error("a command that changes the repo should not run in read-only mode")
+	}
+
+	if len(m.toasts.Items()) == 0 {
+		t.Error("expected a note that the command was refused")
+	}
+}
@@ -0,0 +1,23 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+)
+
+// actionRefresh reloads everything, whether or not the watcher saw a change.
+func (m *Model) actionRefresh() (Model, tea.Cmd) {
+	return *m, m.refreshViews()
+}
+
+// actionToggleAutoRefresh pauses refreshing as files change, freezing the
+// views, or resumes it with a refresh to catch up on what changed meanwhile.
+func (m *Model) actionToggleAutoRefresh() (Model, tea.Cmd) {
+	m.refreshPaused = !m.refreshPaused
+	m.statusBar.SetRefreshPaused(m.refreshPaused)
+
+	if m.refreshPaused {
+		return *m, m.toasts.Info("auto-refresh paused; R refreshes, W resumes")
+	}
+
+	return *m, tea.Batch(m.toasts.Info("auto-refresh resumed"), m.refreshViews())
+}
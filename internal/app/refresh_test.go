@@ -0,0 +1,67 @@
+package app
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestRefresh_KeyReloads(t *testing.T) {
+	m := newTestModel(t)
+
+	if _, cmd := m.handleKeyMsg(tea.KeyPressMsg(tea.Key{Code: 'R', Text: "R"})); cmd == nil {
+		t.Error("R should reload the views")
+	}
+}
+
+func TestAutoRefresh_PausedSkipsWatcherEvents(t *testing.T) {
+	m := newTestModel(t)
+
+	next, _ := m.actionToggleAutoRefresh()
+	*m = next
+
+	if !m.refreshPaused {
+		t.Fatal("W should pause auto-refresh")
+	}
+
+	m.handleWatcherEvent(jj.WatcherMsg{})
+
+	if m.watcherPending {
+		t.Error("a change while paused should not schedule a refresh")
+	}
+}
+
+func TestAutoRefresh_PausedDuringFlush(t *testing.T) {
+	m := newTestModel(t)
+
+	m.handleWatcherEvent(jj.WatcherMsg{})
+	m.refreshPaused = true
+
+	m.handleWatcherFlush(watcherFlushMsg{})
+
+	if m.watcherPending {
+		t.Error("the flush should still clear the pending refresh")
+	}
+}
+
+func TestAutoRefresh_ResumeRefreshes(t *testing.T) {
+	m := newTestModel(t)
+	m.refreshPaused = true
+
+	next, cmd := m.actionToggleAutoRefresh()
+	*m = next
+
+	if m.refreshPaused {
+		t.Error("W should resume auto-refresh")
+	}
+
+	if cmd == nil {
+		t.Error("resuming should refresh to catch up")
+	}
+}
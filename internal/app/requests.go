@@ -0,0 +1,86 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// requestSlot tracks the latest request for one pane's content. Starting a
+// new request cancels the previous one, killing its jj process, and bumps the
+// generation so a result that still arrives late can be recognized and dropped.
+type requestSlot struct {
+	generation int
+	cancel     context.CancelFunc
+}
+
+// next cancels the in-flight request, if any, and returns the context and
+// generation for its replacement.
+func (s *requestSlot) next(parent context.Context) (context.Context, int) {
+	if s.cancel != nil {
+		s.cancel()
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+	s.cancel = cancel
+	s.generation++
+
+	return ctx, s.generation
+}
+
+// invalidate cancels the in-flight request without starting another, so its
+// result is dropped even before a replacement is issued.
+func (s *requestSlot) invalidate() {
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+
+	s.generation++
+}
+
+// current reports whether a result tagged with generation is still wanted.
+func (s *requestSlot) current(generation int) bool {
+	return generation == s.generation
+}
+
+// superseded turns the error of a cancelled request into no message at all:
+// the user has moved on, so a killed jj process is not worth reporting.
+func superseded(ctx context.Context, err error) tea.Msg {
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	return errMsg{err}
+}
+
+// diffDebounceMsg fires once the cursor has rested for the debounce delay.
+type diffDebounceMsg struct {
+	generation int // must match Model.diffDebounceGen or a newer move superseded it
+}
+
+// debounceDiffLoad defers loading the selected item's diff until the cursor
+// has been still for the configured delay. The previous selection's diff is
+// abandoned immediately since it is no longer wanted.
+func (m *Model) debounceDiffLoad() tea.Cmd {
+	if m.diffDebounce <= 0 {
+		return m.loadSelectedDiff()
+	}
+
+	m.diffRequests.invalidate()
+	m.diffDebounceGen++
+	generation := m.diffDebounceGen
+
+	return tea.Tick(m.diffDebounce, func(time.Time) tea.Msg {
+		return diffDebounceMsg{generation: generation}
+	})
+}
+
+func (m *Model) handleDiffDebounce(msg diffDebounceMsg) tea.Cmd {
+	if msg.generation != m.diffDebounceGen {
+		return nil
+	}
+
+	return m.loadSelectedDiff()
+}
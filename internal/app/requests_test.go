@@ -0,0 +1,137 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"pgregory.net/rapid"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestRequestSlot_NextCancelsPrevious(t *testing.T) {
+	slot := &requestSlot{}
+
+	first, firstGen := slot.next(context.Background())
+	second, secondGen := slot.next(context.Background())
+
+	if first.Err() == nil {
+		t.Error("starting a new request should cancel the previous one")
+	}
+
+	if second.Err() != nil {
+		t.Error("the newest request should still be live")
+	}
+
+	if slot.current(firstGen) || !slot.current(secondGen) {
+		t.Error("only the newest generation should be current")
+	}
+}
+
+func TestSuperseded_DropsCancelledErrors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if msg := superseded(ctx, errors.New("signal: killed")); msg != nil {
+		t.Errorf("cancelled request should produce no message, got %T", msg)
+	}
+
+	if _, ok := superseded(context.Background(), errors.New("boom")).(errMsg); !ok {
+		t.Error("live request errors should still be reported")
+	}
+}
+
+func TestDiffPane_DropsStaleResults(t *testing.T) {
+	m := newTestModel(t)
+
+	_, stale := m.diffRequests.next(m.ctx)
+	_, latest := m.diffRequests.next(m.ctx)
+
+	m.handleDiffLoaded(diffLoadedMsg{changeID: "new", diffOutput: "latest diff", generation: latest})
+	m.handleDiffLoaded(diffLoadedMsg{changeID: "old", diffOutput: "stale diff", generation: stale})
+
+	if m.currentDiff != "latest diff" {
+		t.Errorf("out-of-order stale result replaced the diff: %q", m.currentDiff)
+	}
+
+	m.handleOpShowLoaded(opShowLoadedMsg{opID: "abc", output: "stale op", generation: stale})
+	m.handleFileDiffLoaded(fileDiffLoadedMsg{diffOutput: "stale file", generation: stale})
+
+	if m.currentDiff != "latest diff" {
+		t.Errorf("stale op/file results should be ignored, got %q", m.currentDiff)
+	}
+}
+
+func TestDebounceDiffLoad_OnlyLatestMoveLoads(t *testing.T) {
+	m := newTestModel(t)
+	m.logPanel.SetContent("@ xsssnyux\n", []jj.Change{{ChangeID: "xsssnyux", Raw: "@ xsssnyux"}})
+
+	_, inFlight := m.diffRequests.next(m.ctx)
+
+	m.debounceDiffLoad()
+	first := m.diffDebounceGen
+	m.debounceDiffLoad()
+
+	if m.diffRequests.current(inFlight) {
+		t.Error("moving the cursor should abandon the in-flight diff")
+	}
+
+	if cmd := m.handleDiffDebounce(diffDebounceMsg{generation: first}); cmd != nil {
+		t.Error("a superseded debounce tick should not load anything")
+	}
+
+	if cmd := m.handleDiffDebounce(diffDebounceMsg{generation: m.diffDebounceGen}); cmd == nil {
+		t.Error("the latest debounce tick should load the selected diff")
+	}
+}
+
+func TestDebounceDiffLoad_ZeroDelayLoadsImmediately(t *testing.T) {
+	m := newTestModel(t)
+	m.diffDebounce = 0
+	m.logPanel.SetContent("@ xsssnyux\n", []jj.Change{{ChangeID: "xsssnyux", Raw: "@ xsssnyux"}})
+
+	before := m.diffRequests.generation
+
+	if cmd := m.debounceDiffLoad(); cmd == nil {
+		t.Fatal("expected a load command")
+	}
+
+	if m.diffDebounceGen != 0 || m.diffRequests.generation != before+1 {
+		t.Error("zero debounce should issue the diff request directly")
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: after any number of requests, exactly the last generation is current.
+func TestRequestSlot_OnlyLatestCurrent(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		slot := &requestSlot{}
+		count := rapid.IntRange(1, 50).Draw(t, "count")
+
+		contexts := make([]context.Context, count)
+		generations := make([]int, count)
+
+		for i := range count {
+			contexts[i], generations[i] = slot.next(context.Background())
+		}
+
+		for i := range count {
+			latest := i == count-1
+			if slot.current(generations[i]) != latest {
+				t.Fatalf("generation %d current=%v, want %v", generations[i], !latest, latest)
+			}
+
+			if (contexts[i].Err() == nil) != latest {
+				t.Fatalf("request %d cancelled=%v, want %v", i, !latest, !latest)
+			}
+		}
+	})
+}
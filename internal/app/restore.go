@@ -0,0 +1,97 @@
+package app
+
+import (
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+)
+
+// restoreCompleteMsg reports a file restored in the files view.
+type restoreCompleteMsg struct {
+	summary string
+}
+
+// restoreKey returns the restore binding, enabled only in the files view.
+func (m *Model) restoreKey() key.Binding {
+	binding := m.keys.Restore
+	binding.SetEnabled(m.viewMode == ViewFiles)
+
+	return binding
+}
+
+// actionRestore restores the file selected in the files view, after asking
+// how: discard what the change did to it, or copy it as it is in the change
+// into the working copy. For @ only discarding does anything, and an
+// immutable change can't be rewritten, so those offer just one.
+func (m *Model) actionRestore() (Model, tea.Cmd) {
+	if m.focusedPane != PaneLog || m.viewMode != ViewFiles {
+		return *m, nil
+	}
+
+	file := m.filesPanel.SelectedFile()
+	rev := m.filesPanel.ChangeID()
+
+	if file == nil || rev == "" {
+		return *m, nil
+	}
+
+	path := file.Path
+	discard := func() tea.Cmd { return m.runDiscardChanges(rev, path) }
+	restore := func() tea.Cmd { return m.runRestoreFrom(rev, path) }
+
+	change, _ := m.loadedChange(rev)
+
+	switch {
+	case change.IsWorkingCopy:
+		m.askConfirm("Discard changes", "Discard the working-copy changes to "+path+"?",
+			"discard", "", confirmation{onYes: discard})
+	case change.IsImmutable:
+		m.askConfirm("Restore file", "Copy "+path+" as it is in "+rev+" into the working copy?",
+			"restore into @", "", confirmation{onYes: restore})
+	default:
+		m.askConfirm("Restore file", "Discard "+rev+"'s changes to "+path+", rewriting "+rev+
+			", or copy the file as it is in "+rev+" into the working copy?",
+			"discard in "+rev, "restore into @", confirmation{onYes: discard, onNo: restore})
+	}
+
+	return *m, nil
+}
+
+// runDiscardChanges undoes what rev changed in path.
+func (m *Model) runDiscardChanges(rev, path string) tea.Cmd {
+	runner := m.runner
+
+	return m.startJob("jj restore --changes-in "+rev+" "+path, func() tea.Msg {
+		if err := runner.DiscardChanges(rev, path); err != nil {
+			return errMsg{err}
+		}
+
+		return restoreCompleteMsg{summary: "discarded changes to " + path + " in " + rev}
+	})
+}
+
+// runRestoreFrom copies path as it is in rev into the working copy.
+func (m *Model) runRestoreFrom(rev, path string) tea.Cmd {
+	runner := m.runner
+
+	return m.startJob("jj restore --from "+rev+" "+path, func() tea.Msg {
+		if err := runner.RestoreFrom(rev, path); err != nil {
+			return errMsg{err}
+		}
+
+		return restoreCompleteMsg{summary: "restored " + path + " from " + rev}
+	})
+}
+
+// handleRestoreComplete reloads, with the files view, where the file may
+// have left the change.
+func (m *Model) handleRestoreComplete(msg restoreCompleteMsg) tea.Cmd {
+	cmds := []tea.Cmd{m.completeMutation(msg.summary)}
+
+	if m.viewMode == ViewFiles {
+		if change := m.filesPanel.ChangeID(); change != "" {
+			cmds = append(cmds, m.loadFiles(change))
+		}
+	}
+
+	return tea.Batch(cmds...)
+}
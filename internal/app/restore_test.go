@@ -0,0 +1,88 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui"
+)
+
+// restoreTestModel returns a model in the files view of change with main.go selected.
+func restoreTestModel(t *testing.T, change jj.Change) *Model {
+	t.Helper()
+
+	m := newTestModel(t)
+	m.changes = []jj.Change{change}
+	m.viewMode = ViewFiles
+	m.filesPanel.SetFiles(change.ChangeID, change.ChangeID[:2], []jj.File{{Path: "main.go", Status: jj.FileModified}})
+
+	return m
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestRestore_AsksHow(t *testing.T) {
+	tests := []struct {
+		name   string
+		change jj.Change
+		choice ui.ConfirmChoice
+		want   string
+	}{
+		{"discard in a change", jj.Change{ChangeID: "aaaaaaaa"}, ui.ConfirmYes, "jj restore --changes-in aaaaaaaa main.go"},
+		{"restore into @", jj.Change{ChangeID: "aaaaaaaa"}, ui.ConfirmNo, "jj restore --from aaaaaaaa main.go"},
+		{"discard in @", jj.Change{ChangeID: "aaaaaaaa", IsWorkingCopy: true}, ui.ConfirmYes, "jj restore --changes-in aaaaaaaa main.go"},
+		{"restore from immutable", jj.Change{ChangeID: "aaaaaaaa", IsImmutable: true}, ui.ConfirmYes, "jj restore --from aaaaaaaa main.go"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := restoreTestModel(t, tt.change)
+
+			next, _ := m.actionRestore()
+			*m = next
+
+			if !m.confirming || m.jobs.busy() {
+				t.Fatal("restoring should ask first")
+			}
+
+			m.handleConfirm(ui.ConfirmMsg{Choice: tt.choice})
+
+			if !m.jobs.busy() {
+				t.Fatal("answering should run jj restore")
+			}
+
+			if got := m.jobs.running[0].label; got != tt.want {
+				t.Errorf("ran %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRestore_OneChoiceForWorkingCopy(t *testing.T) {
+	m := restoreTestModel(t, jj.Change{ChangeID: "aaaaaaaa", IsWorkingCopy: true})
+
+	next, _ := m.actionRestore()
+	*m = next
+
+	if m.pendingConfirm.onNo != nil {
+		t.Error("restoring @ from itself does nothing, so only discarding should be offered")
+	}
+}
+
+func TestRestore_OnlyInFilesView(t *testing.T) {
+	m := restoreTestModel(t, jj.Change{ChangeID: "aaaaaaaa"})
+	m.viewMode = ViewLog
+
+	if m.restoreKey().Enabled() {
+		t.Error("restore should only be offered in the files view")
+	}
+
+	next, _ := m.actionRestore()
+	*m = next
+
+	if m.confirming {
+		t.Error("restore should do nothing outside the files view")
+	}
+}
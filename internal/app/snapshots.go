@@ -0,0 +1,38 @@
+package app
+
+import (
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+)
+
+// snapshotsKey returns the binding that hides snapshot operations, enabled
+// only with the op log (not an evolog) focused.
+func (m *Model) snapshotsKey() key.Binding {
+	binding := m.keys.Snapshots
+	binding.SetEnabled(m.focusedPane == PaneOpLog && m.viewMode == ViewLog)
+
+	return binding
+}
+
+// actionToggleSnapshots hides the "snapshot working copy" operations jj
+// records before nearly every command, or shows them again.
+func (m *Model) actionToggleSnapshots() (Model, tea.Cmd) {
+	if m.focusedPane != PaneOpLog || m.viewMode != ViewLog {
+		return *m, nil
+	}
+
+	hide := !m.opLogPanel.HidingSnapshots()
+	m.opLogPanel.SetHideSnapshots(hide)
+
+	var cmds []tea.Cmd
+	if op := m.opLogPanel.SelectedOperation(); op != nil {
+		cmds = append(cmds, m.loadOpShow(op.OpID))
+	}
+
+	if hide {
+		// Fewer operations are left to scroll through before the page ends
+		cmds = append(cmds, m.loadMoreOpLog())
+	}
+
+	return *m, tea.Batch(cmds...)
+}
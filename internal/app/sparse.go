@@ -0,0 +1,155 @@
+package app
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/ui/help"
+)
+
+// sparseBindingID identifies the choices of the sparse pattern menu.
+const sparseBindingID = "sparse"
+
+// sparseLoadedMsg carries the working copy's sparse patterns for the menu.
+type sparseLoadedMsg struct {
+	patterns []string
+}
+
+// sparseCompleteMsg reports the sparse patterns changed.
+type sparseCompleteMsg struct {
+	summary string
+}
+
+// actionSparse lists the paths the working copy checks out, to add to or
+// remove from.
+func (m *Model) actionSparse() (Model, tea.Cmd) {
+	runner := m.runner
+
+	return *m, func() tea.Msg {
+		patterns, err := runner.SparsePatterns()
+		if err != nil {
+			return errMsg{err}
+		}
+
+		return sparseLoadedMsg{patterns: patterns}
+	}
+}
+
+// handleSparseLoaded opens the sparse pattern menu: add a pattern, remove
+// each one, or check out everything again. A repo that can't be changed
+// just has its patterns reported.
+func (m *Model) handleSparseLoaded(msg sparseLoadedMsg) tea.Cmd {
+	if m.readOnly || m.timeTravelOp != "" {
+		return m.toasts.Info("sparse patterns: " + strings.Join(msg.patterns, ", "))
+	}
+
+	choice := func(keys, desc string, run func(m *Model) tea.Cmd) ActionBinding {
+		return ActionBinding{
+			Binding: help.Binding{Key: key.NewBinding(key.WithHelp(keys, desc))},
+			ID:      sparseBindingID,
+			Action: func(m *Model) (Model, tea.Cmd) {
+				return *m, run(m)
+			},
+			Mutates: true,
+		}
+	}
+
+	menu := []ActionBinding{choice("jj sparse set --add", "add a pattern", (*Model).askSparseAdd)}
+
+	whole := len(msg.patterns) == 1 && msg.patterns[0] == "."
+	if !whole {
+		for _, pattern := range msg.patterns {
+			menu = append(menu, choice("jj sparse set --remove "+pattern, "remove "+pattern, func(m *Model) tea.Cmd {
+				return m.confirmSparseRemove(pattern)
+			}))
+		}
+
+		menu = append(menu, choice("jj sparse reset", "check out all files", (*Model).confirmSparseReset))
+	}
+
+	title := "Sparse patterns (whole checkout)"
+	if !whole {
+		title = "Sparse patterns (" + strings.Join(msg.patterns, ", ") + ")"
+	}
+
+	m.palette = menu
+	m.finding = true
+	m.sizeFinder()
+
+	return m.finder.Open(title, paletteItems(m.palette))
+}
+
+// askSparseAdd asks which path to check out too.
+func (m *Model) askSparseAdd() tea.Cmd {
+	m.commanding = true
+	m.promptAnswer = (*Model).confirmSparseAdd
+	m.sizeCommandLine()
+
+	return m.commandLine.Ask("Add a sparse pattern", "path: ", "src")
+}
+
+// confirmSparseAdd asks before checking out the files under pattern too.
+func (m *Model) confirmSparseAdd(pattern string) tea.Cmd {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return nil
+	}
+
+	runner := m.runner
+
+	return m.confirmSparse(
+		"Add sparse pattern "+pattern+"?",
+		"The working copy checks out the files under "+pattern+" too.",
+		"add",
+		"jj sparse set --add "+pattern,
+		"added sparse pattern "+pattern,
+		func() error { return runner.SparseAdd(pattern) },
+	)
+}
+
+// confirmSparseRemove asks before removing the files under pattern from
+// the working copy.
+func (m *Model) confirmSparseRemove(pattern string) tea.Cmd {
+	runner := m.runner
+
+	return m.confirmSparse(
+		"Remove sparse pattern "+pattern+"?",
+		"The files under "+pattern+" are removed from the working copy; they stay in the repo.",
+		"remove",
+		"jj sparse set --remove "+pattern,
+		"removed sparse pattern "+pattern,
+		func() error { return runner.SparseRemove(pattern) },
+	)
+}
+
+// confirmSparseReset asks before checking out every file again.
+func (m *Model) confirmSparseReset() tea.Cmd {
+	return m.confirmSparse(
+		"Check out all files?",
+		"The sparse patterns are cleared and the working copy checks out the whole repo.",
+		"reset",
+		"jj sparse reset",
+		"checked out all files",
+		m.runner.SparseReset,
+	)
+}
+
+// confirmSparse asks title and, on yes, runs the sparse command run as a
+// job labelled label, reporting summary when it's done.
+func (m *Model) confirmSparse(title, body, yesHint, label, summary string, run func() error) tea.Cmd {
+	m.askConfirm(title, body, yesHint, "", confirmation{
+		onYes: func() tea.Cmd {
+			return m.startJob(label, func() tea.Msg {
+				if err := run(); err != nil {
+					return errMsg{err}
+				}
+
+				return sparseCompleteMsg{summary: summary}
+			})
+		},
+	})
+
+	return nil
+}
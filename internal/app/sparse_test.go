@@ -0,0 +1,79 @@
+package app
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/chatter/chado/internal/ui"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestSparse_MenuOffersPatterns(t *testing.T) {
+	m := newTestModel(t)
+
+	m.handleSparseLoaded(sparseLoadedMsg{patterns: []string{"docs", "src"}})
+
+	want := []string{"add a pattern", "remove docs", "remove src", "check out all files"}
+	if got := menuLabels(m); !m.finding || !slices.Equal(got, want) {
+		t.Errorf("menu = %v, want %v", got, want)
+	}
+}
+
+func TestSparse_WholeCheckoutOnlyAdds(t *testing.T) {
+	m := newTestModel(t)
+
+	m.handleSparseLoaded(sparseLoadedMsg{patterns: []string{"."}})
+
+	want := []string{"add a pattern"}
+	if got := menuLabels(m); !slices.Equal(got, want) {
+		t.Errorf("menu = %v, want %v", got, want)
+	}
+}
+
+func TestSparse_ReadOnlyReports(t *testing.T) {
+	m := newTestModel(t)
+	m.readOnly = true
+
+	m.handleSparseLoaded(sparseLoadedMsg{patterns: []string{"docs"}})
+
+	if m.finding || len(m.toasts.Items()) != 1 {
+		t.Error("a read-only repo should just report its patterns")
+	}
+}
+
+func TestSparse_AddAsksThenConfirms(t *testing.T) {
+	m := newTestModel(t)
+	m.handleSparseLoaded(sparseLoadedMsg{patterns: []string{"docs"}})
+
+	m.handlePaletteSelect(ui.FinderSelectMsg{Index: 0})
+
+	if !m.commanding || m.promptAnswer == nil {
+		t.Fatal("adding a pattern should ask which")
+	}
+
+	m.handlePromptSubmit(ui.PromptSubmitMsg{Value: "src"})
+
+	if m.jobs.busy() || !m.confirming {
+		t.Fatal("adding a pattern should ask first")
+	}
+
+	m.handleConfirm(ui.ConfirmMsg{Choice: ui.ConfirmYes})
+
+	if !m.jobs.busy() || m.jobs.running[0].label != "jj sparse set --add src" {
+		t.Error("confirming should run jj sparse set --add")
+	}
+}
+
+func TestSparse_RemoveConfirms(t *testing.T) {
+	m := newTestModel(t)
+	m.handleSparseLoaded(sparseLoadedMsg{patterns: []string{"docs"}})
+
+	m.handlePaletteSelect(ui.FinderSelectMsg{Index: 1})
+
+	if m.jobs.busy() || !m.confirming {
+		t.Error("removing a pattern should ask first")
+	}
+}
@@ -0,0 +1,77 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+)
+
+const (
+	// minLeftWidthPct and maxLeftWidthPct bound the left panes' share of
+	// screen width so neither side disappears.
+	minLeftWidthPct = 20
+	maxLeftWidthPct = 80
+
+	// leftWidthStepPct is how far one key press moves the divider.
+	leftWidthStepPct = 5
+)
+
+// leftWidth returns the width of the left panes for the current split.
+func (m *Model) leftWidth() int {
+	return m.width * m.leftWidthPct / percentDivisor
+}
+
+// setLeftWidthPct moves the divider, within bounds, and resizes the panels.
+func (m *Model) setLeftWidthPct(pct int) {
+	m.leftWidthPct = min(max(pct, minLeftWidthPct), maxLeftWidthPct)
+	m.updatePanelSizes()
+}
+
+// actionShrinkLeft moves the divider left, giving the diff more room.
+func (m *Model) actionShrinkLeft() (Model, tea.Cmd) {
+	m.setLeftWidthPct(m.leftWidthPct - leftWidthStepPct)
+	return *m, nil
+}
+
+// actionGrowLeft moves the divider right, giving the log more room.
+func (m *Model) actionGrowLeft() (Model, tea.Cmd) {
+	m.setLeftWidthPct(m.leftWidthPct + leftWidthStepPct)
+	return *m, nil
+}
+
+// onDivider reports whether screen column x is on the borders between the
+// left and right panes. Stacked panes have no divider.
+func (m *Model) onDivider(x int) bool {
+	if m.stacked() {
+		return false
+	}
+
+	leftWidth := m.leftWidth()
+
+	return x == leftWidth-1 || x == leftWidth
+}
+
+// handleDividerDrag moves the divider while the left button drags it. It
+// reports whether the event was part of a drag.
+func (m *Model) handleDividerDrag(msg tea.MouseMsg) bool {
+	mouse := msg.Mouse()
+
+	switch msg.(type) {
+	case tea.MouseClickMsg:
+		if mouse.Button == tea.MouseLeft && m.onDivider(mouse.X) {
+			m.draggingDivider = true
+		}
+	case tea.MouseMotionMsg:
+		if m.draggingDivider && m.width > 0 {
+			m.setLeftWidthPct((mouse.X + 1) * percentDivisor / m.width)
+		}
+	case tea.MouseReleaseMsg:
+		if !m.draggingDivider {
+			return false
+		}
+
+		m.draggingDivider = false
+
+		return true
+	}
+
+	return m.draggingDivider
+}
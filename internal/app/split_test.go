@@ -0,0 +1,127 @@
+package app
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"pgregory.net/rapid"
+)
+
+// newSizedTestModel returns a test model laid out on a 100x40 screen.
+func newSizedTestModel(t *testing.T) *Model {
+	t.Helper()
+
+	m := newTestModel(t)
+	m.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+
+	return m
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestSplit_KeysMoveDivider(t *testing.T) {
+	m := newSizedTestModel(t)
+
+	next, _ := m.actionGrowLeft()
+	*m = next
+
+	if m.leftWidthPct != leftPanelWidthPct+leftWidthStepPct {
+		t.Errorf("expected %d%%, got %d%%", leftPanelWidthPct+leftWidthStepPct, m.leftWidthPct)
+	}
+
+	next, _ = m.actionShrinkLeft()
+	*m = next
+	next, _ = m.actionShrinkLeft()
+	*m = next
+
+	if m.leftWidthPct != leftPanelWidthPct-leftWidthStepPct {
+		t.Errorf("expected %d%%, got %d%%", leftPanelWidthPct-leftWidthStepPct, m.leftWidthPct)
+	}
+}
+
+func TestSplit_ClampedToBounds(t *testing.T) {
+	m := newSizedTestModel(t)
+
+	for range 20 {
+		next, _ := m.actionShrinkLeft()
+		*m = next
+	}
+
+	if m.leftWidthPct != minLeftWidthPct {
+		t.Errorf("expected the minimum %d%%, got %d%%", minLeftWidthPct, m.leftWidthPct)
+	}
+
+	for range 20 {
+		next, _ := m.actionGrowLeft()
+		*m = next
+	}
+
+	if m.leftWidthPct != maxLeftWidthPct {
+		t.Errorf("expected the maximum %d%%, got %d%%", maxLeftWidthPct, m.leftWidthPct)
+	}
+}
+
+func TestSplit_DragDivider(t *testing.T) {
+	m := newSizedTestModel(t)
+	divider := m.leftWidth() - 1
+
+	m.Update(tea.MouseClickMsg{X: divider, Y: 5, Button: tea.MouseLeft})
+
+	if !m.draggingDivider {
+		t.Fatal("clicking the divider should start a drag")
+	}
+
+	m.Update(tea.MouseMotionMsg{X: 59, Y: 5, Button: tea.MouseLeft})
+	m.Update(tea.MouseReleaseMsg{X: 59, Y: 5, Button: tea.MouseLeft})
+
+	if m.draggingDivider {
+		t.Error("releasing the button should end the drag")
+	}
+
+	if m.leftWidth() != 60 {
+		t.Errorf("expected the left panes to end at the mouse, got width %d", m.leftWidth())
+	}
+
+	if m.focusedPane != PaneLog {
+		t.Errorf("dragging should not click the panes, got focus %v", m.focusedPane)
+	}
+}
+
+func TestSplit_MotionWithoutDragIgnored(t *testing.T) {
+	m := newSizedTestModel(t)
+
+	if m.handleDividerDrag(tea.MouseMotionMsg{X: 70, Y: 5, Button: tea.MouseLeft}) {
+		t.Error("motion without a drag should be left to the panes")
+	}
+
+	if m.leftWidthPct != leftPanelWidthPct {
+		t.Errorf("motion away from a drag should not move the divider, got %d%%", m.leftWidthPct)
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+func TestSplit_StaysInBounds(t *testing.T) {
+	m := newTestModel(t)
+
+	rapid.Check(t, func(t *rapid.T) {
+		m.leftWidthPct = leftPanelWidthPct
+		m.width = rapid.IntRange(40, 300).Draw(t, "width")
+
+		for _, grow := range rapid.SliceOf(rapid.Bool()).Draw(t, "moves") {
+			if grow {
+				*m, _ = m.actionGrowLeft()
+			} else {
+				*m, _ = m.actionShrinkLeft()
+			}
+		}
+
+		if m.leftWidthPct < minLeftWidthPct || m.leftWidthPct > maxLeftWidthPct {
+			t.Fatalf("split %d%% out of bounds", m.leftWidthPct)
+		}
+	})
+}
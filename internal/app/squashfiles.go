@@ -0,0 +1,135 @@
+package app
+
+import (
+	"cmp"
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui/help"
+)
+
+// squashFilesBindingID identifies the destinations offered when squashing
+// files.
+const squashFilesBindingID = "squash-files"
+
+// squashFilesCompleteMsg reports files moved out of a change.
+type squashFilesCompleteMsg struct {
+	from    string
+	summary string
+}
+
+// squashFilesPaths returns the files a squash in the files view moves: the
+// marked ones, or the selected file when none are marked.
+func (m *Model) squashFilesPaths() []string {
+	if paths := m.filesPanel.MarkedPaths(); len(paths) > 0 {
+		return paths
+	}
+
+	if file := m.filesPanel.SelectedFile(); file != nil {
+		return []string{file.Path}
+	}
+
+	return nil
+}
+
+// actionSquashFiles offers where to move the marked files of the change
+// shown in the files view, or the selected file when none are marked: its
+// parent, or any other mutable change in the log.
+func (m *Model) actionSquashFiles() (Model, tea.Cmd) {
+	from := m.filesPanel.ChangeID()
+	paths := m.squashFilesPaths()
+
+	if from == "" || len(paths) == 0 {
+		return *m, nil
+	}
+
+	if change, _ := m.loadedChange(from); change.IsImmutable {
+		return *m, m.refuseImmutable("squash", from)
+	}
+
+	m.palette = squashMenu(squashFilesBindingID, from, m.changes, func(m *Model, into string) tea.Cmd {
+		return m.runSquashFiles(from, into, paths)
+	})
+	m.finding = true
+	m.sizeFinder()
+
+	return *m, m.finder.Open("Squash "+describePaths(paths)+" into", paletteItems(m.palette))
+}
+
+// squashMenu returns where from's changes can be squashed, run through the
+// palette as choices with the given ID: from's parent first, then the other
+// mutable changes. run squashes into a change, or the parent when into is "".
+func squashMenu(id, from string, changes []jj.Change, run func(m *Model, into string) tea.Cmd) []ActionBinding {
+	choice := func(keys, desc, into string) ActionBinding {
+		return ActionBinding{
+			Binding: help.Binding{Key: key.NewBinding(key.WithHelp(keys, desc))},
+			ID:      id,
+			Action: func(m *Model) (Model, tea.Cmd) {
+				return *m, run(m, into)
+			},
+			Mutates: true,
+		}
+	}
+
+	menu := []ActionBinding{choice("jj squash -r "+from, "parent of "+from, "")}
+
+	for _, change := range changes {
+		if change.ChangeID == from || change.IsImmutable {
+			continue
+		}
+
+		title, _, _ := strings.Cut(change.Description, "\n")
+		menu = append(menu, choice("jj squash --into "+change.ChangeID,
+			change.ChangeID+" "+cmp.Or(title, "(no description set)"), change.ChangeID))
+	}
+
+	return menu
+}
+
+// runSquashFiles executes jj squash moving paths of from into another change,
+// or into from's parent when into is empty.
+func (m *Model) runSquashFiles(from, into string, paths []string) tea.Cmd {
+	label := "jj squash -r " + from
+	summary := "squashed " + describePaths(paths) + " of " + from + " into its parent"
+
+	if into != "" {
+		label = "jj squash --from " + from + " --into " + into
+		summary = "squashed " + describePaths(paths) + " of " + from + " into " + into
+	}
+
+	runner := m.runner
+
+	return m.startJob(label+" "+strings.Join(paths, " "), func() tea.Msg {
+		if err := runner.SquashPaths(from, into, paths...); err != nil {
+			return errMsg{err}
+		}
+
+		return squashFilesCompleteMsg{from: from, summary: summary}
+	})
+}
+
+// handleSquashFilesComplete reloads, with the files view, which the moved
+// files have left; their marks go with them.
+func (m *Model) handleSquashFilesComplete(msg squashFilesCompleteMsg) tea.Cmd {
+	cmds := []tea.Cmd{m.completeMutation(msg.summary)}
+
+	if m.viewMode == ViewFiles && m.filesPanel.ChangeID() == msg.from {
+		m.filesPanel.ClearMarks()
+		cmds = append(cmds, m.loadFiles(msg.from))
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// describePaths names a single path, or counts several.
+func describePaths(paths []string) string {
+	if len(paths) == 1 {
+		return paths[0]
+	}
+
+	return strconv.Itoa(len(paths)) + " files"
+}
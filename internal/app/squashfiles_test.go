@@ -0,0 +1,99 @@
+package app
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui"
+)
+
+// squashFilesTestModel returns a model in the files view of bbbbbbbb, with
+// a.go selected, over a log of a mutable and an immutable change besides it.
+func squashFilesTestModel(t *testing.T) *Model {
+	t.Helper()
+
+	m := newTestModel(t)
+	m.changes = []jj.Change{
+		{ChangeID: "aaaaaaaa", Description: "fix parser\n\nDetails."},
+		{ChangeID: "bbbbbbbb"},
+		{ChangeID: "cccccccc", IsImmutable: true},
+	}
+	m.viewMode = ViewFiles
+	m.filesPanel.SetFiles("bbbbbbbb", "b", []jj.File{
+		{Path: "a.go", Status: jj.FileModified},
+		{Path: "b.go", Status: jj.FileModified},
+		{Path: "c.go", Status: jj.FileAdded},
+	})
+
+	return m
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestSquashFiles_OffersDestinations(t *testing.T) {
+	m := squashFilesTestModel(t)
+
+	next, _ := m.actionSquash()
+	*m = next
+
+	if !m.finding {
+		t.Fatal("s in the files view should ask where to squash")
+	}
+
+	var labels []string
+	for _, item := range paletteItems(m.palette) {
+		labels = append(labels, item.Label)
+	}
+
+	want := []string{"parent of bbbbbbbb", "aaaaaaaa fix parser"}
+	if !slices.Equal(labels, want) {
+		t.Errorf("destinations = %v, want %v", labels, want)
+	}
+
+	m.handlePaletteSelect(ui.FinderSelectMsg{Index: 0})
+
+	if got := m.jobs.running[0].label; got != "jj squash -r bbbbbbbb a.go" {
+		t.Errorf("with nothing marked the selected file should move, ran %q", got)
+	}
+}
+
+func TestSquashFiles_MovesMarkedFiles(t *testing.T) {
+	m := squashFilesTestModel(t)
+	m.filesPanel.ToggleMark()
+	m.filesPanel.GotoBottom()
+	m.filesPanel.ToggleMark()
+
+	next, _ := m.actionSquash()
+	*m = next
+	m.handlePaletteSelect(ui.FinderSelectMsg{Index: 1})
+
+	if got := m.jobs.running[0].label; got != "jj squash --from bbbbbbbb --into aaaaaaaa a.go c.go" {
+		t.Errorf("ran %q", got)
+	}
+}
+
+func TestSquashFiles_RefusesImmutable(t *testing.T) {
+	m := squashFilesTestModel(t)
+	m.changes[1].IsImmutable = true
+
+	next, _ := m.actionSquash()
+	*m = next
+
+	if m.finding || m.jobs.busy() {
+		t.Error("files of an immutable change should not be squashed")
+	}
+}
+
+func TestSquashFiles_CompleteClearsMarks(t *testing.T) {
+	m := squashFilesTestModel(t)
+	m.filesPanel.ToggleMark()
+
+	m.handleSquashFilesComplete(squashFilesCompleteMsg{from: "bbbbbbbb", summary: "squashed a.go of bbbbbbbb into its parent"})
+
+	if got := m.filesPanel.MarkedPaths(); len(got) != 0 {
+		t.Errorf("moved files should be unmarked, got %v", got)
+	}
+}
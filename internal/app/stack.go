@@ -0,0 +1,64 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+)
+
+// stackCompleteMsg reports the working copy moved up or down the stack.
+type stackCompleteMsg struct {
+	summary string
+}
+
+// actionNext moves the working copy to its child (jj next).
+func (m *Model) actionNext() (Model, tea.Cmd) {
+	return *m, m.runStack(true)
+}
+
+// actionPrev moves the working copy to its parent (jj prev).
+func (m *Model) actionPrev() (Model, tea.Cmd) {
+	return *m, m.runStack(false)
+}
+
+// runStack executes jj next, or jj prev, editing the change it reaches or
+// starting a new one on it as configured.
+func (m *Model) runStack(next bool) tea.Cmd {
+	edit := m.stackEdit
+	command, run, relative := "prev", m.runner.Prev, "parent"
+
+	if next {
+		command, run, relative = "next", m.runner.Next, "child"
+	}
+
+	label, summary := "jj "+command, "started a new change on the "+relative+" of @"
+	if edit {
+		label, summary = label+" --edit", "editing the "+relative+" of @"
+	}
+
+	return m.startJob(label, func() tea.Msg {
+		if err := run(edit); err != nil {
+			return errMsg{err}
+		}
+
+		return stackCompleteMsg{summary: summary}
+	})
+}
+
+// handleStackComplete reloads with the log selecting where @ went, so the
+// stack can be walked key by key.
+func (m *Model) handleStackComplete(msg stackCompleteMsg) tea.Cmd {
+	m.followWorkingCopy = true
+
+	return m.completeMutation(msg.summary)
+}
+
+// selectWorkingCopy selects @ in the log, once it has loaded.
+func (m *Model) selectWorkingCopy() {
+	m.followWorkingCopy = false
+
+	for _, change := range m.changes {
+		if change.IsWorkingCopy {
+			m.logPanel.SelectChangeID(change.ChangeID)
+			return
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestStack_Labels(t *testing.T) {
+	tests := []struct {
+		name string
+		next bool
+		edit bool
+		want string
+	}{
+		{"next editing", true, true, "jj next --edit"},
+		{"prev editing", false, true, "jj prev --edit"},
+		{"next new change", true, false, "jj next"},
+		{"prev new change", false, false, "jj prev"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(t)
+			m.stackEdit = tt.edit
+
+			if tt.next {
+				m.actionNext()
+			} else {
+				m.actionPrev()
+			}
+
+			if got := m.jobs.running[0].label; got != tt.want {
+				t.Errorf("ran %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStack_SelectsWorkingCopyAfterMove(t *testing.T) {
+	m := newTestModel(t)
+	m.changes = []jj.Change{
+		{ChangeID: "aaaaaaaa"},
+		{ChangeID: "bbbbbbbb", IsWorkingCopy: true},
+	}
+	m.logPanel.SetContent("○ aaaaaaaa\n@ bbbbbbbb\n", m.changes)
+	m.logPanel.SelectChangeID("aaaaaaaa")
+
+	m.handleStackComplete(stackCompleteMsg{summary: "editing the parent of @"})
+
+	if !m.followWorkingCopy {
+		t.Fatal("the next log load should follow @")
+	}
+
+	m.selectWorkingCopy()
+
+	if selected := m.logPanel.SelectedChange(); selected == nil || selected.ChangeID != "bbbbbbbb" {
+		t.Errorf("expected @ to be selected, got %+v", selected)
+	}
+
+	if m.followWorkingCopy {
+		t.Error("following @ should stop once it is selected")
+	}
+}
@@ -0,0 +1,179 @@
+package app
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// stacksLoadedMsg carries the mutable changes above trunk, grouped into stacks.
+type stacksLoadedMsg struct {
+	stacks []jj.Stack
+}
+
+// stackMutatedMsg reports a whole stack pushed or rebased.
+type stackMutatedMsg struct {
+	summary string
+}
+
+// actionStacks toggles the stacks panel in place of the log.
+func (m *Model) actionStacks() (Model, tea.Cmd) {
+	switch m.viewMode {
+	case ViewStacks:
+		return *m, m.handleBack()
+	case ViewFiles:
+		return *m, nil
+	case ViewLog, ViewBookmarks:
+	}
+
+	m.viewMode = ViewStacks
+	m.focusedPane = PaneLog
+	m.updatePanelFocus()
+
+	return *m, m.loadStacks()
+}
+
+// loadStacks fetches the stacks for the stacks panel.
+func (m *Model) loadStacks() tea.Cmd {
+	runner := m.runner
+
+	return func() tea.Msg {
+		stacks, err := runner.Stacks()
+		if err != nil {
+			return errMsg{err}
+		}
+
+		return stacksLoadedMsg{stacks: stacks}
+	}
+}
+
+func (m *Model) handleStacksLoaded(msg stacksLoadedMsg) tea.Cmd {
+	m.stacksPanel.SetStacks(msg.stacks)
+
+	if m.viewMode == ViewStacks && m.focusedPane == PaneLog {
+		return m.loadSelectedDiff()
+	}
+
+	return nil
+}
+
+// selectedStackChange returns the change under the stacks panel's cursor,
+// or the newest change of the stack whose header is selected.
+func (m *Model) selectedStackChange() *jj.Change {
+	if change := m.stacksPanel.SelectedChange(); change != nil {
+		return change
+	}
+
+	if stack := m.stacksPanel.SelectedStack(); stack != nil && len(stack.Changes) > 0 {
+		return &stack.Changes[0]
+	}
+
+	return nil
+}
+
+// focusStackChange goes back to the log with the selected change selected;
+// on a stack's header, it folds or unfolds the stack instead.
+func (m *Model) focusStackChange() tea.Cmd {
+	change := m.stacksPanel.SelectedChange()
+	if change == nil {
+		m.stacksPanel.ToggleFold()
+		return nil
+	}
+
+	if !m.logPanel.SelectChangeID(change.ChangeID) {
+		return m.toasts.Info(change.ChangeID + " is not in the log")
+	}
+
+	return m.handleBack()
+}
+
+// rebaseStackKey returns the binding that rebases a stack onto trunk,
+// enabled only with the stacks panel focused.
+func (m *Model) rebaseStackKey() key.Binding {
+	binding := m.keys.RebaseStack
+	binding.SetEnabled(m.focusedPane == PaneLog && m.viewMode == ViewStacks)
+
+	return binding
+}
+
+// actionRebaseStack previews and then rebases the selected stack, from its
+// roots up, onto trunk.
+func (m *Model) actionRebaseStack() (Model, tea.Cmd) {
+	if m.focusedPane != PaneLog || m.viewMode != ViewStacks {
+		return *m, nil
+	}
+
+	stack := m.stacksPanel.SelectedStack()
+	if stack == nil {
+		return *m, nil
+	}
+
+	return *m, m.previewMutation(m.rebaseStackPreview(*stack))
+}
+
+// rebaseStackPreview describes rebasing a stack onto trunk. Without jj's dry
+// run, the fallback lists the changes that move.
+func (m *Model) rebaseStackPreview(stack jj.Stack) previewedMutation {
+	name, roots := stack.Name(), stack.Roots
+	runner := m.runner
+
+	return previewedMutation{
+		title:   "Rebase stack " + name + " onto trunk?",
+		label:   "jj rebase --source " + strings.Join(roots, " --source ") + " --destination trunk()",
+		yesHint: "rebase",
+		dryRun: func() (string, error) {
+			return runner.RebaseStackPreview(roots)
+		},
+		fallback: func() (string, error) {
+			log, err := runner.Descendants(strings.Join(roots, " | "), previewDescendantLimit)
+			if err != nil {
+				return "", err
+			}
+
+			return "These changes move onto trunk(), keeping their shape.\n\n" + log, nil
+		},
+		run: func() tea.Cmd {
+			return m.startJob("jj rebase stack "+name, func() tea.Msg {
+				if err := runner.RebaseStack(roots); err != nil {
+					return errMsg{err}
+				}
+
+				return stackMutatedMsg{summary: "rebased stack " + name + " onto trunk"}
+			})
+		},
+	}
+}
+
+// pushStackPreview describes pushing the bookmarks on every change in a
+// stack using jj's dry run.
+func (m *Model) pushStackPreview(stack jj.Stack) previewedMutation {
+	name, revset := stack.Name(), stack.Revset()
+	runner := m.runner
+
+	return previewedMutation{
+		title:   "Push stack " + name + "?",
+		label:   "jj git push -r '" + revset + "'",
+		yesHint: "push",
+		dryRun: func() (string, error) {
+			return runner.PushPreview(revset)
+		},
+		run: func() tea.Cmd {
+			return m.startJob("jj git push stack "+name, func() tea.Msg {
+				if err := runner.Push(revset); err != nil {
+					return errMsg{err}
+				}
+
+				return stackMutatedMsg{summary: "pushed bookmarks on stack " + name}
+			})
+		},
+	}
+}
+
+// handleStackMutated reports the stack pushed or rebased and reloads, the
+// stacks panel included.
+func (m *Model) handleStackMutated(msg stackMutatedMsg) tea.Cmd {
+	return tea.Batch(m.completeMutation(msg.summary), m.loadStacks())
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// stacksTestModel returns a model showing a two-change parser stack, whose
+// changes are also in the log.
+func stacksTestModel(t *testing.T) *Model {
+	t.Helper()
+
+	m := newTestModel(t)
+	m.changes = []jj.Change{{ChangeID: "qqqqqqqq"}, {ChangeID: "pppppppp"}}
+	m.logPanel.SetContent("@ qqqqqqqq\n○ pppppppp\n", m.changes)
+
+	m.actionStacks()
+	m.handleStacksLoaded(stacksLoadedMsg{stacks: []jj.Stack{{
+		Changes: []jj.Change{
+			{ChangeID: "qqqqqqqq", Bookmarks: []string{"parser"}},
+			{ChangeID: "pppppppp"},
+		},
+		Roots: []string{"pppppppp"},
+	}}})
+
+	return m
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestStacks_ToggleView(t *testing.T) {
+	m := stacksTestModel(t)
+
+	if m.viewMode != ViewStacks || m.focusedPane != PaneLog {
+		t.Fatalf("expected focused stacks view, got mode %v pane %v", m.viewMode, m.focusedPane)
+	}
+
+	m.actionStacks()
+
+	if m.viewMode != ViewLog {
+		t.Error("pressing stacks again should return to the log")
+	}
+}
+
+func TestStacks_EnterFoldsHeaderAndGoesToChange(t *testing.T) {
+	m := stacksTestModel(t)
+
+	m.handleEnter()
+
+	if m.viewMode != ViewStacks || m.stacksPanel.SelectedChange() != nil {
+		t.Fatal("enter on a header should fold the stack, staying in the stacks view")
+	}
+
+	m.handleEnter() // unfold
+	m.stacksPanel.CursorDown()
+	m.stacksPanel.CursorDown()
+
+	m.handleEnter()
+
+	if selected := m.logPanel.SelectedChange(); m.viewMode != ViewLog || selected == nil || selected.ChangeID != "pppppppp" {
+		t.Errorf("enter on a change should select it in the log, got mode %v and %+v", m.viewMode, selected)
+	}
+}
+
+func TestStacks_RebaseOnlyInStacksView(t *testing.T) {
+	m := newTestModel(t)
+
+	if m.rebaseStackKey().Enabled() {
+		t.Error("rebasing a stack should be off outside the stacks view")
+	}
+
+	if m = stacksTestModel(t); !m.rebaseStackKey().Enabled() {
+		t.Error("rebasing a stack should be on in the stacks view")
+	}
+}
+
+func TestStacks_PreviewsNameTheStack(t *testing.T) {
+	m := stacksTestModel(t)
+	stack := *m.stacksPanel.SelectedStack()
+
+	if rebase := m.rebaseStackPreview(stack); rebase.label != "jj rebase --source pppppppp --destination trunk()" {
+		t.Errorf("rebase label = %q", rebase.label)
+	}
+
+	if push := m.pushStackPreview(stack); push.title != "Push stack parser?" || push.label != "jj git push -r 'qqqqqqqq | pppppppp'" {
+		t.Errorf("push = %q, %q", push.title, push.label)
+	}
+}
+
+func TestStacks_PushWholeStack(t *testing.T) {
+	m := stacksTestModel(t)
+
+	if _, cmd := m.actionPush(); cmd == nil {
+		t.Error("push in the stacks view should preview pushing the selected stack")
+	}
+}
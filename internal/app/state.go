@@ -0,0 +1,51 @@
+package app
+
+import (
+	"github.com/chatter/chado/internal/state"
+)
+
+// RestoreState puts the UI back where a previous session left it: the
+// focused pane, split, and description history right away, the selected
+// change once the log loads.
+func (m *Model) RestoreState(saved state.Repo) {
+	if saved.FocusedPane >= 0 && saved.FocusedPane < paneCount {
+		m.focusedPane = FocusedPane(saved.FocusedPane)
+		m.updatePanelFocus()
+	}
+
+	if saved.LeftWidthPct != 0 {
+		m.setLeftWidthPct(saved.LeftWidthPct)
+	}
+
+	m.describeInput.SetHistory(saved.Descriptions)
+	m.restoreChangeID = saved.ChangeID
+}
+
+// SavedState returns the UI state for the next session to restore.
+func (m *Model) SavedState() state.Repo {
+	saved := state.Repo{
+		FocusedPane:  int(m.focusedPane),
+		LeftWidthPct: m.leftWidthPct,
+		ChangeID:     m.restoreChangeID, // still pending if the log never loaded
+		Descriptions: m.describeInput.History(),
+	}
+
+	if selected := m.logPanel.SelectedChange(); selected != nil {
+		saved.ChangeID = selected.ChangeID
+	}
+
+	return saved
+}
+
+// SaveState saves the UI state for the next session in the workspace chado
+// ended in. Call it on the model the program finished with: key actions
+// replace the model, so the one passed to it goes stale.
+func (m *Model) SaveState() error {
+	return state.Save(m.workDir, m.SavedState())
+}
+
+// WorkDir returns the root of the workspace chado is in, which switching
+// workspace changes; its state is saved there.
+func (m *Model) WorkDir() string {
+	return m.workDir
+}
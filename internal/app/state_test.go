@@ -0,0 +1,172 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/state"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// pressKeys sends keys to the model the way bubbletea does, each to the
+// model the last one returned, and returns the model it ends with.
+func pressKeys(t *testing.T, m *Model, keys ...tea.KeyPressMsg) *Model {
+	t.Helper()
+
+	var current tea.Model = m
+
+	for _, msg := range keys {
+		current, _ = current.Update(msg)
+	}
+
+	final, ok := current.(*Model)
+	if !ok {
+		t.Fatalf("expected a *Model, got %T", current)
+	}
+
+	return final
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestRestoreState_FocusAndSplit(t *testing.T) {
+	m := newTestModel(t)
+
+	m.RestoreState(state.Repo{FocusedPane: int(PaneOpLog), LeftWidthPct: 55})
+
+	if m.focusedPane != PaneOpLog {
+		t.Errorf("expected the op log focused, got %v", m.focusedPane)
+	}
+
+	if m.leftWidthPct != 55 {
+		t.Errorf("expected a 55%% split, got %d%%", m.leftWidthPct)
+	}
+}
+
+func TestRestoreState_IgnoresUnsavedAndInvalid(t *testing.T) {
+	m := newTestModel(t)
+
+	m.RestoreState(state.Repo{FocusedPane: 7})
+
+	if m.focusedPane != PaneLog || m.leftWidthPct != leftPanelWidthPct {
+		t.Errorf("expected the defaults, got pane %v split %d%%", m.focusedPane, m.leftWidthPct)
+	}
+}
+
+func TestRestoreState_SelectsChangeWhenLogLoads(t *testing.T) {
+	m := newTestModel(t)
+	m.RestoreState(state.Repo{FocusedPane: int(PaneLog), ChangeID: "zzzzzzzz"})
+
+	changes := []jj.Change{{ChangeID: "qpvuntsm"}, {ChangeID: "zzzzzzzz"}}
+	m.handleLogLoaded(logLoadedMsg{changes: changes})
+
+	if got := m.logPanel.SelectedChange(); got == nil || got.ChangeID != "zzzzzzzz" {
+		t.Fatalf("expected the saved change selected, got %+v", got)
+	}
+
+	// Later reloads keep the user's own selection
+	m.logPanel.GotoTop()
+	m.handleLogLoaded(logLoadedMsg{changes: changes})
+
+	if got := m.logPanel.SelectedChange(); got.ChangeID != "qpvuntsm" {
+		t.Errorf("the saved change should only be restored once, got %q", got.ChangeID)
+	}
+}
+
+func TestSavedState_DescriptionHistory(t *testing.T) {
+	m := newTestModel(t)
+	m.RestoreState(state.Repo{Descriptions: []string{"fix: parser"}})
+
+	m.changes = []jj.Change{{ChangeID: "aaaaaaaa", IsWorkingCopy: true}}
+	m.logPanel.SetContent("@ aaaaaaaa\n", m.changes)
+	m.actionDescribe()
+	m.describeInput.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+
+	if got := m.describeInput.Value(); got != "fix: parser" {
+		t.Fatalf("up should recall the last session's description, got %q", got)
+	}
+
+	m.describeInput.Update(tea.KeyPressMsg{Code: '!', Text: "!"})
+	m.describeInput.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+
+	if got := m.SavedState().Descriptions; !reflect.DeepEqual(got, []string{"fix: parser", "fix: parser!"}) {
+		t.Errorf("the cancelled description should be saved for next time, got %q", got)
+	}
+}
+
+func TestSaveState_AfterKeyActions(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	m := newTestModel(t)
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	// The first action returns a copy; the second moves focus on it alone
+	tab := tea.KeyPressMsg{Code: tea.KeyTab}
+
+	final := pressKeys(t, m, tab, tab)
+	if final == m || final.focusedPane == m.focusedPane {
+		t.Fatal("expected the second Tab to move focus only on the model the first returned")
+	}
+
+	if err := final.SaveState(); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := state.Load(final.WorkDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if saved.FocusedPane != int(final.focusedPane) {
+		t.Errorf("the focus at exit should be saved, got pane %d, want %d", saved.FocusedPane, final.focusedPane)
+	}
+}
+
+func TestSaveState_HistoryWrittenDuringSession(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	m := newTestModel(t)
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m.changes = []jj.Change{{ChangeID: "aaaaaaaa", IsWorkingCopy: true}}
+	m.logPanel.SetContent("@ aaaaaaaa\n", m.changes)
+
+	// Tab and back replace the model before describing: d, type, Esc
+	final := pressKeys(t, m,
+		tea.KeyPressMsg{Code: tea.KeyTab},
+		tea.KeyPressMsg{Code: tea.KeyTab, Mod: tea.ModShift},
+		tea.KeyPressMsg{Code: 'd', Text: "d"},
+		tea.KeyPressMsg{Code: 'x', Text: "x"},
+		tea.KeyPressMsg{Code: tea.KeyEscape},
+	)
+
+	if err := final.SaveState(); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := state.Load(final.WorkDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(saved.Descriptions, []string{"x"}) {
+		t.Errorf("a description written after the first key action should be saved, got %q", saved.Descriptions)
+	}
+}
+
+func TestSavedState_RoundTrip(t *testing.T) {
+	m := newTestModel(t)
+	m.RestoreState(state.Repo{FocusedPane: int(PaneDiff), LeftWidthPct: 35, ChangeID: "zzzzzzzz"})
+
+	want := state.Repo{FocusedPane: int(PaneDiff), LeftWidthPct: 35, ChangeID: "zzzzzzzz"}
+	if got := m.SavedState(); !reflect.DeepEqual(got, want) {
+		t.Errorf("a session that never loaded the log should keep the saved change, got %+v", got)
+	}
+}
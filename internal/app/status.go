@@ -0,0 +1,52 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+)
+
+// statusTitle heads the diff pane while it shows the working copy status.
+const statusTitle = "jj status"
+
+// statusLoadedMsg carries jj status output for the diff pane.
+type statusLoadedMsg struct {
+	output string
+	err    error
+}
+
+// showingStatus reports whether the diff pane shows the working copy status.
+func (m *Model) showingStatus() bool {
+	return m.diffPanel.Previewing() && m.diffPanel.Title() == statusTitle
+}
+
+// actionToggleStatus shows the working copy status in the diff pane, or
+// hides it again.
+func (m *Model) actionToggleStatus() (Model, tea.Cmd) {
+	if m.showingStatus() {
+		m.diffPanel.ClosePreview()
+		return *m, nil
+	}
+
+	return *m, m.loadStatus()
+}
+
+// loadStatus fetches jj status for the working copy.
+func (m *Model) loadStatus() tea.Cmd {
+	runner := m.runner
+
+	return func() tea.Msg {
+		output, err := runner.Status()
+		return statusLoadedMsg{output: output, err: err}
+	}
+}
+
+// handleStatusLoaded shows the status in the diff pane, where it stays until
+// toggled off or esc, refreshed as the working copy changes.
+func (m *Model) handleStatusLoaded(msg statusLoadedMsg) tea.Cmd {
+	if msg.err != nil {
+		return m.handleErr(errMsg{msg.err})
+	}
+
+	m.diffPanel.ShowOutput(statusTitle, msg.output)
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"errors"
+	"testing"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestStatus_ShownAndToggledOff(t *testing.T) {
+	m := newTestModel(t)
+	m.diffPanel.SetTitle("Diff")
+
+	m.handleStatusLoaded(statusLoadedMsg{output: "Working copy changes:\nM main.go\n"})
+
+	if !m.showingStatus() {
+		t.Fatal("the status should show in the diff pane")
+	}
+
+	next, _ := m.actionToggleStatus()
+	*m = next
+
+	if m.showingStatus() || m.diffPanel.Previewing() {
+		t.Error("toggling again should hide the status")
+	}
+
+	if m.diffPanel.Title() != "Diff" {
+		t.Errorf("hiding the status should restore the diff, got title %q", m.diffPanel.Title())
+	}
+}
+
+func TestStatus_OtherOutputIsNotStatus(t *testing.T) {
+	m := newTestModel(t)
+
+	m.diffPanel.ShowOutput("jj log", "output")
+
+	if m.showingStatus() {
+		t.Error("command output should not count as the status")
+	}
+}
+
+func TestStatus_ErrorToasts(t *testing.T) {
+	m := newTestModel(t)
+
+	m.handleStatusLoaded(statusLoadedMsg{err: errors.New("no working copy")})
+
+	if m.showingStatus() || !m.toasts.HasErrors() {
+		t.Error("a failed status should report the error instead of showing")
+	}
+}
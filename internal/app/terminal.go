@@ -0,0 +1,33 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/ui"
+)
+
+// windowTitle names the repo and the working-copy change in the terminal's
+// title, e.g. "chado: chado @ xsssnyux", so the tab can be told apart from
+// others; the change is left out until the log has loaded.
+func (m *Model) windowTitle() string {
+	title := "chado: " + filepath.Base(m.workDir)
+
+	for _, change := range m.changes {
+		if change.IsWorkingCopy {
+			return title + " @ " + change.ChangeID
+		}
+	}
+
+	return title
+}
+
+// announceDirectory tells the terminal chado works in the repo (OSC 7), so
+// new tabs open there.
+func (m *Model) announceDirectory() tea.Cmd {
+	host, _ := os.Hostname() // file:///path still names the directory
+
+	return tea.Raw(ui.CurrentDirectory(host, m.workDir))
+}
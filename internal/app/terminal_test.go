@@ -0,0 +1,73 @@
+package app
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"pgregory.net/rapid"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestWindowTitle_FollowsWorkingCopy(t *testing.T) {
+	m := newTestModel(t)
+	repo := filepath.Base(m.workDir)
+
+	if got := m.windowTitle(); got != "chado: "+repo {
+		t.Errorf("before the log loads only the repo should be named, got %q", got)
+	}
+
+	m.changes = []jj.Change{{ChangeID: "aaaaaaaa"}, {ChangeID: "bbbbbbbb", IsWorkingCopy: true}}
+
+	if got := m.windowTitle(); got != "chado: "+repo+" @ bbbbbbbb" {
+		t.Errorf("the title should name the working-copy change, got %q", got)
+	}
+
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	if got := m.View().WindowTitle; got != m.windowTitle() {
+		t.Errorf("the view should set the terminal title, got %q", got)
+	}
+}
+
+func TestAnnounceDirectory(t *testing.T) {
+	m := newTestModel(t)
+
+	msg, ok := m.announceDirectory()().(tea.RawMsg)
+	if !ok {
+		t.Fatalf("expected the sequence to be written to the terminal, got %T", msg)
+	}
+
+	if seq, _ := msg.Msg.(string); !strings.HasPrefix(seq, "\x1b]7;file://") || !strings.Contains(seq, filepath.ToSlash(m.workDir)) {
+		t.Errorf("expected OSC 7 naming the repo, got %q", msg.Msg)
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: the title names whichever loaded change is @, wherever it is.
+func TestWindowTitle_NamesWorkingCopy(t *testing.T) {
+	rapid.Check(t, func(rt *rapid.T) {
+		m := newTestModel(t)
+
+		count := rapid.IntRange(1, 20).Draw(rt, "count")
+		at := rapid.IntRange(0, count-1).Draw(rt, "at")
+
+		m.changes = make([]jj.Change, count)
+		for i := range m.changes {
+			m.changes[i] = jj.Change{ChangeID: strings.Repeat(string(rune('a'+i)), 8), IsWorkingCopy: i == at}
+		}
+
+		if !strings.HasSuffix(m.windowTitle(), " @ "+m.changes[at].ChangeID) {
+			rt.Fatalf("title %q should name %s", m.windowTitle(), m.changes[at].ChangeID)
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/config"
+	"github.com/chatter/chado/internal/ui/theme"
+)
+
+// loadThemes returns the themes to cycle through and the index of the
+// configured one, whose colors are adjusted by the config file. The "auto"
+// theme starts as the default and adjusts both it and the light theme, as
+// either may be picked once the terminal background is known. It also
+// returns a description of each problem with the theme config; an unknown
+// theme name falls back to the default theme.
+func loadThemes(cfg config.ThemeConfig) ([]theme.Theme, int, []string) {
+	themes := theme.Builtin()
+
+	var problems []string
+
+	name := cfg.Name
+	adjust := []string{name}
+
+	if name == theme.AutoName {
+		name = theme.DefaultName
+		adjust = []string{theme.DefaultName, theme.LightName}
+	}
+
+	current := themeIndex(themes, name)
+	if current < 0 {
+		problems = append(problems, fmt.Sprintf("unknown theme %q", cfg.Name))
+		current = themeIndex(themes, theme.DefaultName)
+		adjust = []string{theme.DefaultName}
+	}
+
+	for i, name := range adjust {
+		index := themeIndex(themes, name)
+
+		adjusted, colorProblems := themes[index].WithColors(cfg.Colors)
+		themes[index] = adjusted
+
+		// The same overrides have the same problems in every theme
+		if i == 0 {
+			problems = append(problems, colorProblems...)
+		}
+	}
+
+	return themes, current, problems
+}
+
+// themeIndex returns the index of the theme with the given name, or -1.
+func themeIndex(themes []theme.Theme, name string) int {
+	return slices.IndexFunc(themes, func(t theme.Theme) bool { return t.Name == name })
+}
+
+// applyTheme recolors every panel and overlay in the current theme.
+func (m *Model) applyTheme() {
+	t := m.themes[m.themeIndex]
+
+	m.styles.SetTheme(t)
+	m.statusBar.SetTheme(t)
+	m.floatingHelp.SetTheme(t)
+	m.whichKey.SetTheme(t)
+	m.describeInput.SetTheme(t)
+	m.finder.SetTheme(t)
+	m.commandLine.SetTheme(t)
+	m.toasts.SetTheme(t)
+	m.confirmDialog.SetTheme(t)
+	m.errorPanel.SetTheme(t)
+
+	// Content rendered ahead of time keeps its old colors until re-rendered
+	m.logPanel.Restyle()
+	m.compareLogPanel.Restyle()
+	m.opLogPanel.Restyle()
+	m.filesPanel.Restyle()
+	m.diffPanel.Restyle()
+	m.bookmarksPanel.Restyle()
+	m.stacksPanel.Restyle()
+}
+
+// SetDarkBackground picks the default or light theme to suit the terminal
+// background when the theme is "auto"; a named theme is kept as configured.
+func (m *Model) SetDarkBackground(dark bool) {
+	if !m.themeAuto {
+		return
+	}
+
+	m.themeIndex = themeIndex(m.themes, theme.ForBackground(dark).Name)
+	m.applyTheme()
+}
+
+// actionCycleTheme switches to the next theme.
+func (m *Model) actionCycleTheme() (Model, tea.Cmd) {
+	m.themeAuto = false
+	m.themeIndex = (m.themeIndex + 1) % len(m.themes)
+	m.applyTheme()
+
+	return *m, m.toasts.Info("theme: " + m.themes[m.themeIndex].Name)
+}
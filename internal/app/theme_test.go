@@ -0,0 +1,128 @@
+package app
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/chatter/chado/internal/config"
+	"github.com/chatter/chado/internal/logger"
+	"github.com/chatter/chado/internal/ui/theme"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestLoadThemes_ConfiguredThemeWithOverrides(t *testing.T) {
+	themes, current, problems := loadThemes(config.ThemeConfig{
+		Name:   "gruvbox",
+		Colors: map[string]string{"accent": "#ff8700"},
+	})
+
+	if len(problems) != 0 {
+		t.Fatalf("unexpected problems: %v", problems)
+	}
+
+	if themes[current].Name != "gruvbox" || themes[current].Accent != "#ff8700" {
+		t.Errorf("expected gruvbox with the accent overridden, got %+v", themes[current])
+	}
+
+	if len(themes) != len(theme.Builtin()) {
+		t.Errorf("every built-in theme should stay available, got %d", len(themes))
+	}
+}
+
+func TestLoadThemes_UnknownNameFallsBack(t *testing.T) {
+	themes, current, problems := loadThemes(config.ThemeConfig{Name: "solarized"})
+
+	if themes[current].Name != theme.DefaultName {
+		t.Errorf("expected the default theme, got %q", themes[current].Name)
+	}
+
+	want := []string{`unknown theme "solarized"`}
+	if !reflect.DeepEqual(problems, want) {
+		t.Errorf("expected %q, got %q", want, problems)
+	}
+}
+
+func TestLoadThemes_AutoAdjustsBothCandidates(t *testing.T) {
+	themes, current, problems := loadThemes(config.ThemeConfig{
+		Name:   theme.AutoName,
+		Colors: map[string]string{"accent": "#ff8700", "sparkle": "1"},
+	})
+
+	if themes[current].Name != theme.DefaultName {
+		t.Errorf("auto should start with the default theme, got %q", themes[current].Name)
+	}
+
+	for _, name := range []string{theme.DefaultName, theme.LightName} {
+		if got := themes[themeIndex(themes, name)].Accent; got != "#ff8700" {
+			t.Errorf("%s: expected the accent overridden, got %q", name, got)
+		}
+	}
+
+	if len(problems) != 1 {
+		t.Errorf("each problem should be reported once, got %q", problems)
+	}
+}
+
+func TestSetDarkBackground_AutoFollowsBackground(t *testing.T) {
+	m := newTestModel(t)
+
+	m.SetDarkBackground(false)
+
+	if got := m.styles.Theme().Name; got != theme.LightName {
+		t.Errorf("expected the light theme on a light background, got %q", got)
+	}
+
+	m.SetDarkBackground(true)
+
+	if got := m.styles.Theme().Name; got != theme.DefaultName {
+		t.Errorf("expected the default theme on a dark background, got %q", got)
+	}
+}
+
+func TestSetDarkBackground_NamedThemeKept(t *testing.T) {
+	log, _ := logger.New("")
+	cfg := config.Default()
+	cfg.Theme.Name = "nord"
+	m := New(context.Background(), t.TempDir(), "test", cfg, log)
+
+	m.SetDarkBackground(false)
+
+	if got := m.styles.Theme().Name; got != "nord" {
+		t.Errorf("a named theme should not follow the background, got %q", got)
+	}
+}
+
+func TestSetDarkBackground_IgnoredAfterCycling(t *testing.T) {
+	m := newTestModel(t)
+
+	next, _ := m.actionCycleTheme()
+	*m = next
+	picked := m.styles.Theme().Name
+
+	m.SetDarkBackground(true)
+
+	if got := m.styles.Theme().Name; got != picked {
+		t.Errorf("a theme picked by hand should stay, got %q want %q", got, picked)
+	}
+}
+
+func TestCycleTheme_WrapsAndRestyles(t *testing.T) {
+	m := newTestModel(t)
+
+	for range m.themes {
+		next, _ := m.actionCycleTheme()
+		*m = next
+
+		if got, want := m.styles.Theme().Name, m.themes[m.themeIndex].Name; got != want {
+			t.Fatalf("styles should follow the theme, got %q want %q", got, want)
+		}
+	}
+
+	if m.themeIndex != 0 {
+		t.Errorf("cycling through every theme should come back to the first, got %d", m.themeIndex)
+	}
+}
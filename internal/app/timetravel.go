@@ -0,0 +1,57 @@
+package app
+
+import (
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+)
+
+// logTitle is the change log's title in the present.
+const logTitle = "Change Log"
+
+// timeTravelKey returns the time travel binding, enabled only with the op
+// log (not an evolog) focused.
+func (m *Model) timeTravelKey() key.Binding {
+	binding := m.keys.TimeTravel
+	binding.SetEnabled(m.focusedPane == PaneOpLog && m.viewMode == ViewLog)
+
+	return binding
+}
+
+// actionTimeTravel reloads the change log as it was right after the
+// operation selected in the op log. Nothing can change the repo until esc
+// returns to the present.
+func (m *Model) actionTimeTravel() (Model, tea.Cmd) {
+	if m.focusedPane != PaneOpLog || m.viewMode != ViewLog {
+		return *m, nil
+	}
+
+	op := m.opLogPanel.SelectedOperation()
+	if op == nil {
+		return *m, nil
+	}
+
+	m.travelTo(op.OpID)
+
+	return *m, tea.Batch(m.toasts.Info("showing the repo at op "+op.OpID+"; esc returns"), m.loadLog())
+}
+
+// returnToPresent leaves time travel and reloads the current log.
+func (m *Model) returnToPresent() tea.Cmd {
+	m.travelTo("")
+
+	return tea.Batch(m.toasts.Info("back in the present"), m.loadLog())
+}
+
+// travelTo shows the repo at operation opID, or in the present when empty.
+func (m *Model) travelTo(opID string) {
+	m.timeTravelOp = opID
+	m.runner.SetAtOperation(opID)
+	m.statusBar.SetTimeTravel(opID)
+
+	title := logTitle
+	if opID != "" {
+		title += " at op " + opID
+	}
+
+	m.logPanel.SetHeading(1, title)
+}
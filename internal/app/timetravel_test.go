@@ -0,0 +1,77 @@
+package app
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestTimeTravel_RequiresOpLogFocus(t *testing.T) {
+	m := newTestModel(t)
+	m.opLogPanel.SetOpLogContent("@  bbc9fee12c4d user 1 minute ago\n", []jj.Operation{{OpID: "bbc9fee12c4d"}})
+
+	if m.timeTravelKey().Enabled() {
+		t.Error("time travel should only be offered in the op log")
+	}
+
+	m.actionTimeTravel()
+
+	if m.timeTravelOp != "" {
+		t.Fatal("time travel should only start from the op log")
+	}
+
+	m.focusedPane = PaneOpLog
+	m.actionTimeTravel()
+
+	if m.timeTravelOp != "bbc9fee12c4d" {
+		t.Errorf("expected to travel to the selected operation, got %q", m.timeTravelOp)
+	}
+}
+
+func TestTimeTravel_DisablesMutationsUntilEsc(t *testing.T) {
+	m := newTestModel(t)
+	m.travelTo("bbc9fee12c4d")
+
+	for _, ab := range m.globalBindings() {
+		if ab.Mutates && ab.Key.Enabled() {
+			t.Errorf("%s should be disabled while back in time", ab.ID)
+		}
+	}
+
+	if _, cmd := dispatchKey(m, tea.KeyPressMsg{Code: tea.KeyEscape}, m.globalBindings()); cmd == nil {
+		t.Fatal("esc should reload the present log")
+	}
+
+	if m.timeTravelOp != "" {
+		t.Error("esc should return to the present")
+	}
+
+	for _, ab := range m.globalBindings() {
+		if ab.ID == "new" && !ab.Key.Enabled() {
+			t.Error("mutations should be back in the present")
+		}
+	}
+}
+
+func TestTimeTravel_DropsLogsFromAnotherTime(t *testing.T) {
+	m := newTestModel(t)
+	m.travelTo("bbc9fee12c4d")
+
+	m.handleLogLoaded(logLoadedMsg{raw: "now\n", changes: []jj.Change{{ChangeID: "now"}}})
+
+	if len(m.changes) != 0 {
+		t.Error("a log of the present should not show while back in time")
+	}
+
+	m.handleLogLoaded(logLoadedMsg{raw: "then\n", changes: []jj.Change{{ChangeID: "then"}}, atOp: "bbc9fee12c4d"})
+
+	if len(m.changes) != 1 || m.changes[0].ChangeID != "then" {
+		t.Errorf("expected the log at the operation, got %+v", m.changes)
+	}
+}
@@ -0,0 +1,224 @@
+package app
+
+import (
+	"path/filepath"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui"
+	"github.com/chatter/chado/internal/ui/help"
+)
+
+// workspacesBindingID identifies the choices of the workspace menu.
+const workspacesBindingID = "workspaces"
+
+// workspacesLoadedMsg carries the repo's workspaces for the menu.
+type workspacesLoadedMsg struct {
+	workspaces []jj.Workspace
+}
+
+// workspaceRootMsg carries where the workspace being switched to is.
+type workspaceRootMsg struct {
+	name string
+	root string
+}
+
+// workspaceCompleteMsg reports a workspace added or forgotten.
+type workspaceCompleteMsg struct {
+	summary string
+}
+
+// actionWorkspaces lists the repo's workspaces to switch to, add to, or
+// forget one of.
+func (m *Model) actionWorkspaces() (Model, tea.Cmd) {
+	runner := m.runner
+
+	return *m, func() tea.Msg {
+		workspaces, err := runner.Workspaces()
+		if err != nil {
+			return errMsg{err}
+		}
+
+		return workspacesLoadedMsg{workspaces: workspaces}
+	}
+}
+
+// currentWorkspace returns the name of the workspace chado runs in: the one
+// whose working copy is @.
+func (m *Model) currentWorkspace(workspaces []jj.Workspace) string {
+	for _, change := range m.changes {
+		if !change.IsWorkingCopy {
+			continue
+		}
+
+		for _, workspace := range workspaces {
+			if sameChange(workspace.ChangeID, change.ChangeID) {
+				return workspace.Name
+			}
+		}
+	}
+
+	return ""
+}
+
+// handleWorkspacesLoaded opens the workspace menu: switch to each other
+// workspace, then, unless the repo can't be changed, add one or forget one.
+func (m *Model) handleWorkspacesLoaded(msg workspacesLoadedMsg) tea.Cmd {
+	current := m.currentWorkspace(msg.workspaces)
+	mutable := !m.readOnly && m.timeTravelOp == ""
+
+	choice := func(keys, desc string, mutates bool, run func(m *Model) tea.Cmd) ActionBinding {
+		return ActionBinding{
+			Binding: help.Binding{Key: key.NewBinding(key.WithHelp(keys, desc))},
+			ID:      workspacesBindingID,
+			Action: func(m *Model) (Model, tea.Cmd) {
+				return *m, run(m)
+			},
+			Mutates: mutates,
+		}
+	}
+
+	var menu, forget []ActionBinding
+
+	for _, workspace := range msg.workspaces {
+		if workspace.Name == current {
+			continue
+		}
+
+		name := workspace.Name
+		menu = append(menu, choice(workspace.ChangeID+" "+workspace.Summary, "switch to "+name, false, func(m *Model) tea.Cmd {
+			return m.loadWorkspaceRoot(name)
+		}))
+		forget = append(forget, choice("jj workspace forget "+name, "forget "+name, true, func(m *Model) tea.Cmd {
+			return m.confirmWorkspaceForget(name)
+		}))
+	}
+
+	if mutable {
+		menu = append(menu, choice("jj workspace add", "add a workspace", true, (*Model).askWorkspaceAdd))
+		menu = append(menu, forget...)
+	}
+
+	if len(menu) == 0 {
+		return m.toasts.Info("no other workspaces")
+	}
+
+	title := "Workspaces"
+	if current != "" {
+		title += " (in " + current + ")"
+	}
+
+	m.palette = menu
+	m.finding = true
+	m.sizeFinder()
+
+	return m.finder.Open(title, paletteItems(m.palette))
+}
+
+// loadWorkspaceRoot asks jj where the workspace called name is, to switch
+// to it.
+func (m *Model) loadWorkspaceRoot(name string) tea.Cmd {
+	runner := m.runner
+
+	return func() tea.Msg {
+		root, err := runner.WorkspaceRoot(name)
+		if err != nil {
+			return errMsg{err}
+		}
+
+		return workspaceRootMsg{name: name, root: root}
+	}
+}
+
+// switchWorkspace moves chado to the workspace at msg.root: jj runs and the
+// watcher watches there, and everything reloads with its @ selected.
+func (m *Model) switchWorkspace(msg workspaceRootMsg) tea.Cmd {
+	if m.watcher != nil {
+		m.watcher.Close()
+		m.watcher = nil
+	}
+
+	m.log.Info("switching workspace", "name", msg.name, "root", msg.root)
+	m.workDir = msg.root
+	m.runner = m.runner.WithWorkDir(msg.root)
+
+	if m.fileLinks {
+		m.filesPanel.SetFileRoot(msg.root)
+	}
+
+	m.diffPanel.ClosePreview()
+	m.viewMode = ViewLog
+	m.focusedPane = PaneLog
+	m.updatePanelFocus()
+	m.restoreChangeID = ""
+	m.followWorkingCopy = true
+
+	return tea.Batch(
+		m.toasts.Success("switched to workspace "+msg.name),
+		m.refresh(),
+		m.startWatcher(),
+		m.announceDirectory(),
+	)
+}
+
+// askWorkspaceAdd asks where to add a workspace.
+func (m *Model) askWorkspaceAdd() tea.Cmd {
+	m.commanding = true
+	m.promptAnswer = (*Model).runWorkspaceAdd
+	m.sizeCommandLine()
+
+	return m.commandLine.Ask("Add a workspace", "path: ", "../"+filepath.Base(m.workDir)+"-feature")
+}
+
+// runWorkspaceAdd executes jj workspace add.
+func (m *Model) runWorkspaceAdd(path string) tea.Cmd {
+	runner := m.runner
+
+	return m.startJob("jj workspace add "+path, func() tea.Msg {
+		if err := runner.WorkspaceAdd(path); err != nil {
+			return errMsg{err}
+		}
+
+		return workspaceCompleteMsg{summary: "added workspace at " + path}
+	})
+}
+
+// confirmWorkspaceForget asks before forgetting the workspace called name.
+func (m *Model) confirmWorkspaceForget(name string) tea.Cmd {
+	runner := m.runner
+
+	m.askConfirm(
+		"Forget workspace "+name+"?",
+		"jj stops tracking workspace "+name+". Its files stay on disk.",
+		"forget",
+		"",
+		confirmation{
+			onYes: func() tea.Cmd {
+				return m.startJob("jj workspace forget "+name, func() tea.Msg {
+					if err := runner.WorkspaceForget(name); err != nil {
+						return errMsg{err}
+					}
+
+					return workspaceCompleteMsg{summary: "forgot workspace " + name}
+				})
+			},
+		},
+	)
+
+	return nil
+}
+
+// handlePromptSubmit passes the answer to the question the prompt asked.
+func (m *Model) handlePromptSubmit(msg ui.PromptSubmitMsg) tea.Cmd {
+	m.commanding = false
+	answer := m.promptAnswer
+	m.promptAnswer = nil
+
+	if answer == nil {
+		return nil
+	}
+
+	return answer(m, msg.Value)
+}
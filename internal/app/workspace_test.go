@@ -0,0 +1,141 @@
+package app
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui"
+)
+
+// workspaceTestModel returns a model in workspace default, whose @ is
+// aaaaaaaa, with the menu of the default and feature workspaces open.
+func workspaceTestModel(t *testing.T, readOnly bool) *Model {
+	t.Helper()
+
+	m := newTestModel(t)
+	m.readOnly = readOnly
+	m.changes = []jj.Change{{ChangeID: "aaaaaaaa", IsWorkingCopy: true}}
+	m.logPanel.SetContent("@ aaaaaaaa\n", m.changes)
+
+	m.handleWorkspacesLoaded(workspacesLoadedMsg{workspaces: []jj.Workspace{
+		{Name: "default", ChangeID: "aaaa", CommitID: "11111111", Summary: "(empty) (no description set)"},
+		{Name: "feature", ChangeID: "zzzz", CommitID: "22222222", Summary: "parser: handle tabs"},
+	}})
+
+	return m
+}
+
+// menuLabels returns the labels of the open menu's choices.
+func menuLabels(m *Model) []string {
+	var labels []string
+	for _, item := range paletteItems(m.palette) {
+		labels = append(labels, item.Label)
+	}
+
+	return labels
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestWorkspaces_MenuOffersOtherWorkspaces(t *testing.T) {
+	m := workspaceTestModel(t, false)
+
+	want := []string{"switch to feature", "add a workspace", "forget feature"}
+	if got := menuLabels(m); !m.finding || !slices.Equal(got, want) {
+		t.Errorf("menu = %v, want %v", got, want)
+	}
+}
+
+func TestWorkspaces_ReadOnlyOnlySwitches(t *testing.T) {
+	m := workspaceTestModel(t, true)
+
+	want := []string{"switch to feature"}
+	if got := menuLabels(m); !slices.Equal(got, want) {
+		t.Errorf("menu = %v, want %v", got, want)
+	}
+}
+
+func TestWorkspaces_NoOtherWorkspaces(t *testing.T) {
+	m := newTestModel(t)
+	m.readOnly = true
+
+	m.handleWorkspacesLoaded(workspacesLoadedMsg{})
+
+	if m.finding || len(m.toasts.Items()) != 1 {
+		t.Error("an empty menu should be reported rather than opened")
+	}
+}
+
+func TestSwitchWorkspace_RunsThere(t *testing.T) {
+	m := newTestModel(t)
+	m.viewMode = ViewFiles
+	root := t.TempDir()
+
+	m.switchWorkspace(workspaceRootMsg{name: "feature", root: root})
+
+	if m.WorkDir() != root || !m.followWorkingCopy {
+		t.Errorf("workDir = %q, followWorkingCopy = %v; want %q and true", m.WorkDir(), m.followWorkingCopy, root)
+	}
+
+	if m.viewMode != ViewLog || m.focusedPane != PaneLog {
+		t.Error("switching workspace should go back to the log")
+	}
+}
+
+// Run with -race: commands built before a switch read the runner they
+// were built with, while the switch replaces the model's.
+func TestSwitchWorkspace_WhileJobRuns(t *testing.T) {
+	m := newTestModel(t)
+	job := m.runDescribe("aaaaaaaa", "parser: handle tabs")
+	load := m.loadBookmarks()
+
+	var wg sync.WaitGroup
+
+	wg.Go(func() {
+		for _, cmd := range job().(tea.BatchMsg) {
+			if _, ok := cmd().(jobDoneMsg); ok {
+				break
+			}
+		}
+	})
+	wg.Go(func() { load() })
+
+	m.switchWorkspace(workspaceRootMsg{name: "feature", root: t.TempDir()})
+	wg.Wait()
+}
+
+func TestWorkspaceAdd_AsksForPath(t *testing.T) {
+	m := workspaceTestModel(t, false)
+
+	m.handlePaletteSelect(ui.FinderSelectMsg{Index: 1})
+
+	if !m.commanding || m.promptAnswer == nil {
+		t.Fatal("adding a workspace should ask where")
+	}
+
+	m.handlePromptSubmit(ui.PromptSubmitMsg{Value: "../feature-2"})
+
+	if m.commanding || m.promptAnswer != nil {
+		t.Error("the prompt should close once answered")
+	}
+
+	if !m.jobs.busy() || m.jobs.running[0].label != "jj workspace add ../feature-2" {
+		t.Error("the answer should run jj workspace add")
+	}
+}
+
+func TestWorkspaceForget_Confirms(t *testing.T) {
+	m := workspaceTestModel(t, false)
+
+	m.handlePaletteSelect(ui.FinderSelectMsg{Index: 2})
+
+	if m.jobs.busy() || !m.confirming {
+		t.Error("forgetting a workspace should ask first")
+	}
+}
@@ -0,0 +1,62 @@
+// Package clipboard copies text to the system clipboard. The terminal is
+// asked to set it with an OSC 52 escape sequence, which also works over SSH,
+// and a platform copy tool is run as well where the terminal ignores it.
+package clipboard
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// ErrNoTool is returned by Write when none of the copy tools is installed.
+var ErrNoTool = errors.New("no clipboard tool found (pbcopy, wl-copy, xclip)")
+
+// tool is a command that copies its standard input to the clipboard.
+type tool struct {
+	name string
+	args []string
+}
+
+// tools are tried in order: macOS, Wayland, then X11.
+var tools = []tool{
+	{name: "pbcopy"},
+	{name: "wl-copy"},
+	{name: "xclip", args: []string{"-selection", "clipboard"}},
+}
+
+// Copy sets the clipboard to text through the terminal and the first
+// installed copy tool. The tool's result is passed to done, whose message is
+// returned to the program, as with tea.ExecProcess.
+func Copy(text string, done func(error) tea.Msg) tea.Cmd {
+	return tea.Batch(
+		tea.SetClipboard(text),
+		func() tea.Msg {
+			return done(Write(text))
+		},
+	)
+}
+
+// Write copies text with the first installed copy tool.
+func Write(text string) error {
+	for _, t := range tools {
+		path, err := exec.LookPath(t.name)
+		if err != nil {
+			continue
+		}
+
+		cmd := exec.Command(path, t.args...)
+		cmd.Stdin = strings.NewReader(text)
+
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("%s: %w: %s", t.name, err, strings.TrimSpace(string(out)))
+		}
+
+		return nil
+	}
+
+	return ErrNoTool
+}
@@ -0,0 +1,95 @@
+package clipboard
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// installTool puts a script named name on an otherwise empty PATH.
+func installTool(t *testing.T, name, script string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatalf("writing %s: %v", name, err)
+	}
+
+	t.Setenv("PATH", dir)
+}
+
+// installSavingTool installs a tool that saves its input to the returned file.
+func installSavingTool(t *testing.T, name string) string {
+	t.Helper()
+
+	cat, err := exec.LookPath("cat")
+	if err != nil {
+		t.Skip("cat is not installed")
+	}
+
+	out := filepath.Join(t.TempDir(), "copied")
+	installTool(t, name, cat+" > '"+out+"'\n")
+
+	return out
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestWrite_NoTool(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	if err := Write("abc"); !errors.Is(err, ErrNoTool) {
+		t.Errorf("expected ErrNoTool, got %v", err)
+	}
+}
+
+func TestWrite_UsesInstalledTool(t *testing.T) {
+	for _, name := range []string{"pbcopy", "wl-copy", "xclip"} {
+		t.Run(name, func(t *testing.T) {
+			out := installSavingTool(t, name)
+
+			if err := Write("xsssnyux"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got, _ := os.ReadFile(out); string(got) != "xsssnyux" {
+				t.Errorf("expected the text on the tool's input, got %q", got)
+			}
+		})
+	}
+}
+
+func TestWrite_ToolFails(t *testing.T) {
+	installTool(t, "xclip", "echo no display >&2\nexit 1\n")
+
+	err := Write("abc")
+	if err == nil || errors.Is(err, ErrNoTool) {
+		t.Fatalf("expected the tool's failure, got %v", err)
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+func TestWrite_TextUnchanged(t *testing.T) {
+	out := installSavingTool(t, "pbcopy")
+
+	rapid.Check(t, func(t *rapid.T) {
+		text := rapid.String().Draw(t, "text")
+
+		if err := Write(text); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got, _ := os.ReadFile(out); string(got) != text {
+			t.Fatalf("expected %q, got %q", text, got)
+		}
+	})
+}
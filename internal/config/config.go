@@ -0,0 +1,427 @@
+// Package config loads user preferences from a TOML file in the XDG config directory.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// fileName is the config file name inside the chado config directory.
+const fileName = "config.toml"
+
+// errKeyNotString is returned for a key binding that is not a key name.
+var errKeyNotString = errors.New("keys must be strings")
+
+// Config holds user preferences. Zero-value fields fall back to Default().
+type Config struct {
+	Hints    HintsConfig    `toml:"hints"`
+	Log      LogConfig      `toml:"log"`
+	OpLog    OpLogConfig    `toml:"op_log"`
+	Diff     DiffConfig     `toml:"diff"`
+	Files    FilesConfig    `toml:"files"`
+	Stack    StackConfig    `toml:"stack"`
+	Describe DescribeConfig `toml:"describe"`
+	Open     OpenConfig     `toml:"open"`
+	Theme    ThemeConfig    `toml:"theme"`
+	Watch    WatchConfig    `toml:"watch"`
+	Links    LinksConfig    `toml:"links"`
+	Forge    ForgeConfig    `toml:"forge"`
+	Fetch    FetchConfig    `toml:"fetch"`
+
+	// ReadOnly disables every action that changes the repository.
+	ReadOnly bool `toml:"read_only"`
+
+	// ReducedMotion turns animations off: the focus border shows at once
+	// instead of wrapping around the panel, and the job spinner stands still.
+	ReducedMotion bool `toml:"reduced_motion"`
+
+	// NoColor draws without colors, as the NO_COLOR environment variable
+	// asks: jj's output comes uncolored, and focus and the cursor show in
+	// reverse video and ASCII markers.
+	NoColor bool `toml:"no_color"`
+
+	// Linear shows one panel at a time as plain text for screen readers:
+	// no borders, a heading naming the panel, and a line saying what is
+	// under the cursor.
+	Linear bool `toml:"linear"`
+
+	// Keys remaps actions, by binding ID (e.g. "describe"), to other keys.
+	Keys map[string]KeyList `toml:"keys"`
+}
+
+// KeyList is the keys bound to one action, in bubbletea's key names (e.g.
+// "D", "ctrl+d", "f2"). A single key may be given as a plain string.
+type KeyList []string
+
+// UnmarshalTOML accepts a string or an array of strings.
+func (k *KeyList) UnmarshalTOML(value any) error {
+	switch value := value.(type) {
+	case string:
+		*k = KeyList{value}
+		return nil
+	case []any:
+		keys := make(KeyList, len(value))
+
+		for i, item := range value {
+			name, ok := item.(string)
+			if !ok {
+				return fmt.Errorf("%w: %v", errKeyNotString, item)
+			}
+
+			keys[i] = name
+		}
+
+		*k = keys
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: %v", errKeyNotString, value)
+}
+
+// HintsConfig controls the occasional keybinding tips in the status bar.
+type HintsConfig struct {
+	Enabled bool `toml:"enabled"`
+}
+
+// LogConfig controls which revisions the log shows and how many it loads.
+type LogConfig struct {
+	// Revset selects the revisions shown (e.g. "@ | ancestors(trunk()..@, 50)");
+	// empty uses jj's revsets.log setting.
+	Revset string `toml:"revset"`
+
+	// Template renders each change in the log: a jj built-in such as
+	// "builtin_log_detailed", or a template of one's own; empty uses jj's
+	// templates.log setting.
+	Template string `toml:"template"`
+
+	// PageSize is how many changes the log loads at first, and how many more
+	// it loads each time the cursor nears the end; zero loads the whole log.
+	PageSize int `toml:"page_size"`
+}
+
+// OpLogConfig controls how much of the operation log loads and what it shows.
+type OpLogConfig struct {
+	// PageSize is how many operations the op log loads at first, and how
+	// many more it loads each time the cursor nears the end; zero loads the
+	// whole op log.
+	PageSize int `toml:"page_size"`
+
+	// HideSnapshots leaves out the "snapshot working copy" operations jj
+	// records before nearly every command.
+	HideSnapshots bool `toml:"hide_snapshots"`
+}
+
+// DiffConfig controls how the diff pane follows the cursor and renders diffs.
+type DiffConfig struct {
+	// Debounce is how long the cursor must rest before the diff loads
+	// (e.g. "150ms"); zero loads on every move.
+	Debounce time.Duration `toml:"debounce"`
+
+	// Format is the diff format: "color-words" (changed words colored
+	// inline), "git" (unified diff with changed words emphasized), or
+	// "stat" (lines changed per file); empty follows jj's ui.diff-formatter.
+	Format string `toml:"format"`
+
+	// SyntaxHighlight colors file content by language under jj's own colors.
+	SyntaxHighlight bool `toml:"syntax_highlight"`
+
+	// LineNumbers shows the old and new line numbers jj puts in front of
+	// each diff line; false hides them for the room.
+	LineNumbers bool `toml:"line_numbers"`
+
+	// Wrap wraps long diff lines to the pane's width; false leaves them
+	// running past it, for scrolling sideways.
+	Wrap bool `toml:"wrap"`
+
+	// SyntaxMaxLines turns highlighting off for diffs longer than this;
+	// zero highlights diffs of any size.
+	SyntaxMaxLines int `toml:"syntax_max_lines"`
+
+	// Tool is the external diff tool (e.g. "difft", "meld"), by its name in
+	// jj's merge-tools config; empty uses jj's ui.diff-formatter.
+	Tool string `toml:"tool"`
+}
+
+// FilesConfig controls the files list of a change.
+type FilesConfig struct {
+	// Tree groups files under collapsible directories instead of listing
+	// full paths.
+	Tree bool `toml:"tree"`
+}
+
+// StackConfig controls moving the working copy up and down the stack.
+type StackConfig struct {
+	// Edit makes next and previous edit the child or parent of @ (jj next
+	// --edit); otherwise they start a new change on it.
+	Edit bool `toml:"edit"`
+}
+
+// DescribeConfig sets up the describe and commit input's helpers for
+// conventional commits.
+type DescribeConfig struct {
+	// Template is what an empty description starts as, e.g. "feat: ".
+	Template string `toml:"template"`
+
+	// Prefixes are the conventional-commit types tab cycles the subject's
+	// prefix through; empty turns cycling off.
+	Prefixes []string `toml:"prefixes"`
+
+	// SubjectLength is the subject length the counter warns past; zero
+	// hides the counter.
+	SubjectLength int `toml:"subject_length"`
+
+	// Trailers are the lines ctrl+t appends, with {name}, {email}, and
+	// {change_id} filled in from jj's user and the change.
+	Trailers []string `toml:"trailers"`
+
+	// Lint warns of an empty description, a subject past SubjectLength, or
+	// no blank line after the subject when submitting; submitting again
+	// goes ahead.
+	Lint bool `toml:"lint"`
+
+	// History is how many descriptions written in chado are remembered, in
+	// the state file, for up and down to recall; zero remembers none.
+	History int `toml:"history"`
+}
+
+// LinksConfig controls the hyperlinks chado draws (OSC 8), which terminals
+// that support them make clickable.
+type LinksConfig struct {
+	// Commits links the commit IDs in the log to their page on the forge,
+	// found from the git remote (origin first).
+	Commits bool `toml:"commits"`
+
+	// CommitURL is the commit page to link to when the remote is not on a
+	// forge chado knows, {commit_id} standing for the commit (e.g.
+	// "https://git.example.com/chado/commit/{commit_id}").
+	CommitURL string `toml:"commit_url"`
+
+	// Files links the file names in a change's files to the files on disk.
+	Files bool `toml:"files"`
+}
+
+// ForgeConfig controls asking the forge about pull requests, to show their
+// state beside the bookmarks they were opened from.
+type ForgeConfig struct {
+	// GitHub looks up the pull requests of the bookmarks in the GitHub repo
+	// of the git remote (origin first), with their CI checks.
+	GitHub bool `toml:"github"`
+
+	// TokenEnv names the environment variable holding the API token; without
+	// a token only public repos can be read, at a lower rate limit.
+	TokenEnv string `toml:"token_env"`
+
+	// Refresh is how long answers are reused before the forge is asked
+	// again (e.g. "2m").
+	Refresh time.Duration `toml:"refresh"`
+}
+
+// FetchConfig controls fetching from the git remotes in the background.
+type FetchConfig struct {
+	// Interval is how often jj git fetch runs while chado is open (e.g.
+	// "10m"), keeping remote bookmarks current; zero never fetches.
+	Interval time.Duration `toml:"interval"`
+}
+
+// WatchConfig controls how chado notices changes to the repo.
+type WatchConfig struct {
+	// Mode is what is watched: "tree" (every directory of the working copy
+	// that is not ignored), "op-heads" (only jj operations, for big repos
+	// where watching the tree uses too many inotify watches), or
+	// "op-heads+root" (operations and the top directory of the working copy).
+	Mode string `toml:"mode"`
+
+	// Debounce is how long chado waits after a change for more before it
+	// refreshes (e.g. "300ms"), so a burst of writes refreshes once.
+	Debounce time.Duration `toml:"debounce"`
+
+	// Ignore lists gitignore-style patterns (e.g. "*.swp") of files whose
+	// changes are not worth a refresh, on top of what .gitignore ignores.
+	Ignore []string `toml:"ignore"`
+}
+
+// ThemeConfig picks the color theme and adjusts its colors.
+type ThemeConfig struct {
+	// Name is a built-in theme: "default", "light", "nord", or "gruvbox";
+	// "auto" picks default or light to suit the terminal background.
+	Name string `toml:"name"`
+
+	// Colors overrides the theme's colors by name (e.g. accent = "#ff8700"),
+	// each an ANSI 256 color number or a hex color.
+	Colors map[string]string `toml:"colors"`
+}
+
+// OpenConfig holds the commands for opening a directory outside chado, per
+// platform (runtime.GOOS), since the same config file may be shared between
+// machines.
+type OpenConfig struct {
+	Linux   OpenCommands `toml:"linux"`
+	Darwin  OpenCommands `toml:"darwin"`
+	Windows OpenCommands `toml:"windows"`
+}
+
+// OpenCommands are command templates; "{dir}" in an argument is replaced
+// with the directory being opened, and "{url}" with the page.
+type OpenCommands struct {
+	// FileManager opens a directory in the platform file manager.
+	FileManager string `toml:"file_manager"`
+
+	// Shell is started in the directory while chado is suspended;
+	// empty uses $SHELL.
+	Shell string `toml:"shell"`
+
+	// Browser opens a web page; "{url}" is replaced with its address.
+	Browser string `toml:"browser"`
+}
+
+// Commands returns the open commands for a platform. Platforms without their
+// own entry use the Linux ones (xdg-open works on most other Unixes).
+func (c OpenConfig) Commands(goos string) OpenCommands {
+	switch goos {
+	case "darwin":
+		return c.Darwin
+	case "windows":
+		return c.Windows
+	}
+
+	return c.Linux
+}
+
+const (
+	// defaultLogPageSize keeps startup fast in repos with long histories.
+	defaultLogPageSize = 200
+
+	// defaultOpLogPageSize keeps startup fast in repos with long op histories.
+	defaultOpLogPageSize = 200
+
+	// defaultDiffDebounce keeps fast scrolling from spawning a jj call per row.
+	defaultDiffDebounce = 150 * time.Millisecond
+
+	// defaultSyntaxMaxLines keeps highlighting from stalling on huge diffs.
+	defaultSyntaxMaxLines = 5000
+
+	// defaultThemeName follows the terminal's light or dark background.
+	defaultThemeName = "auto"
+
+	// defaultSubjectLength is the subject length git's conventions ask
+	// descriptions to keep to.
+	defaultSubjectLength = 50
+
+	// defaultDescribeHistory is how many descriptions are remembered.
+	defaultDescribeHistory = 50
+
+	// defaultWatchMode shows edits as they are saved.
+	defaultWatchMode = "tree"
+
+	// defaultWatchDebounce lets a save or a jj command finish writing before
+	// the refresh.
+	defaultWatchDebounce = 300 * time.Millisecond
+
+	// defaultForgeTokenEnv is where the gh CLI and GitHub Actions put a token.
+	defaultForgeTokenEnv = "GITHUB_TOKEN"
+
+	// defaultForgeRefresh keeps well within GitHub's rate limit while
+	// showing a finished CI run soon enough.
+	defaultForgeRefresh = 2 * time.Minute
+)
+
+// defaultWatchIgnore is the editor swap, backup, and temp files that come
+// and go while editing.
+func defaultWatchIgnore() []string {
+	return []string{"*.swp", "*~", "*.tmp"}
+}
+
+// defaultDescribePrefixes is the conventional-commit types most projects use.
+func defaultDescribePrefixes() []string {
+	return []string{"feat", "fix", "chore", "docs", "refactor", "test"}
+}
+
+// Default returns the configuration used when no config file exists.
+func Default() Config {
+	return Config{
+		Hints: HintsConfig{Enabled: true},
+		Log:   LogConfig{PageSize: defaultLogPageSize},
+		OpLog: OpLogConfig{PageSize: defaultOpLogPageSize},
+		Diff: DiffConfig{
+			Debounce:        defaultDiffDebounce,
+			SyntaxHighlight: true,
+			LineNumbers:     true,
+			Wrap:            true,
+			SyntaxMaxLines:  defaultSyntaxMaxLines,
+		},
+		Stack: StackConfig{Edit: true},
+		Describe: DescribeConfig{
+			Prefixes:      defaultDescribePrefixes(),
+			SubjectLength: defaultSubjectLength,
+			Trailers:      []string{"Signed-off-by: {name} <{email}>"},
+			Lint:          true,
+			History:       defaultDescribeHistory,
+		},
+		Theme: ThemeConfig{Name: defaultThemeName},
+		Links: LinksConfig{Commits: true, Files: true},
+		Forge: ForgeConfig{TokenEnv: defaultForgeTokenEnv, Refresh: defaultForgeRefresh},
+		Watch: WatchConfig{
+			Mode:     defaultWatchMode,
+			Debounce: defaultWatchDebounce,
+			Ignore:   defaultWatchIgnore(),
+		},
+		Open: OpenConfig{
+			Linux:  OpenCommands{FileManager: "xdg-open {dir}", Browser: "xdg-open {url}"},
+			Darwin: OpenCommands{FileManager: "open {dir}", Browser: "open {url}"},
+			Windows: OpenCommands{
+				FileManager: "explorer {dir}",
+				Shell:       "cmd",
+				Browser:     "rundll32 url.dll,FileProtocolHandler {url}",
+			},
+		},
+	}
+}
+
+// Path returns the config file location: $XDG_CONFIG_HOME/chado/config.toml,
+// falling back to ~/.config/chado/config.toml.
+func Path() (string, error) {
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("could not determine home directory: %w", err)
+		}
+
+		configDir = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(configDir, "chado", fileName), nil
+}
+
+// Load reads the config file from Path. A missing file is not an error.
+func Load() (Config, error) {
+	path, err := Path()
+	if err != nil {
+		return Default(), err
+	}
+
+	return LoadFile(path)
+}
+
+// LoadFile reads config from path, layering it over Default(). A missing file
+// yields the defaults; a malformed one yields the defaults and an error.
+func LoadFile(path string) (Config, error) {
+	cfg := Default()
+
+	if _, err := toml.DecodeFile(path, &cfg); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return Default(), nil
+		}
+
+		return Default(), fmt.Errorf("reading config %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
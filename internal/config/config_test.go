@@ -0,0 +1,454 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+
+	return path
+}
+
+func TestLoadFile_MissingUsesDefaults(t *testing.T) {
+	cfg, err := LoadFile(filepath.Join(t.TempDir(), "missing.toml"))
+	if err != nil {
+		t.Fatalf("missing file should not error: %v", err)
+	}
+
+	if !reflect.DeepEqual(cfg, Default()) {
+		t.Errorf("expected defaults, got %+v", cfg)
+	}
+}
+
+func TestLoadFile_OverridesDefaults(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "[hints]\nenabled = false\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Hints.Enabled {
+		t.Error("hints should be disabled by config")
+	}
+}
+
+func TestLoadFile_MalformedReturnsDefaultsAndError(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "[hints\nenabled = ="))
+	if err == nil {
+		t.Fatal("expected an error for malformed config")
+	}
+
+	if !reflect.DeepEqual(cfg, Default()) {
+		t.Errorf("expected defaults on error, got %+v", cfg)
+	}
+}
+
+func TestPath_UsesXDGConfigHome(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	path, err := Path()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := filepath.Join(dir, "chado", "config.toml"); path != want {
+		t.Errorf("Path() = %q, want %q", path, want)
+	}
+}
+
+func TestLoadFile_LogPageSize(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "[log]\npage_size = 0\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Log.PageSize != 0 {
+		t.Errorf("expected the whole log to load, got page size %d", cfg.Log.PageSize)
+	}
+
+	if Default().Log.PageSize != defaultLogPageSize {
+		t.Errorf("by default the log should load in pages of %d", defaultLogPageSize)
+	}
+}
+
+func TestLoadFile_OpLog(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "[op_log]\npage_size = 0\nhide_snapshots = true\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.OpLog.PageSize != 0 || !cfg.OpLog.HideSnapshots {
+		t.Errorf("expected the whole op log without snapshots, got %+v", cfg.OpLog)
+	}
+
+	if Default().OpLog.PageSize != defaultOpLogPageSize || Default().OpLog.HideSnapshots {
+		t.Errorf("by default the op log should load in pages of %d, snapshots shown", defaultOpLogPageSize)
+	}
+}
+
+func TestLoadFile_ReadOnly(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "read_only = true\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !cfg.ReadOnly {
+		t.Error("read-only mode should be enabled by config")
+	}
+
+	if Default().ReadOnly {
+		t.Error("by default actions that change the repo should be enabled")
+	}
+}
+
+func TestLoadFile_WatchMode(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "[watch]\nmode = \"op-heads\"\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Watch.Mode != "op-heads" {
+		t.Errorf("expected the configured watch mode, got %q", cfg.Watch.Mode)
+	}
+
+	if Default().Watch.Mode != defaultWatchMode {
+		t.Errorf("by default the whole tree should be watched, got %q", Default().Watch.Mode)
+	}
+}
+
+func TestLoadFile_Forge(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "[forge]\ngithub = true\nrefresh = \"5m\"\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !cfg.Forge.GitHub || cfg.Forge.Refresh != 5*time.Minute {
+		t.Errorf("expected GitHub lookups every 5m, got %+v", cfg.Forge)
+	}
+
+	if cfg.Forge.TokenEnv != defaultForgeTokenEnv {
+		t.Errorf("unset forge keys should keep their defaults, got %+v", cfg.Forge)
+	}
+
+	if Default().Forge.GitHub {
+		t.Error("by default chado should not call out to the forge")
+	}
+}
+
+func TestLoadFile_FetchInterval(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "[fetch]\ninterval = \"10m\"\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Fetch.Interval != 10*time.Minute {
+		t.Errorf("expected a fetch every 10m, got %v", cfg.Fetch.Interval)
+	}
+
+	if Default().Fetch.Interval != 0 {
+		t.Error("by default chado should not fetch on its own")
+	}
+}
+
+func TestLoadFile_WatchDebounceAndIgnore(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "[watch]\ndebounce = \"1s\"\nignore = [\"*.bak\"]\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Watch.Debounce != time.Second {
+		t.Errorf("expected a 1s debounce, got %v", cfg.Watch.Debounce)
+	}
+
+	if !slices.Equal(cfg.Watch.Ignore, []string{"*.bak"}) {
+		t.Errorf("the configured patterns should replace the defaults, got %v", cfg.Watch.Ignore)
+	}
+
+	if cfg.Watch.Mode != defaultWatchMode {
+		t.Errorf("unset watch keys should keep their defaults, got %+v", cfg.Watch)
+	}
+
+	if !slices.Contains(Default().Watch.Ignore, "*.swp") {
+		t.Errorf("editor swap files should be ignored by default, got %v", Default().Watch.Ignore)
+	}
+}
+
+func TestLoadFile_LogRevset(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "[log]\nrevset = \"trunk()..@\"\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Log.Revset != "trunk()..@" {
+		t.Errorf("expected the configured revset, got %q", cfg.Log.Revset)
+	}
+
+	if cfg.Log.PageSize != defaultLogPageSize {
+		t.Errorf("unset log keys should keep their defaults, got %+v", cfg.Log)
+	}
+}
+
+func TestLoadFile_LogTemplate(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "[log]\ntemplate = \"builtin_log_comfortable\"\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Log.Template != "builtin_log_comfortable" {
+		t.Errorf("expected the configured template, got %q", cfg.Log.Template)
+	}
+}
+
+func TestLoadFile_DiffDebounce(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "[diff]\ndebounce = \"40ms\"\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Diff.Debounce != 40*time.Millisecond {
+		t.Errorf("expected 40ms debounce, got %v", cfg.Diff.Debounce)
+	}
+
+	if !cfg.Hints.Enabled {
+		t.Error("unset sections should keep their defaults")
+	}
+}
+
+func TestLoadFile_DiffDebounceMalformed(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "[diff]\ndebounce = \"soon\"\n"))
+	if err == nil {
+		t.Fatal("expected an error for an unparseable duration")
+	}
+
+	if !reflect.DeepEqual(cfg, Default()) {
+		t.Errorf("expected defaults, got %+v", cfg)
+	}
+}
+
+func TestLoadFile_SyntaxHighlight(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "[diff]\nsyntax_highlight = false\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Diff.SyntaxHighlight {
+		t.Error("syntax highlighting should be disabled by config")
+	}
+
+	if cfg.Diff.SyntaxMaxLines != defaultSyntaxMaxLines || cfg.Diff.Debounce != defaultDiffDebounce {
+		t.Errorf("unset diff keys should keep their defaults, got %+v", cfg.Diff)
+	}
+}
+
+func TestLoadFile_LineNumbers(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "[diff]\nline_numbers = false\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Diff.LineNumbers {
+		t.Error("line numbers should be hidden by config")
+	}
+
+	if !Default().Diff.LineNumbers {
+		t.Error("line numbers should show by default")
+	}
+}
+
+func TestLoadFile_Describe(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "[describe]\nprefixes = [\"feat\", \"fix\"]\ntemplate = \"feat: \"\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !slices.Equal(cfg.Describe.Prefixes, []string{"feat", "fix"}) || cfg.Describe.Template != "feat: " {
+		t.Errorf("describe config not read: %+v", cfg.Describe)
+	}
+
+	if cfg.Describe.SubjectLength != defaultSubjectLength || len(cfg.Describe.Trailers) != 1 || !cfg.Describe.Lint ||
+		cfg.Describe.History != defaultDescribeHistory {
+		t.Errorf("unset describe keys should keep their defaults, got %+v", cfg.Describe)
+	}
+}
+
+func TestLoadFile_ReducedMotion(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "reduced_motion = true\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !cfg.ReducedMotion {
+		t.Error("reduced motion should be turned on by config")
+	}
+
+	if Default().ReducedMotion {
+		t.Error("animations should be on by default")
+	}
+}
+
+func TestLoadFile_NoColor(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "no_color = true\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !cfg.NoColor {
+		t.Error("colors should be turned off by config")
+	}
+
+	if Default().NoColor {
+		t.Error("colors should be on by default")
+	}
+}
+
+func TestLoadFile_Linear(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "linear = true\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !cfg.Linear {
+		t.Error("linear mode should be turned on by config")
+	}
+
+	if Default().Linear {
+		t.Error("the panels should be laid out by default")
+	}
+}
+
+func TestLoadFile_Links(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "[links]\nfiles = false\ncommit_url = \"https://git.example.com/c/{commit_id}\"\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Links.Files || !cfg.Links.Commits {
+		t.Errorf("only file links should be turned off, got %+v", cfg.Links)
+	}
+
+	if cfg.Links.CommitURL != "https://git.example.com/c/{commit_id}" {
+		t.Errorf("expected the commit URL kept, got %q", cfg.Links.CommitURL)
+	}
+
+	if !Default().Links.Commits || !Default().Links.Files {
+		t.Error("links should be on by default")
+	}
+}
+
+func TestLoadFile_Wrap(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "[diff]\nwrap = false\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Diff.Wrap {
+		t.Error("wrapping should be turned off by config")
+	}
+
+	if !Default().Diff.Wrap {
+		t.Error("long lines should wrap by default")
+	}
+}
+
+func TestLoadFile_DiffTool(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "[diff]\ntool = \"difft\"\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Diff.Tool != "difft" {
+		t.Errorf("expected the difft tool, got %q", cfg.Diff.Tool)
+	}
+
+	if Default().Diff.Tool != "" {
+		t.Error("by default jj's own diff formatter should be used")
+	}
+}
+
+func TestLoadFile_FilesTree(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "[files]\ntree = true\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !cfg.Files.Tree {
+		t.Error("tree mode should be enabled by config")
+	}
+}
+
+func TestLoadFile_StackEdit(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "[stack]\nedit = false\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Stack.Edit {
+		t.Error("edit should be turned off by config")
+	}
+
+	if !Default().Stack.Edit {
+		t.Error("by default next and previous should edit")
+	}
+}
+
+func TestLoadFile_OpenCommandsPerPlatform(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "[open.darwin]\nshell = \"zsh\"\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	darwin := cfg.Open.Commands("darwin")
+	if darwin.Shell != "zsh" || darwin.FileManager != Default().Open.Darwin.FileManager {
+		t.Errorf("expected overridden shell and default file manager, got %+v", darwin)
+	}
+
+	if cfg.Open.Commands("freebsd") != cfg.Open.Linux {
+		t.Error("other platforms should use the linux commands")
+	}
+}
+
+func TestLoadFile_Keys(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "[keys]\ndescribe = \"D\"\nquit = [\"q\", \"ctrl+q\"]\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]KeyList{"describe": {"D"}, "quit": {"q", "ctrl+q"}}
+	if !reflect.DeepEqual(cfg.Keys, want) {
+		t.Errorf("expected %v, got %v", want, cfg.Keys)
+	}
+}
+
+func TestLoadFile_KeysMustBeStrings(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "[keys]\ndescribe = [1]\n"))
+	if err == nil {
+		t.Fatal("expected an error for a non-string key")
+	}
+
+	if cfg.Keys != nil {
+		t.Errorf("expected defaults on error, got %v", cfg.Keys)
+	}
+}
+
+func TestLoadFile_Theme(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, "[theme]\nname = \"nord\"\n\n[theme.colors]\naccent = \"#ff8700\"\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := ThemeConfig{Name: "nord", Colors: map[string]string{"accent": "#ff8700"}}
+	if !reflect.DeepEqual(cfg.Theme, want) {
+		t.Errorf("expected %+v, got %+v", want, cfg.Theme)
+	}
+}
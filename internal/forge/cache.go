@@ -0,0 +1,57 @@
+package forge
+
+import (
+	"sync"
+	"time"
+)
+
+// cache keeps answers from the forge for a while, so refreshing chado does
+// not spend the API's rate limit asking the same thing again.
+type cache[V any] struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]cacheEntry[V]
+}
+
+// cacheEntry is an answer and when it was fetched.
+type cacheEntry[V any] struct {
+	value   V
+	fetched time.Time
+}
+
+// newCache returns a cache whose answers last ttl.
+func newCache[V any](ttl time.Duration) *cache[V] {
+	return &cache[V]{ttl: ttl, now: time.Now, entries: make(map[string]cacheEntry[V])}
+}
+
+// get returns the answer for key while it is fresh.
+func (c *cache[V]) get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || c.now().Sub(entry.fetched) >= c.ttl {
+		var zero V
+
+		return zero, false
+	}
+
+	return entry.value, true
+}
+
+// put stores the answer for key, dropping answers that have gone stale.
+func (c *cache[V]) put(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+
+	for k, entry := range c.entries {
+		if now.Sub(entry.fetched) >= c.ttl {
+			delete(c.entries, k)
+		}
+	}
+
+	c.entries[key] = cacheEntry[V]{value: value, fetched: now}
+}
@@ -0,0 +1,71 @@
+package forge
+
+import (
+	"testing"
+	"time"
+
+	"pgregory.net/rapid"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestCache_ExpiresAfterTTL(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	c := newCache[int](time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.put("chatter/chado", 7)
+
+	if got, ok := c.get("chatter/chado"); !ok || got != 7 {
+		t.Fatalf("a fresh answer should be reused, got %d, %v", got, ok)
+	}
+
+	now = now.Add(time.Minute)
+
+	if _, ok := c.get("chatter/chado"); ok {
+		t.Error("an answer as old as the TTL should be asked for again")
+	}
+}
+
+func TestCache_PutDropsStale(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	c := newCache[int](time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.put("old", 1)
+	now = now.Add(2 * time.Minute)
+	c.put("new", 2)
+
+	if len(c.entries) != 1 {
+		t.Errorf("stale answers should not pile up, got %v", c.entries)
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: an answer is reused exactly while it is younger than the TTL.
+func TestCache_FreshWithinTTL(t *testing.T) {
+	rapid.Check(t, func(rt *rapid.T) {
+		ttl := time.Duration(rapid.Int64Range(1, int64(time.Hour)).Draw(rt, "ttl"))
+		age := time.Duration(rapid.Int64Range(0, int64(2*time.Hour)).Draw(rt, "age"))
+
+		start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		now := start
+
+		c := newCache[string](ttl)
+		c.now = func() time.Time { return now }
+		c.put("key", "value")
+
+		now = start.Add(age)
+
+		if _, ok := c.get("key"); ok != (age < ttl) {
+			rt.Fatalf("age %v, ttl %v: fresh = %v", age, ttl, ok)
+		}
+	})
+}
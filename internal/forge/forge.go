@@ -0,0 +1,93 @@
+// Package forge asks the code forge hosting a repo (GitHub) about the pull
+// requests of its branches, so chado can show their state beside the
+// bookmarks that push them.
+package forge
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// scpRemoteRe matches a remote in git's scp-like syntax, e.g.
+// "git@github.com:chatter/chado.git", capturing the host and the path.
+var scpRemoteRe = regexp.MustCompile(`^(?:[^@/]+@)?([^:/]+):(.+)$`)
+
+// State is where a pull request stands.
+type State string
+
+const (
+	StateOpen   State = "open"   // Open for review
+	StateDraft  State = "draft"  // Open, but marked as not ready
+	StateMerged State = "merged" // Merged into its base
+	StateClosed State = "closed" // Closed without merging
+)
+
+// Checks sums up the CI checks run on a pull request's head commit.
+type Checks string
+
+const (
+	ChecksNone    Checks = ""        // No checks ran
+	ChecksPending Checks = "pending" // Some are still running, none failed
+	ChecksPassing Checks = "passing" // All finished and passed
+	ChecksFailing Checks = "failing" // At least one failed
+)
+
+// PullRequest is the latest pull request opened from a branch.
+type PullRequest struct {
+	Number int    // e.g. 42 for #42
+	Branch string // The head branch, i.e. the bookmark pushed
+	State  State  // Open, draft, merged, or closed
+	Checks Checks // CI on the head commit; only looked up while open
+	URL    string // The pull request's page
+}
+
+// Repo names a repository on GitHub.
+type Repo struct {
+	Owner string
+	Name  string
+}
+
+// String returns the repo as "owner/name".
+func (r Repo) String() string {
+	return r.Owner + "/" + r.Name
+}
+
+// GitHubRepo returns the GitHub repository a git remote points at, or false
+// for a remote elsewhere.
+func GitHubRepo(remote string) (Repo, bool) {
+	host, path := HostPath(remote)
+	if host != "github.com" {
+		return Repo{}, false
+	}
+
+	owner, name, ok := strings.Cut(path, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return Repo{}, false
+	}
+
+	return Repo{Owner: owner, Name: name}, true
+}
+
+// HostPath splits a git remote URL into its host and repository path,
+// without ".git". Local remotes have no host.
+func HostPath(remote string) (string, string) {
+	var host, path string
+
+	switch {
+	case strings.Contains(remote, "://"):
+		parsed, err := url.Parse(remote)
+		if err != nil || parsed.Scheme == "file" {
+			return "", ""
+		}
+
+		host, path = parsed.Hostname(), parsed.Path
+	case scpRemoteRe.MatchString(remote):
+		match := scpRemoteRe.FindStringSubmatch(remote)
+		host, path = match[1], match[2]
+	default:
+		return "", ""
+	}
+
+	return host, strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+}
@@ -0,0 +1,76 @@
+package forge
+
+import (
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestHostPath(t *testing.T) {
+	tests := []struct {
+		remote string
+		host   string
+		path   string
+	}{
+		{"git@github.com:chatter/chado.git", "github.com", "chatter/chado"},
+		{"https://gitlab.com/group/sub/chado", "gitlab.com", "group/sub/chado"},
+		{"ssh://git@git.example.com:2222/chado.git/", "git.example.com", "chado"},
+		{"file:///srv/git/chado", "", ""},
+		{"/srv/git/chado", "", ""},
+	}
+
+	for _, tt := range tests {
+		if host, path := HostPath(tt.remote); host != tt.host || path != tt.path {
+			t.Errorf("HostPath(%q) = %q, %q, want %q, %q", tt.remote, host, path, tt.host, tt.path)
+		}
+	}
+}
+
+func TestGitHubRepo(t *testing.T) {
+	tests := []struct {
+		remote string
+		want   Repo
+		ok     bool
+	}{
+		{"git@github.com:chatter/chado.git", Repo{Owner: "chatter", Name: "chado"}, true},
+		{"https://github.com/chatter/chado", Repo{Owner: "chatter", Name: "chado"}, true},
+		{"https://gitlab.com/chatter/chado", Repo{}, false},
+		{"https://github.com/chatter", Repo{}, false},
+		{"https://github.com/chatter/chado/tree/main", Repo{}, false},
+	}
+
+	for _, tt := range tests {
+		if got, ok := GitHubRepo(tt.remote); got != tt.want || ok != tt.ok {
+			t.Errorf("GitHubRepo(%q) = %+v, %v, want %+v, %v", tt.remote, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: a GitHub remote names the same repo however it is written.
+func TestGitHubRepo_SameForEverySyntax(t *testing.T) {
+	rapid.Check(t, func(rt *rapid.T) {
+		owner := rapid.StringMatching(`[a-z][a-z0-9-]{0,10}`).Draw(rt, "owner")
+		name := rapid.StringMatching(`[a-z][a-z0-9-]{0,10}`).Draw(rt, "name")
+		suffix := rapid.SampledFrom([]string{"", ".git", "/"}).Draw(rt, "suffix")
+
+		want := Repo{Owner: owner, Name: name}
+
+		for _, remote := range []string{
+			"git@github.com:" + owner + "/" + name + suffix,
+			"https://github.com/" + owner + "/" + name + suffix,
+			"ssh://git@github.com/" + owner + "/" + name + suffix,
+		} {
+			if got, ok := GitHubRepo(remote); !ok || got != want {
+				rt.Fatalf("GitHubRepo(%q) = %+v, %v, want %+v", remote, got, ok, want)
+			}
+		}
+	})
+}
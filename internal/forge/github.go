@@ -0,0 +1,245 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// gitHubAPI is where GitHub's REST API lives.
+	gitHubAPI = "https://api.github.com"
+
+	// gitHubTimeout keeps a slow or unreachable API from holding a load.
+	gitHubTimeout = 10 * time.Second
+
+	// gitHubPageSize is the most pull requests one request returns; only the
+	// most recently updated page is read, which covers the branches in play.
+	gitHubPageSize = 100
+)
+
+// errGitHubStatus is returned when the API answers with an error.
+var errGitHubStatus = errors.New("github api")
+
+// GitHub looks pull requests and their checks up through GitHub's REST API,
+// caching the answers.
+type GitHub struct {
+	baseURL string
+	token   string
+	client  *http.Client
+	pulls   *cache[[]gitHubPull] // by repo
+	checks  *cache[Checks]       // by repo and head commit
+}
+
+// NewGitHub returns a client sending token, if any (without one, only
+// public repos can be read, at a lower rate limit), whose answers are
+// reused for ttl.
+func NewGitHub(token string, ttl time.Duration) *GitHub {
+	return &GitHub{
+		baseURL: gitHubAPI,
+		token:   token,
+		client:  &http.Client{Timeout: gitHubTimeout},
+		pulls:   newCache[[]gitHubPull](ttl),
+		checks:  newCache[Checks](ttl),
+	}
+}
+
+// gitHubPull is the part of a pull request the API returns that chado reads.
+type gitHubPull struct {
+	Number   int     `json:"number"`
+	State    string  `json:"state"`
+	Draft    bool    `json:"draft"`
+	MergedAt *string `json:"merged_at"`
+	HTMLURL  string  `json:"html_url"`
+	Head     struct {
+		Ref  string `json:"ref"`
+		SHA  string `json:"sha"`
+		Repo *struct {
+			FullName string `json:"full_name"`
+		} `json:"repo"`
+	} `json:"head"`
+}
+
+// gitHubCheckRuns is the API's list of checks run on a commit.
+type gitHubCheckRuns struct {
+	CheckRuns []struct {
+		Status     string `json:"status"`
+		Conclusion string `json:"conclusion"`
+	} `json:"check_runs"`
+}
+
+// PullRequests returns the latest pull request from each of branches in
+// repo, by branch name; branches without one are left out, as are pull
+// requests from forks, whose branches are not the repo's bookmarks.
+func (g *GitHub) PullRequests(ctx context.Context, repo Repo, branches []string) (map[string]PullRequest, error) {
+	pulls, err := g.pullList(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(branches))
+	for _, branch := range branches {
+		wanted[branch] = true
+	}
+
+	prs := make(map[string]PullRequest)
+
+	for _, pull := range pulls {
+		if _, seen := prs[pull.Head.Ref]; seen || !wanted[pull.Head.Ref] || !fromRepo(pull, repo) {
+			continue
+		}
+
+		pr := PullRequest{
+			Number: pull.Number,
+			Branch: pull.Head.Ref,
+			State:  pullState(pull),
+			URL:    pull.HTMLURL,
+		}
+
+		if pr.State == StateOpen || pr.State == StateDraft {
+			checks, err := g.commitChecks(ctx, repo, pull.Head.SHA)
+			if err != nil {
+				return nil, err
+			}
+
+			pr.Checks = checks
+		}
+
+		prs[pr.Branch] = pr
+	}
+
+	return prs, nil
+}
+
+// pullList returns the most recently updated pull requests of repo, newest
+// first.
+func (g *GitHub) pullList(ctx context.Context, repo Repo) ([]gitHubPull, error) {
+	if pulls, ok := g.pulls.get(repo.String()); ok {
+		return pulls, nil
+	}
+
+	var pulls []gitHubPull
+
+	path := fmt.Sprintf("/repos/%s/pulls?state=all&sort=updated&direction=desc&per_page=%d", repo, gitHubPageSize)
+	if err := g.get(ctx, path, &pulls); err != nil {
+		return nil, err
+	}
+
+	g.pulls.put(repo.String(), pulls)
+
+	return pulls, nil
+}
+
+// commitChecks sums up the checks run on a commit of repo.
+func (g *GitHub) commitChecks(ctx context.Context, repo Repo, sha string) (Checks, error) {
+	key := repo.String() + "@" + sha
+	if checks, ok := g.checks.get(key); ok {
+		return checks, nil
+	}
+
+	var runs gitHubCheckRuns
+
+	path := fmt.Sprintf("/repos/%s/commits/%s/check-runs?per_page=%d", repo, sha, gitHubPageSize)
+	if err := g.get(ctx, path, &runs); err != nil {
+		return ChecksNone, err
+	}
+
+	conclusions := make([]string, 0, len(runs.CheckRuns))
+
+	for _, run := range runs.CheckRuns {
+		conclusion := run.Conclusion
+		if run.Status != "completed" {
+			conclusion = ""
+		}
+
+		conclusions = append(conclusions, conclusion)
+	}
+
+	checks := summarizeChecks(conclusions)
+	g.checks.put(key, checks)
+
+	return checks, nil
+}
+
+// get fetches an API path and decodes its JSON answer into out.
+func (g *GitHub) get(ctx context.Context, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("github request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-Github-Api-Version", "2022-11-28")
+
+	if g.token != "" {
+		req.Header.Set("Authorization", "Bearer "+g.token)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("github request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var body struct {
+			Message string `json:"message"`
+		}
+
+		_ = json.NewDecoder(resp.Body).Decode(&body) // the status says enough without it
+
+		return fmt.Errorf("%w: %s %s", errGitHubStatus, resp.Status, body.Message)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("github answer: %w", err)
+	}
+
+	return nil
+}
+
+// fromRepo reports whether a pull request's branch lives in repo rather
+// than in a fork (whose repo may also have been deleted).
+func fromRepo(pull gitHubPull, repo Repo) bool {
+	return pull.Head.Repo != nil && strings.EqualFold(pull.Head.Repo.FullName, repo.String())
+}
+
+// pullState tells merged pull requests from closed ones and drafts from
+// those ready for review.
+func pullState(pull gitHubPull) State {
+	switch {
+	case pull.MergedAt != nil:
+		return StateMerged
+	case pull.State == "closed":
+		return StateClosed
+	case pull.Draft:
+		return StateDraft
+	default:
+		return StateOpen
+	}
+}
+
+// summarizeChecks sums up check conclusions, "" for one still running: any
+// failure fails them all, then any still running leaves them pending.
+func summarizeChecks(conclusions []string) Checks {
+	if len(conclusions) == 0 {
+		return ChecksNone
+	}
+
+	checks := ChecksPassing
+
+	for _, conclusion := range conclusions {
+		switch conclusion {
+		case "failure", "timed_out", "cancelled", "action_required", "startup_failure":
+			return ChecksFailing
+		case "":
+			checks = ChecksPending
+		}
+	}
+
+	return checks
+}
@@ -0,0 +1,187 @@
+package forge
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"pgregory.net/rapid"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// pullsJSON is a page of pull requests: an open one with checks, a merged
+// one, an older closed one from the same branch, and one from a fork.
+const pullsJSON = `[
+  {"number": 12, "state": "open", "draft": false, "merged_at": null,
+   "html_url": "https://github.com/chatter/chado/pull/12",
+   "head": {"ref": "push-parser", "sha": "abc123", "repo": {"full_name": "chatter/chado"}}},
+  {"number": 11, "state": "closed", "draft": false, "merged_at": "2026-01-01T00:00:00Z",
+   "html_url": "https://github.com/chatter/chado/pull/11",
+   "head": {"ref": "lexer", "sha": "def456", "repo": {"full_name": "chatter/chado"}}},
+  {"number": 9, "state": "closed", "draft": false, "merged_at": null,
+   "html_url": "https://github.com/chatter/chado/pull/9",
+   "head": {"ref": "push-parser", "sha": "0ld000", "repo": {"full_name": "chatter/chado"}}},
+  {"number": 10, "state": "open", "draft": false, "merged_at": null,
+   "html_url": "https://github.com/chatter/chado/pull/10",
+   "head": {"ref": "fork-branch", "sha": "f0f0f0", "repo": {"full_name": "someone/chado"}}}
+]`
+
+// checkRunsJSON has one passing check and one still running.
+const checkRunsJSON = `{"check_runs": [
+  {"status": "completed", "conclusion": "success"},
+  {"status": "in_progress", "conclusion": null}
+]}`
+
+// newTestGitHub returns a client of a fake API, counting the requests it
+// serves.
+func newTestGitHub(t *testing.T) (*GitHub, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message": "Bad credentials"}`))
+
+			return
+		}
+
+		switch r.URL.Path {
+		case "/repos/chatter/chado/pulls":
+			_, _ = w.Write([]byte(pullsJSON))
+		case "/repos/chatter/chado/commits/abc123/check-runs":
+			_, _ = w.Write([]byte(checkRunsJSON))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewGitHub("secret", time.Minute)
+	client.baseURL = server.URL
+
+	return client, &requests
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestGitHub_PullRequests(t *testing.T) {
+	client, _ := newTestGitHub(t)
+	repo := Repo{Owner: "chatter", Name: "chado"}
+
+	prs, err := client.PullRequests(context.Background(), repo, []string{"push-parser", "lexer", "fork-branch", "main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]PullRequest{
+		"push-parser": {
+			Number: 12, Branch: "push-parser", State: StateOpen, Checks: ChecksPending,
+			URL: "https://github.com/chatter/chado/pull/12",
+		},
+		"lexer": {Number: 11, Branch: "lexer", State: StateMerged, URL: "https://github.com/chatter/chado/pull/11"},
+	}
+
+	if len(prs) != len(want) {
+		t.Fatalf("got %+v, want %+v", prs, want)
+	}
+
+	for branch, pr := range want {
+		if prs[branch] != pr {
+			t.Errorf("%s: got %+v, want %+v", branch, prs[branch], pr)
+		}
+	}
+}
+
+func TestGitHub_PullRequestsCached(t *testing.T) {
+	client, requests := newTestGitHub(t)
+	repo := Repo{Owner: "chatter", Name: "chado"}
+
+	for range 3 {
+		if _, err := client.PullRequests(context.Background(), repo, []string{"push-parser"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("the pull list and checks should each be asked for once, got %d requests", got)
+	}
+}
+
+func TestGitHub_ReportsAPIErrors(t *testing.T) {
+	client, _ := newTestGitHub(t)
+	client.token = ""
+
+	_, err := client.PullRequests(context.Background(), Repo{Owner: "chatter", Name: "chado"}, nil)
+	if !errors.Is(err, errGitHubStatus) || !strings.Contains(err.Error(), "Bad credentials") {
+		t.Errorf("expected the API's message, got %v", err)
+	}
+}
+
+func TestPullState(t *testing.T) {
+	merged := "2026-01-01T00:00:00Z"
+
+	tests := []struct {
+		name string
+		pull gitHubPull
+		want State
+	}{
+		{"open", gitHubPull{State: "open"}, StateOpen},
+		{"draft", gitHubPull{State: "open", Draft: true}, StateDraft},
+		{"merged", gitHubPull{State: "closed", MergedAt: &merged}, StateMerged},
+		{"closed", gitHubPull{State: "closed"}, StateClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pullState(tt.pull); got != tt.want {
+				t.Errorf("pullState() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: one failed check fails them all; otherwise one running leaves
+// them pending, and none running passes them.
+func TestSummarizeChecks(t *testing.T) {
+	rapid.Check(t, func(rt *rapid.T) {
+		conclusions := rapid.SliceOf(rapid.SampledFrom([]string{
+			"success", "neutral", "skipped", "", "failure", "timed_out", "cancelled",
+		})).Draw(rt, "conclusions")
+
+		want := ChecksNone
+
+		for _, conclusion := range conclusions {
+			switch {
+			case conclusion == "failure" || conclusion == "timed_out" || conclusion == "cancelled":
+				want = ChecksFailing
+			case want == ChecksFailing:
+			case conclusion == "":
+				want = ChecksPending
+			case want == ChecksNone:
+				want = ChecksPassing
+			}
+		}
+
+		if got := summarizeChecks(conclusions); got != want {
+			rt.Fatalf("summarizeChecks(%q) = %q, want %q", conclusions, got, want)
+		}
+	})
+}
@@ -0,0 +1,165 @@
+// Package fuzzy implements fzf-style subsequence matching and ranking.
+package fuzzy
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// scoreMatch is awarded for every matched character.
+	scoreMatch = 16
+
+	// bonusConsecutive rewards characters matched right after the previous one.
+	bonusConsecutive = 8
+
+	// bonusBoundary rewards matches at the start of a word or path segment.
+	bonusBoundary = 10
+
+	// bonusFirstChar rewards a match on the very first character of the target.
+	bonusFirstChar = 4
+
+	// penaltyGap is subtracted for each unmatched character inside the match span.
+	penaltyGap = 1
+)
+
+// Result is one target that matched a pattern.
+type Result struct {
+	Index     int   // Position of the target in the slice given to Filter
+	Score     int   // Higher is better
+	Positions []int // Rune offsets of the matched characters, ascending
+}
+
+// Match reports whether every rune of pattern appears in target in order and,
+// if so, how good the match is. Matching is case-insensitive unless pattern
+// contains an upper-case letter (smart case).
+func Match(pattern, target string) (int, []int, bool) {
+	if pattern == "" {
+		return 0, nil, true
+	}
+
+	caseSensitive := hasUpper(pattern)
+	needle := normalize([]rune(pattern), caseSensitive)
+	haystack := []rune(target)
+	folded := normalize(haystack, caseSensitive)
+
+	// Forward pass: earliest position where the whole pattern has matched.
+	end := -1
+
+	for idx, pos := 0, 0; pos < len(folded); pos++ {
+		if folded[pos] != needle[idx] {
+			continue
+		}
+
+		idx++
+		if idx == len(needle) {
+			end = pos
+			break
+		}
+	}
+
+	if end < 0 {
+		return 0, nil, false
+	}
+
+	// Backward pass: latest start that still matches, so the span is tight.
+	positions := make([]int, len(needle))
+	idx := len(needle) - 1
+
+	for pos := end; pos >= 0 && idx >= 0; pos-- {
+		if folded[pos] == needle[idx] {
+			positions[idx] = pos
+			idx--
+		}
+	}
+
+	return score(haystack, positions), positions, true
+}
+
+// Filter matches pattern against every target and returns the matches ranked
+// best first; ties go to the shorter target, then to the earlier one. An empty
+// pattern matches everything in the original order.
+func Filter(pattern string, targets []string) []Result {
+	results := make([]Result, 0, len(targets))
+
+	for idx, target := range targets {
+		if score, positions, ok := Match(pattern, target); ok {
+			results = append(results, Result{Index: idx, Score: score, Positions: positions})
+		}
+	}
+
+	if pattern == "" {
+		return results
+	}
+
+	slices.SortStableFunc(results, func(a, b Result) int {
+		return cmp.Or(
+			cmp.Compare(b.Score, a.Score),
+			cmp.Compare(utf8.RuneCountInString(targets[a.Index]), utf8.RuneCountInString(targets[b.Index])),
+		)
+	})
+
+	return results
+}
+
+// score rates a match from the positions of its characters in target.
+func score(target []rune, positions []int) int {
+	total := 0
+
+	for i, pos := range positions {
+		total += scoreMatch
+
+		switch {
+		case pos == 0:
+			total += bonusFirstChar + bonusBoundary
+		case isBoundary(target[pos-1], target[pos]):
+			total += bonusBoundary
+		}
+
+		if i > 0 {
+			if gap := pos - positions[i-1] - 1; gap == 0 {
+				total += bonusConsecutive
+			} else {
+				total -= gap * penaltyGap
+			}
+		}
+	}
+
+	return total
+}
+
+// isBoundary reports whether cur starts a word: after a separator, or an
+// upper-case letter following a lower-case one (camelCase).
+func isBoundary(prev, cur rune) bool {
+	if strings.ContainsRune("/\\_-. :", prev) {
+		return true
+	}
+
+	return unicode.IsLower(prev) && unicode.IsUpper(cur)
+}
+
+func normalize(runes []rune, caseSensitive bool) []rune {
+	if caseSensitive {
+		return runes
+	}
+
+	folded := make([]rune, len(runes))
+	for i, r := range runes {
+		folded[i] = unicode.ToLower(r)
+	}
+
+	return folded
+}
+
+func hasUpper(s string) bool {
+	for _, r := range s {
+		if unicode.IsUpper(r) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,124 @@
+package fuzzy
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+
+	"pgregory.net/rapid"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestMatch_Subsequence(t *testing.T) {
+	tests := []struct {
+		pattern string
+		target  string
+		want    bool
+	}{
+		{"", "anything", true},
+		{"apgo", "internal/app/app.go", true},
+		{"xyz", "internal/app/app.go", false},
+		{"App", "internal/app/app.go", false}, // smart case: upper-case pattern is exact
+		{"app", "internal/App.go", true},
+	}
+
+	for _, tt := range tests {
+		if _, _, ok := Match(tt.pattern, tt.target); ok != tt.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.pattern, tt.target, ok, tt.want)
+		}
+	}
+}
+
+func TestMatch_PrefersTightBoundaryMatch(t *testing.T) {
+	_, positions, ok := Match("diff", "internal/ui/diff.go")
+	if !ok {
+		t.Fatal("expected match")
+	}
+
+	// The backward pass should land on the "diff" segment, not scattered letters.
+	if positions[0] != strings.Index("internal/ui/diff.go", "diff") {
+		t.Errorf("expected match on the diff segment, got positions %v", positions)
+	}
+}
+
+func TestFilter_RanksBestFirst(t *testing.T) {
+	targets := []string{
+		"docs/difference/notes.txt",
+		"internal/ui/diff.go",
+		"internal/ui/diff_test.go",
+		"README.md",
+	}
+
+	results := Filter("diff", targets)
+	if len(results) != 3 {
+		t.Fatalf("expected 3 matches, got %d", len(results))
+	}
+
+	if targets[results[0].Index] != "internal/ui/diff.go" {
+		t.Errorf("expected shortest exact segment first, got %q", targets[results[0].Index])
+	}
+}
+
+func TestFilter_EmptyPatternKeepsOrder(t *testing.T) {
+	targets := []string{"b", "a", "c"}
+
+	results := Filter("", targets)
+	for i, result := range results {
+		if result.Index != i {
+			t.Fatalf("empty pattern should keep order, got %v", results)
+		}
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: reported positions are ascending and spell the pattern.
+func TestMatch_PositionsSpellPattern(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		target := rapid.StringMatching(`[a-zA-Z0-9/_.-]{0,40}`).Draw(t, "target")
+		pattern := rapid.StringMatching(`[a-z0-9/_.-]{1,6}`).Draw(t, "pattern")
+
+		_, positions, ok := Match(pattern, target)
+		if !ok {
+			return
+		}
+
+		runes := []rune(target)
+		want := []rune(pattern)
+
+		for i, pos := range positions {
+			if i > 0 && pos <= positions[i-1] {
+				t.Fatalf("positions not ascending: %v", positions)
+			}
+
+			if unicode.ToLower(runes[pos]) != want[i] {
+				t.Fatalf("position %d is %q, want %q", pos, runes[pos], want[i])
+			}
+		}
+	})
+}
+
+// Property: any subsequence of a target matches it.
+func TestMatch_SubsequenceAlwaysMatches(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		target := rapid.StringMatching(`[a-z0-9/_.-]{1,40}`).Draw(t, "target")
+		runes := []rune(target)
+
+		var pattern []rune
+
+		for _, r := range runes {
+			if rapid.Bool().Draw(t, "keep") {
+				pattern = append(pattern, r)
+			}
+		}
+
+		if _, _, ok := Match(string(pattern), target); !ok {
+			t.Fatalf("subsequence %q should match %q", string(pattern), target)
+		}
+	})
+}
@@ -8,6 +8,7 @@ package ignore
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 
@@ -17,16 +18,20 @@ import (
 // Matcher checks paths against hierarchical .gitignore rules.
 type Matcher struct {
 	rootPath         string
-	fastDirs         map[string]bool // O(1) lookup for commonly ignored directory names
-	dirPatterns      sync.Map        // dir (string) -> []gitignore.Pattern
-	combinedMatchers sync.Map        // dir (string) -> gitignore.Matcher
+	fastDirs         map[string]bool     // O(1) lookup for commonly ignored directory names
+	basePatterns     []gitignore.Pattern // global excludes and .git/info/exclude, under every .gitignore
+	dirPatterns      sync.Map            // dir (string) -> []gitignore.Pattern
+	combinedMatchers sync.Map            // dir (string) -> gitignore.Matcher
 }
 
 // NewMatcher creates a Matcher rooted at the given repository path.
-// It will read .gitignore files hierarchically from rootPath downward.
+// It will read .gitignore files hierarchically from rootPath downward, over
+// the excludes jj also honors: the user's global git ignore file and the
+// repo's .git/info/exclude.
 func NewMatcher(rootPath string) *Matcher {
 	return &Matcher{
-		rootPath: rootPath,
+		rootPath:     rootPath,
+		basePatterns: readPatterns(append(globalExcludesPaths(), filepath.Join(rootPath, ".git", "info", "exclude"))...),
 		fastDirs: map[string]bool{
 			// Version control
 			".git": true,
@@ -53,6 +58,55 @@ func NewMatcher(rootPath string) *Matcher {
 	}
 }
 
+// AddPatterns ignores paths matching gitignore-style patterns (e.g. "*.swp")
+// anywhere under the root, beneath the rules of every .gitignore. Call it
+// before the matcher is shared.
+func (m *Matcher) AddPatterns(patterns ...string) {
+	m.basePatterns = append(m.basePatterns, parsePatterns(patterns, nil)...)
+	m.Reload()
+}
+
+// Reload forgets the cached patterns, so .gitignore files are read again
+// after one changes. The base excludes are kept.
+func (m *Matcher) Reload() {
+	m.dirPatterns.Clear()
+	m.combinedMatchers.Clear()
+}
+
+// IsIgnoreFile reports whether path is a file of ignore rules that Reload
+// should follow.
+func IsIgnoreFile(path string) bool {
+	return filepath.Base(path) == ".gitignore"
+}
+
+// globalExcludesPaths returns where git looks for the user's ignore file by
+// default: $XDG_CONFIG_HOME/git/ignore, falling back to ~/.config/git/ignore.
+func globalExcludesPaths() []string {
+	if configDir := os.Getenv("XDG_CONFIG_HOME"); configDir != "" {
+		return []string{filepath.Join(configDir, "git", "ignore")}
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+
+	return []string{filepath.Join(home, ".config", "git", "ignore")}
+}
+
+// readPatterns parses the files of root-level ignore rules that exist.
+func readPatterns(paths ...string) []gitignore.Pattern {
+	var patterns []gitignore.Pattern
+
+	for _, path := range paths {
+		if content, err := os.ReadFile(path); err == nil {
+			patterns = append(patterns, parsePatterns(strings.Split(string(content), "\n"), nil)...)
+		}
+	}
+
+	return patterns
+}
+
 // Match reports whether the given absolute path should be ignored.
 // isDir must be true when path refers to a directory so that directory-only
 // patterns (e.g. "backup/") are applied correctly.
@@ -148,9 +202,10 @@ func (m *Matcher) getCombinedMatcher(dir string) gitignore.Matcher {
 		return matcher
 	}
 
-	var allPatterns []gitignore.Pattern
+	// Collect patterns from the base excludes, then root to this directory,
+	// so deeper files take precedence.
+	allPatterns := slices.Clone(m.basePatterns)
 
-	// Collect patterns from root to this directory.
 	relDir, _ := filepath.Rel(m.rootPath, dir)
 
 	var pathParts []string
@@ -165,3 +165,83 @@ func TestNoGitignore(t *testing.T) {
 		t.Error("node_modules should always be ignored via fast path")
 	}
 }
+
+func TestGitInfoExclude(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	infoDir := filepath.Join(root, ".git", "info")
+	if err := os.MkdirAll(infoDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(infoDir, "exclude"), []byte("*.swp\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// A .gitignore can still bring back what the excludes ignore.
+	if err := os.WriteFile(filepath.Join(root, ".gitignore"), []byte("!keep.swp\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := ignore.NewMatcher(root)
+
+	if !m.Match(filepath.Join(root, "main.go.swp"), false) {
+		t.Error("patterns in .git/info/exclude should be ignored")
+	}
+
+	if m.Match(filepath.Join(root, "keep.swp"), false) {
+		t.Error(".gitignore should take precedence over .git/info/exclude")
+	}
+}
+
+func TestGlobalExcludes(t *testing.T) {
+	root := t.TempDir()
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+
+	if err := os.MkdirAll(filepath.Join(configDir, "git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(configDir, "git", "ignore"), []byte("*~\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := ignore.NewMatcher(root)
+
+	if !m.Match(filepath.Join(root, "src", "main.go~"), false) {
+		t.Error("patterns in the global git ignore file should be ignored")
+	}
+}
+
+func TestReload_PicksUpChangedGitignore(t *testing.T) {
+	root := t.TempDir()
+
+	m := ignore.NewMatcher(root)
+
+	target := filepath.Join(root, "target")
+	if m.Match(target, true) {
+		t.Fatal("target/ should not be ignored before a .gitignore exists")
+	}
+
+	if err := os.WriteFile(filepath.Join(root, ".gitignore"), []byte("target/\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m.Reload()
+
+	if !m.Match(target, true) {
+		t.Error("target/ should be ignored once the new .gitignore is read")
+	}
+}
+
+func TestIsIgnoreFile(t *testing.T) {
+	if !ignore.IsIgnoreFile(filepath.Join("src", ".gitignore")) {
+		t.Error("a .gitignore in any directory holds ignore rules")
+	}
+
+	if ignore.IsIgnoreFile(filepath.Join("src", "gitignore.go")) {
+		t.Error("only .gitignore files hold ignore rules")
+	}
+}
@@ -0,0 +1,63 @@
+package jj
+
+import (
+	"strconv"
+	"strings"
+)
+
+// AnnotatedLine is a line of a file with the change that last touched it.
+type AnnotatedLine struct {
+	ChangeID   string
+	LineNumber int // 1-based
+	Content    string
+}
+
+// Annotate returns each line of path at rev with the change that last
+// touched it (jj file annotate). Unlike most jj commands, annotate takes a
+// plain path rather than a fileset, so path is passed as it is.
+func (r *Runner) Annotate(rev, path string) ([]AnnotatedLine, error) {
+	template := r.templates.Get("annotate_commit")
+	if r.Supports(FeatureAnnotationLine) {
+		template = r.templates.Get("annotate")
+	}
+
+	output, err := r.view("file", "annotate", "-r", rev, "--color=never", "-T", template, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseAnnotation(output), nil
+}
+
+// ParseAnnotation reads jj file annotate output rendered by the annotate
+// templates: a change ID and a tab, then "N: content". Older jj writes the
+// line number itself, right-aligned, which the spaces allow for. A line
+// without a number is numbered by its position.
+func ParseAnnotation(output string) []AnnotatedLine {
+	output = strings.TrimSuffix(output, "\n")
+	if output == "" {
+		return nil
+	}
+
+	rawLines := strings.Split(output, "\n")
+	lines := make([]AnnotatedLine, 0, len(rawLines))
+
+	for i, raw := range rawLines {
+		changeID, rest, ok := strings.Cut(raw, "\t")
+		if !ok {
+			continue
+		}
+
+		line := AnnotatedLine{ChangeID: changeID, LineNumber: i + 1, Content: rest}
+
+		if number, content, ok := strings.Cut(strings.TrimLeft(rest, " "), ": "); ok {
+			if n, err := strconv.Atoi(number); err == nil {
+				line.LineNumber, line.Content = n, content
+			}
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
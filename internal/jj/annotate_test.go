@@ -0,0 +1,136 @@
+package jj
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestParseAnnotation(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+	}{
+		{"line templates", "qpvuntsm\t1: package main\nqpvuntsm\t2: \nzsuskuln\t10: func run(a: int) {}\n"},
+		{"commit templates", "qpvuntsm\t    1: package main\nqpvuntsm\t    2: \nzsuskuln\t   10: func run(a: int) {}\n"},
+	}
+
+	want := []AnnotatedLine{
+		{ChangeID: "qpvuntsm", LineNumber: 1, Content: "package main"},
+		{ChangeID: "qpvuntsm", LineNumber: 2, Content: ""},
+		{ChangeID: "zsuskuln", LineNumber: 10, Content: "func run(a: int) {}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseAnnotation(tt.output); !slices.Equal(got, want) {
+				t.Errorf("ParseAnnotation() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestParseAnnotation_WithoutNumbers(t *testing.T) {
+	got := ParseAnnotation("qpvuntsm\tone\nzsuskuln\ttwo")
+	want := []AnnotatedLine{
+		{ChangeID: "qpvuntsm", LineNumber: 1, Content: "one"},
+		{ChangeID: "zsuskuln", LineNumber: 2, Content: "two"},
+	}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("lines should be numbered by position, got %+v", got)
+	}
+}
+
+func TestAnnotate_TemplateByVersion(t *testing.T) {
+	for _, tt := range []struct {
+		version string
+		want    string
+	}{
+		{"0.27.0", `-T change_id.shortest(8) ++ "\t"`},
+		{"0.28.0", `-T commit.change_id().shortest(8) ++ "\t" ++ line_number ++ ": " ++ content`},
+	} {
+		t.Run(tt.version, func(t *testing.T) {
+			args := filepath.Join(t.TempDir(), "args")
+			fakeJJ(t, `if [ "$1" = --version ]; then echo 'jj `+tt.version+`'; exit 0; fi
+printf '%s\n' "$*" >`+args+`
+printf 'qpvuntsm\t1: package main\n'`)
+
+			runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+			lines, err := runner.Annotate("xsssnyux", "main.go")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(lines) != 1 || lines[0].Content != "package main" {
+				t.Errorf("lines = %+v", lines)
+			}
+
+			got, _ := os.ReadFile(args)
+			if !strings.HasPrefix(string(got), "file annotate -r xsssnyux --color=never "+tt.want+" main.go") {
+				t.Errorf("ran jj %s", strings.TrimSpace(string(got)))
+			}
+		})
+	}
+}
+
+func TestAnnotate_PathTakenLiterally(t *testing.T) {
+	args := filepath.Join(t.TempDir(), "args")
+	fakeJJ(t, `if [ "$1" = --version ]; then echo 'jj 0.28.0'; exit 0; fi
+printf '%s\n' "$@" >`+args)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	if _, err := runner.Annotate("xsssnyux", "src/(group)/page~1.tsx"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, _ := os.ReadFile(args)
+	if ran := strings.Split(string(got), "\n"); !slices.Contains(ran, "src/(group)/page~1.tsx") {
+		t.Errorf("annotate takes a path, not a fileset, to pass as it is; ran jj %q", ran)
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: parsing recovers every line rendered by either template
+func TestParseAnnotation_RoundTrip(t *testing.T) {
+	rapid.Check(t, func(rt *rapid.T) {
+		want := rapid.SliceOf(rapid.Custom(func(rt *rapid.T) AnnotatedLine {
+			return AnnotatedLine{
+				ChangeID:   rapid.StringMatching(`[k-z]{8}`).Draw(rt, "id"),
+				LineNumber: rapid.IntRange(1, 99999).Draw(rt, "number"),
+				Content:    rapid.StringMatching(`[^\n]*`).Draw(rt, "content"),
+			}
+		})).Draw(rt, "lines")
+		padded := rapid.Bool().Draw(rt, "padded")
+
+		var output strings.Builder
+
+		for _, line := range want {
+			number := strconv.Itoa(line.LineNumber)
+			if padded {
+				number = strings.Repeat(" ", max(4-len(number), 0)) + number
+			}
+
+			output.WriteString(line.ChangeID + "\t" + number + ": " + line.Content + "\n")
+		}
+
+		if got := ParseAnnotation(output.String()); !slices.Equal(got, want) {
+			rt.Fatalf("ParseAnnotation() = %+v, want %+v", got, want)
+		}
+	})
+}
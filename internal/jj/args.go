@@ -0,0 +1,137 @@
+package jj
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// ErrUnterminatedQuote is returned by SplitArgs for a quote left open.
+var ErrUnterminatedQuote = errors.New("unterminated quote")
+
+// SplitArgs splits a command line into arguments like a POSIX shell would,
+// without expansion: whitespace separates arguments, single quotes keep text
+// literally, and double quotes keep text but honor backslash escapes.
+func SplitArgs(line string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool // an argument has started, even if empty ("")
+		quote   rune // open quote character, 0 when none
+		escaped bool // previous rune was a backslash
+	)
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			current.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 || escaped {
+		return nil, ErrUnterminatedQuote
+	}
+
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
+
+// readOnlyCommands lists jj commands that never change the repository,
+// by command and, where only some subcommands are read-only, subcommand.
+var readOnlyCommands = map[string][]string{
+	"diff":      nil,
+	"evolog":    nil,
+	"obslog":    nil,
+	"help":      nil,
+	"interdiff": nil,
+	"log":       nil,
+	"root":      nil,
+	"show":      nil,
+	"st":        nil,
+	"status":    nil,
+	"version":   nil,
+	"bookmark":  {"list", "l"},
+	"b":         {"list", "l"},
+	"config":    {"get", "list", "path", "l"},
+	"file":      {"annotate", "list", "show"},
+	"op":        {"diff", "log", "show"},
+	"operation": {"diff", "log", "show"},
+	"tag":       {"list", "l"},
+	"workspace": {"list", "root"},
+}
+
+// IsReadOnly reports whether the jj command in args (without the leading
+// "jj") only reads the repository. Unknown commands are assumed to write.
+func IsReadOnly(args []string) bool {
+	var words []string
+
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			words = append(words, arg)
+		}
+	}
+
+	if len(words) == 0 {
+		return true // bare "jj" or only flags like --help and --version
+	}
+
+	subcommands, ok := readOnlyCommands[words[0]]
+	if !ok {
+		return false
+	}
+
+	if subcommands == nil {
+		return true
+	}
+
+	return len(words) > 1 && slices.Contains(subcommands, words[1])
+}
+
+// WithoutSnapshot adds --ignore-working-copy to the jj command in args,
+// unless already there, so it neither snapshots the working copy nor
+// records an operation.
+func WithoutSnapshot(args []string) []string {
+	if slices.Contains(args, ignoreWorkingCopyFlag) {
+		return args
+	}
+
+	return append(args, ignoreWorkingCopyFlag)
+}
+
+// filesetEscaper escapes the characters special inside a fileset string.
+var filesetEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// filePatterns quotes repo paths as filesets naming exactly those files.
+// jj reads bare path arguments as fileset expressions, where characters
+// such as ~, |, and parentheses are operators.
+func filePatterns(paths []string) []string {
+	patterns := make([]string, len(paths))
+	for i, path := range paths {
+		patterns[i] = `root-file:"` + filesetEscaper.Replace(path) + `"`
+	}
+
+	return patterns
+}
@@ -0,0 +1,149 @@
+package jj
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"plain", "log -r @", []string{"log", "-r", "@"}},
+		{"extra whitespace", "  log \t -r  @ ", []string{"log", "-r", "@"}},
+		{"double quotes", `describe -m "fix the thing"`, []string{"describe", "-m", "fix the thing"}},
+		{"single quotes keep backslashes", `log -r 'a\b'`, []string{"log", "-r", `a\b`}},
+		{"escaped quote", `describe -m "say \"hi\""`, []string{"describe", "-m", `say "hi"`}},
+		{"escaped space", `file show a\ b.txt`, []string{"file", "show", "a b.txt"}},
+		{"empty argument", `describe -m ""`, []string{"describe", "-m", ""}},
+		{"adjacent quotes join", `log -r 'mine()'"&"@`, []string{"log", "-r", "mine()&@"}},
+		{"empty line", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SplitArgs(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SplitArgs(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitArgs_Unterminated(t *testing.T) {
+	for _, input := range []string{`describe -m "open`, `log -r 'open`, `trailing\`} {
+		if _, err := SplitArgs(input); !errors.Is(err, ErrUnterminatedQuote) {
+			t.Errorf("SplitArgs(%q) error = %v, want ErrUnterminatedQuote", input, err)
+		}
+	}
+}
+
+func TestIsReadOnly(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{nil, true},
+		{[]string{"log", "-r", "@"}, true},
+		{[]string{"--no-pager", "show"}, true},
+		{[]string{"op", "log"}, true},
+		{[]string{"bookmark", "list"}, true},
+		{[]string{"bookmark", "set", "main"}, false},
+		{[]string{"op", "undo"}, false},
+		{[]string{"new"}, false},
+		{[]string{"some-alias"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := IsReadOnly(tt.args); got != tt.want {
+			t.Errorf("IsReadOnly(%q) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestWithoutSnapshot(t *testing.T) {
+	if got := WithoutSnapshot([]string{"st"}); !slices.Equal(got, []string{"st", "--ignore-working-copy"}) {
+		t.Errorf("expected the flag added, got %v", got)
+	}
+
+	args := []string{"log", "--ignore-working-copy"}
+	if got := WithoutSnapshot(args); !slices.Equal(got, args) {
+		t.Errorf("the flag should not be added twice, got %v", got)
+	}
+}
+
+func TestFilePatterns(t *testing.T) {
+	paths := []string{"main.go", "my notes~1.md", "src/(group)/page.tsx", `say "hi"\now.txt`}
+	want := []string{
+		`root-file:"main.go"`,
+		`root-file:"my notes~1.md"`,
+		`root-file:"src/(group)/page.tsx"`,
+		`root-file:"say \"hi\"\\now.txt"`,
+	}
+
+	if got := filePatterns(paths); !slices.Equal(got, want) {
+		t.Errorf("filePatterns(%q) = %q, want %q", paths, got, want)
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: Single-quoting every argument splits back to the same arguments
+func TestSplitArgs_QuotedRoundTrip(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		args := rapid.SliceOf(rapid.StringMatching(`[a-z0-9 "\\@()&|-]*`)).Draw(t, "args")
+
+		quoted := make([]string, len(args))
+		for i, arg := range args {
+			quoted[i] = "'" + arg + "'"
+		}
+
+		got, err := SplitArgs(strings.Join(quoted, " "))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(args) == 0 {
+			args = nil
+		}
+
+		if !slices.Equal(got, args) {
+			t.Fatalf("expected %q, got %q", args, got)
+		}
+	})
+}
+
+// Property: a quoted path reads back as the path it names.
+func TestFilePatterns_ReadBack(t *testing.T) {
+	rapid.Check(t, func(rt *rapid.T) {
+		path := rapid.StringMatching(`[ -~]{1,30}`).Draw(rt, "path")
+
+		pattern := filePatterns([]string{path})[0]
+
+		quoted, ok := strings.CutPrefix(pattern, "root-file:")
+		if !ok {
+			rt.Fatalf("expected a root-file: pattern, got %s", pattern)
+		}
+
+		if got, err := strconv.Unquote(quoted); err != nil || got != path {
+			rt.Fatalf("%s reads back as %q (%v), want %q", pattern, got, err, path)
+		}
+	})
+}
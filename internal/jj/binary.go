@@ -0,0 +1,253 @@
+package jj
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"regexp"
+	"strings"
+
+	// Decoders for the image formats binary files are described by
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+const (
+	// binaryMarker is the row jj's color-words diff shows in place of the
+	// content of a binary file.
+	binaryMarker = "(binary)"
+
+	// binarySniffBytes is how much of a file IsBinary looks at, as git does.
+	binarySniffBytes = 8000
+
+	// maxDescribedBinaries bounds the binary files of a diff looked up for
+	// their sizes; the rest keep jj's marker.
+	maxDescribedBinaries = 16
+
+	// bytesPerKB is the step between the units of a size.
+	bytesPerKB = 1024
+)
+
+var (
+	// binaryHeaderRe captures the status and path from a color-words file
+	// header, for the marker row that follows.
+	binaryHeaderRe = regexp.MustCompile(`^(Added|Modified|Removed) regular file (.+):\s*$`)
+
+	// gitBinaryRe captures the old and new paths (empty for /dev/null) of a
+	// binary file in a git-format diff, and the description DescribeBinaries
+	// adds.
+	gitBinaryRe = regexp.MustCompile(`^Binary files (?:a/(.+)|/dev/null) and (?:b/(.+)|/dev/null) differ(?:: (.+))?$`)
+)
+
+// BinaryFile is a binary file a diff changes, as it was before and after.
+type BinaryFile struct {
+	Path   string
+	Status FileStatus
+	Old    BinaryContent // Unused when the file is added
+	New    BinaryContent // Unused when the file is removed
+}
+
+// BinaryContent is one version of a binary file.
+type BinaryContent struct {
+	Size  int    // Bytes; negative when it couldn't be read
+	Image string // Dimensions and format for an image, e.g. "640×480 PNG"
+}
+
+// Summary describes the change in one line, e.g. "binary file changed
+// (1.2 KB → 3.4 KB)".
+func (b BinaryFile) Summary() string {
+	kind := "binary file"
+	if b.Old.Image != "" || b.New.Image != "" {
+		kind = "image"
+	}
+
+	switch b.Status {
+	case FileAdded:
+		return fmt.Sprintf("%s added (%s)", kind, b.New)
+	case FileDeleted:
+		return fmt.Sprintf("%s removed (%s)", kind, b.Old)
+	case FileModified, FileRenamed, FileCopied:
+	}
+
+	return fmt.Sprintf("%s changed (%s → %s)", kind, b.Old, b.New)
+}
+
+// String gives the size of the content, after its dimensions for an image.
+func (c BinaryContent) String() string {
+	size := formatSize(c.Size)
+	if c.Image != "" {
+		return c.Image + ", " + size
+	}
+
+	return size
+}
+
+// DescribeContent reads the size of content, and its dimensions if it is
+// an image.
+func DescribeContent(content string) BinaryContent {
+	described := BinaryContent{Size: len(content)}
+
+	if config, format, err := image.DecodeConfig(strings.NewReader(content)); err == nil {
+		described.Image = fmt.Sprintf("%d×%d %s", config.Width, config.Height, strings.ToUpper(format))
+	}
+
+	return described
+}
+
+// IsBinary reports whether content is binary rather than text: whether it
+// has a NUL byte near the start, as git decides.
+func IsBinary(content string) bool {
+	return bytes.IndexByte([]byte(content[:min(len(content), binarySniffBytes)]), 0) >= 0
+}
+
+// FindBinaryFiles finds the binary files in diff output, in jj's
+// color-words format or git's.
+func FindBinaryFiles(output string) []File {
+	var (
+		files  []File
+		header []string
+	)
+
+	for line := range strings.SplitSeq(output, "\n") {
+		stripped := stripANSI(line)
+
+		if match := gitBinaryRe.FindStringSubmatch(stripped); match != nil {
+			files = append(files, gitBinaryFile(match))
+			continue
+		}
+
+		if header != nil && strings.TrimSpace(stripped) == binaryMarker {
+			files = append(files, File{Path: header[2], Status: headerStatus(header[1])})
+		}
+
+		header = binaryHeaderRe.FindStringSubmatch(stripped)
+	}
+
+	return files
+}
+
+// DescribeBinaries puts the summary of each of files in place of the marker
+// jj shows for it in diff output: color-words' "(binary)" row, or after the
+// "Binary files … differ" line of a git diff, where ParseGitDiff finds it.
+func DescribeBinaries(output string, files []BinaryFile) string {
+	if len(files) == 0 {
+		return output
+	}
+
+	byPath := make(map[string]BinaryFile, len(files))
+	for _, file := range files {
+		byPath[file.Path] = file
+	}
+
+	lines := strings.Split(output, "\n")
+
+	var header []string
+
+	for i, line := range lines {
+		stripped := stripANSI(line)
+
+		if match := gitBinaryRe.FindStringSubmatch(stripped); match != nil {
+			if file, ok := byPath[gitBinaryFile(match).Path]; ok {
+				lines[i] = stripped + ": " + file.Summary()
+			}
+
+			continue
+		}
+
+		if header != nil && strings.TrimSpace(stripped) == binaryMarker {
+			if file, ok := byPath[header[2]]; ok {
+				lines[i] = "    " + file.Summary()
+			}
+		}
+
+		header = binaryHeaderRe.FindStringSubmatch(stripped)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// describeBinaries describes the binary files in the diff output of rev by
+// their sizes before and after, in place of jj's markers.
+func (r *Runner) describeBinaries(rev, output string) string {
+	files := FindBinaryFiles(output)
+	if len(files) == 0 {
+		return output
+	}
+
+	described := make([]BinaryFile, 0, min(len(files), maxDescribedBinaries))
+
+	for _, file := range files[:min(len(files), maxDescribedBinaries)] {
+		binary := BinaryFile{Path: file.Path, Status: file.Status}
+
+		if file.Status != FileAdded {
+			binary.Old = r.readBinary(rev+"-", file.Path)
+		}
+
+		if file.Status != FileDeleted {
+			binary.New = r.readBinary(rev, file.Path)
+		}
+
+		described = append(described, binary)
+	}
+
+	return DescribeBinaries(output, described)
+}
+
+// readBinary describes file as it is in rev; its size is negative if it
+// can't be read, e.g. at a merge's several parents.
+func (r *Runner) readBinary(rev, file string) BinaryContent {
+	content, err := r.FileShow(rev, file)
+	if err != nil {
+		return BinaryContent{Size: -1}
+	}
+
+	return DescribeContent(content)
+}
+
+// gitBinaryFile gives the file of a gitBinaryRe match, added or removed when
+// one side is /dev/null.
+func gitBinaryFile(match []string) File {
+	switch {
+	case match[1] == "":
+		return File{Path: match[2], Status: FileAdded}
+	case match[2] == "":
+		return File{Path: match[1], Status: FileDeleted}
+	}
+
+	return File{Path: match[2], Status: FileModified}
+}
+
+// headerStatus gives the status a color-words file header names.
+func headerStatus(word string) FileStatus {
+	switch word {
+	case "Added":
+		return FileAdded
+	case "Removed":
+		return FileDeleted
+	}
+
+	return FileModified
+}
+
+// formatSize gives a size in bytes in the largest unit it has one of.
+func formatSize(size int) string {
+	if size < 0 {
+		return "?"
+	}
+
+	if size < bytesPerKB {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	value := float64(size) / bytesPerKB
+	for _, unit := range []string{"KB", "MB"} {
+		if value < bytesPerKB {
+			return fmt.Sprintf("%.1f %s", value, unit)
+		}
+
+		value /= bytesPerKB
+	}
+
+	return fmt.Sprintf("%.1f GB", value)
+}
@@ -0,0 +1,164 @@
+package jj
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"slices"
+	"strings"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// binaryColorWordsDiff has a changed binary file between two text files.
+const binaryColorWordsDiff = "Modified regular file a.go:\n   1    1: package a\n" +
+	"Modified regular file logo.png:\n    (binary)\n" +
+	"Added regular file b.go:\n        1: (binary)"
+
+// binaryGitDiff adds one binary file and removes another.
+const binaryGitDiff = "diff --git a/logo.png b/logo.png\nnew file mode 100644\nindex 0000000..1234567\n" +
+	"Binary files /dev/null and b/logo.png differ\n" +
+	"diff --git a/old.bin b/old.bin\ndeleted file mode 100644\nindex 1234567..0000000\n" +
+	"Binary files a/old.bin and /dev/null differ"
+
+// testPNG encodes a blank image of the given size.
+func testPNG(t *testing.T, width, height int) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatalf("encoding png: %v", err)
+	}
+
+	return buf.String()
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestFindBinaryFiles_ColorWords(t *testing.T) {
+	want := []File{{Path: "logo.png", Status: FileModified}}
+	if got := FindBinaryFiles(binaryColorWordsDiff); !slices.Equal(got, want) {
+		t.Errorf("FindBinaryFiles() = %v, want %v", got, want)
+	}
+}
+
+func TestFindBinaryFiles_Git(t *testing.T) {
+	want := []File{{Path: "logo.png", Status: FileAdded}, {Path: "old.bin", Status: FileDeleted}}
+	if got := FindBinaryFiles(binaryGitDiff); !slices.Equal(got, want) {
+		t.Errorf("FindBinaryFiles() = %v, want %v", got, want)
+	}
+}
+
+func TestDescribeBinaries_ColorWords(t *testing.T) {
+	logo := BinaryFile{
+		Path:   "logo.png",
+		Status: FileModified,
+		Old:    BinaryContent{Size: 1536},
+		New:    BinaryContent{Size: 3 * 1024 * 1024},
+	}
+
+	got := DescribeBinaries(binaryColorWordsDiff, []BinaryFile{logo})
+	if !strings.Contains(got, "\n    binary file changed (1.5 KB → 3.0 MB)\n") {
+		t.Errorf("the marker row should be replaced by the sizes:\n%s", got)
+	}
+
+	if !strings.HasSuffix(got, "        1: (binary)") {
+		t.Error("file content that reads (binary) should be left alone")
+	}
+}
+
+func TestDescribeBinaries_GitParsed(t *testing.T) {
+	logo := BinaryFile{Path: "logo.png", Status: FileAdded, New: BinaryContent{Size: 52, Image: "16×8 PNG"}}
+
+	_, files := ParseGitDiff(DescribeBinaries(binaryGitDiff, []BinaryFile{logo}))
+	if len(files) != 2 {
+		t.Fatalf("expected 2 files, got %d", len(files))
+	}
+
+	if files[0].Binary != "image added (16×8 PNG, 52 B)" {
+		t.Errorf("a described file should carry its summary, got %q", files[0].Binary)
+	}
+
+	if files[1].Binary != binaryMarker || files[1].Status != FileDeleted {
+		t.Errorf("an undescribed binary file should keep jj's marker, got %+v", files[1])
+	}
+}
+
+func TestBinaryFile_Summary(t *testing.T) {
+	tests := []struct {
+		file BinaryFile
+		want string
+	}{
+		{BinaryFile{Status: FileAdded, New: BinaryContent{Size: 10}}, "binary file added (10 B)"},
+		{BinaryFile{Status: FileDeleted, Old: BinaryContent{Size: 2048}}, "binary file removed (2.0 KB)"},
+		{BinaryFile{Status: FileModified, Old: BinaryContent{Size: -1}, New: BinaryContent{Size: 1}}, "binary file changed (? → 1 B)"},
+		{
+			BinaryFile{Status: FileModified, Old: BinaryContent{Size: 9, Image: "1×1 GIF"}, New: BinaryContent{Size: 9, Image: "2×2 GIF"}},
+			"image changed (1×1 GIF, 9 B → 2×2 GIF, 9 B)",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.file.Summary(); got != tt.want {
+			t.Errorf("Summary() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestDescribeContent_Image(t *testing.T) {
+	content := testPNG(t, 16, 8)
+
+	got := DescribeContent(content)
+	if got.Image != "16×8 PNG" || got.Size != len(content) {
+		t.Errorf("DescribeContent() = %+v", got)
+	}
+
+	if DescribeContent("\x00\x01").Image != "" {
+		t.Error("content that isn't an image should have no dimensions")
+	}
+}
+
+func TestIsBinary(t *testing.T) {
+	if IsBinary("package main\n") {
+		t.Error("text should not be binary")
+	}
+
+	if !IsBinary(testPNG(t, 1, 1)) {
+		t.Error("a PNG should be binary")
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Output without binary files comes back unchanged, whatever is described.
+func TestDescribeBinaries_TextUnchangedProperty(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		output := rapid.StringMatching(`[a-z :\n]{0,80}`).Draw(t, "output")
+		path := rapid.StringMatching(`[a-z]{1,8}\.bin`).Draw(t, "path")
+
+		if got := DescribeBinaries(output, []BinaryFile{{Path: path}}); got != output {
+			t.Fatalf("DescribeBinaries changed text output:\n%q\n%q", output, got)
+		}
+	})
+}
+
+// Sizes never lose their unit, and only unreadable ones are unknown.
+func TestFormatSizeProperty(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		size := rapid.IntRange(-1, 1<<40).Draw(t, "size")
+
+		got := formatSize(size)
+		if (size < 0) != (got == "?") {
+			t.Fatalf("formatSize(%d) = %q", size, got)
+		}
+
+		if size >= 0 && !strings.HasSuffix(got, "B") {
+			t.Fatalf("formatSize(%d) = %q has no unit", size, got)
+		}
+	})
+}
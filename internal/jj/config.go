@@ -0,0 +1,106 @@
+package jj
+
+import (
+	"strconv"
+	"strings"
+)
+
+// colorNever is the ui.color setting that turns jj's colors off.
+const colorNever = "never"
+
+// Settings is jj's effective configuration: each setting's TOML value, by
+// its dotted name (e.g. "ui.color").
+type Settings map[string]string
+
+// Config reads jj's configuration, defaults included (jj config list
+// --include-defaults), so chado can follow it.
+func (r *Runner) Config() (Settings, error) {
+	output, err := r.view("config", "list", "--include-defaults", "--color=never")
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseConfig(output), nil
+}
+
+// ParseConfig reads jj config list output: one "name = value" line a
+// setting. Multi-line values (such as templates) are left out.
+func ParseConfig(output string) Settings {
+	settings := make(Settings)
+
+	var closing string // delimiter ending the multi-line value being skipped
+
+	for line := range strings.SplitSeq(output, "\n") {
+		if closing != "" {
+			if strings.Contains(line, closing) {
+				closing = ""
+			}
+
+			continue
+		}
+
+		name, value, ok := strings.Cut(line, " = ")
+		if !ok || name == "" || strings.ContainsAny(name, " \t\"'") {
+			continue
+		}
+
+		if delimiter := multiLineDelimiter(value); delimiter != "" {
+			closing = delimiter
+			continue
+		}
+
+		settings[name] = value
+	}
+
+	return settings
+}
+
+// multiLineDelimiter returns the delimiter of a TOML multi-line string that
+// value opens but doesn't close on the same line, or "".
+func multiLineDelimiter(value string) string {
+	for _, delimiter := range []string{`"""`, `'''`} {
+		if rest, ok := strings.CutPrefix(value, delimiter); ok && !strings.Contains(rest, delimiter) {
+			return delimiter
+		}
+	}
+
+	return ""
+}
+
+// String returns the setting called name as a string, unquoted; "" when it
+// isn't set.
+func (s Settings) String(name string) string {
+	value := s[name]
+
+	if unquoted, err := strconv.Unquote(value); err == nil && strings.HasPrefix(value, `"`) {
+		return unquoted
+	}
+
+	if len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") {
+		return value[1 : len(value)-1]
+	}
+
+	return value
+}
+
+// DiffFormat returns the diff format jj is configured to show, from
+// ui.diff-formatter (or ui.diff.format before jj 0.29). Formats chado can't
+// show, such as external tools, give color-words.
+func (s Settings) DiffFormat() DiffFormat {
+	format := s.String("ui.diff-formatter")
+	if format == "" {
+		format = s.String("ui.diff.format")
+	}
+
+	if strings.TrimPrefix(format, ":") == string(DiffFormatGit) {
+		return DiffFormatGit
+	}
+
+	return DiffFormatColorWords
+}
+
+// Colored reports whether jj's output should be colored: always, unless
+// ui.color is "never". chado shows it in a terminal, so "auto" colors too.
+func (s Settings) Colored() bool {
+	return s.String("ui.color") != colorNever
+}
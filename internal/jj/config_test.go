@@ -0,0 +1,151 @@
+package jj
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestParseConfig(t *testing.T) {
+	output := `ui.color = "never"
+ui.diff-formatter = ":git"
+revsets.log = 'present(@) | ancestors(immutable_heads().., 2)'
+ui.paginate = "auto"
+templates.log = '''
+builtin_log = "x"
+'''
+git.auto-local-bookmark = false
+template-aliases.short = """one line"""
+`
+
+	settings := ParseConfig(output)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"ui.color", "never"},
+		{"ui.diff-formatter", ":git"},
+		{"revsets.log", "present(@) | ancestors(immutable_heads().., 2)"},
+		{"git.auto-local-bookmark", "false"},
+		{"templates.log", ""},
+		{"builtin_log", ""},
+		{"template-aliases.short", `"""one line"""`},
+		{"ui.editor", ""},
+	}
+
+	for _, tt := range tests {
+		if got := settings.String(tt.name); got != tt.want {
+			t.Errorf("String(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSettings_DiffFormat(t *testing.T) {
+	tests := []struct {
+		output string
+		want   DiffFormat
+	}{
+		{``, DiffFormatColorWords},
+		{`ui.diff-formatter = ":git"`, DiffFormatGit},
+		{`ui.diff-formatter = ":color-words"`, DiffFormatColorWords},
+		{`ui.diff-formatter = "difft"`, DiffFormatColorWords},
+		{`ui.diff.format = "git"`, DiffFormatGit},
+	}
+
+	for _, tt := range tests {
+		if got := ParseConfig(tt.output).DiffFormat(); got != tt.want {
+			t.Errorf("DiffFormat() of %q = %q, want %q", tt.output, got, tt.want)
+		}
+	}
+}
+
+func TestSettings_Colored(t *testing.T) {
+	for output, want := range map[string]bool{
+		``:                    true,
+		`ui.color = "auto"`:   true,
+		`ui.color = "always"`: true,
+		`ui.color = "never"`:  false,
+		`ui.color = "debug"`:  true,
+	} {
+		if got := ParseConfig(output).Colored(); got != want {
+			t.Errorf("Colored() of %q = %v, want %v", output, got, want)
+		}
+	}
+}
+
+func TestConfig_Args(t *testing.T) {
+	fakeJJ(t, `echo "ui.color = \"$*\""`)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	settings, err := runner.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := settings.String("ui.color"); got != "config list --include-defaults --color=never --ignore-working-copy" {
+		t.Errorf("ran jj %s", got)
+	}
+}
+
+func TestSetColored_Plain(t *testing.T) {
+	fakeJJ(t, `echo "$@"`)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+	runner.SetColored(false)
+
+	if output, _ := runner.Status(); !strings.Contains(output, "--color=never") {
+		t.Errorf("with ui.color = never, jj should not color output; ran jj %s", output)
+	}
+}
+
+// Run with -race: jj's settings are read back while the log loads.
+func TestSetColored_ChangedWhileCommandsRun(t *testing.T) {
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+	pinned := runner.Pinned()
+
+	var wg sync.WaitGroup
+
+	wg.Go(func() {
+		for i := range 100 {
+			runner.SetColored(i%2 == 1)
+		}
+	})
+
+	for range 100 {
+		pinned.ColorFlag()
+	}
+
+	wg.Wait()
+
+	if got := pinned.ColorFlag(); got != "--color=always" {
+		t.Errorf("a pinned runner should keep the colors it was pinned with, got %s", got)
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: a string setting reads back as it was written
+func TestParseConfig_StringRoundTrip(t *testing.T) {
+	rapid.Check(t, func(rt *rapid.T) {
+		name := rapid.StringMatching(`[a-z]+(\.[a-z-]+){1,2}`).Draw(rt, "name")
+		value := rapid.StringMatching(`[^\n]*`).Draw(rt, "value")
+
+		settings := ParseConfig(name + " = " + strconv.Quote(value) + "\n")
+
+		if got := settings.String(name); got != value {
+			rt.Fatalf("String(%q) = %q, want %q", name, got, value)
+		}
+	})
+}
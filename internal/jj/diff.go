@@ -0,0 +1,388 @@
+package jj
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DiffFormat selects how jj renders diffs for the diff pane.
+type DiffFormat string
+
+const (
+	// DiffFormatDefault leaves the format to jj's ui.diff-formatter.
+	DiffFormatDefault DiffFormat = ""
+
+	// DiffFormatColorWords is jj's default: changed words colored inline.
+	DiffFormatColorWords DiffFormat = "color-words"
+
+	// DiffFormatGit is a unified git-style diff, parsed with ParseGitDiff.
+	DiffFormatGit DiffFormat = "git"
+
+	// DiffFormatStat is a histogram of the lines changed in each file.
+	DiffFormatStat DiffFormat = "stat"
+)
+
+// DiffFormats are the diff formats the diff pane can show, in the order
+// they are switched between.
+var DiffFormats = []DiffFormat{DiffFormatDefault, DiffFormatGit, DiffFormatColorWords, DiffFormatStat}
+
+// DiffLineKind says how a line of a hunk relates to the two file versions.
+type DiffLineKind int
+
+const (
+	DiffContext DiffLineKind = iota // Present in both versions
+	DiffAdded                       // Only in the new version
+	DiffRemoved                     // Only in the old version
+)
+
+// Span is a byte range [Start, End) within a line's text.
+type Span struct {
+	Start int
+	End   int
+}
+
+// DiffLine is one line of a hunk.
+type DiffLine struct {
+	Kind    DiffLineKind
+	OldLine int    // Line number in the old version; 0 for added lines
+	NewLine int    // Line number in the new version; 0 for removed lines
+	Text    string // Content without the +/-/space marker
+	Changed []Span // Words that differ from the paired removed/added line
+	Moved   bool   // Part of a block added verbatim elsewhere, set by MarkMovedLines
+}
+
+// DiffHunk is one @@ section of a file diff.
+type DiffHunk struct {
+	OldStart     int
+	NewStart     int
+	Lines        []DiffLine
+	OldNoNewline bool // The old version ends, within this hunk, without a newline
+	NewNoNewline bool // Likewise the new version
+}
+
+// FileDiff is the parsed diff of one file.
+type FileDiff struct {
+	Path   string
+	Status FileStatus
+	Hunks  []DiffHunk
+	Binary string // Shown in place of the hunks of a binary file; empty for text
+}
+
+const (
+	// gitDiffHeader starts each file in a git-format diff.
+	gitDiffHeader = "diff --git "
+
+	// maxWordDiffCells bounds the word-level LCS table; longer line pairs
+	// are shown without word highlights.
+	maxWordDiffCells = 40000
+)
+
+var (
+	// gitFileRe captures the new path from a git diff file header.
+	gitFileRe = regexp.MustCompile(`^diff --git a/.+ b/(.+)$`)
+
+	// gitHunkRe captures the start lines and counts from a hunk header.
+	gitHunkRe = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+)
+
+// IsGitDiff reports whether output contains a git-format diff.
+func IsGitDiff(output string) bool {
+	for line := range strings.SplitSeq(output, "\n") {
+		if strings.HasPrefix(stripANSI(line), gitDiffHeader) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseGitDiff splits git-format diff output into whatever precedes the first
+// file (e.g. the header jj show prints) and the parsed files. Paired removed
+// and added lines get their changed words marked.
+func ParseGitDiff(output string) (string, []FileDiff) {
+	lines := strings.Split(output, "\n")
+
+	var (
+		preamble []string
+		files    []FileDiff
+		file     *FileDiff
+		hunk     *DiffHunk
+		oldLine  int
+		newLine  int
+		oldLeft  int
+		newLeft  int
+	)
+
+	finishHunk := func() {
+		if hunk != nil {
+			markWordChanges(hunk.Lines)
+			file.Hunks = append(file.Hunks, *hunk)
+			hunk = nil
+		}
+	}
+
+	finishFile := func() {
+		finishHunk()
+
+		if file != nil {
+			files = append(files, *file)
+			file = nil
+		}
+	}
+
+	for _, raw := range lines {
+		line := stripANSI(raw)
+
+		// Inside a hunk the header counts say how many lines belong to it,
+		// so content like "--- x" or "diff --git" is not mistaken for a header.
+		if hunk != nil && (oldLeft > 0 || newLeft > 0) {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				hunk.Lines = append(hunk.Lines, DiffLine{Kind: DiffAdded, NewLine: newLine, Text: line[1:]})
+				newLine++
+				newLeft--
+			case strings.HasPrefix(line, "-"):
+				hunk.Lines = append(hunk.Lines, DiffLine{Kind: DiffRemoved, OldLine: oldLine, Text: line[1:]})
+				oldLine++
+				oldLeft--
+			case strings.HasPrefix(line, `\`):
+				markNoNewline(hunk)
+			default:
+				hunk.Lines = append(hunk.Lines, DiffLine{
+					Kind:    DiffContext,
+					OldLine: oldLine,
+					NewLine: newLine,
+					Text:    strings.TrimPrefix(line, " "),
+				})
+				oldLine++
+				newLine++
+				oldLeft--
+				newLeft--
+			}
+
+			continue
+		}
+
+		if match := gitFileRe.FindStringSubmatch(line); match != nil {
+			finishFile()
+
+			file = &FileDiff{Path: match[1], Status: FileModified}
+
+			continue
+		}
+
+		if file == nil {
+			preamble = append(preamble, raw)
+			continue
+		}
+
+		// The marker may follow the last line counted in the header
+		if hunk != nil && strings.HasPrefix(line, `\`) {
+			markNoNewline(hunk)
+			continue
+		}
+
+		if match := gitHunkRe.FindStringSubmatch(line); match != nil {
+			finishHunk()
+
+			oldLine = atoiOr(match[1], 0)
+			oldLeft = atoiOr(match[2], 1)
+			newLine = atoiOr(match[3], 0)
+			newLeft = atoiOr(match[4], 1)
+			hunk = &DiffHunk{OldStart: oldLine, NewStart: newLine}
+
+			continue
+		}
+
+		if match := gitBinaryRe.FindStringSubmatch(line); match != nil {
+			file.Binary = match[3]
+			if file.Binary == "" {
+				file.Binary = binaryMarker
+			}
+
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "new file mode"):
+			file.Status = FileAdded
+		case strings.HasPrefix(line, "deleted file mode"):
+			file.Status = FileDeleted
+		case strings.HasPrefix(line, "rename from"):
+			file.Status = FileRenamed
+		case strings.HasPrefix(line, "copy from"):
+			file.Status = FileCopied
+		}
+	}
+
+	finishFile()
+
+	return strings.Join(preamble, "\n"), files
+}
+
+// markNoNewline records a "\ No newline at end of file" marker against the
+// version(s) of the hunk line it follows.
+func markNoNewline(hunk *DiffHunk) {
+	if len(hunk.Lines) == 0 {
+		return
+	}
+
+	switch hunk.Lines[len(hunk.Lines)-1].Kind {
+	case DiffRemoved:
+		hunk.OldNoNewline = true
+	case DiffAdded:
+		hunk.NewNoNewline = true
+	case DiffContext:
+		hunk.OldNoNewline = true
+		hunk.NewNoNewline = true
+	}
+}
+
+// atoiOr parses s, returning fallback when s is empty or malformed.
+func atoiOr(s string, fallback int) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fallback
+	}
+
+	return n
+}
+
+// markWordChanges pairs each run of removed lines with the run of added lines
+// that follows it, line by line, and marks the words that differ.
+func markWordChanges(lines []DiffLine) {
+	for i := 0; i < len(lines); {
+		if lines[i].Kind != DiffRemoved {
+			i++
+			continue
+		}
+
+		removedStart := i
+		for i < len(lines) && lines[i].Kind == DiffRemoved {
+			i++
+		}
+
+		addedStart := i
+		for i < len(lines) && lines[i].Kind == DiffAdded {
+			i++
+		}
+
+		pairs := min(addedStart-removedStart, i-addedStart)
+		for p := range pairs {
+			removed := &lines[removedStart+p]
+			added := &lines[addedStart+p]
+			removed.Changed, added.Changed = WordDiff(removed.Text, added.Text)
+		}
+	}
+}
+
+// WordDiff compares two versions of a line word by word and returns the byte
+// spans of each that are not part of their longest common word sequence.
+// Returns nil spans when the lines are too long to compare.
+func WordDiff(oldText, newText string) ([]Span, []Span) {
+	oldWords := splitWords(oldText)
+	newWords := splitWords(newText)
+
+	if len(oldWords)*len(newWords) > maxWordDiffCells {
+		return nil, nil
+	}
+
+	// lcs[i][j] is the common-sequence length of oldWords[i:] and newWords[j:].
+	lcs := make([][]int, len(oldWords)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newWords)+1)
+	}
+
+	for i := len(oldWords) - 1; i >= 0; i-- {
+		for j := len(newWords) - 1; j >= 0; j-- {
+			if oldWords[i].text == newWords[j].text {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var oldChanged, newChanged []Span
+
+	i, j := 0, 0
+	for i < len(oldWords) || j < len(newWords) {
+		switch {
+		case i < len(oldWords) && j < len(newWords) && oldWords[i].text == newWords[j].text:
+			i++
+			j++
+		case j >= len(newWords) || (i < len(oldWords) && lcs[i+1][j] >= lcs[i][j+1]):
+			oldChanged = appendSpan(oldChanged, oldWords[i].span)
+			i++
+		default:
+			newChanged = appendSpan(newChanged, newWords[j].span)
+			j++
+		}
+	}
+
+	return oldChanged, newChanged
+}
+
+// word is one token of a line with its position.
+type word struct {
+	text string
+	span Span
+}
+
+// splitWords tokenizes a line into identifier-like runs, whitespace runs,
+// and single other characters.
+func splitWords(text string) []word {
+	var words []word
+
+	for start := 0; start < len(text); {
+		r, size := utf8.DecodeRuneInString(text[start:])
+		end := start + size
+
+		if class := wordClass(r); class != wordOther {
+			for end < len(text) {
+				next, nextSize := utf8.DecodeRuneInString(text[end:])
+				if wordClass(next) != class {
+					break
+				}
+
+				end += nextSize
+			}
+		}
+
+		words = append(words, word{text: text[start:end], span: Span{Start: start, End: end}})
+		start = end
+	}
+
+	return words
+}
+
+// Word classes for splitWords: runs of the same class form one word.
+const (
+	wordOther = iota
+	wordIdent
+	wordSpace
+)
+
+func wordClass(r rune) int {
+	switch {
+	case r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+		return wordIdent
+	case unicode.IsSpace(r):
+		return wordSpace
+	}
+
+	return wordOther
+}
+
+// appendSpan adds span to spans, merging it into the last span when adjacent.
+func appendSpan(spans []Span, span Span) []Span {
+	if n := len(spans); n > 0 && spans[n-1].End == span.Start {
+		spans[n-1].End = span.End
+		return spans
+	}
+
+	return append(spans, span)
+}
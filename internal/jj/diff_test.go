@@ -0,0 +1,285 @@
+package jj
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// sampleGitDiff has a modified file with a changed line and an added file.
+const sampleGitDiff = `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,3 @@
+ package main
+-func run(a int) {}
++func run(a, b int) {}
+ // end
+diff --git a/new.go b/new.go
+new file mode 100644
+index 0000000..3333333
+--- /dev/null
++++ b/new.go
+@@ -0,0 +1,2 @@
++package main
++--- not a header`
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestParseGitDiff(t *testing.T) {
+	preamble, files := ParseGitDiff("Rev: abc\n----\n" + sampleGitDiff)
+
+	if preamble != "Rev: abc\n----" {
+		t.Errorf("expected show header as preamble, got %q", preamble)
+	}
+
+	if len(files) != 2 {
+		t.Fatalf("expected 2 files, got %d", len(files))
+	}
+
+	if files[0].Path != "main.go" || files[0].Status != FileModified {
+		t.Errorf("unexpected first file %+v", files[0])
+	}
+
+	if files[1].Path != "new.go" || files[1].Status != FileAdded {
+		t.Errorf("unexpected second file %+v", files[1])
+	}
+
+	lines := files[0].Hunks[0].Lines
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 hunk lines, got %d", len(lines))
+	}
+
+	if lines[1].Kind != DiffRemoved || lines[1].OldLine != 2 || lines[1].NewLine != 0 {
+		t.Errorf("unexpected removed line %+v", lines[1])
+	}
+
+	if lines[3].Kind != DiffContext || lines[3].OldLine != 3 || lines[3].NewLine != 3 {
+		t.Errorf("unexpected context line %+v", lines[3])
+	}
+
+	added := files[1].Hunks[0].Lines
+	if len(added) != 2 || added[1].Text != "--- not a header" {
+		t.Errorf("hunk content should not be read as headers, got %+v", added)
+	}
+}
+
+func TestParseGitDiff_MarksChangedWords(t *testing.T) {
+	_, files := ParseGitDiff(sampleGitDiff)
+	lines := files[0].Hunks[0].Lines
+
+	removed, added := lines[1], lines[2]
+
+	if got := spanTexts(removed.Text, removed.Changed); len(got) != 0 {
+		t.Errorf("nothing was removed from the line, got %q", got)
+	}
+
+	if got := kept(added.Text, added.Changed); got != removed.Text {
+		t.Errorf("only the added parameter should be marked, unmarked text is %q", got)
+	}
+
+	if lines[0].Changed != nil || lines[3].Changed != nil {
+		t.Error("context lines should have no changed words")
+	}
+}
+
+func TestWordDiff(t *testing.T) {
+	oldChanged, newChanged := WordDiff("return a + b", "return a - c")
+
+	if got := spanTexts("return a + b", oldChanged); !slices.Equal(got, []string{"+", "b"}) {
+		t.Errorf("unexpected old spans %q", got)
+	}
+
+	if got := spanTexts("return a - c", newChanged); !slices.Equal(got, []string{"-", "c"}) {
+		t.Errorf("unexpected new spans %q", got)
+	}
+}
+
+func TestWordDiff_TooLongSkipped(t *testing.T) {
+	long := strings.Repeat("a ", maxWordDiffCells)
+
+	if oldChanged, newChanged := WordDiff(long, long+"b"); oldChanged != nil || newChanged != nil {
+		t.Error("overlong lines should not be compared")
+	}
+}
+
+func TestIsGitDiff(t *testing.T) {
+	if !IsGitDiff("\x1b[1m" + sampleGitDiff) {
+		t.Error("git diff should be detected")
+	}
+
+	if IsGitDiff("Modified regular file main.go:\n   1    1: package main") {
+		t.Error("color-words diff is not a git diff")
+	}
+}
+
+func TestParseFiles_GitFormat(t *testing.T) {
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	files := runner.ParseFiles(sampleGitDiff)
+
+	want := []File{{Path: "main.go", Status: FileModified}, {Path: "new.go", Status: FileAdded}}
+	if !slices.Equal(files, want) {
+		t.Errorf("expected %+v, got %+v", want, files)
+	}
+}
+
+func TestDiffArgs_GitFormat(t *testing.T) {
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	if args := runner.diffArgs("diff"); slices.Contains(args, "--git") {
+		t.Error("color-words should not pass --git")
+	}
+
+	runner.SetDiffFormat(DiffFormatGit)
+
+	if args := runner.diffArgs("diff"); !slices.Contains(args, "--git") {
+		t.Error("git format should pass --git")
+	}
+}
+
+func TestDiffArgs_Formats(t *testing.T) {
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	tests := []struct {
+		format DiffFormat
+		want   []string
+	}{
+		{DiffFormatDefault, []string{"diff"}},
+		{DiffFormatGit, []string{"diff", "--git"}},
+		{DiffFormatColorWords, []string{"diff", "--color-words"}},
+		{DiffFormatStat, []string{"diff", "--stat"}},
+	}
+
+	for _, tt := range tests {
+		runner.SetDiffFormat(tt.format)
+
+		if args := runner.diffArgs("diff"); !slices.Equal(args, tt.want) {
+			t.Errorf("format %q: expected %v, got %v", tt.format, tt.want, args)
+		}
+	}
+}
+
+// Run with -race: the format is cycled while a diff loads.
+func TestDiffArgs_FormatChangedWhileDiffLoads(t *testing.T) {
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+	pinned := runner.Pinned()
+
+	var wg sync.WaitGroup
+
+	wg.Go(func() {
+		for _, format := range slices.Repeat(DiffFormats, 25) {
+			runner.SetDiffFormat(format)
+		}
+	})
+
+	for range 100 {
+		pinned.diffArgs("diff")
+	}
+
+	wg.Wait()
+
+	if got := pinned.DiffFormat(); got != DiffFormatColorWords {
+		t.Errorf("a pinned runner should keep the format it was pinned with, got %q", got)
+	}
+}
+
+func TestDiffArgs_Options(t *testing.T) {
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	runner.SetDiffFormat(DiffFormatDefault)
+
+	if args := runner.diffArgs("diff"); len(args) != 1 {
+		t.Errorf("no options should be passed by default, got %v", args)
+	}
+
+	runner.SetIgnoreWhitespace(true)
+	runner.SetDiffContext(0)
+
+	want := []string{"diff", "--ignore-all-space", "--context", "0"}
+	if args := runner.diffArgs("diff"); !slices.Equal(args, want) {
+		t.Errorf("expected %v, got %v", want, args)
+	}
+
+	runner.SetDiffContext(-1)
+
+	if args := runner.diffArgs("diff"); slices.Contains(args, "--context") {
+		t.Errorf("a negative context should leave it to jj, got %v", args)
+	}
+}
+
+// Run with -race: diff options change while diffs load.
+func TestDiffArgs_ChangedWhileDiffsLoad(t *testing.T) {
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+	derived := runner.WithContext(context.Background())
+
+	var wg sync.WaitGroup
+
+	wg.Go(func() {
+		for i := range 100 {
+			runner.SetIgnoreWhitespace(i%2 == 0)
+			runner.SetDiffContext(i)
+		}
+	})
+
+	for range 100 {
+		derived.diffArgs("diff")
+	}
+
+	wg.Wait()
+
+	want := []string{"diff", "--color-words", "--context", "99"}
+	if args := derived.diffArgs("diff"); !slices.Equal(args, want) {
+		t.Errorf("expected the latest options %v, got %v", want, args)
+	}
+}
+
+// spanTexts returns the text covered by each span.
+func spanTexts(text string, spans []Span) []string {
+	texts := make([]string, len(spans))
+	for i, span := range spans {
+		texts[i] = text[span.Start:span.End]
+	}
+
+	return texts
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: the unchanged words of both lines are the same sequence
+func TestWordDiff_UnchangedWordsMatch(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		oldText := rapid.StringMatching(`[a-c (),]{0,30}`).Draw(t, "old")
+		newText := rapid.StringMatching(`[a-c (),]{0,30}`).Draw(t, "new")
+
+		oldChanged, newChanged := WordDiff(oldText, newText)
+
+		if kept(oldText, oldChanged) != kept(newText, newChanged) {
+			t.Fatalf("unchanged text differs: %q vs %q", kept(oldText, oldChanged), kept(newText, newChanged))
+		}
+	})
+}
+
+// kept returns text with the spans removed.
+func kept(text string, spans []Span) string {
+	var out strings.Builder
+
+	pos := 0
+	for _, span := range spans {
+		out.WriteString(text[pos:span.Start])
+		pos = span.End
+	}
+
+	out.WriteString(text[pos:])
+
+	return out.String()
+}
@@ -0,0 +1,31 @@
+package jj
+
+import (
+	"regexp"
+	"strings"
+)
+
+// fetchLineRe matches a remote bookmark that moved in jj git fetch output,
+// e.g. "bookmark: main@origin [updated] tracked".
+var fetchLineRe = regexp.MustCompile(`^\s*bookmark:\s+(\S+)\s+\[(?:new|updated)\]`)
+
+// GitFetch fetches from the git remotes (jj git fetch) and returns jj's
+// report of what moved.
+func (r *Runner) GitFetch() (string, error) {
+	return r.RunCombined("git", "fetch")
+}
+
+// FetchedBookmarks returns the remote bookmarks (e.g. "main@origin") that
+// jj git fetch brought new commits for, in the order it reported them.
+// Deleted bookmarks bring none and are left out.
+func FetchedBookmarks(output string) []string {
+	var names []string
+
+	for line := range strings.SplitSeq(stripANSI(output), "\n") {
+		if match := fetchLineRe.FindStringSubmatch(line); match != nil {
+			names = append(names, match[1])
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,85 @@
+package jj
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestFetchedBookmarks(t *testing.T) {
+	output := "bookmark: main@origin     [updated] tracked\n" +
+		"bookmark: push-parser@origin [new] untracked\n" +
+		"bookmark: old@origin      [deleted] untracked\n" +
+		"tag: v1.0@git [new]\n"
+
+	want := []string{"main@origin", "push-parser@origin"}
+	if got := FetchedBookmarks(output); !slices.Equal(got, want) {
+		t.Errorf("FetchedBookmarks() = %v, want %v", got, want)
+	}
+
+	if got := FetchedBookmarks("Nothing changed.\n"); got != nil {
+		t.Errorf("a fetch that changed nothing brought nothing, got %v", got)
+	}
+}
+
+func TestGitFetch_Args(t *testing.T) {
+	args := filepath.Join(t.TempDir(), "args")
+	fakeJJ(t, `echo "$@" >`+args+`
+echo "bookmark: main@origin [updated] tracked" >&2`)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	output, err := runner.GitFetch()
+	if err != nil || !strings.Contains(output, "main@origin") {
+		t.Fatalf("expected jj's report from stderr, got %q, %v", output, err)
+	}
+
+	got, err := os.ReadFile(args)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(string(got), "git fetch") {
+		t.Errorf("expected jj git fetch, got %q", got)
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: every new or updated bookmark line is reported, whatever its
+// alignment, and nothing else is.
+func TestFetchedBookmarks_NewAndUpdated(t *testing.T) {
+	rapid.Check(t, func(rt *rapid.T) {
+		kinds := rapid.SliceOf(rapid.SampledFrom([]string{"new", "updated", "deleted"})).Draw(rt, "kinds")
+
+		var (
+			output strings.Builder
+			want   []string
+		)
+
+		for i, kind := range kinds {
+			name := "b" + strings.Repeat("x", i) + "@origin"
+			pad := strings.Repeat(" ", rapid.IntRange(1, 4).Draw(rt, "pad"))
+			output.WriteString("bookmark: " + name + pad + "[" + kind + "] tracked\n")
+
+			if kind != "deleted" {
+				want = append(want, name)
+			}
+		}
+
+		if got := FetchedBookmarks(output.String()); !slices.Equal(got, want) {
+			rt.Fatalf("FetchedBookmarks() = %v, want %v", got, want)
+		}
+	})
+}
@@ -0,0 +1,343 @@
+package jj
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// HunkEditorCommand is the first argument of chado when jj runs it as the
+// diff editor of an interactive command: chado apply-hunks <patch> <left>
+// <right> writes the left files with the patch's hunks applied to the right.
+const HunkEditorCommand = "apply-hunks"
+
+const (
+	// hunkTool is the merge tool name chado configures to run itself as the
+	// diff editor.
+	hunkTool = "chado-hunks"
+
+	// hunkEditorArgs counts the arguments after HunkEditorCommand.
+	hunkEditorArgs = 3
+)
+
+var (
+	// errHunkMismatch is returned by ApplyHunks when a hunk's old lines are
+	// not in the content, e.g. because the file changed since it was picked.
+	errHunkMismatch = errors.New("hunk does not apply")
+
+	// errHunkEditorArgs is returned by RunHunkEditor for the wrong arguments.
+	errHunkEditorArgs = errors.New("usage: chado " + HunkEditorCommand + " <patch> <left> <right>")
+)
+
+// FormatGitDiff writes files as a git-format diff that ParseGitDiff reads
+// back, for handing picked hunks to the diff editor.
+func FormatGitDiff(files []FileDiff) string {
+	var out strings.Builder
+
+	for _, file := range files {
+		oldPath, newPath := "a/"+file.Path, "b/"+file.Path
+
+		fmt.Fprintf(&out, "diff --git %s %s\n", oldPath, newPath)
+
+		switch file.Status {
+		case FileAdded:
+			out.WriteString("new file mode 100644\n")
+
+			oldPath = "/dev/null"
+		case FileDeleted:
+			out.WriteString("deleted file mode 100644\n")
+
+			newPath = "/dev/null"
+		case FileModified, FileRenamed, FileCopied:
+		}
+
+		fmt.Fprintf(&out, "--- %s\n+++ %s\n", oldPath, newPath)
+
+		for _, hunk := range file.Hunks {
+			writeHunk(&out, hunk)
+		}
+	}
+
+	return out.String()
+}
+
+// writeHunk writes one hunk in git format, with a "\ No newline at end of
+// file" marker after the last line of a version that has none.
+func writeHunk(out *strings.Builder, hunk DiffHunk) {
+	oldCount, newCount := hunk.Counts()
+	fmt.Fprintf(out, "@@ -%d,%d +%d,%d @@\n", hunk.OldStart, oldCount, hunk.NewStart, newCount)
+
+	lastOld, lastNew := -1, -1
+
+	for i, line := range hunk.Lines {
+		if line.Kind != DiffAdded {
+			lastOld = i
+		}
+
+		if line.Kind != DiffRemoved {
+			lastNew = i
+		}
+	}
+
+	for i, line := range hunk.Lines {
+		switch line.Kind {
+		case DiffAdded:
+			out.WriteString("+" + line.Text + "\n")
+		case DiffRemoved:
+			out.WriteString("-" + line.Text + "\n")
+		case DiffContext:
+			out.WriteString(" " + line.Text + "\n")
+		}
+
+		if (i == lastOld && hunk.OldNoNewline) || (i == lastNew && hunk.NewNoNewline) {
+			out.WriteString("\\ No newline at end of file\n")
+		}
+	}
+}
+
+// Counts returns how many lines of the old and new versions the hunk spans.
+func (h DiffHunk) Counts() (int, int) {
+	var oldCount, newCount int
+
+	for _, line := range h.Lines {
+		if line.Kind != DiffAdded {
+			oldCount++
+		}
+
+		if line.Kind != DiffRemoved {
+			newCount++
+		}
+	}
+
+	return oldCount, newCount
+}
+
+// ReverseDiff returns the diff that undoes files: the versions swap, so
+// added lines become removed ones and added files deleted ones.
+func ReverseDiff(files []FileDiff) []FileDiff {
+	reversed := make([]FileDiff, len(files))
+
+	for i, file := range files {
+		reversed[i] = FileDiff{Path: file.Path, Status: file.Status, Hunks: make([]DiffHunk, len(file.Hunks))}
+
+		switch file.Status {
+		case FileAdded:
+			reversed[i].Status = FileDeleted
+		case FileDeleted:
+			reversed[i].Status = FileAdded
+		case FileModified, FileRenamed, FileCopied:
+		}
+
+		for j, hunk := range file.Hunks {
+			lines := make([]DiffLine, len(hunk.Lines))
+
+			for k, line := range hunk.Lines {
+				lines[k] = DiffLine{Kind: line.Kind, OldLine: line.NewLine, NewLine: line.OldLine, Text: line.Text}
+
+				switch line.Kind {
+				case DiffAdded:
+					lines[k].Kind = DiffRemoved
+				case DiffRemoved:
+					lines[k].Kind = DiffAdded
+				case DiffContext:
+				}
+			}
+
+			reversed[i].Hunks[j] = DiffHunk{
+				OldStart:     hunk.NewStart,
+				NewStart:     hunk.OldStart,
+				Lines:        lines,
+				OldNoNewline: hunk.NewNoNewline,
+				NewNoNewline: hunk.OldNoNewline,
+			}
+		}
+	}
+
+	return reversed
+}
+
+// ApplyHunks returns content with hunks applied, in order. Each hunk's
+// context and removed lines must be where its header says, or an error
+// wrapping errHunkMismatch is returned.
+func ApplyHunks(content string, hunks []DiffHunk) (string, error) {
+	old, newline := splitLines(content)
+
+	var out []string
+
+	pos := 0
+
+	for _, hunk := range hunks {
+		// A hunk without old lines inserts after its start line
+		start := hunk.OldStart - 1
+		if oldCount, _ := hunk.Counts(); oldCount == 0 {
+			start = hunk.OldStart
+		}
+
+		if start < pos || start > len(old) {
+			return "", fmt.Errorf("%w at line %d", errHunkMismatch, hunk.OldStart)
+		}
+
+		out = append(out, old[pos:start]...)
+		pos = start
+
+		for _, line := range hunk.Lines {
+			if line.Kind != DiffAdded {
+				if pos >= len(old) || old[pos] != line.Text {
+					return "", fmt.Errorf("%w at line %d", errHunkMismatch, pos+1)
+				}
+
+				pos++
+			}
+
+			if line.Kind != DiffRemoved {
+				out = append(out, line.Text)
+			}
+		}
+
+		if pos == len(old) {
+			newline = !hunk.NewNoNewline
+		}
+	}
+
+	out = append(out, old[pos:]...)
+
+	if len(out) == 0 {
+		return "", nil
+	}
+
+	result := strings.Join(out, "\n")
+	if newline {
+		result += "\n"
+	}
+
+	return result, nil
+}
+
+// splitLines splits content into lines and reports whether it ends with a
+// newline.
+func splitLines(content string) ([]string, bool) {
+	if content == "" {
+		return nil, true
+	}
+
+	trimmed, newline := strings.CutSuffix(content, "\n")
+
+	return strings.Split(trimmed, "\n"), newline
+}
+
+// RunHunkEditor is chado run as jj's diff editor, with the arguments after
+// HunkEditorCommand: it reads the patch and writes each file in it to the
+// right directory as it is in the left one with the patch's hunks applied.
+func RunHunkEditor(args []string) error {
+	if len(args) != hunkEditorArgs {
+		return errHunkEditorArgs
+	}
+
+	patch, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("reading hunks: %w", err)
+	}
+
+	_, files := ParseGitDiff(string(patch))
+
+	for _, file := range files {
+		if err := applyFileHunks(file, args[1], args[2]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyFileHunks writes file's left version with its hunks applied to the
+// right directory, removing it when a deletion leaves nothing.
+func applyFileHunks(file FileDiff, left, right string) error {
+	leftPath := filepath.Join(left, filepath.FromSlash(file.Path))
+	rightPath := filepath.Join(right, filepath.FromSlash(file.Path))
+
+	content, err := os.ReadFile(leftPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("reading %s: %w", file.Path, err)
+	}
+
+	result, err := ApplyHunks(string(content), file.Hunks)
+	if err != nil {
+		return fmt.Errorf("%s: %w", file.Path, err)
+	}
+
+	if result == "" && file.Status == FileDeleted {
+		if err := os.Remove(rightPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("removing %s: %w", file.Path, err)
+		}
+
+		return nil
+	}
+
+	// Keep the file's mode, from either version
+	mode := fs.FileMode(0o644)
+	if info, err := os.Stat(rightPath); err == nil {
+		mode = info.Mode().Perm()
+	} else if info, err := os.Stat(leftPath); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	if err := os.MkdirAll(filepath.Dir(rightPath), 0o755); err != nil {
+		return fmt.Errorf("writing %s: %w", file.Path, err)
+	}
+
+	if err := os.WriteFile(rightPath, []byte(result), mode); err != nil {
+		return fmt.Errorf("writing %s: %w", file.Path, err)
+	}
+
+	return nil
+}
+
+// runHunkEditor runs an interactive jj command, limited to the files in
+// patch, with chado as its diff editor taking just the hunks in patch.
+func (r *Runner) runHunkEditor(args []string, patch []FileDiff) error {
+	program, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("finding chado to edit the diff: %w", err)
+	}
+
+	file, err := os.CreateTemp("", "chado-hunks-*.patch")
+	if err != nil {
+		return fmt.Errorf("writing hunks: %w", err)
+	}
+
+	defer func() { _ = os.Remove(file.Name()) }()
+
+	_, err = file.WriteString(FormatGitDiff(patch))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("writing hunks: %w", err)
+	}
+
+	editArgs := []string{HunkEditorCommand, file.Name(), "$left", "$right"}
+	for i, arg := range editArgs {
+		editArgs[i] = strconv.Quote(arg)
+	}
+
+	args = append(args, "--interactive", "--tool", hunkTool,
+		"--config", "ui.diff-instructions=false",
+		"--config", "merge-tools."+hunkTool+".program="+strconv.Quote(program),
+		"--config", "merge-tools."+hunkTool+".edit-args=["+strings.Join(editArgs, ", ")+"]")
+
+	paths := make([]string, len(patch))
+	for i, file := range patch {
+		paths[i] = file.Path
+	}
+
+	args = append(args, filePatterns(paths)...)
+
+	_, err = r.Run(args...)
+
+	return err
+}
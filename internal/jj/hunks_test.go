@@ -0,0 +1,308 @@
+package jj
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// twoHunkDiff changes the second and ninth lines of a ten-line file.
+const twoHunkDiff = `diff --git a/list.txt b/list.txt
+--- a/list.txt
++++ b/list.txt
+@@ -1,3 +1,3 @@
+ one
+-two
++TWO
+ three
+@@ -8,3 +8,3 @@
+ eight
+-nine
++NINE
+ ten
+`
+
+// twoHunkOld is the old version of the file in twoHunkDiff.
+const twoHunkOld = "one\ntwo\nthree\nfour\nfive\nsix\nseven\neight\nnine\nten\n"
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestApplyHunks_PicksHunks(t *testing.T) {
+	_, files := ParseGitDiff(twoHunkDiff)
+	hunks := files[0].Hunks
+
+	tests := []struct {
+		name  string
+		hunks []DiffHunk
+		want  string
+	}{
+		{"none", nil, twoHunkOld},
+		{"first", hunks[:1], strings.Replace(twoHunkOld, "two", "TWO", 1)},
+		{"second", hunks[1:], strings.Replace(twoHunkOld, "nine", "NINE", 1)},
+		{"both", hunks, strings.Replace(strings.Replace(twoHunkOld, "two", "TWO", 1), "nine", "NINE", 1)},
+	}
+
+	for _, tt := range tests {
+		got, err := ApplyHunks(twoHunkOld, tt.hunks)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+
+		if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestApplyHunks_RefusesChangedFile(t *testing.T) {
+	_, files := ParseGitDiff(twoHunkDiff)
+
+	_, err := ApplyHunks(strings.Replace(twoHunkOld, "two", "deux", 1), files[0].Hunks)
+	if !errors.Is(err, errHunkMismatch) {
+		t.Errorf("a hunk whose lines moved on should not apply, got %v", err)
+	}
+}
+
+func TestApplyHunks_NoNewlineAtEnd(t *testing.T) {
+	_, files := ParseGitDiff("diff --git a/f b/f\n--- a/f\n+++ b/f\n@@ -1,1 +1,1 @@\n-old\n\\ No newline at end of file\n+new\n")
+	hunk := files[0].Hunks[0]
+
+	if !hunk.OldNoNewline || hunk.NewNoNewline {
+		t.Fatalf("the marker should apply to the removed line, got %+v", hunk)
+	}
+
+	if got, _ := ApplyHunks("old", files[0].Hunks); got != "new\n" {
+		t.Errorf("got %q, want the newline added", got)
+	}
+
+	if got, _ := ApplyHunks("new\n", ReverseDiff(files)[0].Hunks); got != "old" {
+		t.Errorf("reversed got %q, want the newline dropped", got)
+	}
+}
+
+func TestFormatGitDiff_RoundTrips(t *testing.T) {
+	_, files := ParseGitDiff(sampleGitDiff)
+
+	_, again := ParseGitDiff(FormatGitDiff(files))
+
+	if len(again) != len(files) || again[1].Status != FileAdded {
+		t.Fatalf("expected the files back, got %+v", again)
+	}
+
+	for i := range files {
+		if !hunksEqual(again[i].Hunks, files[i].Hunks) {
+			t.Errorf("%s: hunks changed: %+v", files[i].Path, again[i].Hunks)
+		}
+	}
+}
+
+func TestRunHunkEditor(t *testing.T) {
+	left, right := t.TempDir(), t.TempDir()
+
+	writeFile(t, filepath.Join(left, "list.txt"), twoHunkOld)
+	writeFile(t, filepath.Join(right, "list.txt"), "whatever jj put there\n")
+	writeFile(t, filepath.Join(left, "gone.txt"), "bye\n")
+	writeFile(t, filepath.Join(right, "gone.txt"), "bye\n")
+
+	_, files := ParseGitDiff(twoHunkDiff +
+		"diff --git a/gone.txt b/gone.txt\ndeleted file mode 100644\n--- a/gone.txt\n+++ /dev/null\n@@ -1,1 +0,0 @@\n-bye\n" +
+		"diff --git a/dir/new.txt b/dir/new.txt\nnew file mode 100644\n--- /dev/null\n+++ b/dir/new.txt\n@@ -0,0 +1,1 @@\n+hi\n")
+	files[0].Hunks = files[0].Hunks[1:]
+
+	patch := filepath.Join(t.TempDir(), "hunks.patch")
+	writeFile(t, patch, FormatGitDiff(files))
+
+	if err := RunHunkEditor([]string{patch, left, right}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, _ := os.ReadFile(filepath.Join(right, "list.txt")); string(got) != strings.Replace(twoHunkOld, "nine", "NINE", 1) {
+		t.Errorf("only the picked hunk should apply, got %q", got)
+	}
+
+	if got, _ := os.ReadFile(filepath.Join(right, "dir", "new.txt")); string(got) != "hi\n" {
+		t.Errorf("an added file should be written, got %q", got)
+	}
+
+	if _, err := os.Stat(filepath.Join(right, "gone.txt")); !errors.Is(err, os.ErrNotExist) {
+		t.Error("a fully deleted file should be removed")
+	}
+
+	if err := RunHunkEditor([]string{patch}); !errors.Is(err, errHunkEditorArgs) {
+		t.Errorf("expected a usage error, got %v", err)
+	}
+}
+
+func TestHunkCommands_Args(t *testing.T) {
+	args := filepath.Join(t.TempDir(), "args")
+	fakeJJ(t, `echo "$@" >`+args)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+	_, files := ParseGitDiff(twoHunkDiff)
+
+	tests := []struct {
+		name string
+		run  func() error
+		want string
+	}{
+		{"squash", func() error { return runner.SquashHunks("xsssnyux", "", files) }, "squash -r xsssnyux --interactive --tool chado-hunks"},
+		{"squash into", func() error { return runner.SquashHunks("xsssnyux", "kkmpptxz", files) }, "squash --from xsssnyux --into kkmpptxz --interactive"},
+		{"discard", func() error { return runner.DiscardHunks("xsssnyux", files) }, "restore --changes-in xsssnyux --interactive"},
+	}
+
+	for _, tt := range tests {
+		if err := tt.run(); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+
+		got, _ := os.ReadFile(args)
+		ran := strings.TrimSpace(string(got))
+
+		if !strings.HasPrefix(ran, tt.want) || !strings.HasSuffix(ran, ` root-file:"list.txt"`) {
+			t.Errorf("%s: ran jj %s, want jj %s ... root-file:\"list.txt\"", tt.name, ran, tt.want)
+		}
+
+		if !strings.Contains(ran, "merge-tools.chado-hunks.edit-args=[\""+HunkEditorCommand+"\"") {
+			t.Errorf("%s: chado should be the diff editor, ran jj %s", tt.name, ran)
+		}
+	}
+}
+
+func TestHunkDiff_Args(t *testing.T) {
+	fakeJJ(t, `echo "$@"`)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	output, err := runner.HunkDiff("xsssnyux", "my list~1.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `root-file:"my list~1.txt"`; !strings.Contains(output, want) {
+		t.Errorf("the path should be passed as %s, ran jj %s", want, output)
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: applying a hunk gives its new version, and applying the reversed
+// hunk to that gives the old version back
+func TestApplyHunks_ReverseUndoes(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		hunk, oldContent, newContent := drawHunk(t)
+
+		got, err := ApplyHunks(oldContent, []DiffHunk{hunk})
+		if err != nil || got != newContent {
+			t.Fatalf("applying gave %q (%v), want %q", got, err, newContent)
+		}
+
+		reversed := ReverseDiff([]FileDiff{{Path: "f", Hunks: []DiffHunk{hunk}}})
+
+		got, err = ApplyHunks(newContent, reversed[0].Hunks)
+		if err != nil || got != oldContent {
+			t.Fatalf("reversing gave %q (%v), want %q", got, err, oldContent)
+		}
+	})
+}
+
+// Property: a formatted diff parses back to the same hunks
+func TestFormatGitDiff_ParsesBack(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		hunk, _, _ := drawHunk(t)
+		files := []FileDiff{{Path: "f", Status: FileModified, Hunks: []DiffHunk{hunk}}}
+
+		_, parsed := ParseGitDiff(FormatGitDiff(files))
+
+		if len(parsed) != 1 || !hunksEqual(parsed[0].Hunks, files[0].Hunks) {
+			t.Fatalf("parsed %+v from\n%s", parsed, FormatGitDiff(files))
+		}
+	})
+}
+
+// drawHunk draws a hunk spanning a whole file, with the old and new contents
+// it turns one into the other.
+func drawHunk(t *rapid.T) (DiffHunk, string, string) {
+	kinds := rapid.SliceOfN(rapid.SampledFrom([]DiffLineKind{DiffContext, DiffAdded, DiffRemoved}), 1, 12).Draw(t, "kinds")
+
+	var (
+		hunk               DiffHunk
+		oldLines, newLines []string
+	)
+
+	for _, kind := range kinds {
+		text := rapid.StringMatching(`[a-z ]{0,6}`).Draw(t, "text")
+		line := DiffLine{Kind: kind, Text: text}
+
+		if kind != DiffAdded {
+			oldLines = append(oldLines, text)
+			line.OldLine = len(oldLines)
+		}
+
+		if kind != DiffRemoved {
+			newLines = append(newLines, text)
+			line.NewLine = len(newLines)
+		}
+
+		hunk.Lines = append(hunk.Lines, line)
+	}
+
+	if len(oldLines) > 0 {
+		hunk.OldStart = 1
+	}
+
+	if len(newLines) > 0 {
+		hunk.NewStart = 1
+	}
+
+	return hunk, joinLines(oldLines), joinLines(newLines)
+}
+
+// joinLines joins lines into newline-terminated file content.
+func joinLines(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// hunksEqual compares hunks by position, line kinds and text.
+func hunksEqual(a, b []DiffHunk) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i].OldStart != b[i].OldStart || a[i].NewStart != b[i].NewStart ||
+			a[i].OldNoNewline != b[i].OldNoNewline || a[i].NewNoNewline != b[i].NewNoNewline ||
+			len(a[i].Lines) != len(b[i].Lines) {
+			return false
+		}
+
+		for j := range a[i].Lines {
+			if a[i].Lines[j].Kind != b[i].Lines[j].Kind || a[i].Lines[j].Text != b[i].Lines[j].Text {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// writeFile writes a test file, failing the test if it can't.
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
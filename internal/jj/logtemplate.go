@@ -0,0 +1,45 @@
+package jj
+
+// LogTemplates are jj's built-in log templates, from the least detail to the
+// most, for switching between.
+var LogTemplates = []string{
+	"builtin_log_oneline",
+	"builtin_log_compact",
+	"builtin_log_comfortable",
+	"builtin_log_detailed",
+}
+
+// changeLedTemplates start each entry with its change ID, the way chado
+// finds entries in the log. Other templates get it put in front.
+var changeLedTemplates = map[string]bool{
+	"builtin_log_oneline":     true,
+	"builtin_log_compact":     true,
+	"builtin_log_comfortable": true,
+}
+
+// SetLogTemplate selects the template Log, PlainLog, and LogAtOp render
+// changes with: a built-in's name, such as builtin_log_detailed, or a
+// template of one's own. Empty leaves it to jj's templates.log setting.
+func (r *Runner) SetLogTemplate(template string) {
+	r.configure(func(s *runnerSettings) { s.logTemplate = template })
+}
+
+// LogTemplate returns the template set by SetLogTemplate.
+func (r *Runner) LogTemplate() string {
+	return r.current().logTemplate
+}
+
+// templateArgs adds the log template to a log command, led by the change
+// ID when it doesn't start with one.
+func (r *Runner) templateArgs(args ...string) []string {
+	template := r.current().logTemplate
+
+	switch {
+	case template == "":
+		return args
+	case changeLedTemplates[template]:
+		return append(args, "-T", template)
+	default:
+		return append(args, "-T", `format_short_change_id(change_id) ++ " " ++ (`+template+`)`)
+	}
+}
@@ -0,0 +1,84 @@
+package jj
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestLogTemplate_Args(t *testing.T) {
+	fakeJJ(t, `printf '%s\n' "$*"`)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{"", "log --color=always --ignore-working-copy"},
+		{"builtin_log_oneline", "log --color=always -T builtin_log_oneline --ignore-working-copy"},
+		{"builtin_log_detailed", `log --color=always -T format_short_change_id(change_id) ++ " " ++ (builtin_log_detailed) --ignore-working-copy`},
+	}
+
+	for _, tt := range tests {
+		runner.SetLogTemplate(tt.template)
+
+		if output, _ := runner.Log(0); strings.TrimSpace(output) != tt.want {
+			t.Errorf("template %q: ran jj %s, want jj %s", tt.template, strings.TrimSpace(output), tt.want)
+		}
+	}
+}
+
+func TestLogTemplate_MetadataKeepsItsOwn(t *testing.T) {
+	fakeJJ(t, `printf '%s\n' "$*"`)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+	runner.SetLogTemplate("builtin_log_detailed")
+
+	if output, _ := runner.LogMetadata(0); strings.Contains(output, "builtin_log_detailed") {
+		t.Errorf("the metadata has a template of its own, ran jj %s", output)
+	}
+}
+
+// Run with -race: the template is picked while a log loads.
+func TestLogTemplate_ChangedWhileLogLoads(t *testing.T) {
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+	pinned := runner.Pinned()
+
+	var wg sync.WaitGroup
+
+	wg.Go(func() {
+		for range 100 {
+			runner.SetLogTemplate("builtin_log_oneline")
+			runner.SetLogTemplate("")
+		}
+	})
+
+	for range 100 {
+		pinned.templateArgs("log")
+	}
+
+	wg.Wait()
+
+	if got := pinned.templateArgs("log"); len(got) != 1 {
+		t.Errorf("a pinned runner should keep the template it was pinned with, got %v", got)
+	}
+}
+
+func TestParseLogLines_LedByChangeID(t *testing.T) {
+	runner := NewRunner(context.Background(), ".", testLogger(t))
+
+	// builtin_log_detailed with the change ID put in front
+	output := "@  xsssnyux Commit ID: 1a2b3c4d5e6f\n│  Change ID: xsssnyuxkqnmzvtl\n│  Author: user\n│\n│      feature\n│\n" +
+		"○  zzzzzzzz Commit ID: 000000000000\n   Change ID: zzzzzzzzzzzzzzzz\n"
+
+	changes := runner.ParseLogLines(output)
+	if len(changes) != 2 || changes[0].ChangeID != "xsssnyux" || changes[1].ChangeID != "zzzzzzzz" {
+		t.Errorf("expected the two changes by ID, got %+v", changes)
+	}
+}
@@ -0,0 +1,136 @@
+package jj
+
+import (
+	"unicode"
+)
+
+const (
+	// minMovedAlnum is how many letters and digits a block of lines needs
+	// to be marked moved, as git's --color-moved asks, so that a lone
+	// brace or blank line matching one elsewhere is not.
+	minMovedAlnum = 20
+
+	// maxMoveCandidates bounds the removed lines a line is matched against,
+	// for lines such as "}" that a large diff removes in many places.
+	maxMoveCandidates = 64
+)
+
+// lineRef locates a line within the files of a diff.
+type lineRef struct {
+	file, hunk, line int
+}
+
+// runPos locates a line within the runs of removed lines.
+type runPos struct {
+	run, index int
+}
+
+// MarkMovedLines marks the blocks of lines that files remove in one place
+// and add verbatim in another, as git diff --color-moved does: each run of
+// added lines is matched, longest first, against runs of removed lines.
+func MarkMovedLines(files []FileDiff) {
+	removed := lineRuns(files, DiffRemoved)
+	added := lineRuns(files, DiffAdded)
+
+	byText := make(map[string][]runPos)
+
+	for r, run := range removed {
+		for i, ref := range run {
+			text := lineAt(files, ref).Text
+			if len(byText[text]) < maxMoveCandidates {
+				byText[text] = append(byText[text], runPos{run: r, index: i})
+			}
+		}
+	}
+
+	for _, run := range added {
+		for i := 0; i < len(run); {
+			from, length := longestMatch(files, run[i:], removed, byText)
+			if length == 0 || alnumCount(files, run[i:i+length]) < minMovedAlnum {
+				i++
+				continue
+			}
+
+			for k := range length {
+				lineAt(files, run[i+k]).Moved = true
+				lineAt(files, removed[from.run][from.index+k]).Moved = true
+			}
+
+			i += length
+		}
+	}
+}
+
+// lineRuns returns the runs of consecutive lines of kind in each hunk.
+func lineRuns(files []FileDiff, kind DiffLineKind) [][]lineRef {
+	var runs [][]lineRef
+
+	for f, file := range files {
+		for h, hunk := range file.Hunks {
+			var run []lineRef
+
+			for l, line := range hunk.Lines {
+				if line.Kind == kind {
+					run = append(run, lineRef{file: f, hunk: h, line: l})
+					continue
+				}
+
+				if len(run) > 0 {
+					runs = append(runs, run)
+					run = nil
+				}
+			}
+
+			if len(run) > 0 {
+				runs = append(runs, run)
+			}
+		}
+	}
+
+	return runs
+}
+
+// longestMatch finds the removed run position whose lines match the most
+// of added from its start, and how many lines match.
+func longestMatch(files []FileDiff, added []lineRef, removed [][]lineRef, byText map[string][]runPos) (runPos, int) {
+	var (
+		best    runPos
+		longest int
+	)
+
+	for _, candidate := range byText[lineAt(files, added[0]).Text] {
+		run := removed[candidate.run][candidate.index:]
+
+		length := 0
+		for length < len(run) && length < len(added) &&
+			lineAt(files, run[length]).Text == lineAt(files, added[length]).Text {
+			length++
+		}
+
+		if length > longest {
+			best, longest = candidate, length
+		}
+	}
+
+	return best, longest
+}
+
+// alnumCount counts the letters and digits in lines.
+func alnumCount(files []FileDiff, lines []lineRef) int {
+	count := 0
+
+	for _, ref := range lines {
+		for _, r := range lineAt(files, ref).Text {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+// lineAt returns the line ref locates, for updating in place.
+func lineAt(files []FileDiff, ref lineRef) *DiffLine {
+	return &files[ref.file].Hunks[ref.hunk].Lines[ref.line]
+}
@@ -0,0 +1,112 @@
+package jj
+
+import (
+	"fmt"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// movedGitDiff moves a function from a.go to b.go and changes a brace.
+const movedGitDiff = `diff --git a/a.go b/a.go
+--- a/a.go
++++ b/a.go
+@@ -1,4 +1,2 @@
+ package a
+-func helper() int {
+-	return computeTheAnswer()
+-}
++}
+diff --git a/b.go b/b.go
+--- a/b.go
++++ b/b.go
+@@ -1,1 +1,4 @@
+ package b
++func helper() int {
++	return computeTheAnswer()
++}`
+
+// movedKinds lists which lines of a file's first hunk are marked moved.
+func movedKinds(file FileDiff) string {
+	marks := ""
+
+	for _, line := range file.Hunks[0].Lines {
+		switch {
+		case line.Moved:
+			marks += "m"
+		default:
+			marks += "."
+		}
+	}
+
+	return marks
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestMarkMovedLines_AcrossFiles(t *testing.T) {
+	_, files := ParseGitDiff(movedGitDiff)
+	MarkMovedLines(files)
+
+	if got := movedKinds(files[0]); got != ".mmm." {
+		t.Errorf("a.go moved lines = %q, want the removed function marked", got)
+	}
+
+	if got := movedKinds(files[1]); got != ".mmm" {
+		t.Errorf("b.go moved lines = %q, want the added function marked", got)
+	}
+}
+
+func TestMarkMovedLines_IgnoresShortBlocks(t *testing.T) {
+	_, files := ParseGitDiff("diff --git a/a.go b/a.go\n--- a/a.go\n+++ b/a.go\n" +
+		"@@ -1,3 +1,3 @@\n-}\n x\n+}")
+	MarkMovedLines(files)
+
+	if got := movedKinds(files[0]); got != "..." {
+		t.Errorf("a lone brace should not count as moved, got %q", got)
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Every line marked moved has a line of the same text marked on the other
+// side, and context lines are never marked.
+func TestMarkMovedLines_PairedProperty(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		texts := []string{"func helper() int {", "return computeTheAnswer()", "}", "", "x := 1"}
+
+		var hunk DiffHunk
+		for i := range rapid.IntRange(1, 30).Draw(t, "lines") {
+			kind := DiffLineKind(rapid.IntRange(0, 2).Draw(t, fmt.Sprintf("kind%d", i)))
+			text := rapid.SampledFrom(texts).Draw(t, fmt.Sprintf("text%d", i))
+			hunk.Lines = append(hunk.Lines, DiffLine{Kind: kind, Text: text})
+		}
+
+		files := []FileDiff{{Path: "a.go", Hunks: []DiffHunk{hunk}}}
+		MarkMovedLines(files)
+
+		moved := map[DiffLineKind]map[string]bool{DiffAdded: {}, DiffRemoved: {}}
+
+		for _, line := range files[0].Hunks[0].Lines {
+			if !line.Moved {
+				continue
+			}
+
+			if line.Kind == DiffContext {
+				t.Fatalf("context line %q marked moved", line.Text)
+			}
+
+			moved[line.Kind][line.Text] = true
+		}
+
+		for text := range moved[DiffAdded] {
+			if !moved[DiffRemoved][text] {
+				t.Fatalf("added %q marked moved with no removed line to match", text)
+			}
+		}
+	})
+}
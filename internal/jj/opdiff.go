@@ -0,0 +1,80 @@
+package jj
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// opDiffSectionRe matches the heading of a section of jj op diff
+	// output, e.g. "Changed commits:".
+	opDiffSectionRe = regexp.MustCompile(`^[A-Z][^:]*:$`)
+
+	// opDiffCommitRe captures whether a line of jj op diff's changed commits
+	// adds (+) or removes (-) a commit, after the graph drawn before it.
+	opDiffCommitRe = regexp.MustCompile(`^[│├└─╯╮╭○@◆◇●×~\s]*([+-]) [k-z]{4,}`)
+)
+
+// OpDiffSummary counts the commits jj op diff shows becoming visible and
+// hidden between two operations.
+type OpDiffSummary struct {
+	Added   int
+	Removed int
+}
+
+// String describes the counts, e.g. "adds 2 commits and removes 1".
+func (s OpDiffSummary) String() string {
+	if s.Added == 0 && s.Removed == 0 {
+		return "changes no commits"
+	}
+
+	return fmt.Sprintf("adds %s and removes %d", countCommits(s.Added), s.Removed)
+}
+
+// SummarizeOpDiff counts the commits added and removed in the changed
+// commits of jj op diff output.
+func SummarizeOpDiff(output string) OpDiffSummary {
+	var (
+		summary OpDiffSummary
+		section string
+	)
+
+	for line := range strings.SplitSeq(output, "\n") {
+		stripped := stripANSI(line)
+
+		if opDiffSectionRe.MatchString(stripped) {
+			section = stripped
+			continue
+		}
+
+		if section != "Changed commits:" {
+			continue
+		}
+
+		switch match := opDiffCommitRe.FindStringSubmatch(stripped); {
+		case match == nil:
+		case match[1] == "+":
+			summary.Added++
+		default:
+			summary.Removed++
+		}
+	}
+
+	return summary
+}
+
+// OpRestoreDiff returns what restoring the repo to operation opID (jj op
+// restore) would change: jj op diff from the current operation to it.
+func (r *Runner) OpRestoreDiff(opID string) (string, error) {
+	return r.view("op", "diff", "--from", "@", "--to", opID, r.ColorFlag())
+}
+
+// countCommits counts commits, e.g. "1 commit" or "3 commits".
+func countCommits(count int) string {
+	if count == 1 {
+		return "1 commit"
+	}
+
+	return fmt.Sprintf("%d commits", count)
+}
@@ -0,0 +1,102 @@
+package jj
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// opDiffOutput is jj op diff output restoring an earlier operation: a
+// rewritten commit and a new one go, and the old version comes back.
+const opDiffOutput = `From operation: 1a2b3c4d5e6f (2025-01-01 10:00:00) describe commit abc
+  To operation: 6f5e4d3c2b1a (2025-01-01 09:00:00) new empty commit
+
+Changed commits:
+○  + qpvuntsm 1c2d3e4f (empty) old description
+○  - qpvuntsm/1 5a6b7c8d (empty) new description
+│  - rlvkpnrz 9e8d7c6b add lexer
+
+Changed local bookmarks:
+main:
++ qpvuntsm 1c2d3e4f (empty) old description
+- rlvkpnrz 9e8d7c6b add lexer
+`
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestSummarizeOpDiff(t *testing.T) {
+	got := SummarizeOpDiff(opDiffOutput)
+
+	if got != (OpDiffSummary{Added: 1, Removed: 2}) {
+		t.Errorf("summary = %+v, want 1 added and 2 removed, bookmarks not counted", got)
+	}
+
+	if want := "adds 1 commit and removes 2"; got.String() != want {
+		t.Errorf("String() = %q, want %q", got.String(), want)
+	}
+
+	if got := SummarizeOpDiff("From operation: a\n  To operation: a\n").String(); got != "changes no commits" {
+		t.Errorf("no commits changed should say so, got %q", got)
+	}
+}
+
+func TestSummarizeOpDiff_Colored(t *testing.T) {
+	colored := strings.ReplaceAll(opDiffOutput, "+ qpvuntsm", "\x1b[32m+\x1b[0m \x1b[1mqpvuntsm\x1b[0m")
+
+	if got := SummarizeOpDiff(colored); got.Added != 1 {
+		t.Errorf("colors should be ignored, got %+v", got)
+	}
+}
+
+func TestOpRestoreDiff_Args(t *testing.T) {
+	args := filepath.Join(t.TempDir(), "args")
+	fakeJJ(t, `echo "$@" >`+args)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+	runner.SetAtOperation("cccccccccccc")
+
+	if _, err := runner.OpRestoreDiff("6f5e4d3c2b1a"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, _ := os.ReadFile(args)
+	if want := "op diff --from @ --to 6f5e4d3c2b1a"; !strings.HasPrefix(string(got), want) || strings.Contains(string(got), "--at-op") {
+		t.Errorf("ran jj %s, want jj %s from the present", strings.TrimSpace(string(got)), want)
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+func TestSummarizeOpDiff_CountsCommitLines(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		signs := rapid.SliceOf(rapid.SampledFrom([]string{"+", "-"})).Draw(t, "signs")
+
+		lines := []string{"Changed commits:"}
+		want := OpDiffSummary{}
+
+		for _, sign := range signs {
+			graph := rapid.SampledFrom([]string{"○  ", "│  ", "◆  ", ""}).Draw(t, "graph")
+			lines = append(lines, graph+sign+" qpvuntsm 1c2d3e4f desc")
+
+			if sign == "+" {
+				want.Added++
+			} else {
+				want.Removed++
+			}
+		}
+
+		lines = append(lines, "Changed local bookmarks:", "+ qpvuntsm 1c2d3e4f")
+
+		if got := SummarizeOpDiff(strings.Join(lines, "\n")); got != want {
+			t.Fatalf("summary = %+v, want %+v", got, want)
+		}
+	})
+}
@@ -0,0 +1,38 @@
+package jj
+
+import (
+	"strings"
+)
+
+// GitRemote is a git remote of the repo, as jj git remote list shows it.
+type GitRemote struct {
+	Name string
+	URL  string
+}
+
+// GitRemotes lists the repo's git remotes (jj git remote list).
+func (r *Runner) GitRemotes() ([]GitRemote, error) {
+	output, err := r.view("git", "remote", "list", "--color=never")
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseGitRemotes(output), nil
+}
+
+// ParseGitRemotes reads jj git remote list output: one remote a line, its
+// name and then its URL, e.g. "origin git@github.com:chatter/chado.git".
+func ParseGitRemotes(output string) []GitRemote {
+	var remotes []GitRemote
+
+	for line := range strings.SplitSeq(output, "\n") {
+		name, url, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok || name == "" {
+			continue
+		}
+
+		remotes = append(remotes, GitRemote{Name: name, URL: strings.TrimSpace(url)})
+	}
+
+	return remotes
+}
@@ -0,0 +1,71 @@
+package jj
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestParseGitRemotes(t *testing.T) {
+	output := "origin git@github.com:chatter/chado.git\n" +
+		"upstream https://gitlab.com/jj/chado\n\n"
+
+	want := []GitRemote{
+		{Name: "origin", URL: "git@github.com:chatter/chado.git"},
+		{Name: "upstream", URL: "https://gitlab.com/jj/chado"},
+	}
+
+	if got := ParseGitRemotes(output); !slices.Equal(got, want) {
+		t.Errorf("ParseGitRemotes() = %+v, want %+v", got, want)
+	}
+}
+
+func TestGitRemotes_Args(t *testing.T) {
+	args := filepath.Join(t.TempDir(), "args")
+	fakeJJ(t, `echo "$@" >`+args+`
+echo "origin https://github.com/chatter/chado.git"`)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	remotes, err := runner.GitRemotes()
+	if err != nil || len(remotes) != 1 || remotes[0].Name != "origin" {
+		t.Fatalf("GitRemotes() = %+v, %v", remotes, err)
+	}
+
+	if got, _ := os.ReadFile(args); strings.TrimSpace(string(got)) != "git remote list --color=never --ignore-working-copy" {
+		t.Errorf("ran jj %s", strings.TrimSpace(string(got)))
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+func TestParseGitRemotes_RoundTrip(t *testing.T) {
+	rapid.Check(t, func(rt *rapid.T) {
+		want := rapid.SliceOf(rapid.Custom(func(rt *rapid.T) GitRemote {
+			return GitRemote{
+				Name: rapid.StringMatching(`[a-z][a-z0-9-]{0,10}`).Draw(rt, "name"),
+				URL:  rapid.StringMatching(`(https://|git@)[a-z]{1,10}\.[a-z]{2,3}[:/][a-z]{1,8}/[a-z]{1,8}(\.git)?`).Draw(rt, "url"),
+			}
+		})).Draw(rt, "remotes")
+
+		var output strings.Builder
+		for _, remote := range want {
+			output.WriteString(remote.Name + " " + remote.URL + "\n")
+		}
+
+		if got := ParseGitRemotes(output.String()); !slices.Equal(got, want) {
+			rt.Fatalf("ParseGitRemotes() = %+v, want %+v", got, want)
+		}
+	})
+}
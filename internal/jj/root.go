@@ -0,0 +1,35 @@
+package jj
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ErrNotRepo is returned by FindRoot when no directory up to the filesystem
+// root holds a .jj directory.
+var ErrNotRepo = errors.New("not a jj repository (or any parent up to mount point /)")
+
+// FindRoot returns the root of the jj repository containing dir: the
+// nearest of dir and its parents that holds a .jj directory.
+func FindRoot(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("resolving %s: %w", dir, err)
+	}
+
+	for {
+		info, err := os.Stat(filepath.Join(dir, ".jj"))
+		if err == nil && info.IsDir() {
+			return dir, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ErrNotRepo
+		}
+
+		dir = parent
+	}
+}
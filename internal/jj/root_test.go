@@ -0,0 +1,62 @@
+package jj
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestFindRoot_FromSubdirectory(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "src", "pkg")
+
+	for _, dir := range []string{filepath.Join(root, ".jj"), sub} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, dir := range []string{root, sub} {
+		got, err := FindRoot(dir)
+		if err != nil {
+			t.Fatalf("FindRoot(%s): %v", dir, err)
+		}
+
+		if got != root {
+			t.Errorf("FindRoot(%s) = %s, want %s", dir, got, root)
+		}
+	}
+}
+
+func TestFindRoot_NestedRepoWins(t *testing.T) {
+	outer := t.TempDir()
+	inner := filepath.Join(outer, "vendor", "lib")
+
+	for _, dir := range []string{filepath.Join(outer, ".jj"), filepath.Join(inner, ".jj")} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got, err := FindRoot(inner); err != nil || got != inner {
+		t.Errorf("FindRoot(%s) = %s, %v; want the nearest repository", inner, got, err)
+	}
+}
+
+func TestFindRoot_NotRepo(t *testing.T) {
+	dir := t.TempDir()
+
+	// A .jj file is not a repository
+	if err := os.WriteFile(filepath.Join(dir, ".jj"), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := FindRoot(dir); !errors.Is(err, ErrNotRepo) {
+		t.Errorf("expected ErrNotRepo, got %v", err)
+	}
+}
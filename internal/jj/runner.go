@@ -5,10 +5,17 @@ import (
 	"bytes"
 	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"math"
 	"os/exec"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/chatter/chado/internal/logger"
 )
@@ -19,15 +26,344 @@ type Runner struct {
 	workDir   string
 	log       *logger.Logger
 	templates *Templates
+
+	revset string // revisions the log shows; empty for jj's revsets.log
+
+	// Shared by runners derived via WithContext
+	settings     *atomic.Pointer[runnerSettings]
+	dryRun       *dryRunProbes
+	versionProbe *versionProbe
+	recovery     *recovery
+}
+
+// runnerSettings shape what commands show. The UI changes them while
+// commands run in the background, so they are never edited in place: a
+// change stores an edited copy, and each command reads one copy whole.
+type runnerSettings struct {
+	diffFormat  DiffFormat // format of Show, Diff, and DiffFile output
+	diffSpace   bool       // diffs ignore whitespace (--ignore-all-space)
+	diffContext int        // lines of context diffs show; negative for jj's config
+	atOp        string     // operation display commands read the repo at; empty for now
+	logTemplate string     // template the log renders changes with; empty for jj's templates.log
+	plain       bool       // output without colors, as jj's ui.color = "never" asks
 }
 
+// dryRunProbes caches which subcommands accept --dry-run.
+type dryRunProbes struct {
+	mu        sync.Mutex      // commands run from concurrent tea.Cmds
+	supported map[string]bool // subcommand -> supports --dry-run
+}
+
+// recovery tracks how commands get past a stale working copy or a repo lock
+// held by another process, across concurrent commands.
+type recovery struct {
+	mu        sync.Mutex // one command at a time updates a stale working copy
+	notices   chan string
+	lockWaits atomic.Int32 // commands waiting to retry past the repo lock
+}
+
+// lockRetries is how many times a command held up by the repo lock is
+// retried, after pauses doubling from lockRetryBase (3.1s in all).
+const lockRetries = 5
+
+// lockRetryBase is the first pause before retrying past the repo lock.
+var lockRetryBase = 100 * time.Millisecond
+
+// repoLockedRe matches jj's errors for a lock another process holds: its
+// own locks, and git's index.lock during import and export.
+var repoLockedRe = regexp.MustCompile(`(?i)failed to (?:acquire )?lock|unable to create .*\.lock|another git process`)
+
+// noticeBuffer is how many notices wait for the UI before more are dropped.
+const noticeBuffer = 8
+
+// staleWorkingCopyText is in jj's error when another workspace or an
+// interrupted command left this working copy behind the repo.
+const staleWorkingCopyText = "working copy is stale"
+
+// ErrDryRunUnsupported is returned by DryRun for subcommands without --dry-run.
+var ErrDryRunUnsupported = errors.New("dry run not supported")
+
+// ErrNotOneChange is returned by ResolveChange for a revision naming no
+// change, or more than one.
+var ErrNotOneChange = errors.New("not a single change")
+
+// dryRunFlag is the flag jj subcommands use to report effects without applying them.
+const dryRunFlag = "--dry-run"
+
+// ignoreWorkingCopyFlag stops jj from snapshotting the working copy before a
+// command, so browsing never records operations of its own.
+const ignoreWorkingCopyFlag = "--ignore-working-copy"
+
 // NewRunner creates a new jj command runner.
 func NewRunner(ctx context.Context, workDir string, log *logger.Logger) *Runner {
-	return &Runner{ctx: ctx, workDir: workDir, log: log, templates: NewTemplates()}
+	settings := &atomic.Pointer[runnerSettings]{}
+	settings.Store(&runnerSettings{diffFormat: DiffFormatColorWords, diffContext: -1})
+
+	return &Runner{
+		ctx:          ctx,
+		workDir:      workDir,
+		log:          log,
+		templates:    NewTemplates(),
+		settings:     settings,
+		dryRun:       &dryRunProbes{supported: make(map[string]bool)},
+		versionProbe: &versionProbe{},
+		recovery:     &recovery{notices: make(chan string, noticeBuffer)},
+	}
+}
+
+// current returns the settings commands run with now.
+func (r *Runner) current() runnerSettings {
+	return *r.settings.Load()
+}
+
+// configure stores a copy of the settings with change applied. Only the UI
+// changes settings, so no other writer comes between the load and store.
+func (r *Runner) configure(change func(*runnerSettings)) {
+	next := r.current()
+	change(&next)
+	r.settings.Store(&next)
+}
+
+// Pinned returns a copy of the runner whose settings stay as they are now,
+// for a load whose commands must agree while the UI changes the settings.
+// Changes to the copy's settings do not reach the runner.
+func (r *Runner) Pinned() *Runner {
+	settings := r.current()
+
+	pinned := *r
+	pinned.settings = &atomic.Pointer[runnerSettings]{}
+	pinned.settings.Store(&settings)
+
+	return &pinned
+}
+
+// SetDiffFormat selects the format of diffs returned by Show, Diff, and
+// DiffFile. Unknown formats leave it to jj's ui.diff-formatter.
+func (r *Runner) SetDiffFormat(format DiffFormat) {
+	r.configure(func(s *runnerSettings) { s.diffFormat = format })
+}
+
+// DiffFormat returns the format set by SetDiffFormat.
+func (r *Runner) DiffFormat() DiffFormat {
+	return r.current().diffFormat
+}
+
+// SetIgnoreWhitespace sets whether diffs returned by Show, Diff, and
+// DiffFile leave out changes in whitespace.
+func (r *Runner) SetIgnoreWhitespace(ignore bool) {
+	r.configure(func(s *runnerSettings) { s.diffSpace = ignore })
+}
+
+// IgnoreWhitespace reports whether diffs leave out changes in whitespace.
+func (r *Runner) IgnoreWhitespace() bool {
+	return r.current().diffSpace
+}
+
+// SetDiffContext sets the lines of context around each change in diffs
+// returned by Show, Diff, and DiffFile; negative leaves it to jj's config.
+func (r *Runner) SetDiffContext(lines int) {
+	r.configure(func(s *runnerSettings) { s.diffContext = lines })
+}
+
+// DiffContext returns the lines of context set by SetDiffContext, negative
+// when left to jj's config.
+func (r *Runner) DiffContext() int {
+	return r.current().diffContext
+}
+
+// SetColored sets whether jj colors the output chado shows, as the
+// ui.color setting says.
+func (r *Runner) SetColored(colored bool) {
+	r.configure(func(s *runnerSettings) { s.plain = !colored })
+}
+
+// ColorFlag returns the --color flag for output chado shows.
+func (r *Runner) ColorFlag() string {
+	if r.current().plain {
+		return "--color=never"
+	}
+
+	return "--color=always"
+}
+
+// diffArgs appends the flags for the configured diff format and options
+// to args.
+func (r *Runner) diffArgs(args ...string) []string {
+	settings := r.current()
+
+	switch settings.diffFormat {
+	case DiffFormatGit:
+		args = append(args, "--git")
+	case DiffFormatColorWords:
+		args = append(args, "--color-words")
+	case DiffFormatStat:
+		args = append(args, "--stat")
+	case DiffFormatDefault:
+	}
+
+	if settings.diffSpace {
+		args = append(args, "--ignore-all-space")
+	}
+
+	if settings.diffContext >= 0 {
+		args = append(args, "--context", strconv.Itoa(settings.diffContext))
+	}
+
+	return args
+}
+
+// WithContext returns a runner whose commands are bound to ctx, so cancelling
+// ctx kills any jj process it started. Used to abandon superseded requests.
+func (r *Runner) WithContext(ctx context.Context) *Runner {
+	derived := *r
+	derived.ctx = ctx
+
+	return &derived
 }
 
 // Run executes a jj command and returns the output with colors preserved.
 func (r *Runner) Run(args ...string) (string, error) {
+	stdout, _, err := r.run(args...)
+	return stdout, err
+}
+
+// view executes a display-only jj command without snapshotting the working
+// copy. Edits show up once Snapshot or a command changing the repo records them.
+func (r *Runner) view(args ...string) (string, error) {
+	return r.Run(r.viewArgs(args...)...)
+}
+
+// viewArgs adds the flags of a display-only command to args: no snapshot, and
+// the operation set by SetAtOperation. Op commands ignore that operation, so
+// the op log still leads back to the present.
+func (r *Runner) viewArgs(args ...string) []string {
+	args = append(args, ignoreWorkingCopyFlag)
+
+	if atOp := r.current().atOp; atOp != "" && args[0] != "op" {
+		args = append(args, "--at-op", atOp)
+	}
+
+	return args
+}
+
+// SetAtOperation makes display-only commands show the repo as it was right
+// after operation opID. Empty returns them to the present.
+func (r *Runner) SetAtOperation(opID string) {
+	r.configure(func(s *runnerSettings) { s.atOp = opID })
+}
+
+// Snapshot records edits in the working copy into @. Any jj command not told
+// to ignore the working copy snapshots it first; this one prints nothing else.
+func (r *Runner) Snapshot() error {
+	_, err := r.Run("log", "-r", "@", "--no-graph", "-T", `""`)
+	return err
+}
+
+// RunCombined executes a jj command and returns stdout followed by stderr.
+// Some commands (e.g. git push) report their effects only on stderr.
+func (r *Runner) RunCombined(args ...string) (string, error) {
+	stdout, stderr, err := r.run(args...)
+	if err != nil {
+		return "", err
+	}
+
+	return stdout + stderr, nil
+}
+
+// Notices delivers what the runner did on its own to keep commands working,
+// e.g. updating a stale working copy, for the UI to report.
+func (r *Runner) Notices() <-chan string {
+	return r.recovery.notices
+}
+
+// WaitingForLock reports whether a command is waiting for another process
+// to release the repo lock.
+func (r *Runner) WaitingForLock() bool {
+	return r.recovery.lockWaits.Load() > 0
+}
+
+// IsRepoLocked reports whether err is jj failing because another process
+// holds a lock on the repo.
+func IsRepoLocked(err error) bool {
+	var jjErr *Error
+	return errors.As(err, &jjErr) && repoLockedRe.MatchString(jjErr.Stderr)
+}
+
+// IsStaleWorkingCopy reports whether err is jj refusing to run because the
+// working copy is stale.
+func IsStaleWorkingCopy(err error) bool {
+	var jjErr *Error
+	return errors.As(err, &jjErr) && strings.Contains(jjErr.Stderr, staleWorkingCopyText)
+}
+
+// run executes a jj command, returning stdout and stderr separately. A
+// command refused for a stale working copy is retried once after jj
+// workspace update-stale.
+func (r *Runner) run(args ...string) (string, string, error) {
+	stdout, stderr, err := r.exec(args...)
+	if !IsStaleWorkingCopy(err) {
+		return stdout, stderr, err
+	}
+
+	return r.recoverStale(args)
+}
+
+// recoverStale updates the stale working copy and retries a command jj
+// refused for it. Commands take turns: the first updates it, and the rest
+// find it fixed on their next try.
+func (r *Runner) recoverStale(args []string) (string, string, error) {
+	r.recovery.mu.Lock()
+	defer r.recovery.mu.Unlock()
+
+	stdout, stderr, err := r.exec(args...)
+	if !IsStaleWorkingCopy(err) {
+		return stdout, stderr, err
+	}
+
+	if _, _, updateErr := r.exec("workspace", "update-stale"); updateErr != nil {
+		r.log.Warn("updating stale working copy failed", "err", updateErr)
+		return stdout, stderr, err
+	}
+
+	select {
+	case r.recovery.notices <- "working copy was stale; ran jj workspace update-stale":
+	default:
+	}
+
+	return r.exec(args...)
+}
+
+// exec runs jj, retrying with doubling pauses while another process holds
+// the repo lock.
+func (r *Runner) exec(args ...string) (string, string, error) {
+	stdout, stderr, err := r.execOnce(args...)
+	if !IsRepoLocked(err) {
+		return stdout, stderr, err
+	}
+
+	r.recovery.lockWaits.Add(1)
+	defer r.recovery.lockWaits.Add(-1)
+
+	for attempt := range lockRetries {
+		select {
+		case <-r.ctx.Done():
+			return stdout, stderr, err
+		case <-time.After(lockRetryBase << attempt):
+		}
+
+		r.log.Info("retrying jj command held up by the repo lock", "args", args, "attempt", attempt+1)
+
+		stdout, stderr, err = r.execOnce(args...)
+		if !IsRepoLocked(err) {
+			break
+		}
+	}
+
+	return stdout, stderr, err
+}
+
+// execOnce runs jj once, returning stdout and stderr separately.
+func (r *Runner) execOnce(args ...string) (string, string, error) {
 	r.log.Debug("executing jj command", "args", args)
 
 	cmd := exec.CommandContext(r.ctx, "jj", args...)
@@ -49,52 +385,181 @@ func (r *Runner) Run(args ...string) (string, error) {
 			}
 			r.log.Error("jj command failed", "args", args, "err", jjErr)
 
-			return "", jjErr
+			return "", "", jjErr
 		}
 
 		r.log.Error("jj command failed", "args", args, "err", err)
 
-		return "", fmt.Errorf("jj command failed: %w", err)
+		return "", "", fmt.Errorf("jj command failed: %w", err)
 	}
 
 	r.log.Debug("jj command completed", "args", args, "output_len", len(stdout.String()))
 
-	return stdout.String(), nil
+	return stdout.String(), stderr.String(), nil
 }
 
-// Log returns the jj log output with colors.
-func (r *Runner) Log() (string, error) {
-	return r.Run("log", "--color=always")
+// SupportsDryRun reports whether a jj subcommand (e.g. "git", "push") accepts
+// --dry-run. The answer is probed from the subcommand's --help once and cached,
+// so newer jj versions that add the flag are picked up without code changes.
+func (r *Runner) SupportsDryRun(subcommand ...string) bool {
+	name := strings.Join(subcommand, " ")
+
+	r.dryRun.mu.Lock()
+	supported, known := r.dryRun.supported[name]
+	r.dryRun.mu.Unlock()
+
+	if known {
+		return supported
+	}
+
+	helpText, err := r.Run(append(slices.Clone(subcommand), "--help")...)
+	supported = err == nil && strings.Contains(helpText, dryRunFlag)
+
+	r.dryRun.mu.Lock()
+	r.dryRun.supported[name] = supported
+	r.dryRun.mu.Unlock()
+
+	return supported
+}
+
+// DryRun runs a subcommand with --dry-run and returns what it reports it
+// would do. Returns ErrDryRunUnsupported if the subcommand lacks the flag.
+func (r *Runner) DryRun(subcommand []string, args ...string) (string, error) {
+	if !r.SupportsDryRun(subcommand...) {
+		return "", fmt.Errorf("%w: jj %s", ErrDryRunUnsupported, strings.Join(subcommand, " "))
+	}
+
+	full := slices.Concat(subcommand, args, []string{dryRunFlag, r.ColorFlag()})
+
+	return r.RunCombined(full...)
+}
+
+// SetRevset selects the revisions Log, LogAtOp, and LogMetadata show. Empty
+// leaves the choice to jj's revsets.log setting.
+func (r *Runner) SetRevset(revset string) {
+	r.revset = revset
+}
+
+// Log returns the jj log output with colors, cut off after limit changes
+// when limit is positive.
+func (r *Runner) Log(limit int) (string, error) {
+	return r.view(r.logArgs(limit, r.templateArgs("log", r.ColorFlag())...)...)
+}
+
+// PlainLog returns the jj log without colors, for output that is not a
+// terminal.
+func (r *Runner) PlainLog() (string, error) {
+	return r.view(r.logArgs(0, r.templateArgs("log", "--color=never")...)...)
+}
+
+// LogAtOp returns the jj log as it was at an earlier operation, with colors.
+func (r *Runner) LogAtOp(opID string) (string, error) {
+	at := r.Pinned()
+	at.SetAtOperation(opID)
+
+	return at.view(at.logArgs(0, at.templateArgs("log", at.ColorFlag())...)...)
+}
+
+// LogMetadata returns one machine-readable line per change in the log
+// revset, for merging into the changes parsed from Log via ApplyMetadata.
+// limit should match the one Log was given.
+func (r *Runner) LogMetadata(limit int) (string, error) {
+	return r.view(r.logArgs(limit, "log", "--no-graph", "--color=never", "-T", r.templates.Get("change_meta"))...)
+}
+
+// logArgs adds the configured revset to a log command, and --limit when
+// limit is positive.
+func (r *Runner) logArgs(limit int, args ...string) []string {
+	if r.revset != "" {
+		args = append(args, "-r", r.revset)
+	}
+
+	if limit > 0 {
+		args = append(args, "--limit", strconv.Itoa(limit))
+	}
+
+	return args
 }
 
 // LogWithTemplate returns jj log with a custom template.
 func (r *Runner) LogWithTemplate(template string) (string, error) {
-	return r.Run("log", "--color=always", "-T", template)
+	return r.view("log", r.ColorFlag(), "-T", template)
 }
 
-// Show returns details for a specific revision.
+// Show returns details for a specific revision, with its binary files
+// described by their sizes.
 func (r *Runner) Show(rev string) (string, error) {
-	return r.Run("show", "-r", rev, "--color=always", "-T", r.templates.Get("show"))
+	output, err := r.view(r.diffArgs("show", "-r", rev, r.ColorFlag(), "-T", r.templates.Get("show"))...)
+	if err != nil {
+		return "", err
+	}
+
+	return r.describeBinaries(rev, output), nil
 }
 
 // Diff returns the diff for a revision.
 func (r *Runner) Diff(rev string) (string, error) {
-	return r.Run("diff", "-r", rev, "--color=always")
+	return r.view(r.diffArgs("diff", "-r", rev, r.ColorFlag())...)
 }
 
-// DiffFile returns the diff for a specific file in a revision.
+// DiffFile returns the diff for a specific file in a revision, described
+// by its sizes if it is binary.
 func (r *Runner) DiffFile(rev, file string) (string, error) {
-	return r.Run("diff", "-r", rev, "--color=always", file)
+	output, err := r.view(r.diffArgs("diff", "-r", rev, r.ColorFlag(), file)...)
+	if err != nil {
+		return "", err
+	}
+
+	return r.describeBinaries(rev, output), nil
+}
+
+// FileShow returns the content of file as it is in a revision.
+func (r *Runner) FileShow(rev, file string) (string, error) {
+	return r.view(append([]string{"file", "show", "-r", rev}, filePatterns([]string{file})...)...)
+}
+
+// DiffRange returns the difference between the contents of two revisions.
+func (r *Runner) DiffRange(from, to string) (string, error) {
+	return r.view(r.diffArgs("diff", "--from", from, "--to", to, r.ColorFlag())...)
+}
+
+// DiffToolCmd returns a command that shows the diff of a revision, or of
+// one file in it when path is not empty, in an external diff tool. jj writes
+// the before and after contents for the tool; tool names an entry in jj's
+// merge-tools config, or is empty for the ui.diff-formatter. The command is
+// not started, so the caller can hand it the terminal.
+func (r *Runner) DiffToolCmd(rev, path, tool string) *exec.Cmd {
+	args := r.viewArgs("diff", "-r", rev)
+	if tool != "" {
+		args = append(args, "--tool", tool)
+	}
+
+	if path != "" {
+		args = append(args, filePatterns([]string{path})...)
+	}
+
+	r.log.Debug("preparing diff tool command", "args", args)
+
+	cmd := exec.CommandContext(r.ctx, "jj", args...)
+	cmd.Dir = r.workDir
+
+	return cmd
 }
 
 // Status returns jj status output.
 func (r *Runner) Status() (string, error) {
-	return r.Run("status", "--color=always")
+	return r.view("status", r.ColorFlag())
 }
 
-// OpLog returns the jj operation log output with colors.
-func (r *Runner) OpLog() (string, error) {
-	return r.Run("op", "log", "--color=always")
+// OpLog returns the jj operation log output with colors: the latest limit
+// operations, or all of them when limit is zero.
+func (r *Runner) OpLog(limit int) (string, error) {
+	args := []string{"op", "log", r.ColorFlag()}
+	if limit > 0 {
+		args = append(args, "--limit", strconv.Itoa(limit))
+	}
+
+	return r.view(args...)
 }
 
 // evoLogTemplate formats evolog output to show operation details
@@ -104,12 +569,44 @@ const evoLogTemplate = `self.operation().id().short(12) ++ " " ++ self.operation
 
 // EvoLog returns the evolution log for a specific change.
 func (r *Runner) EvoLog(rev string) (string, error) {
-	return r.Run("evolog", "-r", rev, "--color=always", "-T", evoLogTemplate)
+	return r.view("evolog", "-r", rev, r.ColorFlag(), "-T", evoLogTemplate)
 }
 
 // OpShow returns details for a specific operation.
 func (r *Runner) OpShow(opID string) (string, error) {
-	return r.Run("op", "show", opID, "--color=always", "--patch")
+	return r.view("op", "show", opID, r.ColorFlag(), "--patch")
+}
+
+// OpDiff returns the changes an operation made to the repo: the commits it
+// added, rewrote, or abandoned, and the bookmarks it moved.
+func (r *Runner) OpDiff(opID string) (string, error) {
+	return r.view("op", "diff", "--op", opID, r.ColorFlag())
+}
+
+// OpAbandon discards operation opID and every operation before it from the
+// op log (jj op abandon ..opID); later operations are kept.
+func (r *Runner) OpAbandon(opID string) error {
+	_, err := r.Run("op", "abandon", ".."+opID)
+	return err
+}
+
+// GC removes the operations and commits nothing refers to any more from the
+// repo's storage (jj util gc).
+func (r *Runner) GC() error {
+	_, err := r.Run("util", "gc")
+	return err
+}
+
+// Interdiff returns the difference between two versions of a change,
+// leaving out what changed in their parents.
+func (r *Runner) Interdiff(from, to string) (string, error) {
+	return r.view(r.diffArgs("interdiff", "--from", from, "--to", to, r.ColorFlag())...)
+}
+
+// AtOperation returns a revset for rev as it was right after operation opID,
+// which addresses one version of a change from its evolog.
+func AtOperation(opID, rev string) string {
+	return fmt.Sprintf("at_operation(%s, %s)", opID, rev)
 }
 
 // Describe updates the description (commit message) for a revision.
@@ -124,12 +621,59 @@ func (r *Runner) Edit(rev string) error {
 	return err
 }
 
-// New creates a new empty change on top of the current working copy.
-func (r *Runner) New() error {
-	_, err := r.Run("new")
+// Next moves the working copy to the child of @: editing it when edit is
+// set, otherwise starting a new change on it.
+func (r *Runner) Next(edit bool) error {
+	_, err := r.Run(stackArgs("next", edit)...)
 	return err
 }
 
+// Prev moves the working copy to the parent of @, like Next.
+func (r *Runner) Prev(edit bool) error {
+	_, err := r.Run(stackArgs("prev", edit)...)
+	return err
+}
+
+// stackArgs returns the arguments of jj next or prev.
+func stackArgs(command string, edit bool) []string {
+	if edit {
+		return []string{command, "--edit"}
+	}
+
+	return []string{command}
+}
+
+// Commit describes the working copy with message and starts a new empty
+// change on top of it.
+func (r *Runner) Commit(message string) error {
+	_, err := r.Run("commit", "-m", message)
+	return err
+}
+
+// New creates a new empty change on top of parents, or of the current
+// working copy when none are given. Several parents make a merge.
+func (r *Runner) New(parents ...string) error {
+	_, err := r.Run(append([]string{"new"}, parents...)...)
+	return err
+}
+
+// NewInsert creates a new empty change inserted after rev, rebasing rev's
+// children onto it, or before rev, rebasing rev onto it.
+func (r *Runner) NewInsert(rev string, before bool) error {
+	_, err := r.Run("new", insertFlag(before), rev)
+	return err
+}
+
+// insertFlag is the jj new flag that inserts the new change before or after
+// a revision.
+func insertFlag(before bool) string {
+	if before {
+		return "--insert-before"
+	}
+
+	return "--insert-after"
+}
+
 // Abandon removes a revision from the repository.
 func (r *Runner) Abandon(rev string) error {
 	_, err := r.Run("abandon", rev)
@@ -142,9 +686,156 @@ func (r *Runner) Squash(rev string) error {
 	return err
 }
 
+// SquashPaths moves what from changed in paths into another revision, into
+// from's parent when into is empty.
+func (r *Runner) SquashPaths(from, into string, paths ...string) error {
+	args := []string{"squash", "-r", from}
+	if into != "" {
+		args = []string{"squash", "--from", from, "--into", into}
+	}
+
+	_, err := r.Run(append(args, filePatterns(paths)...)...)
+
+	return err
+}
+
+// HunkDiff returns the git-format diff of rev, or of paths in it, without
+// colors, to pick hunks from for SquashHunks and DiscardHunks.
+func (r *Runner) HunkDiff(rev string, paths ...string) (string, error) {
+	return r.view(append([]string{"diff", "-r", rev, "--git", "--color=never"}, filePatterns(paths)...)...)
+}
+
+// SquashHunks moves the hunks in patch, picked from from's diff, into
+// another revision, or into from's parent when into is empty.
+func (r *Runner) SquashHunks(from, into string, patch []FileDiff) error {
+	args := []string{"squash", "-r", from}
+	if into != "" {
+		args = []string{"squash", "--from", from, "--into", into}
+	}
+
+	return r.runHunkEditor(args, patch)
+}
+
+// DiscardHunks undoes the hunks in patch, picked from rev's diff, in rev.
+func (r *Runner) DiscardHunks(rev string, patch []FileDiff) error {
+	// jj restore's diff editor goes from rev back to its parents
+	return r.runHunkEditor([]string{"restore", "--changes-in", rev}, ReverseDiff(patch))
+}
+
+// Absorb moves the changes of a revision into the ancestors that last
+// touched the same lines, only those of paths when given, and returns jj's
+// report of what it did.
+func (r *Runner) Absorb(from string, paths ...string) (string, error) {
+	return r.RunCombined(append([]string{"absorb", "--from", from}, filePatterns(paths)...)...)
+}
+
+// ParseAbsorbed returns the change IDs of the revisions jj absorb reports
+// amending: the indented lines below its "Absorbed changes into" line.
+func ParseAbsorbed(output string) []string {
+	var (
+		changeIDs []string
+		listing   bool
+	)
+
+	for line := range strings.SplitSeq(stripANSI(output), "\n") {
+		switch {
+		case strings.HasPrefix(line, "Absorbed changes into"):
+			listing = true
+		case listing && strings.HasPrefix(line, " "):
+			if fields := strings.Fields(line); len(fields) > 0 {
+				changeIDs = append(changeIDs, fields[0])
+			}
+		default:
+			listing = false
+		}
+	}
+
+	return changeIDs
+}
+
+// RestoreFrom copies paths as they are in rev into the working copy.
+func (r *Runner) RestoreFrom(rev string, paths ...string) error {
+	_, err := r.Run(append([]string{"restore", "--from", rev}, filePatterns(paths)...)...)
+	return err
+}
+
+// DiscardChanges undoes what rev changed in paths, leaving them as in its
+// parents.
+func (r *Runner) DiscardChanges(rev string, paths ...string) error {
+	_, err := r.Run(append([]string{"restore", "--changes-in", rev}, filePatterns(paths)...)...)
+	return err
+}
+
+// Duplicate creates a copy of a revision with the same parents.
+func (r *Runner) Duplicate(rev string) error {
+	_, err := r.Run("duplicate", rev)
+	return err
+}
+
+// Backout creates a change on top of the working copy that undoes a
+// revision, with jj revert, or jj backout before it existed.
+func (r *Runner) Backout(rev string) error {
+	args := []string{"backout", "-r", rev}
+	if r.Supports(FeatureRevert) {
+		args = []string{"revert", "-r", rev, "--onto", "@"}
+	}
+
+	_, err := r.Run(args...)
+
+	return err
+}
+
+// Push pushes the bookmarks pointing at a revision to the remote.
+func (r *Runner) Push(rev string) error {
+	_, err := r.Run("git", "push", "-r", rev)
+	return err
+}
+
+// PushPreview reports what Push would send to the remote, without pushing.
+func (r *Runner) PushPreview(rev string) (string, error) {
+	return r.DryRun([]string{"git", "push"}, "-r", rev)
+}
+
+// Bookmarks returns one machine-readable line per local bookmark.
+func (r *Runner) Bookmarks() ([]Bookmark, error) {
+	output, err := r.view("bookmark", "list", "--color=never", "-T", r.templates.Get("bookmarks"))
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseBookmarks(output), nil
+}
+
+// PushBookmarks pushes only the named bookmarks in a single jj git push and
+// returns jj's report of what moved.
+func (r *Runner) PushBookmarks(names []string) (string, error) {
+	return r.RunCombined(slices.Concat([]string{"git", "push"}, bookmarkArgs(names))...)
+}
+
+// PushBookmarksPreview reports what PushBookmarks would send, without pushing.
+func (r *Runner) PushBookmarksPreview(names []string) (string, error) {
+	return r.DryRun([]string{"git", "push"}, bookmarkArgs(names)...)
+}
+
+// bookmarkArgs repeats --bookmark for each name.
+func bookmarkArgs(names []string) []string {
+	var args []string
+	for _, name := range names {
+		args = append(args, "--bookmark", name)
+	}
+
+	return args
+}
+
+// Descendants returns the log of a revision and everything built on top of
+// it, capped at limit entries. Used to preview what a rewrite would touch.
+func (r *Runner) Descendants(rev string, limit int) (string, error) {
+	return r.view("log", "-r", rev+"::", r.ColorFlag(), "--limit", strconv.Itoa(limit))
+}
+
 // ShortestChangeID returns the shortest unique prefix for a change ID.
 func (r *Runner) ShortestChangeID(rev string) (string, error) {
-	output, err := r.Run("log", "-r", rev, "-T", "change_id.shortest()", "--no-graph")
+	output, err := r.view("log", "-r", rev, "-T", "change_id.shortest()", "--no-graph")
 	if err != nil {
 		return "", err
 	}
@@ -152,11 +843,90 @@ func (r *Runner) ShortestChangeID(rev string) (string, error) {
 	return strings.TrimSpace(output), nil
 }
 
+// ResolveChange returns the change ID, as the log shows it, of the one
+// change a change ID prefix, bookmark, or revset names.
+func (r *Runner) ResolveChange(rev string) (string, error) {
+	output, err := r.view("log", "-r", rev, "--no-graph", "--color=never", "--limit", "2", "-T", `change_id.shortest(8) ++ "\n"`)
+	if err != nil {
+		return "", err
+	}
+
+	switch ids := strings.Fields(output); len(ids) {
+	case 0:
+		return "", fmt.Errorf("%w: %s names no change", ErrNotOneChange, rev)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("%w: %s names more than one change", ErrNotOneChange, rev)
+	}
+}
+
+// FullDescription returns every line of rev's description, where the log
+// shows it joined up, without its trailing newline.
+func (r *Runner) FullDescription(rev string) (string, error) {
+	output, err := r.view("log", "-r", rev, "--no-graph", "--color=never", "-T", "description")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(output, "\n"), nil
+}
+
+// statSummaryRe matches the totals line at the end of jj diff --stat output,
+// e.g. "3 files changed, 12 insertions(+), 3 deletions(-)".
+var statSummaryRe = regexp.MustCompile(`(\d+) files? changed(?:, (\d+) insertions?\(\+\))?(?:, (\d+) deletions?\(-\))?`)
+
+var (
+	// fileStatRe matches a per-file line of jj diff --stat output, e.g.
+	// "src/main.go | 15 +++++++++++++--", capturing path, total, and bar.
+	fileStatRe = regexp.MustCompile(`^\s*(.+?)\s+\|\s+(\d+)\s*(\+*)(-*)\s*$`)
+
+	// statRenameRe matches the "{old => new}" part of a renamed path.
+	statRenameRe = regexp.MustCompile(`\{[^{}]* => ([^{}]*)\}`)
+)
+
+// ChangeStat returns the inserted/deleted line totals for a revision.
+func (r *Runner) ChangeStat(rev string) (ChangeStat, error) {
+	output, err := r.view("diff", "-r", rev, "--stat", "--color=never")
+	if err != nil {
+		return ChangeStat{}, err
+	}
+
+	return ParseStatSummary(output), nil
+}
+
+// DiffStat returns the inserted/deleted line counts of each file in a revision.
+func (r *Runner) DiffStat(rev string) ([]FileStat, error) {
+	output, err := r.view("diff", "-r", rev, "--stat", "--color=never")
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseDiffStat(output), nil
+}
+
+// WorkingCopyState reports whether @ is immutable or already pushed. The check
+// itself never snapshots pending edits.
+func (r *Runner) WorkingCopyState() (WorkingCopyState, error) {
+	output, err := r.view("log", "-r", "@", "--no-graph", "--color=never", "-T", r.templates.Get("working_copy"))
+	if err != nil {
+		return WorkingCopyState{}, err
+	}
+
+	return ParseWorkingCopyState(output), nil
+}
+
 // LogStat returns log with file stats.
 func (r *Runner) LogStat(rev string) (string, error) {
-	return r.Run("log", "-r", rev, "--stat", "--color=always")
+	return r.view("log", "-r", rev, "--stat", r.ColorFlag())
 }
 
+// commitIDRe matches a short or full hex commit hash.
+var commitIDRe = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// immutableSymbol is the graph node jj log draws for an immutable change.
+const immutableSymbol = "◆"
+
 // ParseLogLines parses the raw log output into Change structs.
 // For now, we keep the raw lines and just extract basic info.
 func (r *Runner) ParseLogLines(output string) []Change {
@@ -172,7 +942,7 @@ func (r *Runner) ParseLogLines(output string) []Change {
 	// Matches lines like: "@ xsssnyux ..." or "○ nlkzwoyt/2 ..." or "◆ kyztkmnt ..."
 	// Symbols: @ (working copy), ○ (normal), ◆ (immutable), ◇ (empty), ● (hidden), × (conflict)
 	// Change IDs use reverse-hex [k-z] and may have version suffix /N
-	changeLineRe := regexp.MustCompile(`^[│├└\s]*[@○◆◇●×]\s*([k-z]{8,}(?:/\d+)?)\s`)
+	changeLineRe := regexp.MustCompile(`^[│├└\s]*([@○◆◇●×])\s*([k-z]{8,}(?:/\d+)?)\s`)
 
 	finalizeChange := func() {
 		if currentChange == nil {
@@ -189,7 +959,13 @@ func (r *Runner) ParseLogLines(output string) []Change {
 		if match := changeLineRe.FindStringSubmatch(stripped); match != nil {
 			finalizeChange()
 
-			currentChange = &Change{ChangeID: match[1], Raw: line}
+			// ◆ marks an immutable change until the metadata says for sure
+			currentChange = &Change{
+				ChangeID:    match[2],
+				CommitID:    extractCommitID(stripped),
+				IsImmutable: match[1] == immutableSymbol,
+				Raw:         line,
+			}
 			descLines = nil
 		} else if currentChange != nil && strings.TrimSpace(line) != "" {
 			if desc := extractDesc(stripped); desc != "" {
@@ -257,6 +1033,15 @@ func (r *Runner) ParseOpLogLines(output string) []Operation {
 func (r *Runner) ParseFiles(diffOutput string) []File {
 	var files []File
 
+	if IsGitDiff(diffOutput) {
+		_, diffs := ParseGitDiff(diffOutput)
+		for _, diff := range diffs {
+			files = append(files, File{Path: diff.Path, Status: diff.Status})
+		}
+
+		return files
+	}
+
 	lines := strings.Split(diffOutput, "\n")
 
 	// jj diff format:
@@ -328,6 +1113,226 @@ func FindHunks(diffOutput string) []Hunk {
 	return hunks
 }
 
+// ApplyMetadata fills the metadata fields of changes from LogMetadata output,
+// matching records by change ID. Changes without a record are left untouched.
+func ApplyMetadata(changes []Change, output string) {
+	const (
+		fieldChangeID = iota
+		fieldCommitID
+		fieldAuthor
+		fieldTimestamp
+		fieldBookmarks
+		fieldTags
+		fieldEmpty
+		fieldImmutable
+		fieldConflict
+		fieldWorkingCopy
+		fieldCount
+	)
+
+	records := make(map[string][]string)
+
+	for line := range strings.SplitSeq(output, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != fieldCount {
+			continue
+		}
+
+		records[fields[fieldChangeID]] = fields
+	}
+
+	for i := range changes {
+		fields, ok := records[changes[i].ChangeID]
+		if !ok {
+			continue
+		}
+
+		change := &changes[i]
+		change.CommitID = cmp.Or(fields[fieldCommitID], change.CommitID)
+		change.Author = fields[fieldAuthor]
+		change.Timestamp = fields[fieldTimestamp]
+		change.Bookmarks = splitList(fields[fieldBookmarks])
+		change.Tags = splitList(fields[fieldTags])
+		change.IsEmpty = fields[fieldEmpty] == "true"
+		change.IsImmutable = fields[fieldImmutable] == "true"
+		change.IsConflicted = fields[fieldConflict] == "true"
+		change.IsWorkingCopy = fields[fieldWorkingCopy] == "true"
+	}
+}
+
+// splitList splits a comma-separated template list, returning nil for an empty one.
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	return strings.Split(s, ",")
+}
+
+// ParseWorkingCopyState parses output of the working_copy template:
+// change ID, commit ID, immutable flag, and comma-separated remote bookmarks, tab-separated.
+func ParseWorkingCopyState(output string) WorkingCopyState {
+	const (
+		fieldChangeID = iota
+		fieldCommitID
+		fieldImmutable
+		fieldRemotes
+		fieldCount
+	)
+
+	fields := strings.Split(strings.TrimSpace(output), "\t")
+	if len(fields) < fieldCount {
+		fields = append(fields, make([]string, fieldCount-len(fields))...)
+	}
+
+	state := WorkingCopyState{
+		ChangeID:  fields[fieldChangeID],
+		CommitID:  fields[fieldCommitID],
+		Immutable: fields[fieldImmutable] == "true",
+	}
+
+	for remote := range strings.SplitSeq(fields[fieldRemotes], ",") {
+		// The colocated "git" remote mirrors local bookmarks; it isn't a push target.
+		if remote == "" || strings.HasSuffix(remote, "@git") {
+			continue
+		}
+
+		state.RemoteBookmarks = append(state.RemoteBookmarks, remote)
+	}
+
+	return state
+}
+
+// ParseBookmarks parses the output of the bookmarks template.
+func ParseBookmarks(output string) []Bookmark {
+	const (
+		fieldName = iota
+		fieldChangeID
+		fieldDescription
+		fieldConflict
+		fieldSynced
+		fieldCount
+	)
+
+	var bookmarks []Bookmark
+
+	for line := range strings.SplitSeq(output, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != fieldCount || fields[fieldName] == "" {
+			continue
+		}
+
+		bookmarks = append(bookmarks, Bookmark{
+			Name:        fields[fieldName],
+			ChangeID:    fields[fieldChangeID],
+			Description: fields[fieldDescription],
+			Conflict:    fields[fieldConflict] == "true",
+			Synced:      fields[fieldSynced] == "true",
+		})
+	}
+
+	return bookmarks
+}
+
+// pushLineRe matches a bookmark update in jj git push output, e.g.
+// "  Move forward bookmark main from 1a2b3c to 4d5e6f".
+var pushLineRe = regexp.MustCompile(`^\s*(Add|Move forward|Move backward|Move sideways|Delete|Force push) bookmark (\S+)`)
+
+// pushUpToDate is the status of a requested bookmark that jj did not need to move.
+const pushUpToDate = "up to date"
+
+// PushResults merges the outcome of a multi-bookmark push into one result per
+// requested bookmark. A failed push fails every bookmark with jj's error;
+// otherwise each bookmark gets the action jj reported, or "up to date".
+func PushResults(names []string, output string, err error) map[string]PushResult {
+	results := make(map[string]PushResult, len(names))
+
+	if err != nil {
+		status := strings.TrimSpace(err.Error())
+		if first, _, found := strings.Cut(status, "\n"); found {
+			status = first
+		}
+
+		for _, name := range names {
+			results[name] = PushResult{Status: status}
+		}
+
+		return results
+	}
+
+	actions := make(map[string]string)
+
+	for line := range strings.SplitSeq(stripANSI(output), "\n") {
+		if match := pushLineRe.FindStringSubmatch(line); match != nil {
+			actions[match[2]] = strings.ToLower(match[1])
+		}
+	}
+
+	for _, name := range names {
+		results[name] = PushResult{OK: true, Status: cmp.Or(actions[name], pushUpToDate)}
+	}
+
+	return results
+}
+
+// ParseStatSummary extracts insertion and deletion totals from jj diff --stat output.
+// Returns a zero ChangeStat when no summary line is present (e.g. an empty change).
+func ParseStatSummary(output string) ChangeStat {
+	match := statSummaryRe.FindStringSubmatch(stripANSI(output))
+	if match == nil {
+		return ChangeStat{}
+	}
+
+	added, _ := strconv.Atoi(match[2])
+	removed, _ := strconv.Atoi(match[3])
+
+	return ChangeStat{Added: added, Removed: removed}
+}
+
+// ParseDiffStat extracts per-file line counts from jj diff --stat output.
+// jj scales the +/- bar down for large files, so the split of the total
+// between added and removed lines is then proportional to the bar.
+func ParseDiffStat(output string) []FileStat {
+	var stats []FileStat
+
+	for line := range strings.SplitSeq(stripANSI(output), "\n") {
+		match := fileStatRe.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		total, _ := strconv.Atoi(match[2])
+		plus, minus := len(match[3]), len(match[4])
+
+		added := plus
+		if bar := plus + minus; bar > 0 && bar != total {
+			added = int(math.Round(float64(total) * float64(plus) / float64(bar)))
+		}
+
+		// "dir/{old.go => new.go}" is listed under its new path; an empty
+		// side (a file moved out of a directory) leaves a doubled slash
+		path := statRenameRe.ReplaceAllString(match[1], "$1")
+		path = strings.ReplaceAll(path, "//", "/")
+
+		stats = append(stats, FileStat{Path: path, ChangeStat: ChangeStat{Added: added, Removed: total - added}})
+	}
+
+	return stats
+}
+
+// extractCommitID returns the last commit-hash-looking token on a change line.
+// Change IDs use the reverse-hex alphabet (k-z), so they never collide with it.
+func extractCommitID(stripped string) string {
+	fields := strings.Fields(stripped)
+	for i := len(fields) - 1; i >= 0; i-- {
+		if commitIDRe.MatchString(fields[i]) {
+			return fields[i]
+		}
+	}
+
+	return ""
+}
+
 // extractDesc pulls description text from a graph-continuation or indented line.
 // Returns empty string if the line isn't a description line.
 func extractDesc(stripped string) string {
@@ -338,9 +1343,16 @@ func extractDesc(stripped string) string {
 	return strings.TrimSpace(strings.TrimPrefix(stripped, "│"))
 }
 
-// stripANSI removes ANSI escape codes from a string.
+// ansiRe matches one ANSI CSI escape sequence.
+var ansiRe = regexp.MustCompile(`\x1b\[[0-9;]*[a-zA-Z]`)
+
+// stripANSI removes ANSI escape codes from a string. Output jj was asked
+// for without colors has none, so it is returned as is.
 func stripANSI(s string) string {
-	ansiRe := regexp.MustCompile(`\x1b\[[0-9;]*[a-zA-Z]`)
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+
 	return ansiRe.ReplaceAllString(s, "")
 }
 
@@ -355,3 +1367,37 @@ type Error struct {
 func (e *Error) Error() string {
 	return "jj " + e.Command + ": " + e.Stderr
 }
+
+// Message returns jj's stderr without its hints.
+func (e *Error) Message() string {
+	message, _ := splitHints(e.Stderr)
+	return message
+}
+
+// Hints returns the advice jj gives on its "Hint:" lines, without the prefix.
+func (e *Error) Hints() []string {
+	_, hints := splitHints(e.Stderr)
+	return hints
+}
+
+// hintPrefix starts each line of advice jj adds after an error.
+const hintPrefix = "hint:"
+
+// splitHints separates the hint lines of jj's stderr from the rest.
+func splitHints(stderr string) (string, []string) {
+	var (
+		message []string
+		hints   []string
+	)
+
+	for line := range strings.SplitSeq(strings.TrimSpace(stripANSI(stderr)), "\n") {
+		if len(line) >= len(hintPrefix) && strings.EqualFold(line[:len(hintPrefix)], hintPrefix) {
+			hints = append(hints, strings.TrimSpace(line[len(hintPrefix):]))
+			continue
+		}
+
+		message = append(message, line)
+	}
+
+	return strings.TrimSpace(strings.Join(message, "\n")), hints
+}
@@ -2,9 +2,16 @@ package jj
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/chatter/chado/internal/jj/testgen"
 	"github.com/chatter/chado/internal/logger"
@@ -534,6 +541,39 @@ func TestEvoLog_MethodExists(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// Operation Diff Tests
+// =============================================================================
+
+func TestOpDiff_MethodExists(t *testing.T) {
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	// OpDiff should accept an operation ID and return (string, error)
+	if _, err := runner.OpDiff("bbc9fee12c4d"); err == nil {
+		t.Log("OpDiff returned no error (unexpected outside a jj repo)")
+	}
+}
+
+// =============================================================================
+// Interdiff Tests
+// =============================================================================
+
+func TestInterdiff_MethodExists(t *testing.T) {
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	// Interdiff should accept two revisions and return (string, error)
+	if _, err := runner.Interdiff("abc", "def"); err == nil {
+		t.Log("Interdiff returned no error (unexpected outside a jj repo)")
+	}
+}
+
+func TestAtOperation(t *testing.T) {
+	got := AtOperation("bbc9fee12c4d", "mkvurkku")
+	if want := "at_operation(bbc9fee12c4d, mkvurkku)"; got != want {
+		t.Errorf("AtOperation = %q, want %q", got, want)
+	}
+}
+
 // =============================================================================
 // Describe Tests
 // =============================================================================
@@ -611,6 +651,260 @@ func TestNew_MethodExists(t *testing.T) {
 	}
 }
 
+func TestNew_Args(t *testing.T) {
+	args := filepath.Join(t.TempDir(), "args")
+	fakeJJ(t, `echo "$@" >`+args)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	tests := []struct {
+		name string
+		run  func() error
+		want string
+	}{
+		{"on @", func() error { return runner.New() }, "new"},
+		{"on a change", func() error { return runner.New("xsssnyux") }, "new xsssnyux"},
+		{"merge", func() error { return runner.New("xsssnyux", "qpvuntsm") }, "new xsssnyux qpvuntsm"},
+		{"insert after", func() error { return runner.NewInsert("xsssnyux", false) }, "new --insert-after xsssnyux"},
+		{"insert before", func() error { return runner.NewInsert("xsssnyux", true) }, "new --insert-before xsssnyux"},
+	}
+
+	for _, tt := range tests {
+		if err := tt.run(); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+
+		got, _ := os.ReadFile(args)
+		if strings.TrimSpace(string(got)) != tt.want {
+			t.Errorf("%s: ran jj %s, want jj %s", tt.name, strings.TrimSpace(string(got)), tt.want)
+		}
+	}
+}
+
+func TestDuplicate_Args(t *testing.T) {
+	args := filepath.Join(t.TempDir(), "args")
+	fakeJJ(t, `echo "$@" >`+args)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+	if err := runner.Duplicate("xsssnyux"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, _ := os.ReadFile(args); strings.TrimSpace(string(got)) != "duplicate xsssnyux" {
+		t.Errorf("ran jj %s", got)
+	}
+}
+
+func TestBackout_RevertsWhereSupported(t *testing.T) {
+	for _, tt := range []struct {
+		version string
+		want    string
+	}{
+		{"0.27.0", "backout -r xsssnyux"},
+		{"0.28.0", "revert -r xsssnyux --onto @"},
+	} {
+		t.Run(tt.version, func(t *testing.T) {
+			args := filepath.Join(t.TempDir(), "args")
+			fakeJJ(t, `if [ "$1" = --version ]; then echo 'jj `+tt.version+`'; exit 0; fi
+echo "$@" >`+args)
+
+			runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+			if err := runner.Backout("xsssnyux"); err != nil {
+				t.Fatal(err)
+			}
+
+			if got, _ := os.ReadFile(args); strings.TrimSpace(string(got)) != tt.want {
+				t.Errorf("ran jj %s, want jj %s", strings.TrimSpace(string(got)), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAbsorbed(t *testing.T) {
+	output := "Absorbed changes into 2 revisions:\n" +
+		"  zsuskuln 3027ff9b parser: handle tabs\n" +
+		"  kkmpptxz 5c0d7e8a (no description set)\n" +
+		"Rebased 1 descendant commits.\n" +
+		"Working copy  (@) now at: vruxwmqv 8e4f2a1b (empty) (no description set)\n"
+
+	if got, want := ParseAbsorbed(output), []string{"zsuskuln", "kkmpptxz"}; !slices.Equal(got, want) {
+		t.Errorf("ParseAbsorbed() = %v, want %v", got, want)
+	}
+
+	if got := ParseAbsorbed("Nothing changed.\n"); got != nil {
+		t.Errorf("nothing absorbed should list no revisions, got %v", got)
+	}
+}
+
+func TestAbsorb_Args(t *testing.T) {
+	fakeJJ(t, `echo "$@"`)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	output, err := runner.Absorb("xsssnyux", "src/(app)/main~1.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := strings.TrimSpace(output); got != `absorb --from xsssnyux root-file:"src/(app)/main~1.go"` {
+		t.Errorf("ran jj %s", got)
+	}
+}
+
+func TestRestore_Args(t *testing.T) {
+	args := filepath.Join(t.TempDir(), "args")
+	fakeJJ(t, `echo "$@" >`+args)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	tests := []struct {
+		name string
+		run  func() error
+		want string
+	}{
+		{"from", func() error { return runner.RestoreFrom("xsssnyux", "main.go") }, `restore --from xsssnyux root-file:"main.go"`},
+		{"discard", func() error { return runner.DiscardChanges("xsssnyux", "my notes~1.md") }, `restore --changes-in xsssnyux root-file:"my notes~1.md"`},
+	}
+
+	for _, tt := range tests {
+		if err := tt.run(); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+
+		if got, _ := os.ReadFile(args); strings.TrimSpace(string(got)) != tt.want {
+			t.Errorf("%s: ran jj %s, want jj %s", tt.name, strings.TrimSpace(string(got)), tt.want)
+		}
+	}
+}
+
+func TestNextPrev_Args(t *testing.T) {
+	args := filepath.Join(t.TempDir(), "args")
+	fakeJJ(t, `echo "$@" >`+args)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	tests := []struct {
+		name string
+		run  func() error
+		want string
+	}{
+		{"next edit", func() error { return runner.Next(true) }, "next --edit"},
+		{"next new", func() error { return runner.Next(false) }, "next"},
+		{"prev edit", func() error { return runner.Prev(true) }, "prev --edit"},
+		{"prev new", func() error { return runner.Prev(false) }, "prev"},
+	}
+
+	for _, tt := range tests {
+		if err := tt.run(); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+
+		if got, _ := os.ReadFile(args); strings.TrimSpace(string(got)) != tt.want {
+			t.Errorf("%s: ran jj %s, want jj %s", tt.name, strings.TrimSpace(string(got)), tt.want)
+		}
+	}
+}
+
+func TestCommit_Args(t *testing.T) {
+	args := filepath.Join(t.TempDir(), "args")
+	fakeJJ(t, `echo "$@" >`+args)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	if err := runner.Commit("fix parser"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, _ := os.ReadFile(args); strings.TrimSpace(string(got)) != "commit -m fix parser" {
+		t.Errorf("ran jj %s, want jj commit -m fix parser", strings.TrimSpace(string(got)))
+	}
+}
+
+func TestFileShow_Args(t *testing.T) {
+	fakeJJ(t, `echo "$@"`)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	got, err := runner.FileShow("xsssnyux", "docs/a|b (draft).md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `file show -r xsssnyux root-file:"docs/a|b (draft).md" --ignore-working-copy`; strings.TrimSpace(got) != want {
+		t.Errorf("ran jj %s, want jj %s", strings.TrimSpace(got), want)
+	}
+}
+
+func TestResolveChange(t *testing.T) {
+	tests := []struct {
+		output string
+		want   string
+		err    bool
+	}{
+		{"xsssnyux\n", "xsssnyux", false},
+		{"", "", true},
+		{"xsssnyux\nkyztkmnt\n", "", true},
+	}
+
+	for _, tt := range tests {
+		fakeJJ(t, `printf '`+strings.ReplaceAll(tt.output, "\n", `\n`)+`'`)
+
+		runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+		got, err := runner.ResolveChange("main")
+		if got != tt.want || (err != nil) != tt.err || (err != nil && !errors.Is(err, ErrNotOneChange)) {
+			t.Errorf("output %q: got %q, %v; want %q (error: %v)", tt.output, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestFullDescription(t *testing.T) {
+	args := filepath.Join(t.TempDir(), "args")
+	fakeJJ(t, `echo "$@" >`+args+`; printf 'fix: parser\n\nRefs: PROJ-1\n'`)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	got, err := runner.FullDescription("xsssnyux")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := "fix: parser\n\nRefs: PROJ-1"; got != want {
+		t.Errorf("FullDescription = %q, want %q", got, want)
+	}
+
+	ran, _ := os.ReadFile(args)
+	if !strings.Contains(string(ran), "log -r xsssnyux --no-graph --color=never -T description") {
+		t.Errorf("ran jj %s", ran)
+	}
+}
+
+func TestSquashPaths_Args(t *testing.T) {
+	args := filepath.Join(t.TempDir(), "args")
+	fakeJJ(t, `echo "$@" >`+args)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	tests := []struct {
+		name string
+		into string
+		want string
+	}{
+		{"parent", "", `squash -r xsssnyux root-file:"a.go" root-file:"b c~.go"`},
+		{"into", "kkmpptxz", `squash --from xsssnyux --into kkmpptxz root-file:"a.go" root-file:"b c~.go"`},
+	}
+
+	for _, tt := range tests {
+		if err := runner.SquashPaths("xsssnyux", tt.into, "a.go", "b c~.go"); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+
+		if got, _ := os.ReadFile(args); strings.TrimSpace(string(got)) != tt.want {
+			t.Errorf("%s: ran jj %s, want jj %s", tt.name, strings.TrimSpace(string(got)), tt.want)
+		}
+	}
+}
+
 func TestAbandon_MethodExists(t *testing.T) {
 	// This test verifies the Abandon method exists and has the correct signature.
 	runner := NewRunner(context.Background(), ".", testLogger(t))
@@ -661,3 +955,689 @@ func TestEvoLog_ParsesAsOperations(t *testing.T) {
 		}
 	}
 }
+
+// =============================================================================
+// Change Stat Tests
+// =============================================================================
+
+func TestParseStatSummary(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected ChangeStat
+	}{
+		{
+			name:     "insertions and deletions",
+			input:    "src/main.go | 15 +++++++++++++--\n1 file changed, 13 insertions(+), 2 deletions(-)\n",
+			expected: ChangeStat{Added: 13, Removed: 2},
+		},
+		{
+			name:     "insertions only",
+			input:    "a.txt | 1 +\nb.txt | 2 ++\n2 files changed, 3 insertions(+)\n",
+			expected: ChangeStat{Added: 3},
+		},
+		{
+			name:     "deletions only",
+			input:    "a.txt | 4 ----\n1 file changed, 4 deletions(-)\n",
+			expected: ChangeStat{Removed: 4},
+		},
+		{
+			name:     "singular insertion and deletion",
+			input:    "1 file changed, 1 insertion(+), 1 deletion(-)",
+			expected: ChangeStat{Added: 1, Removed: 1},
+		},
+		{
+			name:     "empty change",
+			input:    "0 files changed, 0 insertions(+), 0 deletions(-)\n",
+			expected: ChangeStat{},
+		},
+		{
+			name:     "no summary",
+			input:    "",
+			expected: ChangeStat{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseStatSummary(tt.input); got != tt.expected {
+				t.Errorf("ParseStatSummary() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseDiffStat(t *testing.T) {
+	output := "README.md              |  4 ++--\n" +
+		"src/{old.go => new.go} |  1 +\n" +
+		"internal/big.go        | 90 ++++++++++--------------------\n" +
+		"...ng/path/to/file.go  |  3 ---\n" +
+		"4 files changed, 40 insertions(+), 58 deletions(-)\n"
+
+	want := []FileStat{
+		{Path: "README.md", ChangeStat: ChangeStat{Added: 2, Removed: 2}},
+		{Path: "src/new.go", ChangeStat: ChangeStat{Added: 1}},
+		{Path: "internal/big.go", ChangeStat: ChangeStat{Added: 30, Removed: 60}}, // bar scaled 10:20
+		{Path: "...ng/path/to/file.go", ChangeStat: ChangeStat{Removed: 3}},
+	}
+
+	got := ParseDiffStat(output)
+	if !slices.Equal(got, want) {
+		t.Errorf("ParseDiffStat() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFileStat_MatchesTruncatedPath(t *testing.T) {
+	stat := FileStat{Path: "...ng/path/to/file.go"}
+
+	if !stat.Matches("some/very/long/path/to/file.go") {
+		t.Error("a shortened path should match by suffix")
+	}
+
+	if stat.Matches("other/file.go") {
+		t.Error("a different path should not match")
+	}
+
+	if !(FileStat{Path: "a.go"}).Matches("a.go") || (FileStat{Path: "a.go"}).Matches("b/a.go") {
+		t.Error("a full path should match exactly")
+	}
+}
+
+func TestParseLogLines_ExtractsCommitID(t *testing.T) {
+	runner := NewRunner(context.Background(), ".", testLogger(t))
+
+	changeID := testgen.ChangeID(testgen.WithShort).Example()
+	commitID := testgen.CommitID(testgen.WithShort).Example()
+	email := testgen.Email().Example()
+	ts := testgen.Timestamp().Example()
+
+	input := fmt.Sprintf("@  %s %s %s %s\n│  description", changeID, email, ts, commitID)
+
+	changes := runner.ParseLogLines(input)
+	if len(changes) != 1 {
+		t.Fatalf("expected 1 change, got %d", len(changes))
+	}
+
+	if changes[0].CommitID != commitID {
+		t.Errorf("CommitID = %q, want %q", changes[0].CommitID, commitID)
+	}
+}
+
+func TestParseLogLines_ImmutableSymbol(t *testing.T) {
+	runner := NewRunner(context.Background(), ".", testLogger(t))
+
+	changes := runner.ParseLogLines("○  xsssnyux user 1 minute ago 1a2b3c4d\n│  feature\n◆  zzzzzzzz root() 00000000\n")
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %d", len(changes))
+	}
+
+	if changes[0].IsImmutable || !changes[1].IsImmutable {
+		t.Errorf("only the ◆ change should be immutable, got %v and %v", changes[0].IsImmutable, changes[1].IsImmutable)
+	}
+}
+
+// =============================================================================
+// Working Copy State Tests
+// =============================================================================
+
+func TestParseWorkingCopyState(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		published bool
+		remotes   []string
+	}{
+		{
+			name:  "local only",
+			input: "xsssnyux\t1a2b3c4d5e6f\tfalse\t",
+		},
+		{
+			name:      "pushed",
+			input:     "xsssnyux\t1a2b3c4d5e6f\tfalse\tmain@origin,feat@upstream",
+			published: true,
+			remotes:   []string{"main@origin", "feat@upstream"},
+		},
+		{
+			name:  "colocated git remote ignored",
+			input: "xsssnyux\t1a2b3c4d5e6f\tfalse\tmain@git",
+		},
+		{
+			name:      "immutable",
+			input:     "zzzzzzzz\t000000000000\ttrue\t",
+			published: true,
+		},
+		{
+			name:  "empty output",
+			input: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := ParseWorkingCopyState(tt.input)
+
+			if state.Published() != tt.published {
+				t.Errorf("Published() = %v, want %v", state.Published(), tt.published)
+			}
+
+			if strings.Join(state.RemoteBookmarks, ",") != strings.Join(tt.remotes, ",") {
+				t.Errorf("RemoteBookmarks = %v, want %v", state.RemoteBookmarks, tt.remotes)
+			}
+		})
+	}
+}
+
+// =============================================================================
+// Change Metadata Tests
+// =============================================================================
+
+func TestApplyMetadata(t *testing.T) {
+	changes := []Change{
+		{ChangeID: "xsssnyux", CommitID: "1a2b"},
+		{ChangeID: "zzzzzzzz"},
+		{ChangeID: "qpvuntsm"},
+	}
+
+	output := "xsssnyux\t1a2b3c4d5e6f\tdev@example.com\t2024-01-02 03:04:05\tmain,feat\tv1.0\tfalse\tfalse\ttrue\ttrue\n" +
+		"zzzzzzzz\t000000000000\t\t1970-01-01 00:00:00\t\t\ttrue\ttrue\tfalse\tfalse\n" +
+		"malformed line\n"
+
+	ApplyMetadata(changes, output)
+
+	first := changes[0]
+	if first.CommitID != "1a2b3c4d5e6f" || first.Author != "dev@example.com" || first.Timestamp != "2024-01-02 03:04:05" {
+		t.Errorf("unexpected identity fields: %+v", first)
+	}
+
+	if strings.Join(first.Bookmarks, ",") != "main,feat" || strings.Join(first.Tags, ",") != "v1.0" {
+		t.Errorf("unexpected refs: bookmarks=%v tags=%v", first.Bookmarks, first.Tags)
+	}
+
+	if first.IsEmpty || first.IsImmutable || !first.IsConflicted || !first.IsWorkingCopy {
+		t.Errorf("unexpected flags: %+v", first)
+	}
+
+	root := changes[1]
+	if !root.IsEmpty || !root.IsImmutable || root.IsWorkingCopy || root.Bookmarks != nil || root.Tags != nil {
+		t.Errorf("unexpected root metadata: %+v", root)
+	}
+
+	if untouched := changes[2]; untouched.CommitID != "" || untouched.Author != "" || untouched.IsImmutable {
+		t.Errorf("change without a record should be untouched: %+v", changes[2])
+	}
+}
+
+func TestDryRun_UnsupportedSubcommand(t *testing.T) {
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+	// Seed the probe cache so no jj binary is needed
+	runner.dryRun.supported["abandon"] = false
+
+	if runner.SupportsDryRun("abandon") {
+		t.Error("cached false should be reported as unsupported")
+	}
+
+	_, err := runner.DryRun([]string{"abandon"}, "xsssnyux")
+	if !errors.Is(err, ErrDryRunUnsupported) {
+		t.Errorf("expected ErrDryRunUnsupported, got %v", err)
+	}
+}
+
+func TestSupportsDryRun_CachedPerSubcommand(t *testing.T) {
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+	runner.dryRun.supported["git push"] = true
+	runner.dryRun.supported["rebase"] = false
+
+	if !runner.SupportsDryRun("git", "push") {
+		t.Error("git push should use its cached answer")
+	}
+
+	if runner.SupportsDryRun("rebase") {
+		t.Error("rebase should use its own cached answer")
+	}
+}
+
+func TestParseBookmarks(t *testing.T) {
+	output := "main\txsssnyux\tFix the widget\tfalse\ttrue\n" +
+		"feature\tkpqvmwzo\t\tfalse\tfalse\n" +
+		"split\t\t\ttrue\tfalse\n" +
+		"malformed line\n"
+
+	bookmarks := ParseBookmarks(output)
+	if len(bookmarks) != 3 {
+		t.Fatalf("expected 3 bookmarks, got %d: %+v", len(bookmarks), bookmarks)
+	}
+
+	if got := bookmarks[0]; got.Name != "main" || got.ChangeID != "xsssnyux" ||
+		got.Description != "Fix the widget" || got.Conflict || !got.Synced {
+		t.Errorf("unexpected main bookmark: %+v", got)
+	}
+
+	if got := bookmarks[1]; got.Name != "feature" || got.Synced {
+		t.Errorf("unexpected feature bookmark: %+v", got)
+	}
+
+	if got := bookmarks[2]; !got.Conflict || got.ChangeID != "" {
+		t.Errorf("conflicted bookmark should have no target: %+v", got)
+	}
+}
+
+func TestPushResults_MergesPerBookmark(t *testing.T) {
+	output := "Changes to push to origin:\n" +
+		"  Move forward bookmark main from 1a2b3c4d to 5e6f7a8b\n" +
+		"  Add bookmark feature to 9c0d1e2f\n"
+
+	results := PushResults([]string{"main", "feature", "docs"}, output, nil)
+
+	want := map[string]PushResult{
+		"main":    {OK: true, Status: "move forward"},
+		"feature": {OK: true, Status: "add"},
+		"docs":    {OK: true, Status: pushUpToDate},
+	}
+
+	for name, expected := range want {
+		if results[name] != expected {
+			t.Errorf("%s: expected %+v, got %+v", name, expected, results[name])
+		}
+	}
+}
+
+func TestError_SplitsHints(t *testing.T) {
+	err := &Error{
+		Command: "squash",
+		Stderr:  "Error: Cannot rewrite immutable commit\nHint: Pass `--ignore-immutable` to rewrite it anyway.\nhint: See `jj help -k config`.\n",
+	}
+
+	if got := err.Message(); got != "Error: Cannot rewrite immutable commit" {
+		t.Errorf("Message() = %q", got)
+	}
+
+	want := []string{"Pass `--ignore-immutable` to rewrite it anyway.", "See `jj help -k config`."}
+	if got := err.Hints(); !slices.Equal(got, want) {
+		t.Errorf("Hints() = %q, want %q", got, want)
+	}
+
+	if hints := (&Error{Stderr: "Error: no such revision"}).Hints(); hints != nil {
+		t.Errorf("an error without hints should have none, got %q", hints)
+	}
+}
+
+func TestPushResults_FailureFailsEveryBookmark(t *testing.T) {
+	err := &Error{Command: "git push", Stderr: "Error: Refusing to push a bookmark that unexpectedly moved\nHint: fetch first"}
+
+	results := PushResults([]string{"main", "feature"}, "", err)
+
+	for _, name := range []string{"main", "feature"} {
+		result := results[name]
+		if result.OK {
+			t.Errorf("%s should have failed", name)
+		}
+
+		if strings.Contains(result.Status, "\n") || !strings.Contains(result.Status, "unexpectedly moved") {
+			t.Errorf("%s status should be the first error line, got %q", name, result.Status)
+		}
+	}
+}
+
+// Property: every requested bookmark gets exactly one result.
+func TestPushResults_CoversRequestedBookmarks(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		names := rapid.SliceOfDistinct(rapid.StringMatching(`[a-z][a-z0-9-]{0,15}`), rapid.ID[string]).Draw(t, "names")
+		output := rapid.String().Draw(t, "output")
+
+		results := PushResults(names, output, nil)
+		if len(results) != len(names) {
+			t.Fatalf("expected %d results, got %d", len(names), len(results))
+		}
+
+		for _, name := range names {
+			if !results[name].OK || results[name].Status == "" {
+				t.Fatalf("%s: successful push should report OK with a status, got %+v", name, results[name])
+			}
+		}
+	})
+}
+
+func TestWithContext_SharesProbesAndCancels(t *testing.T) {
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+	runner.dryRun.supported["git push"] = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	derived := runner.WithContext(ctx)
+
+	if !derived.SupportsDryRun("git", "push") {
+		t.Error("derived runner should share the dry-run probe cache")
+	}
+
+	cancel()
+
+	if _, err := derived.Run("log"); err == nil {
+		t.Error("commands on a cancelled runner should fail")
+	}
+
+	if runner.ctx.Err() != nil {
+		t.Error("cancelling the derived context must not affect the parent runner")
+	}
+}
+
+func TestPinned_KeepsSettings(t *testing.T) {
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+	runner.SetAtOperation("bbc9fee12c4d")
+
+	pinned := runner.Pinned()
+	runner.SetAtOperation("")
+
+	if got := pinned.viewArgs("log"); !slices.Contains(got, "bbc9fee12c4d") {
+		t.Errorf("a pinned runner should keep the operation it was pinned at, got %v", got)
+	}
+
+	pinned.SetAtOperation("0ld0p0000000")
+
+	if got := runner.viewArgs("log"); slices.Contains(got, "--at-op") {
+		t.Errorf("settings changed on a pinned runner must not reach the runner, got %v", got)
+	}
+}
+
+// Run with -race: the UI changes settings while commands read them.
+func TestSettings_ChangedWhileCommandsRead(t *testing.T) {
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+	derived := runner.WithContext(context.Background())
+
+	var wg sync.WaitGroup
+
+	wg.Go(func() {
+		for i := range 100 {
+			runner.SetAtOperation(strconv.Itoa(i))
+		}
+	})
+
+	for range 100 {
+		derived.viewArgs("log")
+		runner.Pinned().viewArgs("log")
+	}
+
+	wg.Wait()
+
+	if got := derived.viewArgs("log"); !slices.Contains(got, "99") {
+		t.Errorf("runners derived via WithContext should see the latest settings, got %v", got)
+	}
+}
+
+// =============================================================================
+// Log Revset and Limit Tests
+// =============================================================================
+
+func TestLogArgs(t *testing.T) {
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	if got := runner.logArgs(0, "log"); !slices.Equal(got, []string{"log"}) {
+		t.Errorf("no limit or revset should load jj's default log, got %v", got)
+	}
+
+	if got := runner.logArgs(200, "log"); !slices.Equal(got, []string{"log", "--limit", "200"}) {
+		t.Errorf("expected --limit 200, got %v", got)
+	}
+
+	runner.SetRevset("trunk()..@")
+
+	want := []string{"log", "-r", "trunk()..@", "--limit", "200"}
+	if got := runner.logArgs(200, "log"); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestOpLog_Limit(t *testing.T) {
+	fakeJJ(t, `echo "$@"`)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+	runner.SetRevset("trunk()..@")
+
+	if output, _ := runner.OpLog(100); !strings.Contains(output, "op log --color=always --limit 100") {
+		t.Errorf("expected the op log limited to 100, ran jj %s", output)
+	}
+
+	if output, _ := runner.OpLog(0); strings.Contains(output, "--limit") || strings.Contains(output, "-r") {
+		t.Errorf("expected the whole op log, ran jj %s", output)
+	}
+}
+
+func TestOpAbandon_Args(t *testing.T) {
+	args := filepath.Join(t.TempDir(), "args")
+	fakeJJ(t, `echo "$@" >>`+args)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	if err := runner.OpAbandon("86d0094c958f"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runner.GC(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, _ := os.ReadFile(args); string(got) != "op abandon ..86d0094c958f\nutil gc\n" {
+		t.Errorf("ran jj %q", got)
+	}
+}
+
+func TestOperation_IsCurrent(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want bool
+	}{
+		{"@  bbc9fee12c4d user@host 1 minute ago\n│  new empty commit", true},
+		{"\x1b[1;35m@\x1b[0m  \x1b[1;34mbbc9fee12c4d\x1b[0m user@host", true},
+		{"○  86d0094c958f user@host 2 minutes ago\n│  @ in the description", false},
+	}
+
+	for _, tt := range tests {
+		if got := (Operation{Raw: tt.raw}).IsCurrent(); got != tt.want {
+			t.Errorf("IsCurrent(%q) = %v, want %v", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestOperation_IsSnapshot(t *testing.T) {
+	runner := NewRunner(context.Background(), ".", testLogger(t))
+
+	ops := runner.ParseOpLogLines("@  bbc9fee12c4d user@host 1 minute ago\n│  snapshot working copy\n│  args: jj log\n" +
+		"○  86d0094c958f user@host 2 minutes ago\n│  new empty commit\n│  args: jj new\n")
+
+	if len(ops) != 2 || !ops[0].IsSnapshot() || ops[1].IsSnapshot() {
+		t.Errorf("only the first operation is a snapshot, got %+v", ops)
+	}
+}
+
+func TestDisplayCommands_IgnoreWorkingCopy(t *testing.T) {
+	fakeJJ(t, `echo "$@"`)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	commands := map[string]func() (string, error){
+		"log":       func() (string, error) { return runner.Log(50) },
+		"plain log": runner.PlainLog,
+		"show":      func() (string, error) { return runner.Show("xsssnyux") },
+		"diff":      func() (string, error) { return runner.Diff("xsssnyux") },
+		"file diff": func() (string, error) { return runner.DiffFile("xsssnyux", "main.go") },
+		"status":    runner.Status,
+		"op log":    func() (string, error) { return runner.OpLog(50) },
+		"evolog":    func() (string, error) { return runner.EvoLog("xsssnyux") },
+		"op show":   func() (string, error) { return runner.OpShow("abc123") },
+	}
+
+	for name, run := range commands {
+		output, err := run()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		if !strings.Contains(output, ignoreWorkingCopyFlag) {
+			t.Errorf("%s should not snapshot the working copy, ran jj %s", name, output)
+		}
+	}
+}
+
+func TestSetAtOperation_SparesTheOpLog(t *testing.T) {
+	fakeJJ(t, `echo "$@"`)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+	runner.SetAtOperation("bbc9fee12c4d")
+
+	if output, _ := runner.Show("xsssnyux"); !strings.Contains(output, "--at-op bbc9fee12c4d") {
+		t.Errorf("show should read the repo at the operation, ran jj %s", output)
+	}
+
+	if output, _ := runner.OpLog(0); strings.Contains(output, "--at-op") {
+		t.Errorf("the op log should still reach the present, ran jj %s", output)
+	}
+
+	runner.SetAtOperation("")
+
+	if output, _ := runner.Show("xsssnyux"); strings.Contains(output, "--at-op") {
+		t.Errorf("show should be back in the present, ran jj %s", output)
+	}
+}
+
+func TestRun_UpdatesStaleWorkingCopyAndRetries(t *testing.T) {
+	updated := filepath.Join(t.TempDir(), "updated")
+	fakeJJ(t, `if [ "$1" = workspace ]; then : >`+updated+`; exit 0; fi
+if [ -f `+updated+` ]; then echo ok; exit 0; fi
+echo "Error: The working copy is stale (not updated since operation 3f2a9c1b7e44)." >&2
+exit 1`)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	output, err := runner.Run("status")
+	if err != nil || output != "ok\n" {
+		t.Fatalf("expected the retry to succeed, got %q, %v", output, err)
+	}
+
+	select {
+	case notice := <-runner.Notices():
+		if !strings.Contains(notice, "update-stale") {
+			t.Errorf("expected the notice to name the fix, got %q", notice)
+		}
+	default:
+		t.Error("expected a notice that the working copy was updated")
+	}
+}
+
+func TestRun_StaleWorkingCopyUpdateFails(t *testing.T) {
+	fakeJJ(t, `echo "Error: The working copy is stale (not updated since operation 3f2a9c1b7e44)." >&2
+exit 1`)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	if _, err := runner.Run("status"); !IsStaleWorkingCopy(err) {
+		t.Errorf("expected the stale working copy error when updating fails, got %v", err)
+	}
+
+	select {
+	case notice := <-runner.Notices():
+		t.Errorf("nothing was fixed, yet got notice %q", notice)
+	default:
+	}
+}
+
+func TestIsRepoLocked(t *testing.T) {
+	for stderr, want := range map[string]bool{
+		"Error: Failed to lock working copy":                                  true,
+		"Error: Unable to create '/repo/.git/index.lock': File exists.":       true,
+		"Error: The working copy is stale (not updated since operation abc).": false,
+		"Error: Revision `nope` doesn't exist":                                false,
+	} {
+		if got := IsRepoLocked(&Error{Command: "new", Stderr: stderr}); got != want {
+			t.Errorf("IsRepoLocked(%q) = %v, want %v", stderr, got, want)
+		}
+	}
+}
+
+func TestRun_RetriesWhileRepoLocked(t *testing.T) {
+	defer func(base time.Duration) { lockRetryBase = base }(lockRetryBase)
+	lockRetryBase = time.Millisecond
+
+	tries := t.TempDir()
+	fakeJJ(t, `if [ -f `+tries+`/second ]; then echo ok; exit 0; fi
+if [ -f `+tries+`/first ]; then : >`+tries+`/second; else : >`+tries+`/first; fi
+echo "Error: Failed to lock working copy" >&2
+exit 1`)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	output, err := runner.Run("new")
+	if err != nil || output != "ok\n" {
+		t.Fatalf("expected a retry to get past the lock, got %q, %v", output, err)
+	}
+
+	if runner.WaitingForLock() {
+		t.Error("nothing should be waiting once the command ran")
+	}
+}
+
+func TestRun_GivesUpOnHeldLock(t *testing.T) {
+	defer func(base time.Duration) { lockRetryBase = base }(lockRetryBase)
+	lockRetryBase = time.Millisecond
+
+	fakeJJ(t, `echo "Error: Failed to lock working copy" >&2
+exit 1`)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	if _, err := runner.Run("new"); !IsRepoLocked(err) {
+		t.Errorf("expected the lock error once the retries run out, got %v", err)
+	}
+}
+
+func TestPlainLog_MethodExists(t *testing.T) {
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	// PlainLog should return (string, error)
+	if _, err := runner.PlainLog(); err == nil {
+		t.Log("PlainLog returned no error (unexpected outside a jj repo)")
+	}
+}
+
+// =============================================================================
+// Diff Range Tests
+// =============================================================================
+
+func TestDiffRange_MethodExists(t *testing.T) {
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	// DiffRange should accept two revisions and return (string, error)
+	if _, err := runner.DiffRange("abc", "def"); err == nil {
+		t.Log("DiffRange returned no error (unexpected outside a jj repo)")
+	}
+}
+
+// =============================================================================
+// Diff Tool Tests
+// =============================================================================
+
+func TestDiffToolCmd_Args(t *testing.T) {
+	dir := t.TempDir()
+	runner := NewRunner(context.Background(), dir, testLogger(t))
+
+	tests := []struct {
+		name       string
+		path, tool string
+		want       []string
+	}{
+		{"change with formatter", "", "", []string{"jj", "diff", "-r", "xsssnyux", "--ignore-working-copy"}},
+		{"change with tool", "", "difft", []string{"jj", "diff", "-r", "xsssnyux", "--ignore-working-copy", "--tool", "difft"}},
+		{"file with tool", "src/main~1.go", "meld", []string{"jj", "diff", "-r", "xsssnyux", "--ignore-working-copy", "--tool", "meld", `root-file:"src/main~1.go"`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := runner.DiffToolCmd("xsssnyux", tt.path, tt.tool)
+
+			if !slices.Equal(cmd.Args, tt.want) {
+				t.Errorf("got args %q, want %q", cmd.Args, tt.want)
+			}
+
+			if cmd.Dir != dir {
+				t.Errorf("expected the command to run in %s, got %s", dir, cmd.Dir)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package jj
+
+import (
+	"strings"
+)
+
+// SparsePatterns lists the paths the working copy checks out (jj sparse
+// list). A whole checkout is the single pattern ".".
+func (r *Runner) SparsePatterns() ([]string, error) {
+	output, err := r.view("sparse", "list")
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseSparsePatterns(output), nil
+}
+
+// ParseSparsePatterns reads jj sparse list output: one pattern a line.
+func ParseSparsePatterns(output string) []string {
+	var patterns []string
+
+	for line := range strings.SplitSeq(output, "\n") {
+		if pattern := strings.TrimSpace(line); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	return patterns
+}
+
+// SparseAdd checks out the files under pattern too (jj sparse set --add).
+func (r *Runner) SparseAdd(pattern string) error {
+	_, err := r.Run("sparse", "set", "--add", pattern)
+	return err
+}
+
+// SparseRemove stops checking out the files under pattern, removing them
+// from the working copy (jj sparse set --remove).
+func (r *Runner) SparseRemove(pattern string) error {
+	_, err := r.Run("sparse", "set", "--remove", pattern)
+	return err
+}
+
+// SparseReset checks out every file again (jj sparse reset).
+func (r *Runner) SparseReset() error {
+	_, err := r.Run("sparse", "reset")
+	return err
+}
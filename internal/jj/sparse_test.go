@@ -0,0 +1,68 @@
+package jj
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestParseSparsePatterns(t *testing.T) {
+	want := []string{"docs", "src/parser"}
+
+	if got := ParseSparsePatterns("docs\nsrc/parser\n\n"); !slices.Equal(got, want) {
+		t.Errorf("ParseSparsePatterns() = %v, want %v", got, want)
+	}
+}
+
+func TestSparse_Args(t *testing.T) {
+	args := filepath.Join(t.TempDir(), "args")
+	fakeJJ(t, `echo "$@" >`+args+`
+echo .`)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	tests := []struct {
+		name string
+		run  func() error
+		want string
+	}{
+		{"list", func() error { _, err := runner.SparsePatterns(); return err }, "sparse list --ignore-working-copy"},
+		{"add", func() error { return runner.SparseAdd("docs") }, "sparse set --add docs"},
+		{"remove", func() error { return runner.SparseRemove("docs") }, "sparse set --remove docs"},
+		{"reset", runner.SparseReset, "sparse reset"},
+	}
+
+	for _, tt := range tests {
+		if err := tt.run(); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+
+		if got, _ := os.ReadFile(args); strings.TrimSpace(string(got)) != tt.want {
+			t.Errorf("%s: ran jj %s, want jj %s", tt.name, strings.TrimSpace(string(got)), tt.want)
+		}
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: every listed pattern is parsed back
+func TestParseSparsePatterns_RoundTrip(t *testing.T) {
+	rapid.Check(t, func(rt *rapid.T) {
+		want := rapid.SliceOf(rapid.StringMatching(`[a-z./_-][a-z0-9./_ -]{0,15}[a-z0-9./_-]`)).Draw(rt, "patterns")
+
+		if got := ParseSparsePatterns(strings.Join(want, "\n") + "\n"); !slices.Equal(got, want) {
+			rt.Fatalf("ParseSparsePatterns() = %v, want %v", got, want)
+		}
+	})
+}
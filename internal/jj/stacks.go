@@ -0,0 +1,157 @@
+package jj
+
+import (
+	"slices"
+	"strings"
+)
+
+// stacksRevset selects the changes grouped into stacks: those above trunk
+// that can still be rewritten.
+const stacksRevset = "trunk().. ~ ::immutable_heads()"
+
+// Stack is a connected group of mutable changes above trunk, such as the
+// changes behind a series of stacked pull requests.
+type Stack struct {
+	Changes []Change // Newest first, as jj log lists them
+	Roots   []string // Changes whose parents are all outside the stack
+}
+
+// Name returns the newest bookmark in the stack, or the change ID of its
+// newest change when no bookmark points into it.
+func (s Stack) Name() string {
+	for _, change := range s.Changes {
+		if len(change.Bookmarks) > 0 {
+			return change.Bookmarks[0]
+		}
+	}
+
+	if len(s.Changes) == 0 {
+		return ""
+	}
+
+	return s.Changes[0].ChangeID
+}
+
+// Revset returns a revset of every change in the stack.
+func (s Stack) Revset() string {
+	ids := make([]string, len(s.Changes))
+	for i, change := range s.Changes {
+		ids[i] = change.ChangeID
+	}
+
+	return strings.Join(ids, " | ")
+}
+
+// Stacks returns the mutable changes above trunk, grouped into stacks.
+func (r *Runner) Stacks() ([]Stack, error) {
+	output, err := r.view("log", "--no-graph", "--color=never", "-r", stacksRevset, "-T", r.templates.Get("stacks"))
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseStacks(output), nil
+}
+
+// RebaseStack moves a stack, from its roots up, onto trunk.
+func (r *Runner) RebaseStack(roots []string) error {
+	_, err := r.Run(rebaseStackArgs(roots)...)
+	return err
+}
+
+// RebaseStackPreview reports what RebaseStack would do, without rebasing.
+func (r *Runner) RebaseStackPreview(roots []string) (string, error) {
+	return r.DryRun([]string{"rebase"}, rebaseStackArgs(roots)[1:]...)
+}
+
+// rebaseStackArgs builds jj rebase with a --source for each root.
+func rebaseStackArgs(roots []string) []string {
+	args := []string{"rebase"}
+	for _, root := range roots {
+		args = append(args, "--source", root)
+	}
+
+	return append(args, "--destination", "trunk()")
+}
+
+// ParseStacks reads the stacks template's output, one change a line, and
+// groups changes joined by a parent into stacks, newest stack first.
+func ParseStacks(output string) []Stack {
+	const (
+		fieldChangeID = iota
+		fieldParents
+		fieldBookmarks
+		fieldDescription
+		fieldCount
+	)
+
+	var (
+		changes []Change
+		parents = make(map[string][]string)
+	)
+
+	for line := range strings.SplitSeq(output, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != fieldCount || fields[fieldChangeID] == "" {
+			continue
+		}
+
+		changes = append(changes, Change{
+			ChangeID:    fields[fieldChangeID],
+			Bookmarks:   splitList(fields[fieldBookmarks]),
+			Description: fields[fieldDescription],
+		})
+		parents[fields[fieldChangeID]] = splitList(fields[fieldParents])
+	}
+
+	// Union the changes joined by a parent; a parent outside the listed
+	// changes is below the stack and joins nothing.
+	group := make(map[string]string, len(changes))
+	for _, change := range changes {
+		group[change.ChangeID] = change.ChangeID
+	}
+
+	var find func(id string) string
+	find = func(id string) string {
+		if group[id] != id {
+			group[id] = find(group[id])
+		}
+
+		return group[id]
+	}
+
+	for _, change := range changes {
+		for _, parent := range parents[change.ChangeID] {
+			if _, ok := group[parent]; ok {
+				group[find(parent)] = find(change.ChangeID)
+			}
+		}
+	}
+
+	var (
+		stacks []Stack
+		index  = make(map[string]int)
+	)
+
+	for _, change := range changes {
+		key := find(change.ChangeID)
+
+		idx, ok := index[key]
+		if !ok {
+			idx = len(stacks)
+			index[key] = idx
+			stacks = append(stacks, Stack{})
+		}
+
+		stack := &stacks[idx]
+		stack.Changes = append(stack.Changes, change)
+
+		if !slices.ContainsFunc(parents[change.ChangeID], func(parent string) bool {
+			_, ok := group[parent]
+			return ok
+		}) {
+			stack.Roots = append(stack.Roots, change.ChangeID)
+		}
+	}
+
+	return stacks
+}
@@ -0,0 +1,139 @@
+package jj
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// stackLine renders one line of the stacks template.
+func stackLine(id string, parents []string, bookmark, description string) string {
+	return fmt.Sprintf("%s\t%s\t%s\t%s\n", id, strings.Join(parents, ","), bookmark, description)
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestParseStacks(t *testing.T) {
+	output := stackLine("rrrrrrrr", []string{"qqqqqqqq"}, "", "parser: docs") +
+		stackLine("xxxxxxxx", []string{"wwwwwwww"}, "ui-fix", "ui: fix border") +
+		stackLine("qqqqqqqq", []string{"pppppppp"}, "parser-tabs", "parser: handle tabs") +
+		stackLine("pppppppp", []string{"zzzzzzzz"}, "parser", "parser: tidy")
+
+	stacks := ParseStacks(output)
+	if len(stacks) != 2 {
+		t.Fatalf("expected 2 stacks, got %+v", stacks)
+	}
+
+	parser, ui := stacks[0], stacks[1]
+
+	if len(parser.Changes) != 3 || parser.Name() != "parser-tabs" || !slices.Equal(parser.Roots, []string{"pppppppp"}) {
+		t.Errorf("parser stack = %+v (name %q)", parser, parser.Name())
+	}
+
+	if parser.Revset() != "rrrrrrrr | qqqqqqqq | pppppppp" {
+		t.Errorf("Revset() = %q", parser.Revset())
+	}
+
+	if len(ui.Changes) != 1 || ui.Name() != "ui-fix" {
+		t.Errorf("ui stack = %+v", ui)
+	}
+}
+
+func TestParseStacks_MergeJoinsStacks(t *testing.T) {
+	output := stackLine("mmmmmmmm", []string{"kkkkkkkk", "llllllll"}, "", "merge") +
+		stackLine("kkkkkkkk", []string{"zzzzzzzz"}, "", "left") +
+		stackLine("llllllll", []string{"zzzzzzzz"}, "", "right")
+
+	stacks := ParseStacks(output)
+	if len(stacks) != 1 || !slices.Equal(stacks[0].Roots, []string{"kkkkkkkk", "llllllll"}) {
+		t.Fatalf("a merge should join both sides into one stack with two roots, got %+v", stacks)
+	}
+
+	if stacks[0].Name() != "mmmmmmmm" {
+		t.Errorf("with no bookmark, the stack is named by its newest change, got %q", stacks[0].Name())
+	}
+}
+
+func TestStacks_Args(t *testing.T) {
+	fakeJJ(t, `printf 'nnnnnnnn\t\t\t%s\n' "$*"`)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	stacks, err := runner.Stacks()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stacks) != 1 || !strings.HasPrefix(stacks[0].Changes[0].Description, "log --no-graph --color=never -r "+stacksRevset) {
+		t.Errorf("ran jj %+v", stacks)
+	}
+}
+
+func TestRebaseStack_Args(t *testing.T) {
+	want := []string{"rebase", "--source", "kkkkkkkk", "--source", "llllllll", "--destination", "trunk()"}
+	if got := rebaseStackArgs([]string{"kkkkkkkk", "llllllll"}); !slices.Equal(got, want) {
+		t.Errorf("args = %v, want %v", got, want)
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: every change lands in exactly one stack, alongside its parents
+func TestParseStacks_Partition(t *testing.T) {
+	rapid.Check(t, func(rt *rapid.T) {
+		count := rapid.IntRange(1, 12).Draw(rt, "count")
+
+		ids := make([]string, count)
+		for i := range ids {
+			ids[i] = strings.Repeat(string(rune('k'+i)), 8)
+		}
+
+		// Newest first: each change's parents are older (later) changes or trunk
+		var output strings.Builder
+
+		parents := make(map[string][]string)
+
+		for i, id := range ids {
+			parent := rapid.IntRange(i+1, count).Draw(rt, "parent")
+
+			parentID := "zzzzzzzz"
+			if parent < count {
+				parentID = ids[parent]
+			}
+
+			parents[id] = []string{parentID}
+			output.WriteString(stackLine(id, parents[id], "", ""))
+		}
+
+		stackOf := make(map[string]int)
+
+		for idx, stack := range ParseStacks(output.String()) {
+			for _, change := range stack.Changes {
+				if _, seen := stackOf[change.ChangeID]; seen {
+					rt.Fatalf("%s is in two stacks", change.ChangeID)
+				}
+
+				stackOf[change.ChangeID] = idx
+			}
+		}
+
+		for _, id := range ids {
+			idx, ok := stackOf[id]
+			if !ok {
+				rt.Fatalf("%s is in no stack", id)
+			}
+
+			if parentIdx, ok := stackOf[parents[id][0]]; ok && parentIdx != idx {
+				rt.Fatalf("%s and its parent are in different stacks", id)
+			}
+		}
+	})
+}
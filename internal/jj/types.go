@@ -1,6 +1,12 @@
 package jj
 
-import "regexp"
+import (
+	"regexp"
+	"strings"
+)
+
+// statTruncation starts a path jj shortened in diff --stat output.
+const statTruncation = "..."
 
 // EntryLineRe matches entry lines in both op log and evolog output:
 //   - Operation IDs: 12 hex characters (0-9a-f) from jj op log.
@@ -9,14 +15,68 @@ var EntryLineRe = regexp.MustCompile(`^[│├└\s]*[@○]\s+(?:(?P<opID>[0-9a-
 
 // Change represents a jj change/commit.
 type Change struct {
-	ChangeID    string   // Short change ID (e.g., "xsssnyux")
-	CommitID    string   // Git commit hash
-	Author      string   // Author email
-	Timestamp   string   // Formatted timestamp
-	Description string   // Full commit message
-	Bookmarks   []string // Bookmarks pointing to this change
-	IsEmpty     bool     // Does this change have no diff?
-	Raw         string   // Raw line from jj log (with ANSI colors)
+	ChangeID      string   // Short change ID (e.g., "xsssnyux")
+	CommitID      string   // Git commit hash
+	Author        string   // Author email
+	Timestamp     string   // Formatted timestamp
+	Description   string   // Full commit message
+	Bookmarks     []string // Local bookmarks pointing to this change
+	Tags          []string // Tags pointing to this change
+	IsEmpty       bool     // Does this change have no diff?
+	IsImmutable   bool     // Is this change in immutable_heads()::?
+	IsConflicted  bool     // Does this change contain unresolved conflicts?
+	IsWorkingCopy bool     // Is this the working-copy change (@)?
+	Raw           string   // Raw line from jj log (with ANSI colors)
+}
+
+// ChangeStat summarizes the size of a change's diff.
+type ChangeStat struct {
+	Added   int // Lines inserted
+	Removed int // Lines deleted
+}
+
+// FileStat is the size of one file's diff within a change.
+type FileStat struct {
+	Path string // New path; jj may shorten long paths to "..." plus a suffix
+	ChangeStat
+}
+
+// Matches reports whether the stat is for path, allowing for a path jj
+// shortened to fit the stat width.
+func (s FileStat) Matches(path string) bool {
+	if rest, ok := strings.CutPrefix(s.Path, statTruncation); ok {
+		return strings.HasSuffix(path, rest)
+	}
+
+	return s.Path == path
+}
+
+// WorkingCopyState describes whether the working-copy commit is safe to amend.
+type WorkingCopyState struct {
+	ChangeID        string   // Shortest change ID of @
+	CommitID        string   // Short commit hash of @
+	Immutable       bool     // Is @ in immutable_heads()?
+	RemoteBookmarks []string // Remote bookmarks on @ (e.g. "main@origin"), excluding the colocated git remote
+}
+
+// Published reports whether amending the working copy would rewrite shared history.
+func (s WorkingCopyState) Published() bool {
+	return s.Immutable || len(s.RemoteBookmarks) > 0
+}
+
+// Bookmark represents a local bookmark from jj bookmark list.
+type Bookmark struct {
+	Name        string // Bookmark name (e.g., "main")
+	ChangeID    string // Shortest change ID of the target (empty when conflicted)
+	Description string // First line of the target's description
+	Conflict    bool   // Does the bookmark point at more than one commit?
+	Synced      bool   // Does it match all of its tracked remotes?
+}
+
+// PushResult is the outcome of pushing one bookmark.
+type PushResult struct {
+	OK     bool   // Did the push succeed for this bookmark?
+	Status string // What happened, e.g. "move forward", "up to date", or the error
 }
 
 // Operation represents a jj operation from op log.
@@ -30,6 +90,23 @@ type Operation struct {
 	Raw         string // Raw line from jj op log (with ANSI colors)
 }
 
+// IsCurrent reports whether the operation is the one the repo is at, which
+// the op log marks with @.
+func (o Operation) IsCurrent() bool {
+	first, _, _ := strings.Cut(stripANSI(o.Raw), "\n")
+	return strings.HasPrefix(strings.TrimLeft(first, "│├└ "), "@")
+}
+
+// snapshotDescription is how jj describes the operation it records when it
+// snapshots working-copy edits before running a command.
+const snapshotDescription = "snapshot working copy"
+
+// IsSnapshot reports whether the operation only recorded working-copy edits,
+// which jj does before nearly every command.
+func (o Operation) IsSnapshot() bool {
+	return o.Description == snapshotDescription
+}
+
 // File represents a file changed in a commit.
 type File struct {
 	Path   string
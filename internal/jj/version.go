@@ -0,0 +1,118 @@
+package jj
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"sync"
+)
+
+// ErrNotInstalled is returned by Runner.Version when jj is not on PATH.
+var ErrNotInstalled = errors.New("jj is not installed")
+
+// errNoVersion is returned by ParseVersion for output without a version.
+var errNoVersion = errors.New("no version in jj --version output")
+
+// versionRe matches the version in jj --version output, e.g.
+// "jj 0.30.0-2a7d1b0c" or "jj 0.31.0".
+var versionRe = regexp.MustCompile(`(\d+)\.(\d+)\.(\d+)`)
+
+// Version is a jj release version.
+type Version struct {
+	Major, Minor, Patch int
+}
+
+// MinVersion is the oldest jj chado runs with. Newer features are gated by
+// their Feature instead, so older releases still get the rest.
+var MinVersion = Version{Major: 0, Minor: 25, Patch: 0}
+
+// Feature is something chado uses that older jj releases lack.
+type Feature struct {
+	Name  string
+	Since Version // first jj release with the feature
+}
+
+// FeatureEvologOperations is evolog templates that reach the operation
+// behind each entry, which the evolog pane lists and compares versions by.
+var FeatureEvologOperations = Feature{Name: "evolog by operation", Since: Version{Major: 0, Minor: 30, Patch: 0}}
+
+// FeatureRevert is jj revert, which replaced jj backout.
+var FeatureRevert = Feature{Name: "jj revert", Since: Version{Major: 0, Minor: 28, Patch: 0}}
+
+// FeatureAnnotationLine is jj file annotate templates over each line
+// (AnnotationLine) rather than its commit. Either way annotate works, so it
+// is not listed in Features.
+var FeatureAnnotationLine = Feature{Name: "annotation line templates", Since: Version{Major: 0, Minor: 28, Patch: 0}}
+
+// Features lists the gated features, for explaining what is unavailable.
+var Features = []Feature{FeatureEvologOperations, FeatureRevert}
+
+// versionProbe caches the result of jj --version; jj is not upgraded under
+// a running chado.
+type versionProbe struct {
+	once    sync.Once
+	version Version
+	err     error
+}
+
+// ParseVersion reads the version from jj --version output.
+func ParseVersion(output string) (Version, error) {
+	match := versionRe.FindStringSubmatch(output)
+	if match == nil {
+		return Version{}, fmt.Errorf("%w: %q", errNoVersion, output)
+	}
+
+	// The regexp only matches digits, so these cannot fail
+	major, _ := strconv.Atoi(match[1])
+	minor, _ := strconv.Atoi(match[2])
+	patch, _ := strconv.Atoi(match[3])
+
+	return Version{Major: major, Minor: minor, Patch: patch}, nil
+}
+
+// Less reports whether v is older than other.
+func (v Version) Less(other Version) bool {
+	if v.Major != other.Major {
+		return v.Major < other.Major
+	}
+
+	if v.Minor != other.Minor {
+		return v.Minor < other.Minor
+	}
+
+	return v.Patch < other.Patch
+}
+
+// String formats the version as major.minor.patch.
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Version returns the version of the jj on PATH, or ErrNotInstalled. jj is
+// asked once; later calls return the same answer.
+func (r *Runner) Version() (Version, error) {
+	r.versionProbe.once.Do(func() {
+		output, err := r.Run("--version")
+
+		switch {
+		case errors.Is(err, exec.ErrNotFound):
+			r.versionProbe.err = ErrNotInstalled
+		case err != nil:
+			r.versionProbe.err = err
+		default:
+			r.versionProbe.version, r.versionProbe.err = ParseVersion(output)
+		}
+	})
+
+	return r.versionProbe.version, r.versionProbe.err
+}
+
+// Supports reports whether the jj on PATH has feature. An unknown version
+// supports nothing gated.
+func (r *Runner) Supports(feature Feature) bool {
+	version, err := r.Version()
+
+	return err == nil && !version.Less(feature.Since)
+}
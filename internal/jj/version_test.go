@@ -0,0 +1,126 @@
+package jj
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		output string
+		want   Version
+	}{
+		{"jj 0.30.0\n", Version{0, 30, 0}},
+		{"jj 0.31.0-2a7d1b0c1f5e\n", Version{0, 31, 0}},
+		{"jj 1.2.3", Version{1, 2, 3}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseVersion(tt.output)
+		if err != nil {
+			t.Fatalf("ParseVersion(%q): %v", tt.output, err)
+		}
+
+		if got != tt.want {
+			t.Errorf("ParseVersion(%q) = %v, want %v", tt.output, got, tt.want)
+		}
+	}
+
+	if _, err := ParseVersion("jj (dev build)"); err == nil {
+		t.Error("expected an error for output without a version")
+	}
+}
+
+func TestVersion_Less(t *testing.T) {
+	if !(Version{0, 24, 9}).Less(MinVersion) {
+		t.Error("0.24.9 should be older than the minimum")
+	}
+
+	if (Version{1, 0, 0}).Less(MinVersion) || MinVersion.Less(MinVersion) {
+		t.Error("the minimum and newer versions should be supported")
+	}
+}
+
+func TestVersion_NotInstalled(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	if _, err := runner.Version(); !errors.Is(err, ErrNotInstalled) {
+		t.Errorf("expected ErrNotInstalled without jj on PATH, got %v", err)
+	}
+}
+
+func TestSupports(t *testing.T) {
+	for _, tt := range []struct {
+		version string
+		want    bool
+	}{
+		{"0.29.0", false},
+		{"0.30.0", true},
+		{"0.31.1", true},
+	} {
+		t.Run(tt.version, func(t *testing.T) {
+			fakeJJ(t, "echo 'jj "+tt.version+"'")
+
+			runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+			if got := runner.Supports(FeatureEvologOperations); got != tt.want {
+				t.Errorf("Supports(%s) with jj %s = %v, want %v", FeatureEvologOperations.Name, tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSupports_NotInstalled(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	if runner.Supports(FeatureEvologOperations) {
+		t.Error("an unknown jj should support no gated feature")
+	}
+}
+
+// fakeJJ puts a jj on PATH that runs the shell script for any command.
+func fakeJJ(t *testing.T, script string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	script = "#!/bin/sh\n" + script + "\n"
+
+	if err := os.WriteFile(filepath.Join(dir, "jj"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("PATH", dir)
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: a formatted version parses back to itself
+func TestVersion_RoundTrip(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		v := Version{
+			Major: rapid.IntRange(0, 99).Draw(t, "major"),
+			Minor: rapid.IntRange(0, 99).Draw(t, "minor"),
+			Patch: rapid.IntRange(0, 99).Draw(t, "patch"),
+		}
+
+		got, err := ParseVersion("jj " + v.String())
+		if err != nil || got != v {
+			t.Fatalf("ParseVersion(%q) = %v, %v", "jj "+v.String(), got, err)
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/fsnotify/fsnotify"
@@ -16,18 +17,51 @@ import (
 // WatcherMsg is sent when the jj repo changes.
 type WatcherMsg struct{}
 
+// WatchMode is what a Watcher watches for changes to the repo.
+type WatchMode string
+
+const (
+	// WatchTree watches the op heads and every directory of the working copy
+	// that is not ignored, so edits show up as they are saved.
+	WatchTree WatchMode = "tree"
+
+	// WatchOpHeads watches only the op heads: changes made by jj commands.
+	// It needs a handful of watches however big the repo is.
+	WatchOpHeads WatchMode = "op-heads"
+
+	// WatchOpHeadsAndRoot also watches the top directory of the working
+	// copy, but none below it.
+	WatchOpHeadsAndRoot WatchMode = "op-heads+root"
+)
+
+// WatchOptions configures a Watcher.
+type WatchOptions struct {
+	Mode   WatchMode
+	Ignore []string // gitignore-style patterns of files not worth a refresh, e.g. "*.swp"
+}
+
 // Watcher watches the .jj directory for changes.
 type Watcher struct {
-	watcher  *fsnotify.Watcher
-	filtered chan fsnotify.Event
-	done     chan struct{}
-	log      *logger.Logger
-	ignore   *ignore.Matcher
+	watcher   *fsnotify.Watcher
+	filtered  chan fsnotify.Event
+	done      chan struct{}
+	log       *logger.Logger
+	ignore    *ignore.Matcher
+	recursive bool // watch new directories as they are created
 }
 
-// NewWatcher creates a new file watcher for the jj repo.
+// NewWatcher creates a new file watcher for the jj repo, watching the whole
+// working copy.
 func NewWatcher(repoPath string, log *logger.Logger) (*Watcher, error) {
-	log.Debug("creating file watcher", "repo_path", repoPath)
+	return NewWatcherWith(repoPath, WatchOptions{Mode: WatchTree}, log)
+}
+
+// NewWatcherWith creates a new file watcher for the jj repo that watches
+// what opts.Mode says, skipping files opts.Ignore matches. Unknown modes
+// watch the whole working copy.
+func NewWatcherWith(repoPath string, opts WatchOptions, log *logger.Logger) (*Watcher, error) {
+	mode := opts.Mode
+	log.Debug("creating file watcher", "repo_path", repoPath, "mode", mode)
 
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
@@ -37,7 +71,7 @@ func NewWatcher(repoPath string, log *logger.Logger) (*Watcher, error) {
 	}
 
 	// Watch the .jj/repo/op_heads/heads directory for changes.
-	jjPath := filepath.Join(repoPath, ".jj", "repo", "op_heads", "heads")
+	jjPath := opHeadsPath(repoPath)
 	if err := watcher.Add(jjPath); err != nil {
 		log.Error("failed to watch .jj directory", "path", jjPath, "err", err)
 		watcher.Close()
@@ -46,8 +80,64 @@ func NewWatcher(repoPath string, log *logger.Logger) (*Watcher, error) {
 	}
 
 	ignoreMatcher := ignore.NewMatcher(repoPath)
+	ignoreMatcher.AddPatterns(opts.Ignore...)
+	recursive := mode != WatchOpHeads && mode != WatchOpHeadsAndRoot
 
-	// Walk the repo directory and add all non-ignored subdirectories.
+	watchCount := 0
+
+	switch {
+	case mode == WatchOpHeadsAndRoot:
+		if err := watcher.Add(repoPath); err == nil {
+			watchCount++
+		}
+	case recursive:
+		watchCount = watchTree(watcher, repoPath, ignoreMatcher)
+	}
+
+	log.Info("watcher started", "mode", mode, "watched_dirs", watchCount)
+
+	self := &Watcher{
+		watcher:   watcher,
+		filtered:  make(chan fsnotify.Event, 1),
+		done:      make(chan struct{}),
+		log:       log,
+		ignore:    ignoreMatcher,
+		recursive: recursive,
+	}
+
+	go self.filterEvents()
+
+	return self, nil
+}
+
+// opHeadsPath is the directory holding a file per operation head.
+func opHeadsPath(repoPath string) string {
+	return filepath.Join(repoPath, ".jj", "repo", "op_heads", "heads")
+}
+
+// OpHeads returns the IDs of the repo's operation heads, sorted and joined,
+// or "" when they can't be read. It changes whenever a jj command records an
+// operation, snapshots included, so comparing it tells edits to working-copy
+// files apart from changes made by jj.
+func OpHeads(repoPath string) string {
+	entries, err := os.ReadDir(opHeadsPath(repoPath))
+	if err != nil {
+		return ""
+	}
+
+	heads := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		heads = append(heads, entry.Name())
+	}
+
+	slices.Sort(heads)
+
+	return strings.Join(heads, ",")
+}
+
+// watchTree adds repoPath and every directory below it that is not ignored,
+// returning how many were added.
+func watchTree(watcher *fsnotify.Watcher, repoPath string, ignoreMatcher *ignore.Matcher) int {
 	watchCount := 0
 	_ = filepath.WalkDir(repoPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
@@ -69,19 +159,7 @@ func NewWatcher(repoPath string, log *logger.Logger) (*Watcher, error) {
 		return nil
 	})
 
-	log.Info("watcher started", "watched_dirs", watchCount)
-
-	self := &Watcher{
-		watcher:  watcher,
-		filtered: make(chan fsnotify.Event, 1),
-		done:     make(chan struct{}),
-		log:      log,
-		ignore:   ignoreMatcher,
-	}
-
-	go self.filterEvents()
-
-	return self, nil
+	return watchCount
 }
 
 // Events returns the channel of filtered fsnotify events.
@@ -117,6 +195,11 @@ func (w *Watcher) filterEvents() {
 				return
 			}
 
+			// New ignore rules apply from the next event on
+			if ignore.IsIgnoreFile(event.Name) {
+				w.ignore.Reload()
+			}
+
 			w.trackNewDirectory(event)
 
 			if !w.shouldForward(event) {
@@ -143,7 +226,7 @@ func (w *Watcher) filterEvents() {
 // trackNewDirectory adds newly created directories to the watcher so that
 // file changes in them are picked up. Ignored directories are skipped.
 func (w *Watcher) trackNewDirectory(event fsnotify.Event) {
-	if !event.Has(fsnotify.Create) {
+	if !w.recursive || !event.Has(fsnotify.Create) {
 		return
 	}
 
@@ -171,9 +254,13 @@ func (w *Watcher) shouldForward(event fsnotify.Event) bool {
 		return false
 	}
 
-	if w.ignore.Match(event.Name, false) {
-		return false
+	// Directory-only rules (e.g. "target/") apply to directories created,
+	// not just to what is written inside them
+	isDir := false
+	if event.Has(fsnotify.Create) {
+		info, err := os.Stat(event.Name)
+		isDir = err == nil && info.IsDir()
 	}
 
-	return true
+	return !w.ignore.Match(event.Name, isDir)
 }
@@ -303,6 +303,71 @@ func TestWatcher_IgnoresGitignorePatterns(t *testing.T) {
 	}
 }
 
+func TestWatcher_IgnoresNewGitignoredDirectory(t *testing.T) {
+	dir := t.TempDir()
+	setupFakeJJDir(t, dir)
+
+	if err := os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("target/\n"), 0o644); err != nil {
+		t.Fatalf("failed to create .gitignore: %v", err)
+	}
+
+	w, err := NewWatcher(dir, testLogger(t))
+	if err != nil {
+		t.Fatalf("NewWatcher failed: %v", err)
+	}
+	defer w.Close()
+
+	// A build creating an ignored directory should NOT trigger an event
+	targetDir := filepath.Join(dir, "target")
+	if err := os.Mkdir(targetDir, 0o755); err != nil {
+		t.Fatalf("failed to create target dir: %v", err)
+	}
+
+	regularFile := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(regularFile, []byte("package main"), 0o644); err != nil {
+		t.Fatalf("failed to create regular file: %v", err)
+	}
+
+	select {
+	case event := <-w.Events():
+		if event.Name != regularFile {
+			t.Errorf("expected event for %s, got %s", regularFile, event.Name)
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Error("expected event for regular file, got none")
+	}
+}
+
+func TestWatcherWith_IgnoresConfiguredPatterns(t *testing.T) {
+	dir := t.TempDir()
+	setupFakeJJDir(t, dir)
+
+	w, err := NewWatcherWith(dir, WatchOptions{Mode: WatchTree, Ignore: []string{"*.swp"}}, testLogger(t))
+	if err != nil {
+		t.Fatalf("NewWatcherWith failed: %v", err)
+	}
+	defer w.Close()
+
+	// An editor swap file should NOT trigger an event
+	if err := os.WriteFile(filepath.Join(dir, ".main.go.swp"), []byte("swap"), 0o644); err != nil {
+		t.Fatalf("failed to create swap file: %v", err)
+	}
+
+	regularFile := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(regularFile, []byte("package main"), 0o644); err != nil {
+		t.Fatalf("failed to create regular file: %v", err)
+	}
+
+	select {
+	case event := <-w.Events():
+		if event.Name != regularFile {
+			t.Errorf("expected event for %s, got %s", regularFile, event.Name)
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Error("expected event for regular file, got none")
+	}
+}
+
 func TestWatcher_IgnoresNodeModulesDirectory(t *testing.T) {
 	dir := t.TempDir()
 	setupFakeJJDir(t, dir)
@@ -343,6 +408,73 @@ func TestWatcher_IgnoresNodeModulesDirectory(t *testing.T) {
 	}
 }
 
+func TestWatcherMode_OpHeadsSkipsWorkingCopy(t *testing.T) {
+	dir := t.TempDir()
+	setupFakeJJDir(t, dir)
+
+	w, err := NewWatcherWith(dir, WatchOptions{Mode: WatchOpHeads}, testLogger(t))
+	if err != nil {
+		t.Fatalf("NewWatcherWith failed: %v", err)
+	}
+	defer w.Close()
+
+	// Edits to the working copy should NOT trigger an event
+	if err := os.WriteFile(filepath.Join(dir, "edited.txt"), []byte("content"), 0o644); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+
+	// A new operation SHOULD trigger an event
+	opHead := filepath.Join(dir, ".jj", "repo", "op_heads", "heads", "3f2a9c1b7e44")
+	if err := os.WriteFile(opHead, nil, 0o644); err != nil {
+		t.Fatalf("failed to create op head: %v", err)
+	}
+
+	select {
+	case event := <-w.Events():
+		if event.Name != opHead {
+			t.Errorf("expected event for %s, got %s", opHead, event.Name)
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Error("expected event for the new op head, got none")
+	}
+}
+
+func TestWatcherMode_OpHeadsAndRootSkipsSubdirectories(t *testing.T) {
+	dir := t.TempDir()
+	setupFakeJJDir(t, dir)
+
+	subDir := filepath.Join(dir, "src")
+	if err := os.Mkdir(subDir, 0o755); err != nil {
+		t.Fatalf("failed to create subdir: %v", err)
+	}
+
+	w, err := NewWatcherWith(dir, WatchOptions{Mode: WatchOpHeadsAndRoot}, testLogger(t))
+	if err != nil {
+		t.Fatalf("NewWatcherWith failed: %v", err)
+	}
+	defer w.Close()
+
+	// A file below the top directory should NOT trigger an event
+	if err := os.WriteFile(filepath.Join(subDir, "main.go"), []byte("package main"), 0o644); err != nil {
+		t.Fatalf("failed to create file in subdir: %v", err)
+	}
+
+	// A file in the top directory SHOULD trigger an event
+	topFile := filepath.Join(dir, "README.md")
+	if err := os.WriteFile(topFile, []byte("readme"), 0o644); err != nil {
+		t.Fatalf("failed to create top-level file: %v", err)
+	}
+
+	select {
+	case event := <-w.Events():
+		if event.Name != topFile {
+			t.Errorf("expected event for %s, got %s", topFile, event.Name)
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Error("expected event for the top-level file, got none")
+	}
+}
+
 func TestWatcher_Close(t *testing.T) {
 	dir := t.TempDir()
 	setupFakeJJDir(t, dir)
@@ -369,6 +501,27 @@ func TestWatcher_Close(t *testing.T) {
 	}
 }
 
+func TestOpHeads(t *testing.T) {
+	dir := t.TempDir()
+
+	if got := OpHeads(dir); got != "" {
+		t.Errorf("OpHeads() without a repo = %q, want empty", got)
+	}
+
+	setupFakeJJDir(t, dir)
+	heads := filepath.Join(dir, ".jj", "repo", "op_heads", "heads")
+
+	for _, id := range []string{"b2", "a1"} {
+		if err := os.WriteFile(filepath.Join(heads, id), nil, 0o644); err != nil {
+			t.Fatalf("failed to create op head: %v", err)
+		}
+	}
+
+	if got := OpHeads(dir); got != "a1,b2" {
+		t.Errorf("OpHeads() = %q, want %q", got, "a1,b2")
+	}
+}
+
 // =============================================================================
 // Property Tests
 // =============================================================================
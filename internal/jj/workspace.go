@@ -0,0 +1,82 @@
+package jj
+
+import (
+	"strings"
+)
+
+// workspaceFields is how many fields follow a workspace's name: change ID,
+// commit ID, and summary.
+const workspaceFields = 3
+
+// Workspace is a working copy of the repo, as jj workspace list shows it.
+type Workspace struct {
+	Name     string
+	ChangeID string // of the workspace's working-copy commit
+	CommitID string
+	Summary  string // the rest of jj's line: markers such as "(empty)" and the description
+}
+
+// Workspaces lists the repo's workspaces (jj workspace list).
+func (r *Runner) Workspaces() ([]Workspace, error) {
+	output, err := r.view("workspace", "list", "--color=never")
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseWorkspaces(output), nil
+}
+
+// ParseWorkspaces reads jj workspace list output: one workspace a line, its
+// name, then the change ID, commit ID, and summary of its working copy, e.g.
+// "default: qpvuntsm 230dd059 (empty) (no description set)".
+func ParseWorkspaces(output string) []Workspace {
+	var workspaces []Workspace
+
+	for line := range strings.SplitSeq(output, "\n") {
+		name, rest, ok := strings.Cut(line, ": ")
+		if !ok || name == "" {
+			continue
+		}
+
+		fields := strings.SplitN(rest, " ", workspaceFields)
+		fields = append(fields, make([]string, workspaceFields-len(fields))...)
+		workspace := Workspace{Name: name, ChangeID: fields[0], CommitID: fields[1], Summary: fields[2]}
+
+		workspaces = append(workspaces, workspace)
+	}
+
+	return workspaces
+}
+
+// WorkspaceRoot returns the root directory of the workspace called name.
+func (r *Runner) WorkspaceRoot(name string) (string, error) {
+	output, err := r.view("workspace", "root", "--name", name)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(output), nil
+}
+
+// WorkspaceAdd creates a workspace at path, relative to the current one's
+// root, named after the path's last element.
+func (r *Runner) WorkspaceAdd(path string) error {
+	_, err := r.Run("workspace", "add", path)
+	return err
+}
+
+// WorkspaceForget stops jj tracking the workspace called name. Its files
+// stay on disk.
+func (r *Runner) WorkspaceForget(name string) error {
+	_, err := r.Run("workspace", "forget", name)
+	return err
+}
+
+// WithWorkDir returns a copy of the runner that runs jj in dir, such as
+// another workspace's root.
+func (r *Runner) WithWorkDir(dir string) *Runner {
+	derived := *r
+	derived.workDir = dir
+
+	return &derived
+}
@@ -0,0 +1,106 @@
+package jj
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestParseWorkspaces(t *testing.T) {
+	output := "default: qpvuntsm 230dd059 (empty) (no description set)\n" +
+		"feature: zsuskuln 5c0d7e8a parser: handle tabs\n"
+
+	want := []Workspace{
+		{Name: "default", ChangeID: "qpvuntsm", CommitID: "230dd059", Summary: "(empty) (no description set)"},
+		{Name: "feature", ChangeID: "zsuskuln", CommitID: "5c0d7e8a", Summary: "parser: handle tabs"},
+	}
+
+	if got := ParseWorkspaces(output); !slices.Equal(got, want) {
+		t.Errorf("ParseWorkspaces() = %+v, want %+v", got, want)
+	}
+}
+
+func TestWorkspace_Args(t *testing.T) {
+	args := filepath.Join(t.TempDir(), "args")
+	fakeJJ(t, `echo "$@" >`+args+`
+echo /repos/feature`)
+
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t))
+
+	root, err := runner.WorkspaceRoot("feature")
+	if err != nil || root != "/repos/feature" {
+		t.Fatalf("WorkspaceRoot() = %q, %v", root, err)
+	}
+
+	tests := []struct {
+		name string
+		run  func() error
+		want string
+	}{
+		{"root", func() error { _, err := runner.WorkspaceRoot("feature"); return err }, "workspace root --name feature --ignore-working-copy"},
+		{"add", func() error { return runner.WorkspaceAdd("../feature") }, "workspace add ../feature"},
+		{"forget", func() error { return runner.WorkspaceForget("feature") }, "workspace forget feature"},
+	}
+
+	for _, tt := range tests {
+		if err := tt.run(); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+
+		if got, _ := os.ReadFile(args); strings.TrimSpace(string(got)) != tt.want {
+			t.Errorf("%s: ran jj %s, want jj %s", tt.name, strings.TrimSpace(string(got)), tt.want)
+		}
+	}
+}
+
+func TestWithWorkDir_RunsThere(t *testing.T) {
+	fakeJJ(t, `pwd`)
+
+	dir := t.TempDir()
+	runner := NewRunner(context.Background(), t.TempDir(), testLogger(t)).WithWorkDir(dir)
+
+	got, err := runner.Run("status")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.TrimSpace(got) != dir {
+		t.Errorf("ran in %s, want %s", strings.TrimSpace(got), dir)
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: every listed workspace is parsed back, whatever its summary
+func TestParseWorkspaces_RoundTrip(t *testing.T) {
+	rapid.Check(t, func(rt *rapid.T) {
+		want := rapid.SliceOf(rapid.Custom(func(rt *rapid.T) Workspace {
+			return Workspace{
+				Name:     rapid.StringMatching(`[a-z][a-z0-9-]{0,10}`).Draw(rt, "name"),
+				ChangeID: rapid.StringMatching(`[k-z]{8}`).Draw(rt, "change"),
+				CommitID: rapid.StringMatching(`[0-9a-f]{8}`).Draw(rt, "commit"),
+				Summary:  rapid.StringMatching(`[^\n]+`).Draw(rt, "summary"),
+			}
+		})).Draw(rt, "workspaces")
+
+		var output strings.Builder
+		for _, w := range want {
+			output.WriteString(w.Name + ": " + w.ChangeID + " " + w.CommitID + " " + w.Summary + "\n")
+		}
+
+		if got := ParseWorkspaces(output.String()); !slices.Equal(got, want) {
+			rt.Fatalf("ParseWorkspaces() = %+v, want %+v", got, want)
+		}
+	})
+}
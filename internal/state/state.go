@@ -0,0 +1,139 @@
+// Package state remembers where the user was in each repository between
+// sessions, in a file in the XDG state directory.
+package state
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+)
+
+const (
+	// fileName is the state file name inside the chado state directory.
+	fileName = "state.toml"
+
+	// dirPermissions is the mode for the state directory (owner rwx, group/other rx).
+	dirPermissions = 0o755
+
+	// filePermissions is the mode for the state file (owner rw).
+	filePermissions = 0o600
+)
+
+// Repo is the UI state saved for one repository.
+type Repo struct {
+	FocusedPane  int    `toml:"focused_pane"`
+	LeftWidthPct int    `toml:"left_width_pct"` // zero when never saved
+	ChangeID     string `toml:"change_id"`      // selected in the log
+
+	// Descriptions written in the describe input, oldest first
+	Descriptions []string `toml:"descriptions,omitempty"`
+}
+
+// file is the state file's layout: each repository's state by workspace root.
+type file struct {
+	Repos map[string]Repo `toml:"repos"`
+}
+
+// Path returns the state file location: $XDG_STATE_HOME/chado/state.toml,
+// falling back to ~/.local/state/chado/state.toml.
+func Path() (string, error) {
+	stateDir := os.Getenv("XDG_STATE_HOME")
+	if stateDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("could not determine home directory: %w", err)
+		}
+
+		stateDir = filepath.Join(home, ".local", "state")
+	}
+
+	return filepath.Join(stateDir, "chado", fileName), nil
+}
+
+// Load reads the state saved for the repository at root from Path.
+func Load(root string) (Repo, error) {
+	path, err := Path()
+	if err != nil {
+		return Repo{}, err
+	}
+
+	return LoadFile(path, root)
+}
+
+// LoadFile reads the state saved for the repository at root from path. A
+// missing file or repository yields the zero Repo.
+func LoadFile(path, root string) (Repo, error) {
+	saved, err := readFile(path)
+	if err != nil {
+		return Repo{}, err
+	}
+
+	return saved.Repos[root], nil
+}
+
+// Save records the state of the repository at root in the file at Path.
+func Save(root string, repo Repo) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+
+	return SaveFile(path, root, repo)
+}
+
+// SaveFile records the state of the repository at root in the file at path,
+// keeping the state saved for other repositories.
+func SaveFile(path, root string, repo Repo) error {
+	saved, err := readFile(path)
+	if err != nil {
+		// Start over rather than keep failing on a damaged file
+		saved = file{}
+	}
+
+	if saved.Repos == nil {
+		saved.Repos = make(map[string]Repo)
+	}
+
+	saved.Repos[root] = repo
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(saved); err != nil {
+		return fmt.Errorf("encoding state: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), dirPermissions); err != nil {
+		return fmt.Errorf("could not create state directory: %w", err)
+	}
+
+	// Write beside the file and rename so a crash never leaves it half written
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), filePermissions); err != nil {
+		return fmt.Errorf("writing state %s: %w", tmp, err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("writing state %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// readFile decodes the state file at path; a missing file is empty.
+func readFile(path string) (file, error) {
+	var saved file
+
+	if _, err := toml.DecodeFile(path, &saved); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return file{}, nil
+		}
+
+		return file{}, fmt.Errorf("reading state %s: %w", path, err)
+	}
+
+	return saved, nil
+}
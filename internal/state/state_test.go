@@ -0,0 +1,114 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestLoadFile_MissingIsEmpty(t *testing.T) {
+	repo, err := LoadFile(filepath.Join(t.TempDir(), "missing.toml"), "/repo")
+	if err != nil {
+		t.Fatalf("missing file should not error: %v", err)
+	}
+
+	if !reflect.DeepEqual(repo, Repo{}) {
+		t.Errorf("expected no saved state, got %+v", repo)
+	}
+}
+
+func TestSaveFile_KeepsOtherRepos(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chado", "state.toml")
+	first := Repo{FocusedPane: 2, LeftWidthPct: 55, ChangeID: "qpvuntsm", Descriptions: []string{"fix: parser\n\nbody"}}
+	second := Repo{FocusedPane: 0, LeftWidthPct: 30, ChangeID: "zzzzzzzz"}
+
+	if err := SaveFile(path, "/one", first); err != nil {
+		t.Fatalf("saving: %v", err)
+	}
+
+	if err := SaveFile(path, "/two", second); err != nil {
+		t.Fatalf("saving: %v", err)
+	}
+
+	if got, _ := LoadFile(path, "/one"); !reflect.DeepEqual(got, first) {
+		t.Errorf("expected %+v, got %+v", first, got)
+	}
+
+	if got, _ := LoadFile(path, "/two"); !reflect.DeepEqual(got, second) {
+		t.Errorf("expected %+v, got %+v", second, got)
+	}
+}
+
+func TestLoadFile_MalformedErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.toml")
+	if err := os.WriteFile(path, []byte("[repos\n"), 0o600); err != nil {
+		t.Fatalf("writing state: %v", err)
+	}
+
+	if _, err := LoadFile(path, "/repo"); err == nil {
+		t.Error("expected an error for a malformed state file")
+	}
+
+	if err := SaveFile(path, "/repo", Repo{ChangeID: "abc"}); err != nil {
+		t.Fatalf("saving over a malformed file should start over: %v", err)
+	}
+
+	if got, err := LoadFile(path, "/repo"); err != nil || got.ChangeID != "abc" {
+		t.Errorf("expected the saved state, got %+v, %v", got, err)
+	}
+}
+
+func TestPath_UsesXDGStateHome(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", dir)
+
+	path, err := Path()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := filepath.Join(dir, "chado", "state.toml"); path != want {
+		t.Errorf("Path() = %q, want %q", path, want)
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+func TestSaveFile_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.toml")
+
+	rapid.Check(t, func(t *rapid.T) {
+		root := rapid.StringMatching(`/[a-z ."\\]{1,20}`).Draw(t, "root")
+		repo := Repo{
+			FocusedPane:  rapid.IntRange(0, 2).Draw(t, "pane"),
+			LeftWidthPct: rapid.IntRange(0, 100).Draw(t, "pct"),
+			ChangeID:     rapid.StringMatching(`[k-z]{0,12}`).Draw(t, "change"),
+		}
+
+		if descriptions := rapid.SliceOf(rapid.StringMatching(`[a-z "\\\n]{1,20}`)).Draw(t, "descriptions"); len(descriptions) > 0 {
+			repo.Descriptions = descriptions
+		}
+
+		if err := SaveFile(path, root, repo); err != nil {
+			t.Fatalf("saving: %v", err)
+		}
+
+		got, err := LoadFile(path, root)
+		if err != nil {
+			t.Fatalf("loading: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, repo) {
+			t.Fatalf("expected %+v, got %+v", repo, got)
+		}
+	})
+}
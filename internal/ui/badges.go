@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/chatter/chado/internal/forge"
+	"github.com/chatter/chado/internal/jj"
+)
+
+// renderBadges returns the badge cluster appended to a change's first log line:
+// bookmarks with their pull requests, tags, and flags for immutable,
+// conflicted, and empty changes. Returns an empty string when the change has
+// nothing to show.
+func renderBadges(change jj.Change, prs map[string]forge.PullRequest, styles *Styles) string {
+	var badges []string
+
+	for _, bookmark := range change.Bookmarks {
+		badges = append(badges, styles.BadgeBookmark.Render("⚑"+bookmark))
+
+		if pr, ok := prs[bookmark]; ok {
+			badges = append(badges, renderPullRequest(pr, styles))
+		}
+	}
+
+	for _, tag := range change.Tags {
+		badges = append(badges, styles.BadgeTag.Render("#"+tag))
+	}
+
+	if change.IsConflicted {
+		badges = append(badges, styles.BadgeConflict.Render("[conflict]"))
+	}
+
+	if change.IsImmutable {
+		badges = append(badges, styles.BadgeImmutable.Render("[immutable]"))
+	}
+
+	if change.IsEmpty {
+		badges = append(badges, styles.BadgeEmpty.Render("[empty]"))
+	}
+
+	if len(badges) == 0 {
+		return ""
+	}
+
+	return " " + strings.Join(badges, " ")
+}
+
+// renderPullRequest returns a pull request's badge, e.g. "#12 open ✓": its
+// number, its state, and how its checks went, linked to its page.
+func renderPullRequest(pr forge.PullRequest, styles *Styles) string {
+	stateStyle := styles.StatAdded
+
+	switch pr.State {
+	case forge.StateDraft:
+		stateStyle = styles.Dim
+	case forge.StateMerged:
+		stateStyle = styles.BadgeTag
+	case forge.StateClosed:
+		stateStyle = styles.StatRemoved
+	case forge.StateOpen:
+	}
+
+	badge := stateStyle.Render("#" + strconv.Itoa(pr.Number) + " " + string(pr.State))
+
+	switch pr.Checks {
+	case forge.ChecksPassing:
+		badge += " " + styles.StatAdded.Render("✓")
+	case forge.ChecksFailing:
+		badge += " " + styles.StatRemoved.Render("✗")
+	case forge.ChecksPending:
+		badge += " " + styles.Dim.Render("…")
+	case forge.ChecksNone:
+	}
+
+	if pr.URL == "" {
+		return badge
+	}
+
+	return Hyperlink(badge, pr.URL)
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chatter/chado/internal/forge"
+	"github.com/chatter/chado/internal/jj"
+)
+
+func TestRenderBadges(t *testing.T) {
+	styles := NewStyles()
+
+	tests := []struct {
+		name   string
+		change jj.Change
+		want   string
+	}{
+		{
+			name:   "no badges",
+			change: jj.Change{ChangeID: "xsssnyux"},
+			want:   "",
+		},
+		{
+			name:   "bookmarks and tags",
+			change: jj.Change{Bookmarks: []string{"main"}, Tags: []string{"v1.0"}},
+			want:   " ⚑main #v1.0",
+		},
+		{
+			name:   "flags",
+			change: jj.Change{IsConflicted: true, IsImmutable: true, IsEmpty: true},
+			want:   " [conflict] [immutable] [empty]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripANSI(renderBadges(tt.change, nil, styles)); got != tt.want {
+				t.Errorf("renderBadges() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderPullRequest(t *testing.T) {
+	styles := NewStyles()
+
+	tests := []struct {
+		name string
+		pr   forge.PullRequest
+		want string
+	}{
+		{"open and passing", forge.PullRequest{Number: 12, State: forge.StateOpen, Checks: forge.ChecksPassing}, "#12 open ✓"},
+		{"draft and running", forge.PullRequest{Number: 3, State: forge.StateDraft, Checks: forge.ChecksPending}, "#3 draft …"},
+		{"merged", forge.PullRequest{Number: 7, State: forge.StateMerged}, "#7 merged"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripANSI(renderPullRequest(tt.pr, styles)); got != tt.want {
+				t.Errorf("renderPullRequest() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	linked := renderPullRequest(forge.PullRequest{Number: 1, State: forge.StateOpen, URL: "https://github.com/o/r/pull/1"}, styles)
+	if !strings.Contains(linked, "https://github.com/o/r/pull/1") {
+		t.Error("the badge should link to the pull request's page")
+	}
+}
+
+func TestLogPanel_RendersPullRequestBadges(t *testing.T) {
+	panel := NewLogPanel(NewStyles())
+	panel.SetSize(80, 10)
+	panel.SetContent("@ xsssnyux fix parser\n", []jj.Change{
+		{ChangeID: "xsssnyux", Raw: "@ xsssnyux fix parser", Bookmarks: []string{"push-parser"}},
+	})
+	panel.SetPullRequests(map[string]forge.PullRequest{
+		"push-parser": {Number: 12, Branch: "push-parser", State: forge.StateOpen},
+	})
+
+	if !strings.Contains(StripANSI(panel.viewport.View()), "⚑push-parser #12 open") {
+		t.Errorf("the log should show the pull request after its bookmark, got:\n%s", panel.viewport.View())
+	}
+}
+
+func TestLogPanel_RendersBadges(t *testing.T) {
+	panel := NewLogPanel(NewStyles())
+	panel.SetSize(80, 10)
+	panel.SetContent("◆ zzzzzzzz root() 00000000\n", []jj.Change{
+		{ChangeID: "zzzzzzzz", Raw: "◆ zzzzzzzz root() 00000000", IsImmutable: true},
+	})
+
+	if !strings.Contains(StripANSI(panel.viewport.View()), "[immutable]") {
+		t.Error("log should show the immutable badge on the change line")
+	}
+}
@@ -0,0 +1,395 @@
+package ui
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/chatter/chado/internal/forge"
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui/help"
+)
+
+// pushPendingStatus is shown inline while a bookmark's push is in flight.
+const pushPendingStatus = "pushing…"
+
+// BookmarksPanel lists local bookmarks and lets the user pick several to push.
+type BookmarksPanel struct {
+	Navigator // count prefixes and paging keys
+
+	viewport        viewport.Model
+	styles          *Styles
+	bookmarks       []jj.Bookmark
+	cursor          int
+	focused         bool
+	width           int
+	height          int
+	selected        map[string]bool              // bookmark name -> marked for push
+	results         map[string]jj.PushResult     // outcome of the last push, by name
+	pending         map[string]bool              // bookmarks with a push in flight
+	pullRequests    map[string]forge.PullRequest // pull request opened from each bookmark, if any
+	borderAnimPhase float64                      // 0..1 for focus border animation
+	borderAnimating bool                         // true only while the one-shot wrap is running
+}
+
+// NewBookmarksPanel creates a new bookmarks panel.
+func NewBookmarksPanel(styles *Styles) BookmarksPanel {
+	vp := viewport.New()
+	vp.SoftWrap = false
+
+	return BookmarksPanel{
+		viewport: vp,
+		styles:   styles,
+		selected: make(map[string]bool),
+		results:  make(map[string]jj.PushResult),
+		pending:  make(map[string]bool),
+	}
+}
+
+// SetSize sets the panel dimensions.
+func (p *BookmarksPanel) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+	p.viewport.SetWidth(width - PanelBorderWidth)
+	p.viewport.SetHeight(height - PanelChromeHeight)
+	p.updateViewport()
+}
+
+// SetFocused sets the focus state.
+func (p *BookmarksPanel) SetFocused(focused bool) {
+	p.focused = focused
+}
+
+// SetBorderAnimPhase sets the border animation phase (0..1) for the focus wrap effect.
+func (p *BookmarksPanel) SetBorderAnimPhase(phase float64) {
+	p.borderAnimPhase = phase
+}
+
+// SetBorderAnimating sets whether the focus border animation is running.
+func (p *BookmarksPanel) SetBorderAnimating(animating bool) {
+	p.borderAnimating = animating
+}
+
+// SetBookmarks replaces the bookmark list. The cursor stays on the same
+// bookmark when it still exists; selections of removed bookmarks are dropped.
+func (p *BookmarksPanel) SetBookmarks(bookmarks []jj.Bookmark) {
+	var current string
+	if bookmark := p.SelectedBookmark(); bookmark != nil {
+		current = bookmark.Name
+	}
+
+	p.bookmarks = bookmarks
+	p.cursor = 0
+
+	present := make(map[string]bool, len(bookmarks))
+
+	for idx, bookmark := range bookmarks {
+		present[bookmark.Name] = true
+
+		if bookmark.Name == current {
+			p.cursor = idx
+		}
+	}
+
+	for name := range p.selected {
+		if !present[name] {
+			delete(p.selected, name)
+		}
+	}
+
+	p.updateViewport()
+}
+
+// SetPullRequests shows the pull request opened from each bookmark, by
+// bookmark name, beside it.
+func (p *BookmarksPanel) SetPullRequests(prs map[string]forge.PullRequest) {
+	p.pullRequests = prs
+	p.updateViewport()
+}
+
+// SelectedBookmark returns the bookmark under the cursor.
+func (p *BookmarksPanel) SelectedBookmark() *jj.Bookmark {
+	if p.cursor >= 0 && p.cursor < len(p.bookmarks) {
+		return &p.bookmarks[p.cursor]
+	}
+
+	return nil
+}
+
+// ToggleSelected marks or unmarks the bookmark under the cursor for pushing.
+func (p *BookmarksPanel) ToggleSelected() {
+	bookmark := p.SelectedBookmark()
+	if bookmark == nil {
+		return
+	}
+
+	if p.selected[bookmark.Name] {
+		delete(p.selected, bookmark.Name)
+	} else {
+		p.selected[bookmark.Name] = true
+	}
+
+	p.updateViewport()
+}
+
+// PushTargets returns the marked bookmarks in list order, or the bookmark
+// under the cursor when nothing is marked.
+func (p *BookmarksPanel) PushTargets() []string {
+	var names []string
+
+	for _, bookmark := range p.bookmarks {
+		if p.selected[bookmark.Name] {
+			names = append(names, bookmark.Name)
+		}
+	}
+
+	if len(names) == 0 {
+		if bookmark := p.SelectedBookmark(); bookmark != nil {
+			names = []string{bookmark.Name}
+		}
+	}
+
+	return names
+}
+
+// SetPushing marks bookmarks as being pushed, replacing their previous results.
+func (p *BookmarksPanel) SetPushing(names []string) {
+	for _, name := range names {
+		p.pending[name] = true
+		delete(p.results, name)
+	}
+
+	p.updateViewport()
+}
+
+// SetPushResults shows each bookmark's push outcome inline. Successfully
+// pushed bookmarks are unmarked so a retry only resends the failures.
+func (p *BookmarksPanel) SetPushResults(results map[string]jj.PushResult) {
+	for name, result := range results {
+		delete(p.pending, name)
+		p.results[name] = result
+
+		if result.OK {
+			delete(p.selected, name)
+		}
+	}
+
+	p.updateViewport()
+}
+
+// CursorUp moves the cursor up.
+func (p *BookmarksPanel) CursorUp() {
+	if p.cursor > 0 {
+		p.cursor--
+		p.updateViewport()
+	}
+}
+
+// CursorDown moves the cursor down.
+func (p *BookmarksPanel) CursorDown() {
+	if p.cursor < len(p.bookmarks)-1 {
+		p.cursor++
+		p.updateViewport()
+	}
+}
+
+// MoveBy moves the cursor by rows, up when negative, stopping at the ends.
+func (p *BookmarksPanel) MoveBy(rows int) {
+	if len(p.bookmarks) > 0 {
+		p.cursor = clampCursor(p.cursor, rows, len(p.bookmarks))
+		p.updateViewport()
+	}
+}
+
+// PageRows returns how many items one screen of the panel shows.
+func (p *BookmarksPanel) PageRows() int {
+	return p.viewport.Height()
+}
+
+// GotoTop moves to the first item.
+func (p *BookmarksPanel) GotoTop() {
+	p.cursor = 0
+	p.updateViewport()
+}
+
+// GotoBottom moves to the last item.
+func (p *BookmarksPanel) GotoBottom() {
+	if len(p.bookmarks) > 0 {
+		p.cursor = len(p.bookmarks) - 1
+		p.updateViewport()
+	}
+}
+
+// HandleClick selects the bookmark at the given Y coordinate (relative to content area).
+func (p *BookmarksPanel) HandleClick(y int) bool {
+	visualLine := y + p.viewport.YOffset()
+
+	if visualLine >= 0 && visualLine < len(p.bookmarks) && visualLine != p.cursor {
+		p.cursor = visualLine
+		p.updateViewport()
+
+		return true
+	}
+
+	return false
+}
+
+// SelectedAt reports whether the given Y coordinate (relative to content
+// area) is the selected bookmark's row, so a second click can act on it.
+func (p *BookmarksPanel) SelectedAt(y int) bool {
+	visualLine := y + p.viewport.YOffset()
+
+	return visualLine == p.cursor && visualLine < len(p.bookmarks)
+}
+
+// Update handles input.
+func (p *BookmarksPanel) Update(msg tea.Msg) tea.Cmd {
+	if !p.focused {
+		return nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		if _, handled := p.Navigate(p, msg); handled {
+			return nil
+		}
+
+		switch msg.String() {
+		case "space":
+			p.ToggleSelected()
+			p.CursorDown()
+		}
+	}
+
+	return nil
+}
+
+// View renders the panel.
+func (p *BookmarksPanel) View() string {
+	title := p.styles.PanelTitle(1, "bookmarks", p.focused)
+
+	var style lipgloss.Style
+
+	switch {
+	case p.focused && p.borderAnimating:
+		style = p.styles.AnimatedFocusBorderStyle(p.borderAnimPhase, p.width, p.height)
+	case p.focused:
+		style = p.styles.FocusedPanel
+	default:
+		style = p.styles.Panel
+	}
+
+	style = style.Height(p.height - PanelBorderHeight)
+
+	content := title + "\n" + p.viewport.View()
+
+	return style.Render(content)
+}
+
+// HelpBindings returns the keybindings for this panel (display-only, for status bar).
+func (p *BookmarksPanel) HelpBindings() []help.Binding {
+	return []help.Binding{
+		{
+			Key:      key.NewBinding(key.WithKeys("j", "k"), key.WithHelp("j/k", "up/down")),
+			Category: help.CategoryNavigation,
+			Order:    PanelOrderPrimary,
+		},
+		{
+			Key:      key.NewBinding(key.WithKeys("space"), key.WithHelp("space", "select")),
+			Category: help.CategoryActions,
+			Order:    PanelOrderPrimary,
+		},
+		{
+			Key:      key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "go to change")),
+			Category: help.CategoryNavigation,
+			Order:    PanelOrderPrimary,
+		},
+		{
+			Key:      key.NewBinding(key.WithKeys("g", "G"), key.WithHelp("gg/G", "top/bottom")),
+			Category: help.CategoryNavigation,
+			Order:    PanelOrderSecondary,
+		},
+	}
+}
+
+// Restyle re-renders the content in the current styles, after a theme change.
+func (p *BookmarksPanel) Restyle() {
+	p.updateViewport()
+}
+
+func (p *BookmarksPanel) updateViewport() {
+	if len(p.bookmarks) == 0 {
+		p.viewport.SetContent("No bookmarks")
+		return
+	}
+
+	var content strings.Builder
+
+	for idx, bookmark := range p.bookmarks {
+		cursor := "  "
+		if idx == p.cursor {
+			cursor = p.styles.Cursor()
+		}
+
+		mark := "[ ] "
+		if p.selected[bookmark.Name] {
+			mark = "[x] "
+		}
+
+		line := cursor + mark + p.styles.BadgeBookmark.Render(bookmark.Name)
+
+		if !bookmark.Synced {
+			line += p.styles.Dim.Render("*")
+		}
+
+		switch {
+		case bookmark.Conflict:
+			line += " " + p.styles.BadgeConflict.Render("[conflict]")
+		case bookmark.ChangeID != "":
+			line += " " + p.styles.ShortCode.Render(bookmark.ChangeID)
+		}
+
+		if pr, ok := p.pullRequests[bookmark.Name]; ok {
+			line += " " + renderPullRequest(pr, p.styles)
+		}
+
+		if bookmark.Description != "" {
+			line += " " + bookmark.Description
+		}
+
+		if status := p.renderStatus(bookmark.Name); status != "" {
+			line += "  " + status
+		}
+
+		content.WriteString(line + "\n")
+	}
+
+	p.viewport.SetContent(content.String())
+
+	// Ensure cursor is visible
+	if p.cursor < p.viewport.YOffset() {
+		p.viewport.SetYOffset(p.cursor)
+	} else if p.cursor >= p.viewport.YOffset()+p.viewport.Height() {
+		p.viewport.SetYOffset(p.cursor - p.viewport.Height() + 1)
+	}
+}
+
+// renderStatus renders the inline push status for a bookmark, if any.
+func (p *BookmarksPanel) renderStatus(name string) string {
+	if p.pending[name] {
+		return p.styles.Dim.Render(pushPendingStatus)
+	}
+
+	result, ok := p.results[name]
+	if !ok {
+		return ""
+	}
+
+	if result.OK {
+		return p.styles.StatAdded.Render("✓ " + result.Status)
+	}
+
+	return p.styles.StatRemoved.Render("✗ " + result.Status)
+}
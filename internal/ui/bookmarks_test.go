@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"pgregory.net/rapid"
+
+	"github.com/chatter/chado/internal/forge"
+	"github.com/chatter/chado/internal/jj"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+func newTestBookmarksPanel(names ...string) BookmarksPanel {
+	panel := NewBookmarksPanel(NewStyles())
+	panel.SetSize(80, 20)
+	panel.SetFocused(true)
+
+	bookmarks := make([]jj.Bookmark, len(names))
+	for i, name := range names {
+		bookmarks[i] = jj.Bookmark{Name: name, ChangeID: "xsssnyux", Synced: true}
+	}
+
+	panel.SetBookmarks(bookmarks)
+
+	return panel
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestBookmarksPanel_PushTargetsDefaultsToCursor(t *testing.T) {
+	panel := newTestBookmarksPanel("main", "feature")
+	panel.CursorDown()
+
+	targets := panel.PushTargets()
+	if len(targets) != 1 || targets[0] != "feature" {
+		t.Errorf("with nothing marked, expected cursor bookmark, got %v", targets)
+	}
+}
+
+func TestBookmarksPanel_SelectedAt(t *testing.T) {
+	panel := newTestBookmarksPanel("main", "feature")
+
+	if !panel.SelectedAt(0) || panel.SelectedAt(1) || panel.SelectedAt(5) {
+		t.Error("only the selected bookmark's row should count")
+	}
+}
+
+func TestBookmarksPanel_SpaceMarksAndAdvances(t *testing.T) {
+	panel := newTestBookmarksPanel("main", "feature", "docs")
+	space := tea.KeyPressMsg(tea.Key{Code: tea.KeySpace, Text: " "})
+
+	panel.Update(space) // marks main, moves to feature
+	panel.CursorDown()  // skip feature
+	panel.Update(space) // marks docs
+
+	targets := panel.PushTargets()
+	if len(targets) != 2 || targets[0] != "main" || targets[1] != "docs" {
+		t.Errorf("expected marked bookmarks in list order, got %v", targets)
+	}
+}
+
+func TestBookmarksPanel_ResultsShownInline(t *testing.T) {
+	panel := newTestBookmarksPanel("main", "feature")
+	panel.ToggleSelected()
+	panel.CursorDown()
+	panel.ToggleSelected()
+
+	panel.SetPushing([]string{"main", "feature"})
+	if !strings.Contains(panel.viewport.View(), pushPendingStatus) {
+		t.Error("pending bookmarks should show a pushing status")
+	}
+
+	panel.SetPushResults(map[string]jj.PushResult{
+		"main":    {OK: true, Status: "move forward"},
+		"feature": {Status: "rejected"},
+	})
+
+	view := panel.viewport.View()
+	if !strings.Contains(view, "✓ move forward") || !strings.Contains(view, "✗ rejected") {
+		t.Errorf("expected per-bookmark results inline, got:\n%s", view)
+	}
+
+	targets := panel.PushTargets()
+	if len(targets) != 1 || targets[0] != "feature" {
+		t.Errorf("only failed bookmarks should stay marked, got %v", targets)
+	}
+}
+
+func TestBookmarksPanel_ShowsPullRequests(t *testing.T) {
+	panel := newTestBookmarksPanel("main", "feature")
+	panel.SetPullRequests(map[string]forge.PullRequest{
+		"feature": {Number: 12, Branch: "feature", State: forge.StateOpen, Checks: forge.ChecksFailing},
+	})
+
+	lines := strings.Split(StripANSI(panel.viewport.View()), "\n")
+	if !strings.Contains(lines[1], "feature xsssnyux #12 open ✗") {
+		t.Errorf("the pull request should show beside its bookmark, got %q", lines[1])
+	}
+
+	if strings.Contains(lines[0], "#") {
+		t.Errorf("a bookmark without a pull request should show none, got %q", lines[0])
+	}
+}
+
+func TestBookmarksPanel_SetBookmarksKeepsCursorAndDropsStaleMarks(t *testing.T) {
+	panel := newTestBookmarksPanel("main", "feature", "docs")
+	panel.ToggleSelected() // main
+	panel.GotoBottom()     // docs
+
+	panel.SetBookmarks([]jj.Bookmark{{Name: "docs"}, {Name: "feature"}})
+
+	if selected := panel.SelectedBookmark(); selected == nil || selected.Name != "docs" {
+		t.Errorf("cursor should follow docs, got %+v", selected)
+	}
+
+	targets := panel.PushTargets()
+	if len(targets) != 1 || targets[0] != "docs" {
+		t.Errorf("removed bookmark should no longer be marked, got %v", targets)
+	}
+}
+
+func TestBookmarksPanel_Empty(t *testing.T) {
+	panel := newTestBookmarksPanel()
+
+	if panel.SelectedBookmark() != nil || panel.PushTargets() != nil {
+		t.Error("empty panel should have no selection or targets")
+	}
+
+	if !strings.Contains(panel.viewport.View(), "No bookmarks") {
+		t.Error("empty panel should say so")
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: push targets are always a subset of the listed bookmarks.
+func TestBookmarksPanel_PushTargetsSubset(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		names := rapid.SliceOfNDistinct(rapid.StringMatching(`[a-z]{1,8}`), 1, 10, rapid.ID[string]).Draw(t, "names")
+		panel := newTestBookmarksPanel(names...)
+
+		steps := rapid.IntRange(0, 30).Draw(t, "steps")
+		for range steps {
+			switch rapid.IntRange(0, 2).Draw(t, "op") {
+			case 0:
+				panel.CursorDown()
+			case 1:
+				panel.CursorUp()
+			case 2:
+				panel.ToggleSelected()
+			}
+		}
+
+		listed := make(map[string]bool)
+		for _, name := range names {
+			listed[name] = true
+		}
+
+		targets := panel.PushTargets()
+		if len(targets) == 0 {
+			t.Fatal("a non-empty panel always has a push target")
+		}
+
+		for _, name := range targets {
+			if !listed[name] {
+				t.Fatalf("target %q is not a listed bookmark", name)
+			}
+		}
+	})
+}
@@ -0,0 +1,213 @@
+package ui
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/chatter/chado/internal/ui/theme"
+)
+
+const (
+	// commandHorizontalPadding is the horizontal padding inside the border.
+	commandHorizontalPadding = 2
+
+	// commandChrome is the horizontal space taken by the border (2) and padding (4).
+	commandChrome = 6
+
+	// commandMinWidth keeps the prompt usable on small terminals.
+	commandMinWidth = 30
+
+	// commandPrompt is shown before the typed arguments.
+	commandPrompt = "jj "
+
+	// commandPlaceholder suggests what to type for a jj command.
+	commandPlaceholder = "log -r 'mine()'"
+
+	// commandTitle heads the prompt when it runs jj commands.
+	commandTitle = "Run jj command"
+)
+
+// CommandSubmitMsg is sent when the user runs a command line.
+type CommandSubmitMsg struct {
+	Line string // Arguments as typed, without the leading "jj"
+}
+
+// CommandCancelMsg is sent when the user closes the prompt without running.
+type CommandCancelMsg struct{}
+
+// PromptSubmitMsg is sent when the user answers a question put by Ask.
+type PromptSubmitMsg struct {
+	Value string
+}
+
+// CommandLine is a one-line prompt for jj arguments, with history of the
+// lines run this session. Ask puts it to other questions.
+type CommandLine struct {
+	input   textinput.Model
+	width   int
+	history []string
+	recall  int // index into history while browsing; len(history) when not
+
+	// A question put by Ask, answered with PromptSubmitMsg; empty for a command
+	question string
+
+	// Key bindings
+	submit key.Binding
+	cancel key.Binding
+	prev   key.Binding
+	next   key.Binding
+
+	// Styles
+	borderStyle lipgloss.Style
+	titleStyle  lipgloss.Style
+	hintStyle   lipgloss.Style
+}
+
+// NewCommandLine creates a new command prompt.
+func NewCommandLine() *CommandLine {
+	input := textinput.New()
+	input.Prompt = commandPrompt
+	input.Placeholder = commandPlaceholder
+
+	c := &CommandLine{
+		input:  input,
+		submit: key.NewBinding(key.WithKeys("enter")),
+		cancel: key.NewBinding(key.WithKeys("esc")),
+		prev:   key.NewBinding(key.WithKeys("up", "ctrl+p")),
+		next:   key.NewBinding(key.WithKeys("down", "ctrl+n")),
+		borderStyle: lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			Padding(1, commandHorizontalPadding),
+		titleStyle: lipgloss.NewStyle().
+			Bold(true),
+		hintStyle: lipgloss.NewStyle(),
+	}
+	c.SetTheme(theme.Default())
+
+	return c
+}
+
+// SetTheme colors the prompt from t.
+func (c *CommandLine) SetTheme(t theme.Theme) {
+	c.borderStyle = c.borderStyle.BorderForeground(lipgloss.Color(t.OverlayBorder))
+	c.titleStyle = c.titleStyle.Foreground(lipgloss.Color(t.OverlayTitle))
+	c.hintStyle = c.hintStyle.Foreground(lipgloss.Color(t.Secondary))
+}
+
+// Open clears the prompt and focuses it.
+func (c *CommandLine) Open() tea.Cmd {
+	return c.open("", commandPrompt, commandPlaceholder)
+}
+
+// Ask opens the prompt for the answer to question instead of a jj command,
+// with prompt before the input and placeholder suggesting an answer.
+func (c *CommandLine) Ask(question, prompt, placeholder string) tea.Cmd {
+	return c.open(question, prompt, placeholder)
+}
+
+// open clears the prompt for question, or a command when it is empty, and
+// focuses it.
+func (c *CommandLine) open(question, prompt, placeholder string) tea.Cmd {
+	c.question = question
+	c.input.Prompt = prompt
+	c.input.Placeholder = placeholder
+	c.input.SetValue("")
+	c.recall = len(c.history)
+	c.SetWidth(c.width)
+
+	return c.input.Focus()
+}
+
+// SetWidth sets the overlay's outer width.
+func (c *CommandLine) SetWidth(width int) {
+	c.width = max(width, commandMinWidth)
+	c.input.SetWidth(c.width - commandChrome - PanelBorderWidth - len(c.input.Prompt))
+}
+
+// Value returns the typed arguments.
+func (c *CommandLine) Value() string {
+	return c.input.Value()
+}
+
+// Update handles input.
+func (c *CommandLine) Update(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, c.submit):
+			line := strings.TrimSpace(c.input.Value())
+			if line == "" {
+				return func() tea.Msg { return CommandCancelMsg{} }
+			}
+
+			if c.question != "" {
+				return func() tea.Msg { return PromptSubmitMsg{Value: line} }
+			}
+
+			c.remember(line)
+
+			return func() tea.Msg { return CommandSubmitMsg{Line: line} }
+		case key.Matches(msg, c.cancel):
+			return func() tea.Msg { return CommandCancelMsg{} }
+		case c.question != "":
+		case key.Matches(msg, c.prev):
+			c.recallAt(c.recall - 1)
+			return nil
+		case key.Matches(msg, c.next):
+			c.recallAt(c.recall + 1)
+			return nil
+		}
+	}
+
+	var cmd tea.Cmd
+	c.input, cmd = c.input.Update(msg)
+
+	return cmd
+}
+
+// View renders the prompt.
+func (c *CommandLine) View() string {
+	title, hint := commandTitle, "⏎ run • ↑/↓ history • ⎋ cancel"
+	if c.question != "" {
+		title, hint = c.question, "⏎ ok • ⎋ cancel"
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		c.titleStyle.Render(title),
+		"",
+		c.input.View(),
+		"",
+		c.hintStyle.Render(hint),
+	)
+
+	return c.borderStyle.Width(c.width).Render(content)
+}
+
+// remember adds a run line to the history, dropping an identical previous entry.
+func (c *CommandLine) remember(line string) {
+	if n := len(c.history); n > 0 && c.history[n-1] == line {
+		return
+	}
+
+	c.history = append(c.history, line)
+}
+
+// recallAt shows the history entry at index; one past the end clears the prompt.
+func (c *CommandLine) recallAt(index int) {
+	if index < 0 || index > len(c.history) {
+		return
+	}
+
+	c.recall = index
+
+	if index == len(c.history) {
+		c.input.SetValue("")
+		return
+	}
+
+	c.input.SetValue(c.history[index])
+	c.input.CursorEnd()
+}
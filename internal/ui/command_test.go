@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+func typeCommand(c *CommandLine, text string) {
+	for _, r := range text {
+		c.Update(tea.KeyPressMsg(tea.Key{Code: r, Text: string(r)}))
+	}
+}
+
+func runCommand(t *testing.T, c *CommandLine, text string) tea.Msg {
+	t.Helper()
+
+	typeCommand(c, text)
+
+	cmd := c.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	if cmd == nil {
+		t.Fatal("enter should produce a message")
+	}
+
+	return cmd()
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestCommandLine_SubmitSendsLine(t *testing.T) {
+	c := NewCommandLine()
+	c.SetWidth(60)
+	c.Open()
+
+	msg, ok := runCommand(t, c, "  log -r @ ").(CommandSubmitMsg)
+	if !ok || msg.Line != "log -r @" {
+		t.Errorf("expected trimmed line submitted, got %#v", msg)
+	}
+}
+
+func TestCommandLine_EmptySubmitCancels(t *testing.T) {
+	c := NewCommandLine()
+	c.Open()
+
+	if _, ok := runCommand(t, c, "   ").(CommandCancelMsg); !ok {
+		t.Error("submitting a blank line should cancel")
+	}
+}
+
+func TestCommandLine_History(t *testing.T) {
+	c := NewCommandLine()
+	c.Open()
+	runCommand(t, c, "log")
+	c.Open()
+	runCommand(t, c, "status")
+	c.Open()
+
+	c.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyUp}))
+
+	if c.Value() != "status" {
+		t.Errorf("up should recall the last command, got %q", c.Value())
+	}
+
+	c.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyUp}))
+	c.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyUp}))
+
+	if c.Value() != "log" {
+		t.Errorf("up should stop at the oldest command, got %q", c.Value())
+	}
+
+	c.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyDown}))
+	c.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyDown}))
+
+	if c.Value() != "" {
+		t.Errorf("down past the newest command should clear the prompt, got %q", c.Value())
+	}
+}
+
+func TestCommandLine_Ask(t *testing.T) {
+	c := NewCommandLine()
+	c.SetWidth(60)
+	c.Ask("Add workspace", "path: ", "../feature")
+
+	if view := StripANSI(c.View()); !strings.Contains(view, "Add workspace") || !strings.Contains(view, "path: ") {
+		t.Errorf("the question should replace the command title, got:\n%s", view)
+	}
+
+	msg, ok := runCommand(t, c, "../feature").(PromptSubmitMsg)
+	if !ok || msg.Value != "../feature" {
+		t.Fatalf("expected the answer, got %+v", msg)
+	}
+
+	c.Open()
+
+	if _, ok := runCommand(t, c, "status").(CommandSubmitMsg); !ok {
+		t.Error("opening again should take a command")
+	}
+
+	if len(c.history) != 1 {
+		t.Errorf("answers should stay out of the command history, got %v", c.history)
+	}
+}
@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/chatter/chado/internal/ui/theme"
+)
+
+const (
+	// confirmHorizontalPadding is the horizontal padding inside the dialog border.
+	confirmHorizontalPadding = 2
+
+	// confirmMaxBodyWidth wraps the dialog body so long messages stay readable.
+	confirmMaxBodyWidth = 56
+)
+
+// ConfirmChoice is the user's answer to a confirmation dialog.
+type ConfirmChoice int
+
+const (
+	ConfirmYes    ConfirmChoice = iota // Accept the proposed action
+	ConfirmNo                          // Decline it but carry on (only when offered)
+	ConfirmCancel                      // Abort the whole flow
+)
+
+// ConfirmMsg is sent when the user answers the confirmation dialog.
+type ConfirmMsg struct {
+	Choice ConfirmChoice
+}
+
+// ConfirmDialog is a modal yes/no(/cancel) prompt.
+type ConfirmDialog struct {
+	title   string
+	body    string
+	yesHint string
+	noHint  string // empty = no "no" option; n behaves like cancel
+
+	// Key bindings
+	yes    key.Binding
+	no     key.Binding
+	cancel key.Binding
+
+	// Styles
+	borderStyle lipgloss.Style
+	titleStyle  lipgloss.Style
+	bodyStyle   lipgloss.Style
+	hintStyle   lipgloss.Style
+}
+
+// NewConfirmDialog creates a new confirmation dialog.
+func NewConfirmDialog() *ConfirmDialog {
+	c := &ConfirmDialog{
+		yes:    key.NewBinding(key.WithKeys("y", "enter")),
+		no:     key.NewBinding(key.WithKeys("n")),
+		cancel: key.NewBinding(key.WithKeys("esc", "q")),
+		borderStyle: lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			Padding(1, confirmHorizontalPadding),
+		titleStyle: lipgloss.NewStyle().
+			Bold(true),
+		bodyStyle: lipgloss.NewStyle().
+			Width(confirmMaxBodyWidth),
+		hintStyle: lipgloss.NewStyle(),
+	}
+	c.SetTheme(theme.Default())
+
+	return c
+}
+
+// SetTheme colors the dialog from t.
+func (c *ConfirmDialog) SetTheme(t theme.Theme) {
+	c.borderStyle = c.borderStyle.BorderForeground(lipgloss.Color(t.OverlayBorder))
+	c.titleStyle = c.titleStyle.Foreground(lipgloss.Color(t.OverlayTitle))
+	c.hintStyle = c.hintStyle.Foreground(lipgloss.Color(t.Secondary))
+}
+
+// SetPrompt configures the dialog text. yesHint and noHint label the y and n
+// choices; an empty noHint hides the n choice so only yes/cancel are offered.
+func (c *ConfirmDialog) SetPrompt(title, body, yesHint, noHint string) {
+	c.title = title
+	c.body = body
+	c.yesHint = yesHint
+	c.noHint = noHint
+}
+
+// Update handles input messages.
+func (c *ConfirmDialog) Update(msg tea.Msg) tea.Cmd {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+
+	var choice ConfirmChoice
+
+	switch {
+	case key.Matches(keyMsg, c.yes):
+		choice = ConfirmYes
+	case key.Matches(keyMsg, c.no) && c.noHint != "":
+		choice = ConfirmNo
+	case key.Matches(keyMsg, c.no), key.Matches(keyMsg, c.cancel):
+		choice = ConfirmCancel
+	default:
+		return nil
+	}
+
+	return func() tea.Msg {
+		return ConfirmMsg{Choice: choice}
+	}
+}
+
+// View renders the confirmation dialog.
+func (c *ConfirmDialog) View() string {
+	hint := "y " + c.yesHint
+	if c.noHint != "" {
+		hint += " • n " + c.noHint
+	}
+
+	hint += " • ⎋ cancel"
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		c.titleStyle.Render(c.title),
+		"",
+		c.bodyStyle.Render(c.body),
+		"",
+		c.hintStyle.Render(hint),
+	)
+
+	return c.borderStyle.Render(content)
+}
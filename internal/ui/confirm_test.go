@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func confirmChoice(t *testing.T, dialog *ConfirmDialog, msg tea.KeyPressMsg) (ConfirmChoice, bool) {
+	t.Helper()
+
+	cmd := dialog.Update(msg)
+	if cmd == nil {
+		return 0, false
+	}
+
+	result, ok := cmd().(ConfirmMsg)
+	if !ok {
+		t.Fatalf("expected ConfirmMsg, got %T", cmd())
+	}
+
+	return result.Choice, true
+}
+
+func TestConfirmDialog_Choices(t *testing.T) {
+	dialog := NewConfirmDialog()
+	dialog.SetPrompt("Title", "Body", "do it", "skip")
+
+	tests := []struct {
+		name string
+		key  tea.KeyPressMsg
+		want ConfirmChoice
+	}{
+		{"y confirms", tea.KeyPressMsg(tea.Key{Code: 'y'}), ConfirmYes},
+		{"enter confirms", tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}), ConfirmYes},
+		{"n declines", tea.KeyPressMsg(tea.Key{Code: 'n'}), ConfirmNo},
+		{"esc cancels", tea.KeyPressMsg(tea.Key{Code: tea.KeyEscape}), ConfirmCancel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := confirmChoice(t, dialog, tt.key)
+			if !ok || got != tt.want {
+				t.Errorf("choice = %v (ok=%v), want %v", got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfirmDialog_NoWithoutNoOptionCancels(t *testing.T) {
+	dialog := NewConfirmDialog()
+	dialog.SetPrompt("Title", "Body", "do it", "")
+
+	got, ok := confirmChoice(t, dialog, tea.KeyPressMsg(tea.Key{Code: 'n'}))
+	if !ok || got != ConfirmCancel {
+		t.Errorf("n without a no option should cancel, got %v", got)
+	}
+}
+
+func TestConfirmDialog_OtherKeysIgnored(t *testing.T) {
+	dialog := NewConfirmDialog()
+	dialog.SetPrompt("Title", "Body", "do it", "skip")
+
+	if _, ok := confirmChoice(t, dialog, tea.KeyPressMsg(tea.Key{Code: 'j'})); ok {
+		t.Error("unrelated keys should not answer the dialog")
+	}
+}
+
+func TestConfirmDialog_View(t *testing.T) {
+	dialog := NewConfirmDialog()
+	dialog.SetPrompt("Working copy is pushed", "Create a new change?", "new change", "continue")
+
+	view := StripANSI(dialog.View())
+
+	for _, want := range []string{"Working copy is pushed", "Create a new change?", "y new change", "n continue", "⎋ cancel"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q", want)
+		}
+	}
+}
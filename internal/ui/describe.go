@@ -1,49 +1,113 @@
 package ui
 
 import (
+	"fmt"
+
 	"charm.land/bubbles/v2/key"
-	"charm.land/bubbles/v2/textinput"
+	"charm.land/bubbles/v2/textarea"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+
+	"github.com/chatter/chado/internal/ui/theme"
 )
 
 const (
 	// describeHorizontalPadding is the horizontal padding value for the overlay border.
 	describeHorizontalPadding = 2
 
-	// describeInputChrome is the total horizontal space consumed by the overlay's
-	// border (2) and padding (4) on each side: (1+2)*2 = 12.
-	describeInputChrome = 12
+	// describeInputChrome is the horizontal space consumed by the overlay's
+	// border (1) and padding (2) on each side: (1+2)*2 = 6.
+	describeInputChrome = 6
+
+	// describeVerticalChrome is the vertical space consumed by the border (2),
+	// padding (2), and the title, hint, and blank separator lines (4).
+	describeVerticalChrome = 8
 
 	// minDescribeInputWidth is the floor width for the text input field.
 	minDescribeInputWidth = 20
+
+	// describeWidthPct is the overlay's share of the available width.
+	describeWidthPct = 60
+
+	// percentDivisor converts a percentage numerator to a fraction.
+	percentDivisor = 100
+
+	// minDescribeWidth and maxDescribeWidth clamp the overlay's outer width.
+	minDescribeWidth = 40
+	maxDescribeWidth = 100
+
+	// maxDescribeRows caps how tall the input grows before it scrolls.
+	maxDescribeRows = 8
+
+	// describeCharLimit caps the description, with room for its trailers.
+	describeCharLimit = 1024
 )
 
 // DescribeInput is a text input overlay for editing change descriptions.
+// It sizes itself from the space given to SetSize and grows a row at a time
+// as the description wraps.
 type DescribeInput struct {
-	input    textinput.Model
+	input    textarea.Model
 	changeID string
-	width    int
-	height   int
+	commit   bool   // submitting commits the change, starting a new one on it
+	batch    string // position in a batch of changes described in turn, e.g. "2/5"
+	lastOne  bool   // the change is the batch's last, whose submit saves them all
+	width    int    // available width, usually the window's
+	height   int    // available height, usually the window's
+	maxRows  int    // input rows that fit in the available height
+
+	// Conventional-commit helpers: the types tab cycles through, the subject
+	// length the counter warns past (0 hides it), and the trailers ctrl+t adds
+	prefixes   []string
+	subjectMax int
+	trailers   []string
+
+	// Linting: whether submitting warns of problems first, and whether it
+	// has for the description as it is
+	lint   bool
+	warned bool
+
+	// History of descriptions written here, recalled with up and down: the
+	// entry shown (len(history) when none), what was typed before recalling,
+	// and what the description was opened with, which isn't remembered
+	history      []string
+	historyLimit int
+	recall       int
+	draft        string
+	initial      string
 
 	// Key bindings
-	submit key.Binding
-	cancel key.Binding
+	submit     key.Binding
+	cancel     key.Binding
+	prev       key.Binding
+	next       key.Binding
+	nextPrefix key.Binding
+	prevPrefix key.Binding
+	addTrailer key.Binding
 
 	// Styles
 	borderStyle lipgloss.Style
 	titleStyle  lipgloss.Style
 	hintStyle   lipgloss.Style
+	warnStyle   lipgloss.Style
 }
 
 // NewDescribeInput creates a new describe input overlay.
 func NewDescribeInput() *DescribeInput {
-	input := textinput.New()
+	input := textarea.New()
 	input.Placeholder = "Enter description..."
-	input.CharLimit = 256
+	input.CharLimit = describeCharLimit
+	input.Prompt = ""
+	input.ShowLineNumbers = false
+	input.KeyMap.InsertNewline.SetEnabled(false) // enter submits
+
+	styles := input.Styles()
+	styles.Focused.CursorLine = lipgloss.NewStyle()
+	input.SetStyles(styles)
+	input.SetHeight(1)
 	input.Focus()
 
-	return &DescribeInput{
+	d := &DescribeInput{
 		input: input,
 		submit: key.NewBinding(
 			key.WithKeys("enter"),
@@ -51,28 +115,54 @@ func NewDescribeInput() *DescribeInput {
 		cancel: key.NewBinding(
 			key.WithKeys("esc"),
 		),
+		prev: key.NewBinding(
+			key.WithKeys("up"),
+		),
+		next: key.NewBinding(
+			key.WithKeys("down"),
+		),
+		nextPrefix: key.NewBinding(
+			key.WithKeys("tab"),
+		),
+		prevPrefix: key.NewBinding(
+			key.WithKeys("shift+tab"),
+		),
+		addTrailer: key.NewBinding(
+			key.WithKeys("ctrl+t"),
+		),
 		borderStyle: lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("62")).
 			Padding(1, describeHorizontalPadding),
 		titleStyle: lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("86")),
-		hintStyle: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241")),
+			Bold(true),
+		hintStyle: lipgloss.NewStyle(),
+		warnStyle: lipgloss.NewStyle(),
 	}
+	d.SetTheme(theme.Default())
+
+	return d
+}
+
+// SetTheme colors the overlay from t.
+func (d *DescribeInput) SetTheme(t theme.Theme) {
+	d.borderStyle = d.borderStyle.BorderForeground(lipgloss.Color(t.OverlayBorder))
+	d.titleStyle = d.titleStyle.Foreground(lipgloss.Color(t.OverlayTitle))
+	d.hintStyle = d.hintStyle.Foreground(lipgloss.Color(t.Secondary))
+	d.warnStyle = d.warnStyle.Foreground(lipgloss.Color(t.Error))
 }
 
-// SetSize sets the available size for the overlay.
+// SetSize sets the space the overlay may occupy. The overlay takes a share
+// of the width within fixed bounds, never wider than the space itself.
 func (d *DescribeInput) SetSize(width, height int) {
 	d.width = width
 	d.height = height
 
-	// Set input width to fit within the modal
-	// Account for border (2) + padding (4) on each side
-	inputWidth := max(width-describeInputChrome, minDescribeInputWidth)
+	outer := min(max(width*describeWidthPct/percentDivisor, minDescribeWidth), maxDescribeWidth, width)
+	inputWidth := max(outer-describeInputChrome, minDescribeInputWidth)
 
 	d.input.SetWidth(inputWidth)
+	d.maxRows = min(max(height-describeVerticalChrome, 1), maxDescribeRows)
+	d.fitRows()
 }
 
 // SetChangeID sets the change ID being edited.
@@ -80,11 +170,53 @@ func (d *DescribeInput) SetChangeID(changeID string) {
 	d.changeID = changeID
 }
 
+// SetCommit sets whether submitting commits the change (jj commit) rather
+// than only describing it.
+func (d *DescribeInput) SetCommit(commit bool) {
+	d.commit = commit
+}
+
+// SetBatch shows the change as the position-th of total described in turn;
+// a total of one or less hides it.
+func (d *DescribeInput) SetBatch(position, total int) {
+	d.batch, d.lastOne = "", position >= total
+	if total > 1 {
+		d.batch = fmt.Sprintf("%d/%d", position, total)
+	}
+}
+
+// SetPrefixes sets the conventional-commit types (e.g. "feat") that tab and
+// shift+tab cycle the subject's prefix through; none turns cycling off.
+func (d *DescribeInput) SetPrefixes(prefixes []string) {
+	d.prefixes = prefixes
+}
+
+// SetSubjectLength sets the subject length the counter warns past; zero
+// hides the counter.
+func (d *DescribeInput) SetSubjectLength(length int) {
+	d.subjectMax = length
+}
+
+// SetTrailers sets the lines ctrl+t appends to the description, e.g.
+// "Signed-off-by: A <a@b.c>"; none turns it off.
+func (d *DescribeInput) SetTrailers(trailers []string) {
+	d.trailers = trailers
+}
+
 // SetValue sets the current description text.
 func (d *DescribeInput) SetValue(value string) {
+	d.initial = value
+	d.recall = len(d.history)
+	d.setText(value)
+}
+
+// setText replaces the text being edited.
+func (d *DescribeInput) setText(value string) {
 	d.input.SetValue(value)
 	// Move cursor to end
-	d.input.CursorEnd()
+	d.input.MoveToEnd()
+	d.fitRows()
+	d.warned = false
 }
 
 // Value returns the current input value.
@@ -106,6 +238,7 @@ func (d *DescribeInput) Focus() tea.Cmd {
 type DescribeSubmitMsg struct {
 	ChangeID    string
 	Description string
+	Commit      bool // commit the change with the description
 }
 
 // DescribeCancelMsg is sent when the user cancels editing.
@@ -115,33 +248,127 @@ type DescribeCancelMsg struct{}
 func (d *DescribeInput) Update(msg tea.Msg) tea.Cmd {
 	if msg, ok := msg.(tea.KeyMsg); ok {
 		if key.Matches(msg, d.submit) {
+			if d.holdSubmit() {
+				return nil
+			}
+
+			d.rememberDescription()
+
 			return func() tea.Msg {
 				return DescribeSubmitMsg{
 					ChangeID:    d.changeID,
 					Description: d.input.Value(),
+					Commit:      d.commit,
 				}
 			}
 		}
 
 		if key.Matches(msg, d.cancel) {
+			d.rememberDescription()
+
 			return func() tea.Msg {
 				return DescribeCancelMsg{}
 			}
 		}
+
+		if edited, ok := d.applyHelper(msg); ok {
+			d.setText(edited)
+			return nil
+		}
+
+		if key.Matches(msg, d.prev) && d.recallKey(true) {
+			d.recallAt(d.recall - 1)
+			return nil
+		}
+
+		if key.Matches(msg, d.next) && d.recallKey(false) {
+			d.recallAt(d.recall + 1)
+			return nil
+		}
 	}
 
 	// Forward to text input
 	var cmd tea.Cmd
 
+	before := d.input.Value()
 	d.input, cmd = d.input.Update(msg)
+	d.fitRows()
+
+	if d.input.Value() != before {
+		d.warned = false
+	}
 
 	return cmd
 }
 
+// applyHelper returns the description a helper key edits it to: the next
+// or previous prefix, or the trailers added. It reports false for other keys
+// and helpers that aren't set up.
+func (d *DescribeInput) applyHelper(msg tea.KeyMsg) (string, bool) {
+	switch {
+	case key.Matches(msg, d.nextPrefix) && len(d.prefixes) > 0:
+		return cyclePrefix(d.input.Value(), d.prefixes, 1), true
+	case key.Matches(msg, d.prevPrefix) && len(d.prefixes) > 0:
+		return cyclePrefix(d.input.Value(), d.prefixes, -1), true
+	case key.Matches(msg, d.addTrailer) && len(d.trailers) > 0:
+		return appendTrailers(d.input.Value(), d.trailers), true
+	}
+
+	return "", false
+}
+
+// Rows returns the number of input rows currently shown.
+func (d *DescribeInput) Rows() int {
+	return d.input.Height()
+}
+
+// fitRows grows or shrinks the input to the wrapped height of its text,
+// up to the rows that fit; beyond that the input scrolls.
+func (d *DescribeInput) fitRows() {
+	// Enter submits, so the text is one logical line unless it was pasted in;
+	// extra logical lines count once each.
+	rows := d.input.LineCount() - 1 + d.input.LineInfo().Height
+	d.input.SetHeight(min(max(rows, 1), max(d.maxRows, 1)))
+}
+
 // View renders the describe input overlay.
 func (d *DescribeInput) View() string {
-	title := d.titleStyle.Render("Describe: " + d.changeID)
-	hint := d.hintStyle.Render("⏎ save • ⎋ cancel")
+	title := "Describe: " + d.changeID
+	hint := "⏎ save • ⎋ cancel"
+
+	if d.commit {
+		title = "Commit: " + d.changeID
+		hint = "⏎ commit • ⎋ cancel"
+	}
+
+	if d.batch != "" {
+		title += " (" + d.batch + ")"
+		hint = "⏎ next • ⎋ cancel all"
+
+		if d.lastOne {
+			hint = "⏎ save all • ⎋ cancel all"
+		}
+	}
+
+	title = d.titleStyle.Render(title)
+
+	if len(d.prefixes) > 0 {
+		hint += " • ⇥ type"
+	}
+
+	if len(d.trailers) > 0 {
+		hint += " • ^t trailers"
+	}
+
+	if len(d.history) > 0 {
+		hint += " • ↑/↓ history"
+	}
+
+	// Wrapped to the input's width, so the helpers don't widen the overlay
+	hint = lipgloss.NewStyle().Width(d.input.Width()).Render(d.hintStyle.Render(hint) + d.subjectCounter())
+	if d.warned {
+		hint = d.lintWarning()
+	}
 
 	content := lipgloss.JoinVertical(lipgloss.Left,
 		title,
@@ -154,6 +381,23 @@ func (d *DescribeInput) View() string {
 	return d.borderStyle.Render(content)
 }
 
+// subjectCounter shows the subject's length against the one it should keep
+// to, warning once it is past it.
+func (d *DescribeInput) subjectCounter() string {
+	if d.subjectMax <= 0 {
+		return ""
+	}
+
+	length := subjectLength(d.input.Value())
+
+	style := d.hintStyle
+	if length > d.subjectMax {
+		style = d.warnStyle
+	}
+
+	return d.hintStyle.Render(" • ") + style.Render(fmt.Sprintf("%d/%d", length, d.subjectMax))
+}
+
 // Width returns the rendered width of the overlay.
 func (d *DescribeInput) Width() int {
 	return lipgloss.Width(d.View())
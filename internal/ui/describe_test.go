@@ -65,6 +65,62 @@ func TestDescribeInput_SetSize(t *testing.T) {
 	}
 }
 
+func TestDescribeInput_WidthAdaptsToWindow(t *testing.T) {
+	input := NewDescribeInput()
+	input.SetChangeID("xsssnyux")
+
+	input.SetSize(80, 24)
+	narrow := input.Width()
+
+	input.SetSize(160, 24)
+	wide := input.Width()
+
+	if wide <= narrow {
+		t.Errorf("overlay should widen with the window: %d at 80 cols, %d at 160", narrow, wide)
+	}
+
+	input.SetSize(400, 24)
+	if input.Width() > maxDescribeWidth {
+		t.Errorf("overlay should be at most %d wide, got %d", maxDescribeWidth, input.Width())
+	}
+}
+
+func TestDescribeInput_GrowsAsTextWraps(t *testing.T) {
+	input := NewDescribeInput()
+	input.SetSize(80, 40)
+	input.SetValue("short")
+
+	if input.Rows() != 1 {
+		t.Fatalf("short text should use one row, got %d", input.Rows())
+	}
+
+	short := input.Height()
+
+	input.SetValue(strings.Repeat("wrapping words ", 12))
+
+	if input.Rows() < 2 {
+		t.Errorf("long text should wrap onto more rows, got %d", input.Rows())
+	}
+
+	if input.Height() != short+input.Rows()-1 {
+		t.Errorf("overlay should grow by the added rows: %d -> %d", short, input.Height())
+	}
+}
+
+func TestDescribeInput_FitsSmallTerminal(t *testing.T) {
+	input := NewDescribeInput()
+	input.SetSize(45, 12)
+	input.SetValue(strings.Repeat("wrapping words ", 20))
+
+	if input.Width() > 45 {
+		t.Errorf("overlay wider than the terminal: %d", input.Width())
+	}
+
+	if input.Height() > 12 {
+		t.Errorf("overlay taller than the terminal: %d", input.Height())
+	}
+}
+
 func TestDescribeInput_Update_Submit(t *testing.T) {
 	input := NewDescribeInput()
 	input.SetChangeID("testchange")
@@ -133,6 +189,42 @@ func TestDescribeInput_View_ContainsElements(t *testing.T) {
 	}
 }
 
+func TestDescribeInput_Commit(t *testing.T) {
+	input := NewDescribeInput()
+	input.SetChangeID("xsssnyux")
+	input.SetValue("fix parser")
+	input.SetCommit(true)
+
+	if view := input.View(); !strings.Contains(view, "Commit: xsssnyux") || !strings.Contains(view, "⏎ commit") {
+		t.Errorf("view should say submitting commits, got:\n%s", view)
+	}
+
+	msg, ok := input.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))().(DescribeSubmitMsg)
+	if !ok || !msg.Commit || msg.Description != "fix parser" {
+		t.Errorf("expected a commit submission, got %+v", msg)
+	}
+}
+
+func TestDescribeInput_Batch(t *testing.T) {
+	input := NewDescribeInput()
+	input.SetChangeID("xsssnyux")
+	input.SetBatch(2, 3)
+
+	if view := input.View(); !strings.Contains(view, "Describe: xsssnyux (2/3)") || !strings.Contains(view, "⏎ next") {
+		t.Errorf("view should show the change's place in the batch, got:\n%s", view)
+	}
+
+	input.SetBatch(3, 3)
+	if view := input.View(); !strings.Contains(view, "⏎ save all") {
+		t.Errorf("the batch's last change should save them all, got:\n%s", view)
+	}
+
+	input.SetBatch(1, 1)
+	if view := input.View(); strings.Contains(view, "(1/1)") || !strings.Contains(view, "⏎ save •") {
+		t.Errorf("a lone change is no batch, got:\n%s", view)
+	}
+}
+
 func TestDescribeInput_WidthHeight(t *testing.T) {
 	input := NewDescribeInput()
 	input.SetChangeID("xsssnyux")
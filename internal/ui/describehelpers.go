@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// conventionalPrefixRe captures the type of a conventional-commit subject,
+// e.g. the "feat" of "feat(ui)!: add", and what follows it.
+var conventionalPrefixRe = regexp.MustCompile(`^([a-z]+)((?:\([^)]*\))?!?: )`)
+
+// trailerRe matches a trailer line, e.g. "Signed-off-by: A <a@b.c>".
+var trailerRe = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9-]*: `)
+
+// cyclePrefix moves the subject of value to the prefix step places on in
+// prefixes, keeping any scope: from none to the first, and from the last
+// back to none.
+func cyclePrefix(value string, prefixes []string, step int) string {
+	if len(prefixes) == 0 {
+		return value
+	}
+
+	current, rest := -1, value
+	suffix := ": "
+
+	if match := conventionalPrefixRe.FindStringSubmatch(value); match != nil {
+		if i := slices.Index(prefixes, match[1]); i >= 0 {
+			current, rest, suffix = i, value[len(match[0]):], match[2]
+		}
+	}
+
+	// The states are each prefix, then none
+	states := len(prefixes) + 1
+	next := ((current+1+step)%states+states)%states - 1
+
+	if next < 0 {
+		return rest
+	}
+
+	return prefixes[next] + suffix + rest
+}
+
+// appendTrailers appends the trailers value lacks, after a blank line or
+// under the trailers it ends with.
+func appendTrailers(value string, trailers []string) string {
+	lines := strings.Split(strings.TrimRight(value, "\n"), "\n")
+
+	var missing []string
+
+	for _, trailer := range trailers {
+		if !slices.Contains(lines, trailer) {
+			missing = append(missing, trailer)
+		}
+	}
+
+	if len(missing) == 0 {
+		return value
+	}
+
+	value = strings.TrimRight(value, "\n")
+	if last := lines[len(lines)-1]; len(lines) < 2 || !trailerRe.MatchString(last) {
+		value += "\n"
+	}
+
+	return value + "\n" + strings.Join(missing, "\n")
+}
+
+// subjectLength counts the characters of the first line of value.
+func subjectLength(value string) int {
+	subject, _, _ := strings.Cut(value, "\n")
+	return utf8.RuneCountInString(subject)
+}
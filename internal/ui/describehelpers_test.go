@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"pgregory.net/rapid"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestCyclePrefix(t *testing.T) {
+	prefixes := []string{"feat", "fix"}
+
+	tests := []struct {
+		name  string
+		value string
+		step  int
+		want  string
+	}{
+		{"none to first", "add parser", 1, "feat: add parser"},
+		{"first to second", "feat: add parser", 1, "fix: add parser"},
+		{"last to none", "fix: add parser", 1, "add parser"},
+		{"none back to last", "add parser", -1, "fix: add parser"},
+		{"keeps scope and breaking mark", "feat(ui)!: add parser", 1, "fix(ui)!: add parser"},
+		{"unknown type is text", "wip: add parser", 1, "feat: wip: add parser"},
+		{"empty", "", 1, "feat: "},
+		{"keeps body", "feat: add\n\nbody", 1, "fix: add\n\nbody"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cyclePrefix(tt.value, prefixes, tt.step); got != tt.want {
+				t.Errorf("cyclePrefix(%q, %d) = %q, want %q", tt.value, tt.step, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppendTrailers(t *testing.T) {
+	trailers := []string{"Signed-off-by: A <a@b.c>"}
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"after a blank line", "fix: parser", "fix: parser\n\nSigned-off-by: A <a@b.c>"},
+		{"under trailers", "fix: parser\n\nFixes: #1", "fix: parser\n\nFixes: #1\nSigned-off-by: A <a@b.c>"},
+		{"already there", "fix: parser\n\nSigned-off-by: A <a@b.c>", "fix: parser\n\nSigned-off-by: A <a@b.c>"},
+		{"trailing newlines dropped", "fix: parser\n\n", "fix: parser\n\nSigned-off-by: A <a@b.c>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := appendTrailers(tt.value, trailers); got != tt.want {
+				t.Errorf("appendTrailers(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescribeInput_TabCyclesPrefix(t *testing.T) {
+	input := NewDescribeInput()
+	input.SetPrefixes([]string{"feat", "fix"})
+	input.SetValue("add parser")
+
+	input.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	if got := input.Value(); got != "feat: add parser" {
+		t.Fatalf("tab should add the first prefix, got %q", got)
+	}
+
+	input.Update(tea.KeyPressMsg{Code: tea.KeyTab, Mod: tea.ModShift})
+	if got := input.Value(); got != "add parser" {
+		t.Errorf("shift+tab should step back to none, got %q", got)
+	}
+}
+
+func TestDescribeInput_CtrlTAddsTrailers(t *testing.T) {
+	input := NewDescribeInput()
+	input.SetValue("fix: parser")
+	input.SetTrailers([]string{"Signed-off-by: A <a@b.c>"})
+	input.Update(tea.KeyPressMsg{Code: 't', Mod: tea.ModCtrl})
+
+	if got := input.Value(); !strings.HasSuffix(got, "\n\nSigned-off-by: A <a@b.c>") {
+		t.Errorf("ctrl+t should add the trailers, got %q", got)
+	}
+}
+
+func TestDescribeInput_SubjectCounter(t *testing.T) {
+	input := NewDescribeInput()
+	input.SetValue("fix: parser\n\nlonger body that doesn't count")
+
+	if got := input.subjectCounter(); got != "" {
+		t.Errorf("counter should be hidden without a length, got %q", got)
+	}
+
+	input.SetSubjectLength(50)
+
+	if got := StripANSI(input.subjectCounter()); got != " • 11/50" {
+		t.Errorf("counter should count the subject, got %q", got)
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+func TestCyclePrefix_ReturnsAfterFullCycle(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		prefixes := rapid.SliceOfNDistinct(rapid.StringMatching(`[a-z]{1,8}`), 1, 6, rapid.ID[string]).Draw(t, "prefixes")
+		value := rapid.StringMatching(`[a-z ]{0,20}`).Draw(t, "value")
+		step := rapid.SampledFrom([]int{1, -1}).Draw(t, "step")
+
+		got := value
+		for range len(prefixes) + 1 {
+			got = cyclePrefix(got, prefixes, step)
+		}
+
+		if got != value {
+			t.Fatalf("cycling %d times gave %q, want %q", len(prefixes)+1, got, value)
+		}
+	})
+}
+
+func TestAppendTrailers_Idempotent(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		value := rapid.StringMatching(`[a-z]{1,10}(\n\n[a-z ]{0,20})?`).Draw(t, "value")
+		trailers := rapid.SliceOfN(rapid.StringMatching(`[A-Z][a-z]{1,8}: [a-z]{1,8}`), 1, 3).Draw(t, "trailers")
+
+		once := appendTrailers(value, trailers)
+		if twice := appendTrailers(once, trailers); twice != once {
+			t.Fatalf("appending again changed %q to %q", once, twice)
+		}
+	})
+}
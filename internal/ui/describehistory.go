@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+)
+
+// SetHistoryLimit sets how many descriptions the history keeps, dropping
+// the oldest past it; zero keeps none.
+func (d *DescribeInput) SetHistoryLimit(limit int) {
+	d.historyLimit = max(limit, 0)
+	d.trimHistory()
+}
+
+// SetHistory sets the descriptions up and down recall, oldest first.
+func (d *DescribeInput) SetHistory(history []string) {
+	d.history = slices.Clone(history)
+	d.trimHistory()
+}
+
+// trimHistory drops the oldest entries past the limit.
+func (d *DescribeInput) trimHistory() {
+	d.history = d.history[max(len(d.history)-d.historyLimit, 0):]
+	d.recall = len(d.history)
+}
+
+// History returns the descriptions remembered, oldest first.
+func (d *DescribeInput) History() []string {
+	return d.history
+}
+
+// rememberDescription adds the description to the history when it was
+// written here rather than left as it was opened, moving an identical
+// entry to the end.
+func (d *DescribeInput) rememberDescription() {
+	value := d.input.Value()
+	if strings.TrimSpace(value) == "" || value == d.initial || d.historyLimit == 0 {
+		return
+	}
+
+	d.history = append(slices.DeleteFunc(d.history, func(entry string) bool { return entry == value }), value)
+	d.trimHistory()
+}
+
+// recallAt shows the history entry at index, keeping what was being typed
+// to come back to one past the end.
+func (d *DescribeInput) recallAt(index int) {
+	if index < 0 || index > len(d.history) || index == d.recall {
+		return
+	}
+
+	if d.recall == len(d.history) {
+		d.draft = d.input.Value()
+	}
+
+	d.recall = index
+
+	if index == len(d.history) {
+		d.setText(d.draft)
+		return
+	}
+
+	d.setText(d.history[index])
+}
+
+// recallKey reports whether up or down moves through the history: up from
+// the first line of the description, down from its last.
+func (d *DescribeInput) recallKey(up bool) bool {
+	if len(d.history) == 0 {
+		return false
+	}
+
+	if up {
+		return d.input.Line() == 0
+	}
+
+	return d.input.Line() == d.input.LineCount()-1
+}
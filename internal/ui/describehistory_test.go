@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"pgregory.net/rapid"
+)
+
+// newHistoryTestInput returns an input remembering two descriptions, opened
+// empty.
+func newHistoryTestInput() *DescribeInput {
+	input := NewDescribeInput()
+	input.SetHistoryLimit(10)
+	input.SetHistory([]string{"fix: parser", "feat: lexer"})
+	input.SetValue("")
+
+	return input
+}
+
+var (
+	upKey   = tea.KeyPressMsg{Code: tea.KeyUp}
+	downKey = tea.KeyPressMsg{Code: tea.KeyDown}
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestDescribeInput_UpDownRecall(t *testing.T) {
+	input := newHistoryTestInput()
+	input.Update(tea.KeyPressMsg{Code: 'w', Text: "w"})
+
+	input.Update(upKey)
+	if got := input.Value(); got != "feat: lexer" {
+		t.Fatalf("up should recall the latest description, got %q", got)
+	}
+
+	input.Update(upKey)
+	input.Update(upKey)
+
+	if got := input.Value(); got != "fix: parser" {
+		t.Fatalf("up should stop at the oldest description, got %q", got)
+	}
+
+	input.Update(downKey)
+	input.Update(downKey)
+
+	if got := input.Value(); got != "w" {
+		t.Errorf("down past the latest should bring back what was typed, got %q", got)
+	}
+}
+
+func TestDescribeInput_RemembersSubmittedAndCancelled(t *testing.T) {
+	input := newHistoryTestInput()
+
+	input.Update(tea.KeyPressMsg{Code: 'a', Text: "a"})
+	input.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+
+	input.SetValue("fix: parser")
+	input.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	if want := []string{"fix: parser", "feat: lexer", "a"}; !slices.Equal(input.History(), want) {
+		t.Errorf("history = %q, want %q: cancelled text kept, unchanged text not", input.History(), want)
+	}
+
+	input.SetValue("feat: lexer!")
+	input.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	input.SetValue("fix: parser")
+	input.Update(tea.KeyPressMsg{Code: ' ', Text: " "})
+	input.Update(tea.KeyPressMsg{Code: tea.KeyBackspace})
+	input.Update(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	input.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	if got := input.History(); got[len(got)-1] != "fix: parserx" {
+		t.Errorf("the latest description should be last, got %q", got)
+	}
+}
+
+func TestDescribeInput_HistoryLimit(t *testing.T) {
+	input := NewDescribeInput()
+	input.SetHistoryLimit(2)
+	input.SetHistory([]string{"a", "b", "c"})
+
+	if want := []string{"b", "c"}; !slices.Equal(input.History(), want) {
+		t.Errorf("history = %q, want the latest %q", input.History(), want)
+	}
+
+	input.SetHistoryLimit(0)
+	input.SetValue("")
+	input.Update(tea.KeyPressMsg{Code: 'a', Text: "a"})
+	input.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	if len(input.History()) != 0 {
+		t.Errorf("a zero limit should remember nothing, got %q", input.History())
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+func TestDescribeInput_HistoryBoundedAndUnique(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		limit := rapid.IntRange(0, 5).Draw(t, "limit")
+
+		input := NewDescribeInput()
+		input.SetHistoryLimit(limit)
+
+		for _, value := range rapid.SliceOf(rapid.SampledFrom([]string{"a", "b", "c", "d", " "})).Draw(t, "submitted") {
+			input.SetValue("")
+			input.Update(tea.KeyPressMsg{Code: rune(value[0]), Text: value})
+			input.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+			for range rapid.IntRange(0, 3).Draw(t, "ups") {
+				input.Update(upKey)
+			}
+		}
+
+		history := input.History()
+		if len(history) > limit {
+			t.Fatalf("history %q is past its limit %d", history, limit)
+		}
+
+		if len(slices.Compact(slices.Sorted(slices.Values(history)))) != len(history) {
+			t.Fatalf("history %q repeats an entry", history)
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+// lintDescription lists what is wrong with a description by git's
+// conventions: that it is empty, that its subject is longer than
+// subjectMax (unchecked when 0), or that no blank line follows the subject.
+func lintDescription(value string, subjectMax int) []string {
+	if strings.TrimSpace(value) == "" {
+		return []string{"empty description"}
+	}
+
+	var problems []string
+
+	if length := subjectLength(value); subjectMax > 0 && length > subjectMax {
+		problems = append(problems, fmt.Sprintf("subject is %d characters, over %d", length, subjectMax))
+	}
+
+	lines := strings.Split(strings.TrimRight(value, "\n"), "\n")
+	if len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
+		problems = append(problems, "no blank line after the subject")
+	}
+
+	return problems
+}
+
+// SetLint sets whether submitting a description with problems warns first,
+// submitting only when submitted again unchanged.
+func (d *DescribeInput) SetLint(lint bool) {
+	d.lint = lint
+	d.warned = false
+}
+
+// holdSubmit reports whether submitting should wait to warn of the
+// description's problems; a second submit goes ahead.
+func (d *DescribeInput) holdSubmit() bool {
+	if !d.lint || d.warned || len(lintDescription(d.input.Value(), d.subjectMax)) == 0 {
+		return false
+	}
+
+	d.warned = true
+
+	return true
+}
+
+// lintWarning warns of the description's problems after a held submit, in
+// place of the hint, wrapped to the input's width.
+func (d *DescribeInput) lintWarning() string {
+	problems := lintDescription(d.input.Value(), d.subjectMax)
+	again := "⏎ again to save anyway"
+
+	if d.commit {
+		again = "⏎ again to commit anyway"
+	}
+
+	warning := d.warnStyle.Render(strings.Join(problems, " • ")) + d.hintStyle.Render(" • "+again)
+
+	return lipgloss.NewStyle().Width(d.input.Width()).Render(warning)
+}
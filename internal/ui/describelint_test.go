@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"pgregory.net/rapid"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestLintDescription(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  []string
+	}{
+		{"fine", "fix: lexer\n\nbody", nil},
+		{"empty", "  \n", []string{"empty description"}},
+		{"long subject", strings.Repeat("x", 12), []string{"subject is 12 characters, over 10"}},
+		{"no blank line", "fix: lexer\nbody", []string{"no blank line after the subject"}},
+		{"trailing newline only", "fix: lexer\n", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lintDescription(tt.value, 10); !slices.Equal(got, tt.want) {
+				t.Errorf("lintDescription(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescribeInput_LintHoldsFirstSubmit(t *testing.T) {
+	input := NewDescribeInput()
+	input.SetLint(true)
+	input.SetSubjectLength(10)
+	input.SetValue("a subject that is too long")
+
+	if cmd := input.Update(tea.KeyPressMsg{Code: tea.KeyEnter}); cmd != nil {
+		t.Fatal("the first submit should warn instead")
+	}
+
+	if view := StripANSI(input.View()); !strings.Contains(view, "over 10") || !strings.Contains(view, "again to save anyway") {
+		t.Errorf("view should warn of the problem, got:\n%s", view)
+	}
+
+	cmd := input.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("submitting again should go ahead")
+	}
+
+	if msg, ok := cmd().(DescribeSubmitMsg); !ok || msg.Description != "a subject that is too long" {
+		t.Errorf("expected the description submitted, got %+v", msg)
+	}
+}
+
+func TestDescribeInput_LintWarnsAgainAfterEdit(t *testing.T) {
+	input := NewDescribeInput()
+	input.SetLint(true)
+
+	input.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	input.Update(tea.KeyPressMsg{Code: ' ', Text: " "})
+
+	if cmd := input.Update(tea.KeyPressMsg{Code: tea.KeyEnter}); cmd != nil {
+		t.Error("an edited description should be linted again")
+	}
+}
+
+func TestDescribeInput_LintOff(t *testing.T) {
+	input := NewDescribeInput()
+
+	if cmd := input.Update(tea.KeyPressMsg{Code: tea.KeyEnter}); cmd == nil {
+		t.Error("without linting, an empty description should submit")
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+func TestDescribeInput_LintSubmitsWithinTwo(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		input := NewDescribeInput()
+		input.SetLint(true)
+		input.SetSubjectLength(rapid.IntRange(0, 20).Draw(t, "subjectLength"))
+
+		value := rapid.StringMatching(`[a-z \n]{0,40}`).Draw(t, "value")
+		input.SetValue(value)
+
+		held := input.Update(tea.KeyPressMsg{Code: tea.KeyEnter}) == nil
+		if held != (len(lintDescription(value, input.subjectMax)) > 0) {
+			t.Fatalf("submit held = %v for %q", held, value)
+		}
+
+		if held && input.Update(tea.KeyPressMsg{Code: tea.KeyEnter}) == nil {
+			t.Fatalf("second submit of %q should go ahead", value)
+		}
+	})
+}
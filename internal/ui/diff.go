@@ -2,9 +2,12 @@ package ui
 
 import (
 	"crypto/sha256"
+	"fmt"
+	"slices"
 	"strings"
 
 	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
 	"charm.land/bubbles/v2/viewport"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
@@ -19,8 +22,14 @@ const noHunkSelected = -1
 // mouseScrollLines is the number of lines to scroll per mouse wheel tick.
 const mouseScrollLines = 3
 
+// mouseScrollColumns is the number of columns to scroll per sideways mouse
+// wheel tick when lines don't wrap.
+const mouseScrollColumns = 6
+
 // DiffPanel displays diff content with optional details header.
 type DiffPanel struct {
+	Navigator // count prefixes and paging keys
+
 	viewport        viewport.Model
 	styles          *Styles
 	focused         bool
@@ -28,11 +37,57 @@ type DiffPanel struct {
 	height          int
 	title           string
 	diffContent     string
-	hunks           []jj.Hunk
+	rendered        string    // diffContent wrapped to width, before folding
+	hunks           []jj.Hunk // file sections as shown, with folded ones one line tall
 	currentHunk     int
-	contentHash     [32]byte // SHA-256 of diffContent; used to skip no-op SetDiff calls
-	borderAnimPhase float64  // 0..1 for focus border animation
-	borderAnimating bool     // true only while the one-shot wrap is running
+	hunkStarts      []int       // shown line each hunk starts on, for the title's position
+	fileJump        int         // files a ]f or [f had left to move past the diff's ends
+	keptScroll      *keptScroll // where the next content scrolls to, instead of the top
+	contentHash     [32]byte    // SHA-256 of diffContent; used to skip no-op SetDiff calls
+	borderAnimPhase float64     // 0..1 for focus border animation
+	borderAnimating bool        // true only while the one-shot wrap is running
+
+	// Folded file sections, keyed by header so they stay folded across reloads
+	collapsed map[string]bool
+
+	// Search: the query typed after "/", its matches in the shown lines, and
+	// the one jumped to last
+	lines        []string // shown lines, before matches are marked
+	searching    bool
+	searchInput  textinput.Model
+	searchQuery  string
+	searchOrigin int // viewport offset when the prompt opened
+	matches      []searchMatch
+	currentMatch int
+
+	// Syntax highlighting of file content; nil when turned off
+	syntax *SyntaxHighlighter
+
+	// hideLineNumbers drops the line-number gutter from diff lines
+	hideLineNumbers bool
+
+	// noWrap leaves long lines running past the width, scrolled sideways
+	// with h and l, rather than wrapping them
+	noWrap bool
+
+	// The diff format named in the title; empty names none
+	format string
+
+	// Preview mode: content set while previewing is parked until ClosePreview
+	previewing bool
+	savedTitle string
+	savedDiff  string
+	savedPath  string
+
+	// The file diffContent is the whole content of, shown with line numbers
+	// rather than as a diff; empty for a diff
+	contentPath string
+
+	// Hunks being picked, shown as a preview; nil when not picking
+	pick *hunkPick
+
+	// A file's annotated lines, shown as a preview; nil when not annotating
+	annotation *annotation
 }
 
 // NewDiffPanel creates a new diff panel.
@@ -40,9 +95,12 @@ func NewDiffPanel(styles *Styles) DiffPanel {
 	vp := viewport.New()
 
 	return DiffPanel{
-		viewport: vp,
-		styles:   styles,
-		title:    "Diff",
+		viewport:     vp,
+		styles:       styles,
+		title:        "Diff",
+		collapsed:    make(map[string]bool),
+		searchInput:  newSearchInput(),
+		currentMatch: -1,
 	}
 }
 
@@ -71,12 +129,107 @@ func (p *DiffPanel) SetBorderAnimating(animating bool) {
 
 // SetTitle sets the panel title.
 func (p *DiffPanel) SetTitle(title string) {
+	if p.previewing {
+		p.savedTitle = title
+		return
+	}
+
 	p.title = title
 }
 
+// Title returns the panel title, which a preview replaces while shown.
+func (p *DiffPanel) Title() string {
+	return p.title
+}
+
+// SetFormat sets the name of the diff format shown after the title of
+// diffs, though not of file content or previews.
+func (p *DiffPanel) SetFormat(format string) {
+	p.format = format
+}
+
 // SetDiff sets the diff content. If the content is unchanged (same SHA-256
 // hash), it returns immediately — no viewport update, no scroll reset.
+// While a preview is shown, the content is kept for when the preview closes.
 func (p *DiffPanel) SetDiff(diff string) {
+	p.SetFileContent("", diff)
+}
+
+// SetFileContent shows content, the whole of the file at path, with line
+// numbers and syntax highlighting instead of a diff. An empty path shows
+// content as a diff, like SetDiff.
+func (p *DiffPanel) SetFileContent(path, content string) {
+	if p.previewing {
+		p.savedDiff, p.savedPath = content, path
+		return
+	}
+
+	p.setContentPath(path)
+	p.setContent(content)
+}
+
+// setContentPath sets the file the content is of, re-rendering the content
+// when it changes even if the text does not.
+func (p *DiffPanel) setContentPath(path string) {
+	if path != p.contentPath {
+		p.contentPath = path
+		p.contentHash = [sha256.Size]byte{}
+	}
+}
+
+// ShowPreview temporarily replaces the panel content with the predicted
+// effects of a pending action. The current title and diff are restored by
+// ClosePreview; diffs loaded in the meantime replace what is restored.
+func (p *DiffPanel) ShowPreview(label, content string) {
+	p.ShowOutput("Preview: "+label, content)
+}
+
+// ShowOutput temporarily replaces the panel content with command output
+// under the given title, restored like a preview by ClosePreview.
+func (p *DiffPanel) ShowOutput(title, content string) {
+	p.pick = nil
+	p.annotation = nil
+
+	if !p.previewing {
+		p.savedTitle = p.title
+		p.savedDiff = p.diffContent
+		p.savedPath = p.contentPath
+		p.previewing = true
+	}
+
+	p.title = title
+	p.setContentPath("")
+	p.setContent(content)
+}
+
+// ClosePreview leaves preview mode and restores the content it replaced.
+func (p *DiffPanel) ClosePreview() {
+	p.pick = nil
+	p.annotation = nil
+
+	if !p.previewing {
+		return
+	}
+
+	p.previewing = false
+	p.title = p.savedTitle
+	p.setContentPath(p.savedPath)
+	p.setContent(p.savedDiff)
+	p.savedTitle = ""
+	p.savedDiff = ""
+	p.savedPath = ""
+}
+
+// Previewing reports whether the panel is showing a preview.
+func (p *DiffPanel) Previewing() bool {
+	return p.previewing
+}
+
+// setContent replaces the viewport content, skipping unchanged content.
+func (p *DiffPanel) setContent(diff string) {
+	kept := p.keptScroll
+	p.keptScroll = nil
+
 	hash := sha256.Sum256([]byte(diff))
 	if hash == p.contentHash {
 		return
@@ -87,15 +240,38 @@ func (p *DiffPanel) SetDiff(diff string) {
 	p.currentHunk = noHunkSelected
 	p.updateContent()
 	p.viewport.GotoTop()
+	p.restoreScroll(kept)
+}
+
+// SetSyntaxHighlight turns syntax highlighting of file content on or off.
+// Diffs longer than maxLines lines are never highlighted (0 means no limit).
+func (p *DiffPanel) SetSyntaxHighlight(enabled bool, maxLines int) {
+	p.syntax = nil
+	if enabled {
+		p.syntax = NewSyntaxHighlighter(maxLines)
+	}
+
+	p.updateContent()
+}
+
+// SyntaxHighlighting reports whether syntax highlighting is turned on. It may
+// still be skipped for the current diff if that is too large.
+func (p *DiffPanel) SyntaxHighlighting() bool {
+	return p.syntax != nil
 }
 
-// NextHunk jumps to the next hunk/section.
+// NextHunk jumps to the next expanded hunk/section, skipping folded ones.
 func (p *DiffPanel) NextHunk() {
-	if len(p.hunks) == 0 || p.currentHunk >= len(p.hunks)-1 {
+	next := p.currentHunk + 1
+	for next < len(p.hunks) && p.collapsed[p.hunks[next].Header] {
+		next++
+	}
+
+	if next >= len(p.hunks) {
 		return
 	}
 
-	p.currentHunk++
+	p.currentHunk = next
 	p.viewport.SetYOffset(p.hunks[p.currentHunk].StartLine)
 }
 
@@ -119,8 +295,12 @@ func (p *DiffPanel) PrevHunk() {
 		return
 	}
 
-	// Already at start of current hunk, go to previous hunk (or top if at hunk 0)
+	// Already at start of current hunk, go to previous expanded hunk (or top if none)
 	p.currentHunk--
+	for p.currentHunk >= 0 && p.collapsed[p.hunks[p.currentHunk].Header] {
+		p.currentHunk--
+	}
+
 	if p.currentHunk >= 0 {
 		p.viewport.SetYOffset(p.hunks[p.currentHunk].StartLine)
 	} else {
@@ -128,12 +308,123 @@ func (p *DiffPanel) PrevHunk() {
 	}
 }
 
+// ToggleSection folds or unfolds the file section at the top of the view.
+func (p *DiffPanel) ToggleSection() {
+	p.syncCurrentHunk()
+
+	if p.currentHunk == noHunkSelected {
+		return
+	}
+
+	header := p.hunks[p.currentHunk].Header
+	if p.collapsed[header] {
+		delete(p.collapsed, header)
+	} else {
+		p.collapsed[header] = true
+	}
+
+	p.refold()
+}
+
+// foldContinuations are the fold commands, for the pending key popup.
+var foldContinuations = []help.Binding{
+	continuation("a", "za", "fold file"),
+	continuation("M", "zM", "fold all"),
+	continuation("R", "zR", "unfold all"),
+}
+
+// PendingBindings returns the keys that can follow the pending key, the
+// fold commands among them after z, and the copy commands after y.
+func (p *DiffPanel) PendingBindings() []help.Binding {
+	bindings := p.Navigator.PendingBindings()
+
+	switch p.PendingKey() {
+	case "z":
+		bindings = append(slices.Clip(bindings), foldContinuations...)
+	case "y":
+		bindings = copyContinuations
+	}
+
+	return bindings
+}
+
+// fold runs the fold command z followed by key, reporting whether key
+// completes one.
+func (p *DiffPanel) fold(key string) bool {
+	switch key {
+	case "a":
+		p.ToggleSection()
+	case "M":
+		p.SetAllCollapsed(true)
+	case "R":
+		p.SetAllCollapsed(false)
+	default:
+		return false
+	}
+
+	return true
+}
+
+// SetAllCollapsed folds or unfolds every file section.
+func (p *DiffPanel) SetAllCollapsed(collapsed bool) {
+	p.syncCurrentHunk()
+
+	clear(p.collapsed)
+
+	if collapsed {
+		for _, hunk := range p.hunks {
+			p.collapsed[hunk.Header] = true
+		}
+	}
+
+	p.refold()
+}
+
+// refold re-applies folding and keeps the current section at the top of the view.
+func (p *DiffPanel) refold() {
+	current := p.currentHunk
+
+	p.applyFolds()
+
+	if current != noHunkSelected && current < len(p.hunks) {
+		p.currentHunk = current
+		p.viewport.SetYOffset(p.hunks[current].StartLine)
+	}
+}
+
 // GotoTop scrolls to the top.
 func (p *DiffPanel) GotoTop() {
 	p.viewport.GotoTop()
 	p.currentHunk = noHunkSelected
 }
 
+// MoveBy scrolls by rows, up when negative.
+func (p *DiffPanel) MoveBy(rows int) {
+	if rows < 0 {
+		p.viewport.ScrollUp(-rows)
+	} else {
+		p.viewport.ScrollDown(rows)
+	}
+
+	p.syncCurrentHunk()
+}
+
+// PageRows returns how many lines one screen of the panel shows.
+func (p *DiffPanel) PageRows() int {
+	return p.viewport.Height()
+}
+
+// ScrollSelected scrolls the current file section's header to anchor, or
+// with none, the line at the top.
+func (p *DiffPanel) ScrollSelected(anchor ScrollAnchor) {
+	line := p.viewport.YOffset()
+	if p.currentHunk != noHunkSelected && p.currentHunk < len(p.hunks) {
+		line = p.hunks[p.currentHunk].StartLine
+	}
+
+	p.viewport.SetYOffset(anchoredOffset(line, line, p.viewport.Height(), anchor))
+}
+
 // GotoBottom scrolls to the bottom.
 func (p *DiffPanel) GotoBottom() {
 	p.viewport.GotoBottom()
@@ -150,6 +441,10 @@ func (p *DiffPanel) HandleMouseScroll(button tea.MouseButton) {
 		p.viewport.ScrollUp(mouseScrollLines)
 	case tea.MouseWheelDown:
 		p.viewport.ScrollDown(mouseScrollLines)
+	case tea.MouseWheelLeft:
+		p.ScrollColumns(-mouseScrollColumns)
+	case tea.MouseWheelRight:
+		p.ScrollColumns(mouseScrollColumns)
 	}
 
 	p.syncCurrentHunk()
@@ -162,21 +457,53 @@ func (p *DiffPanel) Update(msg tea.Msg) tea.Cmd {
 	}
 
 	if msg, ok := msg.(tea.KeyMsg); ok {
+		if p.pick != nil {
+			return p.updateHunkPick(msg)
+		}
+
+		if p.annotation != nil {
+			return p.updateAnnotate(msg)
+		}
+
+		if p.searching {
+			return p.updateSearch(msg)
+		}
+
+		// The fold commands share the z prefix with zz, zt, and zb
+		if p.PendingKey() == "z" && p.fold(msg.String()) {
+			p.Reset()
+			return nil
+		}
+
+		if p.PendingKey() == "y" {
+			p.Reset()
+			return p.copyCmd(msg.String())
+		}
+
+		count, handled := p.Navigate(p, msg)
+		if handled {
+			return p.fileJumpCmd()
+		}
+
 		switch msg.String() {
-		case "j", "down": //nolint:goconst // key name literals are clearest inline
-			p.viewport.ScrollDown(1)
-			p.syncCurrentHunk()
-		case "k", "up":
-			p.viewport.ScrollUp(1)
-			p.syncCurrentHunk()
+		case "/":
+			return p.StartSearch()
+		case "n":
+			p.NextMatch()
+		case "N":
+			p.PrevMatch()
 		case "}":
-			p.NextHunk()
+			p.JumpHunk(count)
 		case "{":
-			p.PrevHunk()
-		case "g":
-			p.GotoTop()
-		case "G":
-			p.GotoBottom()
+			p.JumpHunk(-count)
+		case "l":
+			p.ScrollColumns(count * scrollColumns)
+		case "h":
+			p.ScrollColumns(-count * scrollColumns)
+		case "y":
+			if p.copyable() {
+				p.pending = "y"
+			}
 		}
 	}
 
@@ -185,7 +512,19 @@ func (p *DiffPanel) Update(msg tea.Msg) tea.Cmd {
 
 // View renders the panel.
 func (p *DiffPanel) View() string {
-	title := p.styles.PanelTitle(0, p.title, p.focused)
+	status := p.formatStatus() + p.positionStatus() + p.searchStatus()
+	if p.pick != nil {
+		status = p.pickStatus()
+	}
+
+	if p.annotation != nil {
+		status = p.annotateStatus()
+	}
+
+	title := p.styles.PanelTitle(0, p.title+status, p.focused)
+	if p.searching {
+		title += " " + p.searchInput.View()
+	}
 
 	// Get the appropriate border style
 	var style lipgloss.Style
@@ -209,7 +548,15 @@ func (p *DiffPanel) View() string {
 
 // HelpBindings returns the keybindings for this panel (display-only, for status bar).
 func (p *DiffPanel) HelpBindings() []help.Binding {
-	return []help.Binding{
+	if p.pick != nil {
+		return hunkPickBindings()
+	}
+
+	if p.annotation != nil {
+		return annotateBindings()
+	}
+
+	bindings := []help.Binding{
 		{
 			Key:      key.NewBinding(key.WithKeys("j", "k"), key.WithHelp("j/k", "up/down")),
 			Category: help.CategoryNavigation,
@@ -221,11 +568,48 @@ func (p *DiffPanel) HelpBindings() []help.Binding {
 			Order:    PanelOrderPrimary,
 		},
 		{
-			Key:      key.NewBinding(key.WithKeys("g", "G"), key.WithHelp("g/G", "top/bottom")),
+			Key:      key.NewBinding(key.WithKeys("]", "["), key.WithHelp("]f/[f", "next/prev file")),
+			Category: help.CategoryDiff,
+			Order:    PanelOrderPrimary,
+		},
+		{
+			Key:      key.NewBinding(key.WithKeys("z"), key.WithHelp("za/⏎", "fold file")),
+			Category: help.CategoryDiff,
+			Order:    PanelOrderSecondary,
+		},
+		{
+			Key:      key.NewBinding(key.WithKeys("z"), key.WithHelp("zM/zR", "fold/unfold all")),
+			Category: help.CategoryDiff,
+			Order:    PanelOrderSecondary,
+		},
+		{
+			Key:      key.NewBinding(key.WithKeys("y"), key.WithHelp("yh/yf", "copy hunk/file")),
+			Category: help.CategoryDiff,
+			Order:    PanelOrderSecondary,
+		},
+		{
+			Key:      key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "search")),
+			Category: help.CategoryDiff,
+			Order:    PanelOrderPrimary,
+		},
+		{
+			Key:      key.NewBinding(key.WithKeys("n", "N"), key.WithHelp("n/N", "next/prev match")),
+			Category: help.CategoryDiff,
+			Order:    PanelOrderSecondary,
+		},
+		{
+			Key:      key.NewBinding(key.WithKeys("g", "G"), key.WithHelp("gg/G", "top/bottom")),
+			Category: help.CategoryNavigation,
+			Order:    PanelOrderSecondary,
+		},
+		{
+			Key:      key.NewBinding(key.WithKeys("z"), key.WithHelp("zz/zt/zb", "scroll to center/top/bottom")),
 			Category: help.CategoryNavigation,
 			Order:    PanelOrderSecondary,
 		},
 	}
+
+	return append(bindings, p.wrapBindings()...)
 }
 
 // syncCurrentHunk updates currentHunk based on viewport position.
@@ -247,21 +631,86 @@ func (p *DiffPanel) syncCurrentHunk() {
 	p.currentHunk = noHunkSelected
 }
 
+// Restyle re-renders the content in the current styles, after a theme change.
+func (p *DiffPanel) Restyle() {
+	p.updateContent()
+}
+
 func (p *DiffPanel) updateContent() {
-	var content string
+	if p.pick != nil {
+		p.renderHunkPick()
+		return
+	}
+
+	if p.annotation != nil {
+		p.renderAnnotate()
+		return
+	}
+
+	content := p.diffContent
+
+	switch {
+	case p.contentPath != "":
+		content = p.renderFileContent()
+	case jj.IsGitDiff(content):
+		content = renderGitDiff(content, p.styles)
+	}
+
+	if p.syntax != nil && p.contentPath == "" {
+		content = p.syntax.Highlight(content)
+	}
+
+	// After highlighting, which finds file content by its gutter
+	if p.hideLineNumbers && p.contentPath == "" {
+		content = hideGutters(content)
+	}
 
 	viewportWidth := p.viewport.Width()
-	if viewportWidth > 0 {
-		content = lipgloss.NewStyle().Width(viewportWidth).Render(p.diffContent)
-	} else {
-		content = p.diffContent
+	if viewportWidth > 0 && !p.noWrap {
+		content = lipgloss.NewStyle().Width(viewportWidth).Render(content)
 	}
 
 	// Replace the template separator with a full-width line
-	if viewportWidth > 0 {
+	if viewportWidth > 0 && p.contentPath == "" {
 		content = strings.Replace(content, "----", strings.Repeat("─", viewportWidth), 1)
 	}
 
-	p.hunks = jj.FindHunks(content)
-	p.viewport.SetContent(content)
+	p.rendered = content
+	p.applyFolds()
+}
+
+// applyFolds shows the rendered diff with each folded file section reduced to
+// its header line, and records where each section now starts.
+func (p *DiffPanel) applyFolds() {
+	lines := strings.Split(p.rendered, "\n")
+	sections := jj.FindHunks(p.rendered)
+	shown := make([]string, 0, len(lines))
+	p.hunks = make([]jj.Hunk, 0, len(sections))
+	p.hunkStarts = p.hunkStarts[:0]
+	next := 0
+
+	for _, section := range sections {
+		shown = append(shown, lines[next:section.StartLine]...)
+		// Trimmed so the fold survives re-wrapping to a new width
+		hunk := jj.Hunk{Header: strings.TrimSpace(section.Header), StartLine: len(shown)}
+
+		if p.collapsed[hunk.Header] {
+			folded := fmt.Sprintf("  ▸ %d lines folded", section.EndLine-section.StartLine)
+			header := strings.TrimRight(lines[section.StartLine], " ") // drop width padding
+			shown = append(shown, header+p.styles.Dim.Render(folded))
+		} else {
+			shown = append(shown, lines[section.StartLine:section.EndLine+1]...)
+		}
+
+		p.addHunkStarts(lines[section.StartLine:section.EndLine+1], hunk.StartLine, p.collapsed[hunk.Header])
+
+		hunk.EndLine = len(shown) - 1
+		p.hunks = append(p.hunks, hunk)
+		next = section.EndLine + 1
+	}
+
+	shown = append(shown, lines[next:]...)
+	p.lines = shown
+	p.findMatches()
+	p.showMatches()
 }
@@ -103,6 +103,54 @@ func TestDiffPanel_SetTitle(t *testing.T) {
 	}
 }
 
+func TestDiffPanel_PreviewRestoresContent(t *testing.T) {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetSize(80, 24)
+	panel.SetTitle("Patch")
+	panel.SetDiff("original diff")
+
+	panel.ShowPreview("jj abandon xsssnyux", "would abandon")
+
+	if !panel.Previewing() {
+		t.Fatal("panel should be previewing")
+	}
+	if panel.title != "Preview: jj abandon xsssnyux" {
+		t.Errorf("unexpected preview title %q", panel.title)
+	}
+	if panel.diffContent != "would abandon" {
+		t.Errorf("preview content not shown, got %q", panel.diffContent)
+	}
+
+	panel.ClosePreview()
+
+	if panel.Previewing() {
+		t.Error("panel should leave preview mode")
+	}
+	if panel.title != "Patch" || panel.diffContent != "original diff" {
+		t.Errorf("expected original content restored, got title %q diff %q", panel.title, panel.diffContent)
+	}
+}
+
+func TestDiffPanel_PreviewParksLoadsUntilClosed(t *testing.T) {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetSize(80, 24)
+	panel.SetDiff("old diff")
+
+	panel.ShowPreview("jj git push", "would push")
+	panel.SetTitle("Operation")
+	panel.SetDiff("new diff")
+
+	if panel.diffContent != "would push" {
+		t.Errorf("loads during preview must not replace it, got %q", panel.diffContent)
+	}
+
+	panel.ClosePreview()
+
+	if panel.title != "Operation" || panel.diffContent != "new diff" {
+		t.Errorf("expected latest load restored, got title %q diff %q", panel.title, panel.diffContent)
+	}
+}
+
 func TestDiffPanel_HunkNavigation(t *testing.T) {
 	panel := NewDiffPanel(NewStyles())
 	panel.SetSize(80, 40) // Taller to allow scrolling
@@ -203,6 +251,114 @@ func TestDiffPanel_EmptyDiff(t *testing.T) {
 	panel.GotoBottom()
 }
 
+// foldTestDiff has three file sections of 4, 3 and 2 content lines.
+const foldTestDiff = `Added regular file a.go:
+        1: package a
+        2:
+        3:
+        4:
+Added regular file b.go:
+        1: package b
+        2:
+        3:
+Added regular file c.go:
+        1: package c
+        2:`
+
+func pressKeys(panel *DiffPanel, keys ...string) {
+	for _, k := range keys {
+		panel.Update(tea.KeyPressMsg{Code: []rune(k)[0], Text: k})
+	}
+}
+
+func TestDiffPanel_FoldSection(t *testing.T) {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetSize(80, 40)
+	panel.SetFocused(true)
+	panel.SetDiff(foldTestDiff)
+	panel.NextHunk()
+
+	pressKeys(&panel, "z")
+
+	if !panel.KeyPending() {
+		t.Fatal("z should wait for the rest of the fold command")
+	}
+
+	pressKeys(&panel, "a")
+
+	if panel.KeyPending() {
+		t.Error("the fold command should be complete")
+	}
+
+	view := StripANSI(panel.viewport.View())
+	if strings.Contains(view, "package a") {
+		t.Error("folded section content should be hidden")
+	}
+
+	if !strings.Contains(view, "a.go:  ▸ 4 lines folded") {
+		t.Errorf("folded header should say how much is hidden, got:\n%s", view)
+	}
+
+	if panel.hunks[1].StartLine != 1 {
+		t.Errorf("next section should follow the folded header, starts at %d", panel.hunks[1].StartLine)
+	}
+
+	panel.ToggleSection()
+
+	if !strings.Contains(StripANSI(panel.viewport.View()), "package a") {
+		t.Error("toggling again should unfold the section")
+	}
+}
+
+func TestDiffPanel_NextHunkSkipsFolded(t *testing.T) {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetSize(80, 5) // short enough to scroll each section to the top
+	panel.SetDiff(foldTestDiff)
+
+	panel.hunks = nil // fold b.go without scrolling to it
+	panel.collapsed["Added regular file b.go:"] = true
+	panel.applyFolds()
+
+	panel.NextHunk()
+	panel.NextHunk()
+
+	if panel.currentHunk != 2 {
+		t.Errorf("expected to skip the folded section to hunk 2, got %d", panel.currentHunk)
+	}
+
+	panel.PrevHunk()
+
+	if panel.currentHunk != 0 {
+		t.Errorf("expected to skip back over the folded section to hunk 0, got %d", panel.currentHunk)
+	}
+}
+
+func TestDiffPanel_FoldAll(t *testing.T) {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetSize(80, 40)
+	panel.SetFocused(true)
+	panel.SetDiff(foldTestDiff)
+
+	pressKeys(&panel, "z", "M")
+
+	if lines := panel.viewport.TotalLineCount(); lines != 3 {
+		t.Errorf("folding all should leave only the 3 headers, got %d lines", lines)
+	}
+
+	// Folds are kept by header when the diff reloads
+	panel.SetDiff(foldTestDiff + "\n        3:")
+
+	if lines := panel.viewport.TotalLineCount(); lines != 3 {
+		t.Errorf("folds should survive a reload, got %d lines", lines)
+	}
+
+	pressKeys(&panel, "z", "R")
+
+	if strings.Count(StripANSI(panel.viewport.View()), "package") != 3 {
+		t.Error("unfolding all should show every section")
+	}
+}
+
 // =============================================================================
 // Property Tests
 // =============================================================================
@@ -721,4 +877,3 @@ func TestDiffPanel_MouseScroll_SyncsHunk(t *testing.T) {
 		}
 	})
 }
-
@@ -0,0 +1,177 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui/help"
+)
+
+// annotateKeys are the keys updateAnnotate handles, which it takes ahead of
+// any global binding.
+var annotateKeys = map[string]bool{
+	"j": true, "down": true, "k": true, "up": true, "g": true, "G": true,
+	"enter": true, "esc": true,
+}
+
+// AnnotateSelectMsg is sent when enter is pressed on an annotated line, to
+// go to the change that last touched it.
+type AnnotateSelectMsg struct {
+	ChangeID string
+}
+
+// annotation is the state of browsing a file's annotated lines.
+type annotation struct {
+	lines  []jj.AnnotatedLine
+	cursor int
+}
+
+// StartAnnotate shows the annotated lines of a file under title, in place
+// of the current content until StopAnnotate. Returns false, leaving the
+// panel alone, if there are no lines.
+func (p *DiffPanel) StartAnnotate(title string, lines []jj.AnnotatedLine) bool {
+	if len(lines) == 0 {
+		return false
+	}
+
+	p.ShowOutput(title, "")
+	p.annotation = &annotation{lines: lines}
+	p.renderAnnotate()
+	p.viewport.GotoTop()
+
+	return true
+}
+
+// StopAnnotate leaves the annotation and restores the content it replaced.
+func (p *DiffPanel) StopAnnotate() {
+	p.ClosePreview()
+}
+
+// Annotating reports whether an annotation is shown.
+func (p *DiffPanel) Annotating() bool {
+	return p.annotation != nil
+}
+
+// annotateStatus gives the line under the cursor for the panel title.
+func (p *DiffPanel) annotateStatus() string {
+	return fmt.Sprintf(" [line %d of %d]", p.annotation.cursor+1, len(p.annotation.lines))
+}
+
+// updateAnnotate handles a key while annotating: move between lines, go to
+// a line's change with enter, or stop with esc.
+func (p *DiffPanel) updateAnnotate(msg tea.KeyMsg) tea.Cmd {
+	a := p.annotation
+
+	if _, handled := p.Navigate(annotatedLines{p}, msg); handled {
+		return nil
+	}
+
+	switch msg.String() {
+	case "enter":
+		changeID := a.lines[a.cursor].ChangeID
+		return func() tea.Msg { return AnnotateSelectMsg{ChangeID: changeID} }
+	case "esc":
+		p.StopAnnotate()
+	}
+
+	return nil
+}
+
+// annotatedLines moves between the annotated lines with the shared
+// navigation keys.
+type annotatedLines struct{ p *DiffPanel }
+
+// MoveBy moves the cursor by rows, up when negative.
+func (l annotatedLines) MoveBy(rows int) {
+	l.p.selectAnnotatedLine(l.p.annotation.cursor + rows)
+}
+
+// GotoTop moves to the first line.
+func (l annotatedLines) GotoTop() {
+	l.p.selectAnnotatedLine(0)
+}
+
+// GotoBottom moves to the last line.
+func (l annotatedLines) GotoBottom() {
+	l.p.selectAnnotatedLine(len(l.p.annotation.lines) - 1)
+}
+
+// PageRows returns how many lines one screen shows.
+func (l annotatedLines) PageRows() int {
+	return l.p.viewport.Height()
+}
+
+// selectAnnotatedLine moves the cursor to line i, within bounds, scrolling
+// just enough to keep it in view.
+func (p *DiffPanel) selectAnnotatedLine(i int) {
+	a := p.annotation
+	a.cursor = min(max(i, 0), len(a.lines)-1)
+	p.renderAnnotate()
+
+	height := max(p.viewport.Height(), 1)
+
+	switch {
+	case a.cursor < p.viewport.YOffset():
+		p.viewport.SetYOffset(a.cursor)
+	case a.cursor >= p.viewport.YOffset()+height:
+		p.viewport.SetYOffset(a.cursor - height + 1)
+	}
+}
+
+// renderAnnotate lays out the annotated lines like a blame: the change of
+// each run of lines from the same change, the line number, and the content,
+// the line under the cursor pointed at.
+func (p *DiffPanel) renderAnnotate() {
+	a := p.annotation
+
+	idWidth, numberWidth := 0, 0
+	for _, line := range a.lines {
+		idWidth = max(idWidth, len(line.ChangeID))
+		numberWidth = max(numberWidth, len(strconv.Itoa(line.LineNumber)))
+	}
+
+	rows := make([]string, len(a.lines))
+
+	for i, line := range a.lines {
+		cursor := "  "
+		if i == a.cursor {
+			cursor = p.styles.Cursor()
+		}
+
+		changeID := strings.Repeat(" ", idWidth)
+		if i == 0 || a.lines[i-1].ChangeID != line.ChangeID || i == a.cursor {
+			changeID = p.styles.ShortCode.Render(fmt.Sprintf("%-*s", idWidth, line.ChangeID))
+		}
+
+		number := p.styles.Dim.Render(fmt.Sprintf("%*d", numberWidth, line.LineNumber))
+		rows[i] = cursor + changeID + " " + number + " " + line.Content
+	}
+
+	p.viewport.SetContent(strings.Join(rows, "\n"))
+}
+
+// annotateBindings returns the keybindings shown while annotating.
+func annotateBindings() []help.Binding {
+	return []help.Binding{
+		{
+			Key:      key.NewBinding(key.WithKeys("j", "k"), key.WithHelp("j/k", "next/prev line")),
+			Category: help.CategoryNavigation,
+			Order:    PanelOrderPrimary,
+		},
+		{
+			Key:      key.NewBinding(key.WithKeys("enter"), key.WithHelp("⏎", "go to change")),
+			Category: help.CategoryDiff,
+			Order:    PanelOrderPrimary,
+		},
+		{
+			Key:      key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "close annotation")),
+			Category: help.CategoryDiff,
+			Order:    PanelOrderSecondary,
+		},
+	}
+}
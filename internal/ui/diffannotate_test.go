@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"pgregory.net/rapid"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// annotateTestLines are five lines from two changes.
+var annotateTestLines = []jj.AnnotatedLine{
+	{ChangeID: "qpvuntsm", LineNumber: 1, Content: "package main"},
+	{ChangeID: "qpvuntsm", LineNumber: 2, Content: ""},
+	{ChangeID: "zsuskuln", LineNumber: 3, Content: "func run() {"},
+	{ChangeID: "zsuskuln", LineNumber: 4, Content: "}"},
+	{ChangeID: "qpvuntsm", LineNumber: 5, Content: "// end"},
+}
+
+// newAnnotateTestPanel returns a focused panel annotating annotateTestLines
+// over a diff it was showing.
+func newAnnotateTestPanel(t *testing.T) DiffPanel {
+	t.Helper()
+
+	panel := NewDiffPanel(NewStyles())
+	panel.SetSize(80, 20)
+	panel.SetFocused(true)
+	panel.SetTitle("Diff")
+	panel.SetDiff("shown before")
+
+	if !panel.StartAnnotate("Annotate: main.go", annotateTestLines) {
+		t.Fatal("the file has lines to annotate")
+	}
+
+	return panel
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestAnnotate_ShowsChangePerRun(t *testing.T) {
+	panel := newAnnotateTestPanel(t)
+	view := StripANSI(panel.viewport.View())
+
+	if !strings.Contains(view, "→ qpvuntsm 1 package main") || !strings.Contains(view, "  zsuskuln 3 func run() {") {
+		t.Errorf("each run of lines should show its change, got:\n%s", view)
+	}
+
+	if strings.Count(view, "zsuskuln") != 1 {
+		t.Errorf("a change should show once per run of lines, got:\n%s", view)
+	}
+}
+
+func TestAnnotate_EnterGoesToChange(t *testing.T) {
+	panel := newAnnotateTestPanel(t)
+	pressKeys(&panel, "j", "j")
+
+	if got := panel.annotateStatus(); got != " [line 3 of 5]" {
+		t.Errorf("title status = %q", got)
+	}
+
+	cmd := panel.Update(enterKey)
+	if cmd == nil {
+		t.Fatal("enter should go to the line's change")
+	}
+
+	if msg, ok := cmd().(AnnotateSelectMsg); !ok || msg.ChangeID != "zsuskuln" {
+		t.Errorf("expected the third line's change, got %+v", msg)
+	}
+}
+
+func TestAnnotate_EscRestores(t *testing.T) {
+	panel := newAnnotateTestPanel(t)
+
+	if !panel.CapturesKey(enterKey) || panel.CapturesKey(spaceKey) {
+		t.Error("annotating should take its own keys and leave the rest")
+	}
+
+	panel.Update(escKey)
+
+	if panel.Annotating() || panel.Title() != "Diff" || panel.diffContent != "shown before" {
+		t.Errorf("esc should restore the diff, got %q: %q", panel.Title(), panel.diffContent)
+	}
+}
+
+func TestAnnotate_NothingToShow(t *testing.T) {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetDiff("shown before")
+
+	if panel.StartAnnotate("Annotate: empty.txt", nil) || panel.Previewing() {
+		t.Error("an empty file should leave the panel alone")
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: the cursor stays on a line and in view, whatever keys are pressed
+func TestAnnotate_CursorInView(t *testing.T) {
+	rapid.Check(t, func(rt *rapid.T) {
+		panel := NewDiffPanel(NewStyles())
+		panel.SetSize(80, rapid.IntRange(5, 30).Draw(rt, "height"))
+		panel.SetFocused(true)
+
+		count := rapid.IntRange(1, 100).Draw(rt, "lines")
+		lines := make([]jj.AnnotatedLine, count)
+
+		for i := range lines {
+			lines[i] = jj.AnnotatedLine{ChangeID: "qpvuntsm", LineNumber: i + 1}
+		}
+
+		panel.StartAnnotate("Annotate", lines)
+
+		for _, k := range rapid.SliceOf(rapid.SampledFrom([]string{"j", "k", "g", "G"})).Draw(rt, "keys") {
+			pressKeys(&panel, k)
+		}
+
+		cursor, top := panel.annotation.cursor, panel.viewport.YOffset()
+		if cursor < 0 || cursor >= count {
+			rt.Fatalf("cursor %d out of %d lines", cursor, count)
+		}
+
+		if cursor < top || cursor >= top+panel.viewport.Height() {
+			rt.Fatalf("cursor %d outside the view from %d, %d high", cursor, top, panel.viewport.Height())
+		}
+	})
+}
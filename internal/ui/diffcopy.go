@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui/help"
+)
+
+// gitHunkPrefix starts each hunk of a file in a git-format diff.
+const gitHunkPrefix = "@@"
+
+// CopyDiffMsg is sent by yh and yf with the plain text of the hunk or file
+// at the top of the diff, for the clipboard.
+type CopyDiffMsg struct {
+	What string // "hunk" or "file diff"
+	Text string
+}
+
+// copyContinuations are the copy commands, for the pending key popup.
+var copyContinuations = []help.Binding{
+	continuation("h", "yh", "copy hunk"),
+	continuation("f", "yf", "copy file diff"),
+}
+
+// copyable reports whether the panel shows files to copy from with yh and
+// yf, rather than file content.
+func (p *DiffPanel) copyable() bool {
+	return len(p.hunks) > 0 && p.contentPath == ""
+}
+
+// copyCmd runs the copy command y followed by key: the hunk (h) or file (f)
+// at the top of the view, under its file's header, without colors.
+func (p *DiffPanel) copyCmd(key string) tea.Cmd {
+	files := rawFiles(p.diffContent)
+	file := max(sort.Search(len(p.hunks), func(i int) bool { return p.hunks[i].StartLine > p.viewport.YOffset() })-1, 0)
+
+	if file >= len(files) {
+		return nil
+	}
+
+	var msg CopyDiffMsg
+
+	switch key {
+	case "h":
+		header, hunks := splitRawHunks(files[file])
+		hunk := min(p.hunkInFile(file), len(hunks)-1)
+		msg = CopyDiffMsg{What: "hunk", Text: strings.Join(append(header, hunks[hunk]...), "\n")}
+	case "f":
+		msg = CopyDiffMsg{What: "file diff", Text: strings.Join(files[file], "\n")}
+	default:
+		return nil
+	}
+
+	return func() tea.Msg { return msg }
+}
+
+// hunkInFile gives the hunk of file at the top of the view, counted from 0.
+func (p *DiffPanel) hunkInFile(file int) int {
+	top := p.viewport.YOffset()
+	start := p.hunks[file].StartLine
+	hunk := -1
+
+	for _, line := range p.hunkStarts {
+		if line >= start && line <= top && line <= p.hunks[file].EndLine {
+			hunk++
+		}
+	}
+
+	return max(hunk, 0)
+}
+
+// rawFiles splits diff output, in jj's color-words format or git's, into
+// the lines of each file, colors stripped.
+func rawFiles(content string) [][]string {
+	git := jj.IsGitDiff(content)
+
+	var files [][]string
+
+	for line := range strings.SplitSeq(StripANSI(content), "\n") {
+		starts := syntaxFileHeaderRe.MatchString(line)
+		if git {
+			starts = strings.HasPrefix(line, "diff --git ")
+		}
+
+		switch {
+		case starts:
+			files = append(files, []string{line})
+		case len(files) > 0:
+			files[len(files)-1] = append(files[len(files)-1], line)
+		}
+	}
+
+	for i, file := range files {
+		files[i] = trimTrailingBlank(file)
+	}
+
+	return files
+}
+
+// splitRawHunks splits the lines of one file into its header and hunks: a
+// git diff's hunks start at each @@ line, and color-words ones are
+// separated by jj's "..." lines.
+func splitRawHunks(file []string) (header []string, hunks [][]string) {
+	if len(file) == 0 {
+		return nil, [][]string{nil}
+	}
+
+	if !strings.HasPrefix(file[0], "diff --git ") {
+		header = file[:1]
+
+		hunk := []string{}
+		for _, line := range file[1:] {
+			if strings.TrimSpace(line) == diffHunkSeparator {
+				hunks = append(hunks, hunk)
+				hunk = []string{}
+
+				continue
+			}
+
+			hunk = append(hunk, line)
+		}
+
+		return header, append(hunks, hunk)
+	}
+
+	for i, line := range file {
+		if strings.HasPrefix(line, gitHunkPrefix) {
+			hunks = append(hunks, []string{line})
+			continue
+		}
+
+		if len(hunks) == 0 {
+			header = file[:i+1]
+		} else {
+			hunks[len(hunks)-1] = append(hunks[len(hunks)-1], line)
+		}
+	}
+
+	if len(hunks) == 0 {
+		hunks = [][]string{nil}
+	}
+
+	return header, hunks
+}
+
+// trimTrailingBlank drops the blank lines at the end of lines.
+func trimTrailingBlank(lines []string) []string {
+	for len(lines) > 1 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
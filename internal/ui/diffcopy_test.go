@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// copyTestGitDiff is a git diff of one file with two hunks.
+const copyTestGitDiff = `diff --git a/a.go b/a.go
+index 1234567..89abcde 100644
+--- a/a.go
++++ b/a.go
+@@ -1,2 +1,2 @@
+ package a
+-var x = 1
++var x = 2
+@@ -10,1 +10,1 @@
+-a
++b`
+
+// copyKeys types y and then key into a focused panel, returning the copy.
+func copyKeys(t *testing.T, panel *DiffPanel, key string) CopyDiffMsg {
+	t.Helper()
+
+	panel.Update(keyPress("y"))
+
+	cmd := panel.Update(keyPress(key))
+	if cmd == nil {
+		t.Fatalf("y%s should copy", key)
+	}
+
+	msg, ok := cmd().(CopyDiffMsg)
+	if !ok {
+		t.Fatalf("y%s should send a CopyDiffMsg", key)
+	}
+
+	return msg
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestDiffPanel_CopyHunk(t *testing.T) {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetSize(80, 5)
+	panel.SetFocused(true)
+	panel.SetDiff("Commit ID: 1234\n" + positionTestDiff)
+	panel.viewport.SetYOffset(4)
+
+	got := copyKeys(t, &panel, "h")
+	want := "Modified regular file a.go:\n  10   10: func a() {}\n  11     : // old\n       11: // new"
+
+	if got.What != "hunk" || got.Text != want {
+		t.Errorf("yh copied %s %q, want %q", got.What, got.Text, want)
+	}
+}
+
+func TestDiffPanel_CopyFile(t *testing.T) {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetSize(80, 5)
+	panel.SetFocused(true)
+	panel.SetDiff("Commit ID: 1234\n" + positionTestDiff)
+	panel.viewport.SetYOffset(8)
+
+	got := copyKeys(t, &panel, "f")
+	if got.What != "file diff" || got.Text != "Added regular file b.go:\n        1: package b" {
+		t.Errorf("yf copied %s %q", got.What, got.Text)
+	}
+}
+
+func TestDiffPanel_CopyGitHunk(t *testing.T) {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetSize(80, 5)
+	panel.SetFocused(true)
+	panel.SetDiff(copyTestGitDiff)
+	panel.JumpHunk(2)
+
+	got := copyKeys(t, &panel, "h")
+	want := "diff --git a/a.go b/a.go\nindex 1234567..89abcde 100644\n--- a/a.go\n+++ b/a.go\n@@ -10,1 +10,1 @@\n-a\n+b"
+
+	if got.Text != want {
+		t.Errorf("yh copied %q, want the git hunk under its header %q", got.Text, want)
+	}
+}
+
+func TestDiffPanel_CopyNeedsFiles(t *testing.T) {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetDiff("Working copy changes:\nM a.go")
+
+	if panel.CapturesKey(keyPress("y")) {
+		t.Error("y should be left alone when there is no file to copy")
+	}
+
+	panel.SetDiff(positionTestDiff)
+
+	if !panel.CapturesKey(keyPress("y")) {
+		t.Error("y should begin yh and yf over a diff")
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Splitting a file into its hunks loses none of its lines.
+func TestSplitRawHunks_KeepsLinesProperty(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		hunks := rapid.IntRange(1, 5).Draw(t, "hunks")
+		file := []string{"Modified regular file a.go:"}
+
+		for i := range hunks {
+			if i > 0 {
+				file = append(file, "    "+diffHunkSeparator)
+			}
+
+			for j := range rapid.IntRange(1, 4).Draw(t, fmt.Sprintf("lines%d", i)) {
+				file = append(file, fmt.Sprintf("%4d %4d: line", j, j))
+			}
+		}
+
+		header, split := splitRawHunks(file)
+		if len(split) != hunks {
+			t.Fatalf("expected %d hunks, got %d", hunks, len(split))
+		}
+
+		lines := len(header) + hunks - 1
+		for _, hunk := range split {
+			lines += len(hunk)
+		}
+
+		if lines != len(file) {
+			t.Fatalf("split %d lines into %d:\n%s", len(file), lines, strings.Join(file, "\n"))
+		}
+	})
+}
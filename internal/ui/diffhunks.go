@@ -0,0 +1,280 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui/help"
+)
+
+// hunkPickKeys are the keys updateHunkPick handles, which it takes ahead of
+// any global binding.
+var hunkPickKeys = map[string]bool{
+	"j": true, "down": true, "}": true, "k": true, "up": true, "{": true,
+	"g": true, "G": true, "space": true, "a": true, "enter": true, "esc": true,
+}
+
+// HunkPickMsg is sent when the hunks marked while picking are confirmed.
+type HunkPickMsg struct{}
+
+// hunkPick is the state of picking hunks: the files of the diff picked
+// from, every hunk in display order, and which are marked.
+type hunkPick struct {
+	files  []jj.FileDiff
+	hunks  []hunkRef
+	marked []bool
+	cursor int
+	starts []int // display line of each hunk's header
+}
+
+// hunkRef locates a hunk within hunkPick.files.
+type hunkRef struct {
+	file, hunk int
+}
+
+// StartHunkPick shows the hunks of a git-format diff under title, in place
+// of the current content until StopHunkPick, for marking which to act on.
+// Renamed and copied files are left out, as their hunks don't apply to one
+// path. Returns false, leaving the panel alone, if there is no hunk to pick.
+func (p *DiffPanel) StartHunkPick(title, diff string) bool {
+	_, files := jj.ParseGitDiff(diff)
+	pick := &hunkPick{}
+
+	for _, file := range files {
+		if file.Status == jj.FileRenamed || file.Status == jj.FileCopied || len(file.Hunks) == 0 {
+			continue
+		}
+
+		for i := range file.Hunks {
+			pick.hunks = append(pick.hunks, hunkRef{file: len(pick.files), hunk: i})
+		}
+
+		pick.files = append(pick.files, file)
+	}
+
+	if len(pick.hunks) == 0 {
+		return false
+	}
+
+	pick.marked = make([]bool, len(pick.hunks))
+
+	p.ShowOutput(title, diff)
+	p.pick = pick
+	p.renderHunkPick()
+	p.viewport.GotoTop()
+
+	return true
+}
+
+// StopHunkPick leaves hunk picking and restores the content it replaced.
+func (p *DiffPanel) StopHunkPick() {
+	p.ClosePreview()
+}
+
+// PickingHunks reports whether hunks are being picked.
+func (p *DiffPanel) PickingHunks() bool {
+	return p.pick != nil
+}
+
+// PickedHunks returns the files with marked hunks, each with just those.
+func (p *DiffPanel) PickedHunks() []jj.FileDiff {
+	if p.pick == nil {
+		return nil
+	}
+
+	var picked []jj.FileDiff
+
+	for i, ref := range p.pick.hunks {
+		if !p.pick.marked[i] {
+			continue
+		}
+
+		file := p.pick.files[ref.file]
+		if len(picked) == 0 || picked[len(picked)-1].Path != file.Path {
+			picked = append(picked, jj.FileDiff{Path: file.Path, Status: file.Status})
+		}
+
+		last := &picked[len(picked)-1]
+		last.Hunks = append(last.Hunks, file.Hunks[ref.hunk])
+	}
+
+	return picked
+}
+
+// pickStatus describes the marked hunks for the panel title.
+func (p *DiffPanel) pickStatus() string {
+	marked := 0
+
+	for _, m := range p.pick.marked {
+		if m {
+			marked++
+		}
+	}
+
+	return fmt.Sprintf(" [%d of %d hunks marked]", marked, len(p.pick.hunks))
+}
+
+// updateHunkPick handles a key while picking hunks: move between hunks, mark
+// them, confirm with enter, or stop with esc.
+func (p *DiffPanel) updateHunkPick(msg tea.KeyMsg) tea.Cmd {
+	pick := p.pick
+
+	count, handled := p.Navigate(pickedHunks{p}, msg)
+	if handled {
+		return nil
+	}
+
+	switch msg.String() {
+	case "}":
+		p.selectHunk(pick.cursor + count)
+	case "{":
+		p.selectHunk(pick.cursor - count)
+	case "space":
+		pick.marked[pick.cursor] = !pick.marked[pick.cursor]
+		p.renderHunkPick()
+	case "a":
+		// Mark every hunk, or clear the marks once all are marked
+		all := !allMarked(pick.marked)
+		for i := range pick.marked {
+			pick.marked[i] = all
+		}
+
+		p.renderHunkPick()
+	case "enter":
+		if len(p.PickedHunks()) == 0 {
+			return nil
+		}
+
+		return func() tea.Msg { return HunkPickMsg{} }
+	case "esc":
+		p.StopHunkPick()
+	}
+
+	return nil
+}
+
+// allMarked reports whether every hunk is marked.
+func allMarked(marked []bool) bool {
+	for _, m := range marked {
+		if !m {
+			return false
+		}
+	}
+
+	return true
+}
+
+// pickedHunks moves between the hunks being picked with the shared
+// navigation keys.
+type pickedHunks struct{ p *DiffPanel }
+
+// MoveBy moves the cursor by rows, up when negative.
+func (h pickedHunks) MoveBy(rows int) {
+	h.p.selectHunk(h.p.pick.cursor + rows)
+}
+
+// GotoTop moves to the first hunk.
+func (h pickedHunks) GotoTop() {
+	h.p.selectHunk(0)
+}
+
+// GotoBottom moves to the last hunk.
+func (h pickedHunks) GotoBottom() {
+	h.p.selectHunk(len(h.p.pick.hunks) - 1)
+}
+
+// PageRows returns how many hunks one screen shows, going by their average
+// height.
+func (h pickedHunks) PageRows() int {
+	return entriesPerPage(h.p.viewport.Height(), len(h.p.pick.hunks), h.p.viewport.TotalLineCount())
+}
+
+// selectHunk moves the cursor to hunk i, within bounds, and scrolls to it;
+// a file's first hunk scrolls to the file's header too.
+func (p *DiffPanel) selectHunk(i int) {
+	pick := p.pick
+	pick.cursor = min(max(i, 0), len(pick.hunks)-1)
+	p.renderHunkPick()
+
+	offset := pick.starts[pick.cursor]
+	if pick.hunks[pick.cursor].hunk == 0 {
+		offset--
+	}
+
+	p.viewport.SetYOffset(offset)
+}
+
+// renderHunkPick lays out the hunks being picked like a git diff, each
+// headed by its mark and the lines it spans, the one under the cursor
+// pointed at.
+func (p *DiffPanel) renderHunkPick() {
+	pick := p.pick
+	pick.starts = pick.starts[:0]
+
+	var lines []string
+
+	for i, ref := range pick.hunks {
+		file := pick.files[ref.file]
+		if ref.hunk == 0 {
+			lines = append(lines, fmt.Sprintf("%s regular file %s:", gitDiffStatusWord(file.Status), file.Path))
+		}
+
+		cursor := "  "
+		if i == pick.cursor {
+			cursor = p.styles.Cursor()
+		}
+
+		mark := "[ ]"
+		if pick.marked[i] {
+			mark = p.styles.ShortCode.Render("[x]")
+		}
+
+		hunk := file.Hunks[ref.hunk]
+		oldCount, newCount := hunk.Counts()
+
+		pick.starts = append(pick.starts, len(lines))
+		lines = append(lines, cursor+mark+" "+p.styles.Dim.Render(
+			fmt.Sprintf("@@ -%d,%d +%d,%d @@", hunk.OldStart, oldCount, hunk.NewStart, newCount)))
+
+		for _, line := range hunk.Lines {
+			lines = append(lines, renderGitDiffLine(line, p.styles))
+		}
+	}
+
+	p.viewport.SetContent(strings.Join(lines, "\n"))
+}
+
+// hunkPickBindings returns the keybindings shown while picking hunks.
+func hunkPickBindings() []help.Binding {
+	return []help.Binding{
+		{
+			Key:      key.NewBinding(key.WithKeys("j", "k"), key.WithHelp("j/k", "next/prev hunk")),
+			Category: help.CategoryNavigation,
+			Order:    PanelOrderPrimary,
+		},
+		{
+			Key:      key.NewBinding(key.WithKeys("space"), key.WithHelp("space", "mark hunk")),
+			Category: help.CategoryDiff,
+			Order:    PanelOrderPrimary,
+		},
+		{
+			Key:      key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "mark all/none")),
+			Category: help.CategoryDiff,
+			Order:    PanelOrderSecondary,
+		},
+		{
+			Key:      key.NewBinding(key.WithKeys("enter"), key.WithHelp("⏎", "act on marked")),
+			Category: help.CategoryDiff,
+			Order:    PanelOrderPrimary,
+		},
+		{
+			Key:      key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "stop picking")),
+			Category: help.CategoryDiff,
+			Order:    PanelOrderSecondary,
+		},
+	}
+}
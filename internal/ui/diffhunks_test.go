@@ -0,0 +1,189 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"pgregory.net/rapid"
+)
+
+// hunkPickTestDiff has a file with two hunks, an added file, and a rename
+// whose hunk can't be picked.
+const hunkPickTestDiff = `diff --git a/list.txt b/list.txt
+--- a/list.txt
++++ b/list.txt
+@@ -1,3 +1,3 @@
+ one
+-two
++TWO
+ three
+@@ -8,3 +8,3 @@
+ eight
+-nine
++NINE
+ ten
+diff --git a/new.txt b/new.txt
+new file mode 100644
+--- /dev/null
++++ b/new.txt
+@@ -0,0 +1,1 @@
++hello
+diff --git a/old.txt b/moved.txt
+rename from old.txt
+rename to moved.txt
+--- a/old.txt
++++ b/moved.txt
+@@ -1,1 +1,1 @@
+-a
++b
+`
+
+var (
+	spaceKey = tea.KeyPressMsg{Code: tea.KeySpace, Text: " "}
+	enterKey = tea.KeyPressMsg{Code: tea.KeyEnter}
+	escKey   = tea.KeyPressMsg{Code: tea.KeyEscape}
+)
+
+// newHunkPickTestPanel returns a focused panel picking hunkPickTestDiff's
+// hunks over a diff it was showing.
+func newHunkPickTestPanel(t *testing.T) DiffPanel {
+	t.Helper()
+
+	panel := NewDiffPanel(NewStyles())
+	panel.SetSize(80, 20)
+	panel.SetFocused(true)
+	panel.SetTitle("Diff")
+	panel.SetDiff("shown before")
+
+	if !panel.StartHunkPick("Pick hunks: xsssnyux", hunkPickTestDiff) {
+		t.Fatal("the diff has hunks to pick")
+	}
+
+	return panel
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestHunkPick_MarksHunks(t *testing.T) {
+	panel := newHunkPickTestPanel(t)
+
+	if len(panel.pick.hunks) != 3 {
+		t.Fatalf("the renamed file's hunk should be left out, got %d hunks", len(panel.pick.hunks))
+	}
+
+	panel.Update(spaceKey)
+	pressKeys(&panel, "j", "j")
+	panel.Update(spaceKey)
+
+	picked := panel.PickedHunks()
+	if len(picked) != 2 || picked[0].Path != "list.txt" || picked[1].Path != "new.txt" {
+		t.Fatalf("expected the first hunk of list.txt and new.txt, got %+v", picked)
+	}
+
+	if len(picked[0].Hunks) != 1 || picked[0].Hunks[0].OldStart != 1 {
+		t.Errorf("only the marked hunk should be picked, got %+v", picked[0].Hunks)
+	}
+
+	if got := panel.pickStatus(); got != " [2 of 3 hunks marked]" {
+		t.Errorf("title status = %q", got)
+	}
+
+	if !strings.Contains(StripANSI(panel.viewport.View()), "[x] @@ -1,3 +1,3 @@") {
+		t.Errorf("marked hunks should show it, got:\n%s", panel.viewport.View())
+	}
+}
+
+func TestHunkPick_MarkAllAndConfirm(t *testing.T) {
+	panel := newHunkPickTestPanel(t)
+
+	if cmd := panel.Update(enterKey); cmd != nil {
+		t.Error("enter should do nothing with no hunk marked")
+	}
+
+	pressKeys(&panel, "a")
+
+	if picked := panel.PickedHunks(); len(picked) != 2 || len(picked[0].Hunks) != 2 {
+		t.Fatalf("a should mark every hunk, got %+v", picked)
+	}
+
+	cmd := panel.Update(enterKey)
+	if cmd == nil {
+		t.Fatal("enter should confirm the marked hunks")
+	}
+
+	if _, ok := cmd().(HunkPickMsg); !ok {
+		t.Error("expected a HunkPickMsg")
+	}
+
+	pressKeys(&panel, "a")
+
+	if picked := panel.PickedHunks(); len(picked) != 0 {
+		t.Errorf("a again should clear the marks, got %+v", picked)
+	}
+}
+
+func TestHunkPick_EscRestores(t *testing.T) {
+	panel := newHunkPickTestPanel(t)
+
+	if !panel.CapturesKey(spaceKey) || panel.CapturesKey(tea.KeyPressMsg{Code: 'q', Text: "q"}) {
+		t.Error("picking should take its own keys and leave the rest")
+	}
+
+	panel.Update(escKey)
+
+	if panel.PickingHunks() || panel.Title() != "Diff" || panel.diffContent != "shown before" {
+		t.Errorf("esc should restore the diff, got %q: %q", panel.Title(), panel.diffContent)
+	}
+}
+
+func TestHunkPick_NothingToPick(t *testing.T) {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetDiff("shown before")
+
+	if panel.StartHunkPick("Pick hunks", "Binary files differ\n") || panel.Previewing() {
+		t.Error("a diff without hunks should leave the panel alone")
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: the cursor stays on a hunk, and as many hunks are picked as are
+// marked, whatever keys are pressed
+func TestHunkPick_CursorAndMarksConsistent(t *testing.T) {
+	rapid.Check(t, func(rt *rapid.T) {
+		panel := newHunkPickTestPanel(t)
+
+		keys := rapid.SliceOf(rapid.SampledFrom([]string{"j", "k", "g", "G", "a", "space"})).Draw(rt, "keys")
+		for _, k := range keys {
+			if k == "space" {
+				panel.Update(spaceKey)
+			} else {
+				pressKeys(&panel, k)
+			}
+		}
+
+		if panel.pick.cursor < 0 || panel.pick.cursor >= len(panel.pick.hunks) {
+			rt.Fatalf("cursor %d out of %d hunks", panel.pick.cursor, len(panel.pick.hunks))
+		}
+
+		marked, picked := 0, 0
+		for _, m := range panel.pick.marked {
+			if m {
+				marked++
+			}
+		}
+
+		for _, file := range panel.PickedHunks() {
+			picked += len(file.Hunks)
+		}
+
+		if marked != picked {
+			rt.Fatalf("%d hunks marked but %d picked", marked, picked)
+		}
+	})
+}
@@ -0,0 +1,245 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// diffHunkSeparator is the line jj prints between the hunks of a file in a
+// color-words diff, which renderGitDiff prints too.
+const diffHunkSeparator = "..."
+
+// FileJumpMsg is sent when ]f or [f moves past the last or first file of
+// the diff, by the files still to move (back when negative), so the files
+// list can go on to the next file's diff.
+type FileJumpMsg struct {
+	Files int
+}
+
+// keptScroll is a scroll position kept across a reload of the diff: the
+// header of the file at the top of the view and the lines below it, or the
+// offset alone above the first file.
+type keptScroll struct {
+	header string
+	offset int
+}
+
+// SetLineNumbers shows or hides the old and new line numbers in front of
+// each diff line.
+func (p *DiffPanel) SetLineNumbers(shown bool) {
+	p.hideLineNumbers = !shown
+	p.updateContent()
+}
+
+// LineNumbers reports whether diff lines show their line numbers.
+func (p *DiffPanel) LineNumbers() bool {
+	return !p.hideLineNumbers
+}
+
+// hideGutters drops the "old new: " line-number gutter from the diff lines
+// of content, after its first file header, keeping their colors.
+func hideGutters(content string) string {
+	lines := strings.Split(content, "\n")
+	inFiles := false
+
+	for i, line := range lines {
+		stripped := StripANSI(line)
+
+		if syntaxFileHeaderRe.MatchString(stripped) {
+			inFiles = true
+			continue
+		}
+
+		if !inFiles {
+			continue
+		}
+
+		if gutter := syntaxLinePrefixRe.FindString(stripped); gutter != "" {
+			lines[i] = dropVisible(line, utf8.RuneCountInString(gutter))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// dropVisible drops the first n visible runes of line, keeping its escape
+// sequences so the colors of the rest are unchanged.
+func dropVisible(line string, n int) string {
+	var out strings.Builder
+
+	for i := 0; i < len(line); {
+		if line[i] == '\x1b' {
+			if seq := ansiRe.FindString(line[i:]); seq != "" {
+				out.WriteString(seq)
+				i += len(seq)
+
+				continue
+			}
+		}
+
+		_, size := utf8.DecodeRuneInString(line[i:])
+		if n > 0 {
+			n--
+		} else {
+			out.WriteString(line[i : i+size])
+		}
+
+		i += size
+	}
+
+	return out.String()
+}
+
+// addHunkStarts records the shown lines the hunks of a file start on: its
+// header for the first, and jj's separator for each after. The hunks of a
+// folded file all start on its header, shown at start.
+func (p *DiffPanel) addHunkStarts(section []string, start int, folded bool) {
+	p.hunkStarts = append(p.hunkStarts, start)
+
+	for i, line := range section {
+		if strings.TrimSpace(StripANSI(line)) != diffHunkSeparator {
+			continue
+		}
+
+		if folded {
+			p.hunkStarts = append(p.hunkStarts, start)
+		} else {
+			p.hunkStarts = append(p.hunkStarts, start+i)
+		}
+	}
+}
+
+// formatStatus names the diff format for the panel title, when showing a
+// diff rather than file content or a preview.
+func (p *DiffPanel) formatStatus() string {
+	if p.format == "" || p.previewing || p.contentPath != "" {
+		return ""
+	}
+
+	return " (" + p.format + ")"
+}
+
+// positionStatus gives the hunk and file at the top of the view for the
+// panel title, or the counts of each above the first file.
+func (p *DiffPanel) positionStatus() string {
+	if len(p.hunks) == 0 || p.contentPath != "" {
+		return ""
+	}
+
+	top := p.viewport.YOffset()
+
+	hunk := sort.Search(len(p.hunkStarts), func(i int) bool { return p.hunkStarts[i] > top })
+	file := sort.Search(len(p.hunks), func(i int) bool { return p.hunks[i].StartLine > top })
+
+	if file == 0 {
+		return fmt.Sprintf(" [%d hunks · %d files]", len(p.hunkStarts), len(p.hunks))
+	}
+
+	return fmt.Sprintf(" [hunk %d/%d · file %d/%d]", hunk, len(p.hunkStarts), file, len(p.hunks))
+}
+
+// JumpHunk scrolls to the start of the hunk hunks after the top of the
+// view, or before it when negative, stopping at the first and last.
+func (p *DiffPanel) JumpHunk(hunks int) {
+	if len(p.hunkStarts) == 0 || hunks == 0 {
+		return
+	}
+
+	top := p.viewport.YOffset()
+
+	var target int
+	if hunks > 0 {
+		target = sort.Search(len(p.hunkStarts), func(i int) bool { return p.hunkStarts[i] > top }) + hunks - 1
+	} else {
+		target = sort.Search(len(p.hunkStarts), func(i int) bool { return p.hunkStarts[i] >= top }) + hunks
+	}
+
+	if target < 0 {
+		p.GotoTop()
+		return
+	}
+
+	p.viewport.SetYOffset(p.hunkStarts[min(target, len(p.hunkStarts)-1)])
+	p.syncCurrentHunk()
+}
+
+// JumpFile moves by files, back when negative, skipping folded files as }
+// did. The files left to move past the last or first are sent on in a
+// FileJumpMsg.
+func (p *DiffPanel) JumpFile(files int) {
+	p.syncCurrentHunk()
+
+	for ; files > 0; files-- {
+		before := p.currentHunk
+		if p.NextHunk(); p.currentHunk == before {
+			p.fileJump = files
+			return
+		}
+	}
+
+	for ; files < 0; files++ {
+		if p.currentHunk == noHunkSelected || p.currentHunk == 0 && p.viewport.YOffset() <= p.hunks[0].StartLine {
+			p.fileJump = files
+			return
+		}
+
+		p.PrevHunk()
+	}
+}
+
+// fileJumpCmd sends on the files a jump had left to move, if any.
+func (p *DiffPanel) fileJumpCmd() tea.Cmd {
+	files := p.fileJump
+	if files == 0 {
+		return nil
+	}
+
+	p.fileJump = 0
+
+	return func() tea.Msg { return FileJumpMsg{Files: files} }
+}
+
+// KeepScroll keeps the scroll position for the next content set, so the
+// same diff re-run with other options stays on the same file and about the
+// same line rather than going back to the top.
+func (p *DiffPanel) KeepScroll() {
+	top := p.viewport.YOffset()
+	kept := &keptScroll{offset: top}
+
+	file := sort.Search(len(p.hunks), func(i int) bool { return p.hunks[i].StartLine > top })
+	if file > 0 {
+		hunk := p.hunks[file-1]
+		kept.header = hunk.Header
+		kept.offset = top - hunk.StartLine
+	}
+
+	p.keptScroll = kept
+}
+
+// restoreScroll scrolls back to a kept position, staying within its file
+// when that is still in the diff.
+func (p *DiffPanel) restoreScroll(kept *keptScroll) {
+	if kept == nil {
+		return
+	}
+
+	if kept.header == "" {
+		p.viewport.SetYOffset(kept.offset)
+		p.syncCurrentHunk()
+
+		return
+	}
+
+	for _, hunk := range p.hunks {
+		if hunk.Header == kept.header {
+			p.viewport.SetYOffset(min(hunk.StartLine+kept.offset, hunk.EndLine))
+			p.syncCurrentHunk()
+
+			return
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// positionTestDiff has two files, the first with two hunks.
+const positionTestDiff = `Modified regular file a.go:
+   1    1: package a
+   2    2: 
+    ...
+  10   10: func a() {}
+  11     : // old
+       11: // new
+Added regular file b.go:
+        1: package b`
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestHideGutters(t *testing.T) {
+	content := "Commit ID: 1234\n   2    2: kept above files\nModified regular file a.go:\n   1    1: package a\n" +
+		"  11     : \x1b[31m// old\x1b[39m\n    ...\n"
+
+	got := hideGutters(content)
+	want := "Commit ID: 1234\n   2    2: kept above files\nModified regular file a.go:\npackage a\n" +
+		"\x1b[31m// old\x1b[39m\n    ...\n"
+
+	if got != want {
+		t.Errorf("hideGutters() = %q, want %q", got, want)
+	}
+}
+
+func TestDiffPanel_SetLineNumbers(t *testing.T) {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetSize(80, 20)
+	panel.SetDiff(positionTestDiff)
+
+	if !panel.LineNumbers() || !strings.Contains(StripANSI(panel.viewport.View()), "1    1: package a") {
+		t.Fatal("line numbers should show by default")
+	}
+
+	panel.SetLineNumbers(false)
+
+	view := StripANSI(panel.viewport.View())
+	if strings.Contains(view, "1: package a") || !strings.Contains(view, "package a") {
+		t.Errorf("hidden line numbers should leave just the lines: %q", view)
+	}
+}
+
+func TestDiffPanel_PositionStatus(t *testing.T) {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetSize(80, 5)
+	panel.SetDiff("Commit ID: 1234\n" + positionTestDiff)
+
+	tests := []struct {
+		offset int
+		want   string
+	}{
+		{0, " [3 hunks · 2 files]"},
+		{1, " [hunk 1/3 · file 1/2]"},
+		{4, " [hunk 2/3 · file 1/2]"},
+		{8, " [hunk 3/3 · file 2/2]"},
+	}
+
+	for _, tt := range tests {
+		panel.viewport.SetYOffset(tt.offset)
+
+		if got := panel.positionStatus(); got != tt.want {
+			t.Errorf("at line %d, positionStatus() = %q, want %q", tt.offset, got, tt.want)
+		}
+	}
+}
+
+func TestDiffPanel_PositionStatusFolded(t *testing.T) {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetSize(80, 5)
+	panel.SetDiff(positionTestDiff)
+	panel.NextHunk()
+	panel.ToggleSection()
+	panel.viewport.SetYOffset(1)
+
+	if got := panel.positionStatus(); got != " [hunk 3/3 · file 2/2]" {
+		t.Errorf("a folded file's hunks should still count, got %q", got)
+	}
+}
+
+func TestDiffPanel_JumpFilePastEnds(t *testing.T) {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetSize(80, 5)
+	panel.SetFocused(true)
+	panel.SetDiff(positionTestDiff)
+
+	if cmd := panel.Update(keyPress("]")); cmd != nil {
+		t.Fatal("] should wait for the f")
+	}
+
+	if cmd := panel.Update(keyPress("f")); cmd != nil || panel.viewport.YOffset() != 7 {
+		t.Fatalf("]f should move to the second file, at line %d", panel.viewport.YOffset())
+	}
+
+	panel.Update(keyPress("3"))
+	panel.Update(keyPress("]"))
+
+	cmd := panel.Update(keyPress("f"))
+	if cmd == nil {
+		t.Fatal("3]f from the last file should hand the rest to the files list")
+	}
+
+	if msg, ok := cmd().(FileJumpMsg); !ok || msg.Files != 3 {
+		t.Errorf("expected a jump of 3 files on, got %#v", cmd())
+	}
+
+	panel.GotoTop()
+	panel.Update(keyPress("["))
+
+	if msg, ok := panel.Update(keyPress("f"))().(FileJumpMsg); !ok || msg.Files != -1 {
+		t.Errorf("[f from the first file should hand a jump of one back on, got %#v", msg)
+	}
+}
+
+func TestDiffPanel_FormatInTitle(t *testing.T) {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetSize(80, 10)
+	panel.SetFormat("git")
+	panel.SetDiff(positionTestDiff)
+
+	if got := panel.formatStatus(); got != " (git)" {
+		t.Errorf("a diff's title should name its format, got %q", got)
+	}
+
+	panel.ShowOutput("Status", "Working copy changes:")
+
+	if got := panel.formatStatus(); got != "" {
+		t.Errorf("output in place of the diff should not name a diff format, got %q", got)
+	}
+}
+
+func TestDiffPanel_KeepScroll(t *testing.T) {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetSize(80, 5)
+	panel.SetDiff("Commit ID: 1234\n" + positionTestDiff)
+	panel.viewport.SetYOffset(4)
+
+	panel.KeepScroll()
+	panel.SetDiff("Commit ID: 1234\nAuthor: someone\n" + positionTestDiff)
+
+	if got := panel.viewport.YOffset(); got != 5 {
+		t.Errorf("a kept scroll should stay as far into a.go, offset = %d, want 5", got)
+	}
+
+	panel.SetDiff("Commit ID: 5678\n" + positionTestDiff)
+
+	if got := panel.viewport.YOffset(); got != 0 {
+		t.Errorf("a scroll should be kept for one reload only, offset = %d", got)
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: dropping visible runes keeps every escape sequence and the rest
+// of the text
+func TestDropVisible_KeepsEscapes(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		parts := rapid.SliceOf(rapid.SampledFrom([]string{"a", "é", " ", ":", "\x1b[31m", "\x1b[39m", "\x1b[1;32m"})).Draw(t, "parts")
+		line := strings.Join(parts, "")
+		n := rapid.IntRange(0, len(parts)).Draw(t, "n")
+
+		got := dropVisible(line, n)
+
+		plain := []rune(StripANSI(line))
+		if want := string(plain[min(n, len(plain)):]); StripANSI(got) != want {
+			t.Fatalf("dropVisible(%q, %d) left %q, want %q", line, n, StripANSI(got), want)
+		}
+
+		if escapes := strings.Join(ansiRe.FindAllString(line, -1), ""); strings.Join(ansiRe.FindAllString(got, -1), "") != escapes {
+			t.Fatalf("dropVisible(%q, %d) = %q lost escape sequences", line, n, got)
+		}
+	})
+}
@@ -0,0 +1,331 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+)
+
+const (
+	// Reverse video marks matches without disturbing the diff's own colors,
+	// which an ordinary style's trailing reset would cut short.
+	matchOn  = "\x1b[7m"
+	matchOff = "\x1b[27m"
+
+	// The current match is underlined as well.
+	currentMatchOn  = "\x1b[7;4m"
+	currentMatchOff = "\x1b[27;24m"
+
+	// searchPrompt precedes the query while it is typed.
+	searchPrompt = "/"
+)
+
+// searchMatch is one occurrence of the search query in the shown diff.
+type searchMatch struct {
+	line       int // display line
+	start, end int // byte offsets within the line without ANSI codes
+}
+
+// newSearchInput creates the one-line input for the search query.
+func newSearchInput() textinput.Model {
+	input := textinput.New()
+	input.Prompt = searchPrompt
+
+	return input
+}
+
+// StartSearch opens the search prompt. Matches are shown as the query is typed.
+func (p *DiffPanel) StartSearch() tea.Cmd {
+	p.searching = true
+	p.searchOrigin = p.viewport.YOffset()
+	p.searchInput.SetValue("")
+
+	return p.searchInput.Focus()
+}
+
+// Searching reports whether the search prompt is open.
+func (p *DiffPanel) Searching() bool {
+	return p.searching
+}
+
+// SearchQuery returns the text being searched for, or "" when there is none.
+func (p *DiffPanel) SearchQuery() string {
+	return p.searchQuery
+}
+
+// SearchMatches returns the position of the current match (1-based, 0 when
+// none is selected) and the number of matches.
+func (p *DiffPanel) SearchMatches() (current, total int) {
+	return p.currentMatch + 1, len(p.matches)
+}
+
+// CapturesKey reports whether the panel should receive msg ahead of any
+// global binding: while a two-key command or the search prompt waits for
+// input, n/N while there are matches to move between, ] and [ while files
+// are shown, and the keys of hunk picking and annotation.
+func (p *DiffPanel) CapturesKey(msg tea.KeyMsg) bool {
+	if p.pick != nil {
+		return hunkPickKeys[msg.String()]
+	}
+
+	if p.annotation != nil {
+		return annotateKeys[msg.String()]
+	}
+
+	if p.KeyPending() || p.searching {
+		return true
+	}
+
+	switch msg.String() {
+	case "n", "N":
+		return p.searchQuery != ""
+	case "]", "[":
+		return len(p.hunks) > 0 || p.contentPath != ""
+	case "h", "l":
+		return p.noWrap
+	case "y":
+		return p.copyable()
+	}
+
+	return false
+}
+
+// NextMatch scrolls to the next match, wrapping to the first.
+func (p *DiffPanel) NextMatch() {
+	if len(p.matches) == 0 {
+		return
+	}
+
+	p.jumpToMatch((p.currentMatch + 1) % len(p.matches))
+}
+
+// PrevMatch scrolls to the previous match, wrapping to the last.
+func (p *DiffPanel) PrevMatch() {
+	if len(p.matches) == 0 {
+		return
+	}
+
+	prev := p.currentMatch - 1
+	if prev < 0 {
+		prev = len(p.matches) - 1
+	}
+
+	p.jumpToMatch(prev)
+}
+
+// updateSearch handles a key typed into the search prompt: enter keeps the
+// query, esc drops it, and anything else edits it and jumps to the first
+// match at or below where the search began.
+func (p *DiffPanel) updateSearch(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter":
+		p.searching = false
+		p.searchInput.Blur()
+
+		return nil
+	case "esc":
+		p.searching = false
+		p.searchInput.Blur()
+		p.setSearchQuery("")
+		p.viewport.SetYOffset(p.searchOrigin)
+		p.syncCurrentHunk()
+
+		return nil
+	}
+
+	var cmd tea.Cmd
+	p.searchInput, cmd = p.searchInput.Update(msg)
+
+	if query := p.searchInput.Value(); query != p.searchQuery {
+		p.setSearchQuery(query)
+		p.jumpToMatch(p.firstMatchFrom(p.searchOrigin))
+	}
+
+	return cmd
+}
+
+// setSearchQuery finds and highlights the matches of query in the shown diff.
+func (p *DiffPanel) setSearchQuery(query string) {
+	p.searchQuery = query
+	p.findMatches()
+	p.showMatches()
+}
+
+// firstMatchFrom returns the first match on or below line, wrapping to the
+// first match, or -1 when there are none.
+func (p *DiffPanel) firstMatchFrom(line int) int {
+	for i, match := range p.matches {
+		if match.line >= line {
+			return i
+		}
+	}
+
+	if len(p.matches) > 0 {
+		return 0
+	}
+
+	return -1
+}
+
+// jumpToMatch makes match i current and scrolls it into view, with a little
+// context above. A negative i only clears the current match.
+func (p *DiffPanel) jumpToMatch(i int) {
+	p.currentMatch = i
+	p.showMatches()
+
+	if i < 0 {
+		return
+	}
+
+	p.viewport.SetYOffset(max(p.matches[i].line-ScrollPadding, 0))
+	p.syncCurrentHunk()
+}
+
+// findMatches locates the query in the shown lines, ignoring case and color.
+func (p *DiffPanel) findMatches() {
+	p.matches = nil
+	p.currentMatch = -1
+
+	if p.searchQuery == "" {
+		return
+	}
+
+	re := queryRegexp(p.searchQuery)
+
+	for i, line := range p.lines {
+		for _, match := range lineMatches(line, re) {
+			match.line = i
+			p.matches = append(p.matches, match)
+		}
+	}
+}
+
+// queryRegexp matches a search query literally, ignoring case.
+func queryRegexp(query string) *regexp.Regexp {
+	return regexp.MustCompile("(?i)" + regexp.QuoteMeta(query))
+}
+
+// lineMatches returns the matches of re in an ANSI-colored line, as offsets
+// into its visible text.
+func lineMatches(line string, re *regexp.Regexp) []searchMatch {
+	var matches []searchMatch
+	for _, loc := range re.FindAllStringIndex(StripANSI(line), -1) {
+		matches = append(matches, searchMatch{start: loc[0], end: loc[1]})
+	}
+
+	return matches
+}
+
+// showMatches sets the viewport to the shown lines with the matches marked.
+func (p *DiffPanel) showMatches() {
+	if len(p.matches) == 0 {
+		p.viewport.SetContent(strings.Join(p.lines, "\n"))
+		return
+	}
+
+	lines := make([]string, len(p.lines))
+	copy(lines, p.lines)
+
+	// Matches are in line order, so each line's run is contiguous
+	for first := 0; first < len(p.matches); {
+		line := p.matches[first].line
+
+		last := first
+		for last < len(p.matches) && p.matches[last].line == line {
+			last++
+		}
+
+		lines[line] = highlightMatches(p.lines[line], p.matches[first:last], p.currentMatch-first)
+		first = last
+	}
+
+	p.viewport.SetContent(strings.Join(lines, "\n"))
+}
+
+// highlightMatches marks the matches in an ANSI-colored line, the one at
+// index current more strongly. Offsets count only the visible text.
+func highlightMatches(line string, matches []searchMatch, current int) string {
+	var out strings.Builder
+
+	visible := 0 // bytes of visible text copied so far
+	next := 0    // next match to open or close
+	open := false
+
+	on := func() string {
+		if next == current {
+			return currentMatchOn
+		}
+
+		return matchOn
+	}
+
+	off := func() string {
+		if next == current {
+			return currentMatchOff
+		}
+
+		return matchOff
+	}
+
+	for i := 0; i < len(line); {
+		if line[i] == '\x1b' {
+			if loc := ansiRe.FindStringIndex(line[i:]); loc != nil && loc[0] == 0 {
+				out.WriteString(line[i : i+loc[1]])
+				i += loc[1]
+
+				// A reset inside a match would end the marking early
+				if open {
+					out.WriteString(on())
+				}
+
+				continue
+			}
+		}
+
+		if open && visible == matches[next].end {
+			out.WriteString(off())
+
+			open = false
+			next++
+		}
+
+		if !open && next < len(matches) && visible == matches[next].start {
+			out.WriteString(on())
+
+			open = true
+		}
+
+		out.WriteByte(line[i])
+		visible++
+		i++
+	}
+
+	if open {
+		out.WriteString(off())
+	}
+
+	return out.String()
+}
+
+// searchStatus summarizes the matches for the panel title.
+func (p *DiffPanel) searchStatus() string {
+	return searchSummary(p.searchQuery, p.currentMatch+1, len(p.matches))
+}
+
+// searchSummary describes a search's matches for a panel title: the position
+// of the current match (1-based, 0 when none is selected) out of total.
+func searchSummary(query string, current, total int) string {
+	switch {
+	case query == "":
+		return ""
+	case total == 0:
+		return " [no matches]"
+	case current == 0:
+		return fmt.Sprintf(" [%d matches]", total)
+	}
+
+	return fmt.Sprintf(" [%d/%d]", current, total)
+}
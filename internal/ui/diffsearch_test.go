@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"pgregory.net/rapid"
+)
+
+// newSearchTestPanel returns a focused panel showing foldTestDiff.
+func newSearchTestPanel() DiffPanel {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetSize(80, 6)
+	panel.SetFocused(true)
+	panel.SetDiff(foldTestDiff)
+
+	return panel
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestDiffSearch_FindsAsYouType(t *testing.T) {
+	panel := newSearchTestPanel()
+
+	pressKeys(&panel, "/", "P", "a", "c", "k")
+
+	if !panel.Searching() {
+		t.Fatal("the prompt should stay open while typing")
+	}
+
+	if current, total := panel.SearchMatches(); current != 1 || total != 3 {
+		t.Errorf("expected match 1 of 3 ignoring case, got %d/%d", current, total)
+	}
+
+	if got := panel.searchStatus(); got != " [1/3]" {
+		t.Errorf("expected the count in the title, got %q", got)
+	}
+}
+
+func TestDiffSearch_NextAndPrevWrap(t *testing.T) {
+	panel := newSearchTestPanel()
+	pressKeys(&panel, "/", "p", "a", "c", "k", "a", "g", "e")
+	panel.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	pressKeys(&panel, "n", "n")
+
+	if current, _ := panel.SearchMatches(); current != 3 {
+		t.Fatalf("expected the third match, got %d", current)
+	}
+
+	if offset := panel.viewport.YOffset(); offset == 0 {
+		t.Error("jumping to a lower match should scroll")
+	}
+
+	pressKeys(&panel, "n")
+
+	if current, _ := panel.SearchMatches(); current != 1 {
+		t.Errorf("n should wrap to the first match, got %d", current)
+	}
+
+	pressKeys(&panel, "N")
+
+	if current, _ := panel.SearchMatches(); current != 3 {
+		t.Errorf("N should wrap to the last match, got %d", current)
+	}
+}
+
+func TestDiffSearch_EscClears(t *testing.T) {
+	panel := newSearchTestPanel()
+	pressKeys(&panel, "/", "b")
+	panel.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+
+	if panel.Searching() || panel.SearchQuery() != "" {
+		t.Error("esc should close the prompt and drop the query")
+	}
+
+	if _, total := panel.SearchMatches(); total != 0 {
+		t.Errorf("expected no matches, got %d", total)
+	}
+}
+
+func TestDiffSearch_NoMatches(t *testing.T) {
+	panel := newSearchTestPanel()
+	pressKeys(&panel, "/", "x", "y", "z")
+
+	if got := panel.searchStatus(); got != " [no matches]" {
+		t.Errorf("expected no matches in the title, got %q", got)
+	}
+}
+
+func TestDiffSearch_CapturesKeys(t *testing.T) {
+	panel := newSearchTestPanel()
+	n := tea.KeyPressMsg{Code: 'n', Text: "n"}
+
+	if panel.CapturesKey(n) {
+		t.Error("n should be left to global bindings without a search")
+	}
+
+	pressKeys(&panel, "/", "a")
+
+	if !panel.CapturesKey(tea.KeyPressMsg{Code: 'q', Text: "q"}) {
+		t.Error("the prompt should take every key while open")
+	}
+
+	panel.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	if !panel.CapturesKey(n) {
+		t.Error("n should move between matches while there is a query")
+	}
+}
+
+func TestHighlightMatches_KeepsColors(t *testing.T) {
+	line := "\x1b[32mpackage\x1b[0m main"
+	got := highlightMatches(line, []searchMatch{{start: 4, end: 11}}, -1)
+
+	want := "\x1b[32mpack" + matchOn + "age\x1b[0m" + matchOn + " mai" + matchOff + "n"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+func TestHighlightMatches_TextUnchanged(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		line := rapid.StringMatching(`(\x1b\[3[0-7]m|\x1b\[0m|[a-z ]){0,30}`).Draw(t, "line")
+		text := StripANSI(line)
+
+		var matches []searchMatch
+
+		for pos := 0; pos < len(text); {
+			start := rapid.IntRange(pos, len(text)).Draw(t, "start")
+			if start == len(text) {
+				break
+			}
+
+			end := rapid.IntRange(start+1, len(text)).Draw(t, "end")
+			matches = append(matches, searchMatch{start: start, end: end})
+			pos = end
+		}
+
+		got := highlightMatches(line, matches, rapid.IntRange(-1, len(matches)).Draw(t, "current"))
+
+		if StripANSI(got) != text {
+			t.Fatalf("marking matches changed the text: %q became %q", text, StripANSI(got))
+		}
+	})
+}
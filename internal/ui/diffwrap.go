@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"charm.land/bubbles/v2/key"
+
+	"github.com/chatter/chado/internal/ui/help"
+)
+
+// scrollColumns is the number of columns h and l scroll when lines don't
+// wrap.
+const scrollColumns = 8
+
+// SetWrap wraps long lines to the panel's width, or leaves them running
+// past it to be scrolled sideways.
+func (p *DiffPanel) SetWrap(wrap bool) {
+	p.noWrap = !wrap
+	p.viewport.SetXOffset(0)
+	p.updateContent()
+}
+
+// Wrap reports whether long lines wrap to the panel's width.
+func (p *DiffPanel) Wrap() bool {
+	return !p.noWrap
+}
+
+// ScrollColumns scrolls unwrapped lines right by columns, or left when
+// negative. Wrapped lines have nothing to scroll to.
+func (p *DiffPanel) ScrollColumns(columns int) {
+	if !p.noWrap {
+		return
+	}
+
+	p.viewport.SetXOffset(p.viewport.XOffset() + columns)
+}
+
+// wrapBindings are the help bindings for scrolling unwrapped lines.
+func (p *DiffPanel) wrapBindings() []help.Binding {
+	if !p.noWrap {
+		return nil
+	}
+
+	return []help.Binding{{
+		Key:      key.NewBinding(key.WithKeys("h", "l"), key.WithHelp("h/l", "scroll left/right")),
+		Category: help.CategoryNavigation,
+		Order:    PanelOrderSecondary,
+	}}
+}
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// wrapTestDiff has a line longer than the test panel is wide.
+const wrapTestDiff = "Added regular file a.go:\n        1: " +
+	"var table = []string{\"alpha\", \"beta\", \"gamma\", \"delta\", \"epsilon\", \"zeta\"}"
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestDiffPanel_UnwrappedLinesScroll(t *testing.T) {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetSize(40, 10)
+	panel.SetDiff(wrapTestDiff)
+
+	wrapped := panel.viewport.TotalLineCount()
+
+	panel.SetWrap(false)
+
+	if got := panel.viewport.TotalLineCount(); got != 2 || got >= wrapped {
+		t.Fatalf("unwrapped, the diff should be its 2 lines, got %d (wrapped %d)", got, wrapped)
+	}
+
+	panel.ScrollColumns(scrollColumns)
+
+	if strings.Contains(StripANSI(panel.viewport.View()), "Added") {
+		t.Error("scrolling right should move the start of the lines out of view")
+	}
+
+	panel.SetWrap(true)
+
+	if panel.viewport.XOffset() != 0 {
+		t.Error("wrapping lines again should scroll back to their start")
+	}
+}
+
+func TestDiffPanel_CapturesScrollKeysUnwrapped(t *testing.T) {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetDiff(wrapTestDiff)
+
+	if panel.CapturesKey(keyPress("l")) {
+		t.Error("wrapped lines should leave l to switch panes")
+	}
+
+	panel.SetWrap(false)
+
+	if !panel.CapturesKey(keyPress("l")) || !panel.CapturesKey(keyPress("h")) {
+		t.Error("unwrapped lines should take h and l to scroll")
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Scrolling sideways never goes left of the start of the lines.
+func TestDiffPanel_ScrollColumnsProperty(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		panel := NewDiffPanel(NewStyles())
+		panel.SetSize(40, 10)
+		panel.SetDiff(wrapTestDiff)
+		panel.SetWrap(rapid.Bool().Draw(t, "wrap"))
+
+		for _, columns := range rapid.SliceOf(rapid.IntRange(-50, 50)).Draw(t, "columns") {
+			panel.ScrollColumns(columns)
+
+			if panel.viewport.XOffset() < 0 {
+				t.Fatalf("offset went negative: %d", panel.viewport.XOffset())
+			}
+
+			if panel.Wrap() && panel.viewport.XOffset() != 0 {
+				t.Fatal("wrapped lines should not scroll sideways")
+			}
+		}
+	})
+}
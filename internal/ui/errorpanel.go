@@ -0,0 +1,133 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/chatter/chado/internal/ui/theme"
+)
+
+const (
+	// errorPanelMaxWidth wraps the details so long stderr lines stay readable.
+	errorPanelMaxWidth = 80
+
+	// errorPanelChrome is the border, padding, and the title, blank, and hint
+	// lines around the details.
+	errorPanelChrome = 8
+)
+
+// ErrorPanelMsg is sent when the error panel is closed.
+type ErrorPanelMsg struct {
+	Retry bool // run the failed command again
+}
+
+// ErrorPanel is a modal showing a failed command with its full error
+// output, with jj's hints set apart.
+type ErrorPanel struct {
+	command    string // what failed, e.g. "jj squash -r xsssnyux"
+	message    string
+	hints      []string
+	retryHint  string // what r does; empty hides it
+	maxWidth   int
+	maxHeight  int
+	retry      key.Binding
+	close      key.Binding
+	border     lipgloss.Style
+	titleStyle lipgloss.Style
+	hintStyle  lipgloss.Style
+	keyStyle   lipgloss.Style
+}
+
+// NewErrorPanel creates an empty error panel.
+func NewErrorPanel() *ErrorPanel {
+	e := &ErrorPanel{
+		maxWidth:   errorPanelMaxWidth,
+		retry:      key.NewBinding(key.WithKeys("r")),
+		close:      key.NewBinding(key.WithKeys("esc", "q", "enter", "E")),
+		border:     lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(1, confirmHorizontalPadding),
+		titleStyle: lipgloss.NewStyle().Bold(true),
+	}
+	e.SetTheme(theme.Default())
+
+	return e
+}
+
+// SetTheme colors the panel from t.
+func (e *ErrorPanel) SetTheme(t theme.Theme) {
+	e.border = e.border.BorderForeground(lipgloss.Color(t.Error))
+	e.titleStyle = e.titleStyle.Foreground(lipgloss.Color(t.Error))
+	e.hintStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.Accent))
+	e.keyStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.Secondary))
+}
+
+// SetSize bounds the panel to the screen.
+func (e *ErrorPanel) SetSize(width, height int) {
+	e.maxWidth = min(max(width-errorPanelChrome, 1), errorPanelMaxWidth)
+	e.maxHeight = height
+}
+
+// SetError shows command's failure: its message and jj's hints. retryHint
+// says what r does, e.g. "run again"; empty offers no retry.
+func (e *ErrorPanel) SetError(command, message string, hints []string, retryHint string) {
+	e.command = command
+	e.message = message
+	e.hints = hints
+	e.retryHint = retryHint
+}
+
+// Update handles input messages.
+func (e *ErrorPanel) Update(msg tea.Msg) tea.Cmd {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+
+	var retry bool
+
+	switch {
+	case key.Matches(keyMsg, e.retry) && e.retryHint != "":
+		retry = true
+	case key.Matches(keyMsg, e.close):
+	default:
+		return nil
+	}
+
+	return func() tea.Msg {
+		return ErrorPanelMsg{Retry: retry}
+	}
+}
+
+// View renders the panel.
+func (e *ErrorPanel) View() string {
+	wrap := lipgloss.NewStyle().Width(e.maxWidth)
+
+	lines := strings.Split(wrap.Render(e.message), "\n")
+	for _, hint := range e.hints {
+		lines = append(lines, strings.Split(e.hintStyle.Width(e.maxWidth).Render("hint: "+hint), "\n")...)
+	}
+
+	// Keep the hints and the end of the error, where jj says what went wrong
+	if room := e.maxHeight - errorPanelChrome; e.maxHeight > 0 && len(lines) > room {
+		room = max(room-1, 1)
+		lines = append([]string{fmt.Sprintf("… %d more lines", len(lines)-room)}, lines[len(lines)-room:]...)
+	}
+
+	keys := "⎋ close"
+	if e.retryHint != "" {
+		keys = "r " + e.retryHint + " • " + keys
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		e.titleStyle.Width(e.maxWidth).Render(e.command+" failed"),
+		"",
+		strings.Join(lines, "\n"),
+		"",
+		e.keyStyle.Render(keys),
+	)
+
+	return e.border.Render(content)
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"pgregory.net/rapid"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestErrorPanel_ShowsMessageAndHints(t *testing.T) {
+	panel := NewErrorPanel()
+	panel.SetError("jj squash", "Error: Cannot rewrite immutable commit", []string{"Pass --ignore-immutable"}, "run again")
+
+	view := StripANSI(panel.View())
+
+	for _, want := range []string{"jj squash failed", "Cannot rewrite immutable commit", "hint: Pass --ignore-immutable", "r run again"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestErrorPanel_Keys(t *testing.T) {
+	panel := NewErrorPanel()
+	panel.SetError("jj new", "Error: boom", nil, "run again")
+
+	if msg := panel.Update(tea.KeyPressMsg(tea.Key{Code: 'r', Text: "r"}))(); msg != (ErrorPanelMsg{Retry: true}) {
+		t.Errorf("r should retry, got %v", msg)
+	}
+
+	if msg := panel.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEscape}))(); msg != (ErrorPanelMsg{}) {
+		t.Errorf("esc should close without retrying, got %v", msg)
+	}
+
+	if cmd := panel.Update(tea.KeyPressMsg(tea.Key{Code: 'j', Text: "j"})); cmd != nil {
+		t.Error("other keys should be ignored")
+	}
+
+	panel.SetError("jj new", "Error: boom", nil, "")
+
+	if cmd := panel.Update(tea.KeyPressMsg(tea.Key{Code: 'r', Text: "r"})); cmd != nil {
+		t.Error("r should do nothing without a retry")
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: however long the error, the panel fits the screen.
+func TestErrorPanel_FitsScreen(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		width := rapid.IntRange(30, 200).Draw(t, "width")
+		height := rapid.IntRange(12, 60).Draw(t, "height")
+		lines := rapid.SliceOfN(rapid.StringMatching(`[a-z ]{0,120}`), 0, 80).Draw(t, "lines")
+		hints := rapid.SliceOfN(rapid.StringMatching(`[a-z ]{1,40}`), 0, 3).Draw(t, "hints")
+
+		panel := NewErrorPanel()
+		panel.SetSize(width, height)
+		panel.SetError("jj new", strings.Join(lines, "\n"), hints, "run again")
+
+		view := panel.View()
+		if got := lipgloss.Width(view); got > width {
+			t.Fatalf("width %d exceeds %d", got, width)
+		}
+
+		if got := lipgloss.Height(view); got > height {
+			t.Fatalf("height %d exceeds %d", got, height)
+		}
+	})
+}
@@ -0,0 +1,31 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// renderFileContent lays out the whole file being shown, syntax highlighted
+// when that is on, each line after its number.
+func (p *DiffPanel) renderFileContent() string {
+	lines := strings.Split(strings.TrimSuffix(p.diffContent, "\n"), "\n")
+	if p.syntax != nil {
+		lines = p.syntax.HighlightFile(p.contentPath, lines)
+	}
+
+	width := len(strconv.Itoa(len(lines)))
+	rows := make([]string, len(lines))
+
+	for i, line := range lines {
+		rows[i] = p.styles.Dim.Render(fmt.Sprintf("%*d", width, i+1)) + " " + line
+	}
+
+	return strings.Join(rows, "\n")
+}
+
+// ShowingFileContent reports whether the whole content of a file is shown
+// rather than a diff.
+func (p *DiffPanel) ShowingFileContent() bool {
+	return p.contentPath != ""
+}
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestDiffPanel_FileContent(t *testing.T) {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetSize(80, 20)
+	panel.SetFileContent("main.go", "package main\n\nfunc main() {}\n")
+
+	if !panel.ShowingFileContent() {
+		t.Fatal("the panel should show file content")
+	}
+
+	view := StripANSI(panel.viewport.View())
+	if !strings.Contains(view, "1 package main") || !strings.Contains(view, "3 func main() {}") {
+		t.Errorf("content should be shown with line numbers, got:\n%s", view)
+	}
+
+	panel.SetDiff("Modified regular file main.go:\n")
+
+	if panel.ShowingFileContent() {
+		t.Error("a diff should replace the content")
+	}
+}
+
+func TestDiffPanel_FileContentSurvivesPreview(t *testing.T) {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetSize(80, 20)
+	panel.SetFileContent("main.go", "package main\n")
+
+	panel.ShowOutput("Output", "some output")
+
+	if panel.ShowingFileContent() {
+		t.Error("output should not be shown as file content")
+	}
+
+	panel.ClosePreview()
+
+	if !panel.ShowingFileContent() || !strings.Contains(StripANSI(panel.viewport.View()), "1 package main") {
+		t.Error("closing the output should restore the file content")
+	}
+}
+
+func TestDiffPanel_FileContentLoadedWhilePreviewing(t *testing.T) {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetSize(80, 20)
+	panel.SetDiff("old diff")
+	panel.ShowOutput("Output", "some output")
+
+	panel.SetFileContent("main.go", "package main\n")
+	panel.ClosePreview()
+
+	if !panel.ShowingFileContent() || panel.diffContent != "package main\n" {
+		t.Errorf("content loaded under the output should show once it closes, got %q", panel.diffContent)
+	}
+}
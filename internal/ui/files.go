@@ -2,6 +2,8 @@ package ui
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"charm.land/bubbles/v2/key"
@@ -15,15 +17,24 @@ import (
 
 // FilesPanel displays the list of files in a change.
 type FilesPanel struct {
+	Navigator // count prefixes and paging keys
+
 	viewport        viewport.Model
 	styles          *Styles
 	files           []jj.File
+	rows            []fileRow // visible lines; the cursor indexes these
 	cursor          int
+	tree            bool                     // group files under collapsible directories
+	collapsedDirs   map[string]bool          // directories folded in tree mode
+	stats           map[string]jj.ChangeStat // line counts by path; missing until loaded
+	sortByChurn     bool                     // most changed files first
+	marked          map[string]bool          // paths picked to act on together, by path
 	focused         bool
 	width           int
 	height          int
 	changeID        string
 	shortCode       string  // shortest unique prefix for coloring
+	fileRoot        string  // directory file paths link into; "" for no links
 	borderAnimPhase float64 // 0..1 for focus border animation
 	borderAnimating bool    // true only while the one-shot wrap is running
 }
@@ -34,10 +45,13 @@ func NewFilesPanel(styles *Styles) FilesPanel {
 	vp.SoftWrap = false // Disable word wrap, allow horizontal scrolling
 
 	return FilesPanel{
-		viewport: vp,
-		styles:   styles,
-		files:    []jj.File{},
-		cursor:   0,
+		viewport:      vp,
+		styles:        styles,
+		files:         []jj.File{},
+		cursor:        0,
+		collapsedDirs: make(map[string]bool),
+		marked:        make(map[string]bool),
+		stats:         make(map[string]jj.ChangeStat),
 	}
 }
 
@@ -65,19 +79,99 @@ func (p *FilesPanel) SetBorderAnimating(animating bool) {
 	p.borderAnimating = animating
 }
 
-// SetFiles sets the file list.
+// SetFiles sets the file list. Marks are kept when the same change reloads,
+// for the files still listed.
 func (p *FilesPanel) SetFiles(changeID string, shortCode string, files []jj.File) {
+	if changeID != p.changeID {
+		clear(p.marked)
+	}
+
+	maps.DeleteFunc(p.marked, func(path string, _ bool) bool {
+		return !slices.ContainsFunc(files, func(file jj.File) bool { return file.Path == path })
+	})
+
 	p.changeID = changeID
 	p.shortCode = shortCode
 	p.files = files
-	p.cursor = 0
+	clear(p.stats)
+	p.buildRows()
+	p.cursor = p.firstFileRow()
+	p.updateViewport()
+}
+
+// SetStats sets the line counts of the listed files, following SetFiles.
+// Stats whose path jj shortened are matched to the file that ends with it.
+// As the order may change, the cursor goes back to the first file.
+func (p *FilesPanel) SetStats(stats []jj.FileStat) {
+	clear(p.stats)
+
+	for _, stat := range stats {
+		for _, file := range p.files {
+			if stat.Matches(file.Path) {
+				p.stats[file.Path] = stat.ChangeStat
+				break
+			}
+		}
+	}
+
+	p.buildRows()
+	p.cursor = p.firstFileRow()
+	p.updateViewport()
+}
+
+// SetSortByChurn orders files by changed lines, most first, instead of by path.
+func (p *FilesPanel) SetSortByChurn(sortByChurn bool) {
+	p.sortByChurn = sortByChurn
+	p.relayout()
+}
+
+// SortByChurn reports whether files are ordered by changed lines.
+func (p *FilesPanel) SortByChurn() bool {
+	return p.sortByChurn
+}
+
+// SetTree switches between the flat file list and the directory tree,
+// keeping the selected file selected.
+func (p *FilesPanel) SetTree(tree bool) {
+	if p.tree == tree {
+		return
+	}
+
+	p.tree = tree
+	p.relayout()
+}
+
+// Tree reports whether files are shown as a directory tree.
+func (p *FilesPanel) Tree() bool {
+	return p.tree
+}
+
+// ToggleDir folds or unfolds the directory under the cursor in tree mode.
+// Returns false if the cursor is not on a directory.
+func (p *FilesPanel) ToggleDir() bool {
+	if p.cursor < 0 || p.cursor >= len(p.rows) || p.rows[p.cursor].file != noFile {
+		return false
+	}
+
+	dir := p.rows[p.cursor].dir
+	if p.collapsedDirs[dir] {
+		delete(p.collapsedDirs, dir)
+	} else {
+		p.collapsedDirs[dir] = true
+	}
+
+	// Rows above the cursor are unaffected, so it stays on the directory
+	p.buildRows()
 	p.updateViewport()
+
+	return true
 }
 
-// SelectedFile returns the currently selected file.
+// SelectedFile returns the currently selected file, or nil when the cursor
+// is on a directory.
 func (p *FilesPanel) SelectedFile() *jj.File {
-	if p.cursor >= 0 && p.cursor < len(p.files) {
-		return &p.files[p.cursor]
+	if p.cursor >= 0 && p.cursor < len(p.rows) && p.rows[p.cursor].file != noFile {
+		return &p.files[p.rows[p.cursor].file]
 	}
 
 	return nil
@@ -88,6 +182,81 @@ func (p *FilesPanel) ChangeID() string {
 	return p.changeID
 }
 
+// ShortCode returns the shortest unique prefix of the current change ID.
+func (p *FilesPanel) ShortCode() string {
+	return p.shortCode
+}
+
+// Files returns the listed files.
+func (p *FilesPanel) Files() []jj.File {
+	return p.files
+}
+
+// ToggleMark marks the selected file, or unmarks it if marked.
+// Returns false if the cursor is not on a file.
+func (p *FilesPanel) ToggleMark() bool {
+	file := p.SelectedFile()
+	if file == nil {
+		return false
+	}
+
+	if p.marked[file.Path] {
+		delete(p.marked, file.Path)
+	} else {
+		p.marked[file.Path] = true
+	}
+
+	p.updateViewport()
+
+	return true
+}
+
+// MarkedPaths returns the paths of the marked files, in listing order.
+func (p *FilesPanel) MarkedPaths() []string {
+	var paths []string
+
+	for _, file := range p.files {
+		if p.marked[file.Path] {
+			paths = append(paths, file.Path)
+		}
+	}
+
+	return paths
+}
+
+// ClearMarks unmarks every file.
+func (p *FilesPanel) ClearMarks() {
+	clear(p.marked)
+	p.updateViewport()
+}
+
+// SelectPath moves the cursor to the file with the given path, unfolding
+// its directories in tree mode. Returns false and leaves the cursor alone if
+// no listed file has that path.
+func (p *FilesPanel) SelectPath(path string) bool {
+	index := slices.IndexFunc(p.files, func(file jj.File) bool { return file.Path == path })
+	if index < 0 {
+		return false
+	}
+
+	for i := strings.LastIndexByte(path, '/'); i > 0; i = strings.LastIndexByte(path[:i], '/') {
+		delete(p.collapsedDirs, path[:i])
+	}
+
+	p.buildRows()
+
+	for i, row := range p.rows {
+		if row.file == index {
+			p.cursor = i
+			break
+		}
+	}
+
+	p.updateViewport()
+
+	return true
+}
+
 // CursorUp moves the cursor up.
 func (p *FilesPanel) CursorUp() {
 	if p.cursor > 0 {
@@ -98,12 +267,53 @@ func (p *FilesPanel) CursorUp() {
 
 // CursorDown moves the cursor down.
 func (p *FilesPanel) CursorDown() {
-	if p.cursor < len(p.files)-1 {
+	if p.cursor < len(p.rows)-1 {
 		p.cursor++
 		p.updateViewport()
 	}
 }
 
+// MoveBy moves the cursor by rows, up when negative, stopping at the ends.
+func (p *FilesPanel) MoveBy(rows int) {
+	if len(p.rows) > 0 {
+		p.cursor = clampCursor(p.cursor, rows, len(p.rows))
+		p.updateViewport()
+	}
+}
+
+// MoveFiles moves the cursor by files, back when negative, past the
+// directories between them, stopping at the first and last file. It
+// reports whether the cursor moved.
+func (p *FilesPanel) MoveFiles(files int) bool {
+	step := 1
+	if files < 0 {
+		step, files = -1, -files
+	}
+
+	target := p.cursor
+
+	for i := p.cursor + step; i >= 0 && i < len(p.rows) && files > 0; i += step {
+		if p.rows[i].file != noFile {
+			target = i
+			files--
+		}
+	}
+
+	if target == p.cursor {
+		return false
+	}
+
+	p.cursor = target
+	p.updateViewport()
+
+	return true
+}
+
+// PageRows returns how many items one screen of the panel shows.
+func (p *FilesPanel) PageRows() int {
+	return p.viewport.Height()
+}
+
 // GotoTop moves to the first item.
 func (p *FilesPanel) GotoTop() {
 	p.cursor = 0
@@ -112,18 +322,18 @@ func (p *FilesPanel) GotoTop() {
 
 // GotoBottom moves to the last item.
 func (p *FilesPanel) GotoBottom() {
-	if len(p.files) > 0 {
-		p.cursor = len(p.files) - 1
+	if len(p.rows) > 0 {
+		p.cursor = len(p.rows) - 1
 		p.updateViewport()
 	}
 }
 
-// HandleClick selects the file at the given Y coordinate (relative to content area).
+// HandleClick selects the row at the given Y coordinate (relative to content area).
 func (p *FilesPanel) HandleClick(y int) bool {
 	// Account for viewport scroll offset
 	visualLine := y + p.viewport.YOffset()
 
-	if visualLine >= 0 && visualLine < len(p.files) && visualLine != p.cursor {
+	if visualLine >= 0 && visualLine < len(p.rows) && visualLine != p.cursor {
 		p.cursor = visualLine
 		p.updateViewport()
 
@@ -140,15 +350,17 @@ func (p *FilesPanel) Update(msg tea.Msg) tea.Cmd {
 	}
 
 	if msg, ok := msg.(tea.KeyMsg); ok {
+		if _, handled := p.Navigate(p, msg); handled {
+			return nil
+		}
+
 		switch msg.String() {
-		case "j", "down":
-			p.CursorDown()
-		case "k", "up":
-			p.CursorUp()
-		case "g":
-			p.GotoTop()
-		case "G":
-			p.GotoBottom()
+		case "t":
+			p.SetTree(!p.tree)
+		case "S":
+			p.SetSortByChurn(!p.sortByChurn)
+		case "space":
+			p.ToggleMark()
 		}
 	}
 
@@ -162,14 +374,8 @@ func (p *FilesPanel) View() string {
 	if p.shortCode != "" && len(p.shortCode) <= len(p.changeID) {
 		rest := p.changeID[len(p.shortCode):]
 		// Replace the reset with the outer title color so styling continues
-		var outerColorCode string
-		if p.focused {
-			outerColorCode = AccentColorCode
-		} else {
-			outerColorCode = PrimaryColorCode
-		}
-
-		coloredID = ReplaceResetWithColor(p.styles.ShortCode.Render(p.shortCode), outerColorCode) + rest
+		outerColor := p.styles.TitleColor(p.focused)
+		coloredID = ReplaceResetWithColor(p.styles.ShortCode.Render(p.shortCode), outerColor) + rest
 	}
 
 	title := p.styles.PanelTitle(1, coloredID+" / files", p.focused)
@@ -203,13 +409,93 @@ func (p *FilesPanel) HelpBindings() []help.Binding {
 			Order:    PanelOrderPrimary,
 		},
 		{
-			Key:      key.NewBinding(key.WithKeys("g", "G"), key.WithHelp("g/G", "top/bottom")),
+			Key:      key.NewBinding(key.WithKeys("g", "G"), key.WithHelp("gg/G", "top/bottom")),
+			Category: help.CategoryNavigation,
+			Order:    PanelOrderSecondary,
+		},
+		{
+			Key:      key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "tree/list")),
+			Category: help.CategoryNavigation,
+			Order:    PanelOrderSecondary,
+		},
+		{
+			Key:      key.NewBinding(key.WithKeys("S"), key.WithHelp("S", "sort by churn")),
+			Category: help.CategoryNavigation,
+			Order:    PanelOrderSecondary,
+		},
+		{
+			Key:      key.NewBinding(key.WithKeys("space"), key.WithHelp("space", "mark file")),
 			Category: help.CategoryNavigation,
 			Order:    PanelOrderSecondary,
 		},
 	}
 }
 
+// buildRows lays out the visible rows for the current mode.
+func (p *FilesPanel) buildRows() {
+	var churn []int
+
+	if p.sortByChurn {
+		churn = make([]int, len(p.files))
+		for i, file := range p.files {
+			stat := p.stats[file.Path]
+			churn[i] = stat.Added + stat.Removed
+		}
+	}
+
+	if p.tree {
+		p.rows = treeFileRows(p.files, p.collapsedDirs, churn)
+	} else {
+		p.rows = flatFileRows(p.files, churn)
+	}
+}
+
+// relayout rebuilds the rows after a mode or order change, keeping the
+// selected file selected.
+func (p *FilesPanel) relayout() {
+	selected := p.SelectedFile()
+	p.buildRows()
+
+	if selected == nil || !p.SelectPath(selected.Path) {
+		p.cursor = min(p.cursor, max(len(p.rows)-1, 0))
+		p.updateViewport()
+	}
+}
+
+// renderStat renders a file's line counts after its name; blank until loaded.
+func (p *FilesPanel) renderStat(file int) string {
+	stat, ok := p.stats[p.files[file].Path]
+	if !ok {
+		return ""
+	}
+
+	return " " + p.styles.StatAdded.Render("+"+abbreviateCount(stat.Added)) +
+		" " + p.styles.StatRemoved.Render("−"+abbreviateCount(stat.Removed))
+}
+
+// firstFileRow returns the index of the first row that is a file, or 0.
+func (p *FilesPanel) firstFileRow() int {
+	for i, row := range p.rows {
+		if row.file != noFile {
+			return i
+		}
+	}
+
+	return 0
+}
+
+// SetFileRoot links each file's name to the file under root (file://);
+// "" removes the links.
+func (p *FilesPanel) SetFileRoot(root string) {
+	p.fileRoot = root
+	p.updateViewport()
+}
+
+// Restyle re-renders the content in the current styles, after a theme change.
+func (p *FilesPanel) Restyle() {
+	p.updateViewport()
+}
+
 func (p *FilesPanel) updateViewport() {
 	if len(p.files) == 0 {
 		p.viewport.SetContent("No files changed")
@@ -218,28 +504,51 @@ func (p *FilesPanel) updateViewport() {
 
 	var content strings.Builder
 
-	for idx, file := range p.files {
+	for idx, row := range p.rows {
+		// Selection indicator, and the mark of files picked to act on
+		cursor := "  "
+
+		switch {
+		case idx == p.cursor:
+			cursor = p.styles.Cursor()
+		case row.file != noFile && p.marked[p.files[row.file].Path]:
+			cursor = p.styles.Mark() + " "
+		}
+
+		indent := strings.Repeat("  ", row.depth)
+
+		if row.file == noFile {
+			fold := "▾"
+			if p.collapsedDirs[row.dir] {
+				fold = "▸"
+			}
+
+			content.WriteString(fmt.Sprintf("%s%s%s %s/ %s\n", cursor, indent, fold, row.name,
+				p.styles.Dim.Render(fmt.Sprintf("(%d)", row.count))))
+
+			continue
+		}
+
 		// Status indicator with color
 		var status string
 
-		switch file.Status {
+		switch file := p.files[row.file]; file.Status {
 		case jj.FileAdded:
-			status = "\033[32mA\033[0m" // Green
+			status = p.styles.FileAdded.Render("A")
 		case jj.FileDeleted:
-			status = "\033[31mD\033[0m" // Red
+			status = p.styles.FileDeleted.Render("D")
 		case jj.FileModified:
-			status = "\033[33mM\033[0m" // Yellow
+			status = p.styles.FileModified.Render("M")
 		default:
 			status = string(file.Status)
 		}
 
-		// Selection indicator
-		cursor := "  "
-		if idx == p.cursor {
-			cursor = "→ "
+		name := row.name
+		if p.fileRoot != "" {
+			name = Hyperlink(name, FileURL(p.fileRoot, p.files[row.file].Path))
 		}
 
-		content.WriteString(fmt.Sprintf("%s%s %s\n", cursor, status, file.Path))
+		content.WriteString(fmt.Sprintf("%s%s%s %s%s\n", cursor, indent, status, name, p.renderStat(row.file)))
 	}
 
 	p.viewport.SetContent(content.String())
@@ -1,8 +1,10 @@
 package ui
 
 import (
+	"strings"
 	"testing"
 
+	tea "charm.land/bubbletea/v2"
 	"pgregory.net/rapid"
 
 	"github.com/chatter/chado/internal/jj"
@@ -154,6 +156,209 @@ func TestFilesPanel_ChangeID(t *testing.T) {
 	}
 }
 
+func TestFilesPanel_SelectPath(t *testing.T) {
+	panel := NewFilesPanel(NewStyles())
+	panel.SetSize(80, 24)
+	panel.SetFiles("test", "", []jj.File{
+		{Path: "a.go", Status: jj.FileModified},
+		{Path: "b.go", Status: jj.FileAdded},
+	})
+
+	if !panel.SelectPath("b.go") || panel.SelectedFile().Path != "b.go" {
+		t.Error("SelectPath should move the cursor to b.go")
+	}
+
+	if panel.SelectPath("missing.go") || panel.SelectedFile().Path != "b.go" {
+		t.Error("SelectPath should leave the cursor alone for an unlisted path")
+	}
+}
+
+func TestFilesPanel_TreeRows(t *testing.T) {
+	panel := NewFilesPanel(NewStyles())
+	panel.SetSize(80, 24)
+	panel.SetTree(true)
+	panel.SetFiles("test", "", []jj.File{
+		{Path: "README.md", Status: jj.FileModified},
+		{Path: "internal/ui/files.go", Status: jj.FileModified},
+		{Path: "internal/ui/filetree.go", Status: jj.FileAdded},
+	})
+
+	want := []struct {
+		name  string
+		depth int
+		count int
+	}{
+		{"internal/ui", 0, 2}, // single-child chain merged
+		{"files.go", 1, 0},
+		{"filetree.go", 1, 0},
+		{"README.md", 0, 0},
+	}
+
+	if len(panel.rows) != len(want) {
+		t.Fatalf("expected %d rows, got %+v", len(want), panel.rows)
+	}
+
+	for i, w := range want {
+		if row := panel.rows[i]; row.name != w.name || row.depth != w.depth || row.count != w.count {
+			t.Errorf("row %d: expected %+v, got %+v", i, w, row)
+		}
+	}
+
+	if file := panel.SelectedFile(); file == nil || file.Path != "internal/ui/files.go" {
+		t.Errorf("cursor should start on the first file, got %+v", file)
+	}
+}
+
+func TestFilesPanel_MoveFilesSkipsDirs(t *testing.T) {
+	panel := NewFilesPanel(NewStyles())
+	panel.SetSize(80, 24)
+	panel.SetTree(true)
+	panel.SetFiles("test", "", []jj.File{
+		{Path: "README.md", Status: jj.FileModified},
+		{Path: "internal/ui/files.go", Status: jj.FileModified},
+		{Path: "internal/ui/filetree.go", Status: jj.FileAdded},
+	})
+
+	if !panel.MoveFiles(2) || panel.SelectedFile().Path != "README.md" {
+		t.Fatalf("two files on should be README.md, got %+v", panel.SelectedFile())
+	}
+
+	if panel.MoveFiles(1) {
+		t.Error("there is no file after the last")
+	}
+
+	if !panel.MoveFiles(-5) || panel.SelectedFile().Path != "internal/ui/files.go" {
+		t.Errorf("moving back should stop on the first file, not its directory, got %+v", panel.SelectedFile())
+	}
+}
+
+func TestFilesPanel_ToggleDir(t *testing.T) {
+	panel := NewFilesPanel(NewStyles())
+	panel.SetSize(80, 24)
+	panel.SetTree(true)
+	panel.SetFiles("test", "", []jj.File{
+		{Path: "src/a.go", Status: jj.FileModified},
+		{Path: "src/b.go", Status: jj.FileModified},
+		{Path: "top.go", Status: jj.FileAdded},
+	})
+
+	if panel.ToggleDir() {
+		t.Error("ToggleDir should do nothing on a file row")
+	}
+
+	panel.GotoTop()
+
+	if !panel.ToggleDir() || len(panel.rows) != 2 {
+		t.Fatalf("folding src should leave 2 rows, got %+v", panel.rows)
+	}
+
+	if panel.SelectedFile() != nil {
+		t.Error("the cursor should stay on the folded directory")
+	}
+
+	if !panel.SelectPath("src/b.go") || panel.SelectedFile().Path != "src/b.go" {
+		t.Error("selecting a file in a folded directory should unfold it")
+	}
+
+	panel.SetTree(false)
+
+	if panel.SelectedFile().Path != "src/b.go" || panel.cursor != 1 {
+		t.Errorf("switching to the list should keep the file selected, cursor at %d", panel.cursor)
+	}
+}
+
+func TestFilesPanel_StatsAndChurnSort(t *testing.T) {
+	panel := NewFilesPanel(NewStyles())
+	panel.SetSize(80, 24)
+	panel.SetFiles("test", "", []jj.File{
+		{Path: "a.go", Status: jj.FileModified},
+		{Path: "deep/nested/path/b.go", Status: jj.FileModified},
+		{Path: "c.go", Status: jj.FileAdded},
+	})
+	panel.SetStats([]jj.FileStat{
+		{Path: "a.go", ChangeStat: jj.ChangeStat{Added: 1}},
+		{Path: "...ted/path/b.go", ChangeStat: jj.ChangeStat{Added: 5, Removed: 5}},
+	})
+
+	if stat := panel.stats["deep/nested/path/b.go"]; stat.Added != 5 {
+		t.Errorf("a shortened stat path should match its file, got %+v", panel.stats)
+	}
+
+	if !strings.Contains(StripANSI(panel.viewport.View()), "a.go +1 −0") {
+		t.Errorf("line counts should follow the path, got:\n%s", panel.viewport.View())
+	}
+
+	panel.SetSortByChurn(true)
+
+	var order []string
+	for _, row := range panel.rows {
+		order = append(order, panel.files[row.file].Path)
+	}
+
+	if strings.Join(order, ",") != "deep/nested/path/b.go,a.go,c.go" {
+		t.Errorf("expected most changed first, got %v", order)
+	}
+
+	if panel.SelectedFile().Path != "a.go" {
+		t.Errorf("sorting should keep the selected file, got %s", panel.SelectedFile().Path)
+	}
+}
+
+func TestFilesPanel_FileLinks(t *testing.T) {
+	panel := NewFilesPanel(NewStyles())
+	panel.SetSize(80, 24)
+	panel.SetFiles("test", "", []jj.File{{Path: "src/a.go", Status: jj.FileModified}})
+
+	if strings.Contains(panel.viewport.View(), "\x1b]8;") {
+		t.Error("file names should not link without a root")
+	}
+
+	panel.SetFileRoot("/repo")
+
+	if !strings.Contains(panel.viewport.GetContent(), Hyperlink("src/a.go", "file:///repo/src/a.go")) {
+		t.Errorf("the file name should link to the file, got %q", panel.viewport.GetContent())
+	}
+}
+
+func TestFilesPanel_Marks(t *testing.T) {
+	panel := NewFilesPanel(NewStyles())
+	panel.SetSize(80, 24)
+	panel.SetFocused(true)
+	panel.SetFiles("test", "", []jj.File{
+		{Path: "a.go", Status: jj.FileModified},
+		{Path: "b.go", Status: jj.FileModified},
+		{Path: "c.go", Status: jj.FileAdded},
+	})
+
+	space := tea.KeyPressMsg(tea.Key{Code: tea.KeySpace, Text: " "})
+
+	panel.GotoBottom()
+	panel.Update(space)
+	panel.GotoTop()
+	panel.Update(space)
+	panel.CursorDown()
+
+	if got := strings.Join(panel.MarkedPaths(), ","); got != "a.go,c.go" {
+		t.Errorf("space should mark files, listed in order, got %s", got)
+	}
+
+	if !strings.Contains(StripANSI(panel.viewport.View()), "• M a.go") {
+		t.Errorf("marked files should show a mark, got:\n%s", panel.viewport.View())
+	}
+
+	panel.SetFiles("test", "", []jj.File{{Path: "a.go"}, {Path: "b.go"}})
+
+	if got := strings.Join(panel.MarkedPaths(), ","); got != "a.go" {
+		t.Errorf("reloading the change should keep marks on listed files, got %s", got)
+	}
+
+	panel.SetFiles("other", "", []jj.File{{Path: "a.go"}})
+
+	if got := panel.MarkedPaths(); len(got) != 0 {
+		t.Errorf("another change should start unmarked, got %v", got)
+	}
+}
+
 func TestFilesPanel_EmptyFiles(t *testing.T) {
 	panel := NewFilesPanel(NewStyles())
 	panel.SetSize(80, 24)
@@ -479,3 +684,43 @@ func BenchmarkFilesPanel_Navigation(b *testing.B) {
 		panel.GotoTop()
 	}
 }
+
+// Property: An unfolded tree lists every file exactly once, in a row whose
+// selection yields that file
+func TestFilesPanel_TreeListsEveryFile(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		panel := NewFilesPanel(NewStyles())
+		panel.SetSize(80, 24)
+		panel.SetTree(true)
+
+		segment := rapid.SampledFrom([]string{"a", "b", "c", "ui", "internal"})
+		paths := rapid.SliceOfNDistinct(
+			rapid.Custom(func(t *rapid.T) string {
+				return strings.Join(rapid.SliceOfN(segment, 1, 4).Draw(t, "segments"), "/") + ".go"
+			}),
+			0, 20, func(path string) string { return path },
+		).Draw(t, "paths")
+
+		files := make([]jj.File, len(paths))
+		for i, path := range paths {
+			files[i] = jj.File{Path: path, Status: jj.FileModified}
+		}
+
+		panel.SetFiles("test", "", files)
+
+		seen := make(map[string]int)
+
+		for i := range panel.rows {
+			panel.cursor = i
+			if file := panel.SelectedFile(); file != nil {
+				seen[file.Path]++
+			}
+		}
+
+		for _, path := range paths {
+			if seen[path] != 1 {
+				t.Fatalf("expected %s listed once, seen %d times in %+v", path, seen[path], panel.rows)
+			}
+		}
+	})
+}
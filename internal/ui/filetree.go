@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// noFile marks a files panel row that is a directory rather than a file.
+const noFile = -1
+
+// fileRow is one visible line of the files panel: a file, or in tree mode a
+// directory heading.
+type fileRow struct {
+	file  int    // index into the panel's files; noFile for a directory
+	dir   string // full path of the directory row
+	name  string // path segment(s) shown for the row
+	depth int    // nesting level in tree mode
+	count int    // files under the directory
+}
+
+// fileTreeNode is a directory or file while building the tree.
+type fileTreeNode struct {
+	name     string
+	path     string
+	file     int
+	count    int
+	churn    int // changed lines under the node
+	children []*fileTreeNode
+}
+
+// flatFileRows lists every file on its own row by full path. With churn (the
+// changed lines of each file) the most changed files come first.
+func flatFileRows(files []jj.File, churn []int) []fileRow {
+	rows := make([]fileRow, len(files))
+	for i, file := range files {
+		rows[i] = fileRow{file: i, name: file.Path}
+	}
+
+	if churn != nil {
+		sort.SliceStable(rows, func(i, j int) bool {
+			return churn[rows[i].file] > churn[rows[j].file]
+		})
+	}
+
+	return rows
+}
+
+// treeFileRows lists files under their directories, directories first, with
+// single-child directory chains merged into one row (e.g. "internal/ui").
+// Children of directories in collapsed are left out. Entries are ordered by
+// name, or with churn by changed lines, most first.
+func treeFileRows(files []jj.File, collapsed map[string]bool, churn []int) []fileRow {
+	root := &fileTreeNode{file: noFile}
+
+	for i, file := range files {
+		lines := 0
+		if churn != nil {
+			lines = churn[i]
+		}
+
+		root.insert(strings.Split(file.Path, "/"), i, lines)
+	}
+
+	root.sortChildren(churn != nil)
+
+	var rows []fileRow
+
+	for _, child := range root.children {
+		rows = child.appendRows(rows, 0, collapsed)
+	}
+
+	return rows
+}
+
+// insert adds the file at index, with churn changed lines, to the tree along
+// its path segments.
+func (n *fileTreeNode) insert(segments []string, index, churn int) {
+	n.count++
+	n.churn += churn
+
+	if len(segments) == 1 {
+		n.children = append(n.children, &fileTreeNode{
+			name:  segments[0],
+			path:  path.Join(n.path, segments[0]),
+			file:  index,
+			count: 1,
+			churn: churn,
+		})
+
+		return
+	}
+
+	var dir *fileTreeNode
+
+	for _, child := range n.children {
+		if child.file == noFile && child.name == segments[0] {
+			dir = child
+			break
+		}
+	}
+
+	if dir == nil {
+		dir = &fileTreeNode{name: segments[0], path: path.Join(n.path, segments[0]), file: noFile}
+		n.children = append(n.children, dir)
+	}
+
+	dir.insert(segments[1:], index, churn)
+}
+
+// sortChildren orders each directory's entries: directories, then files, by
+// name or, byChurn, by changed lines.
+func (n *fileTreeNode) sortChildren(byChurn bool) {
+	sort.SliceStable(n.children, func(i, j int) bool {
+		a, b := n.children[i], n.children[j]
+		if (a.file == noFile) != (b.file == noFile) {
+			return a.file == noFile
+		}
+
+		if byChurn && a.churn != b.churn {
+			return a.churn > b.churn
+		}
+
+		return a.name < b.name
+	})
+
+	for _, child := range n.children {
+		child.sortChildren(byChurn)
+	}
+}
+
+// appendRows appends the node and, unless it is collapsed, its descendants.
+func (n *fileTreeNode) appendRows(rows []fileRow, depth int, collapsed map[string]bool) []fileRow {
+	if n.file != noFile {
+		return append(rows, fileRow{file: n.file, name: n.name, depth: depth})
+	}
+
+	// Merge a chain of directories that each hold only one directory
+	name := n.name
+	for len(n.children) == 1 && n.children[0].file == noFile {
+		n = n.children[0]
+		name += "/" + n.name
+	}
+
+	rows = append(rows, fileRow{file: noFile, dir: n.path, name: name, depth: depth, count: n.count})
+
+	if collapsed[n.path] {
+		return rows
+	}
+
+	for _, child := range n.children {
+		rows = child.appendRows(rows, depth+1, collapsed)
+	}
+
+	return rows
+}
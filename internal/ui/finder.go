@@ -0,0 +1,278 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/chatter/chado/internal/fuzzy"
+	"github.com/chatter/chado/internal/ui/theme"
+)
+
+const (
+	// finderHorizontalPadding is the horizontal padding inside the finder border.
+	finderHorizontalPadding = 2
+
+	// finderChrome is the horizontal space taken by the border (2) and padding (4).
+	finderChrome = 6
+
+	// finderMinWidth and finderMinRows keep the finder usable on small terminals.
+	finderMinWidth = 30
+	finderMinRows  = 3
+
+	// finderFixedLines counts the title, input, blank separators, and hint lines.
+	finderFixedLines = 6
+)
+
+// FinderItem is one entry offered by the finder.
+type FinderItem struct {
+	Label  string // Text matched against the query and shown in the list
+	Detail string // Dimmed annotation shown after the label (not matched)
+}
+
+// FinderSelectMsg is sent when the user picks an item.
+type FinderSelectMsg struct {
+	Item  FinderItem
+	Index int // Position of the item in the slice given to SetItems
+}
+
+// FinderCancelMsg is sent when the user closes the finder without picking.
+type FinderCancelMsg struct{}
+
+// Finder is a fuzzy-filtering picker overlay.
+type Finder struct {
+	input   textinput.Model
+	title   string
+	items   []FinderItem
+	labels  []string
+	results []fuzzy.Result
+	cursor  int
+	width   int
+	rows    int // visible result rows
+
+	// Key bindings
+	submit key.Binding
+	cancel key.Binding
+	up     key.Binding
+	down   key.Binding
+
+	// Styles
+	borderStyle lipgloss.Style
+	titleStyle  lipgloss.Style
+	matchStyle  lipgloss.Style
+	detailStyle lipgloss.Style
+	hintStyle   lipgloss.Style
+	monochrome  bool // cursor marked in ASCII and reverse video
+}
+
+// NewFinder creates a new finder overlay.
+func NewFinder() *Finder {
+	input := textinput.New()
+	input.Placeholder = "Type to filter..."
+	input.Focus()
+
+	f := &Finder{
+		input:  input,
+		submit: key.NewBinding(key.WithKeys("enter")),
+		cancel: key.NewBinding(key.WithKeys("esc")),
+		up:     key.NewBinding(key.WithKeys("up", "ctrl+k", "ctrl+p")),
+		down:   key.NewBinding(key.WithKeys("down", "ctrl+j", "ctrl+n")),
+		borderStyle: lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			Padding(1, finderHorizontalPadding),
+		titleStyle: lipgloss.NewStyle().
+			Bold(true),
+		matchStyle: lipgloss.NewStyle().
+			Bold(true),
+		detailStyle: lipgloss.NewStyle(),
+		hintStyle:   lipgloss.NewStyle(),
+	}
+	f.SetTheme(theme.Default())
+
+	return f
+}
+
+// SetTheme colors the finder from t.
+func (f *Finder) SetTheme(t theme.Theme) {
+	f.borderStyle = f.borderStyle.BorderForeground(lipgloss.Color(t.OverlayBorder))
+	f.titleStyle = f.titleStyle.Foreground(lipgloss.Color(t.OverlayTitle))
+	f.matchStyle = f.matchStyle.Foreground(lipgloss.Color(t.OverlayTitle))
+	f.detailStyle = f.detailStyle.Foreground(lipgloss.Color(t.Secondary))
+	f.hintStyle = f.hintStyle.Foreground(lipgloss.Color(t.Secondary))
+}
+
+// SetMonochrome marks the cursor with an ASCII marker in reverse video, for
+// a terminal without colors.
+func (f *Finder) SetMonochrome(monochrome bool) {
+	f.monochrome = monochrome
+}
+
+// Open resets the query and offers a new set of items.
+func (f *Finder) Open(title string, items []FinderItem) tea.Cmd {
+	f.title = title
+	f.items = items
+
+	f.labels = make([]string, len(items))
+	for i, item := range items {
+		f.labels[i] = item.Label
+	}
+
+	f.input.SetValue("")
+	f.refilter()
+
+	return f.input.Focus()
+}
+
+// SetSize sets the overlay's outer width and the height available to it.
+func (f *Finder) SetSize(width, height int) {
+	f.width = max(width, finderMinWidth)
+	f.rows = max(height-finderFixedLines-PanelBorderHeight, finderMinRows)
+	f.input.SetWidth(f.width - finderChrome - PanelBorderWidth)
+}
+
+// Query returns the current filter text.
+func (f *Finder) Query() string {
+	return f.input.Value()
+}
+
+// Matches returns the items matching the current query, best first.
+func (f *Finder) Matches() []FinderItem {
+	matches := make([]FinderItem, len(f.results))
+	for i, result := range f.results {
+		matches[i] = f.items[result.Index]
+	}
+
+	return matches
+}
+
+// Update handles input messages.
+func (f *Finder) Update(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, f.submit):
+			if f.cursor >= len(f.results) {
+				return nil
+			}
+
+			index := f.results[f.cursor].Index
+			item := f.items[index]
+
+			return func() tea.Msg {
+				return FinderSelectMsg{Item: item, Index: index}
+			}
+		case key.Matches(msg, f.cancel):
+			return func() tea.Msg {
+				return FinderCancelMsg{}
+			}
+		case key.Matches(msg, f.up):
+			f.cursor = max(f.cursor-1, 0)
+			return nil
+		case key.Matches(msg, f.down):
+			f.cursor = max(min(f.cursor+1, len(f.results)-1), 0)
+			return nil
+		}
+	}
+
+	query := f.input.Value()
+
+	var cmd tea.Cmd
+
+	f.input, cmd = f.input.Update(msg)
+
+	if f.input.Value() != query {
+		f.refilter()
+	}
+
+	return cmd
+}
+
+// View renders the finder overlay.
+func (f *Finder) View() string {
+	count := fmt.Sprintf(" %d/%d", len(f.results), len(f.items))
+	title := f.titleStyle.Render(f.title) + f.hintStyle.Render(count)
+	hint := f.hintStyle.Render("⏎ open • ↑/↓ move • ⎋ cancel")
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		"",
+		f.input.View(),
+		"",
+		f.renderResults(),
+		"",
+		hint,
+	)
+
+	return f.borderStyle.Width(f.width).Render(content)
+}
+
+// refilter recomputes matches for the current query and resets the cursor.
+func (f *Finder) refilter() {
+	f.results = fuzzy.Filter(f.input.Value(), f.labels)
+	f.cursor = 0
+}
+
+// renderResults renders the window of results around the cursor.
+func (f *Finder) renderResults() string {
+	rows := max(f.rows, finderMinRows)
+	if len(f.results) == 0 {
+		return f.hintStyle.Render("No matches") + strings.Repeat("\n", rows-1)
+	}
+
+	start := max(f.cursor-rows+1, 0)
+	end := min(start+rows, len(f.results))
+	lines := make([]string, 0, rows)
+
+	for i := start; i < end; i++ {
+		result := f.results[i]
+		item := f.items[result.Index]
+
+		prefix := "  "
+		switch {
+		case i == f.cursor && f.monochrome:
+			prefix = f.matchStyle.Reverse(true).Render(">") + " "
+		case i == f.cursor:
+			prefix = "→ "
+		}
+
+		line := prefix + f.highlight(item.Label, result.Positions)
+		if item.Detail != "" {
+			line += " " + f.detailStyle.Render(item.Detail)
+		}
+
+		lines = append(lines, line)
+	}
+
+	for len(lines) < rows {
+		lines = append(lines, "")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// highlight renders the matched runes of label in the match style.
+func (f *Finder) highlight(label string, positions []int) string {
+	if len(positions) == 0 {
+		return label
+	}
+
+	matched := make(map[int]bool, len(positions))
+	for _, pos := range positions {
+		matched[pos] = true
+	}
+
+	var out strings.Builder
+
+	for i, r := range []rune(label) {
+		if matched[i] {
+			out.WriteString(f.matchStyle.Render(string(r)))
+		} else {
+			out.WriteRune(r)
+		}
+	}
+
+	return out.String()
+}
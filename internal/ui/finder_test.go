@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"pgregory.net/rapid"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+func typeInto(f *Finder, text string) {
+	for _, r := range text {
+		f.Update(tea.KeyPressMsg(tea.Key{Code: r, Text: string(r)}))
+	}
+}
+
+func newTestFinder(labels ...string) *Finder {
+	finder := NewFinder()
+	finder.SetSize(60, 20)
+
+	items := make([]FinderItem, len(labels))
+	for i, label := range labels {
+		items[i] = FinderItem{Label: label}
+	}
+
+	finder.Open("Find file", items)
+
+	return finder
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestFinder_FiltersAsYouType(t *testing.T) {
+	finder := newTestFinder("internal/app/app.go", "internal/ui/diff.go", "README.md")
+
+	if len(finder.Matches()) != 3 {
+		t.Fatal("empty query should list every item")
+	}
+
+	typeInto(finder, "diff")
+
+	matches := finder.Matches()
+	if len(matches) != 1 || matches[0].Label != "internal/ui/diff.go" {
+		t.Errorf("expected only diff.go, got %+v", matches)
+	}
+}
+
+func TestFinder_SelectSendsOriginalIndex(t *testing.T) {
+	finder := newTestFinder("a.go", "b.go", "c.go")
+	typeInto(finder, "c")
+
+	cmd := finder.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	if cmd == nil {
+		t.Fatal("enter should select")
+	}
+
+	msg, ok := cmd().(FinderSelectMsg)
+	if !ok || msg.Index != 2 || msg.Item.Label != "c.go" {
+		t.Errorf("expected c.go at index 2, got %+v", msg)
+	}
+}
+
+func TestFinder_EnterWithNoMatchesDoesNothing(t *testing.T) {
+	finder := newTestFinder("a.go")
+	typeInto(finder, "zzz")
+
+	if cmd := finder.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter})); cmd != nil {
+		t.Error("enter with no matches should not select")
+	}
+
+	if !strings.Contains(StripANSI(finder.View()), "No matches") {
+		t.Error("view should say there are no matches")
+	}
+}
+
+func TestFinder_Cancel(t *testing.T) {
+	finder := newTestFinder("a.go")
+
+	cmd := finder.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEscape}))
+	if cmd == nil {
+		t.Fatal("esc should cancel")
+	}
+
+	if _, ok := cmd().(FinderCancelMsg); !ok {
+		t.Error("expected FinderCancelMsg")
+	}
+}
+
+func TestFinder_MonochromeCursorIsASCII(t *testing.T) {
+	finder := newTestFinder("a.go", "b.go")
+	finder.SetMonochrome(true)
+
+	view := StripANSI(finder.View())
+	if !strings.Contains(view, "> a.go") || strings.Contains(view, "→") {
+		t.Errorf("the cursor should be an ASCII marker, got:\n%s", view)
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: the cursor never leaves the result list, however it is moved.
+func TestFinder_CursorStaysInResults(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		labels := rapid.SliceOfN(rapid.StringMatching(`[a-z]{1,8}`), 1, 20).Draw(t, "labels")
+		finder := newTestFinder(labels...)
+
+		moves := rapid.SliceOf(rapid.SampledFrom([]rune{tea.KeyUp, tea.KeyDown})).Draw(t, "moves")
+		for _, move := range moves {
+			finder.Update(tea.KeyPressMsg(tea.Key{Code: move}))
+		}
+
+		if finder.cursor < 0 || finder.cursor >= len(finder.results) {
+			t.Fatalf("cursor %d outside %d results", finder.cursor, len(finder.results))
+		}
+	})
+}
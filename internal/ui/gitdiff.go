@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// gitDiffHunkSeparator is printed between hunks, as jj does in color-words diffs.
+const gitDiffHunkSeparator = "    ..."
+
+// renderGitDiff lays out a git-format diff like jj's color-words output:
+// a "<status> regular file <path>:" header per file and an "old new: " line
+// number gutter, so hunk navigation and syntax highlighting apply unchanged.
+// Removed and added lines are colored, with their changed words emphasized,
+// and blocks of lines moved from one place to another in colors of their own.
+func renderGitDiff(output string, styles *Styles) string {
+	preamble, files := jj.ParseGitDiff(output)
+	jj.MarkMovedLines(files)
+
+	var out strings.Builder
+
+	if preamble != "" {
+		out.WriteString(preamble)
+		out.WriteString("\n")
+	}
+
+	for _, file := range files {
+		fmt.Fprintf(&out, "%s regular file %s:\n", gitDiffStatusWord(file.Status), file.Path)
+
+		if file.Binary != "" {
+			out.WriteString("    " + file.Binary + "\n")
+		}
+
+		for i, hunk := range file.Hunks {
+			if i > 0 {
+				out.WriteString(gitDiffHunkSeparator + "\n")
+			}
+
+			for _, line := range hunk.Lines {
+				out.WriteString(renderGitDiffLine(line, styles))
+				out.WriteString("\n")
+			}
+		}
+	}
+
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+// gitDiffStatusWord names a file status the way jj's file headers do.
+func gitDiffStatusWord(status jj.FileStatus) string {
+	switch status {
+	case jj.FileAdded:
+		return "Added"
+	case jj.FileDeleted:
+		return "Removed"
+	case jj.FileModified, jj.FileRenamed, jj.FileCopied:
+	}
+
+	return "Modified"
+}
+
+// renderGitDiffLine renders one hunk line with its line-number gutter.
+func renderGitDiffLine(line jj.DiffLine, styles *Styles) string {
+	gutter := fmt.Sprintf("%4s %4s: ", gutterNumber(line.OldLine), gutterNumber(line.NewLine))
+
+	// A moved line is the same on both sides, so has no words to emphasize
+	switch {
+	case line.Moved && line.Kind == jj.DiffAdded:
+		return styles.DiffMovedTo.Render(gutter + line.Text)
+	case line.Moved && line.Kind == jj.DiffRemoved:
+		return styles.DiffMovedFrom.Render(gutter + line.Text)
+	}
+
+	switch line.Kind {
+	case jj.DiffAdded:
+		return styles.DiffAdded.Render(gutter) + emphasizeSpans(line.Text, line.Changed, styles.DiffAdded, styles.DiffAddedWord)
+	case jj.DiffRemoved:
+		return styles.DiffRemoved.Render(gutter) + emphasizeSpans(line.Text, line.Changed, styles.DiffRemoved, styles.DiffRemovedWord)
+	case jj.DiffContext:
+	}
+
+	return gutter + line.Text
+}
+
+// emphasizeSpans renders text in base style with the spans in word style.
+func emphasizeSpans(text string, spans []jj.Span, base, word lipgloss.Style) string {
+	var out strings.Builder
+
+	pos := 0
+
+	for _, span := range spans {
+		if span.Start > pos {
+			out.WriteString(base.Render(text[pos:span.Start]))
+		}
+
+		out.WriteString(word.Render(text[span.Start:span.End]))
+		pos = span.End
+	}
+
+	if pos < len(text) {
+		out.WriteString(base.Render(text[pos:]))
+	}
+
+	return out.String()
+}
+
+// gutterNumber formats a gutter line number, blank for the missing side.
+func gutterNumber(n int) string {
+	if n == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(n)
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestRenderGitDiff_JJLayout(t *testing.T) {
+	diff := `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1,2 +1,2 @@
+ package main
+-var x = 1
++var x = 2
+@@ -10,1 +10,1 @@
+-a
++b`
+
+	out := StripANSI(renderGitDiff(diff, NewStyles()))
+	lines := strings.Split(out, "\n")
+
+	want := []string{
+		"Modified regular file main.go:",
+		"   1    1: package main",
+		"   2     : var x = 1",
+		"        2: var x = 2",
+		gitDiffHunkSeparator,
+		"  10     : a",
+		"       10: b",
+	}
+
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d lines, got %d:\n%s", len(want), len(lines), out)
+	}
+
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d: expected %q, got %q", i, want[i], lines[i])
+		}
+	}
+
+	if hunks := jj.FindHunks(out); len(hunks) != 1 {
+		t.Errorf("rendered diff should keep file sections navigable, got %d", len(hunks))
+	}
+}
+
+func TestRenderGitDiff_BinaryFile(t *testing.T) {
+	diff := "diff --git a/logo.png b/logo.png\nnew file mode 100644\nindex 0000000..1234567\n" +
+		"Binary files /dev/null and b/logo.png differ: image added (16×8 PNG, 52 B)"
+
+	want := "Added regular file logo.png:\n    image added (16×8 PNG, 52 B)"
+	if got := StripANSI(renderGitDiff(diff, NewStyles())); got != want {
+		t.Errorf("renderGitDiff() = %q, want %q", got, want)
+	}
+}
+
+func TestRenderGitDiff_MovedLines(t *testing.T) {
+	diff := "diff --git a/a.go b/a.go\n--- a/a.go\n+++ b/a.go\n@@ -1,3 +1,3 @@\n" +
+		"-return computeTheAnswer()\n x\n+return computeTheAnswer()"
+	styles := NewStyles()
+
+	out := renderGitDiff(diff, styles)
+
+	if !strings.Contains(out, styles.DiffMovedTo.Render("        2: return computeTheAnswer()")) {
+		t.Errorf("the added copy of a moved line should be drawn as moved:\n%q", out)
+	}
+
+	if !strings.Contains(out, styles.DiffMovedFrom.Render("   1     : return computeTheAnswer()")) {
+		t.Errorf("the removed copy of a moved line should be drawn as moved:\n%q", out)
+	}
+}
+
+func TestEmphasizeSpans_CoversText(t *testing.T) {
+	styles := NewStyles()
+	text := "func run(a, b int) {}"
+
+	out := emphasizeSpans(text, []jj.Span{{Start: 10, End: 13}}, styles.DiffAdded, styles.DiffAddedWord)
+
+	if StripANSI(out) != text {
+		t.Errorf("emphasis should not change the text, got %q", StripANSI(out))
+	}
+}
+
+func TestDiffPanel_RendersGitDiff(t *testing.T) {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetSize(80, 20)
+	panel.SetDiff("diff --git a/a.txt b/a.txt\n--- a/a.txt\n+++ b/a.txt\n@@ -1 +1 @@\n-old\n+new")
+
+	if !strings.Contains(StripANSI(panel.viewport.View()), "Modified regular file a.txt:") {
+		t.Error("git diffs should be shown in jj's layout")
+	}
+}
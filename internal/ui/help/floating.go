@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"charm.land/lipgloss/v2"
+
+	"github.com/chatter/chado/internal/ui/theme"
 )
 
 const (
@@ -21,26 +23,39 @@ type FloatingHelp struct {
 	width    int
 	height   int
 	bindings []Binding
+	notes    []string // shown under the bindings, e.g. features jj lacks
 
 	// Styles (cached for frame size calculations)
 	borderStyle lipgloss.Style
 	titleStyle  lipgloss.Style
 	footerStyle lipgloss.Style
+	keyStyle    lipgloss.Style
+	descStyle   lipgloss.Style
+	headerStyle lipgloss.Style
 }
 
 // NewFloatingHelp creates a new floating help modal.
 func NewFloatingHelp() *FloatingHelp {
-	return &FloatingHelp{
-		borderStyle: lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("62")).
-			Padding(0, 1),
-		titleStyle: lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("86")),
-		footerStyle: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241")),
-	}
+	f := &FloatingHelp{}
+	f.SetTheme(theme.Default())
+
+	return f
+}
+
+// SetTheme colors the modal from t.
+func (f *FloatingHelp) SetTheme(t theme.Theme) {
+	f.borderStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(t.OverlayBorder)).
+		Padding(0, 1)
+	f.titleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(t.OverlayTitle))
+	f.footerStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(t.Secondary))
+	f.keyStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.OverlayTitle))
+	f.descStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.Text))
+	f.headerStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(t.OverlayBorder)).Underline(true)
 }
 
 // SetSize sets the available size for the modal.
@@ -54,6 +69,11 @@ func (f *FloatingHelp) SetBindings(bindings []Binding) {
 	f.bindings = bindings
 }
 
+// SetNotes sets lines shown under the keybindings; nil shows none.
+func (f *FloatingHelp) SetNotes(notes []string) {
+	f.notes = notes
+}
+
 // View renders the floating help modal.
 func (f *FloatingHelp) View() string {
 	if f.width <= 0 || f.height <= 0 {
@@ -86,17 +106,35 @@ func (f *FloatingHelp) View() string {
 	titleWidth := lipgloss.Width(title)
 	footerWidth := lipgloss.Width(footer)
 
-	// Modal width = max of title, content, footer (capped by maxInnerWidth)
-	innerWidth := min(max(titleWidth, contentWidth, footerWidth), maxInnerWidth)
+	notesWidth := 0
+	for _, note := range f.notes {
+		notesWidth = max(notesWidth, lipgloss.Width(note))
+	}
+
+	// Modal width = max of title, content, notes, footer (capped by maxInnerWidth)
+	innerWidth := min(max(titleWidth, contentWidth, notesWidth, footerWidth), maxInnerWidth)
 
 	// Right-align footer
 	if footerWidth < innerWidth {
 		footer = strings.Repeat(" ", innerWidth-footerWidth) + footer
 	}
 
+	// Notes go between the bindings and the footer, wrapped to the width and
+	// cut short to leave a blank line and at least one line of bindings
+	notes := ""
+	if len(f.notes) > 0 {
+		noteLines := strings.Split(f.footerStyle.Width(innerWidth).Render(strings.Join(f.notes, "\n")), "\n")
+		noteLines = noteLines[:min(len(noteLines), max(maxInnerHeight-floatingChromeLines-2, 0))]
+		notes = strings.Join(noteLines, "\n")
+	}
+
 	// Calculate available height for content
-	// title (1) + blank (1) + content + blank (1) + footer (1) = 4 + content
+	// title (1) + blank (1) + content + blank (1) + footer (1) = 4 + content,
+	// plus the notes and a blank line above them
 	availableContentHeight := maxInnerHeight - floatingChromeLines
+	if notes != "" {
+		availableContentHeight -= lipgloss.Height(notes) + 1
+	}
 
 	// Truncate content if needed
 	contentLines := strings.Split(strings.TrimRight(content, "\n"), "\n")
@@ -107,7 +145,12 @@ func (f *FloatingHelp) View() string {
 	content = strings.Join(contentLines, "\n")
 
 	// Combine vertically with spacing
-	fullContent := lipgloss.JoinVertical(lipgloss.Left, title, "", content, "", footer)
+	parts := []string{title, "", content}
+	if notes != "" {
+		parts = append(parts, "", notes)
+	}
+
+	fullContent := lipgloss.JoinVertical(lipgloss.Left, append(parts, "", footer)...)
 
 	return f.borderStyle.Render(fullContent)
 }
@@ -118,6 +161,7 @@ func categoryOrder() []Category {
 		CategoryNavigation,
 		CategoryActions,
 		CategoryDiff,
+		CategoryView,
 	}
 }
 
@@ -236,10 +280,6 @@ func (f *FloatingHelp) renderColumns(groups map[Category][]Binding, maxWidth int
 
 // buildColumns creates column structures for each category.
 func (f *FloatingHelp) buildColumns(groups map[Category][]Binding) []column {
-	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("86"))
-	descStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
-	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("62")).Underline(true)
-
 	var columns []column
 
 	for _, cat := range categoryOrder() {
@@ -261,7 +301,7 @@ func (f *FloatingHelp) buildColumns(groups map[Category][]Binding) []column {
 		// Build column lines
 		var lines []string
 
-		lines = append(lines, headerStyle.Render(string(cat)))
+		lines = append(lines, f.headerStyle.Render(string(cat)))
 		colWidth := lipgloss.Width(string(cat))
 
 		for _, hb := range bindings {
@@ -269,8 +309,8 @@ func (f *FloatingHelp) buildColumns(groups map[Category][]Binding) []column {
 			const keyColumnPadding = 2
 
 			help := hb.Key.Help()
-			key := keyStyle.Width(maxKeyWidth + keyColumnPadding).Render(help.Key)
-			desc := descStyle.Render(help.Desc)
+			key := f.keyStyle.Width(maxKeyWidth + keyColumnPadding).Render(help.Key)
+			desc := f.descStyle.Render(help.Desc)
 			line := key + desc
 			lines = append(lines, line)
 
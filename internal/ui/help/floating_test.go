@@ -250,6 +250,48 @@ func TestFloating_EmptyBindingsShowsEmptyModal(t *testing.T) {
 	})
 }
 
+func TestFloating_NotesShownUnderBindings(t *testing.T) {
+	fh := NewFloatingHelp()
+	fh.SetSize(100, 30)
+	fh.SetBindings([]Binding{{
+		Key:      key.NewBinding(key.WithKeys("q"), key.WithHelp("q", "quit")),
+		Category: CategoryActions,
+	}})
+	fh.SetNotes([]string{"evolog needs jj 0.30.0"})
+
+	view := stripANSI(fh.View())
+
+	quit := strings.Index(view, "quit")
+	note := strings.Index(view, "evolog needs jj 0.30.0")
+	footer := strings.Index(view, "? to close")
+
+	if quit < 0 || note < quit || footer < note {
+		t.Errorf("expected the note between the bindings and the footer:\n%s", view)
+	}
+}
+
+func TestFloating_SizeConstraintsRespectedWithNotes(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		width := rapid.IntRange(40, 120).Draw(t, "width")
+		height := rapid.IntRange(10, 40).Draw(t, "height")
+
+		fh := NewFloatingHelp()
+		fh.SetSize(width, height)
+		fh.SetBindings(generateFloatingBindings(t))
+		fh.SetNotes(rapid.SliceOfN(rapid.StringMatching(`[a-z ]{1,100}`), 0, 3).Draw(t, "notes"))
+
+		view := fh.View()
+
+		if viewWidth := lipgloss.Width(view); viewWidth > width {
+			t.Errorf("view width %d exceeds specified width %d", viewWidth, width)
+		}
+
+		if viewHeight := lipgloss.Height(view); viewHeight > height {
+			t.Errorf("view height %d exceeds specified height %d", viewHeight, height)
+		}
+	})
+}
+
 func abs(x int) int {
 	if x < 0 {
 		return -x
@@ -4,27 +4,80 @@ import (
 	"strings"
 
 	"charm.land/lipgloss/v2"
+
+	"github.com/chatter/chado/internal/ui/theme"
 )
 
 // StatusBar renders a minimal status line: key hints and right-aligned version.
 type StatusBar struct {
-	width   int
-	version string
+	width    int
+	version  string
+	hint     string // optional one-line tip shown after the key hints
+	activity string // what chado is busy with, shown ahead of the tip
+	readOnly bool   // flag read-only mode ahead of the version
+	travelOp string // operation the log is shown at, flagged like read-only
+	paused   bool   // flag that file changes don't refresh the views
+	fetched  string // when the remotes were last fetched, e.g. "14:05"
 
 	// Styles
 	keyStyle  lipgloss.Style
 	descStyle lipgloss.Style
 	sepStyle  lipgloss.Style
+	hintStyle lipgloss.Style
 }
 
 // NewStatusBar creates a new status bar that displays the given version string.
 func NewStatusBar(version string) *StatusBar {
-	return &StatusBar{
-		version:   version,
-		keyStyle:  lipgloss.NewStyle().Foreground(lipgloss.Color("#999999")),
-		descStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("#777777")),
-		sepStyle:  lipgloss.NewStyle().Foreground(lipgloss.Color("#555555")),
-	}
+	s := &StatusBar{version: version}
+	s.SetTheme(theme.Default())
+
+	return s
+}
+
+// SetTheme colors the status bar from t.
+func (s *StatusBar) SetTheme(t theme.Theme) {
+	s.keyStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.StatusKey))
+	s.descStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.StatusDesc))
+	s.sepStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.StatusSeparator))
+	s.hintStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.StatusDesc)).Italic(true)
+}
+
+// SetHint sets a tip shown after the key hints; empty clears it.
+func (s *StatusBar) SetHint(hint string) {
+	s.hint = hint
+}
+
+// SetActivity sets what chado is busy with (e.g. a running jj command);
+// empty clears it.
+func (s *StatusBar) SetActivity(activity string) {
+	s.activity = activity
+}
+
+// SetReadOnly sets whether the status bar flags read-only mode.
+func (s *StatusBar) SetReadOnly(readOnly bool) {
+	s.readOnly = readOnly
+}
+
+// SetTimeTravel flags that the repo is shown as of operation opID; empty
+// clears the flag.
+func (s *StatusBar) SetTimeTravel(opID string) {
+	s.travelOp = opID
+}
+
+// SetRefreshPaused sets whether the status bar flags auto-refresh as paused.
+func (s *StatusBar) SetRefreshPaused(paused bool) {
+	s.paused = paused
+}
+
+// SetLastFetch shows when the remotes were last fetched (e.g. "14:05");
+// empty hides it.
+func (s *StatusBar) SetLastFetch(fetched string) {
+	s.fetched = fetched
+}
+
+// Hint returns the tip currently shown.
+func (s *StatusBar) Hint() string {
+	return s.hint
 }
 
 // SetWidth sets the available width for rendering.
@@ -43,14 +96,56 @@ func (s *StatusBar) View() string {
 	sep := s.sepStyle.Render(" • ")
 
 	left := help + sep + quit
-	leftWidth := lipgloss.Width(left)
 
 	// If hints + version don't fit, drop the version.
 	const minGap = 1
 
-	version := s.version
+	// Read-only mode, time travel, paused refresh, and the last fetch are
+	// flagged ahead of the version, and outlast it
+	var flags []string
+	if s.travelOp != "" {
+		flags = append(flags, s.keyStyle.Render("time travel @ "+s.travelOp))
+	}
+
+	if s.paused {
+		flags = append(flags, s.keyStyle.Render("auto-refresh paused"))
+	}
+
+	if s.fetched != "" {
+		flags = append(flags, s.descStyle.Render("fetched "+s.fetched))
+	}
+
+	if s.readOnly {
+		flags = append(flags, s.keyStyle.Render("read-only"))
+	}
+
+	flag := strings.Join(flags, " ")
+
+	version := strings.TrimSpace(flag + " " + s.version)
 	versionWidth := lipgloss.Width(version)
 
+	// Running work matters more than the version, which is dropped first
+	if s.activity != "" {
+		withActivity := left + sep + s.descStyle.Render(s.activity)
+		if lipgloss.Width(withActivity) <= s.width {
+			left = withActivity
+		}
+	}
+
+	// The tip is the least important element: only show it when everything fits.
+	if s.hint != "" {
+		withHint := left + sep + s.hintStyle.Render(s.hint)
+		if lipgloss.Width(withHint)+minGap+versionWidth <= s.width {
+			left = withHint
+		}
+	}
+
+	leftWidth := lipgloss.Width(left)
+
+	if flag != "" && leftWidth+minGap+versionWidth > s.width {
+		version, versionWidth = flag, lipgloss.Width(flag)
+	}
+
 	if leftWidth+minGap+versionWidth > s.width {
 		padding := max(s.width-leftWidth, 0)
 
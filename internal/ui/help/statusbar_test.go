@@ -78,3 +78,149 @@ func TestStatusBar_ZeroWidthReturnsEmpty(t *testing.T) {
 		t.Errorf("expected empty view for zero width, got: %q", view)
 	}
 }
+
+func TestStatusBar_HintShownWhenRoomAvailable(t *testing.T) {
+	sb := NewStatusBar("v1.0.0")
+	sb.SetWidth(100)
+	sb.SetHint("tip: d edits the description")
+
+	if !strings.Contains(sb.View(), "tip: d edits the description") {
+		t.Errorf("hint should appear at width 100: %q", sb.View())
+	}
+
+	sb.SetHint("")
+
+	if strings.Contains(sb.View(), "tip:") {
+		t.Errorf("cleared hint should not appear: %q", sb.View())
+	}
+}
+
+func TestStatusBar_HintDroppedWhenNarrow(t *testing.T) {
+	sb := NewStatusBar("v1.0.0")
+	sb.SetWidth(30)
+	sb.SetHint("tip: d edits the description")
+
+	view := sb.View()
+	if strings.Contains(view, "tip:") {
+		t.Errorf("hint should be dropped when it doesn't fit: %q", view)
+	}
+
+	if lipgloss.Width(view) > 30 {
+		t.Errorf("view width %d exceeds 30", lipgloss.Width(view))
+	}
+}
+
+func TestStatusBar_ActivityBeatsVersion(t *testing.T) {
+	sb := NewStatusBar("v1.0.0")
+	sb.SetWidth(50)
+	sb.SetActivity("⠋ running jj git push -r abc…")
+
+	view := sb.View()
+	if !strings.Contains(view, "running jj git push") {
+		t.Errorf("activity should show: %q", view)
+	}
+
+	if strings.Contains(view, "v1.0.0") {
+		t.Errorf("the version should make way for the activity: %q", view)
+	}
+
+	sb.SetActivity("")
+
+	if strings.Contains(sb.View(), "running") {
+		t.Errorf("cleared activity should not appear: %q", sb.View())
+	}
+}
+
+func TestStatusBar_ActivityNeverExceedsWidth(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		width := rapid.IntRange(20, 120).Draw(t, "width")
+
+		sb := NewStatusBar("v1.0.0")
+		sb.SetWidth(width)
+		sb.SetActivity(rapid.StringMatching(`[a-z ]{0,80}`).Draw(t, "activity"))
+		sb.SetHint(rapid.StringMatching(`[a-z ]{0,40}`).Draw(t, "hint"))
+
+		if viewWidth := lipgloss.Width(sb.View()); viewWidth > width {
+			t.Fatalf("view width %d exceeds %d", viewWidth, width)
+		}
+	})
+}
+
+func TestStatusBar_ReadOnlyOutlastsVersion(t *testing.T) {
+	sb := NewStatusBar("v1.0.0")
+	sb.SetReadOnly(true)
+	sb.SetWidth(80)
+
+	if view := sb.View(); !strings.Contains(view, "read-only") || !strings.Contains(view, "v1.0.0") {
+		t.Errorf("read-only mode should be flagged ahead of the version: %q", view)
+	}
+
+	sb.SetWidth(28)
+
+	view := sb.View()
+	if !strings.Contains(view, "read-only") || strings.Contains(view, "v1.0.0") {
+		t.Errorf("the version should make way for the read-only flag: %q", view)
+	}
+}
+
+func TestStatusBar_TimeTravelFlagged(t *testing.T) {
+	sb := NewStatusBar("v1.0.0")
+	sb.SetTimeTravel("3f2a9c1b7e44")
+	sb.SetWidth(80)
+
+	if view := sb.View(); !strings.Contains(view, "3f2a9c1b7e44") {
+		t.Errorf("time travel should name the operation shown: %q", view)
+	}
+
+	sb.SetTimeTravel("")
+
+	if view := sb.View(); strings.Contains(view, "time travel") {
+		t.Errorf("back in the present the flag should go: %q", view)
+	}
+}
+
+func TestStatusBar_RefreshPausedFlagged(t *testing.T) {
+	sb := NewStatusBar("v1.0.0")
+	sb.SetRefreshPaused(true)
+	sb.SetWidth(80)
+
+	if view := sb.View(); !strings.Contains(view, "auto-refresh paused") {
+		t.Errorf("paused auto-refresh should be flagged: %q", view)
+	}
+
+	sb.SetRefreshPaused(false)
+
+	if view := sb.View(); strings.Contains(view, "paused") {
+		t.Errorf("resumed auto-refresh should not be flagged: %q", view)
+	}
+}
+
+func TestStatusBar_LastFetchShown(t *testing.T) {
+	sb := NewStatusBar("v1.0.0")
+	sb.SetWidth(80)
+
+	if view := sb.View(); strings.Contains(view, "fetched") {
+		t.Errorf("before any fetch nothing should be shown: %q", view)
+	}
+
+	sb.SetLastFetch("14:05")
+
+	if view := sb.View(); !strings.Contains(view, "fetched 14:05") || !strings.Contains(view, "v1.0.0") {
+		t.Errorf("the last fetch should show ahead of the version: %q", view)
+	}
+}
+
+func TestStatusBar_ReadOnlyNeverExceedsWidth(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		width := rapid.IntRange(20, 120).Draw(t, "width")
+
+		sb := NewStatusBar("v1.0.0")
+		sb.SetReadOnly(true)
+		sb.SetWidth(width)
+		sb.SetHint(rapid.StringMatching(`[a-z ]{0,40}`).Draw(t, "hint"))
+
+		if viewWidth := lipgloss.Width(sb.View()); viewWidth > width {
+			t.Fatalf("view width %d exceeds %d", viewWidth, width)
+		}
+	})
+}
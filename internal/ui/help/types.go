@@ -15,6 +15,8 @@ const (
 	CategoryActions Category = "Actions"
 	// CategoryDiff groups diff-specific navigation bindings.
 	CategoryDiff Category = "Diff"
+	// CategoryView groups toggles that change how content is displayed.
+	CategoryView Category = "View"
 )
 
 // Binding contains display information for a keybinding.
@@ -0,0 +1,81 @@
+package help
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/chatter/chado/internal/ui/theme"
+)
+
+// whichKeyKeyPadding is the space between a continuation's keys and what
+// it does.
+const whichKeyKeyPadding = 2
+
+// WhichKey renders a small popup, shown while the first key of a multi-key
+// command waits for the rest, listing the keys that can follow it.
+type WhichKey struct {
+	prefix   string
+	bindings []Binding
+
+	// Styles
+	borderStyle lipgloss.Style
+	titleStyle  lipgloss.Style
+	keyStyle    lipgloss.Style
+	descStyle   lipgloss.Style
+}
+
+// NewWhichKey creates a new pending key popup.
+func NewWhichKey() *WhichKey {
+	w := &WhichKey{}
+	w.SetTheme(theme.Default())
+
+	return w
+}
+
+// SetTheme colors the popup from t.
+func (w *WhichKey) SetTheme(t theme.Theme) {
+	w.borderStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(t.OverlayBorder)).
+		Padding(0, 1)
+	w.titleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(t.OverlayTitle))
+	w.keyStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.OverlayTitle))
+	w.descStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.Text))
+}
+
+// SetPending sets the key typed so far and the bindings that can follow it.
+func (w *WhichKey) SetPending(prefix string, bindings []Binding) {
+	w.prefix = prefix
+	w.bindings = bindings
+}
+
+// View renders the popup, or "" when no enabled binding can follow the
+// pending key.
+func (w *WhichKey) View() string {
+	var bindings []Binding
+
+	keyWidth := 0
+
+	for _, b := range w.bindings {
+		if b.Key.Enabled() {
+			bindings = append(bindings, b)
+			keyWidth = max(keyWidth, lipgloss.Width(b.Key.Help().Key))
+		}
+	}
+
+	if len(bindings) == 0 {
+		return ""
+	}
+
+	lines := []string{w.titleStyle.Render(w.prefix + "…")}
+
+	for _, b := range bindings {
+		h := b.Key.Help()
+		lines = append(lines, w.keyStyle.Width(keyWidth+whichKeyKeyPadding).Render(h.Key)+w.descStyle.Render(h.Desc))
+	}
+
+	return w.borderStyle.Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,65 @@
+package help
+
+import (
+	"strings"
+	"testing"
+
+	"charm.land/bubbles/v2/key"
+	"pgregory.net/rapid"
+)
+
+func TestWhichKey_ListsContinuations(t *testing.T) {
+	w := NewWhichKey()
+	w.SetPending("z", []Binding{
+		{Key: key.NewBinding(key.WithKeys("z"), key.WithHelp("zz", "center"))},
+		{Key: key.NewBinding(key.WithKeys("t"), key.WithHelp("zt", "top"))},
+	})
+
+	view := stripANSI(w.View())
+
+	for _, want := range []string{"z…", "zz", "center", "zt", "top"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("popup should show %q: %q", want, view)
+		}
+	}
+}
+
+func TestWhichKey_EmptyWithoutContinuations(t *testing.T) {
+	disabled := key.NewBinding(key.WithKeys("g"), key.WithHelp("gg", "top"))
+	disabled.SetEnabled(false)
+
+	w := NewWhichKey()
+	w.SetPending("g", []Binding{{Key: disabled}})
+
+	if view := w.View(); view != "" {
+		t.Errorf("a pending key with nothing enabled to follow it shows no popup: %q", view)
+	}
+}
+
+// Property: every enabled continuation is listed, and no disabled one
+func TestWhichKey_EnabledBindingsListed(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		bindings := generateFloatingBindings(t)
+
+		w := NewWhichKey()
+		w.SetPending("g", bindings)
+
+		lines := strings.Split(stripANSI(w.View()), "\n")
+
+		for _, b := range bindings {
+			h := b.Key.Help()
+			listed := false
+
+			for _, line := range lines {
+				fields := strings.Fields(strings.Trim(line, "│ "))
+				if len(fields) == 2 && fields[0] == h.Key && fields[1] == h.Desc {
+					listed = true
+				}
+			}
+
+			if listed != b.Key.Enabled() {
+				t.Fatalf("%s %s listed %v, enabled %v", h.Key, h.Desc, listed, b.Key.Enabled())
+			}
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// CommitIDPlaceholder stands for the commit in a commit URL template.
+const CommitIDPlaceholder = "{commit_id}"
+
+// hyperlinkEnd closes an OSC 8 hyperlink.
+const hyperlinkEnd = "\x1b]8;;\x1b\\"
+
+// hyperlinkStart opens an OSC 8 hyperlink to target, which terminals that
+// support it make clickable and others ignore.
+func hyperlinkStart(target string) string {
+	return "\x1b]8;;" + target + "\x1b\\"
+}
+
+// Hyperlink makes text a link to target.
+func Hyperlink(text, target string) string {
+	return hyperlinkStart(target) + text + hyperlinkEnd
+}
+
+// FileURL returns the file:// URL of path, relative to root.
+func FileURL(root, path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(filepath.Join(root, path))}).String()
+}
+
+// CommitURL fills a commit URL template in for commitID, or returns "" when
+// there is no template or no commit.
+func CommitURL(template, commitID string) string {
+	if template == "" || commitID == "" {
+		return ""
+	}
+
+	return strings.ReplaceAll(template, CommitIDPlaceholder, commitID)
+}
+
+// linkText makes the last occurrence of text in an ANSI-colored line a
+// link to target, however the colors split it. A line without text is
+// returned as is.
+func linkText(line, text, target string) string {
+	start := strings.LastIndex(StripANSI(line), text)
+	if start < 0 || text == "" {
+		return line
+	}
+
+	end := start + len(text)
+
+	var out strings.Builder
+
+	visible := 0 // bytes of visible text copied so far
+
+	for i := 0; i < len(line); {
+		if line[i] == '\x1b' {
+			if loc := ansiRe.FindStringIndex(line[i:]); loc != nil && loc[0] == 0 {
+				out.WriteString(line[i : i+loc[1]])
+				i += loc[1]
+
+				continue
+			}
+		}
+
+		if visible == start {
+			out.WriteString(hyperlinkStart(target))
+		}
+
+		out.WriteByte(line[i])
+		i++
+		visible++
+
+		if visible == end {
+			out.WriteString(hyperlinkEnd)
+		}
+	}
+
+	return out.String()
+}
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestLinkText_SpansColors(t *testing.T) {
+	// jj colors a commit ID's shortest unique prefix apart from the rest
+	line := "@ qpvuntsm \x1b[1m\x1b[38;5;4m23\x1b[0m\x1b[38;5;8m0dd059\x1b[39m"
+
+	got := linkText(line, "230dd059", "https://example.com/c/230dd059")
+	want := "@ qpvuntsm \x1b[1m\x1b[38;5;4m" + hyperlinkStart("https://example.com/c/230dd059") +
+		"23\x1b[0m\x1b[38;5;8m0dd059" + hyperlinkEnd + "\x1b[39m"
+
+	if got != want {
+		t.Errorf("linkText() = %q, want %q", got, want)
+	}
+
+	if linkText(line, "ffffffff", "https://example.com") != line {
+		t.Error("a line without the text should be left alone")
+	}
+}
+
+func TestCommitURL_FillsTemplate(t *testing.T) {
+	if got := CommitURL("https://example.com/c/{commit_id}", "230dd059"); got != "https://example.com/c/230dd059" {
+		t.Errorf("CommitURL() = %q", got)
+	}
+
+	if CommitURL("", "230dd059") != "" || CommitURL("https://example.com/c/{commit_id}", "") != "" {
+		t.Error("without a template or a commit there is no link")
+	}
+}
+
+func TestFileURL(t *testing.T) {
+	if got := FileURL("/repo", "src/my file.go"); got != "file:///repo/src/my%20file.go" {
+		t.Errorf("FileURL() = %q", got)
+	}
+}
+
+func TestStripANSI_RemovesHyperlinks(t *testing.T) {
+	if got := StripANSI(Hyperlink("main.go", "file:///repo/main.go")); got != "main.go" {
+		t.Errorf("StripANSI() = %q, want only the text", got)
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: linking never changes the visible text, and opens and closes one
+// link exactly when the text is there.
+func TestLinkText_KeepsVisibleText(t *testing.T) {
+	rapid.Check(t, func(rt *rapid.T) {
+		parts := rapid.SliceOf(rapid.StringMatching(`[a-z0-9 ]{0,6}`)).Draw(rt, "parts")
+		colors := rapid.SliceOfN(rapid.SampledFrom([]string{"\x1b[0m", "\x1b[1m", "\x1b[38;5;4m", ""}), len(parts), len(parts)).Draw(rt, "colors")
+		text := rapid.StringMatching(`[a-z0-9]{1,4}`).Draw(rt, "text")
+
+		var line strings.Builder
+		for i, part := range parts {
+			line.WriteString(colors[i] + part)
+		}
+
+		linked := linkText(line.String(), text, "https://example.com")
+		if StripANSI(linked) != StripANSI(line.String()) {
+			rt.Fatalf("visible text changed: %q → %q", StripANSI(line.String()), StripANSI(linked))
+		}
+
+		want := 0
+		if strings.Contains(StripANSI(line.String()), text) {
+			want = 1
+		}
+
+		if got := strings.Count(linked, hyperlinkEnd); got != want {
+			rt.Fatalf("%d links closed, want %d, in %q", got, want, linked)
+		}
+	})
+}
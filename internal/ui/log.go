@@ -3,13 +3,16 @@ package ui
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
 	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
 	"charm.land/bubbles/v2/viewport"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 
+	"github.com/chatter/chado/internal/forge"
 	"github.com/chatter/chado/internal/jj"
 	"github.com/chatter/chado/internal/ui/help"
 )
@@ -19,6 +22,8 @@ var ansiRegex = regexp.MustCompile(`\x1b\[[0-9;]*[a-zA-Z]`)
 
 // LogPanel displays the jj log.
 type LogPanel struct {
+	Navigator // count prefixes and paging keys
+
 	viewport         viewport.Model
 	styles           *Styles
 	changes          []jj.Change
@@ -26,11 +31,31 @@ type LogPanel struct {
 	focused          bool
 	width            int
 	height           int
-	rawLog           string  // Keep raw log for display
-	changeStartLines []int   // Line number where each change starts (pre-computed)
-	totalLines       int     // Total number of lines in rawLog (for bounds checking)
-	borderAnimPhase  float64 // 0..1 for focus border wrap animation
-	borderAnimating  bool    // true only while the one-shot wrap is running (explicit focus)
+	rawLog           string                       // Keep raw log for display
+	changeStartLines []int                        // Line number where each change starts (pre-computed)
+	totalLines       int                          // Total number of lines in rawLog (for bounds checking)
+	borderAnimPhase  float64                      // 0..1 for focus border wrap animation
+	borderAnimating  bool                         // true only while the one-shot wrap is running (explicit focus)
+	paneNum          int                          // pane number shown in the title
+	title            string                       // title text after the pane number
+	gone             map[string]bool              // change IDs to flag as absent from the current log
+	markedID         string                       // change marked as the "from" side of a compared range
+	commitURL        string                       // template linking commit IDs to the forge; "" for no links
+	pullRequests     map[string]forge.PullRequest // by bookmark, shown beside it
+
+	// Diff stat column, lazily populated for visible rows only
+	statMode    StatColumnMode
+	stats       map[string]jj.ChangeStat // keyed by statKey; survives log reloads
+	statPending map[string]bool          // keys requested but not yet loaded
+
+	// Search: the query typed after "/" and the indexes of the changes whose
+	// description, change ID, or bookmarks match it
+	searching    bool
+	searchInput  textinput.Model
+	searchQuery  string
+	searchRe     *regexp.Regexp // nil without a query
+	searchOrigin int            // cursor when the prompt opened
+	matches      []int
 }
 
 // NewLogPanel creates a new log panel.
@@ -39,13 +64,32 @@ func NewLogPanel(styles *Styles) LogPanel {
 	vp.SoftWrap = false // Disable word wrap, allow horizontal scrolling
 
 	return LogPanel{
-		viewport: vp,
-		styles:   styles,
-		changes:  []jj.Change{},
-		cursor:   0,
+		viewport:    vp,
+		styles:      styles,
+		changes:     []jj.Change{},
+		cursor:      0,
+		paneNum:     1,
+		title:       "Change Log",
+		stats:       make(map[string]jj.ChangeStat),
+		statPending: make(map[string]bool),
+		searchInput: newSearchInput(),
 	}
 }
 
+// SetHeading sets the pane number and title, for logs shown outside the
+// default top-left slot.
+func (p *LogPanel) SetHeading(paneNum int, title string) {
+	p.paneNum = paneNum
+	p.title = title
+}
+
+// SetGone flags changes that no longer exist in the current log, so a log
+// from an earlier operation shows what restoring that operation brings back.
+func (p *LogPanel) SetGone(changeIDs map[string]bool) {
+	p.gone = changeIDs
+	p.updateViewport()
+}
+
 // SetSize sets the panel dimensions.
 func (p *LogPanel) SetSize(width, height int) {
 	p.width = width
@@ -75,6 +119,93 @@ func (p *LogPanel) BorderAnimPhase() float64 {
 	return p.borderAnimPhase
 }
 
+// SetCommitURL links each change's commit ID to template, with
+// CommitIDPlaceholder standing for the commit; "" removes the links.
+func (p *LogPanel) SetCommitURL(template string) {
+	p.commitURL = template
+	p.updateViewport()
+}
+
+// SetPullRequests shows the pull requests opened from bookmarks, by
+// bookmark name, beside the bookmarks.
+func (p *LogPanel) SetPullRequests(prs map[string]forge.PullRequest) {
+	p.pullRequests = prs
+	p.updateViewport()
+}
+
+// StatMode returns the current diff stat column mode.
+func (p *LogPanel) StatMode() StatColumnMode {
+	return p.statMode
+}
+
+// SetStatMode sets how the diff stat column is rendered.
+func (p *LogPanel) SetStatMode(mode StatColumnMode) {
+	p.statMode = mode
+	p.updateViewport()
+}
+
+// SetStats merges loaded diff stats into the cache, keyed by revision.
+func (p *LogPanel) SetStats(stats map[string]jj.ChangeStat) {
+	for rev, stat := range stats {
+		p.stats[rev] = stat
+		delete(p.statPending, rev)
+	}
+
+	p.updateViewport()
+}
+
+// StatsFailed forgets that the stats of revs were requested after their
+// load failed, so the next PendingStatRevs asks for them again.
+func (p *LogPanel) StatsFailed(revs []string) {
+	for _, rev := range revs {
+		delete(p.statPending, rev)
+	}
+}
+
+// PendingStatRevs returns revisions of visible changes whose stats are neither
+// cached nor already requested, and marks them as requested. Returns nil when
+// the stat column is off.
+func (p *LogPanel) PendingStatRevs() []string {
+	if p.statMode == StatColumnOff {
+		return nil
+	}
+
+	viewTop := p.viewport.YOffset()
+	viewBottom := viewTop + p.viewport.Height()
+
+	var revs []string
+
+	for i, startLine := range p.changeStartLines {
+		if startLine < viewTop || i >= len(p.changes) {
+			continue
+		}
+
+		if startLine >= viewBottom {
+			break
+		}
+
+		rev := statKey(p.changes[i])
+		if _, ok := p.stats[rev]; ok || p.statPending[rev] {
+			continue
+		}
+
+		p.statPending[rev] = true
+		revs = append(revs, rev)
+	}
+
+	return revs
+}
+
+// statKey returns the cache key for a change's stat. Commit IDs are preferred
+// because a rewritten change keeps its change ID but gets a new commit.
+func statKey(change jj.Change) string {
+	if change.CommitID != "" {
+		return change.CommitID
+	}
+
+	return change.ChangeID
+}
+
 // findChangeIndex returns the index of the change with the given ID, or -1 if not found.
 func findChangeIndex(changes []jj.Change, changeID string) int {
 	for i, c := range changes {
@@ -96,6 +227,11 @@ func (p *LogPanel) SetContent(rawLog string, changes []jj.Change) {
 
 	p.rawLog = rawLog
 	p.changes = changes
+	p.findMatches()
+
+	if findChangeIndex(changes, p.markedID) < 0 {
+		p.markedID = ""
+	}
 
 	// Try to preserve selection by change ID
 	if selectedID != "" {
@@ -120,6 +256,65 @@ func (p *LogPanel) SelectedChange() *jj.Change {
 	return nil
 }
 
+// Remaining returns how many changes are listed below the selected one.
+func (p *LogPanel) Remaining() int {
+	return max(len(p.changes)-p.cursor-1, 0)
+}
+
+// SelectChangeID moves the cursor to the change with the given ID and
+// reports whether it is in the log.
+func (p *LogPanel) SelectChangeID(changeID string) bool {
+	idx := findChangeIndex(p.changes, changeID)
+	if idx < 0 {
+		return false
+	}
+
+	p.cursor = idx
+	p.updateViewport()
+
+	return true
+}
+
+// ToggleMark marks the selected change as the "from" side of a compared
+// range, or clears the mark when it is already on it.
+func (p *LogPanel) ToggleMark() {
+	sel := p.SelectedChange()
+	if sel == nil {
+		return
+	}
+
+	if p.markedID == sel.ChangeID {
+		p.markedID = ""
+	} else {
+		p.markedID = sel.ChangeID
+	}
+
+	p.updateViewport()
+}
+
+// ComparedRange returns the marked change and the selected one, when a
+// change is marked and another is selected.
+func (p *LogPanel) ComparedRange() (from, to *jj.Change, ok bool) {
+	marked := findChangeIndex(p.changes, p.markedID)
+	if marked < 0 || marked == p.cursor || p.SelectedChange() == nil {
+		return nil, nil, false
+	}
+
+	return &p.changes[marked], &p.changes[p.cursor], true
+}
+
+// MarkedRange returns the marked change, the selected one, and the changes
+// listed between them, top to bottom, when a change is marked and another
+// is selected.
+func (p *LogPanel) MarkedRange() []jj.Change {
+	marked := findChangeIndex(p.changes, p.markedID)
+	if marked < 0 || marked == p.cursor || p.SelectedChange() == nil {
+		return nil
+	}
+
+	return slices.Clone(p.changes[min(marked, p.cursor) : max(marked, p.cursor)+1])
+}
+
 // CursorUp moves the cursor up.
 func (p *LogPanel) CursorUp() {
 	if p.cursor > 0 {
@@ -136,6 +331,19 @@ func (p *LogPanel) CursorDown() {
 	}
 }
 
+// MoveBy moves the cursor by rows, up when negative, stopping at the ends.
+func (p *LogPanel) MoveBy(rows int) {
+	if len(p.changes) > 0 {
+		p.cursor = clampCursor(p.cursor, rows, len(p.changes))
+		p.updateViewport()
+	}
+}
+
+// PageRows returns how many items one screen of the panel shows.
+func (p *LogPanel) PageRows() int {
+	return entriesPerPage(p.viewport.Height(), len(p.changes), p.totalLines)
+}
+
 // GotoTop moves to the first item.
 func (p *LogPanel) GotoTop() {
 	p.cursor = 0
@@ -184,16 +392,23 @@ func (p *LogPanel) Update(msg tea.Msg) tea.Cmd {
 	}
 
 	if msg, ok := msg.(tea.KeyMsg); ok {
+		if p.searching {
+			return p.updateSearch(msg)
+		}
+
+		if _, handled := p.Navigate(p, msg); handled {
+			return nil
+		}
+
 		switch msg.String() {
-		case "j", "down":
-			p.CursorDown()
-		case "k", "up":
-			p.CursorUp()
-		case "g":
-			// Check for gg
-			p.GotoTop()
-		case "G":
-			p.GotoBottom()
+		case "/":
+			return p.StartSearch()
+		case "n":
+			p.NextMatch()
+		case "N":
+			p.PrevMatch()
+		case "space":
+			p.ToggleMark()
 		}
 	}
 
@@ -202,7 +417,10 @@ func (p *LogPanel) Update(msg tea.Msg) tea.Cmd {
 
 // View renders the panel.
 func (p *LogPanel) View() string {
-	title := p.styles.PanelTitle(1, "Change Log", p.focused)
+	title := p.styles.PanelTitle(p.paneNum, p.title+p.searchStatus(), p.focused)
+	if p.searching {
+		title += " " + p.searchInput.View()
+	}
 
 	var style lipgloss.Style
 
@@ -229,10 +447,30 @@ func (p *LogPanel) HelpBindings() []help.Binding {
 			Order:    PanelOrderPrimary,
 		},
 		{
-			Key:      key.NewBinding(key.WithKeys("g", "G"), key.WithHelp("g/G", "top/bottom")),
+			Key:      key.NewBinding(key.WithKeys("g", "G"), key.WithHelp("gg/G", "top/bottom")),
+			Category: help.CategoryNavigation,
+			Order:    PanelOrderSecondary,
+		},
+		{
+			Key:      key.NewBinding(key.WithKeys("z"), key.WithHelp("zz/zt/zb", "scroll to center/top/bottom")),
 			Category: help.CategoryNavigation,
 			Order:    PanelOrderSecondary,
 		},
+		{
+			Key:      key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "search")),
+			Category: help.CategoryNavigation,
+			Order:    PanelOrderPrimary,
+		},
+		{
+			Key:      key.NewBinding(key.WithKeys("n", "N"), key.WithHelp("n/N", "next/prev match")),
+			Category: help.CategoryNavigation,
+			Order:    PanelOrderSecondary,
+		},
+		{
+			Key:      key.NewBinding(key.WithKeys("space"), key.WithHelp("space", "mark diff from")),
+			Category: help.CategoryView,
+			Order:    PanelOrderSecondary,
+		},
 	}
 }
 
@@ -256,6 +494,15 @@ func (p *LogPanel) computeChangeStartLines() {
 	}
 }
 
+// cachedStat returns the cached stat for a change, or nil if not loaded yet.
+func (p *LogPanel) cachedStat(change jj.Change) *jj.ChangeStat {
+	if stat, ok := p.stats[statKey(change)]; ok {
+		return &stat
+	}
+
+	return nil
+}
+
 func (p *LogPanel) ensureCursorVisible() {
 	if p.cursor < 0 || p.cursor >= len(p.changeStartLines) {
 		return
@@ -272,6 +519,22 @@ func (p *LogPanel) ensureCursorVisible() {
 	}
 }
 
+// ScrollSelected scrolls the selected change's lines to anchor in the view.
+func (p *LogPanel) ScrollSelected(anchor ScrollAnchor) {
+	if p.cursor < 0 || p.cursor >= len(p.changeStartLines) {
+		return
+	}
+
+	first := p.changeStartLines[p.cursor]
+
+	last := p.totalLines - 1
+	if p.cursor+1 < len(p.changeStartLines) {
+		last = p.changeStartLines[p.cursor+1] - 1
+	}
+
+	p.viewport.SetYOffset(anchoredOffset(first, last, p.viewport.Height(), anchor))
+}
+
 // lineToChangeIndex maps a visual line number to a change index.
 // Returns -1 if the line is outside content bounds or before any change.
 func (p *LogPanel) lineToChangeIndex(visualLine int) int {
@@ -293,6 +556,11 @@ func (p *LogPanel) lineToChangeIndex(visualLine int) int {
 	return changeIdx
 }
 
+// Restyle re-renders the content in the current styles, after a theme change.
+func (p *LogPanel) Restyle() {
+	p.updateViewport()
+}
+
 func (p *LogPanel) updateViewport() {
 	if p.rawLog == "" {
 		p.viewport.SetContent("No changes")
@@ -308,11 +576,39 @@ func (p *LogPanel) updateViewport() {
 		// Check if this line starts a change (using pre-computed array)
 		isStart := nextChangeIdx < len(p.changeStartLines) && i == p.changeStartLines[nextChangeIdx]
 
-		// Add selection indicator on the start line of the selected change
-		if isStart && nextChangeIdx == p.cursor {
-			fmt.Fprintf(&result, "→ %s\n", line)
-		} else {
-			fmt.Fprintf(&result, "  %s\n", line)
+		// The change this line belongs to
+		changeIdx := nextChangeIdx - 1
+		if isStart {
+			changeIdx = nextChangeIdx
+		}
+
+		statCell := blankStatCell(p.statMode)
+		if isStart && nextChangeIdx < len(p.changes) {
+			change := p.changes[nextChangeIdx]
+			statCell = renderStatCell(p.statMode, p.cachedStat(change), p.styles)
+
+			if target := CommitURL(p.commitURL, change.CommitID); target != "" {
+				line = linkText(line, change.CommitID, target)
+			}
+
+			line += renderBadges(change, p.pullRequests, p.styles)
+		}
+
+		line = p.highlightLine(line, changeIdx)
+
+		if isStart && nextChangeIdx < len(p.changes) && p.gone[p.changes[nextChangeIdx].ChangeID] {
+			line += " " + p.styles.BadgeGone.Render("[gone]")
+		}
+
+		// Add selection indicator on the start line of the selected change,
+		// and mark the change a range is compared from
+		switch {
+		case isStart && nextChangeIdx == p.cursor:
+			fmt.Fprintf(&result, "%s%s%s\n", p.styles.Cursor(), statCell, line)
+		case isStart && p.markedID != "" && nextChangeIdx < len(p.changes) && p.changes[nextChangeIdx].ChangeID == p.markedID:
+			fmt.Fprintf(&result, "%s %s%s\n", p.styles.Mark(), statCell, line)
+		default:
+			fmt.Fprintf(&result, "  %s%s\n", statCell, line)
 		}
 
 		if isStart {
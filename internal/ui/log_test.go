@@ -2,9 +2,12 @@ package ui
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"testing"
 
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
 	"pgregory.net/rapid"
 
 	"github.com/chatter/chado/internal/jj"
@@ -292,6 +295,151 @@ func TestLogPanel_SetContent_EmptyChanges(t *testing.T) {
 	}
 }
 
+func TestLogPanel_Remaining(t *testing.T) {
+	panel := newLogSearchTestPanel()
+
+	if got := panel.Remaining(); got != 3 {
+		t.Errorf("expected 3 changes below the first, got %d", got)
+	}
+
+	panel.GotoBottom()
+
+	if got := panel.Remaining(); got != 0 {
+		t.Errorf("expected none below the last, got %d", got)
+	}
+
+	empty := NewLogPanel(NewStyles())
+	if got := empty.Remaining(); got != 0 {
+		t.Errorf("an empty log has nothing below, got %d", got)
+	}
+}
+
+func TestLogPanel_ComparedRange_FromMarkToSelection(t *testing.T) {
+	panel := newLogSearchTestPanel()
+
+	panel.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+
+	if _, _, ok := panel.ComparedRange(); ok {
+		t.Fatal("the marked change should not be compared with itself")
+	}
+
+	panel.CursorDown()
+	panel.CursorDown()
+
+	from, to, ok := panel.ComparedRange()
+	if !ok {
+		t.Fatal("expected the marked and selected changes to be compared")
+	}
+
+	if from.ChangeID != "aaaaaaaa" || to.ChangeID != "cccccccc" {
+		t.Errorf("compared %s → %s, want aaaaaaaa → cccccccc", from.ChangeID, to.ChangeID)
+	}
+
+	lines := strings.Split(stripTestANSI(panel.viewport.GetContent()), "\n")
+	if !strings.HasPrefix(lines[0], "• ") {
+		t.Errorf("marked change should be flagged, got %q", lines[0])
+	}
+}
+
+func TestLogPanel_MonochromeMarksInASCII(t *testing.T) {
+	panel := newLogSearchTestPanel()
+	panel.styles.SetMonochrome(true)
+
+	panel.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+	panel.CursorDown()
+
+	content := stripTestANSI(panel.viewport.GetContent())
+	if !strings.HasPrefix(content, "* ") || !strings.Contains(content, "\n> ") {
+		t.Errorf("the mark and cursor should be ASCII markers, got:\n%s", content)
+	}
+
+	if strings.ContainsAny(content, "→•") {
+		t.Errorf("no marker should need more than ASCII, got:\n%s", content)
+	}
+
+	border := panel.styles.AnimatedFocusBorderStyle(0.5, panel.width, panel.height).GetBorderStyle()
+	if border != lipgloss.ThickBorder() {
+		t.Error("focus should show as a thick border, without the color blend")
+	}
+}
+
+func TestLogPanel_ViewWithoutTerminalColors(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	panel := NewLogPanel(NewStyles())
+	panel.SetSize(40, 10)
+	panel.SetFocused(true)
+
+	if view := panel.View(); !strings.Contains(StripANSI(view), "╭") {
+		t.Errorf("the border should still be drawn, got:\n%s", view)
+	}
+}
+
+func TestLogPanel_CommitLinks(t *testing.T) {
+	panel := NewLogPanel(NewStyles())
+	panel.SetSize(80, 20)
+	panel.SetContent("@ aaaaaaaa 230dd059\n│ fix parser\n", []jj.Change{{ChangeID: "aaaaaaaa", CommitID: "230dd059"}})
+
+	panel.SetCommitURL("https://example.com/c/" + CommitIDPlaceholder)
+
+	if !strings.Contains(panel.viewport.GetContent(), Hyperlink("230dd059", "https://example.com/c/230dd059")) {
+		t.Errorf("the commit ID should link to its page, got %q", panel.viewport.GetContent())
+	}
+
+	panel.SetCommitURL("")
+
+	if strings.Contains(panel.viewport.GetContent(), "\x1b]8;") {
+		t.Error("clearing the template should remove the links")
+	}
+}
+
+func TestLogPanel_ToggleMark_Clears(t *testing.T) {
+	panel := newLogSearchTestPanel()
+
+	panel.ToggleMark()
+	panel.ToggleMark()
+	panel.CursorDown()
+
+	if _, _, ok := panel.ComparedRange(); ok {
+		t.Error("marking the same change twice should clear the mark")
+	}
+}
+
+func TestLogPanel_MarkedRange(t *testing.T) {
+	panel := newLogSearchTestPanel()
+	panel.CursorDown()
+	panel.CursorDown()
+	panel.ToggleMark()
+
+	if got := panel.MarkedRange(); got != nil {
+		t.Fatalf("the marked change alone is no range, got %v", got)
+	}
+
+	panel.CursorUp()
+	panel.CursorUp()
+
+	var ids []string
+	for _, change := range panel.MarkedRange() {
+		ids = append(ids, change.ChangeID)
+	}
+
+	if want := []string{"aaaaaaaa", "bbbbbbbb", "cccccccc"}; !slices.Equal(ids, want) {
+		t.Errorf("range = %v, want %v", ids, want)
+	}
+}
+
+func TestLogPanel_Mark_DroppedWithChange(t *testing.T) {
+	panel := newLogSearchTestPanel()
+	panel.ToggleMark()
+
+	panel.SetContent("@ bbbbbbbb 2\n○ cccccccc 3\n", []jj.Change{{ChangeID: "bbbbbbbb"}, {ChangeID: "cccccccc"}})
+	panel.CursorDown()
+
+	if _, _, ok := panel.ComparedRange(); ok {
+		t.Error("the mark should be dropped once its change leaves the log")
+	}
+}
+
 // =============================================================================
 // Property Tests
 // =============================================================================
@@ -436,6 +584,34 @@ func TestIsChangeStart_ANSIInvariant(t *testing.T) {
 	})
 }
 
+// Property: a compared range always spans two different changes in the log
+func TestLogPanel_ComparedRangeDistinct(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		panel := newLogSearchTestPanel()
+
+		for _, op := range rapid.SliceOf(rapid.IntRange(0, 2)).Draw(t, "ops") {
+			switch op {
+			case 0:
+				panel.CursorUp()
+			case 1:
+				panel.CursorDown()
+			case 2:
+				panel.ToggleMark()
+			}
+		}
+
+		from, to, ok := panel.ComparedRange()
+		if ok && from.ChangeID == to.ChangeID {
+			t.Fatalf("range compares %s with itself", from.ChangeID)
+		}
+
+		if marked := panel.MarkedRange(); ok != (marked != nil) ||
+			ok && !slices.ContainsFunc(marked, func(c jj.Change) bool { return c.ChangeID == from.ChangeID }) {
+			t.Fatalf("marked range %v doesn't match compared range (%v)", marked, ok)
+		}
+	})
+}
+
 // =============================================================================
 // Mouse Click Property Tests
 // =============================================================================
@@ -646,3 +822,28 @@ func BenchmarkIsChangeStart(b *testing.B) {
 		}
 	}
 }
+
+func TestLogPanel_HeadingAndGoneBadge(t *testing.T) {
+	panel := NewLogPanel(NewStyles())
+	panel.SetSize(80, 20)
+	panel.SetHeading(0, "Log at op abc123")
+	panel.SetContent("○ aaaaaaaa one\n○ bbbbbbbb two\n", []jj.Change{
+		{ChangeID: "aaaaaaaa", Raw: "○ aaaaaaaa one"},
+		{ChangeID: "bbbbbbbb", Raw: "○ bbbbbbbb two"},
+	})
+	panel.SetGone(map[string]bool{"bbbbbbbb": true})
+
+	if panel.paneNum != 0 || panel.title != "Log at op abc123" {
+		t.Errorf("heading not applied: [%d] %s", panel.paneNum, panel.title)
+	}
+
+	for _, line := range strings.Split(StripANSI(panel.viewport.View()), "\n") {
+		hasBadge := strings.Contains(line, "[gone]")
+		if strings.Contains(line, "aaaaaaaa") && hasBadge {
+			t.Error("present change should not be flagged")
+		}
+		if strings.Contains(line, "bbbbbbbb") && !hasBadge {
+			t.Error("gone change should be flagged")
+		}
+	}
+}
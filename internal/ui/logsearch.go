@@ -0,0 +1,207 @@
+package ui
+
+import (
+	"regexp"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// StartSearch opens the search prompt. The cursor jumps to matching changes
+// as the query is typed.
+func (p *LogPanel) StartSearch() tea.Cmd {
+	p.searching = true
+	p.searchOrigin = p.cursor
+	p.searchInput.SetValue("")
+
+	return p.searchInput.Focus()
+}
+
+// Searching reports whether the search prompt is open.
+func (p *LogPanel) Searching() bool {
+	return p.searching
+}
+
+// SearchQuery returns the text being searched for, or "" when there is none.
+func (p *LogPanel) SearchQuery() string {
+	return p.searchQuery
+}
+
+// SearchMatches returns the position of the selected change among the
+// matches (1-based, 0 when it does not match) and the number of matches.
+func (p *LogPanel) SearchMatches() (current, total int) {
+	for i, idx := range p.matches {
+		if idx == p.cursor {
+			return i + 1, len(p.matches)
+		}
+	}
+
+	return 0, len(p.matches)
+}
+
+// CapturesKey reports whether the panel should receive msg ahead of any
+// global binding: while the search prompt is open, and n/N while there is a
+// query to move between matches of.
+func (p *LogPanel) CapturesKey(msg tea.KeyMsg) bool {
+	if p.searching {
+		return true
+	}
+
+	switch msg.String() {
+	case "n", "N":
+		return p.searchQuery != ""
+	}
+
+	return false
+}
+
+// NextMatch selects the next matching change below the cursor, wrapping to
+// the first.
+func (p *LogPanel) NextMatch() {
+	if len(p.matches) == 0 {
+		return
+	}
+
+	p.selectMatch(p.firstMatchFrom(p.cursor + 1))
+}
+
+// PrevMatch selects the previous matching change above the cursor, wrapping
+// to the last.
+func (p *LogPanel) PrevMatch() {
+	if len(p.matches) == 0 {
+		return
+	}
+
+	prev := p.matches[len(p.matches)-1]
+
+	for _, idx := range p.matches {
+		if idx >= p.cursor {
+			break
+		}
+
+		prev = idx
+	}
+
+	p.selectMatch(prev)
+}
+
+// updateSearch handles a key typed into the search prompt: enter keeps the
+// query, esc drops it and returns to where the search began, and anything
+// else edits it and selects the first match from there.
+func (p *LogPanel) updateSearch(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter":
+		p.searching = false
+		p.searchInput.Blur()
+
+		return nil
+	case "esc":
+		p.searching = false
+		p.searchInput.Blur()
+		p.searchQuery = ""
+		p.findMatches()
+		p.selectMatch(p.searchOrigin)
+
+		return nil
+	}
+
+	var cmd tea.Cmd
+	p.searchInput, cmd = p.searchInput.Update(msg)
+
+	if query := p.searchInput.Value(); query != p.searchQuery {
+		p.searchQuery = query
+		p.findMatches()
+
+		if len(p.matches) > 0 {
+			p.selectMatch(p.firstMatchFrom(p.searchOrigin))
+		} else {
+			p.selectMatch(p.searchOrigin)
+		}
+	}
+
+	return cmd
+}
+
+// selectMatch moves the cursor to the change at idx, if it is in the log.
+func (p *LogPanel) selectMatch(idx int) {
+	if idx >= 0 && idx < len(p.changes) {
+		p.cursor = idx
+	}
+
+	p.updateViewport()
+}
+
+// firstMatchFrom returns the index of the first matching change at or below
+// idx, wrapping to the first match. There must be at least one match.
+func (p *LogPanel) firstMatchFrom(idx int) int {
+	for _, match := range p.matches {
+		if match >= idx {
+			return match
+		}
+	}
+
+	return p.matches[0]
+}
+
+// findMatches collects the changes matching the query, in log order.
+func (p *LogPanel) findMatches() {
+	p.matches = nil
+
+	if p.searchQuery == "" {
+		p.searchRe = nil
+		return
+	}
+
+	p.searchRe = queryRegexp(p.searchQuery)
+
+	for i, change := range p.changes {
+		if changeMatches(change, p.searchRe) {
+			p.matches = append(p.matches, i)
+		}
+	}
+}
+
+// changeMatches reports whether the change's description, change ID, or a
+// bookmark matches re.
+func changeMatches(change jj.Change, re *regexp.Regexp) bool {
+	if re.MatchString(change.Description) || re.MatchString(change.ChangeID) {
+		return true
+	}
+
+	for _, bookmark := range change.Bookmarks {
+		if re.MatchString(bookmark) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isMatch reports whether the change at idx matches the query.
+func (p *LogPanel) isMatch(idx int) bool {
+	for _, match := range p.matches {
+		if match == idx {
+			return true
+		}
+	}
+
+	return false
+}
+
+// highlightLine marks the query in a line belonging to the change at idx,
+// when that change matches.
+func (p *LogPanel) highlightLine(line string, idx int) string {
+	if p.searchRe == nil || !p.isMatch(idx) {
+		return line
+	}
+
+	return highlightMatches(line, lineMatches(line, p.searchRe), -1)
+}
+
+// searchStatus summarizes the matches for the panel title.
+func (p *LogPanel) searchStatus() string {
+	current, total := p.SearchMatches()
+
+	return searchSummary(p.searchQuery, current, total)
+}
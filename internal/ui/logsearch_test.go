@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"pgregory.net/rapid"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// newLogSearchTestPanel returns a focused panel with four changes, the cursor
+// on the first.
+func newLogSearchTestPanel() LogPanel {
+	changes := []jj.Change{
+		{ChangeID: "aaaaaaaa", Description: "fix parser"},
+		{ChangeID: "bbbbbbbb", Description: "add tests", Bookmarks: []string{"main"}},
+		{ChangeID: "cccccccc", Description: "Fix lexer"},
+		{ChangeID: "dddddddd", Description: "docs"},
+	}
+
+	panel := NewLogPanel(NewStyles())
+	panel.SetSize(80, 20)
+	panel.SetFocused(true)
+	panel.SetContent("@ aaaaaaaa 1\n│ fix parser\n○ bbbbbbbb 2\n│ add tests\n○ cccccccc 3\n│ Fix lexer\n○ dddddddd 4\n│ docs\n", changes)
+
+	return panel
+}
+
+// typeLogKeys sends each key to the panel as a key press.
+func typeLogKeys(panel *LogPanel, keys string) {
+	for _, r := range keys {
+		panel.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestLogSearch_JumpsAsYouType(t *testing.T) {
+	panel := newLogSearchTestPanel()
+	panel.CursorDown()
+
+	typeLogKeys(&panel, "/fix")
+
+	if got := panel.SelectedChange().ChangeID; got != "cccccccc" {
+		t.Errorf("expected the first match below the cursor, got %s", got)
+	}
+
+	if current, total := panel.SearchMatches(); current != 2 || total != 2 {
+		t.Errorf("expected match 2 of 2, got %d/%d", current, total)
+	}
+}
+
+func TestLogSearch_MatchesIDAndBookmarks(t *testing.T) {
+	panel := newLogSearchTestPanel()
+
+	typeLogKeys(&panel, "/MAIN")
+
+	if got := panel.SelectedChange().ChangeID; got != "bbbbbbbb" {
+		t.Errorf("expected the bookmarked change, got %s", got)
+	}
+
+	panel.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	typeLogKeys(&panel, "/dddd")
+
+	if got := panel.SelectedChange().ChangeID; got != "dddddddd" {
+		t.Errorf("expected the change by ID, got %s", got)
+	}
+}
+
+func TestLogSearch_NextAndPrevWrap(t *testing.T) {
+	panel := newLogSearchTestPanel()
+	typeLogKeys(&panel, "/fix")
+	panel.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	if panel.Searching() {
+		t.Fatal("enter should close the prompt")
+	}
+
+	typeLogKeys(&panel, "n")
+
+	if got := panel.SelectedChange().ChangeID; got != "cccccccc" {
+		t.Errorf("n should select the next match, got %s", got)
+	}
+
+	typeLogKeys(&panel, "n")
+
+	if got := panel.SelectedChange().ChangeID; got != "aaaaaaaa" {
+		t.Errorf("n should wrap to the first match, got %s", got)
+	}
+
+	typeLogKeys(&panel, "N")
+
+	if got := panel.SelectedChange().ChangeID; got != "cccccccc" {
+		t.Errorf("N should wrap to the last match, got %s", got)
+	}
+}
+
+func TestLogSearch_EscRestoresSelection(t *testing.T) {
+	panel := newLogSearchTestPanel()
+	panel.CursorDown()
+
+	typeLogKeys(&panel, "/docs")
+	panel.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+
+	if got := panel.SelectedChange().ChangeID; got != "bbbbbbbb" {
+		t.Errorf("esc should return to the change selected before, got %s", got)
+	}
+
+	if panel.SearchQuery() != "" || panel.CapturesKey(tea.KeyPressMsg{Code: 'n', Text: "n"}) {
+		t.Error("esc should drop the query and release n")
+	}
+}
+
+func TestLogSearch_HighlightsMatchingChanges(t *testing.T) {
+	panel := newLogSearchTestPanel()
+	typeLogKeys(&panel, "/fix")
+
+	content := panel.viewport.GetContent()
+
+	if got := strings.Count(content, matchOn); got != 2 {
+		t.Errorf("expected both descriptions marked, got %d marks", got)
+	}
+
+	if got := panel.searchStatus(); got != " [1/2]" {
+		t.Errorf("expected the count in the title, got %q", got)
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+func TestLogSearch_CursorOnMatch(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		n := rapid.IntRange(1, 20).Draw(t, "n")
+		changes := make([]jj.Change, n)
+
+		for i := range changes {
+			changes[i] = jj.Change{
+				ChangeID:    strings.Repeat(string(rune('k'+i%16)), 8),
+				Description: rapid.StringMatching(`[ab ]{0,6}`).Draw(t, "desc"),
+			}
+		}
+
+		panel := NewLogPanel(NewStyles())
+		panel.SetFocused(true)
+		panel.SetContent("test", changes)
+
+		typeLogKeys(&panel, "/"+rapid.StringMatching(`[ab]{1,2}`).Draw(t, "query"))
+		panel.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+		typeLogKeys(&panel, rapid.StringMatching(`[nN]{0,5}`).Draw(t, "moves"))
+
+		current, total := panel.SearchMatches()
+		if total > 0 && current == 0 {
+			t.Fatalf("with %d matches the cursor should be on one", total)
+		}
+	})
+}
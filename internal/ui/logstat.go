@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// StatColumnMode controls how the per-change diff stat column is rendered.
+type StatColumnMode int
+
+const (
+	StatColumnOff       StatColumnMode = iota // No stat column
+	StatColumnCounts                          // "+12 −3" line counts
+	StatColumnSparkline                       // "+++−" proportional bar
+)
+
+const (
+	// statCountsWidth is the fixed width of the counts column ("+9999 −9999").
+	statCountsWidth = 11
+
+	// statSparklineWidth is the maximum number of cells in the sparkline bar.
+	statSparklineWidth = 6
+
+	// statThousand is the threshold above which counts are abbreviated ("1.2k").
+	statThousand = 1000
+
+	// statColumnGap separates the stat column from the log graph.
+	statColumnGap = " "
+)
+
+// Next returns the mode that follows m in the off → counts → sparkline cycle.
+func (m StatColumnMode) Next() StatColumnMode {
+	switch m {
+	case StatColumnOff:
+		return StatColumnCounts
+	case StatColumnCounts:
+		return StatColumnSparkline
+	default:
+		return StatColumnOff
+	}
+}
+
+// width returns the number of terminal cells the column occupies, including the gap.
+func (m StatColumnMode) width() int {
+	switch m {
+	case StatColumnCounts:
+		return statCountsWidth + len(statColumnGap)
+	case StatColumnSparkline:
+		return statSparklineWidth + len(statColumnGap)
+	default:
+		return 0
+	}
+}
+
+// renderStatCell renders the stat column cell for one change, padded to the column width.
+// A nil stat means the value has not been loaded yet and renders as blank.
+func renderStatCell(mode StatColumnMode, stat *jj.ChangeStat, styles *Styles) string {
+	if mode == StatColumnOff {
+		return ""
+	}
+
+	var cell string
+
+	if stat != nil {
+		switch mode {
+		case StatColumnCounts:
+			cell = styles.StatAdded.Render("+"+abbreviateCount(stat.Added)) + " " +
+				styles.StatRemoved.Render("−"+abbreviateCount(stat.Removed))
+		case StatColumnSparkline:
+			added, removed := sparklineCells(*stat)
+			cell = styles.StatAdded.Render(strings.Repeat("+", added)) +
+				styles.StatRemoved.Render(strings.Repeat("−", removed))
+		case StatColumnOff:
+		}
+	}
+
+	return padCell(cell, mode.width())
+}
+
+// blankStatCell returns the padding used on continuation lines under a stat cell.
+func blankStatCell(mode StatColumnMode) string {
+	return strings.Repeat(" ", mode.width())
+}
+
+// padCell right-pads a styled string to width cells, truncating if it is wider.
+func padCell(cell string, width int) string {
+	cellWidth := lipgloss.Width(cell)
+	if cellWidth >= width {
+		return lipgloss.NewStyle().MaxWidth(width).Render(cell)
+	}
+
+	return cell + strings.Repeat(" ", width-cellWidth)
+}
+
+// abbreviateCount formats n compactly so large changes still fit the column.
+func abbreviateCount(n int) string {
+	if n < statThousand {
+		return strconv.Itoa(n)
+	}
+
+	return strconv.FormatFloat(float64(n)/statThousand, 'f', 1, 64) + "k"
+}
+
+// sparklineCells splits the sparkline bar between added and removed cells.
+// The bar length grows logarithmically with the total so small and huge
+// changes are both distinguishable within a few cells.
+func sparklineCells(stat jj.ChangeStat) (int, int) {
+	total := stat.Added + stat.Removed
+	if total == 0 {
+		return 0, 0
+	}
+
+	cells := min(int(math.Ceil(math.Log2(float64(total)+1))), statSparklineWidth)
+	added := int(math.Round(float64(cells) * float64(stat.Added) / float64(total)))
+
+	// Never hide a non-zero side entirely.
+	if added == 0 && stat.Added > 0 {
+		added = 1
+	}
+
+	if added == cells && stat.Removed > 0 && cells > 1 {
+		added--
+	}
+
+	return added, cells - added
+}
@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"pgregory.net/rapid"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestStatColumnMode_NextCycles(t *testing.T) {
+	mode := StatColumnOff
+
+	mode = mode.Next()
+	if mode != StatColumnCounts {
+		t.Errorf("expected counts after off, got %v", mode)
+	}
+
+	mode = mode.Next()
+	if mode != StatColumnSparkline {
+		t.Errorf("expected sparkline after counts, got %v", mode)
+	}
+
+	mode = mode.Next()
+	if mode != StatColumnOff {
+		t.Errorf("expected off after sparkline, got %v", mode)
+	}
+}
+
+func TestAbbreviateCount(t *testing.T) {
+	tests := map[int]string{0: "0", 12: "12", 999: "999", 1000: "1.0k", 12345: "12.3k"}
+
+	for n, want := range tests {
+		if got := abbreviateCount(n); got != want {
+			t.Errorf("abbreviateCount(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestRenderStatCell_Counts(t *testing.T) {
+	styles := NewStyles()
+	stat := &jj.ChangeStat{Added: 12, Removed: 3}
+
+	cell := StripANSI(renderStatCell(StatColumnCounts, stat, styles))
+	if !strings.Contains(cell, "+12 −3") {
+		t.Errorf("expected counts in cell, got %q", cell)
+	}
+}
+
+func TestRenderStatCell_NotLoadedIsBlank(t *testing.T) {
+	cell := renderStatCell(StatColumnCounts, nil, NewStyles())
+	if strings.TrimSpace(cell) != "" {
+		t.Errorf("expected blank cell for unloaded stat, got %q", cell)
+	}
+}
+
+func TestLogPanel_PendingStatRevs(t *testing.T) {
+	panel := NewLogPanel(NewStyles())
+	panel.SetSize(80, 20)
+	panel.SetContent("@ aaaaaaaa 1111aaaa\n○ bbbbbbbb 2222bbbb\n", []jj.Change{
+		{ChangeID: "aaaaaaaa", CommitID: "1111aaaa", Raw: "@ aaaaaaaa 1111aaaa"},
+		{ChangeID: "bbbbbbbb", Raw: "○ bbbbbbbb 2222bbbb"},
+	})
+
+	if revs := panel.PendingStatRevs(); revs != nil {
+		t.Fatalf("expected no requests while column is off, got %v", revs)
+	}
+
+	panel.SetStatMode(StatColumnCounts)
+
+	revs := panel.PendingStatRevs()
+	if len(revs) != 2 || revs[0] != "1111aaaa" || revs[1] != "bbbbbbbb" {
+		t.Fatalf("expected commit ID then change ID fallback, got %v", revs)
+	}
+
+	if again := panel.PendingStatRevs(); len(again) != 0 {
+		t.Errorf("expected in-flight revs not to be re-requested, got %v", again)
+	}
+
+	panel.SetStats(map[string]jj.ChangeStat{"1111aaaa": {Added: 5, Removed: 1}})
+
+	if !strings.Contains(StripANSI(panel.viewport.View()), "+5 −1") {
+		t.Error("expected loaded stat to render in the log")
+	}
+}
+
+func TestLogPanel_FailedStatsRequestedAgain(t *testing.T) {
+	panel := NewLogPanel(NewStyles())
+	panel.SetSize(80, 20)
+	panel.SetStatMode(StatColumnCounts)
+	panel.SetContent("@ aaaaaaaa 1111aaaa\n", []jj.Change{
+		{ChangeID: "aaaaaaaa", CommitID: "1111aaaa", Raw: "@ aaaaaaaa 1111aaaa"},
+	})
+
+	revs := panel.PendingStatRevs()
+	panel.StatsFailed(revs)
+
+	if again := panel.PendingStatRevs(); len(again) != 1 || again[0] != "1111aaaa" {
+		t.Errorf("a stat that failed to load should be requested again, got %v", again)
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: sparkline never exceeds its width and never hides a non-zero side.
+func TestSparklineCells_Bounded(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		stat := jj.ChangeStat{
+			Added:   rapid.IntRange(0, 100000).Draw(t, "added"),
+			Removed: rapid.IntRange(0, 100000).Draw(t, "removed"),
+		}
+
+		added, removed := sparklineCells(stat)
+
+		if added < 0 || removed < 0 || added+removed > statSparklineWidth {
+			t.Fatalf("cells out of bounds: %d+%d for %+v", added, removed, stat)
+		}
+
+		if stat.Added > 0 && added == 0 {
+			t.Fatalf("added lines hidden for %+v", stat)
+		}
+
+		if stat.Added+stat.Removed > 0 && added+removed == 0 {
+			t.Fatalf("non-empty change rendered empty for %+v", stat)
+		}
+	})
+}
@@ -0,0 +1,245 @@
+package ui
+
+import (
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chatter/chado/internal/ui/help"
+)
+
+const (
+	// maxCount caps a count prefix, so a held digit can't overflow it.
+	maxCount = 9999
+
+	// countBase is the base count prefixes are typed in.
+	countBase = 10
+
+	// halfPageDivisor halves a page for ctrl+d and ctrl+u.
+	halfPageDivisor = 2
+)
+
+// Navigable is a panel the shared navigation keys move through: a list's
+// cursor, or the diff's scroll position.
+type Navigable interface {
+	MoveBy(rows int) // Moves by rows (up when negative), stopping at the ends
+	GotoTop()
+	GotoBottom()
+	PageRows() int // Rows one screen of the panel shows
+}
+
+// ScrollAnchor is where zz, zt, and zb put the selected line in the view.
+type ScrollAnchor int
+
+const (
+	AnchorCenter ScrollAnchor = iota // zz
+	AnchorTop                        // zt
+	AnchorBottom                     // zb
+)
+
+// Recenterable is a Navigable that can scroll its view around the selected
+// line, and so takes zz, zt, and zb.
+type Recenterable interface {
+	ScrollSelected(anchor ScrollAnchor)
+}
+
+// FileJumper is a Navigable that can jump between the files it shows, and
+// so takes ]f and [f.
+type FileJumper interface {
+	JumpFile(files int) // Moves by files, back when negative
+}
+
+// continuations are the two-key commands the Navigator completes, by their
+// first key, for the pending key popup.
+var continuations = map[string][]help.Binding{
+	"g": {continuation("g", "gg", "top (with a count, that row)")},
+	"z": {
+		continuation("z", "zz", "scroll selection to center"),
+		continuation("t", "zt", "scroll selection to top"),
+		continuation("b", "zb", "scroll selection to bottom"),
+	},
+	"]": {continuation("f", "]f", "next file")},
+	"[": {continuation("f", "[f", "previous file")},
+}
+
+// continuation returns the binding of a two-key command's second key,
+// shown as the whole command.
+func continuation(second, command, desc string) help.Binding {
+	return help.Binding{
+		Key:      key.NewBinding(key.WithKeys(second), key.WithHelp(command, desc)),
+		Category: help.CategoryNavigation,
+	}
+}
+
+// Navigator handles the navigation keys every panel shares, vim style: a
+// count typed first (5j) repeats a motion, ctrl+d and ctrl+u move half a
+// page, and ctrl+f (when not bound to the finder), pgdown, ctrl+b, and
+// pgup move a whole one. gg and G go to the top and bottom, or with a count
+// to that row, zz, zt, and zb scroll a Recenterable panel around its
+// selection, and ]f and [f move a FileJumper between files. Panels embed it
+// and call Navigate first.
+type Navigator struct {
+	count   int    // count prefix typed so far; 0 when none
+	pending string // first key of a two-key command; "" when none
+}
+
+// Counting reports whether a count prefix is being typed, so digits that
+// would otherwise focus a pane go on building it.
+func (n *Navigator) Counting() bool {
+	return n.count > 0
+}
+
+// KeyPending reports whether the first key of a two-key command (gg, zz,
+// ]f) was typed, so the second should reach the panel ahead of any global
+// binding.
+func (n *Navigator) KeyPending() bool {
+	return n.pending != ""
+}
+
+// PendingKey returns the first key of a two-key command typed so far, or ""
+// when none was.
+func (n *Navigator) PendingKey() string {
+	return n.pending
+}
+
+// PendingBindings returns the keys that can follow the pending key, or nil
+// when none is pending.
+func (n *Navigator) PendingBindings() []help.Binding {
+	return continuations[n.pending]
+}
+
+// Reset drops a count prefix or pending key that a key other than a motion
+// cut short.
+func (n *Navigator) Reset() {
+	n.count = 0
+	n.pending = ""
+}
+
+// Navigate applies a key to p. Digits build the count, and navigation keys
+// move count times. Any other key is left to the panel, which gets the
+// count (at least 1) for its own motions, such as the diff's hunks.
+func (n *Navigator) Navigate(p Navigable, msg tea.KeyMsg) (count int, handled bool) {
+	key := msg.String()
+
+	if n.pending != "" {
+		n.completePending(p, key)
+		return 0, true
+	}
+
+	if len(key) == 1 && key[0] >= '0' && key[0] <= '9' && (n.count > 0 || key != "0") {
+		n.count = min(n.count*countBase+int(key[0]-'0'), maxCount)
+		return 0, true
+	}
+
+	switch key {
+	case "g":
+		n.pending = key
+		return 0, true
+	case "z":
+		if _, ok := p.(Recenterable); ok {
+			n.pending = key
+			return 0, true
+		}
+	case "]", "[":
+		if _, ok := p.(FileJumper); ok {
+			n.pending = key
+			return 0, true
+		}
+	}
+
+	counted := n.count
+	count = max(counted, 1)
+	n.count = 0
+
+	switch key {
+	case "j", "down":
+		p.MoveBy(count)
+	case "k", "up":
+		p.MoveBy(-count)
+	case "ctrl+d":
+		p.MoveBy(count * halfPage(p))
+	case "ctrl+u":
+		p.MoveBy(-count * halfPage(p))
+	case "ctrl+f", "pgdown":
+		p.MoveBy(count * max(p.PageRows(), 1))
+	case "ctrl+b", "pgup":
+		p.MoveBy(-count * max(p.PageRows(), 1))
+	case "G":
+		if counted > 0 {
+			gotoRow(p, counted)
+		} else {
+			p.GotoBottom()
+		}
+	default:
+		return count, false
+	}
+
+	return count, true
+}
+
+// completePending finishes the two-key command key completes. A key that
+// completes none is dropped, as in vim, along with the count.
+func (n *Navigator) completePending(p Navigable, key string) {
+	counted := n.count
+	command := n.pending + key
+	n.Reset()
+
+	switch command {
+	case "gg":
+		if counted > 0 {
+			gotoRow(p, counted)
+		} else {
+			p.GotoTop()
+		}
+	case "zz":
+		p.(Recenterable).ScrollSelected(AnchorCenter)
+	case "zt":
+		p.(Recenterable).ScrollSelected(AnchorTop)
+	case "zb":
+		p.(Recenterable).ScrollSelected(AnchorBottom)
+	case "]f":
+		p.(FileJumper).JumpFile(max(counted, 1))
+	case "[f":
+		p.(FileJumper).JumpFile(-max(counted, 1))
+	}
+}
+
+// gotoRow moves to the 1-based row, as a count before gg or G does.
+func gotoRow(p Navigable, row int) {
+	p.GotoTop()
+	p.MoveBy(row - 1)
+}
+
+// anchoredOffset returns the view offset that puts the lines first through
+// last at anchor in a view height lines tall.
+func anchoredOffset(first, last, height int, anchor ScrollAnchor) int {
+	switch anchor {
+	case AnchorTop:
+		return max(first, 0)
+	case AnchorBottom:
+		return max(last-height+1, 0)
+	case AnchorCenter:
+	}
+
+	return max((first+last+1)/halfPageDivisor-height/halfPageDivisor, 0)
+}
+
+// halfPage returns the rows ctrl+d and ctrl+u move.
+func halfPage(p Navigable) int {
+	return max(p.PageRows()/halfPageDivisor, 1)
+}
+
+// clampCursor moves a list cursor by rows, keeping it on one of n items.
+func clampCursor(cursor, rows, n int) int {
+	return max(min(cursor+rows, n-1), 0)
+}
+
+// entriesPerPage returns how many entries of a list whose entries span
+// several lines each (the log's changes, the op log's operations) fill a
+// page of height rows, going by their average height.
+func entriesPerPage(height, entries, lines int) int {
+	if entries == 0 || lines <= entries {
+		return height
+	}
+
+	return max(height*entries/lines, 1)
+}
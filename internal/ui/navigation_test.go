@@ -0,0 +1,345 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"pgregory.net/rapid"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui/help"
+)
+
+// keyPress returns the key message for a key name, as Navigate reads it.
+func keyPress(name string) tea.KeyPressMsg {
+	switch name {
+	case "ctrl+d":
+		return tea.KeyPressMsg(tea.Key{Code: 'd', Mod: tea.ModCtrl})
+	case "ctrl+u":
+		return tea.KeyPressMsg(tea.Key{Code: 'u', Mod: tea.ModCtrl})
+	case "ctrl+b":
+		return tea.KeyPressMsg(tea.Key{Code: 'b', Mod: tea.ModCtrl})
+	case "pgdown":
+		return tea.KeyPressMsg(tea.Key{Code: tea.KeyPgDown})
+	}
+
+	return tea.KeyPressMsg(tea.Key{Code: rune(name[0]), Text: name})
+}
+
+// newTestNavStacks returns a stacks panel of one stack with n changes, ten
+// rows tall.
+func newTestNavStacks(n int) StacksPanel {
+	panel := newTestStacksPanel()
+	panel.SetSize(80, 10+PanelChromeHeight)
+
+	changes := make([]jj.Change, n)
+	for i := range changes {
+		changes[i].ChangeID = "change"
+	}
+
+	panel.SetStacks([]jj.Stack{{Changes: changes}})
+
+	return panel
+}
+
+// newTestNavLog returns a log panel of n changes, two lines each, ten rows
+// tall.
+func newTestNavLog(n int) LogPanel {
+	panel := NewLogPanel(NewStyles())
+	panel.SetSize(80, 10+PanelChromeHeight)
+
+	changes := make([]jj.Change, n)
+
+	var content strings.Builder
+
+	for i := range changes {
+		changes[i] = jj.Change{ChangeID: fmt.Sprintf("change%c%c", 'a'+i/26, 'a'+i%26)}
+		fmt.Fprintf(&content, "○ %s author\n│ description\n", changes[i].ChangeID)
+	}
+
+	panel.SetContent(content.String(), changes)
+	panel.SetFocused(true)
+
+	return panel
+}
+
+// pendingKeys lists the commands of the bindings that can follow a pending
+// key.
+func pendingKeys(bindings []help.Binding) string {
+	keys := make([]string, len(bindings))
+	for i, b := range bindings {
+		keys[i] = b.Key.Help().Key
+	}
+
+	return strings.Join(keys, " ")
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestNavigate_CountRepeatsMotion(t *testing.T) {
+	panel := newTestNavStacks(40)
+
+	for _, name := range []string{"1", "2", "j"} {
+		panel.Update(keyPress(name))
+	}
+
+	if panel.cursor != 12 || panel.Counting() {
+		t.Errorf("12j should move down 12 rows and end the count, cursor at %d", panel.cursor)
+	}
+
+	panel.Update(keyPress("k"))
+
+	if panel.cursor != 11 {
+		t.Errorf("a motion without a count moves once, cursor at %d", panel.cursor)
+	}
+}
+
+func TestNavigate_PagingKeys(t *testing.T) {
+	panel := newTestNavStacks(40)
+
+	tests := []struct {
+		key  string
+		want int
+	}{
+		{"ctrl+d", 5},
+		{"pgdown", 15},
+		{"ctrl+u", 10},
+		{"ctrl+b", 0},
+	}
+
+	for _, tt := range tests {
+		panel.Update(keyPress(tt.key))
+
+		if panel.cursor != tt.want {
+			t.Errorf("after %s, cursor at %d, want %d", tt.key, panel.cursor, tt.want)
+		}
+	}
+}
+
+func TestNavigate_DoubleG(t *testing.T) {
+	panel := newTestNavStacks(40)
+	panel.Update(keyPress("G"))
+
+	panel.Update(keyPress("g"))
+
+	if panel.cursor != len(panel.rows)-1 || !panel.KeyPending() {
+		t.Fatalf("one g should wait for the second, cursor at %d", panel.cursor)
+	}
+
+	panel.Update(keyPress("g"))
+
+	if panel.cursor != 0 || panel.KeyPending() {
+		t.Errorf("gg should go to the top, cursor at %d", panel.cursor)
+	}
+}
+
+func TestNavigate_CountedRow(t *testing.T) {
+	panel := newTestNavStacks(40)
+
+	for _, name := range []string{"5", "G"} {
+		panel.Update(keyPress(name))
+	}
+
+	if panel.cursor != 4 {
+		t.Errorf("5G should go to the fifth row, cursor at %d", panel.cursor)
+	}
+
+	for _, name := range []string{"1", "0", "g", "g"} {
+		panel.Update(keyPress(name))
+	}
+
+	if panel.cursor != 9 {
+		t.Errorf("10gg should go to the tenth row, cursor at %d", panel.cursor)
+	}
+}
+
+func TestNavigate_UnknownSecondKeyDropped(t *testing.T) {
+	panel := newTestNavStacks(40)
+
+	for _, name := range []string{"g", "j"} {
+		panel.Update(keyPress(name))
+	}
+
+	if panel.cursor != 0 || panel.KeyPending() {
+		t.Errorf("gj is no command and should be dropped, cursor at %d", panel.cursor)
+	}
+}
+
+func TestNavigate_ZOnlyWhereRecenterable(t *testing.T) {
+	panel := newTestNavStacks(5)
+	panel.Update(keyPress("z"))
+
+	if panel.KeyPending() {
+		t.Error("a panel that can't scroll around its selection has no z commands")
+	}
+}
+
+func TestLogPanel_ScrollSelected(t *testing.T) {
+	panel := newTestNavLog(30)
+
+	for _, name := range []string{"2", "0", "j"} {
+		panel.Update(keyPress(name))
+	}
+
+	tests := []struct {
+		keys string
+		want int
+	}{
+		{"zt", 40},
+		{"zb", 32},
+		{"zz", 36},
+	}
+
+	for _, tt := range tests {
+		for _, r := range tt.keys {
+			panel.Update(keyPress(string(r)))
+		}
+
+		if got := panel.viewport.YOffset(); got != tt.want {
+			t.Errorf("after %s, offset %d, want %d", tt.keys, got, tt.want)
+		}
+	}
+
+	if panel.cursor != 20 {
+		t.Errorf("z commands should scroll, not move the cursor, at %d", panel.cursor)
+	}
+}
+
+func TestDiffPanel_ScrollSelectedAndFold(t *testing.T) {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetSize(80, 10)
+	panel.SetFocused(true)
+
+	var diff strings.Builder
+
+	for _, name := range []string{"a", "b", "c"} {
+		fmt.Fprintf(&diff, "Added regular file %s.go:\n", name)
+
+		for line := 1; line <= 20; line++ {
+			fmt.Fprintf(&diff, "       %2d: line\n", line)
+		}
+	}
+
+	panel.SetDiff(diff.String())
+	panel.NextHunk()
+	panel.NextHunk()
+
+	start := panel.hunks[panel.currentHunk].StartLine
+
+	pressKeys(&panel, "z", "t")
+
+	if panel.viewport.YOffset() != start {
+		t.Errorf("zt should put the section header at the top, offset %d, want %d", panel.viewport.YOffset(), start)
+	}
+
+	pressKeys(&panel, "z", "b")
+
+	if want := start - panel.viewport.Height() + 1; panel.viewport.YOffset() != want {
+		t.Errorf("zb should put the section header at the bottom, offset %d, want %d", panel.viewport.YOffset(), want)
+	}
+
+	pressKeys(&panel, "z", "a")
+
+	if !panel.collapsed[panel.hunks[panel.currentHunk].Header] || panel.KeyPending() {
+		t.Error("za should still fold the section")
+	}
+}
+
+func TestNavigate_PendingBindings(t *testing.T) {
+	log := newTestNavLog(5)
+
+	if log.PendingBindings() != nil {
+		t.Error("nothing can follow when no key is pending")
+	}
+
+	log.Update(keyPress("z"))
+
+	if got := pendingKeys(log.PendingBindings()); got != "zz zt zb" {
+		t.Errorf("z should be followed by the z commands, got %q", got)
+	}
+
+	diff := NewDiffPanel(NewStyles())
+	diff.SetFocused(true)
+	pressKeys(&diff, "z")
+
+	if got := pendingKeys(diff.PendingBindings()); got != "zz zt zb za zM zR" {
+		t.Errorf("z in the diff should be followed by the fold commands too, got %q", got)
+	}
+
+	diff.Reset()
+	pressKeys(&diff, "g")
+
+	if got := pendingKeys(diff.PendingBindings()); got != "gg" {
+		t.Errorf("g should be followed by gg, got %q", got)
+	}
+}
+
+func TestNavigate_OtherKeysGetCount(t *testing.T) {
+	var nav Navigator
+
+	panel := newTestNavStacks(5)
+	nav.Navigate(&panel, keyPress("3"))
+
+	if count, handled := nav.Navigate(&panel, keyPress("}")); handled || count != 3 {
+		t.Errorf("a key the navigator doesn't know should be left with its count, got %d, %v", count, handled)
+	}
+
+	if nav.Counting() {
+		t.Error("the count should end with the key it was typed for")
+	}
+}
+
+func TestNavigate_ZeroStartsNoCount(t *testing.T) {
+	var nav Navigator
+
+	panel := newTestNavStacks(5)
+
+	if _, handled := nav.Navigate(&panel, keyPress("0")); handled || nav.Counting() {
+		t.Error("0 alone is not a count")
+	}
+}
+
+func TestDiffPanel_CountedHunks(t *testing.T) {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetSize(80, 5)
+	panel.SetFocused(true)
+	panel.SetDiff(positionTestDiff)
+
+	pressKeys(&panel, "2", "}")
+
+	if panel.viewport.YOffset() != 7 {
+		t.Errorf("2} should move to the third hunk, at line %d", panel.viewport.YOffset())
+	}
+
+	pressKeys(&panel, "{")
+
+	if panel.viewport.YOffset() != 3 {
+		t.Errorf("{ should move back to the second hunk, at line %d", panel.viewport.YOffset())
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: no count or motion moves the cursor off the list
+func TestNavigate_StaysOnList(t *testing.T) {
+	keys := []string{"j", "k", "g", "G", "ctrl+d", "ctrl+u", "ctrl+b", "pgdown", "3", "7", "0"}
+
+	rapid.Check(t, func(rt *rapid.T) {
+		n := rapid.IntRange(1, 60).Draw(rt, "n")
+		panel := newTestNavStacks(n)
+
+		for _, name := range rapid.SliceOfN(rapid.SampledFrom(keys), 1, 30).Draw(rt, "keys") {
+			panel.Update(keyPress(name))
+
+			if panel.cursor < 0 || panel.cursor >= len(panel.rows) {
+				rt.Fatalf("cursor %d is off the %d rows after %s", panel.cursor, len(panel.rows), name)
+			}
+		}
+	})
+}
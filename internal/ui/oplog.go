@@ -26,8 +26,14 @@ const (
 	ModeEvoLog                  // Evolution log for a specific change (jj evolog -r)
 )
 
+// OpDetailToggleMsg is sent when the user cycles the diff pane through the
+// selected operation's patch, its op diff, and a preview of restoring to it.
+type OpDetailToggleMsg struct{}
+
 // OpLogPanel displays the jj operation log or evolution log.
 type OpLogPanel struct {
+	Navigator // count prefixes and paging keys
+
 	viewport        viewport.Model
 	styles          *Styles
 	operations      []jj.Operation
@@ -45,6 +51,13 @@ type OpLogPanel struct {
 	mode      OpLogMode // Current display mode (op log or evolog)
 	changeID  string    // Change ID when in evolog mode
 	shortCode string    // Shortest unique prefix for highlighting
+	markedID  string    // evolog entry marked to compare the selected one with
+
+	// The whole op log as loaded, of which snapshot operations are left out
+	// while hideSnapshots is set
+	opLogRaw        string
+	opLogOperations []jj.Operation
+	hideSnapshots   bool
 }
 
 // NewOpLogPanel creates a new operation log panel.
@@ -123,13 +136,59 @@ func findOpIndex(operations []jj.Operation, opID string) int {
 // SetOpLogContent switches to global op log mode and sets content.
 func (p *OpLogPanel) SetOpLogContent(rawLog string, operations []jj.Operation) {
 	p.mode = ModeOpLog
+	p.markedID = ""
 	p.changeID = ""
 	p.shortCode = ""
-	p.SetContent(rawLog, operations)
+	p.opLogRaw = rawLog
+	p.opLogOperations = operations
+	p.SetContent(p.shownOpLog())
+}
+
+// SetHideSnapshots leaves snapshot operations out of the op log, or puts
+// them back.
+func (p *OpLogPanel) SetHideSnapshots(hide bool) {
+	p.hideSnapshots = hide
+
+	if p.mode == ModeOpLog {
+		p.SetContent(p.shownOpLog())
+	}
+}
+
+// HidingSnapshots reports whether snapshot operations are left out.
+func (p *OpLogPanel) HidingSnapshots() bool {
+	return p.hideSnapshots
+}
+
+// shownOpLog returns the op log as loaded, or rebuilt from its operations
+// other than snapshots while they're hidden.
+func (p *OpLogPanel) shownOpLog() (string, []jj.Operation) {
+	if !p.hideSnapshots {
+		return p.opLogRaw, p.opLogOperations
+	}
+
+	var (
+		raw        strings.Builder
+		operations []jj.Operation
+	)
+
+	for _, op := range p.opLogOperations {
+		if op.IsSnapshot() {
+			continue
+		}
+
+		raw.WriteString(op.Raw + "\n")
+		operations = append(operations, op)
+	}
+
+	return raw.String(), operations
 }
 
 // SetEvoLogContent switches to evolog mode for a specific change and sets content.
 func (p *OpLogPanel) SetEvoLogContent(changeID, shortCode, rawLog string, operations []jj.Operation) {
+	if changeID != p.changeID || findOpIndex(operations, p.markedID) < 0 {
+		p.markedID = ""
+	}
+
 	p.mode = ModeEvoLog
 	p.changeID = changeID
 	p.shortCode = shortCode
@@ -151,6 +210,50 @@ func (p *OpLogPanel) SelectedOperation() *jj.Operation {
 	return nil
 }
 
+// Remaining returns how many entries are listed below the selected one.
+func (p *OpLogPanel) Remaining() int {
+	return max(len(p.operations)-p.cursor-1, 0)
+}
+
+// ChangeID returns the change whose evolog is shown, or "" in the op log.
+func (p *OpLogPanel) ChangeID() string {
+	return p.changeID
+}
+
+// ToggleMark marks the selected evolog entry as the version to compare
+// others with, or clears the mark when it is already on it. The op log has
+// no versions to compare, so there it does nothing.
+func (p *OpLogPanel) ToggleMark() {
+	sel := p.SelectedOperation()
+	if p.mode != ModeEvoLog || sel == nil {
+		return
+	}
+
+	if p.markedID == sel.OpID {
+		p.markedID = ""
+	} else {
+		p.markedID = sel.OpID
+	}
+
+	p.updateViewport()
+}
+
+// ComparedVersions returns the marked and selected evolog entries, older
+// first, when a version is marked and another is selected.
+func (p *OpLogPanel) ComparedVersions() (older, newer *jj.Operation, ok bool) {
+	marked := findOpIndex(p.operations, p.markedID)
+	if p.mode != ModeEvoLog || marked < 0 || marked == p.cursor || p.SelectedOperation() == nil {
+		return nil, nil, false
+	}
+
+	// The evolog lists the newest version first
+	if marked > p.cursor {
+		return &p.operations[marked], &p.operations[p.cursor], true
+	}
+
+	return &p.operations[p.cursor], &p.operations[marked], true
+}
+
 // CursorUp moves the cursor up.
 func (p *OpLogPanel) CursorUp() {
 	if p.cursor > 0 {
@@ -167,6 +270,19 @@ func (p *OpLogPanel) CursorDown() {
 	}
 }
 
+// MoveBy moves the cursor by rows, up when negative, stopping at the ends.
+func (p *OpLogPanel) MoveBy(rows int) {
+	if len(p.operations) > 0 {
+		p.cursor = clampCursor(p.cursor, rows, len(p.operations))
+		p.updateViewport()
+	}
+}
+
+// PageRows returns how many items one screen of the panel shows.
+func (p *OpLogPanel) PageRows() int {
+	return entriesPerPage(p.viewport.Height(), len(p.operations), p.totalLines)
+}
+
 // GotoTop moves to the first item.
 func (p *OpLogPanel) GotoTop() {
 	p.cursor = 0
@@ -205,15 +321,15 @@ func (p *OpLogPanel) Update(msg tea.Msg) tea.Cmd {
 	}
 
 	if msg, ok := msg.(tea.KeyMsg); ok {
+		if _, handled := p.Navigate(p, msg); handled {
+			return nil
+		}
+
 		switch msg.String() {
-		case "j", "down":
-			p.CursorDown()
-		case "k", "up":
-			p.CursorUp()
-		case "g":
-			p.GotoTop()
-		case "G":
-			p.GotoBottom()
+		case "p":
+			return func() tea.Msg { return OpDetailToggleMsg{} }
+		case "space":
+			p.ToggleMark()
 		}
 	}
 
@@ -231,20 +347,18 @@ func (p *OpLogPanel) View() string {
 		if p.shortCode != "" && len(p.shortCode) <= len(p.changeID) {
 			rest := p.changeID[len(p.shortCode):]
 
-			var outerColorCode string
-
-			if p.focused {
-				outerColorCode = AccentColorCode
-			} else {
-				outerColorCode = PrimaryColorCode
-			}
-
-			coloredID = ReplaceResetWithColor(p.styles.ShortCode.Render(p.shortCode), outerColorCode) + rest
+			outerColor := p.styles.TitleColor(p.focused)
+			coloredID = ReplaceResetWithColor(p.styles.ShortCode.Render(p.shortCode), outerColor) + rest
 		}
 
 		title = p.styles.PanelTitle(opLogPanelNumber, "Evolution: "+coloredID, p.focused)
 	default:
-		title = p.styles.PanelTitle(opLogPanelNumber, "Operations Log", p.focused)
+		name := "Operations Log"
+		if p.hideSnapshots {
+			name += " (no snapshots)"
+		}
+
+		title = p.styles.PanelTitle(opLogPanelNumber, name, p.focused)
 	}
 
 	// Get the appropriate border style
@@ -267,18 +381,33 @@ func (p *OpLogPanel) View() string {
 
 // HelpBindings returns the keybindings for this panel (display-only, for status bar).
 func (p *OpLogPanel) HelpBindings() []help.Binding {
-	return []help.Binding{
+	bindings := []help.Binding{
 		{
 			Key:      key.NewBinding(key.WithKeys("j", "k"), key.WithHelp("j/k", "up/down")),
 			Category: help.CategoryNavigation,
 			Order:    PanelOrderPrimary,
 		},
 		{
-			Key:      key.NewBinding(key.WithKeys("g", "G"), key.WithHelp("g/G", "top/bottom")),
+			Key:      key.NewBinding(key.WithKeys("g", "G"), key.WithHelp("gg/G", "top/bottom")),
 			Category: help.CategoryNavigation,
 			Order:    PanelOrderSecondary,
 		},
+		{
+			Key:      key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "patch/op diff/restore")),
+			Category: help.CategoryView,
+			Order:    PanelOrderPrimary,
+		},
 	}
+
+	if p.mode == ModeEvoLog {
+		bindings = append(bindings, help.Binding{
+			Key:      key.NewBinding(key.WithKeys("space"), key.WithHelp("space", "compare with version")),
+			Category: help.CategoryView,
+			Order:    PanelOrderSecondary,
+		})
+	}
+
+	return bindings
 }
 
 // computeOpStartLines pre-computes the line number where each operation starts.
@@ -338,6 +467,11 @@ func (p *OpLogPanel) lineToOpIndex(visualLine int) int {
 	return opIdx
 }
 
+// Restyle re-renders the content in the current styles, after a theme change.
+func (p *OpLogPanel) Restyle() {
+	p.updateViewport()
+}
+
 func (p *OpLogPanel) updateViewport() {
 	if p.rawLog == "" {
 		p.viewport.SetContent("No operations")
@@ -353,10 +487,14 @@ func (p *OpLogPanel) updateViewport() {
 		// Check if this line starts an operation (using pre-computed array)
 		isStart := nextOpIdx < len(p.opStartLines) && i == p.opStartLines[nextOpIdx]
 
-		// Add selection indicator on the start line of the selected operation
-		if isStart && nextOpIdx == p.cursor {
-			fmt.Fprintf(&result, "→ %s\n", line)
-		} else {
+		// Add selection indicator on the start line of the selected operation,
+		// and mark the version the selected one is compared with
+		switch {
+		case isStart && nextOpIdx == p.cursor:
+			fmt.Fprintf(&result, "%s%s\n", p.styles.Cursor(), line)
+		case isStart && p.markedID != "" && p.operations[nextOpIdx].OpID == p.markedID:
+			fmt.Fprintf(&result, "%s %s\n", p.styles.Mark(), line)
+		default:
 			fmt.Fprintf(&result, "  %s\n", line)
 		}
 
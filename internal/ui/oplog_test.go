@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	tea "charm.land/bubbletea/v2"
 	"pgregory.net/rapid"
 
 	"github.com/chatter/chado/internal/jj"
@@ -205,6 +206,26 @@ func TestOpLogPanel_Focus(t *testing.T) {
 	}
 }
 
+func TestOpLogPanel_ToggleDetail(t *testing.T) {
+	panel := NewOpLogPanel(NewStyles())
+	p := tea.KeyPressMsg{Code: 'p', Text: "p"}
+
+	if cmd := panel.Update(p); cmd != nil {
+		t.Error("an unfocused panel should ignore p")
+	}
+
+	panel.SetFocused(true)
+
+	cmd := panel.Update(p)
+	if cmd == nil {
+		t.Fatal("p should ask to switch the operation detail")
+	}
+
+	if _, ok := cmd().(OpDetailToggleMsg); !ok {
+		t.Errorf("expected OpDetailToggleMsg, got %T", cmd())
+	}
+}
+
 func TestOpLogPanel_SetSize(t *testing.T) {
 	panel := NewOpLogPanel(NewStyles())
 	panel.SetSize(100, 50)
@@ -606,6 +627,152 @@ func TestOpLogPanel_TitleByMode_EvoLog(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// Version Compare Tests
+// =============================================================================
+
+func newEvoLogPanel(changeID string) OpLogPanel {
+	panel := NewOpLogPanel(NewStyles())
+	panel.SetFocused(true)
+	panel.SetEvoLogContent(changeID, "mkv", "@ aaaaaaaaaaaa 1\n○ bbbbbbbbbbbb 2\n○ cccccccccccc 3", []jj.Operation{
+		{OpID: "aaaaaaaaaaaa"}, {OpID: "bbbbbbbbbbbb"}, {OpID: "cccccccccccc"},
+	})
+
+	return panel
+}
+
+func TestOpLogPanel_ComparedVersions_OlderFirst(t *testing.T) {
+	panel := newEvoLogPanel("mkvurkku")
+	space := tea.KeyPressMsg{Code: tea.KeySpace, Text: " "}
+
+	panel.Update(space)
+
+	if _, _, ok := panel.ComparedVersions(); ok {
+		t.Fatal("the marked version should not be compared with itself")
+	}
+
+	panel.CursorDown()
+	panel.CursorDown()
+
+	older, newer, ok := panel.ComparedVersions()
+	if !ok {
+		t.Fatal("expected the marked and selected versions to be compared")
+	}
+
+	if older.OpID != "cccccccccccc" || newer.OpID != "aaaaaaaaaaaa" {
+		t.Errorf("compared %s → %s, want cccccccccccc → aaaaaaaaaaaa", older.OpID, newer.OpID)
+	}
+}
+
+func TestOpLogPanel_ToggleMark_Clears(t *testing.T) {
+	panel := newEvoLogPanel("mkvurkku")
+
+	panel.ToggleMark()
+	panel.ToggleMark()
+	panel.CursorDown()
+
+	if _, _, ok := panel.ComparedVersions(); ok {
+		t.Error("marking the same version twice should clear the mark")
+	}
+}
+
+func TestOpLogPanel_ToggleMark_IgnoredInOpLog(t *testing.T) {
+	panel := NewOpLogPanel(NewStyles())
+	panel.SetOpLogContent("@ aaaaaaaaaaaa 1\n○ bbbbbbbbbbbb 2", []jj.Operation{
+		{OpID: "aaaaaaaaaaaa"}, {OpID: "bbbbbbbbbbbb"},
+	})
+
+	panel.ToggleMark()
+	panel.CursorDown()
+
+	if _, _, ok := panel.ComparedVersions(); ok {
+		t.Error("operations in the op log are not versions to compare")
+	}
+}
+
+func TestOpLogPanel_Mark_ShownInView(t *testing.T) {
+	panel := newEvoLogPanel("mkvurkku")
+
+	panel.ToggleMark()
+	panel.CursorDown()
+
+	lines := strings.Split(stripTestANSI(panel.viewport.GetContent()), "\n")
+	if !strings.HasPrefix(lines[0], "• ") {
+		t.Errorf("marked version should be flagged, got %q", lines[0])
+	}
+
+	if !strings.HasPrefix(lines[1], "→ ") {
+		t.Errorf("selected version should keep the cursor, got %q", lines[1])
+	}
+}
+
+func TestOpLogPanel_Mark_ClearedForAnotherChange(t *testing.T) {
+	panel := newEvoLogPanel("mkvurkku")
+	panel.ToggleMark()
+
+	// Reloading the same change keeps the mark
+	panel.SetEvoLogContent("mkvurkku", "mkv", "@ aaaaaaaaaaaa 1\n○ bbbbbbbbbbbb 2\n○ cccccccccccc 3", panel.operations)
+	panel.CursorDown()
+
+	if _, _, ok := panel.ComparedVersions(); !ok {
+		t.Fatal("reloading the evolog should keep the mark")
+	}
+
+	panel.SetEvoLogContent("zzzzzzzz", "z", "@ aaaaaaaaaaaa 1\n○ bbbbbbbbbbbb 2\n○ cccccccccccc 3", panel.operations)
+	panel.CursorDown()
+
+	if _, _, ok := panel.ComparedVersions(); ok {
+		t.Error("the mark should not carry over to another change's evolog")
+	}
+}
+
+func TestOpLogPanel_HideSnapshots(t *testing.T) {
+	panel := NewOpLogPanel(NewStyles())
+	panel.SetSize(80, 20)
+
+	operations := []jj.Operation{
+		{OpID: "aaaaaaaaaaaa", Description: "snapshot working copy", Raw: "@  aaaaaaaaaaaa\n│  snapshot working copy"},
+		{OpID: "bbbbbbbbbbbb", Description: "new empty commit", Raw: "○  bbbbbbbbbbbb\n│  new empty commit"},
+		{OpID: "cccccccccccc", Description: "snapshot working copy", Raw: "○  cccccccccccc\n│  snapshot working copy"},
+	}
+	panel.SetOpLogContent("@  aaaaaaaaaaaa\n│  snapshot working copy\n○  bbbbbbbbbbbb\n│  new empty commit\n○  cccccccccccc\n│  snapshot working copy\n", operations)
+	panel.CursorDown()
+
+	panel.SetHideSnapshots(true)
+
+	if len(panel.operations) != 1 || panel.SelectedOperation().OpID != "bbbbbbbbbbbb" {
+		t.Fatalf("only the new commit should be listed, and stay selected, got %+v", panel.operations)
+	}
+
+	if view := panel.viewport.View(); strings.Contains(view, "snapshot working copy") {
+		t.Errorf("snapshots should be hidden, got:\n%s", view)
+	}
+
+	// A reload keeps them hidden
+	panel.SetOpLogContent(panel.opLogRaw, operations)
+
+	if len(panel.operations) != 1 {
+		t.Errorf("a reload should keep snapshots hidden, got %d operations", len(panel.operations))
+	}
+
+	panel.SetHideSnapshots(false)
+
+	if len(panel.operations) != len(operations) {
+		t.Errorf("showing snapshots should list all %d operations, got %d", len(operations), len(panel.operations))
+	}
+}
+
+func TestOpLogPanel_HideSnapshots_SparesEvoLog(t *testing.T) {
+	panel := newEvoLogPanel("mkvurkku")
+	count := len(panel.operations)
+
+	panel.SetHideSnapshots(true)
+
+	if len(panel.operations) != count {
+		t.Error("hiding snapshots should leave the evolog alone")
+	}
+}
+
 // stripTestANSI is a helper to strip ANSI codes for test assertions
 func stripTestANSI(s string) string {
 	// Simple ANSI stripper for tests
@@ -0,0 +1,360 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/chatter/chado/internal/jj"
+	"github.com/chatter/chado/internal/ui/help"
+)
+
+// stackRow is one line of the stacks panel: a stack's header, or one of its
+// changes when change is not negative.
+type stackRow struct {
+	stack  int
+	change int
+}
+
+// StacksPanel lists the mutable changes above trunk grouped into stacks,
+// each under a header that folds it away.
+type StacksPanel struct {
+	Navigator // count prefixes and paging keys
+
+	viewport        viewport.Model
+	styles          *Styles
+	stacks          []jj.Stack
+	rows            []stackRow
+	cursor          int
+	focused         bool
+	width           int
+	height          int
+	folded          map[string]bool // stack name -> header folded
+	borderAnimPhase float64         // 0..1 for focus border animation
+	borderAnimating bool            // true only while the one-shot wrap is running
+}
+
+// NewStacksPanel creates a new stacks panel.
+func NewStacksPanel(styles *Styles) StacksPanel {
+	vp := viewport.New()
+	vp.SoftWrap = false
+
+	return StacksPanel{
+		viewport: vp,
+		styles:   styles,
+		folded:   make(map[string]bool),
+	}
+}
+
+// SetSize sets the panel dimensions.
+func (p *StacksPanel) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+	p.viewport.SetWidth(width - PanelBorderWidth)
+	p.viewport.SetHeight(height - PanelChromeHeight)
+	p.updateViewport()
+}
+
+// SetFocused sets the focus state.
+func (p *StacksPanel) SetFocused(focused bool) {
+	p.focused = focused
+}
+
+// SetBorderAnimPhase sets the border animation phase (0..1) for the focus wrap effect.
+func (p *StacksPanel) SetBorderAnimPhase(phase float64) {
+	p.borderAnimPhase = phase
+}
+
+// SetBorderAnimating sets whether the focus border animation is running.
+func (p *StacksPanel) SetBorderAnimating(animating bool) {
+	p.borderAnimating = animating
+}
+
+// SetStacks replaces the stacks. The cursor stays on the same stack when it
+// still exists, and folded stacks stay folded.
+func (p *StacksPanel) SetStacks(stacks []jj.Stack) {
+	var current string
+	if stack := p.SelectedStack(); stack != nil {
+		current = stack.Name()
+	}
+
+	p.stacks = stacks
+	p.buildRows()
+	p.cursor = 0
+
+	for idx, row := range p.rows {
+		if row.change < 0 && stacks[row.stack].Name() == current {
+			p.cursor = idx
+		}
+	}
+
+	p.updateViewport()
+}
+
+// buildRows lays out a header per stack, followed by its changes unless it
+// is folded.
+func (p *StacksPanel) buildRows() {
+	p.rows = p.rows[:0]
+
+	for idx, stack := range p.stacks {
+		p.rows = append(p.rows, stackRow{stack: idx, change: -1})
+
+		if p.folded[stack.Name()] {
+			continue
+		}
+
+		for change := range stack.Changes {
+			p.rows = append(p.rows, stackRow{stack: idx, change: change})
+		}
+	}
+}
+
+// SelectedStack returns the stack under the cursor, on its header or one of
+// its changes.
+func (p *StacksPanel) SelectedStack() *jj.Stack {
+	if p.cursor >= 0 && p.cursor < len(p.rows) {
+		return &p.stacks[p.rows[p.cursor].stack]
+	}
+
+	return nil
+}
+
+// SelectedChange returns the change under the cursor, or nil on a header.
+func (p *StacksPanel) SelectedChange() *jj.Change {
+	if p.cursor < 0 || p.cursor >= len(p.rows) || p.rows[p.cursor].change < 0 {
+		return nil
+	}
+
+	row := p.rows[p.cursor]
+
+	return &p.stacks[row.stack].Changes[row.change]
+}
+
+// ToggleFold folds or unfolds the stack under the cursor, leaving the
+// cursor on its header.
+func (p *StacksPanel) ToggleFold() {
+	if p.cursor < 0 || p.cursor >= len(p.rows) {
+		return
+	}
+
+	selected := p.rows[p.cursor].stack
+	name := p.stacks[selected].Name()
+
+	if p.folded[name] {
+		delete(p.folded, name)
+	} else {
+		p.folded[name] = true
+	}
+
+	p.buildRows()
+
+	for idx, row := range p.rows {
+		if row.stack == selected && row.change < 0 {
+			p.cursor = idx
+		}
+	}
+
+	p.updateViewport()
+}
+
+// CursorUp moves the cursor up.
+func (p *StacksPanel) CursorUp() {
+	if p.cursor > 0 {
+		p.cursor--
+		p.updateViewport()
+	}
+}
+
+// CursorDown moves the cursor down.
+func (p *StacksPanel) CursorDown() {
+	if p.cursor < len(p.rows)-1 {
+		p.cursor++
+		p.updateViewport()
+	}
+}
+
+// MoveBy moves the cursor by rows, up when negative, stopping at the ends.
+func (p *StacksPanel) MoveBy(rows int) {
+	if len(p.rows) > 0 {
+		p.cursor = clampCursor(p.cursor, rows, len(p.rows))
+		p.updateViewport()
+	}
+}
+
+// PageRows returns how many items one screen of the panel shows.
+func (p *StacksPanel) PageRows() int {
+	return p.viewport.Height()
+}
+
+// GotoTop moves to the first item.
+func (p *StacksPanel) GotoTop() {
+	p.cursor = 0
+	p.updateViewport()
+}
+
+// GotoBottom moves to the last item.
+func (p *StacksPanel) GotoBottom() {
+	if len(p.rows) > 0 {
+		p.cursor = len(p.rows) - 1
+		p.updateViewport()
+	}
+}
+
+// HandleClick selects the row at the given Y coordinate (relative to content area).
+func (p *StacksPanel) HandleClick(y int) bool {
+	visualLine := y + p.viewport.YOffset()
+
+	if visualLine >= 0 && visualLine < len(p.rows) && visualLine != p.cursor {
+		p.cursor = visualLine
+		p.updateViewport()
+
+		return true
+	}
+
+	return false
+}
+
+// SelectedAt reports whether the given Y coordinate (relative to content
+// area) is the selected row, so a second click can act on it.
+func (p *StacksPanel) SelectedAt(y int) bool {
+	visualLine := y + p.viewport.YOffset()
+
+	return visualLine == p.cursor && visualLine < len(p.rows)
+}
+
+// Update handles input.
+func (p *StacksPanel) Update(msg tea.Msg) tea.Cmd {
+	if !p.focused {
+		return nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		if _, handled := p.Navigate(p, msg); handled {
+			return nil
+		}
+
+		if msg.String() == "space" {
+			p.ToggleFold()
+		}
+	}
+
+	return nil
+}
+
+// View renders the panel.
+func (p *StacksPanel) View() string {
+	title := p.styles.PanelTitle(1, "stacks", p.focused)
+
+	var style lipgloss.Style
+
+	switch {
+	case p.focused && p.borderAnimating:
+		style = p.styles.AnimatedFocusBorderStyle(p.borderAnimPhase, p.width, p.height)
+	case p.focused:
+		style = p.styles.FocusedPanel
+	default:
+		style = p.styles.Panel
+	}
+
+	style = style.Height(p.height - PanelBorderHeight)
+
+	content := title + "\n" + p.viewport.View()
+
+	return style.Render(content)
+}
+
+// HelpBindings returns the keybindings for this panel (display-only, for status bar).
+func (p *StacksPanel) HelpBindings() []help.Binding {
+	return []help.Binding{
+		{
+			Key:      key.NewBinding(key.WithKeys("j", "k"), key.WithHelp("j/k", "up/down")),
+			Category: help.CategoryNavigation,
+			Order:    PanelOrderPrimary,
+		},
+		{
+			Key:      key.NewBinding(key.WithKeys("space"), key.WithHelp("space", "fold stack")),
+			Category: help.CategoryNavigation,
+			Order:    PanelOrderPrimary,
+		},
+		{
+			Key:      key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "go to change")),
+			Category: help.CategoryNavigation,
+			Order:    PanelOrderPrimary,
+		},
+		{
+			Key:      key.NewBinding(key.WithKeys("g", "G"), key.WithHelp("gg/G", "top/bottom")),
+			Category: help.CategoryNavigation,
+			Order:    PanelOrderSecondary,
+		},
+	}
+}
+
+// Restyle re-renders the content in the current styles, after a theme change.
+func (p *StacksPanel) Restyle() {
+	p.updateViewport()
+}
+
+func (p *StacksPanel) updateViewport() {
+	if len(p.rows) == 0 {
+		p.viewport.SetContent("No stacks: every change is on trunk or immutable")
+		return
+	}
+
+	var content strings.Builder
+
+	for idx, row := range p.rows {
+		cursor := "  "
+		if idx == p.cursor {
+			cursor = p.styles.Cursor()
+		}
+
+		content.WriteString(cursor + p.renderRow(row) + "\n")
+	}
+
+	p.viewport.SetContent(content.String())
+
+	// Ensure cursor is visible
+	if p.cursor < p.viewport.YOffset() {
+		p.viewport.SetYOffset(p.cursor)
+	} else if p.cursor >= p.viewport.YOffset()+p.viewport.Height() {
+		p.viewport.SetYOffset(p.cursor - p.viewport.Height() + 1)
+	}
+}
+
+// renderRow renders a stack header, with its fold state and size, or one
+// of its changes, indented beneath it.
+func (p *StacksPanel) renderRow(row stackRow) string {
+	stack := p.stacks[row.stack]
+
+	if row.change < 0 {
+		fold := "▾ "
+		if p.folded[stack.Name()] {
+			fold = "▸ "
+		}
+
+		noun := "changes"
+		if len(stack.Changes) == 1 {
+			noun = "change"
+		}
+
+		return fold + p.styles.BadgeBookmark.Render(stack.Name()) + p.styles.Dim.Render(fmt.Sprintf(" %d %s", len(stack.Changes), noun))
+	}
+
+	change := stack.Changes[row.change]
+	line := "  " + p.styles.ShortCode.Render(change.ChangeID)
+
+	for _, bookmark := range change.Bookmarks {
+		line += " " + p.styles.BadgeBookmark.Render("⚑"+bookmark)
+	}
+
+	description := change.Description
+	if description == "" {
+		description = p.styles.Dim.Render("(no description set)")
+	}
+
+	return line + " " + description
+}
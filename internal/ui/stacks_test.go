@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chatter/chado/internal/jj"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// newTestStacksPanel returns a panel with a two-change parser stack and a
+// one-change ui stack.
+func newTestStacksPanel() StacksPanel {
+	panel := NewStacksPanel(NewStyles())
+	panel.SetSize(80, 20)
+	panel.SetFocused(true)
+
+	panel.SetStacks([]jj.Stack{
+		{
+			Changes: []jj.Change{
+				{ChangeID: "qqqqqqqq", Bookmarks: []string{"parser"}, Description: "parser: handle tabs"},
+				{ChangeID: "pppppppp", Description: "parser: tidy"},
+			},
+			Roots: []string{"pppppppp"},
+		},
+		{
+			Changes: []jj.Change{{ChangeID: "xxxxxxxx"}},
+			Roots:   []string{"xxxxxxxx"},
+		},
+	})
+
+	return panel
+}
+
+// plainStacksView returns the panel's content without colors.
+func plainStacksView(panel StacksPanel) string {
+	return ansiRegex.ReplaceAllString(panel.viewport.View(), "")
+}
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestStacksPanel_HeadersAndChanges(t *testing.T) {
+	panel := newTestStacksPanel()
+
+	if panel.SelectedChange() != nil || panel.SelectedStack().Name() != "parser" {
+		t.Error("the cursor should start on the first stack's header")
+	}
+
+	panel.CursorDown()
+
+	if change := panel.SelectedChange(); change == nil || change.ChangeID != "qqqqqqqq" {
+		t.Errorf("expected the stack's newest change under its header, got %+v", change)
+	}
+
+	view := plainStacksView(panel)
+	if !strings.Contains(view, "▾ parser 2 changes") || !strings.Contains(view, "▾ xxxxxxxx 1 change") {
+		t.Errorf("expected a header per stack, got:\n%s", view)
+	}
+}
+
+func TestStacksPanel_FoldHidesChanges(t *testing.T) {
+	panel := newTestStacksPanel()
+	panel.CursorDown()
+
+	panel.ToggleFold()
+
+	if panel.SelectedChange() != nil || len(panel.rows) != 3 {
+		t.Fatalf("folding should hide the stack's changes and select its header, rows = %v", panel.rows)
+	}
+
+	if !strings.Contains(plainStacksView(panel), "▸ parser") {
+		t.Error("a folded stack's header should say so")
+	}
+
+	panel.SetStacks(panel.stacks)
+
+	if len(panel.rows) != 3 {
+		t.Error("a reload should keep the stack folded")
+	}
+}
+
+func TestStacksPanel_SetStacksKeepsStack(t *testing.T) {
+	panel := newTestStacksPanel()
+	panel.GotoBottom()
+
+	panel.SetStacks([]jj.Stack{panel.stacks[1], panel.stacks[0]})
+
+	if stack := panel.SelectedStack(); stack == nil || stack.Name() != "xxxxxxxx" {
+		t.Errorf("the cursor should follow its stack, got %+v", stack)
+	}
+}
+
+func TestStacksPanel_Empty(t *testing.T) {
+	panel := NewStacksPanel(NewStyles())
+	panel.SetSize(80, 20)
+	panel.SetStacks(nil)
+
+	if panel.SelectedStack() != nil || !strings.Contains(plainStacksView(panel), "No stacks") {
+		t.Error("with no stacks, nothing is selected")
+	}
+}
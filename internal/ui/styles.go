@@ -5,9 +5,12 @@ package ui
 import (
 	"image/color"
 	"os"
+	"strings"
 
 	"charm.land/lipgloss/v2"
 	"github.com/charmbracelet/colorprofile"
+
+	"github.com/chatter/chado/internal/ui/theme"
 )
 
 const (
@@ -37,13 +40,6 @@ const (
 	ScrollPadding = 2
 )
 
-// Color codes (ANSI 256).
-const (
-	PrimaryColorCode   = "#808080" // Gray
-	SecondaryColorCode = "241"     // Gray
-	AccentColorCode    = "#30c9b0" // Cyan
-)
-
 // Styles holds all lipgloss styles for the application, constructed from a detected color profile.
 type Styles struct {
 	Panel        lipgloss.Style
@@ -55,37 +51,130 @@ type Styles struct {
 	Selected     lipgloss.Style
 	Dim          lipgloss.Style
 	ShortCode    lipgloss.Style
+	StatAdded    lipgloss.Style
+	StatRemoved  lipgloss.Style
+
+	// File status letters in the files panel.
+	FileAdded    lipgloss.Style
+	FileDeleted  lipgloss.Style
+	FileModified lipgloss.Style
+
+	// Git-format diff lines and the changed words within them.
+	DiffAdded       lipgloss.Style
+	DiffRemoved     lipgloss.Style
+	DiffAddedWord   lipgloss.Style
+	DiffRemovedWord lipgloss.Style
+	DiffMovedFrom   lipgloss.Style
+	DiffMovedTo     lipgloss.Style
+
+	// Change badges in the log panel.
+	BadgeBookmark  lipgloss.Style
+	BadgeTag       lipgloss.Style
+	BadgeImmutable lipgloss.Style
+	BadgeConflict  lipgloss.Style
+	BadgeEmpty     lipgloss.Style
+	BadgeGone      lipgloss.Style
 
 	// Border color blends for panel focus animation.
 	unfocusedBorderBlend []color.Color
 	focusedBorderBlend   []color.Color
+
+	// theme is the palette the styles were built from.
+	theme theme.Theme
+
+	// monochrome draws focus and selection without relying on color.
+	monochrome bool
 }
 
-// NewStyles creates the application styles using the detected terminal color profile.
+// NewStyles creates the application styles in the default theme using the
+// detected terminal color profile.
 func NewStyles() *Styles {
+	return newStyles(theme.Default(), false)
+}
+
+// SetTheme rebuilds the styles from t in place, so every panel sharing them
+// picks up the new colors on its next render.
+func (s *Styles) SetTheme(t theme.Theme) {
+	*s = *newStyles(t, s.monochrome)
+}
+
+// SetMonochrome rebuilds the styles for a terminal without colors: the
+// focused panel gets a thick border and a reverse-video title, the cursor
+// an ASCII marker in reverse video, and changed words an underline.
+func (s *Styles) SetMonochrome(monochrome bool) {
+	*s = *newStyles(s.theme, monochrome)
+}
+
+// Cursor returns the marker for the row under the cursor, with its space.
+func (s *Styles) Cursor() string {
+	if s.monochrome {
+		return s.Selected.Render(">") + " "
+	}
+
+	return "→ "
+}
+
+// Mark returns the marker for a marked row, without its space.
+func (s *Styles) Mark() string {
+	if s.monochrome {
+		return s.ShortCode.Render("*")
+	}
+
+	return s.ShortCode.Render("•")
+}
+
+// Theme returns the palette the styles were built from.
+func (s *Styles) Theme() theme.Theme {
+	return s.theme
+}
+
+// TitleColor returns the panel title color, for text styled inside a title
+// that has to restore it (see ReplaceResetWithColor).
+func (s *Styles) TitleColor(focused bool) string {
+	if focused {
+		return s.theme.Accent
+	}
+
+	return s.theme.Primary
+}
+
+// newStyles creates the styles for t, or for a terminal without colors
+// when monochrome.
+func newStyles(t theme.Theme, monochrome bool) *Styles {
 	profile := colorprofile.Detect(os.Stdout, os.Environ())
 
-	complete := func(hex string) color.Color {
-		return profile.Convert(lipgloss.Color(hex))
+	// Hex colors are fitted to the terminal; ANSI numbers already fit. A
+	// terminal without colors gets none from Convert, which border blends
+	// cannot draw, so the color is kept for the renderer to drop instead
+	complete := func(code string) color.Color {
+		if strings.HasPrefix(code, "#") {
+			if fitted := profile.Convert(lipgloss.Color(code)); fitted != nil {
+				return fitted
+			}
+		}
+
+		return lipgloss.Color(code)
 	}
 
-	primary := complete(PrimaryColorCode)
-	secondary := lipgloss.Color(SecondaryColorCode)
-	accent := complete(AccentColorCode)
+	primary := complete(t.Primary)
+	secondary := complete(t.Secondary)
+	accent := complete(t.Accent)
+	added := complete(t.Added)
+	removed := complete(t.Removed)
 
 	unfocusedBlend := []color.Color{
 		primary,
-		complete("#454545"),
+		complete(t.BorderShade),
 		primary,
-		complete("#3d3d3d"),
+		complete(t.BorderShadow),
 		primary,
 	}
 
 	focusedBlend := []color.Color{
 		accent,
-		complete("#0d4d44"),
+		complete(t.AccentShade),
 		accent,
-		complete("#1e1e1e"),
+		complete(t.AccentShadow),
 		accent,
 	}
 
@@ -94,7 +183,7 @@ func NewStyles() *Styles {
 		BorderStyle(lipgloss.RoundedBorder()).
 		BorderForegroundBlend(unfocusedBlend...)
 
-	return &Styles{
+	styles := &Styles{
 		Panel: panel,
 		FocusedPanel: lipgloss.
 			NewStyle().
@@ -121,17 +210,76 @@ func NewStyles() *Styles {
 		Dim: lipgloss.NewStyle().
 			Foreground(secondary),
 		ShortCode: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("13")). // Bright magenta - matches jj
+			Foreground(complete(t.ShortCode)).
 			Bold(true).
 			Inline(true),
+		StatAdded: lipgloss.NewStyle().
+			Foreground(added),
+		StatRemoved: lipgloss.NewStyle().
+			Foreground(removed),
+
+		FileAdded: lipgloss.NewStyle().
+			Foreground(added),
+		FileDeleted: lipgloss.NewStyle().
+			Foreground(removed),
+		FileModified: lipgloss.NewStyle().
+			Foreground(complete(t.Modified)),
+
+		DiffAdded: lipgloss.NewStyle().
+			Foreground(added),
+		DiffRemoved: lipgloss.NewStyle().
+			Foreground(removed),
+		DiffAddedWord: lipgloss.NewStyle().
+			Foreground(added).
+			Background(complete(t.AddedBackground)).
+			Bold(true),
+		DiffRemovedWord: lipgloss.NewStyle().
+			Foreground(removed).
+			Background(complete(t.RemovedBackground)).
+			Bold(true),
+		DiffMovedFrom: lipgloss.NewStyle().
+			Foreground(complete(t.MovedFrom)),
+		DiffMovedTo: lipgloss.NewStyle().
+			Foreground(complete(t.MovedTo)),
+
+		BadgeBookmark: lipgloss.NewStyle().
+			Foreground(complete(t.Bookmark)),
+		BadgeTag: lipgloss.NewStyle().
+			Foreground(complete(t.Tag)),
+		BadgeImmutable: lipgloss.NewStyle().
+			Foreground(secondary),
+		BadgeConflict: lipgloss.NewStyle().
+			Foreground(removed).
+			Bold(true),
+		BadgeEmpty: lipgloss.NewStyle().
+			Foreground(secondary),
+		BadgeGone: lipgloss.NewStyle().
+			Foreground(added), // Would come back
 
 		unfocusedBorderBlend: unfocusedBlend,
 		focusedBorderBlend:   focusedBlend,
+		theme:                t,
+		monochrome:           monochrome,
 	}
+
+	if monochrome {
+		styles.FocusedPanel = panel.BorderStyle(lipgloss.ThickBorder())
+		styles.FocusedTitle = styles.FocusedTitle.Reverse(true)
+		styles.Selected = styles.Selected.Reverse(true)
+		styles.DiffAddedWord = styles.DiffAddedWord.Underline(true)
+		styles.DiffRemovedWord = styles.DiffRemovedWord.Underline(true)
+	}
+
+	return styles
 }
 
 // AnimatedFocusBorderStyle returns the panel style with the focus border animation at the given phase.
 func (s *Styles) AnimatedFocusBorderStyle(phase float64, width, height int) lipgloss.Style {
+	// Without colors the blend cannot show; the thick border marks focus
+	if s.monochrome {
+		return s.FocusedPanel
+	}
+
 	perimeter := borderSidesPerDimension*width + borderSidesPerDimension*height
 	offset := int(phase * float64(perimeter))
 
@@ -0,0 +1,275 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+)
+
+// syntaxStyleName is the chroma color scheme used for file content.
+const syntaxStyleName = "monokai"
+
+var (
+	// syntaxFileHeaderRe matches jj's per-file section header and captures the path.
+	syntaxFileHeaderRe = regexp.MustCompile(`^(?:Added|Modified|Removed) regular file (.+):\s*$`)
+
+	// syntaxLinePrefixRe matches the "old new: " line-number gutter of a
+	// color-words diff line; either number is blank on added/removed lines.
+	syntaxLinePrefixRe = regexp.MustCompile(`^ *\d* +\d*: ?`)
+
+	// syntaxSGRRe matches one ANSI SGR (style) sequence.
+	syntaxSGRRe = regexp.MustCompile(`^\x1b\[([0-9;]*)m`)
+)
+
+// SyntaxHighlighter colorizes file content in jj diffs by language. Text that
+// jj already colors (added and removed words) keeps jj's colors; only the
+// uncolored text underneath gets syntax colors.
+type SyntaxHighlighter struct {
+	style    *chroma.Style
+	maxLines int // diffs longer than this are left alone; 0 means no limit
+}
+
+// NewSyntaxHighlighter creates a highlighter that skips diffs over maxLines lines.
+func NewSyntaxHighlighter(maxLines int) *SyntaxHighlighter {
+	return &SyntaxHighlighter{
+		style:    styles.Get(syntaxStyleName),
+		maxLines: maxLines,
+	}
+}
+
+// TooLarge reports whether diff exceeds the highlighter's line limit.
+func (h *SyntaxHighlighter) TooLarge(diff string) bool {
+	return h.maxLines > 0 && strings.Count(diff, "\n") >= h.maxLines
+}
+
+// Highlight returns diff with file content lines colorized by the language of
+// their file. Diffs over the line limit and files in unknown languages are
+// returned unchanged.
+func (h *SyntaxHighlighter) Highlight(diff string) string {
+	if h.TooLarge(diff) {
+		return diff
+	}
+
+	lines := strings.Split(diff, "\n")
+
+	for start := 0; start < len(lines); {
+		match := syntaxFileHeaderRe.FindStringSubmatch(StripANSI(lines[start]))
+		if match == nil {
+			start++
+			continue
+		}
+
+		end := start + 1
+		for end < len(lines) && !syntaxFileHeaderRe.MatchString(StripANSI(lines[end])) {
+			end++
+		}
+
+		h.highlightSection(match[1], lines[start+1:end])
+		start = end
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// HighlightFile returns lines, the whole content of the file at path,
+// colorized by its language. Files over the line limit and in unknown
+// languages are returned unchanged.
+func (h *SyntaxHighlighter) HighlightFile(path string, lines []string) []string {
+	lexer := lexers.Match(path)
+	if lexer == nil || (h.maxLines > 0 && len(lines) > h.maxLines) {
+		return lines
+	}
+
+	colors := h.tokenColors(chroma.Coalesce(lexer), lines)
+	if colors == nil {
+		return lines
+	}
+
+	highlighted := make([]string, len(lines))
+	for i, line := range lines {
+		highlighted[i] = layerColors(line, 0, colors[i])
+	}
+
+	return highlighted
+}
+
+// highlightSection colorizes the content lines of one file in place. The
+// content is tokenized as a whole so constructs spanning lines are colored
+// consistently.
+func (h *SyntaxHighlighter) highlightSection(path string, lines []string) {
+	lexer := lexers.Match(path)
+	if lexer == nil {
+		return
+	}
+
+	prefixes := make([]int, len(lines))
+	contents := make([]string, len(lines))
+
+	for i, line := range lines {
+		stripped := StripANSI(line)
+
+		prefix := syntaxLinePrefixRe.FindString(stripped)
+		if prefix == "" {
+			prefixes[i] = -1 // not a content line
+			continue
+		}
+
+		prefixes[i] = utf8.RuneCountInString(prefix)
+		contents[i] = stripped[len(prefix):]
+	}
+
+	colors := h.tokenColors(chroma.Coalesce(lexer), contents)
+	if colors == nil {
+		return
+	}
+
+	for i, line := range lines {
+		if prefixes[i] >= 0 {
+			lines[i] = layerColors(line, prefixes[i], colors[i])
+		}
+	}
+}
+
+// tokenColors returns, for each content line, the syntax color of each rune.
+// Returns nil if the lexer fails.
+func (h *SyntaxHighlighter) tokenColors(lexer chroma.Lexer, contents []string) [][]chroma.Colour {
+	iterator, err := lexer.Tokenise(nil, strings.Join(contents, "\n"))
+	if err != nil {
+		return nil
+	}
+
+	colors := make([][]chroma.Colour, len(contents))
+	line := 0
+
+	for token := iterator(); token != chroma.EOF; token = iterator() {
+		colour := h.style.Get(token.Type).Colour
+
+		for _, r := range token.Value {
+			if r == '\n' {
+				line++
+				continue
+			}
+
+			if line < len(colors) {
+				colors[line] = append(colors[line], colour)
+			}
+		}
+	}
+
+	return colors
+}
+
+// layerColors re-renders line with syntax colors on the runes after the
+// gutter that jj left without a foreground color.
+func layerColors(line string, prefix int, colors []chroma.Colour) string {
+	var out strings.Builder
+
+	var current chroma.Colour // color we have set; zero when none
+
+	jjColored := false
+	visible := 0
+
+	for i := 0; i < len(line); {
+		if line[i] == '\x1b' {
+			seq := ansiRe.FindString(line[i:])
+			if seq != "" {
+				if current.IsSet() {
+					out.WriteString("\x1b[39m")
+					current = 0
+				}
+
+				if sgr := syntaxSGRRe.FindStringSubmatch(seq); sgr != nil {
+					jjColored = foregroundAfter(jjColored, sgr[1])
+				}
+
+				out.WriteString(seq)
+				i += len(seq)
+
+				continue
+			}
+		}
+
+		r, size := utf8.DecodeRuneInString(line[i:])
+
+		var want chroma.Colour
+		if idx := visible - prefix; idx >= 0 && idx < len(colors) && !jjColored {
+			want = colors[idx]
+		}
+
+		if want != current {
+			if current.IsSet() {
+				out.WriteString("\x1b[39m")
+			}
+
+			if want.IsSet() {
+				fmt.Fprintf(&out, "\x1b[38;2;%d;%d;%dm", want.Red(), want.Green(), want.Blue())
+			}
+
+			current = want
+		}
+
+		out.WriteRune(r)
+
+		visible++
+		i += size
+	}
+
+	if current.IsSet() {
+		out.WriteString("\x1b[39m")
+	}
+
+	return out.String()
+}
+
+// foregroundAfter reports whether a foreground color is set after applying
+// the SGR parameters params to a state where one was (active) or wasn't.
+func foregroundAfter(active bool, params string) bool {
+	if params == "" {
+		return false // ESC[m resets everything
+	}
+
+	fields := strings.Split(params, ";")
+
+	for i := 0; i < len(fields); i++ {
+		switch field := fields[i]; {
+		case field == "0" || field == "39":
+			active = false
+		case field == "38":
+			active = true
+			i = skipExtendedColor(fields, i)
+		case field == "48":
+			i = skipExtendedColor(fields, i)
+		case len(field) == 2 && (field[0] == '3' || field[0] == '9') && field[1] >= '0' && field[1] <= '7':
+			active = true
+		}
+	}
+
+	return active
+}
+
+// skipExtendedColor returns the index of the last field of a 38/48 extended
+// color starting at i: "5;n" (256-color) or "2;r;g;b" (true color).
+func skipExtendedColor(fields []string, i int) int {
+	const (
+		paletteArgs   = 2
+		trueColorArgs = 4
+	)
+
+	if i+1 >= len(fields) {
+		return i
+	}
+
+	switch fields[i+1] {
+	case "5":
+		return min(i+paletteArgs, len(fields)-1)
+	case "2":
+		return min(i+trueColorArgs, len(fields)-1)
+	}
+
+	return i
+}
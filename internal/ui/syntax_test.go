@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestSyntaxHighlighter_ColorsKnownLanguage(t *testing.T) {
+	diff := "Modified regular file main.go:\n   1    1: package main\n"
+
+	out := NewSyntaxHighlighter(0).Highlight(diff)
+
+	if out == diff {
+		t.Fatal("Go content should be highlighted")
+	}
+
+	if StripANSI(out) != diff {
+		t.Errorf("highlighting should only add colors, got %q", StripANSI(out))
+	}
+
+	if strings.Contains(strings.SplitN(out, "\n", 2)[0], "\x1b[") {
+		t.Error("the file header should be left alone")
+	}
+}
+
+func TestSyntaxHighlighter_KeepsJJColors(t *testing.T) {
+	removed := "   1     : \x1b[38;5;1mfunc old() {}\x1b[39m"
+	diff := "Modified regular file main.go:\n" + removed
+
+	out := NewSyntaxHighlighter(0).Highlight(diff)
+
+	if got := strings.Split(out, "\n")[1]; got != removed {
+		t.Errorf("text jj colored should keep its colors, got %q", got)
+	}
+}
+
+func TestSyntaxHighlighter_SkipsUnknownLanguage(t *testing.T) {
+	diff := "Added regular file notes.unknownext:\n        1: some text\n"
+
+	if out := NewSyntaxHighlighter(0).Highlight(diff); out != diff {
+		t.Errorf("unknown languages should be left alone, got %q", out)
+	}
+}
+
+func TestSyntaxHighlighter_SkipsLargeDiffs(t *testing.T) {
+	diff := "Added regular file main.go:\n" + strings.Repeat("        1: package main\n", 10)
+
+	if out := NewSyntaxHighlighter(5).Highlight(diff); out != diff {
+		t.Error("diffs over the line limit should be left alone")
+	}
+}
+
+func TestSyntaxHighlighter_HighlightFile(t *testing.T) {
+	lines := []string{"package main", "", "func main() {}"}
+	h := NewSyntaxHighlighter(0)
+
+	out := h.HighlightFile("main.go", lines)
+	if out[0] == lines[0] {
+		t.Fatal("Go content should be highlighted")
+	}
+
+	for i := range lines {
+		if StripANSI(out[i]) != lines[i] {
+			t.Errorf("highlighting should only add colors, got %q", StripANSI(out[i]))
+		}
+	}
+
+	if out := h.HighlightFile("notes.unknownext", lines); out[0] != lines[0] {
+		t.Error("unknown languages should be left alone")
+	}
+
+	if out := NewSyntaxHighlighter(2).HighlightFile("main.go", lines); out[0] != lines[0] {
+		t.Error("files over the line limit should be left alone")
+	}
+}
+
+func TestDiffPanel_SyntaxHighlightToggle(t *testing.T) {
+	panel := NewDiffPanel(NewStyles())
+	panel.SetSize(80, 20)
+	panel.SetDiff("Added regular file main.go:\n        1: package main")
+
+	plain := panel.viewport.View()
+
+	panel.SetSyntaxHighlight(true, 0)
+
+	if !panel.SyntaxHighlighting() || panel.viewport.View() == plain {
+		t.Error("turning highlighting on should recolor the shown diff")
+	}
+
+	panel.SetSyntaxHighlight(false, 0)
+
+	if panel.SyntaxHighlighting() || panel.viewport.View() != plain {
+		t.Error("turning highlighting off should restore the plain diff")
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: highlighting never changes the visible text
+func TestSyntaxHighlighter_PreservesText(t *testing.T) {
+	highlighter := NewSyntaxHighlighter(0)
+
+	rapid.Check(t, func(t *rapid.T) {
+		lines := rapid.SliceOfN(rapid.StringMatching(`[a-z(){}"=; ]{0,30}`), 1, 10).Draw(t, "lines")
+
+		var diff strings.Builder
+
+		diff.WriteString("Modified regular file main.go:\n")
+
+		for _, line := range lines {
+			diff.WriteString("   1    1: " + line + "\n")
+		}
+
+		out := highlighter.Highlight(diff.String())
+		if StripANSI(out) != diff.String() {
+			t.Fatalf("visible text changed:\n%q\n%q", diff.String(), StripANSI(out))
+		}
+	})
+}
@@ -0,0 +1,15 @@
+package ui
+
+import (
+	"net/url"
+	"path/filepath"
+)
+
+// CurrentDirectory returns the OSC 7 sequence telling the terminal that the
+// working directory is dir on host, so new tabs and splits open there and
+// tab titles can name it. Terminals without support ignore it.
+func CurrentDirectory(host, dir string) string {
+	target := url.URL{Scheme: "file", Host: host, Path: filepath.ToSlash(dir)}
+
+	return "\x1b]7;" + target.String() + "\x1b\\"
+}
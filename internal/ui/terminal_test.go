@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestCurrentDirectory(t *testing.T) {
+	if got, want := CurrentDirectory("box", "/home/me/chado"), "\x1b]7;file://box/home/me/chado\x1b\\"; got != want {
+		t.Errorf("CurrentDirectory() = %q, want %q", got, want)
+	}
+
+	if got := CurrentDirectory("box", "/home/me/my repo"); !strings.Contains(got, "/home/me/my%20repo") {
+		t.Errorf("spaces should be escaped in the URL, got %q", got)
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: the sequence is one OSC 7, however odd the path, so it never
+// ends early and leaks the rest of the path onto the screen.
+func TestCurrentDirectory_OneSequence(t *testing.T) {
+	rapid.Check(t, func(rt *rapid.T) {
+		dir := "/" + rapid.String().Draw(rt, "dir")
+
+		got := CurrentDirectory("box", dir)
+		if !strings.HasPrefix(got, "\x1b]7;file://box/") || strings.Count(got, "\x1b") != 2 {
+			rt.Fatalf("CurrentDirectory(%q) = %q", dir, got)
+		}
+	})
+}
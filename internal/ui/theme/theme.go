@@ -0,0 +1,288 @@
+// Package theme defines the color palettes the TUI is drawn with.
+package theme
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+const (
+	// DefaultName is chado's original theme, for dark terminals.
+	DefaultName = "default"
+
+	// LightName is the theme for light terminals.
+	LightName = "light"
+
+	// AutoName picks DefaultName or LightName to suit the terminal background.
+	AutoName = "auto"
+)
+
+// maxANSIColor is the highest ANSI 256 color number.
+const maxANSIColor = 255
+
+// hexColorRe matches a "#rgb" or "#rrggbb" color.
+var hexColorRe = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// Theme is a palette of colors, each an ANSI 256 color number (e.g. "241")
+// or a hex color (e.g. "#30c9b0").
+type Theme struct {
+	Name string
+
+	// Panel chrome: titles and borders. The shades are the darker stops of
+	// the border blends, unfocused and focused.
+	Primary      string
+	Secondary    string // dim text and hints
+	Accent       string // focused panel
+	BorderShade  string
+	BorderShadow string
+	AccentShade  string
+	AccentShadow string
+
+	// Overlays: dialogs, finder, command prompt, and help.
+	OverlayBorder string
+	OverlayTitle  string
+	Text          string
+
+	// Changes: diff lines, stats, and file statuses.
+	Added             string
+	Removed           string
+	Modified          string
+	AddedBackground   string
+	RemovedBackground string
+	MovedFrom         string // removed lines added again elsewhere
+	MovedTo           string // added lines removed from elsewhere
+	Error             string
+
+	// Log decorations.
+	ShortCode string
+	Bookmark  string
+	Tag       string
+
+	// Status bar key hints.
+	StatusKey       string
+	StatusDesc      string
+	StatusSeparator string
+}
+
+// Default returns chado's original palette, tuned for dark terminals.
+func Default() Theme {
+	return Theme{
+		Name:              DefaultName,
+		Primary:           "#808080",
+		Secondary:         "241",
+		Accent:            "#30c9b0",
+		BorderShade:       "#454545",
+		BorderShadow:      "#3d3d3d",
+		AccentShade:       "#0d4d44",
+		AccentShadow:      "#1e1e1e",
+		OverlayBorder:     "62",
+		OverlayTitle:      "86",
+		Text:              "252",
+		Added:             "2",
+		Removed:           "1",
+		Modified:          "3",
+		AddedBackground:   "22",
+		RemovedBackground: "52",
+		MovedFrom:         "5",
+		MovedTo:           "6",
+		Error:             "9",
+		ShortCode:         "13",
+		Bookmark:          "5",
+		Tag:               "3",
+		StatusKey:         "#999999",
+		StatusDesc:        "#777777",
+		StatusSeparator:   "#555555",
+	}
+}
+
+// Light returns a palette for light terminals, where the default's pale
+// grays and bright accents wash out.
+func Light() Theme {
+	return Theme{
+		Name:              LightName,
+		Primary:           "#767676",
+		Secondary:         "#8a8a8a",
+		Accent:            "#00877a",
+		BorderShade:       "#bcbcbc",
+		BorderShadow:      "#d0d0d0",
+		AccentShade:       "#7fcfc4",
+		AccentShadow:      "#e4e4e4",
+		OverlayBorder:     "#5f5fd7",
+		OverlayTitle:      "#00875f",
+		Text:              "#303030",
+		Added:             "#2e7d32",
+		Removed:           "#c62828",
+		Modified:          "#a66f00",
+		AddedBackground:   "#d7f5dd",
+		RemovedBackground: "#fbdada",
+		MovedFrom:         "#8700af",
+		MovedTo:           "#00878f",
+		Error:             "#c62828",
+		ShortCode:         "#af00af",
+		Bookmark:          "#8700af",
+		Tag:               "#a66f00",
+		StatusKey:         "#4e4e4e",
+		StatusDesc:        "#767676",
+		StatusSeparator:   "#b2b2b2",
+	}
+}
+
+// Nord returns a palette after the Nord color scheme.
+func Nord() Theme {
+	return Theme{
+		Name:              "nord",
+		Primary:           "#4c566a",
+		Secondary:         "#616e88",
+		Accent:            "#88c0d0",
+		BorderShade:       "#3b4252",
+		BorderShadow:      "#2e3440",
+		AccentShade:       "#5e81ac",
+		AccentShadow:      "#2e3440",
+		OverlayBorder:     "#5e81ac",
+		OverlayTitle:      "#88c0d0",
+		Text:              "#d8dee9",
+		Added:             "#a3be8c",
+		Removed:           "#bf616a",
+		Modified:          "#ebcb8b",
+		AddedBackground:   "#3b4a3a",
+		RemovedBackground: "#4a2f34",
+		MovedFrom:         "#b48ead",
+		MovedTo:           "#8fbcbb",
+		Error:             "#bf616a",
+		ShortCode:         "#b48ead",
+		Bookmark:          "#b48ead",
+		Tag:               "#ebcb8b",
+		StatusKey:         "#d8dee9",
+		StatusDesc:        "#81a1c1",
+		StatusSeparator:   "#4c566a",
+	}
+}
+
+// Gruvbox returns a palette after the Gruvbox dark color scheme.
+func Gruvbox() Theme {
+	return Theme{
+		Name:              "gruvbox",
+		Primary:           "#928374",
+		Secondary:         "#7c6f64",
+		Accent:            "#8ec07c",
+		BorderShade:       "#504945",
+		BorderShadow:      "#3c3836",
+		AccentShade:       "#427b58",
+		AccentShadow:      "#282828",
+		OverlayBorder:     "#458588",
+		OverlayTitle:      "#8ec07c",
+		Text:              "#ebdbb2",
+		Added:             "#b8bb26",
+		Removed:           "#fb4934",
+		Modified:          "#fabd2f",
+		AddedBackground:   "#3c3f1e",
+		RemovedBackground: "#4a1e1a",
+		MovedFrom:         "#d3869b",
+		MovedTo:           "#83a598",
+		Error:             "#fb4934",
+		ShortCode:         "#d3869b",
+		Bookmark:          "#d3869b",
+		Tag:               "#fabd2f",
+		StatusKey:         "#a89984",
+		StatusDesc:        "#928374",
+		StatusSeparator:   "#665c54",
+	}
+}
+
+// Builtin returns the built-in themes in the order they cycle through.
+func Builtin() []Theme {
+	return []Theme{Default(), Light(), Nord(), Gruvbox()}
+}
+
+// ForBackground returns the built-in theme that reads best on a dark or
+// light terminal background.
+func ForBackground(dark bool) Theme {
+	if dark {
+		return Default()
+	}
+
+	return Light()
+}
+
+// Named returns the built-in theme with the given name.
+func Named(name string) (Theme, bool) {
+	for _, t := range Builtin() {
+		if t.Name == name {
+			return t, true
+		}
+	}
+
+	return Theme{}, false
+}
+
+// colorsByName maps each color's config name to its field so the config
+// file can override it.
+func (t *Theme) colorsByName() map[string]*string {
+	return map[string]*string{
+		"primary":            &t.Primary,
+		"secondary":          &t.Secondary,
+		"accent":             &t.Accent,
+		"border_shade":       &t.BorderShade,
+		"border_shadow":      &t.BorderShadow,
+		"accent_shade":       &t.AccentShade,
+		"accent_shadow":      &t.AccentShadow,
+		"overlay_border":     &t.OverlayBorder,
+		"overlay_title":      &t.OverlayTitle,
+		"text":               &t.Text,
+		"added":              &t.Added,
+		"removed":            &t.Removed,
+		"modified":           &t.Modified,
+		"added_background":   &t.AddedBackground,
+		"removed_background": &t.RemovedBackground,
+		"moved_from":         &t.MovedFrom,
+		"moved_to":           &t.MovedTo,
+		"error":              &t.Error,
+		"short_code":         &t.ShortCode,
+		"bookmark":           &t.Bookmark,
+		"tag":                &t.Tag,
+		"status_key":         &t.StatusKey,
+		"status_desc":        &t.StatusDesc,
+		"status_separator":   &t.StatusSeparator,
+	}
+}
+
+// WithColors returns the theme with colors replaced by config name (e.g.
+// "accent"). It also returns a description of each override it skipped:
+// unknown color names and values that are not colors.
+func (t Theme) WithColors(colors map[string]string) (Theme, []string) {
+	fields := t.colorsByName()
+
+	var problems []string
+
+	for _, name := range slices.Sorted(maps.Keys(colors)) {
+		field, ok := fields[name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unknown color %q", name))
+			continue
+		}
+
+		value := colors[name]
+		if !ValidColor(value) {
+			problems = append(problems, fmt.Sprintf("%s: %q is not a color", name, value))
+			continue
+		}
+
+		*field = value
+	}
+
+	return t, problems
+}
+
+// ValidColor reports whether s is an ANSI 256 color number or a hex color.
+func ValidColor(s string) bool {
+	if hexColorRe.MatchString(s) {
+		return true
+	}
+
+	n, err := strconv.Atoi(s)
+
+	return err == nil && n >= 0 && n <= maxANSIColor
+}
@@ -0,0 +1,115 @@
+package theme
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestBuiltin_ColorsAreValid(t *testing.T) {
+	for _, th := range Builtin() {
+		for name, field := range th.colorsByName() {
+			if !ValidColor(*field) {
+				t.Errorf("%s: %s = %q is not a color", th.Name, name, *field)
+			}
+		}
+	}
+}
+
+func TestNamed(t *testing.T) {
+	if th, ok := Named("nord"); !ok || th.Name != "nord" {
+		t.Errorf("expected the nord theme, got %q, %v", th.Name, ok)
+	}
+
+	if _, ok := Named("solarized"); ok {
+		t.Error("unknown theme names should not be found")
+	}
+}
+
+func TestForBackground(t *testing.T) {
+	if got := ForBackground(true).Name; got != DefaultName {
+		t.Errorf("dark backgrounds should get the default theme, got %q", got)
+	}
+
+	if got := ForBackground(false).Name; got != LightName {
+		t.Errorf("light backgrounds should get the light theme, got %q", got)
+	}
+}
+
+func TestWithColors(t *testing.T) {
+	th, problems := Default().WithColors(map[string]string{
+		"accent":  "#ff8700",
+		"added":   "10",
+		"removed": "red",
+		"sparkle": "1",
+	})
+
+	if th.Accent != "#ff8700" || th.Added != "10" {
+		t.Errorf("valid overrides should apply, got accent=%q added=%q", th.Accent, th.Added)
+	}
+
+	if th.Removed != Default().Removed {
+		t.Errorf("an invalid color should keep the theme's, got %q", th.Removed)
+	}
+
+	want := []string{`removed: "red" is not a color`, `unknown color "sparkle"`}
+	if !reflect.DeepEqual(problems, want) {
+		t.Errorf("expected problems %q, got %q", want, problems)
+	}
+}
+
+func TestWithColors_LeavesOriginal(t *testing.T) {
+	original := Default()
+	original.WithColors(map[string]string{"accent": "#ff8700"})
+
+	if original != Default() {
+		t.Error("overriding colors should not change the theme it was called on")
+	}
+}
+
+func TestValidColor(t *testing.T) {
+	for _, tt := range []struct {
+		color string
+		want  bool
+	}{
+		{"0", true},
+		{"255", true},
+		{"256", false},
+		{"-1", false},
+		{"#abc", true},
+		{"#30c9b0", true},
+		{"#30c9b", false},
+		{"30c9b0", false},
+		{"", false},
+		{"red", false},
+	} {
+		if got := ValidColor(tt.color); got != tt.want {
+			t.Errorf("ValidColor(%q) = %v, want %v", tt.color, got, tt.want)
+		}
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+func TestValidColor_AcceptsEveryColor(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		ansi := rapid.IntRange(0, maxANSIColor).Draw(t, "ansi")
+		rgb := rapid.IntRange(0, 0xffffff).Draw(t, "rgb")
+
+		if !ValidColor(fmt.Sprint(ansi)) {
+			t.Fatalf("ANSI color %d should be valid", ansi)
+		}
+
+		if hex := fmt.Sprintf("#%06x", rgb); !ValidColor(hex) {
+			t.Fatalf("hex color %s should be valid", hex)
+		}
+	})
+}
@@ -0,0 +1,211 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/chatter/chado/internal/ui/theme"
+)
+
+const (
+	// toastDuration is how long non-error toasts stay on screen.
+	toastDuration = 4 * time.Second
+
+	// toastMaxVisible caps how many toasts are stacked at once; older ones are
+	// dropped, expiring ones before errors.
+	toastMaxVisible = 4
+
+	// toastMaxWidth is the widest a toast may render, including its border.
+	toastMaxWidth = 60
+
+	// toastChrome is the horizontal space taken by a toast's border and padding.
+	toastChrome = 4
+
+	// toastMaxLines caps the body height so long stderr output stays readable.
+	toastMaxLines = 3
+)
+
+// ToastLevel distinguishes transient notices from errors that must be dismissed.
+type ToastLevel int
+
+const (
+	ToastInfo    ToastLevel = iota // Neutral notice, auto-expires
+	ToastSuccess                   // Completed action, auto-expires
+	ToastError                     // Failure, persists until dismissed
+)
+
+// Toast is a single notification.
+type Toast struct {
+	ID    int
+	Level ToastLevel
+	Text  string
+}
+
+// ToastExpiredMsg is sent when a toast's display time has elapsed.
+type ToastExpiredMsg struct {
+	ID int
+}
+
+// Toasts is a stack of notifications rendered in a screen corner.
+type Toasts struct {
+	toasts []Toast
+	nextID int
+
+	// Styles
+	infoStyle    lipgloss.Style
+	successStyle lipgloss.Style
+	errorStyle   lipgloss.Style
+}
+
+// NewToasts creates an empty toast stack.
+func NewToasts() *Toasts {
+	t := &Toasts{}
+	t.SetTheme(theme.Default())
+
+	return t
+}
+
+// SetTheme colors the toasts from th.
+func (t *Toasts) SetTheme(th theme.Theme) {
+	base := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		Padding(0, 1)
+
+	t.infoStyle = base.BorderForeground(lipgloss.Color(th.Secondary))
+	t.successStyle = base.BorderForeground(lipgloss.Color(th.Added))
+	t.errorStyle = base.BorderForeground(lipgloss.Color(th.Removed)).Foreground(lipgloss.Color(th.Error))
+}
+
+// Push adds a toast and returns a command that expires it, or nil for errors,
+// which stay until dismissed.
+func (t *Toasts) Push(level ToastLevel, text string) tea.Cmd {
+	t.nextID++
+	id := t.nextID
+
+	t.toasts = append(t.toasts, Toast{ID: id, Level: level, Text: text})
+	for len(t.toasts) > toastMaxVisible {
+		t.dropOldest()
+	}
+
+	if level == ToastError {
+		return nil
+	}
+
+	return tea.Tick(toastDuration, func(time.Time) tea.Msg {
+		return ToastExpiredMsg{ID: id}
+	})
+}
+
+// dropOldest removes the oldest toast that would expire anyway, so a burst
+// of notices cannot push an error off screen before it is dismissed. Only a
+// new error, on a stack of nothing else, drops the oldest error.
+func (t *Toasts) dropOldest() {
+	oldest := 0
+
+	for i, toast := range t.toasts {
+		if toast.Level != ToastError {
+			oldest = i
+			break
+		}
+	}
+
+	t.toasts = append(t.toasts[:oldest], t.toasts[oldest+1:]...)
+}
+
+// Info pushes an auto-expiring neutral toast.
+func (t *Toasts) Info(text string) tea.Cmd {
+	return t.Push(ToastInfo, text)
+}
+
+// Success pushes an auto-expiring success toast.
+func (t *Toasts) Success(text string) tea.Cmd {
+	return t.Push(ToastSuccess, text)
+}
+
+// Error pushes a persistent error toast.
+func (t *Toasts) Error(text string) tea.Cmd {
+	return t.Push(ToastError, text)
+}
+
+// Expire removes the toast with the given ID if it is still shown.
+func (t *Toasts) Expire(id int) {
+	for i, toast := range t.toasts {
+		if toast.ID == id {
+			t.toasts = append(t.toasts[:i], t.toasts[i+1:]...)
+			return
+		}
+	}
+}
+
+// Dismiss removes the most recent error toast. Returns false if there were none.
+func (t *Toasts) Dismiss() bool {
+	for i := len(t.toasts) - 1; i >= 0; i-- {
+		if t.toasts[i].Level == ToastError {
+			t.toasts = append(t.toasts[:i], t.toasts[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasErrors reports whether any persistent error toast is shown.
+func (t *Toasts) HasErrors() bool {
+	for _, toast := range t.toasts {
+		if toast.Level == ToastError {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Items returns the currently shown toasts, oldest first.
+func (t *Toasts) Items() []Toast {
+	return t.toasts
+}
+
+// View renders the toast stack, newest at the bottom, fitted to maxWidth.
+// Returns an empty string when there is nothing to show.
+func (t *Toasts) View(maxWidth int) string {
+	if len(t.toasts) == 0 {
+		return ""
+	}
+
+	width := min(maxWidth, toastMaxWidth)
+	textWidth := max(width-toastChrome, 1)
+
+	rendered := make([]string, 0, len(t.toasts))
+
+	for _, toast := range t.toasts {
+		text := toast.Text
+		if toast.Level == ToastError {
+			text = "✗ " + text
+		}
+
+		lines := strings.Split(strings.TrimSpace(text), "\n")
+		if len(lines) > toastMaxLines {
+			lines = append(lines[:toastMaxLines-1], "…")
+		}
+
+		body := lipgloss.NewStyle().MaxWidth(textWidth).Render(strings.Join(lines, "\n"))
+		rendered = append(rendered, t.styleFor(toast.Level).Render(body))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Right, rendered...)
+}
+
+// styleFor returns the border style for a toast level.
+func (t *Toasts) styleFor(level ToastLevel) lipgloss.Style {
+	switch level {
+	case ToastSuccess:
+		return t.successStyle
+	case ToastError:
+		return t.errorStyle
+	default:
+		return t.infoStyle
+	}
+}
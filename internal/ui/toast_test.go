@@ -0,0 +1,190 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"pgregory.net/rapid"
+)
+
+// =============================================================================
+// Unit Tests
+// =============================================================================
+
+func TestToasts_SuccessExpires(t *testing.T) {
+	toasts := NewToasts()
+
+	cmd := toasts.Success("described xsssnyux")
+	if cmd == nil {
+		t.Fatal("success toast should schedule expiry")
+	}
+
+	items := toasts.Items()
+	if len(items) != 1 || items[0].Text != "described xsssnyux" {
+		t.Fatalf("unexpected toasts: %+v", items)
+	}
+
+	toasts.Expire(items[0].ID)
+
+	if len(toasts.Items()) != 0 {
+		t.Error("toast should be removed after expiry")
+	}
+}
+
+func TestToasts_ErrorPersistsUntilDismissed(t *testing.T) {
+	toasts := NewToasts()
+
+	if cmd := toasts.Error("jj push failed"); cmd != nil {
+		t.Error("error toasts should not schedule expiry")
+	}
+
+	if !toasts.HasErrors() {
+		t.Fatal("expected an error toast")
+	}
+
+	if !toasts.Dismiss() {
+		t.Fatal("dismiss should remove the error toast")
+	}
+
+	if toasts.HasErrors() || toasts.Dismiss() {
+		t.Error("no error toasts should remain")
+	}
+}
+
+func TestToasts_DismissKeepsNonErrors(t *testing.T) {
+	toasts := NewToasts()
+	toasts.Success("ok")
+	toasts.Error("bad")
+
+	toasts.Dismiss()
+
+	items := toasts.Items()
+	if len(items) != 1 || items[0].Level != ToastSuccess {
+		t.Errorf("expected only the success toast to remain, got %+v", items)
+	}
+}
+
+func TestToasts_ErrorOutlastsBurstOfNotices(t *testing.T) {
+	toasts := NewToasts()
+	toasts.Error("jj push failed")
+
+	for range toastMaxVisible + 2 {
+		toasts.Info("fetched")
+	}
+
+	items := toasts.Items()
+	if len(items) != toastMaxVisible {
+		t.Fatalf("stack has %d toasts, cap is %d", len(items), toastMaxVisible)
+	}
+
+	if !toasts.HasErrors() {
+		t.Errorf("notices should not push the error off screen, got %+v", items)
+	}
+}
+
+func TestToasts_ExpireUnknownIDIsNoop(t *testing.T) {
+	toasts := NewToasts()
+	toasts.Info("hello")
+
+	toasts.Expire(999)
+
+	if len(toasts.Items()) != 1 {
+		t.Error("expiring an unknown ID should not remove toasts")
+	}
+}
+
+func TestToasts_ViewEmpty(t *testing.T) {
+	if view := NewToasts().View(80); view != "" {
+		t.Errorf("expected empty view, got %q", view)
+	}
+}
+
+func TestToasts_ViewContainsText(t *testing.T) {
+	toasts := NewToasts()
+	toasts.Success("abandoned xsssnyux")
+	toasts.Error("jj push failed")
+
+	view := StripANSI(toasts.View(80))
+
+	if !strings.Contains(view, "abandoned xsssnyux") {
+		t.Error("view should contain success text")
+	}
+
+	if !strings.Contains(view, "✗ jj push failed") {
+		t.Error("view should mark errors")
+	}
+}
+
+// =============================================================================
+// Property Tests
+// =============================================================================
+
+// Property: the stack never exceeds its cap and keeps the newest toasts,
+// unless errors fill it.
+func TestToasts_StackBounded(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		toasts := NewToasts()
+		count := rapid.IntRange(1, 20).Draw(t, "count")
+
+		for i := range count {
+			toasts.Push(ToastLevel(i%3), "toast")
+		}
+
+		items := toasts.Items()
+		if len(items) > toastMaxVisible {
+			t.Fatalf("stack has %d toasts, cap is %d", len(items), toastMaxVisible)
+		}
+
+		errorsFull := !slices.ContainsFunc(items, func(toast Toast) bool { return toast.Level != ToastError })
+		if items[len(items)-1].ID != count && !errorsFull {
+			t.Fatalf("newest toast should be last, got ID %d want %d", items[len(items)-1].ID, count)
+		}
+	})
+}
+
+// Property: expiring toasts are dropped before any error is.
+func TestToasts_ErrorsDroppedLast(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		toasts := NewToasts()
+		levels := rapid.SliceOfN(rapid.SampledFrom([]ToastLevel{ToastInfo, ToastSuccess, ToastError}), 1, 20).Draw(t, "levels")
+
+		errors := 0
+
+		for _, level := range levels {
+			toasts.Push(level, "toast")
+
+			if level == ToastError {
+				errors++
+			}
+		}
+
+		shown := 0
+
+		for _, toast := range toasts.Items() {
+			if toast.Level == ToastError {
+				shown++
+			}
+		}
+
+		if want := min(errors, toastMaxVisible); shown != want {
+			t.Fatalf("pushed %d errors, %d shown, want %d", errors, shown, want)
+		}
+	})
+}
+
+// Property: the rendered stack never exceeds the available width.
+func TestToasts_ViewWidthBounded(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		toasts := NewToasts()
+		width := rapid.IntRange(10, 200).Draw(t, "width")
+		text := rapid.StringMatching(`[a-z ]{1,200}`).Draw(t, "text")
+
+		toasts.Error(text)
+
+		if got := lipgloss.Width(toasts.View(width)); got > width {
+			t.Fatalf("toast width %d exceeds %d", got, width)
+		}
+	})
+}
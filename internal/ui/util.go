@@ -4,22 +4,41 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+
+	"charm.land/lipgloss/v2"
 )
 
+// rgbaShift scales color.Color's 16-bit channels down to 8 bits.
+const rgbaShift = 8
+
 // ReplaceResetWithColor replaces ANSI reset codes with a specific foreground color.
 // This allows nested styles to restore the outer color instead of resetting completely.
 //
 // Example: If you have styled text that ends with a reset (\x1b[0m), this replaces
 // that reset with a foreground color code, allowing the text that follows to
 // continue with the specified color rather than falling back to terminal defaults.
-// The color parameter should be an ANSI 256 color code (e.g. "241" for gray).
+// The color parameter is an ANSI 256 color code (e.g. "241" for gray) or a
+// "#rrggbb" hex color.
 func ReplaceResetWithColor(s string, color string) string {
 	colorCode := fmt.Sprintf("\x1b[38;5;%sm", color)
+
+	if strings.HasPrefix(color, "#") {
+		r, g, b, _ := lipgloss.Color(color).RGBA()
+		colorCode = fmt.Sprintf("\x1b[38;2;%d;%d;%dm", r>>rgbaShift, g>>rgbaShift, b>>rgbaShift)
+	}
+
 	return strings.ReplaceAll(s, "\x1b[0m", colorCode)
 }
 
-// StripANSI removes ANSI escape codes.
+// ansiRe matches one ANSI CSI escape sequence, or one end of an OSC 8
+// hyperlink.
+var ansiRe = regexp.MustCompile(`\x1b\[[0-9;]*[a-zA-Z]|\x1b\]8;[^\x07\x1b]*(?:\x1b\\|\x07)`)
+
+// StripANSI removes ANSI escape codes, returning text without any as is.
 func StripANSI(s string) string {
-	ansiRe := regexp.MustCompile(`\x1b\[[0-9;]*[a-zA-Z]`)
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+
 	return ansiRe.ReplaceAllString(s, "")
 }
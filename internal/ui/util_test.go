@@ -55,6 +55,12 @@ func TestReplaceResetWithColor(t *testing.T) {
 			color:    "241",
 			expected: "\x1b[38;5;241m",
 		},
+		{
+			name:     "hex color",
+			input:    "\x1b[1mfoo\x1b[0m",
+			color:    "#30c9b0",
+			expected: "\x1b[1mfoo\x1b[38;2;48;201;176m",
+		},
 	}
 
 	for _, tt := range tests {
@@ -3,14 +3,22 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"runtime/debug"
 
 	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/colorprofile"
+	"github.com/charmbracelet/x/term"
 	"github.com/chatter/chado/internal/app"
+	"github.com/chatter/chado/internal/config"
+	"github.com/chatter/chado/internal/jj"
 	"github.com/chatter/chado/internal/logger"
+	"github.com/chatter/chado/internal/state"
+	"github.com/chatter/chado/internal/ui/theme"
 )
 
 // maxRealVersionLen is the upper bound for a "real" semver tag.
@@ -32,10 +40,28 @@ func resolveVersion() string {
 }
 
 func run(ctx context.Context, args []string) error {
+	// jj runs chado as the diff editor when applying picked hunks
+	if len(args) > 0 && args[0] == jj.HunkEditorCommand {
+		if err := jj.RunHunkEditor(args[1:]); err != nil {
+			return fmt.Errorf("applying hunks: %w", err)
+		}
+
+		return nil
+	}
+
 	// Parse flags
 	fs := flag.NewFlagSet("chado", flag.ContinueOnError)
 	logLevel := fs.String("log-level", "", "log level: debug, info, warn, error")
 	fs.StringVar(logLevel, "l", "", "log level (shorthand)")
+	revisions := fs.String("revisions", "", "revset the log shows (default: log.revset config, then jj's revsets.log)")
+	fs.StringVar(revisions, "r", "", "revset the log shows (shorthand)")
+	repository := fs.String("repository", "", "path to the jj repository, or any directory in it (default: current directory)")
+	fs.StringVar(repository, "R", "", "path to the jj repository (shorthand)")
+	readOnly := fs.Bool("read-only", false, "disable actions that change the repository")
+	reducedMotion := fs.Bool("reduced-motion", false, "turn animations off")
+	noColor := fs.Bool("no-color", false, "draw without colors (also set by NO_COLOR)")
+	linear := fs.Bool("linear", false, "show one panel at a time as plain text, for screen readers")
+	pick := fs.Bool("pick", false, "print the change ID, file path, or bookmark chosen with enter, and exit")
 
 	if err := fs.Parse(args); err != nil {
 		return fmt.Errorf("parsing flags: %w", err)
@@ -50,30 +76,140 @@ func run(ctx context.Context, args []string) error {
 	}
 	defer log.Close()
 
-	if _, err := os.Stat(".jj"); os.IsNotExist(err) {
-		fmt.Fprintln(os.Stderr, "error: not a jj repository (or any parent up to mount point /)")
+	start := *repository
+	if start == "" {
+		if start, err = os.Getwd(); err != nil {
+			fmt.Fprintf(os.Stderr, "error: could not get current directory: %v\n", err)
+			return fmt.Errorf("getting working directory: %w", err)
+		}
+	}
+
+	root, err := jj.FindRoot(start)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
 		return fmt.Errorf("checking jj repository: %w", err)
 	}
 
-	cwd, err := os.Getwd()
+	cfg, err := config.Load()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "error: could not get current directory: %v\n", err)
-		return fmt.Errorf("getting working directory: %w", err)
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		log.Warn("config load failed, using defaults", "err", err)
+	}
+
+	if *revisions != "" {
+		cfg.Log.Revset = *revisions
+	}
+
+	if *readOnly {
+		cfg.ReadOnly = true
+	}
+
+	if *reducedMotion {
+		cfg.ReducedMotion = true
+	}
+
+	if *linear {
+		cfg.Linear = true
+	}
+
+	// NO_COLOR asks for no colors whatever its value, as long as it has one
+	if *noColor || os.Getenv("NO_COLOR") != "" {
+		cfg.NoColor = true
+	}
+
+	// Piped or redirected output gets the log once instead of the TUI;
+	// picking draws on stderr, so it only needs that to be a terminal
+	if !*pick && !term.IsTerminal(os.Stdout.Fd()) {
+		return printLog(ctx, root, cfg, log)
 	}
 
 	version := resolveVersion()
-	model := app.New(ctx, cwd, version, log)
+	model := app.New(ctx, root, version, cfg, log)
+	model.SetPicking(*pick)
+
+	// When picking, stdout carries the selection, so draw on stderr
+	output := os.Stdout
+	if *pick {
+		output = os.Stderr
+	}
+
+	// Ask the terminal before bubbletea takes over its input
+	if cfg.Theme.Name == theme.AutoName {
+		model.SetDarkBackground(lipgloss.HasDarkBackground(os.Stdin, output))
+	}
+
+	saved, err := state.Load(root)
+	if err != nil {
+		log.Warn("state load failed, starting fresh", "err", err)
+	}
+
+	model.RestoreState(saved)
 
-	p := tea.NewProgram(
-		&model,
-		tea.WithContext(ctx),
-	)
+	options := []tea.ProgramOption{tea.WithContext(ctx), tea.WithOutput(output)}
+
+	// Colors that slip through, from jj or the theme, are dropped on output
+	if cfg.NoColor {
+		options = append(options, tea.WithColorProfile(colorprofile.Ascii))
+	}
 
-	if _, err := p.Run(); err != nil {
+	p := tea.NewProgram(&model, options...)
+
+	final, err := p.Run()
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
 		return fmt.Errorf("running program: %w", err)
 	}
 
+	// Key actions replace the model, so the state is the final one's
+	if finished, ok := final.(*app.Model); ok {
+		if err := finished.SaveState(); err != nil {
+			log.Warn("state save failed", "err", err)
+		}
+	}
+
+	if *pick {
+		return printPicked(final)
+	}
+
+	return nil
+}
+
+// printLog writes the log once, without colors, for output that is not a
+// terminal.
+func printLog(ctx context.Context, root string, cfg config.Config, log *logger.Logger) error {
+	runner := jj.NewRunner(ctx, root, log)
+	runner.SetRevset(cfg.Log.Revset)
+	runner.SetLogTemplate(cfg.Log.Template)
+
+	if !cfg.ReadOnly {
+		if err := runner.Snapshot(); err != nil {
+			return fmt.Errorf("snapshotting the working copy: %w", err)
+		}
+	}
+
+	output, err := runner.PlainLog()
+	if err != nil {
+		return fmt.Errorf("running jj log: %w", err)
+	}
+
+	fmt.Print(output)
+
+	return nil
+}
+
+// errNothingPicked makes a quit without choosing fail, so scripts using
+// --pick don't go on with an empty selection.
+var errNothingPicked = errors.New("nothing picked")
+
+// printPicked writes the selection made in picker mode to stdout.
+func printPicked(final tea.Model) error {
+	model, ok := final.(*app.Model)
+	if !ok || model.Picked() == "" {
+		return errNothingPicked
+	}
+
+	fmt.Println(model.Picked())
+
 	return nil
 }
 